@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/config/viper"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/spf13/cobra"
+
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+	"github.com/flyteorg/flytepropeller/pkg/webhook"
+	webhookConfig "github.com/flyteorg/flytepropeller/pkg/webhook/config"
+)
+
+const storageProbeKey = "config-validate-probe"
+
+// defaultConfigSnapshot holds the value of all registered config sections before any configuration is loaded.
+var defaultConfigSnapshot map[string]interface{}
+
+type validateOptions struct {
+	probeStorage      bool
+	checkWebhookCerts bool
+}
+
+// newConfigCommand extends the standard config command with propeller specific validation of the loaded config.
+func newConfigCommand() *cobra.Command {
+	configCmd := viper.GetConfigCommand()
+	opts := &validateOptions{}
+	for _, c := range configCmd.Commands() {
+		if c.Name() != "validate" {
+			continue
+		}
+
+		validateCmd := c
+		schemaValidate := validateCmd.RunE
+		validateCmd.Flags().BoolVar(&opts.probeStorage, "probe-storage", false, "Verifies that the configured storage is reachable.")
+		validateCmd.Flags().BoolVar(&opts.checkWebhookCerts, "webhook-certs", false, "Verifies that the webhook certs exist in the configured cert directory.")
+		validateCmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if schemaValidate != nil {
+				if err := schemaValidate(cmd, args); err != nil {
+					return err
+				}
+			}
+
+			return validateEffectiveConfig(context.Background(), cmd.OutOrStdout(), opts)
+		}
+	}
+
+	return configCmd
+}
+
+func validateEffectiveConfig(ctx context.Context, w io.Writer, opts *validateOptions) error {
+	errs := config2.GetConfig().Validate()
+	if opts.checkWebhookCerts {
+		if err := webhook.ValidateCerts(webhookConfig.GetConfig()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if opts.probeStorage {
+		if err := probeStorage(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	effective, err := utils.SnapshotConfigSections(config.GetRootSection())
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(w, "Effective config values that differ from defaults:")
+	for _, change := range utils.DiffConfigs(defaultConfigSnapshot, effective) {
+		_, _ = fmt.Fprintf(w, "  %s: %v (default: %v)\n", change.Key, change.Value, change.Default)
+	}
+
+	if len(errs) > 0 {
+		for _, err := range errs {
+			_, _ = fmt.Fprintf(w, "Invalid config: %v\n", err)
+		}
+
+		return fmt.Errorf("found [%d] invalid config values", len(errs))
+	}
+
+	_, _ = fmt.Fprintln(w, "Config is valid.")
+	return nil
+}
+
+func probeStorage(ctx context.Context) error {
+	store, err := storage.NewDataStore(storage.GetConfig(), promutils.NewScope("config_validate"))
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage. Error: %w", err)
+	}
+
+	ref, err := store.ConstructReference(ctx, store.GetBaseContainerFQN(ctx), storageProbeKey)
+	if err != nil {
+		return fmt.Errorf("failed to construct storage probe reference. Error: %w", err)
+	}
+
+	if _, err = store.Head(ctx, ref); err != nil {
+		return fmt.Errorf("storage is not reachable. Error: %w", err)
+	}
+
+	return nil
+}
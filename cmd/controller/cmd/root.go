@@ -27,7 +27,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller"
+	"github.com/flyteorg/flytepropeller/pkg/controller/introspection"
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 	"github.com/flyteorg/flytepropeller/pkg/signals"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
 )
 
 const (
@@ -80,7 +83,7 @@ func init() {
 
 	configAccessor.InitializePflags(rootCmd.PersistentFlags())
 
-	rootCmd.AddCommand(viper.GetConfigCommand())
+	rootCmd.AddCommand(newConfigCommand())
 }
 
 func initConfig(cmd *cobra.Command, _ []string) error {
@@ -91,7 +94,14 @@ func initConfig(cmd *cobra.Command, _ []string) error {
 
 	configAccessor.InitializePflags(cmd.PersistentFlags())
 
-	err := configAccessor.UpdateConfig(context.TODO())
+	// Capture the defaults before they are overridden by the loaded config, they are used to report the effective config.
+	var err error
+	defaultConfigSnapshot, err = utils.SnapshotConfigSections(config.GetRootSection())
+	if err != nil {
+		return err
+	}
+
+	err = configAccessor.UpdateConfig(context.TODO())
 	if err != nil {
 		return err
 	}
@@ -120,15 +130,33 @@ func executeRootCmd(baseCtx context.Context, cfg *config2.Config) error {
 		ClientBuilder: executors.NewFallbackClientBuilder(propellerScope.NewSubScope("kube")),
 	}
 
+	shutdownTracing, err := tracing.InitOpenTelemetry(ctx, cfg.OpenTelemetry)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to initialize OpenTelemetry. Error: %v", err)
+		return err
+	}
+
+	defer func() {
+		if err := shutdownTracing(baseCtx); err != nil {
+			logger.Errorf(baseCtx, "Failed to flush OpenTelemetry spans. Error: %v", err)
+		}
+	}()
+
 	mgr, err := controller.CreateControllerManager(ctx, cfg, options)
 	if err != nil {
 		logger.Fatalf(ctx, "Failed to create controller manager. Error: %v", err)
 		return err
 	}
 
+	debugHandlers, err := introspection.NewHandlers(ctx, introspection.GetConfig(), introspection.DefaultRecorder())
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to create introspection handlers. Error: %v", err)
+		return err
+	}
+
 	g, childCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
-		err := profutils.StartProfilingServerWithDefaultHandlers(childCtx, cfg.ProfilerPort.Port, nil)
+		err := profutils.StartProfilingServerWithDefaultHandlers(childCtx, cfg.ProfilerPort.Port, debugHandlers)
 		if err != nil {
 			logger.Fatalf(childCtx, "Failed to Start profiling and metrics server. Error: %v", err)
 		}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type AbortOpts struct {
+	*RootOptions
+}
+
+func NewAbortCommand(opts *RootOptions) *cobra.Command {
+
+	abortOpts := &AbortOpts{
+		RootOptions: opts,
+	}
+
+	abortCmd := &cobra.Command{
+		Use:   "abort <workflow_name>",
+		Short: "Requests a running workflow to be aborted",
+		Long: `Requests the workflow to be aborted the same way Admin does, by deleting it. The finalizer of the workflow
+keeps it around until propeller aborted its running nodes and recorded it as aborted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("workflow name is required")
+			}
+
+			return abortOpts.abortWorkflow(context.Background(), args[0])
+		},
+	}
+
+	return abortCmd
+}
+
+func (a *AbortOpts) abortWorkflow(ctx context.Context, name string) error {
+	namespace, name := splitWorkflowName(name, a.ConfigOverrides.Context.Namespace)
+	workflows := a.flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(namespace)
+	w, err := workflows.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if w.Status.IsTerminated() {
+		return fmt.Errorf("workflow [%s/%s] already completed in phase [%s]", namespace, name, w.Status.Phase.String())
+	}
+
+	if w.GetDeletionTimestamp() != nil {
+		fmt.Printf("Workflow [%s/%s] is already being aborted\n", namespace, name)
+		return nil
+	}
+
+	p := v1.DeletePropagationBackground
+	if err := workflows.Delete(ctx, name, v1.DeleteOptions{PropagationPolicy: &p}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Requested workflow [%s/%s] to be aborted\n", namespace, name)
+	return nil
+}
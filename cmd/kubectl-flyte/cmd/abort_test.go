@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+)
+
+func TestAbortOpts_abortWorkflow(t *testing.T) {
+	ctx := context.TODO()
+	running := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Name: "exec-1", Namespace: "project-development"},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowPhaseRunning},
+	}
+	succeeded := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Name: "exec-2", Namespace: "project-development"},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowPhaseSuccess},
+	}
+
+	flyteClient := fake.NewSimpleClientset(running, succeeded)
+	opts := &AbortOpts{
+		RootOptions: &RootOptions{
+			ConfigOverrides: &clientcmd.ConfigOverrides{},
+			flyteClient:     flyteClient,
+		},
+	}
+
+	t.Run("running", func(t *testing.T) {
+		assert.NoError(t, opts.abortWorkflow(ctx, "project-development/exec-1"))
+		_, err := flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows("project-development").Get(ctx, "exec-1", v1.GetOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		assert.Error(t, opts.abortWorkflow(ctx, "project-development/exec-2"))
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		assert.Error(t, opts.abortWorkflow(ctx, "project-development/exec-3"))
+	})
+}
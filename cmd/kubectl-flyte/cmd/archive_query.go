@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/spf13/cobra"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/archive"
+	ctrlConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type ArchiveQueryOpts struct {
+	*RootOptions
+	configPath  string
+	prefix      string
+	since       time.Duration
+	phase       string
+	failureCode string
+	summary     bool
+}
+
+// Filters the records of archived executions.
+type archiveFilter struct {
+	from        time.Time
+	to          time.Time
+	phase       string
+	failureCode string
+}
+
+func (f archiveFilter) matches(r archive.Record) bool {
+	return !r.StoppedAt.Before(f.from) && !r.StoppedAt.After(f.to) &&
+		(len(f.phase) == 0 || r.Phase == f.phase) &&
+		(len(f.failureCode) == 0 || r.FailureCode == f.failureCode)
+}
+
+func NewArchiveQueryCommand(opts *RootOptions) *cobra.Command {
+
+	archiveQueryOpts := &ArchiveQueryOpts{
+		RootOptions: opts,
+	}
+
+	archiveQueryCmd := &cobra.Command{
+		Use:   "archive-query [opts]",
+		Short: "Queries the index of the executions archived by propeller",
+		Long: `Lists the executions that propeller archived before deleting them, or summarizes them by phase and failure
+code. The storage and the archive prefix are read from the config of propeller.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			store, prefix, err := archiveQueryOpts.newArchiveStore(ctx)
+			if err != nil {
+				return err
+			}
+
+			namespaces, err := archiveQueryOpts.namespaces(ctx)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			records, err := queryArchive(ctx, store, prefix, namespaces, archiveFilter{
+				from:        now.Add(-archiveQueryOpts.since),
+				to:          now,
+				phase:       archiveQueryOpts.phase,
+				failureCode: archiveQueryOpts.failureCode,
+			})
+			if err != nil {
+				return err
+			}
+
+			if archiveQueryOpts.summary {
+				printArchiveSummary(os.Stdout, records)
+			} else {
+				printArchiveRecords(os.Stdout, records)
+			}
+
+			return nil
+		},
+	}
+
+	archiveQueryCmd.Flags().StringVarP(&archiveQueryOpts.configPath, "config", "c", "", "Path to the config of propeller to read the storage config and archive prefix from.")
+	archiveQueryCmd.Flags().StringVar(&archiveQueryOpts.prefix, "prefix", "", "Prefix the executions are archived under. Defaults to ttl-gc.archive-prefix of the config.")
+	archiveQueryCmd.Flags().DurationVar(&archiveQueryOpts.since, "since", 7*24*time.Hour, "Only executions that stopped more recently than this are queried.")
+	archiveQueryCmd.Flags().StringVar(&archiveQueryOpts.phase, "phase", "", "Only executions that completed in this phase, e.g. Failed, are queried.")
+	archiveQueryCmd.Flags().StringVar(&archiveQueryOpts.failureCode, "failure-code", "", "Only executions that failed with this error code are queried.")
+	archiveQueryCmd.Flags().BoolVar(&archiveQueryOpts.summary, "summary", false, "Summarize the executions by phase and failure code instead of listing them.")
+
+	return archiveQueryCmd
+}
+
+func (a *ArchiveQueryOpts) newArchiveStore(ctx context.Context) (*storage.DataStore, storage.DataReference, error) {
+	if len(a.configPath) == 0 {
+		return nil, "", fmt.Errorf("the config of propeller is required")
+	}
+
+	store, err := newPropellerDataStore(ctx, a.configPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := a.prefix
+	if len(prefix) == 0 {
+		prefix = ctrlConfig.GetConfig().TTLGarbageCollector.ArchivePrefix
+	}
+
+	if len(prefix) == 0 {
+		return nil, "", fmt.Errorf("no archive prefix configured, use --prefix")
+	}
+
+	return store, storage.DataReference(prefix), nil
+}
+
+func (a *ArchiveQueryOpts) namespaces(ctx context.Context) ([]string, error) {
+	if !a.allNamespaces {
+		if len(a.ConfigOverrides.Context.Namespace) == 0 {
+			return nil, fmt.Errorf("namespace is required, use --namespace or --all-namespaces")
+		}
+
+		return []string{a.ConfigOverrides.Context.Namespace}, nil
+	}
+
+	namespaceList, err := a.kubeClient.CoreV1().Namespaces().List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0, len(namespaceList.Items))
+	for _, n := range namespaceList.Items {
+		if n.Status.Phase != v12.NamespaceTerminating {
+			namespaces = append(namespaces, n.GetName())
+		}
+	}
+
+	return namespaces, nil
+}
+
+// Reads the records of the executions of the namespaces that match the filter, from the partitions of the index of the
+// days in the range of the filter. Records are sorted by the time the executions stopped.
+func queryArchive(ctx context.Context, store *storage.DataStore, prefix storage.DataReference, namespaces []string,
+	filter archiveFilter) ([]archive.Record, error) {
+	var records []archive.Record
+	for _, namespace := range namespaces {
+		for day := filter.from.UTC().Truncate(24 * time.Hour); !day.After(filter.to); day = day.Add(24 * time.Hour) {
+			ref, err := archive.IndexReference(ctx, store, prefix, namespace, day)
+			if err != nil {
+				return nil, err
+			}
+
+			partition, err := archive.ReadIndex(ctx, store, ref)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, r := range partition {
+				if filter.matches(r) {
+					records = append(records, r)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].StoppedAt.Before(records[j].StoppedAt)
+	})
+
+	return records, nil
+}
+
+func printArchiveRecords(out io.Writer, records []archive.Record) {
+	fmt.Fprintf(out, "|%30s|%40s|%10s|%25s|%12s|%30s|\n", "Namespace", "Name", "Phase", "Stopped", "Duration", "FailureCode")
+	for _, r := range records {
+		duration := time.Duration(r.DurationSeconds * float64(time.Second)).Round(time.Second)
+		fmt.Fprintf(out, "|%30s|%40s|%10s|%25s|%12s|%30s|\n", r.Namespace, r.Name, r.Phase,
+			r.StoppedAt.Format(time.RFC3339), duration.String(), r.FailureCode)
+	}
+
+	fmt.Fprintf(out, "Found %d executions\n", len(records))
+}
+
+type archiveSummaryKey struct {
+	phase       string
+	failureCode string
+}
+
+type archiveSummaryCounter struct {
+	archiveSummaryKey
+	count           int
+	durationSeconds float64
+}
+
+// Prints the number and average duration of the executions by the phase and failure code they completed with.
+func printArchiveSummary(out io.Writer, records []archive.Record) {
+	counters := map[archiveSummaryKey]*archiveSummaryCounter{}
+	for _, r := range records {
+		key := archiveSummaryKey{phase: r.Phase, failureCode: r.FailureCode}
+		c, ok := counters[key]
+		if !ok {
+			c = &archiveSummaryCounter{archiveSummaryKey: key}
+			counters[key] = c
+		}
+
+		c.count++
+		c.durationSeconds += r.DurationSeconds
+	}
+
+	sorted := make([]*archiveSummaryCounter, 0, len(counters))
+	for _, c := range counters {
+		sorted = append(sorted, c)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count == sorted[j].count {
+			return sorted[i].phase+sorted[i].failureCode < sorted[j].phase+sorted[j].failureCode
+		}
+
+		return sorted[i].count > sorted[j].count
+	})
+
+	fmt.Fprintf(out, "|%10s|%30s|%7s|%12s|\n", "Phase", "FailureCode", "Total", "AvgDuration")
+	for _, c := range sorted {
+		avg := time.Duration(c.durationSeconds / float64(c.count) * float64(time.Second)).Round(time.Second)
+		fmt.Fprintf(out, "|%10s|%30s|%7d|%12s|\n", c.phase, c.failureCode, c.count, avg.String())
+	}
+
+	fmt.Fprintf(out, "Found %d executions\n", len(records))
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/archive"
+)
+
+func TestQueryArchive(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	now := time.Date(2021, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	archiver := archive.NewArchiver(store, "s3://bucket/archive")
+	archiveWorkflow := func(namespace, name string, phase v1alpha1.WorkflowPhase, stoppedAgo time.Duration, code string) {
+		startedAt := v1.NewTime(now.Add(-stoppedAgo - time.Minute))
+		stoppedAt := v1.NewTime(now.Add(-stoppedAgo))
+		w := &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     v1alpha1.WorkflowStatus{Phase: phase, StartedAt: &startedAt, StoppedAt: &stoppedAt},
+		}
+		if len(code) > 0 {
+			w.Status.Error = &v1alpha1.ExecutionError{ExecutionError: &core.ExecutionError{Code: code}}
+		}
+
+		assert.NoError(t, archiver.Archive(ctx, w))
+	}
+
+	archiveWorkflow("ns", "recent-success", v1alpha1.WorkflowPhaseSuccess, time.Hour, "")
+	archiveWorkflow("ns", "recent-failure", v1alpha1.WorkflowPhaseFailed, 2*time.Hour, "OOMKilled")
+	archiveWorkflow("ns", "older-failure", v1alpha1.WorkflowPhaseFailed, 50*time.Hour, "OOMKilled")
+	archiveWorkflow("ns", "old-failure", v1alpha1.WorkflowPhaseFailed, 30*24*time.Hour, "OOMKilled")
+	archiveWorkflow("other", "failure", v1alpha1.WorkflowPhaseFailed, time.Hour, "OOMKilled")
+	assert.NoError(t, archiver.Flush(ctx))
+
+	t.Run("all", func(t *testing.T) {
+		records, err := queryArchive(ctx, store, "s3://bucket/archive", []string{"ns"},
+			archiveFilter{from: now.Add(-7 * 24 * time.Hour), to: now})
+		assert.NoError(t, err)
+		names := make([]string, 0, len(records))
+		for _, r := range records {
+			names = append(names, r.Name)
+		}
+
+		assert.Equal(t, []string{"older-failure", "recent-failure", "recent-success"}, names)
+
+		out := &bytes.Buffer{}
+		printArchiveSummary(out, records)
+		assert.Contains(t, out.String(), "|    Failed|                     OOMKilled|      2|        1m0s|")
+		assert.Contains(t, out.String(), "Found 3 executions")
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		records, err := queryArchive(ctx, store, "s3://bucket/archive", []string{"ns", "other"},
+			archiveFilter{from: now.Add(-24 * time.Hour), to: now, phase: "Failed", failureCode: "OOMKilled"})
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+
+		out := &bytes.Buffer{}
+		printArchiveRecords(out, records)
+		assert.Contains(t, out.String(), "recent-failure")
+		assert.Contains(t, out.String(), "Found 2 executions")
+	})
+}
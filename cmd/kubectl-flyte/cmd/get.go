@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	gotree "github.com/DiSiqueira/GoTree"
 	"github.com/flyteorg/flytestdlib/storage"
@@ -22,6 +23,8 @@ type GetOpts struct {
 	limit              int64
 	chunkSize          int64
 	showQuota          bool
+	showSummary        bool
+	filter             workflowFilter
 }
 
 func NewGetCommand(opts *RootOptions) *cobra.Command {
@@ -33,7 +36,8 @@ func NewGetCommand(opts *RootOptions) *cobra.Command {
 	getCmd := &cobra.Command{
 		Use:   "get [opts] [<workflow_name>]",
 		Short: "Gets a single workflow or lists all workflows currently in execution",
-		Long:  `use labels to filter`,
+		Long: `use labels to filter. Workflows can also be filtered by phase, project, domain, launch plan and age, across
+namespaces with --all-namespaces.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -49,6 +53,13 @@ func NewGetCommand(opts *RootOptions) *cobra.Command {
 	getCmd.Flags().BoolVarP(&getOpts.showQuota, "show-quota", "q", false, "Shows resource quota usage for that resource.")
 	getCmd.Flags().Int64VarP(&getOpts.chunkSize, "chunk-size", "c", 100, "Use this much batch size.")
 	getCmd.Flags().Int64VarP(&getOpts.limit, "limit", "l", -1, "Only get limit records. -1 => all records.")
+	getCmd.Flags().BoolVar(&getOpts.showSummary, "summary", false, "Prints the number of workflows per phase, the oldest running workflow and the largest workflow.")
+	getCmd.Flags().StringSliceVar(&getOpts.filter.phases, "phase", nil, "Only list workflows in one of these phases, e.g. Running,Failed.")
+	getCmd.Flags().StringVar(&getOpts.filter.project, "project", "", "Only list workflows of this project.")
+	getCmd.Flags().StringVar(&getOpts.filter.domain, "domain", "", "Only list workflows of this domain.")
+	getCmd.Flags().StringVar(&getOpts.filter.launchPlan, "launch-plan", "", "Only list workflows launched from this launch plan.")
+	getCmd.Flags().DurationVar(&getOpts.filter.olderThan, "older-than", 0, "Only list workflows created longer ago than this, e.g. 24h.")
+	getCmd.Flags().DurationVar(&getOpts.filter.newerThan, "newer-than", 0, "Only list workflows created more recently than this, e.g. 1h.")
 
 	return getCmd
 }
@@ -63,6 +74,10 @@ func (g *GetOpts) getWorkflow(ctx context.Context, name string) error {
 	if err != nil {
 		return err
 	}
+	w, err = g.hydrateWorkflow(ctx, w)
+	if err != nil {
+		return err
+	}
 	wp := printers.WorkflowPrinter{}
 	tree := gotree.New("Workflow")
 	w.DataReferenceConstructor = storage.URLPathConstructor{}
@@ -73,6 +88,16 @@ func (g *GetOpts) getWorkflow(ctx context.Context, name string) error {
 	return nil
 }
 
+// Returns the namespace to list in, all namespaces are listed with --all-namespaces.
+func (g *GetOpts) listNamespace() string {
+	if g.allNamespaces {
+		return v1.NamespaceAll
+	}
+
+	return g.ConfigOverrides.Context.Namespace
+}
+
+// Iterates over the workflows that match the filter. The limit applies to the matching workflows.
 func (g *GetOpts) iterateOverWorkflows(f func(*v1alpha1.FlyteWorkflow) error, batchSize int64, limit int64) error {
 	if limit > 0 && limit < batchSize {
 		batchSize = limit
@@ -81,19 +106,32 @@ func (g *GetOpts) iterateOverWorkflows(f func(*v1alpha1.FlyteWorkflow) error, ba
 	if err != nil {
 		return err
 	}
+	phases, err := g.filter.parsePhases()
+	if err != nil {
+		return err
+	}
 	opts := &v1.ListOptions{
 		Limit:          batchSize,
 		TimeoutSeconds: &t,
+		LabelSelector:  g.filter.labelSelector(),
 	}
+	now := time.Now()
 	var counter int64
 	for {
-		wList, err := g.flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(g.ConfigOverrides.Context.Namespace).List(context.TODO(), *opts)
+		wList, err := g.flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(g.listNamespace()).List(context.TODO(), *opts)
 		if err != nil {
 			return err
 		}
 		for _, w := range wList.Items {
 			_w := w
-			if err := f(&_w); err != nil {
+			if !g.filter.matches(&_w, phases, now) {
+				continue
+			}
+			decoded, err := g.hydrateWorkflow(context.TODO(), &_w)
+			if err != nil {
+				return err
+			}
+			if err := f(decoded); err != nil {
 				return err
 			}
 			counter++
@@ -205,7 +243,7 @@ func (s SortableNSDist) Swap(i, j int) {
 }
 
 func (g *GetOpts) listWorkflows() error {
-	fmt.Printf("Listing workflows in [%s]\n", g.ConfigOverrides.Context.Namespace)
+	fmt.Printf("Listing workflows in [%s]\n", g.listNamespace())
 	wp := printers.WorkflowPrinter{}
 	workflows := gotree.New("workflows")
 	var counter int64
@@ -214,9 +252,13 @@ func (g *GetOpts) listWorkflows() error {
 	var running = 0
 	var waiting = 0
 	perNS := make(map[string]*perNSCounter)
+	summary := newWorkflowSummary()
 	err := g.iterateOverWorkflows(
 		func(w *v1alpha1.FlyteWorkflow) error {
 			counter++
+			if err := summary.add(w); err != nil {
+				return err
+			}
 			if err := wp.PrintShort(workflows, w); err != nil {
 				return err
 			}
@@ -228,7 +270,7 @@ func (g *GetOpts) listWorkflows() error {
 			c := perNS[w.Namespace]
 			c.total++
 			switch w.GetExecutionStatus().GetPhase() {
-			case v1alpha1.WorkflowPhaseReady:
+			case v1alpha1.WorkflowPhaseReady, v1alpha1.WorkflowPhaseQueued:
 				c.waiting++
 				waiting++
 			case v1alpha1.WorkflowPhaseSuccess:
@@ -289,5 +331,10 @@ func (g *GetOpts) listWorkflows() error {
 	for _, v := range perNSDist {
 		fmt.Println(v.String(g.showQuota))
 	}
+
+	if g.showSummary {
+		fmt.Println("")
+		fmt.Print(summary.String(time.Now()))
+	}
 	return nil
 }
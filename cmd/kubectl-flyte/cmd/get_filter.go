@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+)
+
+// workflowFilter selects the workflows listed by the get command. Projects, domains and launch plans are matched by the
+// labels of the workflows on the server, while phases and ages are matched on the client.
+type workflowFilter struct {
+	phases     []string
+	project    string
+	domain     string
+	launchPlan string
+	olderThan  time.Duration
+	newerThan  time.Duration
+}
+
+// Returns the label selector matching the project, domain and launch plan of the filter.
+func (f workflowFilter) labelSelector() string {
+	set := labels.Set{}
+	if f.project != "" {
+		set[k8s.ProjectLabel] = f.project
+	}
+
+	if f.domain != "" {
+		set[k8s.DomainLabel] = f.domain
+	}
+
+	if f.launchPlan != "" {
+		set[k8s.LaunchPlanNameLabel] = f.launchPlan
+	}
+
+	return set.String()
+}
+
+// Parses the phases of the filter, case insensitively. An empty set matches all phases.
+func (f workflowFilter) parsePhases() (map[v1alpha1.WorkflowPhase]bool, error) {
+	phases := make(map[v1alpha1.WorkflowPhase]bool, len(f.phases))
+	for _, name := range f.phases {
+		found := false
+		for p := v1alpha1.WorkflowPhaseReady; p <= v1alpha1.WorkflowPhaseQueued; p++ {
+			if strings.EqualFold(p.String(), name) {
+				phases[p] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown workflow phase [%v]", name)
+		}
+	}
+
+	return phases, nil
+}
+
+// Returns whether the workflow is in one of the phases and its age within the bounds of the filter.
+func (f workflowFilter) matches(w *v1alpha1.FlyteWorkflow, phases map[v1alpha1.WorkflowPhase]bool, now time.Time) bool {
+	if len(phases) > 0 && !phases[w.GetExecutionStatus().GetPhase()] {
+		return false
+	}
+
+	age := now.Sub(w.CreationTimestamp.Time)
+	if f.olderThan > 0 && age < f.olderThan {
+		return false
+	}
+
+	if f.newerThan > 0 && age > f.newerThan {
+		return false
+	}
+
+	return true
+}
+
+// workflowSummary aggregates the listed workflows across namespaces, for operators triaging a cluster.
+type workflowSummary struct {
+	perPhase      map[v1alpha1.WorkflowPhase]int
+	oldestRunning *v1alpha1.FlyteWorkflow
+	largest       *v1alpha1.FlyteWorkflow
+	largestSize   int
+}
+
+func newWorkflowSummary() *workflowSummary {
+	return &workflowSummary{
+		perPhase: make(map[v1alpha1.WorkflowPhase]int),
+	}
+}
+
+// Adds the workflow to the summary. The size of a workflow is the size of its serialized CR.
+func (s *workflowSummary) add(w *v1alpha1.FlyteWorkflow) error {
+	phase := w.GetExecutionStatus().GetPhase()
+	s.perPhase[phase]++
+
+	if !w.Status.IsTerminated() && phase != v1alpha1.WorkflowPhaseReady && phase != v1alpha1.WorkflowPhaseQueued &&
+		(s.oldestRunning == nil || w.CreationTimestamp.Before(&s.oldestRunning.CreationTimestamp)) {
+		s.oldestRunning = w
+	}
+
+	raw, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	if s.largest == nil || len(raw) > s.largestSize {
+		s.largest = w
+		s.largestSize = len(raw)
+	}
+
+	return nil
+}
+
+func (s *workflowSummary) String(now time.Time) string {
+	phases := make([]v1alpha1.WorkflowPhase, 0, len(s.perPhase))
+	for p := range s.perPhase {
+		phases = append(phases, p)
+	}
+
+	sort.Slice(phases, func(i, j int) bool {
+		return phases[i] < phases[j]
+	})
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("|%20s|%7s|\n", "Phase", "Count"))
+	for _, p := range phases {
+		sb.WriteString(fmt.Sprintf("|%20s|%7d|\n", p.String(), s.perPhase[p]))
+	}
+
+	if s.oldestRunning != nil {
+		sb.WriteString(fmt.Sprintf("Oldest running: %s/%s, age %v\n", s.oldestRunning.Namespace, s.oldestRunning.Name,
+			now.Sub(s.oldestRunning.CreationTimestamp.Time).Round(time.Second)))
+	}
+
+	if s.largest != nil {
+		sb.WriteString(fmt.Sprintf("Largest: %s/%s, %d bytes\n", s.largest.Namespace, s.largest.Name, s.largestSize))
+	}
+
+	return sb.String()
+}
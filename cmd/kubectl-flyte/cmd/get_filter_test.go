@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+)
+
+func newFilterTestWorkflow(namespace, name, project string, phase v1alpha1.WorkflowPhase, createdAt time.Time) *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: v1.NewTime(createdAt),
+			Labels:            map[string]string{k8s.ProjectLabel: project, k8s.DomainLabel: "development"},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: phase},
+	}
+}
+
+func TestWorkflowFilter(t *testing.T) {
+	now := time.Now()
+
+	t.Run("label selector", func(t *testing.T) {
+		assert.Equal(t, "", workflowFilter{}.labelSelector())
+		assert.Equal(t, "domain=development,launch-plan-name=lp,project=flytesnacks",
+			workflowFilter{project: "flytesnacks", domain: "development", launchPlan: "lp"}.labelSelector())
+	})
+
+	t.Run("phases", func(t *testing.T) {
+		phases, err := workflowFilter{phases: []string{"running", "Failed"}}.parsePhases()
+		assert.NoError(t, err)
+		assert.Equal(t, map[v1alpha1.WorkflowPhase]bool{v1alpha1.WorkflowPhaseRunning: true, v1alpha1.WorkflowPhaseFailed: true}, phases)
+
+		_, err = workflowFilter{phases: []string{"sleeping"}}.parsePhases()
+		assert.Error(t, err)
+	})
+
+	t.Run("matches", func(t *testing.T) {
+		w := newFilterTestWorkflow("ns", "w", "p", v1alpha1.WorkflowPhaseRunning, now.Add(-2*time.Hour))
+		running := map[v1alpha1.WorkflowPhase]bool{v1alpha1.WorkflowPhaseRunning: true}
+		failed := map[v1alpha1.WorkflowPhase]bool{v1alpha1.WorkflowPhaseFailed: true}
+
+		assert.True(t, workflowFilter{}.matches(w, nil, now))
+		assert.True(t, workflowFilter{}.matches(w, running, now))
+		assert.False(t, workflowFilter{}.matches(w, failed, now))
+		assert.True(t, workflowFilter{olderThan: time.Hour}.matches(w, nil, now))
+		assert.False(t, workflowFilter{olderThan: 3 * time.Hour}.matches(w, nil, now))
+		assert.True(t, workflowFilter{newerThan: 3 * time.Hour}.matches(w, nil, now))
+		assert.False(t, workflowFilter{newerThan: time.Hour}.matches(w, nil, now))
+	})
+}
+
+func TestWorkflowSummary(t *testing.T) {
+	now := time.Now()
+	s := newWorkflowSummary()
+	oldest := newFilterTestWorkflow("ns", "oldest", "p", v1alpha1.WorkflowPhaseRunning, now.Add(-2*time.Hour))
+	largest := newFilterTestWorkflow("ns", "largest", "p", v1alpha1.WorkflowPhaseFailed, now.Add(-3*time.Hour))
+	largest.Status.Message = "a long failure message that makes this workflow the largest one"
+
+	assert.NoError(t, s.add(newFilterTestWorkflow("ns", "w", "p", v1alpha1.WorkflowPhaseRunning, now.Add(-time.Hour))))
+	assert.NoError(t, s.add(oldest))
+	assert.NoError(t, s.add(largest))
+	assert.NoError(t, s.add(newFilterTestWorkflow("ns", "queued", "p", v1alpha1.WorkflowPhaseQueued, now.Add(-4*time.Hour))))
+
+	assert.Equal(t, map[v1alpha1.WorkflowPhase]int{
+		v1alpha1.WorkflowPhaseRunning: 2,
+		v1alpha1.WorkflowPhaseFailed:  1,
+		v1alpha1.WorkflowPhaseQueued:  1,
+	}, s.perPhase)
+	assert.Equal(t, "oldest", s.oldestRunning.Name)
+	assert.Equal(t, "largest", s.largest.Name)
+
+	out := s.String(now)
+	assert.Contains(t, out, "Oldest running: ns/oldest, age 2h0m0s")
+	assert.Contains(t, out, "Largest: ns/largest")
+	assert.Contains(t, out, "|             Running|      2|")
+}
+
+func TestGetOpts_IterateOverWorkflows(t *testing.T) {
+	now := time.Now()
+	g := &GetOpts{
+		RootOptions: &RootOptions{
+			ConfigOverrides: &clientcmd.ConfigOverrides{},
+			allNamespaces:   true,
+			flyteClient: fake.NewSimpleClientset(
+				newFilterTestWorkflow("ns", "a", "p1", v1alpha1.WorkflowPhaseRunning, now),
+				newFilterTestWorkflow("ns", "b", "p1", v1alpha1.WorkflowPhaseFailed, now),
+				newFilterTestWorkflow("other", "c", "p1", v1alpha1.WorkflowPhaseRunning, now),
+				newFilterTestWorkflow("other", "d", "p2", v1alpha1.WorkflowPhaseRunning, now),
+			),
+		},
+		filter: workflowFilter{project: "p1", phases: []string{"Running"}},
+	}
+
+	var names []string
+	assert.NoError(t, g.iterateOverWorkflows(func(w *v1alpha1.FlyteWorkflow) error {
+		names = append(names, w.Name)
+		return nil
+	}, 100, -1))
+	assert.ElementsMatch(t, []string{"a", "c"}, names)
+}
@@ -14,7 +14,7 @@ import (
 
 func ColorizeWorkflowPhase(p v1alpha1.WorkflowPhase) string {
 	switch p {
-	case v1alpha1.WorkflowPhaseReady:
+	case v1alpha1.WorkflowPhaseReady, v1alpha1.WorkflowPhaseQueued:
 		return p.String()
 	case v1alpha1.WorkflowPhaseRunning:
 		return color.YellowString("%s", p.String())
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+type RetryNodeOpts struct {
+	*RootOptions
+}
+
+func NewRetryNodeCommand(opts *RootOptions) *cobra.Command {
+
+	retryNodeOpts := &RetryNodeOpts{
+		RootOptions: opts,
+	}
+
+	retryNodeCmd := &cobra.Command{
+		Use:   "retry-node <workflow_name> <node_id>",
+		Short: "Resets a failed node of a workflow that has not completed yet so that it is retried",
+		Long: `Moves the failed node to RetryableFailure, the same phase propeller moves nodes to when they fail with a
+retryable error, and the workflow back to Running if it is failing. Propeller then aborts what is left of the last attempt
+of the node, clears its state and starts a new attempt. Workflows that completed already cannot be retried this way and
+need to be relaunched.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("workflow name and node id are required")
+			}
+
+			return retryNodeOpts.retryNode(context.Background(), args[0], v1alpha1.NodeID(args[1]))
+		},
+	}
+
+	return retryNodeCmd
+}
+
+func (r *RetryNodeOpts) retryNode(ctx context.Context, name string, nodeID v1alpha1.NodeID) error {
+	namespace, name := splitWorkflowName(name, r.ConfigOverrides.Context.Namespace)
+	workflows := r.flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(namespace)
+	// Propeller updates the workflow every round, so the update is retried on conflicts with a freshly read workflow.
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		w, err := workflows.Get(ctx, name, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if err := resetFailedNode(w, nodeID); err != nil {
+			return err
+		}
+
+		_, err = workflows.Update(ctx, w, v1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Node [%s] of workflow [%s/%s] will be retried\n", nodeID, namespace, name)
+	return nil
+}
+
+// Resets the status of a failed node so that propeller retries it in its next round.
+func resetFailedNode(w *v1alpha1.FlyteWorkflow, nodeID v1alpha1.NodeID) error {
+	if w.GetDeletionTimestamp() != nil {
+		return fmt.Errorf("workflow [%s] is being aborted", w.GetName())
+	}
+
+	if p := w.Status.Phase; p != v1alpha1.WorkflowPhaseRunning && p != v1alpha1.WorkflowPhaseFailing {
+		return fmt.Errorf("only nodes of running or failing workflows can be retried, workflow [%s] is [%s]",
+			w.GetName(), p.String())
+	}
+
+	if len(w.Status.NodeStatusRef) > 0 || len(w.Status.EncodedNodeStatus) > 0 {
+		return fmt.Errorf("the node status of workflow [%s] is not stored inline and cannot be modified", w.GetName())
+	}
+
+	nodeStatus, ok := w.Status.NodeStatus[nodeID]
+	if !ok {
+		return fmt.Errorf("node [%s] of workflow [%s] not found", nodeID, w.GetName())
+	}
+
+	if p := nodeStatus.GetPhase(); p != v1alpha1.NodePhaseFailed && p != v1alpha1.NodePhaseTimedOut {
+		return fmt.Errorf("only failed nodes can be retried, node [%s] is [%s]", nodeID, p.String())
+	}
+
+	nodeStatus.UpdatePhase(v1alpha1.NodePhaseRetryableFailure, v1.Now(), "manually retried", nodeStatus.GetExecutionError())
+	if w.Status.Phase == v1alpha1.WorkflowPhaseFailing {
+		w.Status.UpdatePhase(v1alpha1.WorkflowPhaseRunning, fmt.Sprintf("retrying node [%s]", nodeID), nil)
+		w.Status.Error = nil
+	}
+
+	return nil
+}
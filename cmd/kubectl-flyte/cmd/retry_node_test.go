@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+)
+
+func TestResetFailedNode(t *testing.T) {
+	execErr := &core.ExecutionError{Code: "code", Message: "msg", Kind: core.ExecutionError_USER}
+	newWorkflow := func(phase v1alpha1.WorkflowPhase) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{Name: "exec-1", Namespace: "project-development"},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: phase,
+				Error: &v1alpha1.ExecutionError{ExecutionError: execErr},
+				NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+					"n0": {Phase: v1alpha1.NodePhaseSucceeded},
+					"n1": {Phase: v1alpha1.NodePhaseFailed, Error: &v1alpha1.ExecutionError{ExecutionError: execErr}},
+				},
+			},
+		}
+	}
+
+	t.Run("failing", func(t *testing.T) {
+		w := newWorkflow(v1alpha1.WorkflowPhaseFailing)
+		assert.NoError(t, resetFailedNode(w, "n1"))
+		assert.Equal(t, v1alpha1.NodePhaseRetryableFailure, w.Status.NodeStatus["n1"].GetPhase())
+		assert.Equal(t, v1alpha1.WorkflowPhaseRunning, w.Status.Phase)
+		assert.Nil(t, w.Status.Error)
+	})
+
+	t.Run("not-failed", func(t *testing.T) {
+		assert.Error(t, resetFailedNode(newWorkflow(v1alpha1.WorkflowPhaseFailing), "n0"))
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		assert.Error(t, resetFailedNode(newWorkflow(v1alpha1.WorkflowPhaseFailing), "n2"))
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		assert.Error(t, resetFailedNode(newWorkflow(v1alpha1.WorkflowPhaseFailed), "n1"))
+	})
+
+	t.Run("aborting", func(t *testing.T) {
+		w := newWorkflow(v1alpha1.WorkflowPhaseRunning)
+		w.DeletionTimestamp = &v1.Time{}
+		assert.Error(t, resetFailedNode(w, "n1"))
+	})
+}
+
+func TestRetryNodeOpts_retryNode(t *testing.T) {
+	ctx := context.TODO()
+	w := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Name: "exec-1", Namespace: "project-development"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowPhaseRunning,
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				"n1": {Phase: v1alpha1.NodePhaseTimedOut},
+			},
+		},
+	}
+
+	flyteClient := fake.NewSimpleClientset(w)
+	opts := &RetryNodeOpts{
+		RootOptions: &RootOptions{
+			ConfigOverrides: &clientcmd.ConfigOverrides{},
+			flyteClient:     flyteClient,
+		},
+	}
+
+	assert.NoError(t, opts.retryNode(ctx, "project-development/exec-1", "n1"))
+	updated, err := flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows("project-development").Get(ctx, "exec-1", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.NodePhaseRetryableFailure, updated.Status.NodeStatus["n1"].GetPhase())
+}
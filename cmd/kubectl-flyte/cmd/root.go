@@ -8,15 +8,21 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
 	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/flyteorg/flytestdlib/version"
 	"github.com/spf13/pflag"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	flyteclient "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
+	"github.com/flyteorg/flytepropeller/pkg/controller/storagerouter"
+	"github.com/flyteorg/flytepropeller/pkg/controller/workflowstore"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +43,9 @@ type RootOptions struct {
 	restConfig    *rest.Config
 	kubeClient    kubernetes.Interface
 	flyteClient   flyteclient.Interface
+	// The data store of propeller, loaded from propellerConfig once a workflow with offloaded node status is read.
+	propellerConfig string
+	dataStore       *storage.DataStore
 }
 
 func (r *RootOptions) GetTimeoutSeconds() (int64, error) {
@@ -51,6 +60,36 @@ func (r *RootOptions) GetTimeoutSeconds() (int64, error) {
 
 }
 
+// Loads the config of propeller from the path and returns the data store it configures.
+func newPropellerDataStore(ctx context.Context, configPath string) (*storage.DataStore, error) {
+	accessor := viper.NewAccessor(config.Options{SearchPaths: []string{configPath}})
+	if err := accessor.UpdateConfig(ctx); err != nil {
+		return nil, err
+	}
+
+	return storagerouter.NewDataStore(ctx, storage.GetConfig(), storagerouter.GetConfig(), promutils.NewScope("kubectl_flyte"))
+}
+
+// Returns the workflow decoded, with the status of its nodes read from the data store of propeller if the workflow
+// store offloaded it.
+func (r *RootOptions) hydrateWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+	if len(w.Status.NodeStatusRef) > 0 && r.dataStore == nil {
+		if len(r.propellerConfig) == 0 {
+			return nil, fmt.Errorf("the node status of workflow [%s] is offloaded to blob storage, use --propeller-config to read it",
+				w.GetName())
+		}
+
+		store, err := newPropellerDataStore(ctx, r.propellerConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		r.dataStore = store
+	}
+
+	return workflowstore.HydrateWorkflow(ctx, r.dataStore, w)
+}
+
 func (r *RootOptions) executeRootCmd() error {
 	ctx := context.TODO()
 	logger.Infof(ctx, "Go Version: %s", runtime.Version())
@@ -99,6 +138,10 @@ func NewFlyteCommand() *cobra.Command {
 	command.AddCommand(NewVisualizeCommand(rootOpts))
 	command.AddCommand(NewCreateCommand(rootOpts))
 	command.AddCommand(NewCompileCommand(rootOpts))
+	command.AddCommand(NewSupportBundleCommand(rootOpts))
+	command.AddCommand(NewAbortCommand(rootOpts))
+	command.AddCommand(NewRetryNodeCommand(rootOpts))
+	command.AddCommand(NewArchiveQueryCommand(rootOpts))
 
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	loadingRules.DefaultClientConfig = &clientcmd.DefaultClientConfig
@@ -110,6 +153,7 @@ func NewFlyteCommand() *cobra.Command {
 
 	command.PersistentFlags().BoolVar(&rootOpts.allNamespaces, "all-namespaces", false, "Enable this flag to execute for all namespaces")
 	command.PersistentFlags().BoolVarP(&rootOpts.showSource, "show-source", "s", false, "Show line number for errors")
+	command.PersistentFlags().StringVar(&rootOpts.propellerConfig, "propeller-config", "", "Path to the config of propeller to read the storage config from. Only required for workflows whose node status is offloaded to blob storage.")
 	command.AddCommand(viper.GetConfigCommand())
 
 	return command
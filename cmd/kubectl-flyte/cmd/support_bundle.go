@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+)
+
+type SupportBundleOpts struct {
+	*RootOptions
+	outputPath          string
+	propellerNamespace  string
+	propellerSelector   string
+	propellerConfigMaps []string
+	logsSince           time.Duration
+}
+
+func NewSupportBundleCommand(opts *RootOptions) *cobra.Command {
+
+	supportBundleOpts := &SupportBundleOpts{
+		RootOptions: opts,
+	}
+
+	supportBundleCmd := &cobra.Command{
+		Use:   "support-bundle [opts] <workflow_name>",
+		Short: "Packages everything needed to debug a workflow into an archive to attach to bug reports",
+		Long: `Writes a gzipped tarball with the FlyteWorkflow, the descriptions of its pods, the events of the workflow
+and its pods, the logs of propeller that mention the workflow and the config of propeller.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("workflow name is required")
+			}
+
+			ctx := context.Background()
+			outputPath := supportBundleOpts.outputPath
+			if len(outputPath) == 0 {
+				outputPath = strings.Replace(args[0], "/", "-", -1) + "-support-bundle.tar.gz"
+			}
+
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+			if err := supportBundleOpts.writeSupportBundle(ctx, args[0], f); err != nil {
+				return err
+			}
+
+			fmt.Printf("Support bundle written to %s\n", outputPath)
+			return nil
+		},
+	}
+
+	supportBundleCmd.Flags().StringVarP(&supportBundleOpts.outputPath, "output", "o", "", "Path to write the archive to. Defaults to <workflow_name>-support-bundle.tar.gz.")
+	supportBundleCmd.Flags().StringVar(&supportBundleOpts.propellerNamespace, "propeller-namespace", "flyte", "Namespace propeller runs in.")
+	supportBundleCmd.Flags().StringVar(&supportBundleOpts.propellerSelector, "propeller-selector", "app=flytepropeller", "Label selector of the propeller pods.")
+	supportBundleCmd.Flags().StringSliceVar(&supportBundleOpts.propellerConfigMaps, "propeller-config-maps", []string{"flyte-propeller-config"}, "Config maps holding the config of propeller.")
+	supportBundleCmd.Flags().DurationVar(&supportBundleOpts.logsSince, "logs-since", time.Hour, "Only the logs of propeller more recent than this are searched for the workflow.")
+
+	return supportBundleCmd
+}
+
+// Writes the files of a support bundle to a gzipped tarball.
+type bundleWriter struct {
+	tw  *tar.Writer
+	now time.Time
+}
+
+func (b *bundleWriter) writeFile(name string, content []byte) error {
+	if err := b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: b.now,
+	}); err != nil {
+		return err
+	}
+
+	_, err := b.tw.Write(content)
+	return err
+}
+
+func (b *bundleWriter) writeYaml(name string, obj interface{}) error {
+	raw, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return b.writeFile(name, raw)
+}
+
+func (s *SupportBundleOpts) writeSupportBundle(ctx context.Context, name string, out io.Writer) error {
+	namespace := s.ConfigOverrides.Context.Namespace
+	parts := strings.Split(name, "/")
+	if len(parts) > 1 {
+		namespace = parts[0]
+		name = parts[1]
+	}
+
+	w, err := s.flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	w, err = s.hydrateWorkflow(ctx, w)
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(out)
+	b := &bundleWriter{tw: tar.NewWriter(gw), now: time.Now()}
+	if err := s.writeBundleFiles(ctx, b, w); err != nil {
+		return err
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func (s *SupportBundleOpts) writeBundleFiles(ctx context.Context, b *bundleWriter, w *v1alpha1.FlyteWorkflow) error {
+	if err := b.writeYaml("workflow.yaml", w); err != nil {
+		return err
+	}
+
+	// Pods are labeled with the execution id of the workflow that launched them.
+	executionID := w.GetLabels()[k8s.ExecutionIDLabel]
+	if len(executionID) == 0 {
+		executionID = w.GetName()
+	}
+
+	pods, err := s.kubeClient.CoreV1().Pods(w.GetNamespace()).List(ctx, v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ExecutionIDLabel, executionID),
+	})
+	if err != nil {
+		return err
+	}
+
+	involvedObjects := []string{w.GetName()}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		involvedObjects = append(involvedObjects, p.GetName())
+		if err := b.writeYaml(fmt.Sprintf("pods/%s.yaml", p.GetName()), p); err != nil {
+			return err
+		}
+	}
+
+	var events []v12.Event
+	for _, involvedObject := range involvedObjects {
+		eventList, err := s.kubeClient.CoreV1().Events(w.GetNamespace()).List(ctx, v1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.name", involvedObject).String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		events = append(events, eventList.Items...)
+	}
+
+	if err := b.writeYaml("events.yaml", events); err != nil {
+		return err
+	}
+
+	if err := s.writePropellerLogs(ctx, b, w.GetName()); err != nil {
+		return err
+	}
+
+	for _, configMapName := range s.propellerConfigMaps {
+		configMap, err := s.kubeClient.CoreV1().ConfigMaps(s.propellerNamespace).Get(ctx, configMapName, v1.GetOptions{})
+		if err != nil {
+			// The config of propeller is nice to have, it may well be stored elsewhere.
+			fmt.Printf("Skipping config map [%s/%s]. Error: %v\n", s.propellerNamespace, configMapName, err)
+			continue
+		}
+
+		if err := b.writeYaml(fmt.Sprintf("config/%s.yaml", configMapName), configMap.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Writes the lines of the recent logs of the propeller pods that mention the workflow, one file per pod.
+func (s *SupportBundleOpts) writePropellerLogs(ctx context.Context, b *bundleWriter, name string) error {
+	pods, err := s.kubeClient.CoreV1().Pods(s.propellerNamespace).List(ctx, v1.ListOptions{
+		LabelSelector: s.propellerSelector,
+	})
+	if err != nil {
+		return err
+	}
+
+	sinceSeconds := int64(s.logsSince.Seconds())
+	for _, p := range pods.Items {
+		stream, err := s.kubeClient.CoreV1().Pods(s.propellerNamespace).GetLogs(p.GetName(), &v12.PodLogOptions{
+			SinceSeconds: &sinceSeconds,
+		}).Stream(ctx)
+		if err != nil {
+			return err
+		}
+
+		filtered := &bytes.Buffer{}
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if strings.Contains(scanner.Text(), name) {
+				filtered.WriteString(scanner.Text())
+				filtered.WriteString("\n")
+			}
+		}
+
+		err = scanner.Err()
+		stream.Close()
+		if err != nil {
+			return err
+		}
+
+		if err := b.writeFile(fmt.Sprintf("logs/%s.log", p.GetName()), filtered.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+)
+
+func readBundle(t *testing.T, r io.Reader) map[string]string {
+	gr, err := gzip.NewReader(r)
+	assert.NoError(t, err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+
+		assert.NoError(t, err)
+		content, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+		files[h.Name] = string(content)
+	}
+}
+
+func TestSupportBundleOpts_writeSupportBundle(t *testing.T) {
+	ctx := context.TODO()
+	w := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "exec-1",
+			Namespace: "project-development",
+			Labels:    map[string]string{k8s.ExecutionIDLabel: "exec-1"},
+		},
+	}
+
+	kubeClient := kubeFake.NewSimpleClientset(
+		&v12.Pod{ObjectMeta: v1.ObjectMeta{
+			Name:      "exec-1-n0-0",
+			Namespace: "project-development",
+			Labels:    map[string]string{k8s.ExecutionIDLabel: "exec-1"},
+		}},
+		&v12.Pod{ObjectMeta: v1.ObjectMeta{
+			Name:      "exec-2-n0-0",
+			Namespace: "project-development",
+			Labels:    map[string]string{k8s.ExecutionIDLabel: "exec-2"},
+		}},
+		&v12.Pod{ObjectMeta: v1.ObjectMeta{
+			Name:      "flytepropeller-0",
+			Namespace: "flyte",
+			Labels:    map[string]string{"app": "flytepropeller"},
+		}},
+		&v12.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{Name: "flyte-propeller-config", Namespace: "flyte"},
+			Data:       map[string]string{"core.yaml": "propeller: {}"},
+		},
+	)
+
+	opts := &SupportBundleOpts{
+		RootOptions: &RootOptions{
+			ConfigOverrides: &clientcmd.ConfigOverrides{},
+			kubeClient:      kubeClient,
+			flyteClient:     fake.NewSimpleClientset(w),
+		},
+		propellerNamespace:  "flyte",
+		propellerSelector:   "app=flytepropeller",
+		propellerConfigMaps: []string{"flyte-propeller-config", "missing-config"},
+	}
+
+	t.Run("bundle", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, opts.writeSupportBundle(ctx, "project-development/exec-1", out))
+
+		files := readBundle(t, out)
+		assert.Contains(t, files, "workflow.yaml")
+		assert.Contains(t, files["workflow.yaml"], "exec-1")
+		assert.Contains(t, files, "pods/exec-1-n0-0.yaml")
+		assert.NotContains(t, files, "pods/exec-2-n0-0.yaml")
+		assert.Contains(t, files, "events.yaml")
+		assert.Contains(t, files, "logs/flytepropeller-0.log")
+		assert.Contains(t, files["config/flyte-propeller-config.yaml"], "propeller: {}")
+		assert.NotContains(t, files, "config/missing-config.yaml")
+	})
+
+	t.Run("workflow not found", func(t *testing.T) {
+		assert.Error(t, opts.writeSupportBundle(ctx, "project-development/exec-3", &bytes.Buffer{}))
+	})
+}
+
+func TestSupportBundleOpts_writeSupportBundle_OffloadedNodeStatus(t *testing.T) {
+	ctx := context.TODO()
+	w := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Name: "exec-1", Namespace: "project-development"},
+		Status: v1alpha1.WorkflowStatus{
+			NodeStatus:    map[v1alpha1.NodeID]*v1alpha1.NodeStatus{"n0": {Phase: v1alpha1.NodePhaseFailed}},
+			NodeStatusRef: "s3://bucket/metadata/exec-1/node-status/1",
+		},
+	}
+
+	opts := &SupportBundleOpts{
+		RootOptions: &RootOptions{
+			ConfigOverrides: &clientcmd.ConfigOverrides{},
+			kubeClient:      kubeFake.NewSimpleClientset(),
+			flyteClient:     fake.NewSimpleClientset(w),
+		},
+	}
+
+	t.Run("no propeller config", func(t *testing.T) {
+		assert.Error(t, opts.writeSupportBundle(ctx, "project-development/exec-1", &bytes.Buffer{}))
+	})
+
+	t.Run("hydrated", func(t *testing.T) {
+		store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		raw, err := json.Marshal(map[v1alpha1.NodeID]*v1alpha1.NodeStatus{"n0": {Phase: v1alpha1.NodePhaseFailed, Message: "oom-killed"}})
+		assert.NoError(t, err)
+		assert.NoError(t, store.WriteRaw(ctx, w.Status.NodeStatusRef, int64(len(raw)), storage.Options{}, bytes.NewReader(raw)))
+		opts.dataStore = store
+
+		out := &bytes.Buffer{}
+		assert.NoError(t, opts.writeSupportBundle(ctx, "project-development/exec-1", out))
+		assert.Contains(t, readBundle(t, out)["workflow.yaml"], "oom-killed")
+	})
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -16,3 +17,13 @@ func requiredFlags(cmd *cobra.Command, flags ...string) error {
 
 	return nil
 }
+
+// Splits a workflow name of the form [namespace/]name, defaulting to the given namespace.
+func splitWorkflowName(name, defaultNamespace string) (namespace, workflowName string) {
+	parts := strings.Split(name, "/")
+	if len(parts) > 1 {
+		return parts[0], parts[1]
+	}
+
+	return defaultNamespace, name
+}
@@ -9,8 +9,14 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	outputDot     = "dot"
+	outputMermaid = "mermaid"
+)
+
 type VisualizeOpts struct {
 	*RootOptions
+	output string
 }
 
 func NewVisualizeCommand(opts *RootOptions) *cobra.Command {
@@ -21,9 +27,10 @@ func NewVisualizeCommand(opts *RootOptions) *cobra.Command {
 
 	visualizeCmd := &cobra.Command{
 		Use:   "visualize <workflow_name>",
-		Short: "Get GraphViz dot-formatted output.",
-		Long:  `Generates GraphViz dot-formatted output for the workflow.`,
-		Args:  cobra.ExactArgs(1),
+		Short: "Get GraphViz dot-formatted or Mermaid output.",
+		Long: `Generates GraphViz dot-formatted or Mermaid output for the workflow, with the nodes color-coded by their
+current phase.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 			w, err := vizOpts.flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(vizOpts.ConfigOverrides.Context.Namespace).Get(context.TODO(), name, v1.GetOptions{})
@@ -31,10 +38,25 @@ func NewVisualizeCommand(opts *RootOptions) *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("Dot-formatted: %v\n", visualize.WorkflowToGraphViz(w))
+			w, err = vizOpts.hydrateWorkflow(context.TODO(), w)
+			if err != nil {
+				return err
+			}
+
+			switch vizOpts.output {
+			case outputDot:
+				fmt.Printf("Dot-formatted: %v\n", visualize.WorkflowToGraphViz(w))
+			case outputMermaid:
+				fmt.Print(visualize.WorkflowToMermaid(w))
+			default:
+				return fmt.Errorf("unsupported output format [%v]. Supported formats: %v (default), %v", vizOpts.output,
+					outputDot, outputMermaid)
+			}
+
 			return nil
 		},
 	}
 
+	visualizeCmd.Flags().StringVarP(&vizOpts.output, "output", "o", outputDot, "Format of the output. Supported formats: dot (default), mermaid")
 	return visualizeCmd
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/flyteorg/flytepropeller/manager"
 	managerConfig "github.com/flyteorg/flytepropeller/manager/config"
+	clientset "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
 	propellerConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
 	"github.com/flyteorg/flytepropeller/pkg/signals"
 	"github.com/flyteorg/flytepropeller/pkg/utils"
@@ -144,11 +145,16 @@ func executeRootCmd(propellerCfg *propellerConfig.Config, cfg *managerConfig.Con
 	ctx := signals.SetupSignalHandler(baseCtx)
 
 	// lookup owner reference
-	kubeClient, _, err := utils.GetKubeConfig(ctx, propellerCfg)
+	kubeClient, kubecfg, err := utils.GetKubeConfig(ctx, propellerCfg)
 	if err != nil {
 		logger.Fatalf(ctx, "error building kubernetes clientset [%v]", err)
 	}
 
+	flyteworkflowClient, err := clientset.NewForConfig(kubecfg)
+	if err != nil {
+		logger.Fatalf(ctx, "error building flyteworkflow clientset [%v]", err)
+	}
+
 	ownerReferences := make([]metav1.OwnerReference, 0)
 	lookupOwnerReferences := true
 	podName, found := os.LookupEnv(podNameEnvVar)
@@ -189,7 +195,7 @@ func executeRootCmd(propellerCfg *propellerConfig.Config, cfg *managerConfig.Con
 		}
 	}()
 
-	m, err := manager.New(ctx, propellerCfg, cfg, podNamespace, ownerReferences, kubeClient, scope)
+	m, err := manager.New(ctx, propellerCfg, cfg, podNamespace, ownerReferences, kubeClient, flyteworkflowClient, scope)
 	if err != nil {
 		logger.Fatalf(ctx, "failed to start manager [%v]", err)
 	} else if m == nil {
@@ -43,6 +43,12 @@ func NewAdminEventSink(ctx context.Context, adminClient service.AdminServiceClie
 func (s *adminEventSink) Sink(ctx context.Context, message proto.Message) error {
 	logger.Debugf(ctx, "AdminEventSink received a new event %s", message.String())
 
+	// FlyteAdmin has no API for the progress of workflows or for paused launch plans.
+	switch message.(type) {
+	case *WorkflowProgressEvent, *LaunchPlanPausedEvent:
+		return nil
+	}
+
 	// Short-circuit if event has already been sent
 	id, err := IDFromMessage(message)
 	if err != nil {
@@ -124,8 +130,7 @@ func IDFromMessage(message proto.Message) ([]byte, error) {
 	return []byte(id), nil
 }
 
-func initializeAdminClientFromConfig(ctx context.Context) (client service.AdminServiceClient, err error) {
-	cfg := admin2.GetConfig(ctx)
+func initializeAdminClientFromConfig(ctx context.Context, cfg *admin2.Config) (client service.AdminServiceClient, err error) {
 	clients, err := admin2.NewClientsetBuilder().WithConfig(cfg).Build(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize clientset. Error: %w", err)
@@ -134,14 +139,14 @@ func initializeAdminClientFromConfig(ctx context.Context) (client service.AdminS
 	return clients.AdminClient(), nil
 }
 
-func ConstructEventSink(ctx context.Context, config *Config, scope promutils.Scope) (EventSink, error) {
-	switch config.Type {
+func constructSink(ctx context.Context, sinkType EventReportingType, filePath string, adminCfg *admin2.Config, config *Config, scope promutils.Scope) (EventSink, error) {
+	switch sinkType {
 	case EventSinkLog:
 		return NewLogSink()
 	case EventSinkFile:
-		return NewFileSink(config.FilePath)
+		return NewFileSink(filePath)
 	case EventSinkAdmin:
-		adminClient, err := initializeAdminClientFromConfig(ctx)
+		adminClient, err := initializeAdminClientFromConfig(ctx, adminCfg)
 		if err != nil {
 			return nil, err
 		}
@@ -156,3 +161,30 @@ func ConstructEventSink(ctx context.Context, config *Config, scope promutils.Sco
 		return NewStdoutSink()
 	}
 }
+
+func ConstructEventSink(ctx context.Context, config *Config, scope promutils.Scope) (EventSink, error) {
+	sink, err := constructSink(ctx, config.Type, config.FilePath, admin2.GetConfig(ctx), config, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Buffer.Enabled {
+		sink, err = NewBufferedEventSink(ctx, sink, config.Buffer, scope.NewSubScope("buffer"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.Routes) > 0 {
+		sink, err = NewRoutingEventSink(ctx, sink, config, scope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.Sinks) == 0 {
+		return sink, nil
+	}
+
+	return NewFanoutEventSink(ctx, sink, config, scope.NewSubScope("fanout"))
+}
@@ -0,0 +1,309 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytepropeller/events/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const bufferedEventFileSuffix = ".json"
+
+// diskEventQueue is a FIFO queue of events that is persisted in a directory, one file per event. Files are named after
+// the sequence number of the event, so that the order of the events can be restored when the queue is reopened.
+type diskEventQueue struct {
+	dir  string
+	seqs []uint64
+	next uint64
+}
+
+func (q *diskEventQueue) path(seq uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", seq, bufferedEventFileSuffix))
+}
+
+func (q *diskEventQueue) Len() int {
+	return len(q.seqs)
+}
+
+// Appends the event to the end of the queue. The event is written to a temporary file first, so that partially written
+// events are never read back.
+func (q *diskEventQueue) Push(message proto.Message) error {
+	envelope, err := marshalEventEnvelope(message)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	path := q.path(q.next)
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, raw, 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	q.seqs = append(q.seqs, q.next)
+	q.next++
+	return nil
+}
+
+// Reads the event at the head of the queue, without removing it.
+func (q *diskEventQueue) Peek() (proto.Message, error) {
+	if len(q.seqs) == 0 {
+		return nil, fmt.Errorf("queue is empty")
+	}
+
+	raw, err := ioutil.ReadFile(q.path(q.seqs[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &eventEnvelope{}
+	if err := json.Unmarshal(raw, envelope); err != nil {
+		return nil, err
+	}
+
+	return unmarshalEventEnvelope(envelope)
+}
+
+// Removes the event at the head of the queue.
+func (q *diskEventQueue) Pop() error {
+	if len(q.seqs) == 0 {
+		return nil
+	}
+
+	if err := os.Remove(q.path(q.seqs[0])); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	q.seqs = q.seqs[1:]
+	return nil
+}
+
+// Opens the queue persisted in the directory, creating the directory if it does not exist yet.
+func openDiskEventQueue(dir string) (*diskEventQueue, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &diskEventQueue{dir: dir}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), bufferedEventFileSuffix) {
+			continue
+		}
+
+		seq, err := strconv.ParseUint(strings.TrimSuffix(f.Name(), bufferedEventFileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		q.seqs = append(q.seqs, seq)
+	}
+
+	sort.Slice(q.seqs, func(i, j int) bool { return q.seqs[i] < q.seqs[j] })
+	if len(q.seqs) > 0 {
+		q.next = q.seqs[len(q.seqs)-1] + 1
+	}
+
+	return q, nil
+}
+
+type bufferedEventSinkMetrics struct {
+	Backlog  prometheus.Gauge
+	Buffered prometheus.Counter
+	Replayed prometheus.Counter
+	Dropped  prometheus.Counter
+	Full     prometheus.Counter
+}
+
+// bufferedEventSink buffers events on disk while the EventSink is unavailable, instead of failing to record them, and
+// replays them in order in the background once the EventSink is available again. Events recorded while there is a
+// backlog are buffered as well, so that they are never sent ahead of events recorded earlier.
+type bufferedEventSink struct {
+	sink      EventSink
+	queue     *diskEventQueue
+	maxEvents int
+	interval  time.Duration
+	metrics   bufferedEventSinkMetrics
+	lock      sync.Mutex
+	closed    bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// Buffers the event, the lock must be held by the caller.
+func (s *bufferedEventSink) buffer(ctx context.Context, message proto.Message) error {
+	if s.queue.Len() >= s.maxEvents {
+		s.metrics.Full.Inc()
+		return fmt.Errorf("event buffer is full, [%d] events are buffered", s.queue.Len())
+	}
+
+	if err := s.queue.Push(message); err != nil {
+		return pkgerrors.Wrap(err, "failed to buffer event")
+	}
+
+	s.metrics.Buffered.Inc()
+	s.metrics.Backlog.Set(float64(s.queue.Len()))
+	logger.Debugf(ctx, "Buffered event, [%d] events are buffered", s.queue.Len())
+	return nil
+}
+
+func (s *bufferedEventSink) Sink(ctx context.Context, message proto.Message) error {
+	s.lock.Lock()
+	if s.queue.Len() > 0 {
+		defer s.lock.Unlock()
+		return s.buffer(ctx, message)
+	}
+	s.lock.Unlock()
+
+	err := s.sink.Sink(ctx, message)
+	if !errors.IsEventSinkError(err) {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if bufferErr := s.buffer(ctx, message); bufferErr != nil {
+		logger.Warnf(ctx, "Failed to buffer event after the EventSink failed with [%v]. Error: %v", err, bufferErr)
+		return err
+	}
+
+	logger.Warnf(ctx, "EventSink is unavailable, buffering events until it is available again. Error: %v", err)
+	return nil
+}
+
+// Sends buffered events in order until the backlog is empty or the EventSink is unavailable. Events that are rejected
+// by the EventSink are dropped, as sending them again will not succeed either.
+func (s *bufferedEventSink) replay(ctx context.Context) {
+	for {
+		s.lock.Lock()
+		if s.queue.Len() == 0 {
+			s.lock.Unlock()
+			return
+		}
+
+		// Only replay removes events from the queue, so the head remains the same while the lock is not held.
+		message, err := s.queue.Peek()
+		s.lock.Unlock()
+
+		if err != nil {
+			logger.Errorf(ctx, "Failed to read buffered event, dropping it. Error: %v", err)
+			s.metrics.Dropped.Inc()
+		} else {
+			err = s.sink.Sink(ctx, message)
+			switch {
+			case errors.IsEventSinkError(err) || errors.IsResourceExhausted(err):
+				logger.Infof(ctx, "Failed to replay buffered event, retrying later. Error: %v", err)
+				return
+			case err == nil || errors.IsAlreadyExists(err):
+				s.metrics.Replayed.Inc()
+			default:
+				logger.Warnf(ctx, "Buffered event was rejected, dropping it. Error: %v", err)
+				s.metrics.Dropped.Inc()
+			}
+		}
+
+		s.lock.Lock()
+		err = s.queue.Pop()
+		s.metrics.Backlog.Set(float64(s.queue.Len()))
+		s.lock.Unlock()
+		if err != nil {
+			logger.Errorf(ctx, "Failed to remove buffered event. Error: %v", err)
+			return
+		}
+	}
+}
+
+func (s *bufferedEventSink) run(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.replay(ctx)
+		}
+	}
+}
+
+// Stops replaying events. Events that have not been replayed yet remain buffered on disk, and are replayed once the
+// EventSink is constructed again from the same directory.
+func (s *bufferedEventSink) Close() error {
+	s.lock.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+	}
+	s.lock.Unlock()
+
+	<-s.done
+	return s.sink.Close()
+}
+
+// NewBufferedEventSink wraps the EventSink to buffer events on disk while it is unavailable, and to replay them once it
+// is available again.
+func NewBufferedEventSink(ctx context.Context, sink EventSink, cfg BufferConfig, scope promutils.Scope) (EventSink, error) {
+	if cfg.MaxEvents <= 0 {
+		return nil, fmt.Errorf("max-events of the event buffer must be positive")
+	}
+
+	if cfg.ReplayInterval.Duration <= 0 {
+		return nil, fmt.Errorf("replay-interval of the event buffer must be positive")
+	}
+
+	queue, err := openDiskEventQueue(cfg.Path)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to open event buffer [%v]", cfg.Path)
+	}
+
+	s := &bufferedEventSink{
+		sink:      sink,
+		queue:     queue,
+		maxEvents: cfg.MaxEvents,
+		interval:  cfg.ReplayInterval.Duration,
+		metrics: bufferedEventSinkMetrics{
+			Backlog:  scope.MustNewGauge("backlog", "Number of buffered events waiting to be replayed"),
+			Buffered: scope.MustNewCounter("buffered", "Number of events buffered because the EventSink was unavailable"),
+			Replayed: scope.MustNewCounter("replayed", "Number of buffered events replayed to the EventSink"),
+			Dropped:  scope.MustNewCounter("dropped", "Number of buffered events dropped because they were rejected or unreadable"),
+			Full:     scope.MustNewCounter("full", "Number of events that could not be buffered because the buffer was full"),
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	s.metrics.Backlog.Set(float64(queue.Len()))
+	if queue.Len() > 0 {
+		logger.Infof(ctx, "Found [%d] buffered events in [%v], replaying them", queue.Len(), cfg.Path)
+	}
+
+	go s.run(ctx)
+	return s, nil
+}
@@ -0,0 +1,204 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytepropeller/events/errors"
+	"github.com/flyteorg/flytepropeller/events/mocks"
+)
+
+var errUnavailable = &errors.EventError{Code: errors.EventSinkError, Cause: fmt.Errorf("unavailable"), Message: "Error sending event"}
+
+func newNamedWorkflowEvent(name string) *event.WorkflowExecutionEvent {
+	return &event.WorkflowExecutionEvent{
+		ExecutionId: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: name},
+	}
+}
+
+func newTestBufferedEventSink(t *testing.T, sink EventSink, dir string, maxEvents int) *bufferedEventSink {
+	s, err := NewBufferedEventSink(context.TODO(), sink, BufferConfig{
+		Path:      dir,
+		MaxEvents: maxEvents,
+		// Events are replayed explicitly by the tests.
+		ReplayInterval: config.Duration{Duration: time.Hour},
+	}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return s.(*bufferedEventSink)
+}
+
+func TestDiskEventQueue(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openDiskEventQueue(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, q.Len())
+	_, err = q.Peek()
+	assert.Error(t, err)
+
+	assert.NoError(t, q.Push(newNamedWorkflowEvent("a")))
+	assert.NoError(t, q.Push(newNamedWorkflowEvent("b")))
+	assert.NoError(t, q.Pop())
+	assert.NoError(t, q.Push(newNamedWorkflowEvent("c")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "unrelated"), []byte{}, 0600))
+
+	// The order of the events is restored when the queue is reopened.
+	q, err = openDiskEventQueue(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, q.Len())
+	for _, name := range []string{"b", "c"} {
+		message, err := q.Peek()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(newNamedWorkflowEvent(name), message))
+		assert.NoError(t, q.Pop())
+	}
+
+	assert.Equal(t, 0, q.Len())
+	assert.NoError(t, q.Push(newNamedWorkflowEvent("d")))
+	assert.Equal(t, filepath.Join(dir, "00000000000000000003.json"), q.path(q.seqs[0]))
+}
+
+func TestBufferedEventSink_Sink(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("available", func(t *testing.T) {
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, t.TempDir(), 10)
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("a")))
+		assert.Equal(t, 0, s.queue.Len())
+		assert.NoError(t, s.Close())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(&errors.EventError{
+			Code: errors.AlreadyExists, Cause: fmt.Errorf("exists"), Message: "exists"})
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, t.TempDir(), 10)
+		assert.True(t, errors.IsAlreadyExists(s.Sink(ctx, newNamedWorkflowEvent("a"))))
+		assert.Equal(t, 0, s.queue.Len())
+		assert.NoError(t, s.Close())
+	})
+
+	t.Run("buffered and replayed in order", func(t *testing.T) {
+		var replayed []string
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(errUnavailable).Once()
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			replayed = append(replayed, args.Get(1).(*event.WorkflowExecutionEvent).ExecutionId.Name)
+		}).Return(nil)
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, t.TempDir(), 10)
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("a")))
+		// Events are buffered while there is a backlog, even though the EventSink is available again.
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("b")))
+		sink.AssertNumberOfCalls(t, "Sink", 1)
+		assert.Equal(t, float64(2), testutil.ToFloat64(s.metrics.Backlog))
+
+		s.replay(ctx)
+		assert.Equal(t, []string{"a", "b"}, replayed)
+		assert.Equal(t, 0, s.queue.Len())
+		assert.Equal(t, float64(0), testutil.ToFloat64(s.metrics.Backlog))
+		assert.Equal(t, float64(2), testutil.ToFloat64(s.metrics.Replayed))
+
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("c")))
+		assert.Equal(t, []string{"a", "b", "c"}, replayed)
+		assert.NoError(t, s.Close())
+	})
+
+	t.Run("full", func(t *testing.T) {
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(errUnavailable)
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, t.TempDir(), 1)
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("a")))
+		assert.Error(t, s.Sink(ctx, newNamedWorkflowEvent("b")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.Full))
+		assert.NoError(t, s.Close())
+	})
+}
+
+func TestBufferedEventSink_Replay(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("unavailable", func(t *testing.T) {
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(errUnavailable)
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, t.TempDir(), 10)
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("a")))
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("b")))
+		s.replay(ctx)
+		sink.AssertNumberOfCalls(t, "Sink", 2)
+		assert.Equal(t, 2, s.queue.Len())
+		assert.NoError(t, s.Close())
+	})
+
+	t.Run("rejected events are dropped", func(t *testing.T) {
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(errUnavailable).Once()
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(&errors.EventError{
+			Code: errors.InvalidArgument, Cause: fmt.Errorf("invalid"), Message: "invalid"}).Once()
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, t.TempDir(), 10)
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("a")))
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("b")))
+		s.replay(ctx)
+		assert.Equal(t, 0, s.queue.Len())
+		assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.Dropped))
+		assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.Replayed))
+		assert.NoError(t, s.Close())
+	})
+
+	t.Run("survives restarts", func(t *testing.T) {
+		dir := t.TempDir()
+		sink := &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(errUnavailable)
+		sink.OnClose().Return(nil)
+
+		s := newTestBufferedEventSink(t, sink, dir, 10)
+		assert.NoError(t, s.Sink(ctx, newNamedWorkflowEvent("a")))
+		assert.NoError(t, s.Close())
+
+		sink = &mocks.EventSink{}
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		sink.OnClose().Return(nil)
+		s = newTestBufferedEventSink(t, sink, dir, 10)
+		assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.Backlog))
+		s.replay(ctx)
+		sink.AssertNumberOfCalls(t, "Sink", 1)
+		assert.Equal(t, 0, s.queue.Len())
+		assert.NoError(t, s.Close())
+	})
+}
+
+func TestNewBufferedEventSink(t *testing.T) {
+	ctx := context.TODO()
+	sink := &mocks.EventSink{}
+	_, err := NewBufferedEventSink(ctx, sink, BufferConfig{Path: t.TempDir(), ReplayInterval: config.Duration{Duration: time.Second}},
+		promutils.NewTestScope())
+	assert.Error(t, err)
+	_, err = NewBufferedEventSink(ctx, sink, BufferConfig{Path: t.TempDir(), MaxEvents: 1}, promutils.NewTestScope())
+	assert.Error(t, err)
+}
@@ -2,7 +2,9 @@ package events
 
 import (
 	"context"
+	"time"
 
+	"github.com/flyteorg/flyteidl/clients/go/admin"
 	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/logger"
 )
@@ -15,23 +17,112 @@ type EventReportingType = string
 const configSectionKey = "Event"
 
 const (
-	EventSinkLog   EventReportingType = "log"
-	EventSinkFile  EventReportingType = "file"
-	EventSinkAdmin EventReportingType = "admin"
+	EventSinkLog     EventReportingType = "log"
+	EventSinkFile    EventReportingType = "file"
+	EventSinkAdmin   EventReportingType = "admin"
+	EventSinkKafka   EventReportingType = "kafka"
+	EventSinkWebhook EventReportingType = "webhook"
 )
 
 type Config struct {
-	Type     EventReportingType `json:"type" pflag:",Sets the type of EventSink to configure [log/admin/file]."`
-	FilePath string             `json:"file-path" pflag:",For file types, specify where the file should be located."`
-	Rate     int64              `json:"rate" pflag:",Max rate at which events can be recorded per second."`
-	Capacity int                `json:"capacity" pflag:",The max bucket size for event recording tokens."`
+	Type       EventReportingType `json:"type" pflag:",Sets the type of EventSink to configure [log/admin/file]."`
+	FilePath   string             `json:"file-path" pflag:",For file types, specify where the file should be located."`
+	Rate       int64              `json:"rate" pflag:",Max rate at which events can be recorded per second."`
+	Capacity   int                `json:"capacity" pflag:",The max bucket size for event recording tokens."`
+	RouteLabel string             `json:"route-label" pflag:",Execution label used to select a route by name, takes precedence over project/domain matching."`
+	Routes     []RouteConfig      `json:"routes" pflag:"-,Routes events of matching executions to other destinations."`
+	Sinks      []SinkConfig       `json:"sinks" pflag:"-,Additional EventSinks all events are fanned out to, besides the EventSink of the execution."`
+	Buffer     BufferConfig       `json:"buffer"`
 }
 
+// BufferConfig configures buffering events on disk while the EventSink is unavailable. Buffered events are replayed in
+// the order they were recorded once the EventSink is available again. While events are buffered, new events are
+// buffered too, so that they are not sent before the events recorded earlier.
+type BufferConfig struct {
+	Enabled        bool            `json:"enabled" pflag:",Buffers events on disk while the EventSink is unavailable and replays them once it is available again."`
+	Path           string          `json:"path" pflag:",Directory events are buffered in. Should be backed by a persistent volume for buffered events to survive restarts."`
+	MaxEvents      int             `json:"max-events" pflag:",Max number of buffered events, events fail to be recorded once the buffer is full."`
+	ReplayInterval config.Duration `json:"replay-interval" pflag:",Interval at which replaying buffered events is attempted."`
+}
+
+// RouteConfig sends the events of matching executions to a different destination than the default EventSink. An
+// execution matches a route if its route label is set to the name of the route, or if both its project and domain
+// are matched. An empty project or domain list matches all projects or domains, routes with neither can only be
+// selected through the route label.
+type RouteConfig struct {
+	Name     string             `json:"name"`
+	Projects []string           `json:"projects,omitempty"`
+	Domains  []string           `json:"domains,omitempty"`
+	Type     EventReportingType `json:"type"`
+	FilePath string             `json:"file-path,omitempty"`
+	// Admin configures the client of admin routes, the global admin client config is used if not set.
+	Admin *admin.Config `json:"admin,omitempty"`
+	// Shadow routes receive a copy of the events, which are still sent to the default EventSink. Failures to send to
+	// shadow routes are logged and otherwise ignored.
+	Shadow bool `json:"shadow,omitempty"`
+}
+
+// SinkConfig configures an additional EventSink that receives a copy of all events. Events are sent to additional
+// EventSinks asynchronously, through a queue per EventSink that is retried independently, so that slow or unavailable
+// external systems do not block the execution of workflows. Events are dropped when the queue of an EventSink is full.
+type SinkConfig struct {
+	Name     string             `json:"name"`
+	Type     EventReportingType `json:"type"`
+	FilePath string             `json:"file-path,omitempty"`
+	// Admin configures the client of admin sinks, the global admin client config is used if not set.
+	Admin   *admin.Config `json:"admin,omitempty"`
+	Kafka   KafkaConfig   `json:"kafka,omitempty"`
+	Webhook WebhookConfig `json:"webhook,omitempty"`
+	// QueueSize is the number of events that may wait to be sent to the EventSink, defaults to 1000.
+	QueueSize int         `json:"queue-size,omitempty"`
+	Retry     RetryConfig `json:"retry,omitempty"`
+}
+
+// KafkaConfig configures an EventSink that produces events to a Kafka topic through the Kafka REST Proxy. Events are
+// keyed by their execution, so that the events of an execution end up in the same partition, in order.
+type KafkaConfig struct {
+	// The URL of the Kafka REST Proxy, e.g. http://kafka-rest-proxy:8082
+	RESTProxyURL string            `json:"rest-proxy-url"`
+	Topic        string            `json:"topic"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Timeout      config.Duration   `json:"timeout,omitempty"`
+}
+
+// WebhookConfig configures an EventSink that posts events as JSON to an HTTP endpoint.
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Timeout config.Duration   `json:"timeout,omitempty"`
+}
+
+// RetryConfig configures the exponential backoff sending an event to an additional EventSink is retried with.
+type RetryConfig struct {
+	// MaxAttempts is the number of times sending an event is attempted before it is dropped, defaults to 5.
+	MaxAttempts int `json:"max-attempts,omitempty"`
+	// BaseDelay is the delay before the first retry, defaults to 100ms. The delay doubles with every retry.
+	BaseDelay config.Duration `json:"base-delay,omitempty"`
+	// MaxDelay caps the delay between retries, defaults to 10s.
+	MaxDelay config.Duration `json:"max-delay,omitempty"`
+}
+
+const (
+	defaultSinkQueueSize        = 1000
+	defaultSinkRetryMaxAttempts = 5
+	defaultSinkRetryBaseDelay   = 100 * time.Millisecond
+	defaultSinkRetryMaxDelay    = 10 * time.Second
+	defaultSinkHTTPTimeout      = 10 * time.Second
+)
+
 var (
 	defaultConfig = Config{
 		Rate:     int64(500),
 		Capacity: 1000,
 		Type:     EventSinkAdmin,
+		Buffer: BufferConfig{
+			Path:           "/var/flyte/events",
+			MaxEvents:      100000,
+			ReplayInterval: config.Duration{Duration: 5 * time.Second},
+		},
 	}
 
 	configSection = config.MustRegisterSection(configSectionKey, &defaultConfig)
@@ -54,5 +54,10 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "file-path"), defaultConfig.FilePath, "For file types,  specify where the file should be located.")
 	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "rate"), defaultConfig.Rate, "Max rate at which events can be recorded per second.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "capacity"), defaultConfig.Capacity, "The max bucket size for event recording tokens.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "route-label"), defaultConfig.RouteLabel, "Execution label used to select a route by name, takes precedence over project/domain matching.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "buffer.enabled"), defaultConfig.Buffer.Enabled, "Buffers events on disk while the EventSink is unavailable and replays them once it is available again.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "buffer.path"), defaultConfig.Buffer.Path, "Directory events are buffered in. Should be backed by a persistent volume for buffered events to survive restarts.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "buffer.max-events"), defaultConfig.Buffer.MaxEvents, "Max number of buffered events, events fail to be recorded once the buffer is full.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "buffer.replay-interval"), defaultConfig.Buffer.ReplayInterval.String(), "Interval at which replaying buffered events is attempted.")
 	return cmdFlags
 }
@@ -155,4 +155,74 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_route-label", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("route-label", testValue)
+			if vString, err := cmdFlags.GetString("route-label"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.RouteLabel)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_buffer.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("buffer.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("buffer.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Buffer.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_buffer.path", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("buffer.path", testValue)
+			if vString, err := cmdFlags.GetString("buffer.path"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Buffer.Path)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_buffer.max-events", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("buffer.max-events", testValue)
+			if vInt, err := cmdFlags.GetInt("buffer.max-events"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.Buffer.MaxEvents)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_buffer.replay-interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Buffer.ReplayInterval.String()
+
+			cmdFlags.Set("buffer.replay-interval", testValue)
+			if vString, err := cmdFlags.GetString("buffer.replay-interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Buffer.ReplayInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
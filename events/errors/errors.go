@@ -124,6 +124,11 @@ func IsResourceExhausted(err error) bool {
 	return errors.Is(err, &EventError{Code: ResourceExhausted})
 }
 
+// Checks if the error is of type EventError and the ErrorCode is of type EventSinkError
+func IsEventSinkError(err error) bool {
+	return errors.Is(err, &EventError{Code: EventSinkError})
+}
+
 // Checks if the error is of type EventError and the ErrorCode is of type TooLarge
 func IsTooLarge(err error) bool {
 	return errors.Is(err, &EventError{Code: TooLarge})
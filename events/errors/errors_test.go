@@ -61,6 +61,7 @@ func TestWrapErrors(t *testing.T) {
 		{"invalidArgs", status.Error(codes.InvalidArgument, "Invalid Arguments"), IsInvalidArguments},
 		{"resourceExhausted", status.Error(codes.ResourceExhausted, "Limit Exceeded"), IsResourceExhausted},
 		{"uncaughtError", status.Error(codes.Unknown, "Unknown Err"), isEventError},
+		{"unavailable", status.Error(codes.Unavailable, "Unavailable"), IsEventSinkError},
 		{"uncaughtError", fmt.Errorf("Random err"), isUnknownError},
 		{"errorWithReason", createTestErrorWithReason(), IsEventAlreadyInTerminalStateError},
 		{"incompatibleCluster", incompatibleClusterErr.Err(), IsEventIncompatibleClusterError},
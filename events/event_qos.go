@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+const eventQoSContextKey contextKey = "event_qos"
+
+// WithEventQoS returns a context carrying the event QoS of the execution that events are recorded for. It overrides the
+// QoS of the event config, unless it's empty.
+func WithEventQoS(ctx context.Context, qos config.EventQoS) context.Context {
+	return context.WithValue(ctx, eventQoSContextKey, qos)
+}
+
+func eventQoS(ctx context.Context, eventConfig *config.EventConfig) config.EventQoS {
+	if qos, ok := ctx.Value(eventQoSContextKey).(config.EventQoS); ok && len(qos) > 0 {
+		return qos
+	}
+
+	if eventConfig == nil || len(eventConfig.QoS) == 0 {
+		return config.EventQoSAll
+	}
+
+	return eventConfig.QoS
+}
+
+func isTerminalNodePhase(p core.NodeExecution_Phase) bool {
+	return p == core.NodeExecution_ABORTED || p == core.NodeExecution_FAILED || p == core.NodeExecution_SKIPPED ||
+		p == core.NodeExecution_SUCCEEDED || p == core.NodeExecution_TIMED_OUT || p == core.NodeExecution_RECOVERED
+}
+
+func isTerminalTaskPhase(p core.TaskExecution_Phase) bool {
+	return p == core.TaskExecution_ABORTED || p == core.TaskExecution_FAILED || p == core.TaskExecution_SUCCEEDED
+}
+
+// Returns true if the node event is recorded at the event QoS. The events of parent nodes are recorded at the
+// TERMINAL_ONLY QoS whatever their phase, since the events of their child nodes refer to them.
+func shouldRecordNodeEvent(ctx context.Context, ev *event.NodeExecutionEvent, eventConfig *config.EventConfig) bool {
+	switch eventQoS(ctx, eventConfig) {
+	case config.EventQoSNone:
+		return false
+	case config.EventQoSTerminalOnly:
+		return isTerminalNodePhase(ev.GetPhase()) || ev.GetIsParent() || ev.GetIsDynamic()
+	default:
+		return true
+	}
+}
+
+// Returns true if the task event is recorded at the event QoS.
+func shouldRecordTaskEvent(ctx context.Context, ev *event.TaskExecutionEvent, eventConfig *config.EventConfig) bool {
+	switch eventQoS(ctx, eventConfig) {
+	case config.EventQoSNone:
+		return false
+	case config.EventQoSTerminalOnly:
+		return isTerminalTaskPhase(ev.GetPhase())
+	default:
+		return true
+	}
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytepropeller/events/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestShouldRecordNodeEvent(t *testing.T) {
+	ctx := context.TODO()
+	running := &event.NodeExecutionEvent{Phase: core.NodeExecution_RUNNING}
+	succeeded := &event.NodeExecutionEvent{Phase: core.NodeExecution_SUCCEEDED}
+	runningParent := &event.NodeExecutionEvent{Phase: core.NodeExecution_RUNNING, IsParent: true}
+
+	assert.True(t, shouldRecordNodeEvent(ctx, running, &config.EventConfig{}))
+	assert.True(t, shouldRecordNodeEvent(ctx, running, &config.EventConfig{QoS: config.EventQoSAll}))
+
+	terminalOnly := &config.EventConfig{QoS: config.EventQoSTerminalOnly}
+	assert.False(t, shouldRecordNodeEvent(ctx, running, terminalOnly))
+	assert.True(t, shouldRecordNodeEvent(ctx, succeeded, terminalOnly))
+	assert.True(t, shouldRecordNodeEvent(ctx, runningParent, terminalOnly))
+
+	none := &config.EventConfig{QoS: config.EventQoSNone}
+	assert.False(t, shouldRecordNodeEvent(ctx, succeeded, none))
+
+	// The QoS of the execution overrides the one of the event config
+	assert.True(t, shouldRecordNodeEvent(WithEventQoS(ctx, config.EventQoSAll), running, none))
+	assert.False(t, shouldRecordNodeEvent(WithEventQoS(ctx, config.EventQoSNone), running, &config.EventConfig{}))
+	assert.False(t, shouldRecordNodeEvent(WithEventQoS(ctx, ""), running, terminalOnly))
+}
+
+func TestShouldRecordTaskEvent(t *testing.T) {
+	ctx := context.TODO()
+	running := &event.TaskExecutionEvent{Phase: core.TaskExecution_RUNNING}
+	failed := &event.TaskExecutionEvent{Phase: core.TaskExecution_FAILED}
+
+	assert.True(t, shouldRecordTaskEvent(ctx, running, &config.EventConfig{}))
+
+	ctx = WithEventQoS(ctx, config.EventQoSTerminalOnly)
+	assert.False(t, shouldRecordTaskEvent(ctx, running, &config.EventConfig{}))
+	assert.True(t, shouldRecordTaskEvent(ctx, failed, &config.EventConfig{}))
+	assert.False(t, shouldRecordTaskEvent(WithEventQoS(ctx, config.EventQoSNone), failed, &config.EventConfig{}))
+}
+
+func TestRecordNodeEvent_Suppressed(t *testing.T) {
+	ctx := WithEventQoS(context.TODO(), config.EventQoSNone)
+	eventRecorder := &mocks.EventRecorder{}
+	recorder := &nodeEventRecorder{eventRecorder: eventRecorder}
+
+	assert.NoError(t, recorder.RecordNodeEvent(ctx, &event.NodeExecutionEvent{Phase: core.NodeExecution_SUCCEEDED}, referenceEventConfig))
+	eventRecorder.AssertNotCalled(t, "RecordNodeEvent", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,233 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flytepropeller/events/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type asyncEventSinkMetrics struct {
+	Sent    prometheus.Counter
+	Retried prometheus.Counter
+	Failed  prometheus.Counter
+	Dropped prometheus.Counter
+}
+
+// asyncEventSink sends events to an EventSink from a queue in the background, retrying failures with exponential
+// backoff. Sending to the EventSink never blocks the caller, events are dropped instead if the queue is full.
+type asyncEventSink struct {
+	name        string
+	sink        EventSink
+	queue       chan proto.Message
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	metrics     asyncEventSinkMetrics
+	// after is used to wait between retries, and can be replaced in tests.
+	after  func(d time.Duration) <-chan time.Time
+	lock   sync.RWMutex
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// Failures that are caused by the event itself will not go away by sending it again.
+func isRetryableEventError(err error) bool {
+	return !errors.IsAlreadyExists(err) && !errors.IsInvalidArguments(err) && !errors.IsTooLarge(err) &&
+		!errors.IsEventAlreadyInTerminalStateError(err) && !errors.IsEventIncompatibleClusterError(err)
+}
+
+func (s *asyncEventSink) enqueue(ctx context.Context, message proto.Message) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.closed {
+		return
+	}
+
+	// The caller owns the event and may reuse it once Sink returns.
+	select {
+	case s.queue <- proto.Clone(message):
+	default:
+		s.metrics.Dropped.Inc()
+		logger.Warnf(ctx, "Queue of EventSink [%v] is full, dropping event", s.name)
+	}
+}
+
+func (s *asyncEventSink) send(ctx context.Context, message proto.Message) {
+	delay := s.baseDelay
+	for attempt := 1; ; attempt++ {
+		err := s.sink.Sink(ctx, message)
+		if err == nil {
+			s.metrics.Sent.Inc()
+			return
+		}
+
+		if !isRetryableEventError(err) || attempt >= s.maxAttempts {
+			s.metrics.Failed.Inc()
+			logger.Warnf(ctx, "Failed to send event to EventSink [%v] after [%d] attempts. Error: %v", s.name, attempt, err)
+			return
+		}
+
+		s.metrics.Retried.Inc()
+		select {
+		case <-s.after(delay):
+		case <-s.stop:
+			s.metrics.Failed.Inc()
+			logger.Warnf(ctx, "EventSink [%v] closed while retrying event. Error: %v", s.name, err)
+			return
+		}
+
+		delay *= 2
+		if delay > s.maxDelay {
+			delay = s.maxDelay
+		}
+	}
+}
+
+func (s *asyncEventSink) run(ctx context.Context) {
+	defer close(s.done)
+	for message := range s.queue {
+		s.send(ctx, message)
+	}
+}
+
+// Stops accepting events and waits for the queued events to be sent, each of them is attempted at least once.
+func (s *asyncEventSink) Close() error {
+	s.lock.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+		close(s.queue)
+	}
+	s.lock.Unlock()
+
+	<-s.done
+	return s.sink.Close()
+}
+
+func newAsyncEventSink(ctx context.Context, name string, sink EventSink, cfg SinkConfig, scope promutils.Scope) *asyncEventSink {
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultSinkQueueSize
+	}
+
+	maxAttempts := cfg.Retry.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultSinkRetryMaxAttempts
+	}
+
+	baseDelay := cfg.Retry.BaseDelay.Duration
+	if baseDelay <= 0 {
+		baseDelay = defaultSinkRetryBaseDelay
+	}
+
+	maxDelay := cfg.Retry.MaxDelay.Duration
+	if maxDelay <= 0 {
+		maxDelay = defaultSinkRetryMaxDelay
+	}
+
+	s := &asyncEventSink{
+		name:        name,
+		sink:        sink,
+		queue:       make(chan proto.Message, queueSize),
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+		metrics: asyncEventSinkMetrics{
+			Sent:    scope.MustNewCounter("sent", "Number of events sent to the EventSink"),
+			Retried: scope.MustNewCounter("retried", "Number of times sending an event to the EventSink was retried"),
+			Failed:  scope.MustNewCounter("failed", "Number of events that could not be sent to the EventSink"),
+			Dropped: scope.MustNewCounter("dropped", "Number of events dropped because the queue of the EventSink was full"),
+		},
+		after: time.After,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	go s.run(ctx)
+	return s
+}
+
+// fanoutEventSink sends events to the primary EventSink, and a copy of them to every additional EventSink. Only the
+// result of sending to the primary EventSink is returned, additional EventSinks do not affect execution.
+type fanoutEventSink struct {
+	primary EventSink
+	sinks   []*asyncEventSink
+}
+
+func (s *fanoutEventSink) Sink(ctx context.Context, message proto.Message) error {
+	for _, sink := range s.sinks {
+		sink.enqueue(ctx, message)
+	}
+
+	return s.primary.Sink(ctx, message)
+}
+
+func (s *fanoutEventSink) Close() error {
+	err := s.primary.Close()
+	for _, sink := range s.sinks {
+		if closeErr := sink.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+func constructAdditionalSink(ctx context.Context, sinkCfg SinkConfig, config *Config, scope promutils.Scope) (EventSink, error) {
+	switch sinkCfg.Type {
+	case EventSinkKafka:
+		return NewKafkaEventSink(sinkCfg.Kafka)
+	case EventSinkWebhook:
+		return NewWebhookEventSink(sinkCfg.Webhook)
+	default:
+		adminCfg := sinkCfg.Admin
+		if adminCfg == nil {
+			adminCfg = admin.GetConfig(ctx)
+		}
+
+		return constructSink(ctx, sinkCfg.Type, sinkCfg.FilePath, adminCfg, config, scope)
+	}
+}
+
+// NewFanoutEventSink wraps the primary EventSink to send a copy of all events to the configured additional EventSinks.
+// Every additional EventSink has its own queue and retries, so that one slow or unavailable EventSink neither delays
+// the execution of workflows nor the other EventSinks.
+func NewFanoutEventSink(ctx context.Context, primary EventSink, config *Config, scope promutils.Scope) (EventSink, error) {
+	names := sets.NewString()
+	sinks := make([]*asyncEventSink, 0, len(config.Sinks))
+	for _, sinkCfg := range config.Sinks {
+		if len(sinkCfg.Name) == 0 {
+			return nil, fmt.Errorf("additional EventSinks must be named")
+		}
+
+		if names.Has(sinkCfg.Name) {
+			return nil, fmt.Errorf("additional EventSink [%v] is configured more than once", sinkCfg.Name)
+		}
+
+		names.Insert(sinkCfg.Name)
+		sinkScope := scope.NewSubScope(sinkCfg.Name)
+		sink, err := constructAdditionalSink(ctx, sinkCfg, config, sinkScope)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "failed to construct additional EventSink [%v]", sinkCfg.Name)
+		}
+
+		logger.Infof(ctx, "Sending a copy of all events to [%v] EventSink [%v]", sinkCfg.Type, sinkCfg.Name)
+		sinks = append(sinks, newAsyncEventSink(ctx, sinkCfg.Name, sink, sinkCfg, sinkScope))
+	}
+
+	return &fanoutEventSink{
+		primary: primary,
+		sinks:   sinks,
+	}, nil
+}
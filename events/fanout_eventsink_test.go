@@ -0,0 +1,165 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytepropeller/events/errors"
+	"github.com/flyteorg/flytepropeller/events/mocks"
+)
+
+func newTestAsyncEventSink(sink EventSink, cfg SinkConfig) *asyncEventSink {
+	s := newAsyncEventSink(context.TODO(), "test", sink, cfg, promutils.NewTestScope())
+	s.after = func(d time.Duration) <-chan time.Time {
+		c := make(chan time.Time, 1)
+		c <- time.Now()
+		return c
+	}
+
+	return s
+}
+
+func TestFanoutEventSink(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("fan out", func(t *testing.T) {
+		primary := &mocks.EventSink{}
+		primary.OnSinkMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("primary error"))
+		primary.OnClose().Return(nil)
+		additional := &mocks.EventSink{}
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		additional.OnClose().Return(nil)
+
+		async := newTestAsyncEventSink(additional, SinkConfig{})
+		sink := &fanoutEventSink{primary: primary, sinks: []*asyncEventSink{async}}
+
+		// Only the result of the primary EventSink is returned.
+		assert.EqualError(t, sink.Sink(ctx, newWorkflowEvent("p", "d")), "primary error")
+		assert.NoError(t, sink.Close())
+		additional.AssertNumberOfCalls(t, "Sink", 1)
+		assert.Equal(t, float64(1), testutil.ToFloat64(async.metrics.Sent))
+
+		// Events are no longer accepted once closed.
+		assert.EqualError(t, sink.Sink(ctx, newWorkflowEvent("p", "d")), "primary error")
+		additional.AssertNumberOfCalls(t, "Sink", 1)
+	})
+
+	t.Run("retried", func(t *testing.T) {
+		additional := &mocks.EventSink{}
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("unavailable")).Twice()
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Return(nil).Once()
+		additional.OnClose().Return(nil)
+
+		async := newTestAsyncEventSink(additional, SinkConfig{})
+		async.enqueue(ctx, newWorkflowEvent("p", "d"))
+		assert.NoError(t, async.Close())
+		additional.AssertNumberOfCalls(t, "Sink", 3)
+		assert.Equal(t, float64(2), testutil.ToFloat64(async.metrics.Retried))
+		assert.Equal(t, float64(1), testutil.ToFloat64(async.metrics.Sent))
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		additional := &mocks.EventSink{}
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("unavailable"))
+		additional.OnClose().Return(nil)
+
+		async := newTestAsyncEventSink(additional, SinkConfig{Retry: RetryConfig{MaxAttempts: 2}})
+		async.enqueue(ctx, newWorkflowEvent("p", "d"))
+		assert.NoError(t, async.Close())
+		additional.AssertNumberOfCalls(t, "Sink", 2)
+		assert.Equal(t, float64(1), testutil.ToFloat64(async.metrics.Failed))
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		additional := &mocks.EventSink{}
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Return(&errors.EventError{
+			Code: errors.InvalidArgument, Cause: fmt.Errorf("invalid"), Message: "invalid"})
+		additional.OnClose().Return(nil)
+
+		async := newTestAsyncEventSink(additional, SinkConfig{})
+		async.enqueue(ctx, newWorkflowEvent("p", "d"))
+		assert.NoError(t, async.Close())
+		additional.AssertNumberOfCalls(t, "Sink", 1)
+		assert.Equal(t, float64(1), testutil.ToFloat64(async.metrics.Failed))
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		block := make(chan struct{})
+		additional := &mocks.EventSink{}
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			<-block
+		}).Return(nil)
+		additional.OnClose().Return(nil)
+
+		async := newTestAsyncEventSink(additional, SinkConfig{QueueSize: 1})
+		// The first event is taken off the queue and blocks, the second one fills the queue.
+		async.enqueue(ctx, newWorkflowEvent("p", "d"))
+		assert.Eventually(t, func() bool { return len(async.queue) == 0 }, time.Second, time.Millisecond)
+		async.enqueue(ctx, newWorkflowEvent("p", "d"))
+		async.enqueue(ctx, newWorkflowEvent("p", "d"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(async.metrics.Dropped))
+
+		close(block)
+		assert.NoError(t, async.Close())
+		additional.AssertNumberOfCalls(t, "Sink", 2)
+	})
+
+	t.Run("events are copied", func(t *testing.T) {
+		var received proto.Message
+		additional := &mocks.EventSink{}
+		additional.OnSinkMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			received = args.Get(1).(proto.Message)
+		}).Return(nil)
+		additional.OnClose().Return(nil)
+
+		async := newTestAsyncEventSink(additional, SinkConfig{})
+		e := newWorkflowEvent("p", "d")
+		async.enqueue(ctx, e)
+		e.ExecutionId.Name = "changed"
+		assert.NoError(t, async.Close())
+		assert.Equal(t, "name", executionIDFromMessage(received).Name)
+	})
+}
+
+func TestNewFanoutEventSink(t *testing.T) {
+	ctx := context.TODO()
+	primary := &mocks.EventSink{}
+
+	t.Run("unnamed", func(t *testing.T) {
+		_, err := NewFanoutEventSink(ctx, primary, &Config{Sinks: []SinkConfig{{Type: EventSinkLog}}}, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := NewFanoutEventSink(ctx, primary, &Config{Sinks: []SinkConfig{
+			{Name: "log", Type: EventSinkLog},
+			{Name: "log", Type: EventSinkLog},
+		}}, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewFanoutEventSink(ctx, primary, &Config{Sinks: []SinkConfig{{Name: "kafka", Type: EventSinkKafka}}},
+			promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("constructed", func(t *testing.T) {
+		sink, err := NewFanoutEventSink(ctx, primary, &Config{Sinks: []SinkConfig{
+			{Name: "log", Type: EventSinkLog},
+			{Name: "webhook", Type: EventSinkWebhook, Webhook: WebhookConfig{URL: "http://localhost"}},
+		}}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.Len(t, sink.(*fanoutEventSink).sinks, 2)
+		primary.OnClose().Return(nil)
+		assert.NoError(t, sink.Close())
+	})
+}
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// The content type of records with JSON keys and values, as accepted by version 2 of the Kafka REST Proxy API.
+const kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+type kafkaRecord struct {
+	Key   string         `json:"key"`
+	Value *eventEnvelope `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaEventSink struct {
+	client   *http.Client
+	topicURL string
+	cfg      KafkaConfig
+}
+
+// Returns the key events are produced with. All events of an execution share the same key, so that they are produced
+// to the same partition and consumed in order.
+func kafkaRecordKey(message proto.Message) string {
+	id := executionIDFromMessage(message)
+	if id == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%s:%s", id.Project, id.Domain, id.Name)
+}
+
+// Produces the event, wrapped in an eventEnvelope, to the configured topic.
+func (s *kafkaEventSink) Sink(ctx context.Context, message proto.Message) error {
+	envelope, err := marshalEventEnvelope(message)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: kafkaRecordKey(message), Value: envelope}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return postEvent(ctx, s.client, s.topicURL, kafkaJSONContentType, s.cfg.Headers, body)
+}
+
+func (s *kafkaEventSink) Close() error {
+	return nil
+}
+
+// Constructs a new EventSink that produces events to a Kafka topic through the Kafka REST Proxy.
+func NewKafkaEventSink(cfg KafkaConfig) (EventSink, error) {
+	if len(cfg.RESTProxyURL) == 0 || len(cfg.Topic) == 0 {
+		return nil, fmt.Errorf("kafka EventSink requires a rest-proxy-url and a topic")
+	}
+
+	timeout := cfg.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultSinkHTTPTimeout
+	}
+
+	return &kafkaEventSink{
+		client:   &http.Client{Timeout: timeout},
+		topicURL: strings.TrimSuffix(cfg.RESTProxyURL, "/") + "/topics/" + url.PathEscape(cfg.Topic),
+		cfg:      cfg,
+	}, nil
+}
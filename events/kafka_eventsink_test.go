@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaEventSink(t *testing.T) {
+	ctx := context.TODO()
+	var path, contentType string
+	var request kafkaProduceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &request))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink, err := NewKafkaEventSink(KafkaConfig{RESTProxyURL: server.URL + "/", Topic: "flyte-events"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, sink.Sink(ctx, &event.TaskExecutionEvent{
+		ParentNodeExecutionId: &core.NodeExecutionIdentifier{
+			NodeId:      "n1",
+			ExecutionId: newWorkflowEvent("p", "d").ExecutionId,
+		},
+	}))
+	assert.Equal(t, "/topics/flyte-events", path)
+	assert.Equal(t, kafkaJSONContentType, contentType)
+	assert.Len(t, request.Records, 1)
+	assert.Equal(t, "p:d:name", request.Records[0].Key)
+	assert.Equal(t, "TaskExecutionEvent", request.Records[0].Value.Type)
+	assert.NoError(t, sink.Close())
+}
+
+func TestNewKafkaEventSink(t *testing.T) {
+	_, err := NewKafkaEventSink(KafkaConfig{RESTProxyURL: "http://localhost:8082"})
+	assert.Error(t, err)
+
+	_, err = NewKafkaEventSink(KafkaConfig{Topic: "flyte-events"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// LaunchPlanPause describes a launch plan of a namespace that was paused because its workflows failed repeatedly, so
+// that operators and the owners of the launch plan can be alerted.
+type LaunchPlanPause struct {
+	// The failed workflow execution that paused the launch plan.
+	ExecutionID *core.WorkflowExecutionIdentifier
+	ProducerID  string
+	OccurredAt  time.Time
+	Namespace   string
+	LaunchPlan  string
+	// Number of workflows of the launch plan that failed within the window.
+	Failures    int
+	Window      time.Duration
+	PausedUntil time.Time
+}
+
+// LaunchPlanPausedEvent is the event EventSinks receive for a LaunchPlanPause. Like the WorkflowProgressEvent, it's
+// carried as a Struct wrapped in its own type, and it's not sent to FlyteAdmin.
+type LaunchPlanPausedEvent struct {
+	*structpb.Struct
+}
+
+// GetExecutionId returns the id of the failed workflow execution that paused the launch plan.
+func (e *LaunchPlanPausedEvent) GetExecutionId() *core.WorkflowExecutionIdentifier {
+	id := e.GetFields()["executionId"].GetStructValue().GetFields()
+	if id == nil {
+		return nil
+	}
+
+	return &core.WorkflowExecutionIdentifier{
+		Project: id["project"].GetStringValue(),
+		Domain:  id["domain"].GetStringValue(),
+		Name:    id["name"].GetStringValue(),
+	}
+}
+
+// NewLaunchPlanPausedEvent creates the event for the pause.
+func NewLaunchPlanPausedEvent(p LaunchPlanPause) *LaunchPlanPausedEvent {
+	return &LaunchPlanPausedEvent{Struct: &structpb.Struct{Fields: map[string]*structpb.Value{
+		"executionId": {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"project": stringValue(p.ExecutionID.GetProject()),
+			"domain":  stringValue(p.ExecutionID.GetDomain()),
+			"name":    stringValue(p.ExecutionID.GetName()),
+		}}}},
+		"producerId":    stringValue(p.ProducerID),
+		"occurredAt":    stringValue(p.OccurredAt.UTC().Format(time.RFC3339Nano)),
+		"namespace":     stringValue(p.Namespace),
+		"launchPlan":    stringValue(p.LaunchPlan),
+		"failures":      numberValue(float64(p.Failures)),
+		"windowSeconds": numberValue(p.Window.Seconds()),
+		"pausedUntil":   stringValue(p.PausedUntil.UTC().Format(time.RFC3339Nano)),
+	}}}
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaunchPlanPausedEvent(t *testing.T) {
+	id := &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "n"}
+	occurredAt := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	e := NewLaunchPlanPausedEvent(LaunchPlanPause{
+		ExecutionID: id,
+		ProducerID:  "c1",
+		OccurredAt:  occurredAt,
+		Namespace:   "p-d",
+		LaunchPlan:  "lp",
+		Failures:    5,
+		Window:      10 * time.Minute,
+		PausedUntil: occurredAt.Add(30 * time.Minute),
+	})
+	assert.True(t, proto.Equal(id, e.GetExecutionId()))
+	assert.True(t, proto.Equal(id, executionIDFromMessage(e)))
+	assert.Equal(t, "p:d:n", kafkaRecordKey(e))
+
+	envelope, err := marshalEventEnvelope(e)
+	assert.NoError(t, err)
+	assert.Equal(t, "LaunchPlanPausedEvent", envelope.Type)
+	assert.JSONEq(t, `{
+		"executionId": {"project": "p", "domain": "d", "name": "n"},
+		"producerId": "c1",
+		"occurredAt": "2021-06-01T15:04:05Z",
+		"namespace": "p-d",
+		"launchPlan": "lp",
+		"failures": 5,
+		"windowSeconds": 600,
+		"pausedUntil": "2021-06-01T15:34:05Z"
+	}`, string(envelope.Event))
+
+	message, err := unmarshalEventEnvelope(envelope)
+	assert.NoError(t, err)
+	assert.IsType(t, &LaunchPlanPausedEvent{}, message)
+	assert.True(t, proto.Equal(e.Struct, message.(*LaunchPlanPausedEvent).Struct))
+}
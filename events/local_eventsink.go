@@ -33,6 +33,16 @@ func (s *localSink) Sink(ctx context.Context, message proto.Message) error {
 	case *event.TaskExecutionEvent:
 		eventOutput = fmt.Sprintf("[--TASK EVENT--] %s,%s, Phase: %s, OccuredAt: %s\n",
 			e.TaskId, e.ParentNodeExecutionId, e.Phase, ptypes.TimestampString(e.OccurredAt))
+	case *WorkflowProgressEvent:
+		fields := e.GetFields()
+		eventOutput = fmt.Sprintf("[--WF PROGRESS--] %s, Complete: %.1f%%, CriticalPathNode: %s, OccuredAt: %s\n",
+			e.GetExecutionId(), fields["percentComplete"].GetNumberValue(), fields["criticalPathNodeId"].GetStringValue(),
+			fields["occurredAt"].GetStringValue())
+	case *LaunchPlanPausedEvent:
+		fields := e.GetFields()
+		eventOutput = fmt.Sprintf("[--LP PAUSED--] %s/%s, Failures: %v, PausedUntil: %s, OccuredAt: %s\n",
+			fields["namespace"].GetStringValue(), fields["launchPlan"].GetStringValue(), fields["failures"].GetNumberValue(),
+			fields["pausedUntil"].GetStringValue(), fields["occurredAt"].GetStringValue())
 	}
 
 	return s.writer.Write(ctx, eventOutput)
@@ -47,6 +47,11 @@ func (r *nodeEventRecorder) handleFailure(ctx context.Context, ev *event.NodeExe
 }
 
 func (r *nodeEventRecorder) RecordNodeEvent(ctx context.Context, ev *event.NodeExecutionEvent, eventConfig *config.EventConfig) error {
+	if !shouldRecordNodeEvent(ctx, ev, eventConfig) {
+		logger.Debugf(ctx, "Not recording node event in phase [%s] at the event QoS of the execution", ev.GetPhase())
+		return nil
+	}
+
 	var origEvent = ev
 	var rawOutputPolicy = eventConfig.RawOutputPolicy
 	if rawOutputPolicy == config.RawOutputPolicyInline && len(ev.GetOutputUri()) > 0 {
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type contextKey string
+
+const executionLabelsContextKey contextKey = "execution_labels"
+
+// WithExecutionLabels returns a context carrying the labels of the execution that events are recorded for. The labels
+// are used to route the events of an execution to the destination selected in the routing table.
+func WithExecutionLabels(ctx context.Context, labels map[string]string) context.Context {
+	return context.WithValue(ctx, executionLabelsContextKey, labels)
+}
+
+func executionLabelsFromContext(ctx context.Context) map[string]string {
+	if labels, ok := ctx.Value(executionLabelsContextKey).(map[string]string); ok {
+		return labels
+	}
+
+	return nil
+}
+
+type eventRoute struct {
+	name     string
+	projects sets.String
+	domains  sets.String
+	shadow   bool
+	sink     EventSink
+}
+
+func (r eventRoute) matches(id *core.WorkflowExecutionIdentifier) bool {
+	if id == nil || (r.projects.Len() == 0 && r.domains.Len() == 0) {
+		return false
+	}
+
+	return (r.projects.Len() == 0 || r.projects.Has(id.Project)) && (r.domains.Len() == 0 || r.domains.Has(id.Domain))
+}
+
+// routingEventSink sends events to the first route matching the execution, falling back to the default EventSink.
+type routingEventSink struct {
+	defaultSink EventSink
+	routeLabel  string
+	routes      []eventRoute
+}
+
+func (s *routingEventSink) resolve(ctx context.Context, message proto.Message) (eventRoute, bool) {
+	if len(s.routeLabel) > 0 {
+		if name, ok := executionLabelsFromContext(ctx)[s.routeLabel]; ok {
+			for _, r := range s.routes {
+				if r.name == name {
+					return r, true
+				}
+			}
+
+			logger.Warnf(ctx, "Execution selects unknown event route [%v], using project/domain routing", name)
+		}
+	}
+
+	id := executionIDFromMessage(message)
+	for _, r := range s.routes {
+		if r.matches(id) {
+			return r, true
+		}
+	}
+
+	return eventRoute{}, false
+}
+
+func (s *routingEventSink) Sink(ctx context.Context, message proto.Message) error {
+	r, ok := s.resolve(ctx, message)
+	if !ok {
+		return s.defaultSink.Sink(ctx, message)
+	}
+
+	if !r.shadow {
+		return r.sink.Sink(ctx, message)
+	}
+
+	if err := r.sink.Sink(ctx, message); err != nil {
+		logger.Warnf(ctx, "Failed to send event to shadow route [%v]. Error: %v", r.name, err)
+	}
+
+	return s.defaultSink.Sink(ctx, message)
+}
+
+func (s *routingEventSink) Close() error {
+	err := s.defaultSink.Close()
+	for _, r := range s.routes {
+		if closeErr := r.sink.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+func executionIDFromMessage(message proto.Message) *core.WorkflowExecutionIdentifier {
+	switch e := message.(type) {
+	case *event.WorkflowExecutionEvent:
+		return e.GetExecutionId()
+	case *event.NodeExecutionEvent:
+		return e.GetId().GetExecutionId()
+	case *event.TaskExecutionEvent:
+		return e.GetParentNodeExecutionId().GetExecutionId()
+	case *WorkflowProgressEvent:
+		return e.GetExecutionId()
+	case *LaunchPlanPausedEvent:
+		return e.GetExecutionId()
+	default:
+		return nil
+	}
+}
+
+func newRoutingEventSink(defaultSink EventSink, routeLabel string, routes []eventRoute) *routingEventSink {
+	return &routingEventSink{
+		defaultSink: defaultSink,
+		routeLabel:  routeLabel,
+		routes:      routes,
+	}
+}
+
+// NewRoutingEventSink wraps the default EventSink to send the events of executions that match one of the configured
+// routes to the destination of that route.
+func NewRoutingEventSink(ctx context.Context, defaultSink EventSink, config *Config, scope promutils.Scope) (EventSink, error) {
+	routes := make([]eventRoute, 0, len(config.Routes))
+	for _, routeCfg := range config.Routes {
+		if len(routeCfg.Name) == 0 {
+			return nil, fmt.Errorf("event routes must be named")
+		}
+
+		adminCfg := routeCfg.Admin
+		if adminCfg == nil {
+			adminCfg = admin.GetConfig(ctx)
+		}
+
+		sink, err := constructSink(ctx, routeCfg.Type, routeCfg.FilePath, adminCfg, config, scope.NewSubScope(routeCfg.Name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to construct EventSink for route [%v]", routeCfg.Name)
+		}
+
+		logger.Infof(ctx, "Routing events of projects %v and domains %v to [%v] EventSink of route [%v]",
+			routeCfg.Projects, routeCfg.Domains, routeCfg.Type, routeCfg.Name)
+		routes = append(routes, eventRoute{
+			name:     routeCfg.Name,
+			projects: sets.NewString(routeCfg.Projects...),
+			domains:  sets.NewString(routeCfg.Domains...),
+			shadow:   routeCfg.Shadow,
+			sink:     sink,
+		})
+	}
+
+	return newRoutingEventSink(defaultSink, config.RouteLabel, routes), nil
+}
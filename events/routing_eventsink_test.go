@@ -0,0 +1,102 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/flyteorg/flytepropeller/events/mocks"
+)
+
+func newWorkflowEvent(project, domain string) *event.WorkflowExecutionEvent {
+	return &event.WorkflowExecutionEvent{
+		ExecutionId: &core.WorkflowExecutionIdentifier{Project: project, Domain: domain, Name: "name"},
+	}
+}
+
+func TestRoutingEventSink_Sink(t *testing.T) {
+	ctx := context.TODO()
+
+	newSinks := func() (*mocks.EventSink, *mocks.EventSink, *mocks.EventSink, EventSink) {
+		defaultSink := &mocks.EventSink{}
+		stagingSink := &mocks.EventSink{}
+		shadowSink := &mocks.EventSink{}
+		return defaultSink, stagingSink, shadowSink, newRoutingEventSink(defaultSink, "event-route", []eventRoute{
+			{name: "staging", projects: sets.NewString(), domains: sets.NewString("staging"), sink: stagingSink},
+			{name: "shadow", projects: sets.NewString(), domains: sets.NewString(), shadow: true, sink: shadowSink},
+		})
+	}
+
+	t.Run("default", func(t *testing.T) {
+		defaultSink, stagingSink, shadowSink, sink := newSinks()
+		defaultSink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		assert.NoError(t, sink.Sink(ctx, newWorkflowEvent("p", "production")))
+		defaultSink.AssertNumberOfCalls(t, "Sink", 1)
+		stagingSink.AssertNotCalled(t, "Sink", mock.Anything, mock.Anything)
+		shadowSink.AssertNotCalled(t, "Sink", mock.Anything, mock.Anything)
+	})
+
+	t.Run("project domain route", func(t *testing.T) {
+		defaultSink, stagingSink, _, sink := newSinks()
+		stagingSink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		assert.NoError(t, sink.Sink(ctx, &event.NodeExecutionEvent{
+			Id: &core.NodeExecutionIdentifier{ExecutionId: newWorkflowEvent("p", "staging").ExecutionId},
+		}))
+		stagingSink.AssertNumberOfCalls(t, "Sink", 1)
+		defaultSink.AssertNotCalled(t, "Sink", mock.Anything, mock.Anything)
+	})
+
+	t.Run("label route", func(t *testing.T) {
+		defaultSink, stagingSink, _, sink := newSinks()
+		stagingSink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		labelCtx := WithExecutionLabels(ctx, map[string]string{"event-route": "staging"})
+		assert.NoError(t, sink.Sink(labelCtx, newWorkflowEvent("p", "production")))
+		stagingSink.AssertNumberOfCalls(t, "Sink", 1)
+		defaultSink.AssertNotCalled(t, "Sink", mock.Anything, mock.Anything)
+	})
+
+	t.Run("shadow route", func(t *testing.T) {
+		defaultSink, _, shadowSink, sink := newSinks()
+		defaultSink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		shadowSink.OnSinkMatch(mock.Anything, mock.Anything).Return(fmt.Errorf("unavailable"))
+		labelCtx := WithExecutionLabels(ctx, map[string]string{"event-route": "shadow"})
+		assert.NoError(t, sink.Sink(labelCtx, newWorkflowEvent("p", "staging")))
+		shadowSink.AssertNumberOfCalls(t, "Sink", 1)
+		defaultSink.AssertNumberOfCalls(t, "Sink", 1)
+	})
+
+	t.Run("unknown label route", func(t *testing.T) {
+		defaultSink, _, _, sink := newSinks()
+		defaultSink.OnSinkMatch(mock.Anything, mock.Anything).Return(nil)
+		labelCtx := WithExecutionLabels(ctx, map[string]string{"event-route": "unknown"})
+		assert.NoError(t, sink.Sink(labelCtx, newWorkflowEvent("p", "production")))
+		defaultSink.AssertNumberOfCalls(t, "Sink", 1)
+	})
+}
+
+func TestNewRoutingEventSink(t *testing.T) {
+	ctx := context.TODO()
+	defaultSink := &mocks.EventSink{}
+
+	t.Run("unnamed route", func(t *testing.T) {
+		_, err := NewRoutingEventSink(ctx, defaultSink, &Config{Routes: []RouteConfig{{Type: EventSinkLog}}}, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("routes", func(t *testing.T) {
+		sink, err := NewRoutingEventSink(ctx, defaultSink, &Config{
+			RouteLabel: "event-route",
+			Routes:     []RouteConfig{{Name: "log", Domains: []string{"staging"}, Type: EventSinkLog}},
+		}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, sink.Sink(ctx, newWorkflowEvent("p", "staging")))
+		defaultSink.AssertNotCalled(t, "Sink", mock.Anything, mock.Anything)
+	})
+}
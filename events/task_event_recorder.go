@@ -47,6 +47,11 @@ func (r *taskEventRecorder) handleFailure(ctx context.Context, ev *event.TaskExe
 }
 
 func (r *taskEventRecorder) RecordTaskEvent(ctx context.Context, ev *event.TaskExecutionEvent, eventConfig *config.EventConfig) error {
+	if !shouldRecordTaskEvent(ctx, ev, eventConfig) {
+		logger.Debugf(ctx, "Not recording task event in phase [%s] at the event QoS of the execution", ev.GetPhase())
+		return nil
+	}
+
 	var origEvent = ev
 	var rawOutputPolicy = eventConfig.RawOutputPolicy
 	if rawOutputPolicy == config.RawOutputPolicyInline && len(ev.GetOutputUri()) > 0 {
@@ -0,0 +1,171 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytepropeller/events/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// The maximum number of bytes of the body of a failed response that is kept in the returned error.
+const maxErrorBodySize = 1024
+
+// eventEnvelope is the JSON representation of events sent to external systems. It carries the type of the event, so
+// that consumers can tell the different types of events apart.
+type eventEnvelope struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+func eventType(message proto.Message) (string, error) {
+	switch message.(type) {
+	case *event.WorkflowExecutionEvent:
+		return "WorkflowExecutionEvent", nil
+	case *event.NodeExecutionEvent:
+		return "NodeExecutionEvent", nil
+	case *event.TaskExecutionEvent:
+		return "TaskExecutionEvent", nil
+	case *WorkflowProgressEvent:
+		return "WorkflowProgressEvent", nil
+	case *LaunchPlanPausedEvent:
+		return "LaunchPlanPausedEvent", nil
+	default:
+		return "", fmt.Errorf("unknown event type [%s]", message.String())
+	}
+}
+
+func marshalEventEnvelope(message proto.Message) (*eventEnvelope, error) {
+	t, err := eventType(message)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := (&jsonpb.Marshaler{}).MarshalToString(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event [%s]. Error: %w", t, err)
+	}
+
+	return &eventEnvelope{Type: t, Event: json.RawMessage(raw)}, nil
+}
+
+func unmarshalEventEnvelope(envelope *eventEnvelope) (proto.Message, error) {
+	var message proto.Message
+	switch envelope.Type {
+	case "WorkflowExecutionEvent":
+		message = &event.WorkflowExecutionEvent{}
+	case "NodeExecutionEvent":
+		message = &event.NodeExecutionEvent{}
+	case "TaskExecutionEvent":
+		message = &event.TaskExecutionEvent{}
+	case "WorkflowProgressEvent":
+		message = &WorkflowProgressEvent{Struct: &structpb.Struct{}}
+	case "LaunchPlanPausedEvent":
+		message = &LaunchPlanPausedEvent{Struct: &structpb.Struct{}}
+	default:
+		return nil, fmt.Errorf("unknown event type [%s]", envelope.Type)
+	}
+
+	if err := jsonpb.UnmarshalString(string(envelope.Event), message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event [%s]. Error: %w", envelope.Type, err)
+	}
+
+	return message, nil
+}
+
+// Converts a response with an unsuccessful status code to an EventError, so that callers can tell whether sending the
+// event again may succeed.
+func httpStatusError(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	cause := fmt.Errorf("received status [%d], body [%s]", resp.StatusCode, string(body))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &errors.EventError{Code: errors.ResourceExhausted, Cause: cause, Message: "Events are sent too often"}
+	case resp.StatusCode == http.StatusRequestEntityTooLarge:
+		return &errors.EventError{Code: errors.TooLarge, Cause: cause, Message: "Event message exceeds the maximum size"}
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &errors.EventError{Code: errors.InvalidArgument, Cause: cause, Message: "Event was rejected"}
+	default:
+		return &errors.EventError{Code: errors.EventSinkError, Cause: cause, Message: "Error sending event"}
+	}
+}
+
+// Posts the body to the url and checks the status of the response.
+func postEvent(ctx context.Context, client *http.Client, url, contentType string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &errors.EventError{Code: errors.EventSinkError, Cause: err, Message: "Error sending event"}
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close the response body of [%v]. Error: %v", url, err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return httpStatusError(resp)
+	}
+
+	return nil
+}
+
+type webhookEventSink struct {
+	client *http.Client
+	cfg    WebhookConfig
+}
+
+// Posts the event, wrapped in an eventEnvelope, as JSON to the configured URL.
+func (s *webhookEventSink) Sink(ctx context.Context, message proto.Message) error {
+	envelope, err := marshalEventEnvelope(message)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	return postEvent(ctx, s.client, s.cfg.URL, "application/json", s.cfg.Headers, body)
+}
+
+func (s *webhookEventSink) Close() error {
+	return nil
+}
+
+// Constructs a new EventSink that posts events as JSON to an HTTP endpoint.
+func NewWebhookEventSink(cfg WebhookConfig) (EventSink, error) {
+	if len(cfg.URL) == 0 {
+		return nil, fmt.Errorf("webhook EventSink requires a url")
+	}
+
+	timeout := cfg.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultSinkHTTPTimeout
+	}
+
+	return &webhookEventSink{
+		client: &http.Client{Timeout: timeout},
+		cfg:    cfg,
+	}, nil
+}
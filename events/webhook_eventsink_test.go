@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/events/errors"
+)
+
+func TestMarshalEventEnvelope(t *testing.T) {
+	envelope, err := marshalEventEnvelope(&event.NodeExecutionEvent{
+		Id:    &core.NodeExecutionIdentifier{NodeId: "n1"},
+		Phase: core.NodeExecution_RUNNING,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "NodeExecutionEvent", envelope.Type)
+	assert.JSONEq(t, `{"id": {"nodeId": "n1"}, "phase": "RUNNING"}`, string(envelope.Event))
+
+	_, err = marshalEventEnvelope(&core.Identifier{})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalEventEnvelope(t *testing.T) {
+	e := &event.TaskExecutionEvent{
+		TaskId: &core.Identifier{Name: "task"},
+		Phase:  core.TaskExecution_SUCCEEDED,
+	}
+	envelope, err := marshalEventEnvelope(e)
+	assert.NoError(t, err)
+	message, err := unmarshalEventEnvelope(envelope)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(e, message))
+
+	_, err = unmarshalEventEnvelope(&eventEnvelope{Type: "Identifier", Event: json.RawMessage("{}")})
+	assert.Error(t, err)
+	_, err = unmarshalEventEnvelope(&eventEnvelope{Type: "TaskExecutionEvent", Event: json.RawMessage("{")})
+	assert.Error(t, err)
+}
+
+func TestWebhookEventSink(t *testing.T) {
+	ctx := context.TODO()
+	status := http.StatusOK
+	var received eventEnvelope
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &received))
+		header = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookEventSink(WebhookConfig{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	assert.NoError(t, err)
+
+	t.Run("sent", func(t *testing.T) {
+		assert.NoError(t, sink.Sink(ctx, newWorkflowEvent("p", "d")))
+		assert.Equal(t, "WorkflowExecutionEvent", received.Type)
+		assert.Equal(t, "Bearer token", header)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		status = http.StatusBadRequest
+		err := sink.Sink(ctx, newWorkflowEvent("p", "d"))
+		assert.True(t, errors.IsInvalidArguments(err))
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		status = http.StatusTooManyRequests
+		err := sink.Sink(ctx, newWorkflowEvent("p", "d"))
+		assert.True(t, errors.IsResourceExhausted(err))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		err := sink.Sink(ctx, newWorkflowEvent("p", "d"))
+		assert.Error(t, err)
+		assert.True(t, isRetryableEventError(err))
+	})
+
+	assert.NoError(t, sink.Close())
+}
+
+func TestNewWebhookEventSink(t *testing.T) {
+	_, err := NewWebhookEventSink(WebhookConfig{})
+	assert.Error(t, err)
+}
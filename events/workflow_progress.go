@@ -0,0 +1,73 @@
+package events
+
+import (
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// WorkflowProgress rolls up the phases of the nodes of a workflow execution, so that consumers can show its progress
+// without aggregating the events of all its nodes.
+type WorkflowProgress struct {
+	ExecutionID *core.WorkflowExecutionIdentifier
+	ProducerID  string
+	OccurredAt  time.Time
+	// Counts of the nodes of the workflow, excluding its start and end nodes.
+	TotalNodes     int
+	SucceededNodes int
+	FailedNodes    int
+	RunningNodes   int
+	// Percentage of the nodes that completed, whether they succeeded, failed or were skipped.
+	PercentComplete float64
+	// The running node with the longest chain of nodes downstream of it, which is likely what the workflow waits for.
+	CriticalPathNodeID string
+}
+
+// WorkflowProgressEvent is the event EventSinks receive for a WorkflowProgress. flyteidl has no message for the progress
+// of executions, so the progress is carried as a Struct, wrapped in its own type so that EventSinks can tell it apart
+// from other events. FlyteAdmin has no API for it, so it's only sent to the other EventSinks.
+type WorkflowProgressEvent struct {
+	*structpb.Struct
+}
+
+func numberValue(n float64) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: n}}
+}
+
+func stringValue(s string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
+}
+
+// GetExecutionId returns the id of the workflow execution the progress belongs to.
+func (e *WorkflowProgressEvent) GetExecutionId() *core.WorkflowExecutionIdentifier {
+	id := e.GetFields()["executionId"].GetStructValue().GetFields()
+	if id == nil {
+		return nil
+	}
+
+	return &core.WorkflowExecutionIdentifier{
+		Project: id["project"].GetStringValue(),
+		Domain:  id["domain"].GetStringValue(),
+		Name:    id["name"].GetStringValue(),
+	}
+}
+
+// NewWorkflowProgressEvent creates the event for the progress.
+func NewWorkflowProgressEvent(p WorkflowProgress) *WorkflowProgressEvent {
+	return &WorkflowProgressEvent{Struct: &structpb.Struct{Fields: map[string]*structpb.Value{
+		"executionId": {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"project": stringValue(p.ExecutionID.GetProject()),
+			"domain":  stringValue(p.ExecutionID.GetDomain()),
+			"name":    stringValue(p.ExecutionID.GetName()),
+		}}}},
+		"producerId":         stringValue(p.ProducerID),
+		"occurredAt":         stringValue(p.OccurredAt.UTC().Format(time.RFC3339Nano)),
+		"totalNodes":         numberValue(float64(p.TotalNodes)),
+		"succeededNodes":     numberValue(float64(p.SucceededNodes)),
+		"failedNodes":        numberValue(float64(p.FailedNodes)),
+		"runningNodes":       numberValue(float64(p.RunningNodes)),
+		"percentComplete":    numberValue(p.PercentComplete),
+		"criticalPathNodeId": stringValue(p.CriticalPathNodeID),
+	}}}
+}
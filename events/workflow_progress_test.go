@@ -0,0 +1,47 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowProgressEvent(t *testing.T) {
+	id := &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "n"}
+	e := NewWorkflowProgressEvent(WorkflowProgress{
+		ExecutionID:        id,
+		ProducerID:         "c1",
+		OccurredAt:         time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC),
+		TotalNodes:         4,
+		SucceededNodes:     1,
+		RunningNodes:       2,
+		PercentComplete:    25,
+		CriticalPathNodeID: "n1",
+	})
+	assert.True(t, proto.Equal(id, e.GetExecutionId()))
+	assert.True(t, proto.Equal(id, executionIDFromMessage(e)))
+	assert.Equal(t, "p:d:n", kafkaRecordKey(e))
+
+	envelope, err := marshalEventEnvelope(e)
+	assert.NoError(t, err)
+	assert.Equal(t, "WorkflowProgressEvent", envelope.Type)
+	assert.JSONEq(t, `{
+		"executionId": {"project": "p", "domain": "d", "name": "n"},
+		"producerId": "c1",
+		"occurredAt": "2021-06-01T15:04:05Z",
+		"totalNodes": 4,
+		"succeededNodes": 1,
+		"failedNodes": 0,
+		"runningNodes": 2,
+		"percentComplete": 25,
+		"criticalPathNodeId": "n1"
+	}`, string(envelope.Event))
+
+	message, err := unmarshalEventEnvelope(envelope)
+	assert.NoError(t, err)
+	assert.IsType(t, &WorkflowProgressEvent{}, message)
+	assert.True(t, proto.Equal(e.Struct, message.(*WorkflowProgressEvent).Struct))
+}
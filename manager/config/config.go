@@ -22,6 +22,7 @@ var (
 			Type:       ShardTypeHash,
 			ShardCount: 3,
 		},
+		LabelWorkflows: true,
 	}
 
 	configSection = config.MustRegisterSection("manager", DefaultConfig)
@@ -55,6 +56,8 @@ type Config struct {
 	PodTemplateNamespace     string          `json:"pod-template-namespace" pflag:",Namespace where the k8s PodTemplate is located"`
 	ScanInterval             config.Duration `json:"scan-interval" pflag:",Frequency to scan FlytePropeller pods and start / restart if necessary"`
 	ShardConfig              ShardConfig     `json:"shard" pflag:",Configure the shard strategy for this manager"`
+	Deployments              bool            `json:"deployments" pflag:",Manage each FlytePropeller instance as a single replica Deployment rather than a bare pod"`
+	LabelWorkflows           bool            `json:"label-workflows" pflag:",Write the shard labels to FlyteWorkflows missing them, so that they are processed by a FlytePropeller instance"`
 }
 
 func GetConfig() *Config {
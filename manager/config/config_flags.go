@@ -57,5 +57,7 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "scan-interval"), DefaultConfig.ScanInterval.String(), "Frequency to scan FlytePropeller pods and start / restart if necessary")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "shard.type"), DefaultConfig.ShardConfig.Type.String(), "Shard implementation to use")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "shard.shard-count"), DefaultConfig.ShardConfig.ShardCount, "The number of shards to manage for a 'hash' shard type")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "deployments"), DefaultConfig.Deployments, "Manage each FlytePropeller instance as a single replica Deployment rather than a bare pod")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "label-workflows"), DefaultConfig.LabelWorkflows, "Write the shard labels to FlyteWorkflows missing them, so that they are processed by a FlytePropeller instance")
 	return cmdFlags
 }
@@ -197,4 +197,32 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_deployments", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("deployments", testValue)
+			if vBool, err := cmdFlags.GetBool("deployments"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Deployments)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_label-workflows", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("label-workflows", testValue)
+			if vBool, err := cmdFlags.GetBool("label-workflows"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LabelWorkflows)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
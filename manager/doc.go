@@ -11,11 +11,17 @@ FlytePropeller Manager is configured at the root of the FlytePropeller configurt
 	  pod-template-name: "flytepropeller-template"  # k8s PodTemplate name to use for starting FlytePropeller pods
 	  pod-template-namespace: "flyte"               # namespace where the k8s PodTemplate is located
 	  scan-interval: 10s                            # frequency to scan FlytePropeller pods and start / restart if necessary
+	  deployments: false                            # manage each FlytePropeller instance as a single replica Deployment rather than a bare pod
+	  label-workflows: true                         # write the shard labels to FlyteWorkflows missing them
 	  shard:                                        # configure sharding strategy
 	    # shard configuration redacted
 
 FlytePropeller Manager handles dynamic updates to both the k8s PodTemplate and shard configuration. The k8s PodTemplate resource has an associated resource version which uniquely identifies changes. Additionally, shard configuration modifications may be tracked using a simple hash. Flyte stores these values as annotations on managed FlytePropeller instances. Therefore, if either of there values change the FlytePropeller Manager instance will detect it and perform the necessary deployment updates.
 
+When configured with "deployments: true" every managed FlytePropeller instance is a single replica k8s Deployment. Changes to the k8s PodTemplate or shard configuration then update the Deployments in place, and k8s replaces their pods using the "Recreate" strategy, so that two instances never evaluate the same shard at the same time.
+
+FlyteWorkflows that are missing the labels shard strategies select on, for example those created before sharding was enabled, are not processed by any managed FlytePropeller instance. Unless disabled with "label-workflows: false", the FlytePropeller Manager periodically lists these FlyteWorkflows and writes the labels derived from their execution ID.
+
 Shard Strategies
 
 Flyte defines a variety of Shard Strategies for configuring how FlyteWorkflows are sharded. These options may include the shard type (ex. hash, project, or domain) along with the number of shards or the distribution of project / domain IDs over shards.
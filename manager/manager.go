@@ -7,6 +7,7 @@ import (
 
 	managerConfig "github.com/flyteorg/flytepropeller/manager/config"
 	"github.com/flyteorg/flytepropeller/manager/shardstrategy"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
 	propellerConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
 	leader "github.com/flyteorg/flytepropeller/pkg/leaderelection"
 	"github.com/flyteorg/flytepropeller/pkg/utils"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,23 +34,30 @@ import (
 const (
 	podTemplateResourceVersion = "podTemplateResourceVersion"
 	shardConfigHash            = "shardConfigHash"
+	shardIndexLabel            = "shard-index"
 )
 
 type metrics struct {
-	Scope       promutils.Scope
-	RoundTime   promutils.StopWatch
-	PodsCreated prometheus.Counter
-	PodsDeleted prometheus.Counter
-	PodsRunning prometheus.Gauge
+	Scope              promutils.Scope
+	RoundTime          promutils.StopWatch
+	PodsCreated        prometheus.Counter
+	PodsDeleted        prometheus.Counter
+	PodsRunning        prometheus.Gauge
+	DeploymentsCreated prometheus.Counter
+	DeploymentsUpdated prometheus.Counter
+	WorkflowsLabeled   prometheus.Counter
 }
 
 func newManagerMetrics(scope promutils.Scope) *metrics {
 	return &metrics{
-		Scope:       scope,
-		RoundTime:   scope.MustNewStopWatch("round_time", "Time to perform one round of validating managed pod status'", time.Millisecond),
-		PodsCreated: scope.MustNewCounter("pods_created_count", "Total number of pods created"),
-		PodsDeleted: scope.MustNewCounter("pods_deleted_count", "Total number of pods deleted"),
-		PodsRunning: scope.MustNewGauge("pods_running_count", "Number of managed pods currently running"),
+		Scope:              scope,
+		RoundTime:          scope.MustNewStopWatch("round_time", "Time to perform one round of validating managed pod status'", time.Millisecond),
+		PodsCreated:        scope.MustNewCounter("pods_created_count", "Total number of pods created"),
+		PodsDeleted:        scope.MustNewCounter("pods_deleted_count", "Total number of pods deleted"),
+		PodsRunning:        scope.MustNewGauge("pods_running_count", "Number of managed pods currently running"),
+		DeploymentsCreated: scope.MustNewCounter("deployments_created_count", "Total number of deployments created"),
+		DeploymentsUpdated: scope.MustNewCounter("deployments_updated_count", "Total number of deployments updated"),
+		WorkflowsLabeled:   scope.MustNewCounter("workflows_labeled_count", "Total number of flyteworkflows labeled with a shard-key"),
 	}
 }
 
@@ -66,39 +75,73 @@ type Manager struct {
 	podTemplateNamespace     string
 	scanInterval             time.Duration
 	shardStrategy            shardstrategy.ShardStrategy
+	deployments              bool
+	shardLabeler             *shardLabeler
 }
 
-func (m *Manager) createPods(ctx context.Context) error {
-	t := m.metrics.RoundTime.Start()
-	defer t.Stop()
-
-	// retrieve pod metadata
+// getPodTemplate retrieves the pod template managed FlytePropeller instances are started from, along with the
+// annotations identifying its configuration.
+func (m *Manager) getPodTemplate(ctx context.Context) (*v1.PodTemplate, map[string]string, error) {
 	podTemplate, err := m.kubeClient.CoreV1().PodTemplates(m.podTemplateNamespace).Get(ctx, m.podTemplateName, metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to retrieve pod template '%s' from namespace '%s' [%v]", m.podTemplateName, m.podTemplateNamespace, err)
+		return nil, nil, fmt.Errorf("failed to retrieve pod template '%s' from namespace '%s' [%v]", m.podTemplateName, m.podTemplateNamespace, err)
 	}
 
 	shardConfigHash, err := m.shardStrategy.HashCode()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	podAnnotations := map[string]string{
 		"podTemplateResourceVersion": podTemplate.ObjectMeta.ResourceVersion,
 		"shardConfigHash":            fmt.Sprintf("%d", shardConfigHash),
 	}
-	podNames := m.getPodNames()
-	podLabels := map[string]string{
-		"app": m.podApplication,
-	}
 
 	// disable leader election on all managed pods
 	container, err := utils.GetContainer(&podTemplate.Template.Spec, m.podTemplateContainerName)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve flytepropeller container from pod template [%v]", err)
+		return nil, nil, fmt.Errorf("failed to retrieve flytepropeller container from pod template [%v]", err)
 	}
 
 	container.Args = append(container.Args, "--propeller.leader-election.enabled=false")
+	return podTemplate, podAnnotations, nil
+}
+
+// buildPod creates the pod of the managed FlytePropeller instance responsible for the shard with the specified index.
+func (m *Manager) buildPod(podTemplate *v1.PodTemplate, objectMeta metav1.ObjectMeta, podIndex int) (*v1.Pod, error) {
+	baseObjectMeta := podTemplate.Template.ObjectMeta.DeepCopy()
+	err := mergo.Merge(baseObjectMeta, objectMeta, mergo.WithOverride, mergo.WithAppendSlice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize pod ObjectMeta for '%s' [%v]", objectMeta.Name, err)
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: *baseObjectMeta,
+		Spec:       *podTemplate.Template.Spec.DeepCopy(),
+	}
+
+	err = m.shardStrategy.UpdatePodSpec(&pod.Spec, m.podTemplateContainerName, podIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pod spec for '%s' [%v]", objectMeta.Name, err)
+	}
+
+	return pod, nil
+}
+
+func (m *Manager) createPods(ctx context.Context) error {
+	t := m.metrics.RoundTime.Start()
+	defer t.Stop()
+
+	// retrieve pod metadata
+	podTemplate, podAnnotations, err := m.getPodTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	podNames := m.getPodNames()
+	podLabels := map[string]string{
+		"app": m.podApplication,
+	}
 
 	// retrieve existing pods
 	listOptions := metav1.ListOptions{
@@ -165,43 +208,146 @@ func (m *Manager) createPods(ctx context.Context) error {
 	errs := stderrors.ErrorCollection{}
 	for i, podName := range podNames {
 		if exists := podExists[podName]; !exists {
-			baseObjectMeta := podTemplate.Template.ObjectMeta.DeepCopy()
-			objectMeta := metav1.ObjectMeta{
+			pod, err := m.buildPod(podTemplate, metav1.ObjectMeta{
 				Annotations:     podAnnotations,
 				Name:            podName,
 				Namespace:       m.podNamespace,
 				Labels:          podLabels,
 				OwnerReferences: m.ownerReferences,
+			}, i)
+			if err != nil {
+				errs.Append(err)
+				continue
 			}
 
-			err = mergo.Merge(baseObjectMeta, objectMeta, mergo.WithOverride, mergo.WithAppendSlice)
+			_, err = m.kubeClient.CoreV1().Pods(m.podNamespace).Create(ctx, pod, metav1.CreateOptions{})
 			if err != nil {
-				errs.Append(fmt.Errorf("failed to initialize pod ObjectMeta for '%s' [%v]", podName, err))
+				errs.Append(fmt.Errorf("failed to create pod '%s' [%v]", podName, err))
 				continue
 			}
 
-			pod := &v1.Pod{
-				ObjectMeta: *baseObjectMeta,
-				Spec:       *podTemplate.Template.Spec.DeepCopy(),
+			m.metrics.PodsCreated.Inc()
+			logger.Infof(ctx, "created pod '%s'", podName)
+		}
+	}
+
+	return errs.ErrorOrDefault()
+}
+
+// createDeployments ensures a single replica Deployment exists for every shard and is up to date with the pod template
+// and shard configuration. Unlike bare pods, Deployments are updated in place and k8s replaces their pods.
+func (m *Manager) createDeployments(ctx context.Context) error {
+	t := m.metrics.RoundTime.Start()
+	defer t.Stop()
+
+	podTemplate, podAnnotations, err := m.getPodTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	deploymentLabels := map[string]string{
+		"app": m.podApplication,
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(deploymentLabels).String(),
+	}
+
+	deployments, err := m.kubeClient.AppsV1().Deployments(m.podNamespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+
+	existingDeployments := make(map[string]*appsv1.Deployment)
+	for i := range deployments.Items {
+		existingDeployments[deployments.Items[i].Name] = &deployments.Items[i]
+	}
+
+	podsRunning := 0
+	errs := stderrors.ErrorCollection{}
+	for i, name := range m.getPodNames() {
+		selectorLabels := map[string]string{
+			"app":           m.podApplication,
+			shardIndexLabel: fmt.Sprintf("%d", i),
+		}
+
+		pod, err := m.buildPod(podTemplate, metav1.ObjectMeta{
+			Annotations: podAnnotations,
+			Labels:      selectorLabels,
+		}, i)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		existing, exists := existingDeployments[name]
+		if exists {
+			podsRunning += int(existing.Status.ReadyReplicas)
+
+			upToDate := true
+			for key, value := range podAnnotations {
+				if existing.ObjectMeta.Annotations[key] != value {
+					upToDate = false
+					break
+				}
 			}
 
-			err := m.shardStrategy.UpdatePodSpec(&pod.Spec, m.podTemplateContainerName, i)
-			if err != nil {
-				errs.Append(fmt.Errorf("failed to update pod spec for '%s' [%v]", podName, err))
+			if upToDate {
 				continue
 			}
 
-			_, err = m.kubeClient.CoreV1().Pods(m.podNamespace).Create(ctx, pod, metav1.CreateOptions{})
+			logger.Infof(ctx, "detected deployment '%s' with stale configuration", name)
+		}
+
+		replicas := int32(1)
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations:     podAnnotations,
+				Name:            name,
+				Namespace:       m.podNamespace,
+				Labels:          deploymentLabels,
+				OwnerReferences: m.ownerReferences,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: selectorLabels,
+				},
+				// a rolling update would briefly run two instances evaluating the same shard
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RecreateDeploymentStrategyType,
+				},
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: pod.ObjectMeta,
+					Spec:       pod.Spec,
+				},
+			},
+		}
+
+		if exists {
+			deployment.ObjectMeta.ResourceVersion = existing.ObjectMeta.ResourceVersion
+			_, err = m.kubeClient.AppsV1().Deployments(m.podNamespace).Update(ctx, deployment, metav1.UpdateOptions{})
 			if err != nil {
-				errs.Append(fmt.Errorf("failed to create pod '%s' [%v]", podName, err))
+				errs.Append(fmt.Errorf("failed to update deployment '%s' [%v]", name, err))
 				continue
 			}
 
-			m.metrics.PodsCreated.Inc()
-			logger.Infof(ctx, "created pod '%s'", podName)
+			m.metrics.DeploymentsUpdated.Inc()
+			logger.Infof(ctx, "updated deployment '%s'", name)
+			continue
+		}
+
+		_, err = m.kubeClient.AppsV1().Deployments(m.podNamespace).Create(ctx, deployment, metav1.CreateOptions{})
+		if err != nil {
+			errs.Append(fmt.Errorf("failed to create deployment '%s' [%v]", name, err))
+			continue
 		}
+
+		m.metrics.DeploymentsCreated.Inc()
+		logger.Infof(ctx, "created deployment '%s'", name)
 	}
 
+	m.metrics.PodsRunning.Set(float64(podsRunning))
 	return errs.ErrorOrDefault()
 }
 
@@ -234,10 +380,23 @@ func (m *Manager) run(ctx context.Context) error {
 	logger.Infof(ctx, "started manager")
 	wait.UntilWithContext(ctx,
 		func(ctx context.Context) {
-			logger.Debugf(ctx, "validating managed pod(s) state")
-			err := m.createPods(ctx)
-			if err != nil {
-				logger.Errorf(ctx, "failed to create pod(s) [%v]", err)
+			if m.deployments {
+				logger.Debugf(ctx, "validating managed deployment(s) state")
+				if err := m.createDeployments(ctx); err != nil {
+					logger.Errorf(ctx, "failed to create deployment(s) [%v]", err)
+				}
+			} else {
+				logger.Debugf(ctx, "validating managed pod(s) state")
+				if err := m.createPods(ctx); err != nil {
+					logger.Errorf(ctx, "failed to create pod(s) [%v]", err)
+				}
+			}
+
+			if m.shardLabeler != nil {
+				logger.Debugf(ctx, "labeling flyteworkflow(s) missing a shard-key")
+				if err := m.shardLabeler.labelWorkflows(ctx); err != nil {
+					logger.Errorf(ctx, "failed to label flyteworkflow(s) [%v]", err)
+				}
 			}
 		},
 		m.scanInterval,
@@ -248,15 +407,16 @@ func (m *Manager) run(ctx context.Context) error {
 }
 
 // New creates a new FlytePropeller Manager instance.
-func New(ctx context.Context, propellerCfg *propellerConfig.Config, cfg *managerConfig.Config, podNamespace string, ownerReferences []metav1.OwnerReference, kubeClient kubernetes.Interface, scope promutils.Scope) (*Manager, error) {
+func New(ctx context.Context, propellerCfg *propellerConfig.Config, cfg *managerConfig.Config, podNamespace string, ownerReferences []metav1.OwnerReference, kubeClient kubernetes.Interface, flyteworkflowClient versioned.Interface, scope promutils.Scope) (*Manager, error) {
 	shardStrategy, err := shardstrategy.NewShardStrategy(ctx, cfg.ShardConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize shard strategy [%v]", err)
 	}
 
+	managerMetrics := newManagerMetrics(scope)
 	manager := &Manager{
 		kubeClient:               kubeClient,
-		metrics:                  newManagerMetrics(scope),
+		metrics:                  managerMetrics,
 		ownerReferences:          ownerReferences,
 		podApplication:           cfg.PodApplication,
 		podNamespace:             podNamespace,
@@ -265,6 +425,14 @@ func New(ctx context.Context, propellerCfg *propellerConfig.Config, cfg *manager
 		podTemplateNamespace:     cfg.PodTemplateNamespace,
 		scanInterval:             cfg.ScanInterval.Duration,
 		shardStrategy:            shardStrategy,
+		deployments:              cfg.Deployments,
+	}
+
+	if cfg.LabelWorkflows {
+		manager.shardLabeler = &shardLabeler{
+			flyteworkflowClient: flyteworkflowClient,
+			metrics:             managerMetrics,
+		}
 	}
 
 	// configure leader elector
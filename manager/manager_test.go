@@ -174,6 +174,64 @@ func TestUpdatePods(t *testing.T) {
 	}
 }
 
+func TestCreateDeployments(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	scope := promutils.NewScope("create_deployments")
+	kubeClient := fake.NewSimpleClientset(podTemplate)
+	shardStrategy := createShardStrategy(3)
+
+	manager := Manager{
+		kubeClient:     kubeClient,
+		metrics:        newManagerMetrics(scope),
+		podApplication: "flytepropeller",
+		shardStrategy:  shardStrategy,
+		deployments:    true,
+	}
+
+	// create all deployments and validate state
+	err := manager.createDeployments(ctx)
+	assert.NoError(t, err)
+
+	kubeDeploymentsClient := kubeClient.AppsV1().Deployments("")
+	deployments, err := kubeDeploymentsClient.List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, shardStrategy.GetPodCount(), len(deployments.Items))
+
+	for _, deployment := range deployments.Items {
+		assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+		assert.Equal(t, "flytepropeller", deployment.Labels["app"])
+		assert.Equal(t, podTemplate.ObjectMeta.ResourceVersion, deployment.Annotations[podTemplateResourceVersion])
+		assert.Equal(t, deployment.Spec.Selector.MatchLabels, map[string]string{
+			"app":           "flytepropeller",
+			shardIndexLabel: deployment.Spec.Template.Labels[shardIndexLabel],
+		})
+		assert.Equal(t, "bar", deployment.Spec.Template.Annotations["foo"])
+		assert.Equal(t, "baz", deployment.Spec.Template.Labels["bar"])
+	}
+
+	// update the pod template and ensure deployments are updated rather than recreated
+	updatedPodTemplate := podTemplate.DeepCopy()
+	updatedPodTemplate.ObjectMeta.ResourceVersion = "1"
+	_, err = kubeClient.CoreV1().PodTemplates("").Update(ctx, updatedPodTemplate, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+
+	err = manager.createDeployments(ctx)
+	assert.NoError(t, err)
+
+	deployments, err = kubeDeploymentsClient.List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, shardStrategy.GetPodCount(), len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		assert.Equal(t, "1", deployment.Annotations[podTemplateResourceVersion])
+	}
+
+	// no pods are managed directly
+	pods, err := kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pods.Items))
+}
+
 func TestGetPodNames(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+
+	stderrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// shardLabeler writes the labels shard strategies select on to FlyteWorkflows that are missing them, for example
+// FlyteWorkflows created before sharding was introduced. Without these labels a FlyteWorkflow is not processed by any of
+// the managed FlytePropeller instances.
+type shardLabeler struct {
+	flyteworkflowClient versioned.Interface
+	metrics             *metrics
+}
+
+// Returns the labels a FlyteWorkflow is missing, derived from its execution id the same way the FlyteWorkflow builder
+// sets them.
+func missingShardLabels(w *v1alpha1.FlyteWorkflow) map[string]string {
+	executionID := w.GetExecutionID()
+	missing := map[string]string{}
+	executionIDLabel, ok := w.Labels[k8s.ExecutionIDLabel]
+	if !ok {
+		executionIDLabel = executionID.GetName()
+		if len(executionIDLabel) == 0 {
+			executionIDLabel = w.Name
+		}
+		missing[k8s.ExecutionIDLabel] = executionIDLabel
+	}
+
+	if _, ok := w.Labels[k8s.ProjectLabel]; !ok && len(executionID.GetProject()) > 0 {
+		missing[k8s.ProjectLabel] = executionID.GetProject()
+	}
+
+	if _, ok := w.Labels[k8s.DomainLabel]; !ok && len(executionID.GetDomain()) > 0 {
+		missing[k8s.DomainLabel] = executionID.GetDomain()
+	}
+
+	if _, ok := w.Labels[k8s.ShardKeyLabel]; !ok {
+		missing[k8s.ShardKeyLabel] = k8s.ComputeShardKey(executionIDLabel)
+	}
+
+	return missing
+}
+
+// labelWorkflows lists the FlyteWorkflows without a shard-key label in all namespaces and labels them.
+func (l *shardLabeler) labelWorkflows(ctx context.Context) error {
+	requirement, err := labels.NewRequirement(k8s.ShardKeyLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return err
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*requirement).String(),
+	}
+
+	workflows, err := l.flyteworkflowClient.FlyteworkflowV1alpha1().FlyteWorkflows(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to list unlabeled flyteworkflows [%v]", err)
+	}
+
+	errs := stderrors.ErrorCollection{}
+	for i := range workflows.Items {
+		w := workflows.Items[i].DeepCopy()
+		missing := missingShardLabels(w)
+		if len(missing) == 0 {
+			continue
+		}
+
+		if w.Labels == nil {
+			w.Labels = map[string]string{}
+		}
+
+		for key, value := range missing {
+			w.Labels[key] = value
+		}
+
+		_, err := l.flyteworkflowClient.FlyteworkflowV1alpha1().FlyteWorkflows(w.Namespace).Update(ctx, w, metav1.UpdateOptions{})
+		if err != nil {
+			errs.Append(fmt.Errorf("failed to label flyteworkflow '%s/%s' [%v]", w.Namespace, w.Name, err))
+			continue
+		}
+
+		l.metrics.WorkflowsLabeled.Inc()
+		logger.Infof(ctx, "labeled flyteworkflow '%s/%s' with shard-key '%s'", w.Namespace, w.Name, w.Labels[k8s.ShardKeyLabel])
+	}
+
+	return errs.ErrorOrDefault()
+}
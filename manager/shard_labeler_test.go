@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMissingShardLabels(t *testing.T) {
+	executionID := v1alpha1.ExecutionID{
+		WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{
+			Project: "flytesnacks",
+			Domain:  "development",
+			Name:    "exec",
+		},
+	}
+
+	t.Run("unlabeled", func(t *testing.T) {
+		missing := missingShardLabels(&v1alpha1.FlyteWorkflow{ExecutionID: executionID})
+		assert.Equal(t, map[string]string{
+			k8s.ExecutionIDLabel: "exec",
+			k8s.ProjectLabel:     "flytesnacks",
+			k8s.DomainLabel:      "development",
+			k8s.ShardKeyLabel:    k8s.ComputeShardKey("exec"),
+		}, missing)
+	})
+
+	t.Run("shard-key only", func(t *testing.T) {
+		missing := missingShardLabels(&v1alpha1.FlyteWorkflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					k8s.ExecutionIDLabel: "label",
+					k8s.ProjectLabel:     "p",
+					k8s.DomainLabel:      "d",
+				},
+			},
+			ExecutionID: executionID,
+		})
+		assert.Equal(t, map[string]string{k8s.ShardKeyLabel: k8s.ComputeShardKey("label")}, missing)
+	})
+
+	t.Run("without execution id", func(t *testing.T) {
+		missing := missingShardLabels(&v1alpha1.FlyteWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "name"}})
+		assert.Equal(t, map[string]string{
+			k8s.ExecutionIDLabel: "name",
+			k8s.ShardKeyLabel:    k8s.ComputeShardKey("name"),
+		}, missing)
+	})
+}
+
+func TestLabelWorkflows(t *testing.T) {
+	ctx := context.TODO()
+	flyteworkflowClient := fake.NewSimpleClientset(
+		&v1alpha1.FlyteWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "ns"},
+			ExecutionID: v1alpha1.ExecutionID{
+				WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{
+					Project: "flytesnacks",
+					Domain:  "development",
+					Name:    "unlabeled",
+				},
+			},
+		},
+		&v1alpha1.FlyteWorkflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "labeled",
+				Namespace: "ns",
+				Labels:    map[string]string{k8s.ShardKeyLabel: "31"},
+			},
+		},
+	)
+
+	labeler := shardLabeler{
+		flyteworkflowClient: flyteworkflowClient,
+		metrics:             newManagerMetrics(promutils.NewTestScope()),
+	}
+
+	assert.NoError(t, labeler.labelWorkflows(ctx))
+
+	workflows := flyteworkflowClient.FlyteworkflowV1alpha1().FlyteWorkflows("ns")
+	w, err := workflows.Get(ctx, "unlabeled", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, k8s.ComputeShardKey("unlabeled"), w.Labels[k8s.ShardKeyLabel])
+	assert.Equal(t, "flytesnacks", w.Labels[k8s.ProjectLabel])
+	assert.Equal(t, "development", w.Labels[k8s.DomainLabel])
+
+	w, err = workflows.Get(ctx, "labeled", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{k8s.ShardKeyLabel: "31"}, w.Labels)
+}
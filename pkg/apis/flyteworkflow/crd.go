@@ -38,4 +38,42 @@ var (
 			},
 		},
 	}
+
+	// SignalCRD defines the Signal resources that open the gate nodes of executions.
+	SignalCRD = apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("signals.%s", GroupName),
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: GroupName,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     "Signal",
+				ListKind: "SignalList",
+				Plural:   "signals",
+				Singular: "signal",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    "v1alpha1",
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"approved": {Type: "boolean"},
+										"message":  {Type: "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 )
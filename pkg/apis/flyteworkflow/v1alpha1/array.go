@@ -0,0 +1,160 @@
+package v1alpha1
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/bitarray"
+)
+
+// ArrayNodeSpec describes a node that runs its sub node once for every element of its list inputs.
+type ArrayNodeSpec struct {
+	// SubNodeSpec is the node that is executed for every element. Its inputs are the elements of the list inputs of the
+	// array node at the same index, all other inputs are passed to every sub node as they are.
+	SubNodeSpec *NodeSpec `json:"subNodeSpec"`
+	// Parallelism limits the number of sub nodes that run at the same time, 0 runs all of them at once.
+	//+optional.
+	Parallelism uint32 `json:"parallelism,omitempty"`
+	// MinSuccesses is the number of sub nodes that have to succeed for the array node to succeed.
+	//+optional.
+	MinSuccesses *uint32 `json:"minSuccesses,omitempty"`
+	// MinSuccessRatio is the ratio of sub nodes that have to succeed for the array node to succeed, it is ignored if
+	// MinSuccesses is set.
+	//+optional.
+	MinSuccessRatio *float32 `json:"minSuccessRatio,omitempty"`
+}
+
+func (in *ArrayNodeSpec) GetSubNodeSpec() *NodeSpec {
+	return in.SubNodeSpec
+}
+
+func (in *ArrayNodeSpec) GetParallelism() uint32 {
+	return in.Parallelism
+}
+
+func (in *ArrayNodeSpec) GetMinSuccesses() *uint32 {
+	return in.MinSuccesses
+}
+
+func (in *ArrayNodeSpec) GetMinSuccessRatio() *float32 {
+	return in.MinSuccessRatio
+}
+
+type ArrayNodePhase int
+
+const (
+	ArrayNodePhaseNone ArrayNodePhase = iota
+	ArrayNodePhaseExecuting
+	ArrayNodePhaseFailing
+	ArrayNodePhaseSucceeding
+)
+
+// ArrayNodeStatus tracks the sub nodes of an array node. The state of every sub node is packed into compact bit arrays,
+// indexed by the position of the sub node, so that the size of the status grows by a few bits per element only. The
+// task state of the sub nodes that are in flight, including the state of their plugins, is kept in full until the sub
+// node terminates.
+type ArrayNodeStatus struct {
+	MutableStruct
+	Phase                 ArrayNodePhase        `json:"phase,omitempty"`
+	ExecutionError        *core.ExecutionError  `json:"executionError,omitempty"`
+	SubNodePhases         bitarray.CompactArray `json:"subphase,omitempty"`
+	SubNodeTaskPhases     bitarray.CompactArray `json:"subtphase,omitempty"`
+	SubNodeRetryAttempts  bitarray.CompactArray `json:"subattempts,omitempty"`
+	SubNodeSystemFailures bitarray.CompactArray `json:"subsysfailures,omitempty"`
+	// SubNodeTaskStates holds the task state of the sub nodes in flight, by the index of the sub node.
+	//+optional.
+	SubNodeTaskStates map[int]*TaskNodeStatus `json:"subtstates,omitempty"`
+}
+
+func (in *ArrayNodeStatus) GetArrayNodePhase() ArrayNodePhase {
+	return in.Phase
+}
+
+func (in *ArrayNodeStatus) SetArrayNodePhase(phase ArrayNodePhase) {
+	if in.Phase != phase {
+		in.SetDirty()
+		in.Phase = phase
+	}
+}
+
+func (in *ArrayNodeStatus) GetExecutionError() *core.ExecutionError {
+	return in.ExecutionError
+}
+
+func (in *ArrayNodeStatus) SetExecutionError(executionError *core.ExecutionError) {
+	if in.ExecutionError != executionError {
+		in.SetDirty()
+		in.ExecutionError = executionError
+	}
+}
+
+func (in *ArrayNodeStatus) GetSubNodePhases() bitarray.CompactArray {
+	return in.SubNodePhases
+}
+
+func (in *ArrayNodeStatus) SetSubNodePhases(phases bitarray.CompactArray) {
+	in.SetDirty()
+	in.SubNodePhases = phases
+}
+
+func (in *ArrayNodeStatus) GetSubNodeTaskPhases() bitarray.CompactArray {
+	return in.SubNodeTaskPhases
+}
+
+func (in *ArrayNodeStatus) SetSubNodeTaskPhases(taskPhases bitarray.CompactArray) {
+	in.SetDirty()
+	in.SubNodeTaskPhases = taskPhases
+}
+
+func (in *ArrayNodeStatus) GetSubNodeRetryAttempts() bitarray.CompactArray {
+	return in.SubNodeRetryAttempts
+}
+
+func (in *ArrayNodeStatus) SetSubNodeRetryAttempts(attempts bitarray.CompactArray) {
+	in.SetDirty()
+	in.SubNodeRetryAttempts = attempts
+}
+
+func (in *ArrayNodeStatus) GetSubNodeSystemFailures() bitarray.CompactArray {
+	return in.SubNodeSystemFailures
+}
+
+func (in *ArrayNodeStatus) SetSubNodeSystemFailures(systemFailures bitarray.CompactArray) {
+	in.SetDirty()
+	in.SubNodeSystemFailures = systemFailures
+}
+
+func (in *ArrayNodeStatus) GetSubNodeTaskStates() map[int]*TaskNodeStatus {
+	return in.SubNodeTaskStates
+}
+
+func (in *ArrayNodeStatus) SetSubNodeTaskStates(taskStates map[int]*TaskNodeStatus) {
+	in.SetDirty()
+	in.SubNodeTaskStates = taskStates
+}
+
+// DeepCopyInto is written by hand because the compact arrays do not implement deep copies themselves.
+func (in *ArrayNodeStatus) DeepCopyInto(out *ArrayNodeStatus) {
+	*out = *in
+	out.MutableStruct = in.MutableStruct
+	// The execution error is never mutated, it is safe to share it.
+	out.SubNodePhases = deepCopyCompactArray(in.SubNodePhases)
+	out.SubNodeTaskPhases = deepCopyCompactArray(in.SubNodeTaskPhases)
+	out.SubNodeRetryAttempts = deepCopyCompactArray(in.SubNodeRetryAttempts)
+	out.SubNodeSystemFailures = deepCopyCompactArray(in.SubNodeSystemFailures)
+	if in.SubNodeTaskStates != nil {
+		out.SubNodeTaskStates = make(map[int]*TaskNodeStatus, len(in.SubNodeTaskStates))
+		for index, taskState := range in.SubNodeTaskStates {
+			out.SubNodeTaskStates[index] = taskState.DeepCopy()
+		}
+	}
+}
+
+func deepCopyCompactArray(in bitarray.CompactArray) bitarray.CompactArray {
+	out := in
+	if in.BitSet != nil {
+		bitSet := make(bitarray.BitSet, len(*in.BitSet))
+		copy(bitSet, *in.BitSet)
+		out.BitSet = &bitSet
+	}
+
+	return out
+}
@@ -64,6 +64,55 @@ func (in *BooleanExpression) DeepCopyInto(out *BooleanExpression) {
 	// Once we figure out the autogenerate story we can replace this
 }
 
+// Operators of ComparisonExpressions that propeller supports on top of the ones defined in flyteidl, to evaluate
+// conditions on complex literals. Operators are open enums in proto3, so these values are carried as-is from the
+// WorkflowClosure to the BranchNodeSpec.
+const (
+	// ComparisonExpressionLengthOffset is added to the flyteidl operators (EQ, NEQ, GT, GTE, LT and LTE) to compare the
+	// length of the collection or map on the left with the integer on the right.
+	ComparisonExpressionLengthOffset core.ComparisonExpression_Operator = 100
+
+	ComparisonExpressionLengthEQ  = ComparisonExpressionLengthOffset + core.ComparisonExpression_EQ
+	ComparisonExpressionLengthNEQ = ComparisonExpressionLengthOffset + core.ComparisonExpression_NEQ
+	ComparisonExpressionLengthGT  = ComparisonExpressionLengthOffset + core.ComparisonExpression_GT
+	ComparisonExpressionLengthGTE = ComparisonExpressionLengthOffset + core.ComparisonExpression_GTE
+	ComparisonExpressionLengthLT  = ComparisonExpressionLengthOffset + core.ComparisonExpression_LT
+	ComparisonExpressionLengthLTE = ComparisonExpressionLengthOffset + core.ComparisonExpression_LTE
+
+	// ComparisonExpressionHasKey checks whether the map on the left has the string on the right as a key.
+	ComparisonExpressionHasKey    core.ComparisonExpression_Operator = 200
+	ComparisonExpressionNotHasKey core.ComparisonExpression_Operator = 201
+
+	// ComparisonExpressionIsNone checks whether the variable on the left is None, or not set at all. The right operand
+	// is ignored.
+	ComparisonExpressionIsNone    core.ComparisonExpression_Operator = 300
+	ComparisonExpressionIsNotNone core.ComparisonExpression_Operator = 301
+)
+
+// GetLengthComparisonOperator returns the flyteidl operator the length is compared with, if op compares the length of
+// a collection or a map.
+func GetLengthComparisonOperator(op core.ComparisonExpression_Operator) (core.ComparisonExpression_Operator, bool) {
+	if op >= ComparisonExpressionLengthEQ && op <= ComparisonExpressionLengthLTE {
+		return op - ComparisonExpressionLengthOffset, true
+	}
+
+	return op, false
+}
+
+// IsComplexComparisonOperator returns whether op is one of the operators propeller supports on complex literals.
+func IsComplexComparisonOperator(op core.ComparisonExpression_Operator) bool {
+	if _, ok := GetLengthComparisonOperator(op); ok {
+		return true
+	}
+
+	switch op {
+	case ComparisonExpressionHasKey, ComparisonExpressionNotHasKey, ComparisonExpressionIsNone, ComparisonExpressionIsNotNone:
+		return true
+	}
+
+	return false
+}
+
 type IfBlock struct {
 	Condition BooleanExpression `json:"condition"`
 	ThenNode  *NodeID           `json:"then"`
@@ -77,11 +126,24 @@ func (in *IfBlock) GetThenNode() *NodeID {
 	return in.ThenNode
 }
 
+// ElseFailMetadata customizes the error a branch node fails with when no branch is satisfied and it has an ElseFail.
+type ElseFailMetadata struct {
+	// Code of the error, UserProvidedError if empty.
+	Code string `json:"code,omitempty"`
+	// MessageTemplate is a text/template the message of the error is rendered with, instead of the message of the
+	// ElseFail. It's executed with the values of the variables compared by the conditions of the branch node, e.g.
+	// "{{ .Values.x }} is not a supported size".
+	MessageTemplate string `json:"messageTemplate,omitempty"`
+	// Kind of the error, USER if unknown.
+	Kind core.ExecutionError_ErrorKind `json:"kind,omitempty"`
+}
+
 type BranchNodeSpec struct {
-	If       IfBlock    `json:"if"`
-	ElseIf   []*IfBlock `json:"elseIf,omitempty"`
-	Else     *NodeID    `json:"else,omitempty"`
-	ElseFail *Error     `json:"elseFail,omitempty"`
+	If               IfBlock           `json:"if"`
+	ElseIf           []*IfBlock        `json:"elseIf,omitempty"`
+	Else             *NodeID           `json:"else,omitempty"`
+	ElseFail         *Error            `json:"elseFail,omitempty"`
+	ElseFailMetadata *ElseFailMetadata `json:"elseFailMetadata,omitempty"`
 }
 
 func (in *BranchNodeSpec) GetIf() ExecutableIfBlock {
@@ -106,3 +168,7 @@ func (in *BranchNodeSpec) GetElseFail() *core.Error {
 	}
 	return nil
 }
+
+func (in *BranchNodeSpec) GetElseFailMetadata() *ElseFailMetadata {
+	return in.ElseFailMetadata
+}
@@ -24,3 +24,43 @@ func TestMarshalUnMarshal_BranchTask(t *testing.T) {
 		assert.NotEmpty(t, raw)
 	}
 }
+
+func TestComplexComparisonOperators(t *testing.T) {
+	op, ok := v1alpha1.GetLengthComparisonOperator(v1alpha1.ComparisonExpressionLengthGTE)
+	assert.True(t, ok)
+	assert.Equal(t, core.ComparisonExpression_GTE, op)
+
+	_, ok = v1alpha1.GetLengthComparisonOperator(core.ComparisonExpression_GTE)
+	assert.False(t, ok)
+	_, ok = v1alpha1.GetLengthComparisonOperator(v1alpha1.ComparisonExpressionHasKey)
+	assert.False(t, ok)
+
+	assert.True(t, v1alpha1.IsComplexComparisonOperator(v1alpha1.ComparisonExpressionLengthEQ))
+	assert.True(t, v1alpha1.IsComplexComparisonOperator(v1alpha1.ComparisonExpressionNotHasKey))
+	assert.True(t, v1alpha1.IsComplexComparisonOperator(v1alpha1.ComparisonExpressionIsNone))
+	assert.False(t, v1alpha1.IsComplexComparisonOperator(core.ComparisonExpression_LTE))
+
+	t.Run("MarshalUnmarshal", func(t *testing.T) {
+		b := v1alpha1.IfBlock{
+			Condition: v1alpha1.BooleanExpression{
+				BooleanExpression: &core.BooleanExpression{
+					Expr: &core.BooleanExpression_Comparison{
+						Comparison: &core.ComparisonExpression{
+							Operator:  v1alpha1.ComparisonExpressionHasKey,
+							LeftValue: &core.Operand{Val: &core.Operand_Var{Var: "x"}},
+							RightValue: &core.Operand{Val: &core.Operand_Primitive{
+								Primitive: &core.Primitive{Value: &core.Primitive_StringValue{StringValue: "k"}},
+							}},
+						},
+					},
+				},
+			},
+		}
+
+		raw, err := json.Marshal(b)
+		assert.NoError(t, err)
+		o := v1alpha1.IfBlock{}
+		assert.NoError(t, json.Unmarshal(raw, &o))
+		assert.Equal(t, v1alpha1.ComparisonExpressionHasKey, o.GetCondition().GetComparison().GetOperator())
+	})
+}
@@ -28,6 +28,33 @@ type ExecutionConfig struct {
 	// Defines the resource requests and limits specified for tasks run as part of this execution that ought to be
 	// applied at execution time.
 	TaskResources TaskResources
+	// Skips reading cached outputs of tasks, while still writing their outputs to the cache. This refreshes stale cached
+	// outputs without disabling caching for the tasks.
+	OverwriteCache bool
+	// Labels and annotations propagated to all the pods and child executions created by the execution, e.g. for cost
+	// attribution. They override the labels and annotations of the workflow.
+	Labels      map[string]string
+	Annotations map[string]string
+	// The identity the pods of the execution run as, overriding the one of its security context.
+	Identity Identity
+	// Which node and task events of the execution are recorded, one of ALL, TERMINAL_ONLY or NONE. Overrides the event
+	// QoS configured for propeller if set.
+	EventQoS string
+	// The prefix the raw outputs of the nodes of the execution are written under, overriding the raw output data config
+	// of the workflow if set.
+	RawOutputDataPrefix string
+}
+
+// Identity is the service account and IAM role the pods created by an execution, or a single node, run as. Empty fields
+// do not override the identity being overridden.
+type Identity struct {
+	K8sServiceAccount string `json:"k8sServiceAccount,omitempty"`
+	IamRole           string `json:"iamRole,omitempty"`
+}
+
+// IsEmpty returns true if the identity overrides neither the service account nor the IAM role.
+func (in Identity) IsEmpty() bool {
+	return len(in.K8sServiceAccount) == 0 && len(in.IamRole) == 0
 }
 
 type TaskPluginOverride struct {
@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ExecutionEnvironment is a snapshot of the runtime conditions a task node executed under. It is kept in the status of
+// the node, and sent with its events, so that results can be traced back to what actually ran long after the pods of
+// the task are gone.
+type ExecutionEnvironment struct {
+	// Images maps the containers of the task to the digest of the image the kubelet actually pulled for them, which may
+	// differ from the image the task asked for if it refers to a mutable tag.
+	Images map[string]string `json:"images,omitempty"`
+	// Resources maps the containers of the task to their resources, after platform defaults and limits were applied.
+	Resources map[string]v1.ResourceRequirements `json:"resources,omitempty"`
+	// PluginID is the id of the plugin that executed the task.
+	PluginID string `json:"pluginId,omitempty"`
+	// PropellerVersion is the version of propeller that executed the task.
+	PropellerVersion string `json:"propellerVersion,omitempty"`
+	// ConfigHash is a hash of the configuration of propeller at the time the task executed.
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
+func (in *ExecutionEnvironment) GetImages() map[string]string {
+	if in == nil {
+		return nil
+	}
+	return in.Images
+}
+
+func (in *ExecutionEnvironment) GetResources() map[string]v1.ResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	return in.Resources
+}
+
+func (in *ExecutionEnvironment) GetPluginID() string {
+	if in == nil {
+		return ""
+	}
+	return in.PluginID
+}
+
+func (in *ExecutionEnvironment) GetPropellerVersion() string {
+	if in == nil {
+		return ""
+	}
+	return in.PropellerVersion
+}
+
+func (in *ExecutionEnvironment) GetConfigHash() string {
+	if in == nil {
+		return ""
+	}
+	return in.ConfigHash
+}
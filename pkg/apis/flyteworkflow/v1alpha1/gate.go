@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GateTaskType is the type of the task templates that the compiler turns into gate nodes, the custom field of these
+// templates holds the GateNodeSpec.
+const GateTaskType = "gate"
+
+type GateNodeKind string
+
+const (
+	// GateNodeKindSleep blocks for a fixed duration.
+	GateNodeKindSleep GateNodeKind = "sleep"
+	// GateNodeKindSignal blocks until a signal with the configured id is sent to the execution.
+	GateNodeKindSignal GateNodeKind = "signal"
+	// GateNodeKindApprove blocks until a signal with the configured id is sent to the execution, and fails the node
+	// unless the signal approves it.
+	GateNodeKindApprove GateNodeKind = "approve"
+)
+
+// GateNodeSpec describes a node that blocks the execution of its downstream nodes until a condition is met.
+type GateNodeSpec struct {
+	Kind GateNodeKind `json:"kind"`
+	// Sleep is the duration a sleep gate blocks for, counted from the start of the node.
+	//+optional.
+	Sleep *v1.Duration `json:"sleep,omitempty"`
+	// SignalID identifies the signal that signal and approve gates wait for.
+	//+optional.
+	SignalID string `json:"signalId,omitempty"`
+}
+
+func (in *GateNodeSpec) GetGateKind() GateNodeKind {
+	return in.Kind
+}
+
+func (in *GateNodeSpec) GetSleepDuration() time.Duration {
+	if in.Sleep == nil {
+		return 0
+	}
+	return in.Sleep.Duration
+}
+
+func (in *GateNodeSpec) GetSignalID() string {
+	return in.SignalID
+}
+
+// Signal is sent to an execution to open the signal and approve gates that wait for it. Signals are created in the
+// namespace of the execution and are named after the execution and the signal id, see SignalName.
+type Signal struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          SignalSpec `json:"spec"`
+}
+
+type SignalSpec struct {
+	// Approved is only meaningful for approve gates, which fail if the signal does not approve them.
+	Approved bool `json:"approved,omitempty"`
+	// Message describes the reason of the decision, e.g. who approved it.
+	//+optional.
+	Message string `json:"message,omitempty"`
+}
+
+// SignalList is a list of Signal resources
+type SignalList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata"`
+
+	Items []Signal `json:"items"`
+}
+
+// SignalName returns the name of the Signal resource that opens the gates with the given signal id in the execution.
+func SignalName(executionName, signalID string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s", executionName, signalID))
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/bitarray"
 	"github.com/flyteorg/flytestdlib/storage"
 )
 
@@ -46,6 +47,8 @@ const (
 	NodeKindWorkflow NodeKind = "workflow" // Either an inline workflow or a remote workflow definition
 	NodeKindStart    NodeKind = "start"    // Start node is a special node
 	NodeKindEnd      NodeKind = "end"
+	NodeKindGate     NodeKind = "gate"  // A Gate node blocks until a sleep elapses or a signal is received
+	NodeKindArray    NodeKind = "array" // An Array node runs its sub node for every element of its list inputs
 )
 
 // NodePhase indicates the current state of the Node (phase). A node progresses through these states
@@ -120,6 +123,9 @@ const (
 	// its failure reason. In other words, its failure will mask the original failure for the workflow. It's imperative
 	// failure nodes should be very simple, very resilient and very well tested.
 	WorkflowPhaseHandlingFailureNode
+	// WorkflowPhaseQueued is the phase a workflow is held in, before it starts, while the concurrency limits of its
+	// namespace or launch plan do not allow it to run. It goes back to Ready once it is admitted.
+	WorkflowPhaseQueued
 )
 
 func (p WorkflowPhase) String() string {
@@ -140,6 +146,8 @@ func (p WorkflowPhase) String() string {
 		return "Aborted"
 	case WorkflowPhaseHandlingFailureNode:
 		return "HandlingFailureNode"
+	case WorkflowPhaseQueued:
+		return "Queued"
 	}
 	return "Unknown"
 }
@@ -197,7 +205,10 @@ type TaskType = string
 // Interface for a Task that can be executed
 type ExecutableTask interface {
 	TaskType() TaskType
+	// CoreTask returns the template of the task. If the template is referenced, only its id and type are set.
 	CoreTask() *core.TaskTemplate
+	// GetTemplateRef returns the location of the template of the task in the DataStore, if it is not stored inline.
+	GetTemplateRef() DataReference
 }
 
 // Interface for the executable If block
@@ -225,6 +236,7 @@ type ExecutableDynamicNodeStatus interface {
 	GetDynamicNodePhase() DynamicNodePhase
 	GetDynamicNodeReason() string
 	GetExecutionError() *core.ExecutionError
+	GetWorkflowChecksum() string
 }
 
 type MutableDynamicNodeStatus interface {
@@ -234,6 +246,7 @@ type MutableDynamicNodeStatus interface {
 	SetDynamicNodePhase(phase DynamicNodePhase)
 	SetDynamicNodeReason(reason string)
 	SetExecutionError(executionError *core.ExecutionError)
+	SetWorkflowChecksum(checksum string)
 }
 
 // Interface for Branch node. All the methods are purely read only except for the GetExecutionStatus.
@@ -243,11 +256,37 @@ type ExecutableBranchNode interface {
 	GetElse() *NodeID
 	GetElseIf() []ExecutableIfBlock
 	GetElseFail() *core.Error
+	GetElseFailMetadata() *ElseFailMetadata
+}
+
+// Interface for array node status.
+type ExecutableArrayNodeStatus interface {
+	GetArrayNodePhase() ArrayNodePhase
+	GetExecutionError() *core.ExecutionError
+	GetSubNodePhases() bitarray.CompactArray
+	GetSubNodeTaskPhases() bitarray.CompactArray
+	GetSubNodeRetryAttempts() bitarray.CompactArray
+	GetSubNodeSystemFailures() bitarray.CompactArray
+	GetSubNodeTaskStates() map[int]*TaskNodeStatus
+}
+
+type MutableArrayNodeStatus interface {
+	Mutable
+	ExecutableArrayNodeStatus
+
+	SetArrayNodePhase(phase ArrayNodePhase)
+	SetExecutionError(executionError *core.ExecutionError)
+	SetSubNodePhases(phases bitarray.CompactArray)
+	SetSubNodeTaskPhases(taskPhases bitarray.CompactArray)
+	SetSubNodeRetryAttempts(attempts bitarray.CompactArray)
+	SetSubNodeSystemFailures(systemFailures bitarray.CompactArray)
+	SetSubNodeTaskStates(taskStates map[int]*TaskNodeStatus)
 }
 
 type ExecutableWorkflowNodeStatus interface {
 	GetWorkflowNodePhase() WorkflowNodePhase
 	GetExecutionError() *core.ExecutionError
+	GetKilledAt() *metav1.Time
 }
 
 type MutableWorkflowNodeStatus interface {
@@ -255,6 +294,7 @@ type MutableWorkflowNodeStatus interface {
 	ExecutableWorkflowNodeStatus
 	SetWorkflowNodePhase(phase WorkflowNodePhase)
 	SetExecutionError(executionError *core.ExecutionError)
+	SetKilledAt(killedAt *metav1.Time)
 }
 
 type Mutable interface {
@@ -269,8 +309,11 @@ type MutableNodeStatus interface {
 	SetParentNodeID(n *NodeID)
 	SetParentTaskID(t *core.TaskExecutionIdentifier)
 	UpdatePhase(phase NodePhase, occurredAt metav1.Time, reason string, err *core.ExecutionError)
+	SetReasonCode(code string)
+	SetRetryBackoff(backoff *RetryBackoff)
 	IncrementAttempts() uint32
 	IncrementSystemFailures() uint32
+	IncrementTimeoutFailures() uint32
 	SetCached()
 	ResetDirty()
 
@@ -286,6 +329,9 @@ type MutableNodeStatus interface {
 	GetOrCreateDynamicNodeStatus() MutableDynamicNodeStatus
 	GetDynamicNodeStatus() MutableDynamicNodeStatus
 	ClearDynamicNodeStatus()
+	GetOrCreateArrayNodeStatus() MutableArrayNodeStatus
+	GetArrayNodeStatus() MutableArrayNodeStatus
+	ClearArrayNodeStatus()
 	ClearLastAttemptStartedAt()
 	ClearSubNodeStatus()
 }
@@ -305,14 +351,17 @@ type ExecutableNodeStatus interface {
 	GetPhase() NodePhase
 	GetQueuedAt() *metav1.Time
 	GetLastAttemptStartedAt() *metav1.Time
+	GetRetryBackoff() *RetryBackoff
 	GetParentNodeID() *NodeID
 	GetParentTaskID() *core.TaskExecutionIdentifier
 	GetDataDir() DataReference
 	GetOutputDir() DataReference
 	GetMessage() string
+	GetReasonCode() string
 	GetExecutionError() *core.ExecutionError
 	GetAttempts() uint32
 	GetSystemFailures() uint32
+	GetTimeoutFailures() uint32
 	GetWorkflowNodeStatus() ExecutableWorkflowNodeStatus
 	GetTaskNodeStatus() ExecutableTaskNodeStatus
 
@@ -336,6 +385,7 @@ type ExecutableTaskNodeStatus interface {
 	GetPluginStateVersion() uint32
 	GetBarrierClockTick() uint32
 	GetLastPhaseUpdatedAt() time.Time
+	GetExecutionEnvironment() *ExecutionEnvironment
 }
 
 type MutableTaskNodeStatus interface {
@@ -347,12 +397,30 @@ type MutableTaskNodeStatus interface {
 	SetPluginState([]byte)
 	SetPluginStateVersion(uint32)
 	SetBarrierClockTick(tick uint32)
+	SetExecutionEnvironment(env *ExecutionEnvironment)
 }
 
 // Interface for a Child Workflow Node
 type ExecutableWorkflowNode interface {
 	GetLaunchPlanRefID() *LaunchPlanRefID
 	GetSubWorkflowRef() *WorkflowID
+	IsDiscoverable() bool
+	GetDiscoveryVersion() string
+}
+
+// Interface for a Gate Node
+type ExecutableGateNode interface {
+	GetGateKind() GateNodeKind
+	GetSleepDuration() time.Duration
+	GetSignalID() string
+}
+
+// Interface for an Array Node
+type ExecutableArrayNode interface {
+	GetSubNodeSpec() *NodeSpec
+	GetParallelism() uint32
+	GetMinSuccesses() *uint32
+	GetMinSuccessRatio() *float32
 }
 
 type BaseNode interface {
@@ -368,6 +436,8 @@ type ExecutableNode interface {
 	GetTaskID() *TaskID
 	GetBranchNode() ExecutableBranchNode
 	GetWorkflowNode() ExecutableWorkflowNode
+	GetGateNode() ExecutableGateNode
+	GetArrayNode() ExecutableArrayNode
 	GetOutputAlias() []Alias
 	GetInputBindings() []*Binding
 	GetResources() *v1.ResourceRequirements
@@ -379,6 +449,19 @@ type ExecutableNode interface {
 	GetName() string
 }
 
+// NodeMetadataOverrides is implemented by nodes that set labels and annotations of their own on the pods and child
+// executions they create, overriding the ones of the execution.
+type NodeMetadataOverrides interface {
+	GetLabels() map[string]string
+	GetAnnotations() map[string]string
+}
+
+// NodeIdentityOverride is implemented by nodes that may override the identity the pods and child executions they create
+// run as.
+type NodeIdentityOverride interface {
+	GetIdentity() *Identity
+}
+
 // Interface for the Workflow p. This is the mutable portion for a Workflow
 type ExecutableWorkflowStatus interface {
 	NodeStatusGetter
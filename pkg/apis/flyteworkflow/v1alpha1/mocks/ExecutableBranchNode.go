@@ -82,6 +82,40 @@ func (_m *ExecutableBranchNode) GetElseFail() *core.Error {
 	return r0
 }
 
+type ExecutableBranchNode_GetElseFailMetadata struct {
+	*mock.Call
+}
+
+func (_m ExecutableBranchNode_GetElseFailMetadata) Return(_a0 *v1alpha1.ElseFailMetadata) *ExecutableBranchNode_GetElseFailMetadata {
+	return &ExecutableBranchNode_GetElseFailMetadata{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableBranchNode) OnGetElseFailMetadata() *ExecutableBranchNode_GetElseFailMetadata {
+	c_call := _m.On("GetElseFailMetadata")
+	return &ExecutableBranchNode_GetElseFailMetadata{Call: c_call}
+}
+
+func (_m *ExecutableBranchNode) OnGetElseFailMetadataMatch(matchers ...interface{}) *ExecutableBranchNode_GetElseFailMetadata {
+	c_call := _m.On("GetElseFailMetadata", matchers...)
+	return &ExecutableBranchNode_GetElseFailMetadata{Call: c_call}
+}
+
+// GetElseFailMetadata provides a mock function with given fields:
+func (_m *ExecutableBranchNode) GetElseFailMetadata() *v1alpha1.ElseFailMetadata {
+	ret := _m.Called()
+
+	var r0 *v1alpha1.ElseFailMetadata
+	if rf, ok := ret.Get(0).(func() *v1alpha1.ElseFailMetadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.ElseFailMetadata)
+		}
+	}
+
+	return r0
+}
+
 type ExecutableBranchNode_GetElseIf struct {
 	*mock.Call
 }
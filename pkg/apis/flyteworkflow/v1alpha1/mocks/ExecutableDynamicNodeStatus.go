@@ -111,3 +111,35 @@ func (_m *ExecutableDynamicNodeStatus) GetExecutionError() *core.ExecutionError
 
 	return r0
 }
+
+type ExecutableDynamicNodeStatus_GetWorkflowChecksum struct {
+	*mock.Call
+}
+
+func (_m ExecutableDynamicNodeStatus_GetWorkflowChecksum) Return(_a0 string) *ExecutableDynamicNodeStatus_GetWorkflowChecksum {
+	return &ExecutableDynamicNodeStatus_GetWorkflowChecksum{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableDynamicNodeStatus) OnGetWorkflowChecksum() *ExecutableDynamicNodeStatus_GetWorkflowChecksum {
+	c_call := _m.On("GetWorkflowChecksum")
+	return &ExecutableDynamicNodeStatus_GetWorkflowChecksum{Call: c_call}
+}
+
+func (_m *ExecutableDynamicNodeStatus) OnGetWorkflowChecksumMatch(matchers ...interface{}) *ExecutableDynamicNodeStatus_GetWorkflowChecksum {
+	c_call := _m.On("GetWorkflowChecksum", matchers...)
+	return &ExecutableDynamicNodeStatus_GetWorkflowChecksum{Call: c_call}
+}
+
+// GetWorkflowChecksum provides a mock function with given fields:
+func (_m *ExecutableDynamicNodeStatus) GetWorkflowChecksum() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
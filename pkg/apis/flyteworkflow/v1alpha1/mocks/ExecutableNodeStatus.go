@@ -20,6 +20,11 @@ type ExecutableNodeStatus struct {
 	mock.Mock
 }
 
+// ClearArrayNodeStatus provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) ClearArrayNodeStatus() {
+	_m.Called()
+}
+
 // ClearDynamicNodeStatus provides a mock function with given fields:
 func (_m *ExecutableNodeStatus) ClearDynamicNodeStatus() {
 	_m.Called()
@@ -45,6 +50,40 @@ func (_m *ExecutableNodeStatus) ClearWorkflowStatus() {
 	_m.Called()
 }
 
+type ExecutableNodeStatus_GetArrayNodeStatus struct {
+	*mock.Call
+}
+
+func (_m ExecutableNodeStatus_GetArrayNodeStatus) Return(_a0 v1alpha1.MutableArrayNodeStatus) *ExecutableNodeStatus_GetArrayNodeStatus {
+	return &ExecutableNodeStatus_GetArrayNodeStatus{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableNodeStatus) OnGetArrayNodeStatus() *ExecutableNodeStatus_GetArrayNodeStatus {
+	c_call := _m.On("GetArrayNodeStatus")
+	return &ExecutableNodeStatus_GetArrayNodeStatus{Call: c_call}
+}
+
+func (_m *ExecutableNodeStatus) OnGetArrayNodeStatusMatch(matchers ...interface{}) *ExecutableNodeStatus_GetArrayNodeStatus {
+	c_call := _m.On("GetArrayNodeStatus", matchers...)
+	return &ExecutableNodeStatus_GetArrayNodeStatus{Call: c_call}
+}
+
+// GetArrayNodeStatus provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) GetArrayNodeStatus() v1alpha1.MutableArrayNodeStatus {
+	ret := _m.Called()
+
+	var r0 v1alpha1.MutableArrayNodeStatus
+	if rf, ok := ret.Get(0).(func() v1alpha1.MutableArrayNodeStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1alpha1.MutableArrayNodeStatus)
+		}
+	}
+
+	return r0
+}
+
 type ExecutableNodeStatus_GetAttempts struct {
 	*mock.Call
 }
@@ -345,6 +384,40 @@ func (_m *ExecutableNodeStatus) GetNodeExecutionStatus(ctx context.Context, id s
 	return r0
 }
 
+type ExecutableNodeStatus_GetOrCreateArrayNodeStatus struct {
+	*mock.Call
+}
+
+func (_m ExecutableNodeStatus_GetOrCreateArrayNodeStatus) Return(_a0 v1alpha1.MutableArrayNodeStatus) *ExecutableNodeStatus_GetOrCreateArrayNodeStatus {
+	return &ExecutableNodeStatus_GetOrCreateArrayNodeStatus{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableNodeStatus) OnGetOrCreateArrayNodeStatus() *ExecutableNodeStatus_GetOrCreateArrayNodeStatus {
+	c_call := _m.On("GetOrCreateArrayNodeStatus")
+	return &ExecutableNodeStatus_GetOrCreateArrayNodeStatus{Call: c_call}
+}
+
+func (_m *ExecutableNodeStatus) OnGetOrCreateArrayNodeStatusMatch(matchers ...interface{}) *ExecutableNodeStatus_GetOrCreateArrayNodeStatus {
+	c_call := _m.On("GetOrCreateArrayNodeStatus", matchers...)
+	return &ExecutableNodeStatus_GetOrCreateArrayNodeStatus{Call: c_call}
+}
+
+// GetOrCreateArrayNodeStatus provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) GetOrCreateArrayNodeStatus() v1alpha1.MutableArrayNodeStatus {
+	ret := _m.Called()
+
+	var r0 v1alpha1.MutableArrayNodeStatus
+	if rf, ok := ret.Get(0).(func() v1alpha1.MutableArrayNodeStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1alpha1.MutableArrayNodeStatus)
+		}
+	}
+
+	return r0
+}
+
 type ExecutableNodeStatus_GetOrCreateBranchStatus struct {
 	*mock.Call
 }
@@ -647,6 +720,72 @@ func (_m *ExecutableNodeStatus) GetQueuedAt() *v1.Time {
 	return r0
 }
 
+type ExecutableNodeStatus_GetReasonCode struct {
+	*mock.Call
+}
+
+func (_m ExecutableNodeStatus_GetReasonCode) Return(_a0 string) *ExecutableNodeStatus_GetReasonCode {
+	return &ExecutableNodeStatus_GetReasonCode{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableNodeStatus) OnGetReasonCode() *ExecutableNodeStatus_GetReasonCode {
+	c_call := _m.On("GetReasonCode")
+	return &ExecutableNodeStatus_GetReasonCode{Call: c_call}
+}
+
+func (_m *ExecutableNodeStatus) OnGetReasonCodeMatch(matchers ...interface{}) *ExecutableNodeStatus_GetReasonCode {
+	c_call := _m.On("GetReasonCode", matchers...)
+	return &ExecutableNodeStatus_GetReasonCode{Call: c_call}
+}
+
+// GetReasonCode provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) GetReasonCode() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type ExecutableNodeStatus_GetRetryBackoff struct {
+	*mock.Call
+}
+
+func (_m ExecutableNodeStatus_GetRetryBackoff) Return(_a0 *v1alpha1.RetryBackoff) *ExecutableNodeStatus_GetRetryBackoff {
+	return &ExecutableNodeStatus_GetRetryBackoff{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableNodeStatus) OnGetRetryBackoff() *ExecutableNodeStatus_GetRetryBackoff {
+	c_call := _m.On("GetRetryBackoff")
+	return &ExecutableNodeStatus_GetRetryBackoff{Call: c_call}
+}
+
+func (_m *ExecutableNodeStatus) OnGetRetryBackoffMatch(matchers ...interface{}) *ExecutableNodeStatus_GetRetryBackoff {
+	c_call := _m.On("GetRetryBackoff", matchers...)
+	return &ExecutableNodeStatus_GetRetryBackoff{Call: c_call}
+}
+
+// GetRetryBackoff provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) GetRetryBackoff() *v1alpha1.RetryBackoff {
+	ret := _m.Called()
+
+	var r0 *v1alpha1.RetryBackoff
+	if rf, ok := ret.Get(0).(func() *v1alpha1.RetryBackoff); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.RetryBackoff)
+		}
+	}
+
+	return r0
+}
+
 type ExecutableNodeStatus_GetStartedAt struct {
 	*mock.Call
 }
@@ -815,6 +954,38 @@ func (_m *ExecutableNodeStatus) GetTaskStatus() v1alpha1.MutableTaskNodeStatus {
 	return r0
 }
 
+type ExecutableNodeStatus_GetTimeoutFailures struct {
+	*mock.Call
+}
+
+func (_m ExecutableNodeStatus_GetTimeoutFailures) Return(_a0 uint32) *ExecutableNodeStatus_GetTimeoutFailures {
+	return &ExecutableNodeStatus_GetTimeoutFailures{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableNodeStatus) OnGetTimeoutFailures() *ExecutableNodeStatus_GetTimeoutFailures {
+	c_call := _m.On("GetTimeoutFailures")
+	return &ExecutableNodeStatus_GetTimeoutFailures{Call: c_call}
+}
+
+func (_m *ExecutableNodeStatus) OnGetTimeoutFailuresMatch(matchers ...interface{}) *ExecutableNodeStatus_GetTimeoutFailures {
+	c_call := _m.On("GetTimeoutFailures", matchers...)
+	return &ExecutableNodeStatus_GetTimeoutFailures{Call: c_call}
+}
+
+// GetTimeoutFailures provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) GetTimeoutFailures() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 type ExecutableNodeStatus_GetWorkflowNodeStatus struct {
 	*mock.Call
 }
@@ -947,6 +1118,38 @@ func (_m *ExecutableNodeStatus) IncrementSystemFailures() uint32 {
 	return r0
 }
 
+type ExecutableNodeStatus_IncrementTimeoutFailures struct {
+	*mock.Call
+}
+
+func (_m ExecutableNodeStatus_IncrementTimeoutFailures) Return(_a0 uint32) *ExecutableNodeStatus_IncrementTimeoutFailures {
+	return &ExecutableNodeStatus_IncrementTimeoutFailures{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableNodeStatus) OnIncrementTimeoutFailures() *ExecutableNodeStatus_IncrementTimeoutFailures {
+	c_call := _m.On("IncrementTimeoutFailures")
+	return &ExecutableNodeStatus_IncrementTimeoutFailures{Call: c_call}
+}
+
+func (_m *ExecutableNodeStatus) OnIncrementTimeoutFailuresMatch(matchers ...interface{}) *ExecutableNodeStatus_IncrementTimeoutFailures {
+	c_call := _m.On("IncrementTimeoutFailures", matchers...)
+	return &ExecutableNodeStatus_IncrementTimeoutFailures{Call: c_call}
+}
+
+// IncrementTimeoutFailures provides a mock function with given fields:
+func (_m *ExecutableNodeStatus) IncrementTimeoutFailures() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 type ExecutableNodeStatus_IsCached struct {
 	*mock.Call
 }
@@ -1041,6 +1244,16 @@ func (_m *ExecutableNodeStatus) SetParentTaskID(t *core.TaskExecutionIdentifier)
 	_m.Called(t)
 }
 
+// SetReasonCode provides a mock function with given fields: code
+func (_m *ExecutableNodeStatus) SetReasonCode(code string) {
+	_m.Called(code)
+}
+
+// SetRetryBackoff provides a mock function with given fields: backoff
+func (_m *ExecutableNodeStatus) SetRetryBackoff(backoff *v1alpha1.RetryBackoff) {
+	_m.Called(backoff)
+}
+
 // UpdatePhase provides a mock function with given fields: phase, occurredAt, reason, err
 func (_m *ExecutableNodeStatus) UpdatePhase(phase v1alpha1.NodePhase, occurredAt v1.Time, reason string, err *core.ExecutionError) {
 	_m.Called(phase, occurredAt, reason, err)
@@ -5,6 +5,8 @@ package mocks
 import (
 	core "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/flyteorg/flytestdlib/storage"
 )
 
 // ExecutableTask is an autogenerated mock type for the ExecutableTask type
@@ -46,6 +48,38 @@ func (_m *ExecutableTask) CoreTask() *core.TaskTemplate {
 	return r0
 }
 
+type ExecutableTask_GetTemplateRef struct {
+	*mock.Call
+}
+
+func (_m ExecutableTask_GetTemplateRef) Return(_a0 storage.DataReference) *ExecutableTask_GetTemplateRef {
+	return &ExecutableTask_GetTemplateRef{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableTask) OnGetTemplateRef() *ExecutableTask_GetTemplateRef {
+	c_call := _m.On("GetTemplateRef")
+	return &ExecutableTask_GetTemplateRef{Call: c_call}
+}
+
+func (_m *ExecutableTask) OnGetTemplateRefMatch(matchers ...interface{}) *ExecutableTask_GetTemplateRef {
+	c_call := _m.On("GetTemplateRef", matchers...)
+	return &ExecutableTask_GetTemplateRef{Call: c_call}
+}
+
+// GetTemplateRef provides a mock function with given fields:
+func (_m *ExecutableTask) GetTemplateRef() storage.DataReference {
+	ret := _m.Called()
+
+	var r0 storage.DataReference
+	if rf, ok := ret.Get(0).(func() storage.DataReference); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(storage.DataReference)
+	}
+
+	return r0
+}
+
 type ExecutableTask_TaskType struct {
 	*mock.Call
 }
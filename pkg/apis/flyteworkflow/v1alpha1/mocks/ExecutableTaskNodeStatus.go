@@ -6,6 +6,8 @@ import (
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+
+	v1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
 // ExecutableTaskNodeStatus is an autogenerated mock type for the ExecutableTaskNodeStatus type
@@ -45,6 +47,40 @@ func (_m *ExecutableTaskNodeStatus) GetBarrierClockTick() uint32 {
 	return r0
 }
 
+type ExecutableTaskNodeStatus_GetExecutionEnvironment struct {
+	*mock.Call
+}
+
+func (_m ExecutableTaskNodeStatus_GetExecutionEnvironment) Return(_a0 *v1alpha1.ExecutionEnvironment) *ExecutableTaskNodeStatus_GetExecutionEnvironment {
+	return &ExecutableTaskNodeStatus_GetExecutionEnvironment{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableTaskNodeStatus) OnGetExecutionEnvironment() *ExecutableTaskNodeStatus_GetExecutionEnvironment {
+	c_call := _m.On("GetExecutionEnvironment")
+	return &ExecutableTaskNodeStatus_GetExecutionEnvironment{Call: c_call}
+}
+
+func (_m *ExecutableTaskNodeStatus) OnGetExecutionEnvironmentMatch(matchers ...interface{}) *ExecutableTaskNodeStatus_GetExecutionEnvironment {
+	c_call := _m.On("GetExecutionEnvironment", matchers...)
+	return &ExecutableTaskNodeStatus_GetExecutionEnvironment{Call: c_call}
+}
+
+// GetExecutionEnvironment provides a mock function with given fields:
+func (_m *ExecutableTaskNodeStatus) GetExecutionEnvironment() *v1alpha1.ExecutionEnvironment {
+	ret := _m.Called()
+
+	var r0 *v1alpha1.ExecutionEnvironment
+	if rf, ok := ret.Get(0).(func() *v1alpha1.ExecutionEnvironment); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.ExecutionEnvironment)
+		}
+	}
+
+	return r0
+}
+
 type ExecutableTaskNodeStatus_GetLastPhaseUpdatedAt struct {
 	*mock.Call
 }
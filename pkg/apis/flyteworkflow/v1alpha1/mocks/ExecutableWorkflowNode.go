@@ -12,6 +12,38 @@ type ExecutableWorkflowNode struct {
 	mock.Mock
 }
 
+type ExecutableWorkflowNode_GetDiscoveryVersion struct {
+	*mock.Call
+}
+
+func (_m ExecutableWorkflowNode_GetDiscoveryVersion) Return(_a0 string) *ExecutableWorkflowNode_GetDiscoveryVersion {
+	return &ExecutableWorkflowNode_GetDiscoveryVersion{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableWorkflowNode) OnGetDiscoveryVersion() *ExecutableWorkflowNode_GetDiscoveryVersion {
+	c_call := _m.On("GetDiscoveryVersion")
+	return &ExecutableWorkflowNode_GetDiscoveryVersion{Call: c_call}
+}
+
+func (_m *ExecutableWorkflowNode) OnGetDiscoveryVersionMatch(matchers ...interface{}) *ExecutableWorkflowNode_GetDiscoveryVersion {
+	c_call := _m.On("GetDiscoveryVersion", matchers...)
+	return &ExecutableWorkflowNode_GetDiscoveryVersion{Call: c_call}
+}
+
+// GetDiscoveryVersion provides a mock function with given fields:
+func (_m *ExecutableWorkflowNode) GetDiscoveryVersion() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 type ExecutableWorkflowNode_GetLaunchPlanRefID struct {
 	*mock.Call
 }
@@ -79,3 +111,35 @@ func (_m *ExecutableWorkflowNode) GetSubWorkflowRef() *string {
 
 	return r0
 }
+
+type ExecutableWorkflowNode_IsDiscoverable struct {
+	*mock.Call
+}
+
+func (_m ExecutableWorkflowNode_IsDiscoverable) Return(_a0 bool) *ExecutableWorkflowNode_IsDiscoverable {
+	return &ExecutableWorkflowNode_IsDiscoverable{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableWorkflowNode) OnIsDiscoverable() *ExecutableWorkflowNode_IsDiscoverable {
+	c_call := _m.On("IsDiscoverable")
+	return &ExecutableWorkflowNode_IsDiscoverable{Call: c_call}
+}
+
+func (_m *ExecutableWorkflowNode) OnIsDiscoverableMatch(matchers ...interface{}) *ExecutableWorkflowNode_IsDiscoverable {
+	c_call := _m.On("IsDiscoverable", matchers...)
+	return &ExecutableWorkflowNode_IsDiscoverable{Call: c_call}
+}
+
+// IsDiscoverable provides a mock function with given fields:
+func (_m *ExecutableWorkflowNode) IsDiscoverable() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -6,6 +6,8 @@ import (
 	core "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	mock "github.com/stretchr/testify/mock"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	v1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
@@ -48,6 +50,40 @@ func (_m *ExecutableWorkflowNodeStatus) GetExecutionError() *core.ExecutionError
 	return r0
 }
 
+type ExecutableWorkflowNodeStatus_GetKilledAt struct {
+	*mock.Call
+}
+
+func (_m ExecutableWorkflowNodeStatus_GetKilledAt) Return(_a0 *v1.Time) *ExecutableWorkflowNodeStatus_GetKilledAt {
+	return &ExecutableWorkflowNodeStatus_GetKilledAt{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutableWorkflowNodeStatus) OnGetKilledAt() *ExecutableWorkflowNodeStatus_GetKilledAt {
+	c_call := _m.On("GetKilledAt")
+	return &ExecutableWorkflowNodeStatus_GetKilledAt{Call: c_call}
+}
+
+func (_m *ExecutableWorkflowNodeStatus) OnGetKilledAtMatch(matchers ...interface{}) *ExecutableWorkflowNodeStatus_GetKilledAt {
+	c_call := _m.On("GetKilledAt", matchers...)
+	return &ExecutableWorkflowNodeStatus_GetKilledAt{Call: c_call}
+}
+
+// GetKilledAt provides a mock function with given fields:
+func (_m *ExecutableWorkflowNodeStatus) GetKilledAt() *v1.Time {
+	ret := _m.Called()
+
+	var r0 *v1.Time
+	if rf, ok := ret.Get(0).(func() *v1.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Time)
+		}
+	}
+
+	return r0
+}
+
 type ExecutableWorkflowNodeStatus_GetWorkflowNodePhase struct {
 	*mock.Call
 }
@@ -112,6 +112,38 @@ func (_m *MutableDynamicNodeStatus) GetExecutionError() *core.ExecutionError {
 	return r0
 }
 
+type MutableDynamicNodeStatus_GetWorkflowChecksum struct {
+	*mock.Call
+}
+
+func (_m MutableDynamicNodeStatus_GetWorkflowChecksum) Return(_a0 string) *MutableDynamicNodeStatus_GetWorkflowChecksum {
+	return &MutableDynamicNodeStatus_GetWorkflowChecksum{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *MutableDynamicNodeStatus) OnGetWorkflowChecksum() *MutableDynamicNodeStatus_GetWorkflowChecksum {
+	c_call := _m.On("GetWorkflowChecksum")
+	return &MutableDynamicNodeStatus_GetWorkflowChecksum{Call: c_call}
+}
+
+func (_m *MutableDynamicNodeStatus) OnGetWorkflowChecksumMatch(matchers ...interface{}) *MutableDynamicNodeStatus_GetWorkflowChecksum {
+	c_call := _m.On("GetWorkflowChecksum", matchers...)
+	return &MutableDynamicNodeStatus_GetWorkflowChecksum{Call: c_call}
+}
+
+// GetWorkflowChecksum provides a mock function with given fields:
+func (_m *MutableDynamicNodeStatus) GetWorkflowChecksum() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 type MutableDynamicNodeStatus_IsDirty struct {
 	*mock.Call
 }
@@ -158,3 +190,8 @@ func (_m *MutableDynamicNodeStatus) SetDynamicNodeReason(reason string) {
 func (_m *MutableDynamicNodeStatus) SetExecutionError(executionError *core.ExecutionError) {
 	_m.Called(executionError)
 }
+
+// SetWorkflowChecksum provides a mock function with given fields: checksum
+func (_m *MutableDynamicNodeStatus) SetWorkflowChecksum(checksum string) {
+	_m.Called(checksum)
+}
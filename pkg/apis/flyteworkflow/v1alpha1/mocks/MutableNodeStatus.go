@@ -18,6 +18,11 @@ type MutableNodeStatus struct {
 	mock.Mock
 }
 
+// ClearArrayNodeStatus provides a mock function with given fields:
+func (_m *MutableNodeStatus) ClearArrayNodeStatus() {
+	_m.Called()
+}
+
 // ClearDynamicNodeStatus provides a mock function with given fields:
 func (_m *MutableNodeStatus) ClearDynamicNodeStatus() {
 	_m.Called()
@@ -43,6 +48,40 @@ func (_m *MutableNodeStatus) ClearWorkflowStatus() {
 	_m.Called()
 }
 
+type MutableNodeStatus_GetArrayNodeStatus struct {
+	*mock.Call
+}
+
+func (_m MutableNodeStatus_GetArrayNodeStatus) Return(_a0 v1alpha1.MutableArrayNodeStatus) *MutableNodeStatus_GetArrayNodeStatus {
+	return &MutableNodeStatus_GetArrayNodeStatus{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *MutableNodeStatus) OnGetArrayNodeStatus() *MutableNodeStatus_GetArrayNodeStatus {
+	c_call := _m.On("GetArrayNodeStatus")
+	return &MutableNodeStatus_GetArrayNodeStatus{Call: c_call}
+}
+
+func (_m *MutableNodeStatus) OnGetArrayNodeStatusMatch(matchers ...interface{}) *MutableNodeStatus_GetArrayNodeStatus {
+	c_call := _m.On("GetArrayNodeStatus", matchers...)
+	return &MutableNodeStatus_GetArrayNodeStatus{Call: c_call}
+}
+
+// GetArrayNodeStatus provides a mock function with given fields:
+func (_m *MutableNodeStatus) GetArrayNodeStatus() v1alpha1.MutableArrayNodeStatus {
+	ret := _m.Called()
+
+	var r0 v1alpha1.MutableArrayNodeStatus
+	if rf, ok := ret.Get(0).(func() v1alpha1.MutableArrayNodeStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1alpha1.MutableArrayNodeStatus)
+		}
+	}
+
+	return r0
+}
+
 type MutableNodeStatus_GetBranchStatus struct {
 	*mock.Call
 }
@@ -111,6 +150,40 @@ func (_m *MutableNodeStatus) GetDynamicNodeStatus() v1alpha1.MutableDynamicNodeS
 	return r0
 }
 
+type MutableNodeStatus_GetOrCreateArrayNodeStatus struct {
+	*mock.Call
+}
+
+func (_m MutableNodeStatus_GetOrCreateArrayNodeStatus) Return(_a0 v1alpha1.MutableArrayNodeStatus) *MutableNodeStatus_GetOrCreateArrayNodeStatus {
+	return &MutableNodeStatus_GetOrCreateArrayNodeStatus{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *MutableNodeStatus) OnGetOrCreateArrayNodeStatus() *MutableNodeStatus_GetOrCreateArrayNodeStatus {
+	c_call := _m.On("GetOrCreateArrayNodeStatus")
+	return &MutableNodeStatus_GetOrCreateArrayNodeStatus{Call: c_call}
+}
+
+func (_m *MutableNodeStatus) OnGetOrCreateArrayNodeStatusMatch(matchers ...interface{}) *MutableNodeStatus_GetOrCreateArrayNodeStatus {
+	c_call := _m.On("GetOrCreateArrayNodeStatus", matchers...)
+	return &MutableNodeStatus_GetOrCreateArrayNodeStatus{Call: c_call}
+}
+
+// GetOrCreateArrayNodeStatus provides a mock function with given fields:
+func (_m *MutableNodeStatus) GetOrCreateArrayNodeStatus() v1alpha1.MutableArrayNodeStatus {
+	ret := _m.Called()
+
+	var r0 v1alpha1.MutableArrayNodeStatus
+	if rf, ok := ret.Get(0).(func() v1alpha1.MutableArrayNodeStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1alpha1.MutableArrayNodeStatus)
+		}
+	}
+
+	return r0
+}
+
 type MutableNodeStatus_GetOrCreateBranchStatus struct {
 	*mock.Call
 }
@@ -379,6 +452,38 @@ func (_m *MutableNodeStatus) IncrementSystemFailures() uint32 {
 	return r0
 }
 
+type MutableNodeStatus_IncrementTimeoutFailures struct {
+	*mock.Call
+}
+
+func (_m MutableNodeStatus_IncrementTimeoutFailures) Return(_a0 uint32) *MutableNodeStatus_IncrementTimeoutFailures {
+	return &MutableNodeStatus_IncrementTimeoutFailures{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *MutableNodeStatus) OnIncrementTimeoutFailures() *MutableNodeStatus_IncrementTimeoutFailures {
+	c_call := _m.On("IncrementTimeoutFailures")
+	return &MutableNodeStatus_IncrementTimeoutFailures{Call: c_call}
+}
+
+func (_m *MutableNodeStatus) OnIncrementTimeoutFailuresMatch(matchers ...interface{}) *MutableNodeStatus_IncrementTimeoutFailures {
+	c_call := _m.On("IncrementTimeoutFailures", matchers...)
+	return &MutableNodeStatus_IncrementTimeoutFailures{Call: c_call}
+}
+
+// IncrementTimeoutFailures provides a mock function with given fields:
+func (_m *MutableNodeStatus) IncrementTimeoutFailures() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 type MutableNodeStatus_IsDirty struct {
 	*mock.Call
 }
@@ -441,6 +546,16 @@ func (_m *MutableNodeStatus) SetParentTaskID(t *core.TaskExecutionIdentifier) {
 	_m.Called(t)
 }
 
+// SetReasonCode provides a mock function with given fields: code
+func (_m *MutableNodeStatus) SetReasonCode(code string) {
+	_m.Called(code)
+}
+
+// SetRetryBackoff provides a mock function with given fields: backoff
+func (_m *MutableNodeStatus) SetRetryBackoff(backoff *v1alpha1.RetryBackoff) {
+	_m.Called(backoff)
+}
+
 // UpdatePhase provides a mock function with given fields: phase, occurredAt, reason, err
 func (_m *MutableNodeStatus) UpdatePhase(phase v1alpha1.NodePhase, occurredAt v1.Time, reason string, err *core.ExecutionError) {
 	_m.Called(phase, occurredAt, reason, err)
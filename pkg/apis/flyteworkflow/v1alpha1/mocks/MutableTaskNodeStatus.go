@@ -6,6 +6,8 @@ import (
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+
+	v1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
 // MutableTaskNodeStatus is an autogenerated mock type for the MutableTaskNodeStatus type
@@ -45,6 +47,40 @@ func (_m *MutableTaskNodeStatus) GetBarrierClockTick() uint32 {
 	return r0
 }
 
+type MutableTaskNodeStatus_GetExecutionEnvironment struct {
+	*mock.Call
+}
+
+func (_m MutableTaskNodeStatus_GetExecutionEnvironment) Return(_a0 *v1alpha1.ExecutionEnvironment) *MutableTaskNodeStatus_GetExecutionEnvironment {
+	return &MutableTaskNodeStatus_GetExecutionEnvironment{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *MutableTaskNodeStatus) OnGetExecutionEnvironment() *MutableTaskNodeStatus_GetExecutionEnvironment {
+	c_call := _m.On("GetExecutionEnvironment")
+	return &MutableTaskNodeStatus_GetExecutionEnvironment{Call: c_call}
+}
+
+func (_m *MutableTaskNodeStatus) OnGetExecutionEnvironmentMatch(matchers ...interface{}) *MutableTaskNodeStatus_GetExecutionEnvironment {
+	c_call := _m.On("GetExecutionEnvironment", matchers...)
+	return &MutableTaskNodeStatus_GetExecutionEnvironment{Call: c_call}
+}
+
+// GetExecutionEnvironment provides a mock function with given fields:
+func (_m *MutableTaskNodeStatus) GetExecutionEnvironment() *v1alpha1.ExecutionEnvironment {
+	ret := _m.Called()
+
+	var r0 *v1alpha1.ExecutionEnvironment
+	if rf, ok := ret.Get(0).(func() *v1alpha1.ExecutionEnvironment); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.ExecutionEnvironment)
+		}
+	}
+
+	return r0
+}
+
 type MutableTaskNodeStatus_GetLastPhaseUpdatedAt struct {
 	*mock.Call
 }
@@ -244,6 +280,11 @@ func (_m *MutableTaskNodeStatus) SetBarrierClockTick(tick uint32) {
 	_m.Called(tick)
 }
 
+// SetExecutionEnvironment provides a mock function with given fields: env
+func (_m *MutableTaskNodeStatus) SetExecutionEnvironment(env *v1alpha1.ExecutionEnvironment) {
+	_m.Called(env)
+}
+
 // SetLastPhaseUpdatedAt provides a mock function with given fields: updatedAt
 func (_m *MutableTaskNodeStatus) SetLastPhaseUpdatedAt(updatedAt time.Time) {
 	_m.Called(updatedAt)
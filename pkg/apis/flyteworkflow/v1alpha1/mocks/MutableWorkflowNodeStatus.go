@@ -6,6 +6,8 @@ import (
 	core "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	mock "github.com/stretchr/testify/mock"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	v1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
@@ -48,6 +50,40 @@ func (_m *MutableWorkflowNodeStatus) GetExecutionError() *core.ExecutionError {
 	return r0
 }
 
+type MutableWorkflowNodeStatus_GetKilledAt struct {
+	*mock.Call
+}
+
+func (_m MutableWorkflowNodeStatus_GetKilledAt) Return(_a0 *v1.Time) *MutableWorkflowNodeStatus_GetKilledAt {
+	return &MutableWorkflowNodeStatus_GetKilledAt{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *MutableWorkflowNodeStatus) OnGetKilledAt() *MutableWorkflowNodeStatus_GetKilledAt {
+	c_call := _m.On("GetKilledAt")
+	return &MutableWorkflowNodeStatus_GetKilledAt{Call: c_call}
+}
+
+func (_m *MutableWorkflowNodeStatus) OnGetKilledAtMatch(matchers ...interface{}) *MutableWorkflowNodeStatus_GetKilledAt {
+	c_call := _m.On("GetKilledAt", matchers...)
+	return &MutableWorkflowNodeStatus_GetKilledAt{Call: c_call}
+}
+
+// GetKilledAt provides a mock function with given fields:
+func (_m *MutableWorkflowNodeStatus) GetKilledAt() *v1.Time {
+	ret := _m.Called()
+
+	var r0 *v1.Time
+	if rf, ok := ret.Get(0).(func() *v1.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Time)
+		}
+	}
+
+	return r0
+}
+
 type MutableWorkflowNodeStatus_GetWorkflowNodePhase struct {
 	*mock.Call
 }
@@ -117,6 +153,11 @@ func (_m *MutableWorkflowNodeStatus) SetExecutionError(executionError *core.Exec
 	_m.Called(executionError)
 }
 
+// SetKilledAt provides a mock function with given fields: killedAt
+func (_m *MutableWorkflowNodeStatus) SetKilledAt(killedAt *v1.Time) {
+	_m.Called(killedAt)
+}
+
 // SetWorkflowNodePhase provides a mock function with given fields: phase
 func (_m *MutableWorkflowNodeStatus) SetWorkflowNodePhase(phase v1alpha1.WorkflowNodePhase) {
 	_m.Called(phase)
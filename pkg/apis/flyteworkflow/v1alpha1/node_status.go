@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/flyteorg/flytestdlib/storage"
@@ -17,21 +19,60 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// readLockStatus read locks the given evaluation lock, if any, and returns the func that unlocks it. Statuses that are
+// not evaluated by the workflow executor, e.g. the ones of workflows read by tools, aren't guarded by a lock.
+func readLockStatus(lock *sync.RWMutex) func() {
+	if lock == nil {
+		return func() {}
+	}
+
+	lock.RLock()
+	return lock.RUnlock
+}
+
+// lockStatus locks the given evaluation lock, if any, and returns the func that unlocks it.
+func lockStatus(lock *sync.RWMutex) func() {
+	if lock == nil {
+		return func() {}
+	}
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// snapshotNodeStatuses returns a shallow copy of the given node status map that can be iterated while other goroutines
+// add to or clear the original map.
+func snapshotNodeStatuses(lock *sync.RWMutex, statuses *map[NodeID]*NodeStatus) map[NodeID]*NodeStatus {
+	defer readLockStatus(lock)()
+	if *statuses == nil {
+		return nil
+	}
+
+	snapshot := make(map[NodeID]*NodeStatus, len(*statuses))
+	for k, v := range *statuses {
+		snapshot[k] = v
+	}
+
+	return snapshot
+}
+
 type MutableStruct struct {
-	isDirty bool
+	// Accessed atomically, since statuses may be marked dirty by nodes that are evaluated concurrently, e.g. when they
+	// add sub node statuses.
+	isDirty uint32
 }
 
 func (in *MutableStruct) SetDirty() {
-	in.isDirty = true
+	atomic.StoreUint32(&in.isDirty, 1)
 }
 
 // For testing only
 func (in *MutableStruct) ResetDirty() {
-	in.isDirty = false
+	atomic.StoreUint32(&in.isDirty, 0)
 }
 
-func (in MutableStruct) IsDirty() bool {
-	return in.isDirty
+func (in *MutableStruct) IsDirty() bool {
+	return atomic.LoadUint32(&in.isDirty) == 1
 }
 
 type BranchNodeStatus struct {
@@ -99,6 +140,9 @@ type DynamicNodeStatus struct {
 	Phase  DynamicNodePhase `json:"phase,omitempty"`
 	Reason string           `json:"reason,omitempty"`
 	Error  *ExecutionError  `json:"error,omitempty"`
+	// WorkflowChecksum is the checksum of the compiled dynamic workflow and its closure offloaded to blob storage. It's
+	// used to verify the integrity of the offloaded workflow every time it's loaded.
+	WorkflowChecksum string `json:"workflowChecksum,omitempty"`
 }
 
 func (in *DynamicNodeStatus) GetDynamicNodePhase() DynamicNodePhase {
@@ -123,6 +167,17 @@ func (in *DynamicNodeStatus) SetDynamicNodeReason(reason string) {
 	}
 }
 
+func (in *DynamicNodeStatus) GetWorkflowChecksum() string {
+	return in.WorkflowChecksum
+}
+
+func (in *DynamicNodeStatus) SetWorkflowChecksum(checksum string) {
+	if in.WorkflowChecksum != checksum {
+		in.SetDirty()
+		in.WorkflowChecksum = checksum
+	}
+}
+
 func (in *DynamicNodeStatus) SetDynamicNodePhase(phase DynamicNodePhase) {
 	if in.Phase != phase {
 		in.SetDirty()
@@ -145,7 +200,7 @@ func (in *DynamicNodeStatus) Equals(o *DynamicNodeStatus) bool {
 	if in == nil || o == nil {
 		return false
 	}
-	return in.Phase == o.Phase && in.Reason == o.Reason
+	return in.Phase == o.Phase && in.Reason == o.Reason && in.WorkflowChecksum == o.WorkflowChecksum
 }
 
 type WorkflowNodePhase int
@@ -160,6 +215,19 @@ type WorkflowNodeStatus struct {
 	MutableStruct
 	Phase          WorkflowNodePhase    `json:"phase,omitempty"`
 	ExecutionError *core.ExecutionError `json:"executionError,omitempty"`
+	// The time the child execution of the node was killed at, while the abort of the node waits for it to terminate.
+	KilledAt *metav1.Time `json:"killedAt,omitempty"`
+}
+
+func (in *WorkflowNodeStatus) GetKilledAt() *metav1.Time {
+	return in.KilledAt
+}
+
+func (in *WorkflowNodeStatus) SetKilledAt(killedAt *metav1.Time) {
+	if in.KilledAt != killedAt {
+		in.SetDirty()
+		in.KilledAt = killedAt
+	}
 }
 
 func (in *WorkflowNodeStatus) SetExecutionError(executionError *core.ExecutionError) {
@@ -184,6 +252,18 @@ func (in *WorkflowNodeStatus) SetWorkflowNodePhase(phase WorkflowNodePhase) {
 	}
 }
 
+// RetryBackoff is the state of the exponential backoff between the attempts of a node that failed with system errors,
+// so that repeated infrastructure failures do not retry the node in a hot loop. The multiplier and the cap are captured
+// when the first backoff starts, so that configuration changes do not reshape backoffs in progress.
+type RetryBackoff struct {
+	// The next attempt of the node starts once this time passed. It's unset once the next attempt started.
+	NextAttemptAt *metav1.Time `json:"nextAttemptAt,omitempty"`
+	// The last delay, without its jitter. Every subsequent delay is the last one times the multiplier, up to the cap.
+	Delay      metav1.Duration `json:"delay,omitempty"`
+	Multiplier uint32          `json:"multiplier,omitempty"`
+	MaxDelay   metav1.Duration `json:"maxDelay,omitempty"`
+}
+
 type NodeStatus struct {
 	MutableStruct
 	Phase                NodePhase     `json:"phase,omitempty"`
@@ -193,10 +273,12 @@ type NodeStatus struct {
 	LastUpdatedAt        *metav1.Time  `json:"lastUpdatedAt,omitempty"`
 	LastAttemptStartedAt *metav1.Time  `json:"laStartedAt,omitempty"`
 	Message              string        `json:"message,omitempty"`
+	ReasonCode           string        `json:"reasonCode,omitempty"`
 	DataDir              DataReference `json:"-"`
 	OutputDir            DataReference `json:"-"`
 	Attempts             uint32        `json:"attempts,omitempty"`
 	SystemFailures       uint32        `json:"systemFailures,omitempty"`
+	TimeoutFailures      uint32        `json:"timeoutFailures,omitempty"`
 	Cached               bool          `json:"cached,omitempty"`
 
 	// This is useful only for branch nodes. If this is set, then it can be used to determine if execution can proceed
@@ -211,24 +293,31 @@ type NodeStatus struct {
 
 	TaskNodeStatus    *TaskNodeStatus    `json:",omitempty"`
 	DynamicNodeStatus *DynamicNodeStatus `json:"dynamicNodeStatus,omitempty"`
+	ArrayNodeStatus   *ArrayNodeStatus   `json:"arrayNodeStatus,omitempty"`
 	// In case of Failing/Failed Phase, an execution error can be optionally associated with the Node
 	Error *ExecutionError `json:"error,omitempty"`
+	// Backoff between the attempts of a node that keeps failing with system errors
+	RetryBackoff *RetryBackoff `json:"retryBackoff,omitempty"`
 
 	// Not Persisted
 	DataReferenceConstructor storage.ReferenceConstructor `json:"-"`
+	// Guards the sub node statuses and their ephemeral attributes while the workflow is evaluated. It's shared by all
+	// the statuses of the workflow, set by WorkflowStatus.SetEvaluationLock and not copied by DeepCopy.
+	evaluationLock *sync.RWMutex
 }
 
 func (in *NodeStatus) IsDirty() bool {
 	isDirty := in.MutableStruct.IsDirty() ||
 		(in.TaskNodeStatus != nil && in.TaskNodeStatus.IsDirty()) ||
 		(in.DynamicNodeStatus != nil && in.DynamicNodeStatus.IsDirty()) ||
+		(in.ArrayNodeStatus != nil && in.ArrayNodeStatus.IsDirty()) ||
 		(in.WorkflowNodeStatus != nil && in.WorkflowNodeStatus.IsDirty()) ||
 		(in.BranchStatus != nil && in.BranchStatus.IsDirty())
 	if isDirty {
 		return true
 	}
 
-	for _, sub := range in.SubNodeStatus {
+	for _, sub := range in.snapshotSubNodeStatus() {
 		if sub.IsDirty() {
 			return true
 		}
@@ -249,6 +338,10 @@ func (in *NodeStatus) ResetDirty() {
 		in.DynamicNodeStatus.ResetDirty()
 	}
 
+	if in.ArrayNodeStatus != nil {
+		in.ArrayNodeStatus.ResetDirty()
+	}
+
 	if in.WorkflowNodeStatus != nil {
 		in.WorkflowNodeStatus.ResetDirty()
 	}
@@ -258,7 +351,7 @@ func (in *NodeStatus) ResetDirty() {
 	}
 
 	// Reset SubNodeStatus Dirty
-	for _, subStatus := range in.SubNodeStatus {
+	for _, subStatus := range in.snapshotSubNodeStatus() {
 		subStatus.ResetDirty()
 	}
 }
@@ -284,8 +377,8 @@ func (in *NodeStatus) GetTaskStatus() MutableTaskNodeStatus {
 	return in.TaskNodeStatus
 }
 
-func (in NodeStatus) VisitNodeStatuses(visitor NodeStatusVisitFn) {
-	for n, s := range in.SubNodeStatus {
+func (in *NodeStatus) VisitNodeStatuses(visitor NodeStatusVisitFn) {
+	for n, s := range in.snapshotSubNodeStatus() {
 		visitor(n, s)
 	}
 }
@@ -313,10 +406,19 @@ func (in *NodeStatus) ClearLastAttemptStartedAt() {
 }
 
 func (in *NodeStatus) ClearSubNodeStatus() {
-	in.SubNodeStatus = nil
+	in.clearSubNodeStatus()
 	in.SetDirty()
 }
 
+func (in *NodeStatus) clearSubNodeStatus() {
+	defer lockStatus(in.evaluationLock)()
+	in.SubNodeStatus = nil
+}
+
+func (in *NodeStatus) snapshotSubNodeStatus() map[NodeID]*NodeStatus {
+	return snapshotNodeStatuses(in.evaluationLock, &in.SubNodeStatus)
+}
+
 func (in *NodeStatus) GetLastUpdatedAt() *metav1.Time {
 	return in.LastUpdatedAt
 }
@@ -325,6 +427,15 @@ func (in *NodeStatus) GetLastAttemptStartedAt() *metav1.Time {
 	return in.LastAttemptStartedAt
 }
 
+func (in *NodeStatus) GetRetryBackoff() *RetryBackoff {
+	return in.RetryBackoff
+}
+
+func (in *NodeStatus) SetRetryBackoff(backoff *RetryBackoff) {
+	in.RetryBackoff = backoff
+	in.SetDirty()
+}
+
 func (in *NodeStatus) GetAttempts() uint32 {
 	return in.Attempts
 }
@@ -333,6 +444,10 @@ func (in *NodeStatus) GetSystemFailures() uint32 {
 	return in.SystemFailures
 }
 
+func (in *NodeStatus) GetTimeoutFailures() uint32 {
+	return in.TimeoutFailures
+}
+
 func (in *NodeStatus) SetCached() {
 	in.Cached = true
 	in.SetDirty()
@@ -354,6 +469,12 @@ func (in *NodeStatus) IncrementSystemFailures() uint32 {
 	return in.SystemFailures
 }
 
+func (in *NodeStatus) IncrementTimeoutFailures() uint32 {
+	in.TimeoutFailures++
+	in.SetDirty()
+	return in.TimeoutFailures
+}
+
 func (in *NodeStatus) GetOrCreateDynamicNodeStatus() MutableDynamicNodeStatus {
 	if in.DynamicNodeStatus == nil {
 		in.SetDirty()
@@ -370,6 +491,29 @@ func (in *NodeStatus) ClearDynamicNodeStatus() {
 	in.SetDirty()
 }
 
+func (in *NodeStatus) GetOrCreateArrayNodeStatus() MutableArrayNodeStatus {
+	if in.ArrayNodeStatus == nil {
+		in.SetDirty()
+		in.ArrayNodeStatus = &ArrayNodeStatus{
+			MutableStruct: MutableStruct{},
+		}
+	}
+
+	return in.ArrayNodeStatus
+}
+
+func (in *NodeStatus) GetArrayNodeStatus() MutableArrayNodeStatus {
+	if in.ArrayNodeStatus == nil {
+		return nil
+	}
+	return in.ArrayNodeStatus
+}
+
+func (in *NodeStatus) ClearArrayNodeStatus() {
+	in.ArrayNodeStatus = nil
+	in.SetDirty()
+}
+
 func (in *NodeStatus) GetOrCreateBranchStatus() MutableBranchNodeStatus {
 	if in.BranchStatus == nil {
 		in.SetDirty()
@@ -397,6 +541,18 @@ func (in *NodeStatus) GetMessage() string {
 	return in.Message
 }
 
+// GetReasonCode returns the machine-readable reason of the last phase transition, if known.
+func (in *NodeStatus) GetReasonCode() string {
+	return in.ReasonCode
+}
+
+func (in *NodeStatus) SetReasonCode(code string) {
+	if in.ReasonCode != code {
+		in.SetDirty()
+		in.ReasonCode = code
+	}
+}
+
 func IsPhaseTerminal(phase NodePhase) bool {
 	return phase == NodePhaseSucceeded || phase == NodePhaseFailed || phase == NodePhaseSkipped || phase == NodePhaseTimedOut || phase == NodePhaseRecovered
 }
@@ -420,6 +576,8 @@ func (in *NodeStatus) UpdatePhase(p NodePhase, occurredAt metav1.Time, reason st
 
 	in.Phase = p
 	in.Message = reason
+	// The reason code of the previous phase does not apply to this one, it's set separately if it's known.
+	in.ReasonCode = ""
 	if len(reason) > maxMessageSize {
 		in.Message = reason[:maxMessageSize]
 	}
@@ -466,11 +624,13 @@ func (in *NodeStatus) UpdatePhase(p NodePhase, occurredAt metav1.Time, reason st
 		in.StartedAt = nil
 		in.LastAttemptStartedAt = nil
 		in.DynamicNodeStatus = nil
+		in.ArrayNodeStatus = nil
 		in.BranchStatus = nil
-		in.SubNodeStatus = nil
+		in.clearSubNodeStatus()
 		in.TaskNodeStatus = nil
 		in.WorkflowNodeStatus = nil
 		in.LastUpdatedAt = nil
+		in.RetryBackoff = nil
 	}
 	in.SetDirty()
 }
@@ -557,12 +717,43 @@ func (in *NodeStatus) setEphemeralNodeExecutionStatusAttributes(ctx context.Cont
 	}
 
 	n.DataReferenceConstructor = in.DataReferenceConstructor
+	n.setEvaluationLock(in.evaluationLock)
 
 	return nil
 }
 
+// Returns whether the ephemeral attributes of the given sub node status are yet to be set.
+func (in *NodeStatus) missesEphemeralNodeExecutionStatusAttributes(n *NodeStatus) bool {
+	return len(n.GetDataDir()) == 0 || len(n.GetOutputDir()) == 0 ||
+		n.DataReferenceConstructor != in.DataReferenceConstructor || n.evaluationLock != in.evaluationLock ||
+		n.ParentTask == nil || n.ParentTask.TaskExecutionIdentifier != in.GetParentTaskID()
+}
+
+// setEvaluationLock sets the evaluation lock of the status and of all of its sub node statuses, unless it's set already.
+func (in *NodeStatus) setEvaluationLock(lock *sync.RWMutex) {
+	if in.evaluationLock == lock {
+		return
+	}
+
+	in.evaluationLock = lock
+	for _, sub := range in.SubNodeStatus {
+		sub.setEvaluationLock(lock)
+	}
+}
+
 func (in *NodeStatus) GetNodeExecutionStatus(ctx context.Context, id NodeID) ExecutableNodeStatus {
+	// Statuses that exist and are up to date, like the ones of upstream nodes that are looked up again, are looked up
+	// under the read lock, so that they can be looked up concurrently.
+	unlock := readLockStatus(in.evaluationLock)
 	n, ok := in.SubNodeStatus[id]
+	upToDate := ok && !in.missesEphemeralNodeExecutionStatusAttributes(n)
+	unlock()
+	if upToDate {
+		return n
+	}
+
+	defer lockStatus(in.evaluationLock)()
+	n, ok = in.SubNodeStatus[id]
 	if !ok {
 		if in.SubNodeStatus == nil {
 			in.SubNodeStatus = make(map[NodeID]*NodeStatus)
@@ -576,10 +767,8 @@ func (in *NodeStatus) GetNodeExecutionStatus(ctx context.Context, id NodeID) Exe
 		in.SetDirty()
 	}
 
-	err := in.setEphemeralNodeExecutionStatusAttributes(ctx, id, n)
-	if err != nil {
+	if err := in.setEphemeralNodeExecutionStatusAttributes(ctx, id, n); err != nil {
 		logger.Errorf(ctx, "Failed to set node attributes for node [%v]. Error: %v", id, err)
-		return n
 	}
 
 	return n
@@ -629,6 +818,10 @@ func (in *NodeStatus) Equals(other *NodeStatus) bool {
 		return false
 	}
 
+	if in.TimeoutFailures != other.TimeoutFailures {
+		return false
+	}
+
 	if in.Phase != other.Phase {
 		return false
 	}
@@ -658,12 +851,13 @@ func (in *NodeStatus) Equals(other *NodeStatus) bool {
 		return false
 	}
 
-	if len(in.SubNodeStatus) != len(other.SubNodeStatus) {
+	subNodeStatus, otherSubNodeStatus := in.snapshotSubNodeStatus(), other.snapshotSubNodeStatus()
+	if len(subNodeStatus) != len(otherSubNodeStatus) {
 		return false
 	}
 
-	for k, v := range in.SubNodeStatus {
-		otherV, ok := other.SubNodeStatus[k]
+	for k, v := range subNodeStatus {
+		otherV, ok := otherSubNodeStatus[k]
 		if !ok {
 			return false
 		}
@@ -717,6 +911,19 @@ type TaskNodeStatus struct {
 	PluginStateVersion uint32    `json:"psv,omitempty"`
 	BarrierClockTick   uint32    `json:"tick,omitempty"`
 	LastPhaseUpdatedAt time.Time `json:"updAt,omitempty"`
+	// ExecutionEnvironment is the snapshot of the environment the current attempt of the task executed in.
+	ExecutionEnvironment *ExecutionEnvironment `json:"env,omitempty"`
+}
+
+func (in *TaskNodeStatus) GetExecutionEnvironment() *ExecutionEnvironment {
+	return in.ExecutionEnvironment
+}
+
+func (in *TaskNodeStatus) SetExecutionEnvironment(env *ExecutionEnvironment) {
+	if !reflect.DeepEqual(in.ExecutionEnvironment, env) {
+		in.ExecutionEnvironment = env
+		in.SetDirty()
+	}
 }
 
 func (in *TaskNodeStatus) GetBarrierClockTick() uint32 {
@@ -3,11 +3,14 @@ package v1alpha1
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/flyteorg/flytestdlib/bitarray"
 	"github.com/flyteorg/flytestdlib/storage"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -254,6 +257,27 @@ func TestNodeStatus_GetNodeExecutionStatus(t *testing.T) {
 		assert.Equal(t, storage.DataReference("/abc/0/xyz/0"), subsubNode.GetOutputDir())
 		assert.Equal(t, storage.DataReference("/abc/0/xyz"), subsubNode.GetDataDir())
 	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		n := NodeStatus{
+			DataReferenceConstructor: storage.URLPathConstructor{},
+		}
+		n.setEvaluationLock(&sync.RWMutex{})
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id := fmt.Sprintf("n%d", i)
+				assert.Equal(t, storage.DataReference("/"+id), n.GetNodeExecutionStatus(ctx, id).GetDataDir())
+			}(i)
+		}
+
+		wg.Wait()
+		assert.Len(t, n.SubNodeStatus, 10)
+		assert.True(t, n.IsDirty())
+	})
 }
 
 func TestNodeStatus_UpdatePhase(t *testing.T) {
@@ -440,3 +464,66 @@ func TestNodeStatus_UpdatePhase(t *testing.T) {
 		assert.NotNil(t, ns.TaskNodeStatus)
 	})
 }
+
+func TestNodeStatus_ArrayNodeStatus(t *testing.T) {
+	n := &NodeStatus{}
+	assert.Nil(t, n.GetArrayNodeStatus())
+
+	phases, err := bitarray.NewCompactArray(3, bitarray.Item(NodePhaseRecovered))
+	assert.NoError(t, err)
+	a := n.GetOrCreateArrayNodeStatus()
+	a.SetArrayNodePhase(ArrayNodePhaseExecuting)
+	a.SetSubNodePhases(phases)
+	assert.True(t, n.IsDirty())
+
+	n.ResetDirty()
+	assert.False(t, n.IsDirty())
+
+	// Copies must not share the compact arrays of the sub nodes.
+	c := n.DeepCopy()
+	n.ArrayNodeStatus.SubNodePhases.SetItem(1, bitarray.Item(NodePhaseRunning))
+	assert.Equal(t, bitarray.Item(NodePhaseNotYetStarted), c.ArrayNodeStatus.SubNodePhases.GetItem(1))
+
+	raw, err := json.Marshal(n)
+	assert.NoError(t, err)
+	unmarshalled := &NodeStatus{}
+	assert.NoError(t, json.Unmarshal(raw, unmarshalled))
+	assert.Equal(t, bitarray.Item(NodePhaseRunning), unmarshalled.ArrayNodeStatus.SubNodePhases.GetItem(1))
+
+	n.UpdatePhase(NodePhaseSucceeded, metav1.Now(), "", nil)
+	assert.Nil(t, n.GetArrayNodeStatus())
+}
+
+func TestTaskNodeStatus_SetExecutionEnvironment(t *testing.T) {
+	in := &TaskNodeStatus{}
+	assert.Nil(t, in.GetExecutionEnvironment())
+
+	env := &ExecutionEnvironment{Images: map[string]string{"primary": "image@sha256:abc"}, PluginID: "k8s-pod"}
+	in.SetExecutionEnvironment(env)
+	assert.True(t, in.IsDirty())
+	assert.Equal(t, env, in.GetExecutionEnvironment())
+
+	// Setting an equal environment does not dirty the status.
+	in.ResetDirty()
+	in.SetExecutionEnvironment(env.DeepCopy())
+	assert.False(t, in.IsDirty())
+
+	c := in.DeepCopy()
+	assert.Equal(t, env, c.GetExecutionEnvironment())
+}
+
+func TestNodeStatus_SetReasonCode(t *testing.T) {
+	n := &NodeStatus{}
+	n.UpdatePhase(NodePhaseQueued, metav1.Now(), "queued", nil)
+	n.SetReasonCode("Queued")
+	assert.True(t, n.IsDirty())
+	assert.Equal(t, "Queued", n.GetReasonCode())
+
+	n.ResetDirty()
+	n.SetReasonCode("Queued")
+	assert.False(t, n.IsDirty())
+
+	// A phase transition clears the reason code of the previous phase.
+	n.UpdatePhase(NodePhaseRunning, metav1.Now(), "running", nil)
+	assert.Empty(t, n.GetReasonCode())
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"bytes"
+	"strings"
 	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -37,6 +38,29 @@ func (in *OutputVarMap) DeepCopyInto(out *OutputVarMap) {
 	// Once we figure out the autogenerate story we can replace this
 }
 
+type InputVarMap struct {
+	*core.VariableMap
+}
+
+func (in *InputVarMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := marshaler.Marshal(&buf, in.VariableMap); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (in *InputVarMap) UnmarshalJSON(b []byte) error {
+	in.VariableMap = &core.VariableMap{}
+	return jsonpb.Unmarshal(bytes.NewReader(b), in.VariableMap)
+}
+
+// DeepCopyInto shares the variable map, like OutputVarMap does, it is never manipulated.
+func (in *InputVarMap) DeepCopyInto(out *InputVarMap) {
+	*out = *in
+}
+
 type Binding struct {
 	*core.Binding
 }
@@ -68,9 +92,45 @@ type RetryStrategy struct {
 	// fail to write the attempt information and end up retrying again.
 	// Also `0` and `1` both mean atleast one attempt will be done. 0 is a degenerate case.
 	MinAttempts *int `json:"minAttempts"`
+	// Policies override the number of retries for failures that match a specific error kind and/or code. The first
+	// matching policy wins. Failures that do not match any policy fall back to MinAttempts.
+	// +optional
+	Policies []RetryPolicy `json:"policies,omitempty"`
 	// TODO Add retrydelay?
 }
 
+// RetryPolicy describes how a node is retried when it fails with an error of the given kind and code.
+type RetryPolicy struct {
+	// Kind of the error (USER or SYSTEM) this policy applies to. An empty kind matches all kinds.
+	Kind string `json:"kind,omitempty"`
+	// Code of the error this policy applies to. An empty code matches all codes.
+	Code string `json:"code,omitempty"`
+	// Retries is the maximum number of retries for matching failures. 0 means matching failures are never retried.
+	Retries int `json:"retries"`
+	// ExecutionDeadlineMultiplier, if greater than 1, scales the execution deadline of every subsequent attempt. This
+	// is only meaningful for policies that match execution timeouts.
+	// +optional
+	ExecutionDeadlineMultiplier int `json:"executionDeadlineMultiplier,omitempty"`
+}
+
+// Matches returns true if the policy applies to the given execution error.
+func (in RetryPolicy) Matches(err *core.ExecutionError) bool {
+	if err == nil {
+		return false
+	}
+	if len(in.Kind) > 0 && !strings.EqualFold(in.Kind, err.GetKind().String()) {
+		return false
+	}
+	return len(in.Code) == 0 || in.Code == err.GetCode()
+}
+
+func (in *RetryStrategy) GetPolicies() []RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	return in.Policies
+}
+
 type Alias struct {
 	core.Alias
 }
@@ -100,6 +160,8 @@ type NodeSpec struct {
 	BranchNode    *BranchNodeSpec               `json:"branch,omitempty"`
 	TaskRef       *TaskID                       `json:"task,omitempty"`
 	WorkflowNode  *WorkflowNodeSpec             `json:"workflow,omitempty"`
+	GateNode      *GateNodeSpec                 `json:"gate,omitempty"`
+	ArrayNode     *ArrayNodeSpec                `json:"array,omitempty"`
 	InputBindings []*Binding                    `json:"inputBindings,omitempty"`
 	Config        *typesv1.ConfigMap            `json:"config,omitempty"`
 	RetryStrategy *RetryStrategy                `json:"retry,omitempty"`
@@ -144,6 +206,15 @@ type NodeSpec struct {
 	// The value set to True means task is OK with getting interrupted
 	// +optional
 	Interruptibe *bool `json:"interruptible,omitempty"`
+	// Labels set on the pods and child executions created by the node, overriding the ones of the execution
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations set on the pods and child executions created by the node, overriding the ones of the execution
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// The identity the pods and child executions created by the node run as, overriding the one of the execution
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
 }
 
 func (in *NodeSpec) GetName() string {
@@ -172,6 +243,18 @@ func (in *NodeSpec) IsInterruptible() *bool {
 	return in.Interruptibe
 }
 
+func (in *NodeSpec) GetLabels() map[string]string {
+	return in.Labels
+}
+
+func (in *NodeSpec) GetAnnotations() map[string]string {
+	return in.Annotations
+}
+
+func (in *NodeSpec) GetIdentity() *Identity {
+	return in.Identity
+}
+
 func (in *NodeSpec) GetConfig() *typesv1.ConfigMap {
 	return in.Config
 }
@@ -191,6 +274,20 @@ func (in *NodeSpec) GetWorkflowNode() ExecutableWorkflowNode {
 	return in.WorkflowNode
 }
 
+func (in *NodeSpec) GetGateNode() ExecutableGateNode {
+	if in.GateNode == nil {
+		return nil
+	}
+	return in.GateNode
+}
+
+func (in *NodeSpec) GetArrayNode() ExecutableArrayNode {
+	if in.ArrayNode == nil {
+		return nil
+	}
+	return in.ArrayNode
+}
+
 func (in *NodeSpec) GetBranchNode() ExecutableBranchNode {
 	if in.BranchNode == nil {
 		return nil
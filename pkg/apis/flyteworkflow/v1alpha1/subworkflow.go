@@ -12,6 +12,12 @@ type WorkflowNodeSpec struct {
 	//+optional.
 	// Workflow *WorkflowSpec `json:"workflow,omitempty"`
 	SubWorkflowReference *WorkflowID `json:"subWorkflowRef,omitempty"`
+	// Indicates that the outputs of a launch plan node may be served from, and recorded to, the catalog
+	//+optional.
+	Discoverable bool `json:"discoverable,omitempty"`
+	// Cache version used together with the launch plan interface to compute the catalog key
+	//+optional.
+	DiscoveryVersion string `json:"discoveryVersion,omitempty"`
 }
 
 func (in *WorkflowNodeSpec) GetLaunchPlanRefID() *LaunchPlanRefID {
@@ -21,3 +27,11 @@ func (in *WorkflowNodeSpec) GetLaunchPlanRefID() *LaunchPlanRefID {
 func (in *WorkflowNodeSpec) GetSubWorkflowRef() *WorkflowID {
 	return in.SubWorkflowReference
 }
+
+func (in *WorkflowNodeSpec) IsDiscoverable() bool {
+	return in.Discoverable
+}
+
+func (in *WorkflowNodeSpec) GetDiscoveryVersion() string {
+	return in.DiscoveryVersion
+}
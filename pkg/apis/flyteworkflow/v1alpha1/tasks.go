@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"bytes"
+	"encoding/json"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/golang/protobuf/jsonpb"
@@ -9,6 +10,17 @@ import (
 
 type TaskSpec struct {
 	*core.TaskTemplate
+	// TemplateRef, if set, points to a content-addressed copy of the task template in the DataStore that is shared by all
+	// executions referencing the same task. The CRD then only stores the id and the type of the task, the template has to
+	// be read from the DataStore.
+	TemplateRef DataReference
+}
+
+// The serialized form of a task spec that references its template.
+type taskTemplateReference struct {
+	TemplateRef DataReference   `json:"templateRef"`
+	ID          json.RawMessage `json:"id,omitempty"`
+	Type        TaskType        `json:"type,omitempty"`
 }
 
 func (in *TaskSpec) TaskType() TaskType {
@@ -19,6 +31,10 @@ func (in *TaskSpec) CoreTask() *core.TaskTemplate {
 	return in.TaskTemplate
 }
 
+func (in *TaskSpec) GetTemplateRef() DataReference {
+	return in.TemplateRef
+}
+
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
 	// We do not manipulate the object, so its ok
@@ -26,6 +42,23 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 }
 
 func (in *TaskSpec) MarshalJSON() ([]byte, error) {
+	if len(in.TemplateRef) > 0 {
+		ref := taskTemplateReference{
+			TemplateRef: in.TemplateRef,
+			Type:        in.GetType(),
+		}
+
+		if in.GetId() != nil {
+			var buf bytes.Buffer
+			if err := marshaler.Marshal(&buf, in.GetId()); err != nil {
+				return nil, err
+			}
+			ref.ID = buf.Bytes()
+		}
+
+		return json.Marshal(ref)
+	}
+
 	var buf bytes.Buffer
 	if err := marshaler.Marshal(&buf, in.TaskTemplate); err != nil {
 		return nil, err
@@ -34,6 +67,18 @@ func (in *TaskSpec) MarshalJSON() ([]byte, error) {
 }
 
 func (in *TaskSpec) UnmarshalJSON(b []byte) error {
+	ref := taskTemplateReference{}
+	if err := json.Unmarshal(b, &ref); err == nil && len(ref.TemplateRef) > 0 {
+		in.TemplateRef = ref.TemplateRef
+		in.TaskTemplate = &core.TaskTemplate{Type: ref.Type}
+		if len(ref.ID) > 0 {
+			in.TaskTemplate.Id = &core.Identifier{}
+			return jsonpb.Unmarshal(bytes.NewReader(ref.ID), in.TaskTemplate.Id)
+		}
+		return nil
+	}
+
+	in.TemplateRef = ""
 	in.TaskTemplate = &core.TaskTemplate{}
 	return jsonpb.Unmarshal(bytes.NewReader(b), in.TaskTemplate)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,3 +19,29 @@ func TestTaskSpec(t *testing.T) {
 	assert.NotNil(t, task.CoreTask())
 	assert.Equal(t, "demo", task.TaskType())
 }
+
+func TestTaskSpec_TemplateRef(t *testing.T) {
+	task := &v1alpha1.TaskSpec{
+		TaskTemplate: &core.TaskTemplate{
+			Id:   &core.Identifier{ResourceType: core.ResourceType_TASK, Name: "t1"},
+			Type: "demo",
+		},
+		TemplateRef: "s3://bucket/task-templates/abc",
+	}
+
+	raw, err := json.Marshal(task)
+	assert.NoError(t, err)
+
+	unmarshalled := &v1alpha1.TaskSpec{}
+	assert.NoError(t, json.Unmarshal(raw, unmarshalled))
+	assert.Equal(t, v1alpha1.DataReference("s3://bucket/task-templates/abc"), unmarshalled.GetTemplateRef())
+	assert.Equal(t, "demo", unmarshalled.TaskType())
+	assert.Equal(t, "t1", unmarshalled.CoreTask().GetId().GetName())
+
+	// Inline templates do not have a reference.
+	j, err := ReadYamlFileAsJSON("testdata/task.yaml")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(j, unmarshalled))
+	assert.Empty(t, unmarshalled.GetTemplateRef())
+	assert.Equal(t, "demo", unmarshalled.TaskType())
+}
@@ -57,6 +57,10 @@ type FlyteWorkflow struct {
 	RawOutputDataConfig RawOutputDataConfig `json:"rawOutputDataConfig,omitempty"`
 	// Workflow-execution specifications and overrides
 	ExecutionConfig ExecutionConfig `json:"executionConfig,omitempty"`
+	// EncodedWorkflow, if set, holds the spec, tasks and sub workflows of the workflow, encoded with the codec named by
+	// the flyte.org/workflow-codec annotation. The spec stored inline then only holds the ID of the workflow. Large
+	// workflows are encoded by the workflow store to reduce their size in etcd, and are decoded again when read.
+	EncodedWorkflow []byte `json:"encodedWorkflow,omitempty"`
 
 	// non-Serialized fields (these will not get written to etcd)
 	// As of 2020-07, the only real implementation of this interface is a URLPathConstructor, which is just an empty
@@ -253,9 +257,19 @@ type WorkflowSpec struct {
 	// Defines a single node to execute in case the system determined the Workflow has failed.
 	OnFailure *NodeSpec `json:"onFailure,omitempty"`
 
+	// Defines the nodes to execute once the rest of the workflow reached a terminal state, whether it succeeded, failed
+	// or was aborted. Finally nodes are independent of each other and are not part of the DAG, they run after the
+	// failure node if any, receive the terminal status of the workflow as inputs and do not change it.
+	Finally []*NodeSpec `json:"finally,omitempty"`
+
 	// Defines the declaration of the outputs types and names this workflow is expected to generate.
 	Outputs *OutputVarMap `json:"outputs,omitempty"`
 
+	// Defines the declaration of the inputs types and names this workflow expects. Workflows compiled before the
+	// inputs were recorded in the spec do not have it.
+	// +optional
+	InputVars *InputVarMap `json:"inputVars,omitempty"`
+
 	// Defines the data links used to construct the final outputs of the workflow. Bindings will typically
 	// refer to specific outputs of a subset of the nodes executed in the Workflow. When executing the end-node,
 	// the execution engine will traverse these bindings and assemble the final set of outputs of the workflow.
@@ -303,6 +317,10 @@ func (in *WorkflowSpec) GetOutputs() *OutputVarMap {
 	return in.Outputs
 }
 
+func (in *WorkflowSpec) GetInputVars() *InputVarMap {
+	return in.InputVars
+}
+
 func (in *WorkflowSpec) GetNode(nodeID NodeID) (ExecutableNode, bool) {
 	n, ok := in.Nodes[nodeID]
 	return n, ok
@@ -313,7 +331,10 @@ func (in *WorkflowSpec) GetConnections() *Connections {
 	// deprecated field. This will happen in one of two cases:
 	//  1. If an old Admin generated the CRD
 	//  2. If new propeller is deployed and is unmarshalling an old CRD.
-	if len(in.Connections.Upstream) == 0 && len(in.Connections.Downstream) == 0 {
+	// The connections are only written if there's anything to copy, so that the connections of a spec that's shared by
+	// nodes evaluated concurrently, once copied, are only read.
+	if len(in.Connections.Upstream) == 0 && len(in.Connections.Downstream) == 0 &&
+		(len(in.DeprecatedConnections.UpstreamEdges) > 0 || len(in.DeprecatedConnections.DownstreamEdges) > 0) {
 		in.Connections.Upstream = in.DeprecatedConnections.UpstreamEdges
 		in.Connections.Downstream = in.DeprecatedConnections.DownstreamEdges
 	}
@@ -332,6 +353,14 @@ func (in *WorkflowSpec) GetOnFailureNode() ExecutableNode {
 	return in.OnFailure
 }
 
+func (in *WorkflowSpec) GetFinallyNodes() []ExecutableNode {
+	nodes := make([]ExecutableNode, 0, len(in.Finally))
+	for _, n := range in.Finally {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
 func (in *WorkflowSpec) GetNodes() []NodeID {
 	nodeIds := make([]NodeID, 0, len(in.Nodes))
 	for id := range in.Nodes {
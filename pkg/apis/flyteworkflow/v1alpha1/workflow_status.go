@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"strconv"
+	"sync"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -27,16 +28,33 @@ type WorkflowStatus struct {
 
 	NodeStatus map[NodeID]*NodeStatus `json:"nodeStatus,omitempty"`
 
+	// NodeStatusRef, if set, points to the full status of the nodes in blob storage. The NodeStatus stored inline then
+	// only holds a summary of the phase of every node. The full status is offloaded for workflows too large to be stored
+	// in etcd, and is hydrated again by the workflow store.
+	NodeStatusRef DataReference `json:"nodeStatusRef,omitempty"`
+
+	// EncodedNodeStatus, if set, holds the status of the nodes encoded with the codec named by NodeStatusCodec. The
+	// NodeStatus stored inline then only holds a summary of the phase of every node, like for offloaded node status.
+	EncodedNodeStatus []byte `json:"encodedNodeStatus,omitempty"`
+	NodeStatusCodec   string `json:"nodeStatusCodec,omitempty"`
+
 	// Number of Attempts completed with rounds resulting in error. this is used to cap out poison pill workflows
 	// that spin in an error loop. The value should be set at the global level and will be enforced. At the end of
 	// the retries the workflow will fail
 	FailedAttempts uint32 `json:"failedAttempts,omitempty"`
 
+	// Number of rounds that panicked since the workflow was last quarantined. Workflows that panic repeatedly are
+	// quarantined rather than evaluated over and over again.
+	Panics uint32 `json:"panics,omitempty"`
+
 	// Stores the Error during the Execution of the Workflow. It is optional and usually associated with Failing/Failed state only
 	Error *ExecutionError `json:"error,omitempty"`
 
 	// non-Serialized fields
 	DataReferenceConstructor storage.ReferenceConstructor `json:"-"`
+	// Guards the node statuses and their ephemeral attributes while the workflow is evaluated. It's not copied by
+	// DeepCopy, so every evaluation sets its own.
+	evaluationLock *sync.RWMutex
 }
 
 func IsWorkflowPhaseTerminal(p WorkflowPhase) bool {
@@ -55,7 +73,8 @@ func (in *WorkflowStatus) UpdatePhase(p WorkflowPhase, msg string, err *core.Exe
 	}
 
 	n := metav1.Now()
-	if in.StartedAt == nil {
+	// A workflow that is waiting to be admitted has not started yet.
+	if in.StartedAt == nil && p != WorkflowPhaseReady && p != WorkflowPhaseQueued {
 		in.StartedAt = &n
 	}
 
@@ -81,6 +100,10 @@ func (in *WorkflowStatus) IncFailedAttempts() {
 	in.FailedAttempts++
 }
 
+func (in *WorkflowStatus) IncPanics() {
+	in.Panics++
+}
+
 func (in *WorkflowStatus) GetPhase() WorkflowPhase {
 	return in.Phase
 }
@@ -105,56 +128,75 @@ func (in *WorkflowStatus) GetMessage() string {
 	return in.Message
 }
 
+// SetEvaluationLock sets the lock that guards the node statuses of the workflow, so that they can be looked up, created
+// and cleared from multiple goroutines while the workflow is evaluated. It must be set before the evaluation starts.
+func (in *WorkflowStatus) SetEvaluationLock(lock *sync.RWMutex) {
+	in.evaluationLock = lock
+	for _, n := range in.NodeStatus {
+		n.setEvaluationLock(lock)
+	}
+}
+
+// Returns whether the ephemeral attributes of the given node status are up to date.
+func (in *WorkflowStatus) hasEphemeralNodeExecutionStatusAttributes(ctx context.Context, n *NodeStatus) bool {
+	if n.DataReferenceConstructor != in.DataReferenceConstructor || n.evaluationLock != in.evaluationLock ||
+		len(n.GetDataDir()) == 0 {
+		return false
+	}
+
+	outputDir, err := in.DataReferenceConstructor.ConstructReference(ctx, n.GetDataDir(), strconv.FormatUint(uint64(n.Attempts), 10))
+	return err == nil && n.GetOutputDir() == outputDir
+}
+
 func (in *WorkflowStatus) GetNodeExecutionStatus(ctx context.Context, id NodeID) ExecutableNodeStatus {
+	// Statuses that exist and are up to date, like the ones of upstream nodes that are looked up again, are looked up
+	// under the read lock, so that they can be looked up concurrently.
+	unlock := readLockStatus(in.evaluationLock)
 	n, ok := in.NodeStatus[id]
-	if ok {
-		n.DataReferenceConstructor = in.DataReferenceConstructor
-		if len(n.GetDataDir()) == 0 {
-			dataDir, err := in.ConstructNodeDataDir(ctx, id)
-			if err != nil {
-				logger.Errorf(ctx, "Failed to construct data dir for node [%v]", id)
-				return n
-			}
-
-			n.SetDataDir(dataDir)
-		}
+	upToDate := ok && in.hasEphemeralNodeExecutionStatusAttributes(ctx, n)
+	unlock()
+	if upToDate {
+		return n
+	}
 
-		outputDir, err := in.DataReferenceConstructor.ConstructReference(ctx, n.GetDataDir(), strconv.FormatUint(uint64(n.Attempts), 10))
-		if err != nil {
-			logger.Errorf(ctx, "Failed to construct output dir for node [%v]", id)
-			return n
+	defer lockStatus(in.evaluationLock)()
+	n, ok = in.NodeStatus[id]
+	if !ok {
+		if in.NodeStatus == nil {
+			in.NodeStatus = make(map[NodeID]*NodeStatus)
 		}
-		n.SetOutputDir(outputDir)
 
-		return n
-	}
+		n = &NodeStatus{
+			MutableStruct: MutableStruct{},
+		}
 
-	if in.NodeStatus == nil {
-		in.NodeStatus = make(map[NodeID]*NodeStatus)
+		in.NodeStatus[id] = n
 	}
 
-	newNodeStatus := &NodeStatus{
-		MutableStruct: MutableStruct{},
-	}
+	n.DataReferenceConstructor = in.DataReferenceConstructor
+	n.setEvaluationLock(in.evaluationLock)
+	if len(n.GetDataDir()) == 0 {
+		dataDir, err := in.ConstructNodeDataDir(ctx, id)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to construct data dir for node [%v]", id)
+			return n
+		}
 
-	dataDir, err := in.ConstructNodeDataDir(ctx, id)
-	if err != nil {
-		logger.Errorf(ctx, "Failed to construct data dir for node [%v], exec id [%v]", id)
-		return n
+		n.SetDataDir(dataDir)
 	}
 
-	outputDir, err := in.DataReferenceConstructor.ConstructReference(ctx, dataDir, "0")
+	outputDir, err := in.DataReferenceConstructor.ConstructReference(ctx, n.GetDataDir(), strconv.FormatUint(uint64(n.Attempts), 10))
 	if err != nil {
 		logger.Errorf(ctx, "Failed to construct output dir for node [%v]", id)
 		return n
 	}
+	n.SetOutputDir(outputDir)
 
-	newNodeStatus.SetDataDir(dataDir)
-	newNodeStatus.SetOutputDir(outputDir)
-	newNodeStatus.DataReferenceConstructor = in.DataReferenceConstructor
+	return n
+}
 
-	in.NodeStatus[id] = newNodeStatus
-	return newNodeStatus
+func (in *WorkflowStatus) snapshotNodeStatus() map[NodeID]*NodeStatus {
+	return snapshotNodeStatuses(in.evaluationLock, &in.NodeStatus)
 }
 
 func (in *WorkflowStatus) ConstructNodeDataDir(ctx context.Context, name NodeID) (storage.DataReference, error) {
@@ -185,6 +227,9 @@ func (in *WorkflowStatus) Equals(other *WorkflowStatus) bool {
 	if in.FailedAttempts != other.FailedAttempts {
 		return false
 	}
+	if in.Panics != other.Panics {
+		return false
+	}
 	if in.Phase != other.Phase {
 		return false
 	}
@@ -197,12 +242,13 @@ func (in *WorkflowStatus) Equals(other *WorkflowStatus) bool {
 		return false
 	}
 
-	if len(in.NodeStatus) != len(other.NodeStatus) {
+	nodeStatus, otherNodeStatus := in.snapshotNodeStatus(), other.snapshotNodeStatus()
+	if len(nodeStatus) != len(otherNodeStatus) {
 		return false
 	}
 
-	for k, v := range in.NodeStatus {
-		otherV, ok := other.NodeStatus[k]
+	for k, v := range nodeStatus {
+		otherV, ok := otherNodeStatus[k]
 		if !ok {
 			return false
 		}
@@ -1,8 +1,12 @@
 package v1alpha1
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 
+	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,3 +56,71 @@ func TestWorkflowStatus_Equals(t *testing.T) {
 	other.OutputReference = "out"
 	assert.True(t, one.Equals(other))
 }
+
+func TestWorkflowStatus_GetNodeExecutionStatus(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("Concurrent", func(t *testing.T) {
+		s := WorkflowStatus{
+			DataDir:                  "/wf",
+			DataReferenceConstructor: storage.URLPathConstructor{},
+		}
+		s.SetEvaluationLock(&sync.RWMutex{})
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id := fmt.Sprintf("n%d", i%5)
+				n := s.GetNodeExecutionStatus(ctx, id)
+				assert.Equal(t, storage.DataReference("/wf/"+id+"/data"), n.GetDataDir())
+				assert.Equal(t, storage.DataReference("/wf/"+id+"/data/0"), n.GetOutputDir())
+			}(i)
+		}
+
+		wg.Wait()
+		assert.Len(t, s.NodeStatus, 5)
+	})
+}
+
+func TestWorkflowStatus_SetEvaluationLock(t *testing.T) {
+	ctx := context.TODO()
+	s := &WorkflowStatus{
+		DataDir:                  "/wf",
+		DataReferenceConstructor: storage.URLPathConstructor{},
+		NodeStatus: map[NodeID]*NodeStatus{
+			"n0": {
+				SubNodeStatus: map[NodeID]*NodeStatus{
+					"n0-0": {},
+				},
+			},
+		},
+	}
+
+	lock := &sync.RWMutex{}
+	s.SetEvaluationLock(lock)
+	assert.Same(t, lock, s.NodeStatus["n0"].evaluationLock)
+	assert.Same(t, lock, s.NodeStatus["n0"].SubNodeStatus["n0-0"].evaluationLock)
+
+	t.Run("new statuses", func(t *testing.T) {
+		n := s.GetNodeExecutionStatus(ctx, "n1").(*NodeStatus)
+		assert.Same(t, lock, n.evaluationLock)
+		assert.Same(t, lock, n.GetNodeExecutionStatus(ctx, "n1-0").(*NodeStatus).evaluationLock)
+	})
+
+	t.Run("not deep copied", func(t *testing.T) {
+		c := s.DeepCopy()
+		assert.Nil(t, c.evaluationLock)
+		assert.Nil(t, c.NodeStatus["n0"].evaluationLock)
+		assert.Nil(t, c.NodeStatus["n0"].SubNodeStatus["n0-0"].evaluationLock)
+		assert.True(t, c.Equals(s))
+	})
+
+	t.Run("next evaluation", func(t *testing.T) {
+		next := &sync.RWMutex{}
+		s.SetEvaluationLock(next)
+		assert.Same(t, next, s.NodeStatus["n1"].evaluationLock)
+		assert.Same(t, next, s.NodeStatus["n1"].SubNodeStatus["n1-0"].evaluationLock)
+	})
+}
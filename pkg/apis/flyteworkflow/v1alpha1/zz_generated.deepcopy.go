@@ -22,6 +22,47 @@ func (in *Alias) DeepCopy() *Alias {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArrayNodeSpec) DeepCopyInto(out *ArrayNodeSpec) {
+	*out = *in
+	if in.SubNodeSpec != nil {
+		in, out := &in.SubNodeSpec, &out.SubNodeSpec
+		*out = new(NodeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinSuccesses != nil {
+		in, out := &in.MinSuccesses, &out.MinSuccesses
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinSuccessRatio != nil {
+		in, out := &in.MinSuccessRatio, &out.MinSuccessRatio
+		*out = new(float32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArrayNodeSpec.
+func (in *ArrayNodeSpec) DeepCopy() *ArrayNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArrayNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArrayNodeStatus.
+func (in *ArrayNodeStatus) DeepCopy() *ArrayNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArrayNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Binding.
 func (in *Binding) DeepCopy() *Binding {
 	if in == nil {
@@ -66,6 +107,11 @@ func (in *BranchNodeSpec) DeepCopyInto(out *BranchNodeSpec) {
 		in, out := &in.ElseFail, &out.ElseFail
 		*out = (*in).DeepCopy()
 	}
+	if in.ElseFailMetadata != nil {
+		in, out := &in.ElseFailMetadata, &out.ElseFailMetadata
+		*out = new(ElseFailMetadata)
+		**out = **in
+	}
 	return
 }
 
@@ -152,6 +198,22 @@ func (in *Error) DeepCopy() *Error {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElseFailMetadata) DeepCopyInto(out *ElseFailMetadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElseFailMetadata.
+func (in *ElseFailMetadata) DeepCopy() *ElseFailMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ElseFailMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecutionConfig) DeepCopyInto(out *ExecutionConfig) {
 	*out = *in
@@ -163,6 +225,20 @@ func (in *ExecutionConfig) DeepCopyInto(out *ExecutionConfig) {
 		}
 	}
 	out.MaxParallelism = in.MaxParallelism
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -176,6 +252,36 @@ func (in *ExecutionConfig) DeepCopy() *ExecutionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionEnvironment) DeepCopyInto(out *ExecutionEnvironment) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.ResourceRequirements, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionEnvironment.
+func (in *ExecutionEnvironment) DeepCopy() *ExecutionEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionError.
 func (in *ExecutionError) DeepCopy() *ExecutionError {
 	if in == nil {
@@ -250,6 +356,11 @@ func (in *FlyteWorkflow) DeepCopyInto(out *FlyteWorkflow) {
 	in.Status.DeepCopyInto(&out.Status)
 	in.RawOutputDataConfig.DeepCopyInto(&out.RawOutputDataConfig)
 	in.ExecutionConfig.DeepCopyInto(&out.ExecutionConfig)
+	if in.EncodedWorkflow != nil {
+		in, out := &in.EncodedWorkflow, &out.EncodedWorkflow
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
 	if in.DataReferenceConstructor != nil {
 		out.DataReferenceConstructor = in.DataReferenceConstructor
 	}
@@ -307,6 +418,27 @@ func (in *FlyteWorkflowList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateNodeSpec) DeepCopyInto(out *GateNodeSpec) {
+	*out = *in
+	if in.Sleep != nil {
+		in, out := &in.Sleep, &out.Sleep
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateNodeSpec.
+func (in *GateNodeSpec) DeepCopy() *GateNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GateNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Identifier.
 func (in *Identifier) DeepCopy() *Identifier {
 	if in == nil {
@@ -317,6 +449,22 @@ func (in *Identifier) DeepCopy() *Identifier {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Identity) DeepCopyInto(out *Identity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Identity.
+func (in *Identity) DeepCopy() *Identity {
+	if in == nil {
+		return nil
+	}
+	out := new(Identity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IfBlock) DeepCopyInto(out *IfBlock) {
 	*out = *in
@@ -414,6 +562,16 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = new(WorkflowNodeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GateNode != nil {
+		in, out := &in.GateNode, &out.GateNode
+		*out = new(GateNodeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArrayNode != nil {
+		in, out := &in.ArrayNode, &out.ArrayNode
+		*out = new(ArrayNodeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InputBindings != nil {
 		in, out := &in.InputBindings, &out.InputBindings
 		*out = make([]*Binding, len(*in))
@@ -478,6 +636,25 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(Identity)
+		**out = **in
+	}
 	return
 }
 
@@ -494,6 +671,7 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	out.evaluationLock = nil
 	out.MutableStruct = in.MutableStruct
 	if in.QueuedAt != nil {
 		in, out := &in.QueuedAt, &out.QueuedAt
@@ -558,10 +736,20 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 		*out = new(DynamicNodeStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArrayNodeStatus != nil {
+		in, out := &in.ArrayNodeStatus, &out.ArrayNodeStatus
+		*out = new(ArrayNodeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Error != nil {
 		in, out := &in.Error, &out.Error
 		*out = (*in).DeepCopy()
 	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(RetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DataReferenceConstructor != nil {
 		out.DataReferenceConstructor = in.DataReferenceConstructor
 	}
@@ -578,6 +766,16 @@ func (in *NodeStatus) DeepCopy() *NodeStatus {
 	return out
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputVarMap.
+func (in *InputVarMap) DeepCopy() *InputVarMap {
+	if in == nil {
+		return nil
+	}
+	out := new(InputVarMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputVarMap.
 func (in *OutputVarMap) DeepCopy() *OutputVarMap {
 	if in == nil {
@@ -598,6 +796,44 @@ func (in *RawOutputDataConfig) DeepCopy() *RawOutputDataConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBackoff) DeepCopyInto(out *RetryBackoff) {
+	*out = *in
+	if in.NextAttemptAt != nil {
+		in, out := &in.NextAttemptAt, &out.NextAttemptAt
+		*out = (*in).DeepCopy()
+	}
+	out.Delay = in.Delay
+	out.MaxDelay = in.MaxDelay
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBackoff.
+func (in *RetryBackoff) DeepCopy() *RetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
 	*out = *in
@@ -606,6 +842,11 @@ func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]RetryPolicy, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -619,6 +860,82 @@ func (in *RetryStrategy) DeepCopy() *RetryStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Signal) DeepCopyInto(out *Signal) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Signal.
+func (in *Signal) DeepCopy() *Signal {
+	if in == nil {
+		return nil
+	}
+	out := new(Signal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Signal) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignalList) DeepCopyInto(out *SignalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Signal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignalList.
+func (in *SignalList) DeepCopy() *SignalList {
+	if in == nil {
+		return nil
+	}
+	out := new(SignalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SignalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignalSpec) DeepCopyInto(out *SignalSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignalSpec.
+func (in *SignalSpec) DeepCopy() *SignalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SignalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionIdentifier.
 func (in *TaskExecutionIdentifier) DeepCopy() *TaskExecutionIdentifier {
 	if in == nil {
@@ -720,6 +1037,10 @@ func (in *WorkflowNodeStatus) DeepCopyInto(out *WorkflowNodeStatus) {
 		*out = new(core.ExecutionError)
 		*out = *in
 	}
+	if in.KilledAt != nil {
+		in, out := &in.KilledAt, &out.KilledAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -759,10 +1080,25 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 		*out = new(NodeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = make([]*NodeSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(NodeSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.Outputs != nil {
 		in, out := &in.Outputs, &out.Outputs
 		*out = (*in).DeepCopy()
 	}
+	if in.InputVars != nil {
+		in, out := &in.InputVars, &out.InputVars
+		*out = (*in).DeepCopy()
+	}
 	if in.OutputBindings != nil {
 		in, out := &in.OutputBindings, &out.OutputBindings
 		*out = make([]*Binding, len(*in))
@@ -789,6 +1125,7 @@ func (in *WorkflowSpec) DeepCopy() *WorkflowSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 	*out = *in
+	out.evaluationLock = nil
 	if in.StartedAt != nil {
 		in, out := &in.StartedAt, &out.StartedAt
 		*out = (*in).DeepCopy()
@@ -816,6 +1153,11 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.EncodedNodeStatus != nil {
+		in, out := &in.EncodedNodeStatus, &out.EncodedNodeStatus
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
 	if in.Error != nil {
 		in, out := &in.Error, &out.Error
 		*out = (*in).DeepCopy()
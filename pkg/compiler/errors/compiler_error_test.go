@@ -33,6 +33,8 @@ func TestErrorCodes(t *testing.T) {
 		UnrecognizedValue:          NewUnrecognizedValueErr("", ""),
 		WorkflowBuildError:         NewWorkflowBuildError(errors.New("")),
 		NoNodesFound:               NewNoNodesFoundErr(""),
+		UniqueNodeIDCollision:      NewUniqueNodeIDCollisionErr("", "", "", ""),
+		LaunchPlanInterfaceDrift:   NewLaunchPlanInterfaceDriftErr("", "", "", ""),
 	}
 
 	for key, value := range testCases {
@@ -90,6 +90,15 @@ const (
 
 	// Given value cannot be assigned to any union variant in a binding
 	IncompatibleBindingUnionValue ErrorCode = "IncompatibleBindingUnionValue"
+
+	// An identity the workflow or one of its nodes runs as is not in the allowlist
+	IdentityNotAllowed ErrorCode = "IdentityNotAllowed"
+
+	// Two nodes execute with the same unique ID, generated from the IDs of their parent nodes and attempts.
+	UniqueNodeIDCollision ErrorCode = "UniqueNodeIdCollision"
+
+	// The interface of a launch plan no longer matches the one the workflow referencing it was compiled against.
+	LaunchPlanInterfaceDrift ErrorCode = "LaunchPlanInterfaceDrift"
 )
 
 func NewBranchNodeNotSpecified(branchNodeID string) *CompileError {
@@ -316,6 +325,30 @@ func NewIncompatibleBindingUnionValue(nodeID, sinkParam, expectedType, binding s
 	)
 }
 
+func NewIdentityNotAllowedErr(nodeID, kind, value string) *CompileError {
+	return newError(
+		IdentityNotAllowed,
+		fmt.Sprintf("%v [%v] is not allowed.", kind, value),
+		nodeID,
+	)
+}
+
+func NewUniqueNodeIDCollisionErr(nodeID, uniqueID, path, otherPath string) *CompileError {
+	return newError(
+		UniqueNodeIDCollision,
+		fmt.Sprintf("Node [%v] executes with unique ID [%v], which is also the unique ID of node [%v].", path, uniqueID, otherPath),
+		nodeID,
+	)
+}
+
+func NewLaunchPlanInterfaceDriftErr(nodeID, variable, boundType, launchPlanType string) *CompileError {
+	return newError(
+		LaunchPlanInterfaceDrift,
+		fmt.Sprintf("Input [%v] of the launch plan is [%v], the workflow binds [%v].", variable, launchPlanType, boundType),
+		nodeID,
+	)
+}
+
 func newError(code ErrorCode, description, nodeID string) (err *CompileError) {
 	err = &CompileError{
 		code:        code,
@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+// IdentityAllowlist holds the service accounts and IAM roles that the execution config and the nodes of a workflow may
+// run as. An empty list allows any value.
+type IdentityAllowlist struct {
+	ServiceAccounts []string
+	IamRoles        []string
+}
+
+func isAllowed(allowed []string, value string) bool {
+	if len(allowed) == 0 || len(value) == 0 {
+		return true
+	}
+
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateIdentity(nodeID string, identity v1alpha1.Identity, allowlist IdentityAllowlist, errs errors.CompileErrors) {
+	if !isAllowed(allowlist.ServiceAccounts, identity.K8sServiceAccount) {
+		errs.Collect(errors.NewIdentityNotAllowedErr(nodeID, "Service account", identity.K8sServiceAccount))
+	}
+
+	if !isAllowed(allowlist.IamRoles, identity.IamRole) {
+		errs.Collect(errors.NewIdentityNotAllowedErr(nodeID, "IAM role", identity.IamRole))
+	}
+}
+
+func validateNodeIdentities(spec *v1alpha1.WorkflowSpec, allowlist IdentityAllowlist, errs errors.CompileErrors) {
+	if spec == nil {
+		return
+	}
+
+	for id, n := range spec.Nodes {
+		if n.GetIdentity() != nil {
+			validateIdentity(id, *n.GetIdentity(), allowlist, errs)
+		}
+	}
+}
+
+// ValidateIdentities validates that the identities the execution config and the nodes of the workflow, including the
+// ones of its sub workflows, override the identity of the execution with are in the allowlist.
+func ValidateIdentities(wf *v1alpha1.FlyteWorkflow, allowlist IdentityAllowlist) error {
+	errs := errors.NewCompileErrors()
+	validateIdentity("root", wf.ExecutionConfig.Identity, allowlist, errs.NewScope())
+	validateNodeIdentities(wf.WorkflowSpec, allowlist, errs.NewScope())
+	for _, subWf := range wf.SubWorkflows {
+		validateNodeIdentities(subWf, allowlist, errs.NewScope())
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return nil
+}
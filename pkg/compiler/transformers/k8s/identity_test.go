@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+func TestValidateIdentities(t *testing.T) {
+	allowlist := IdentityAllowlist{
+		ServiceAccounts: []string{"default", "etl"},
+		IamRoles:        []string{"arn:aws:iam::123:role/etl"},
+	}
+
+	newWorkflow := func(execIdentity v1alpha1.Identity, nodeIdentity, subWfNodeIdentity *v1alpha1.Identity) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ExecutionConfig: v1alpha1.ExecutionConfig{Identity: execIdentity},
+			WorkflowSpec: &v1alpha1.WorkflowSpec{
+				Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+					"n0": {ID: "n0", Identity: nodeIdentity},
+				},
+			},
+			SubWorkflows: map[v1alpha1.WorkflowID]*v1alpha1.WorkflowSpec{
+				"sub": {
+					Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+						"n1": {ID: "n1", Identity: subWfNodeIdentity},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		wf := newWorkflow(v1alpha1.Identity{K8sServiceAccount: "etl"},
+			&v1alpha1.Identity{IamRole: "arn:aws:iam::123:role/etl"}, nil)
+		assert.NoError(t, ValidateIdentities(wf, allowlist))
+	})
+
+	t.Run("empty allowlist", func(t *testing.T) {
+		wf := newWorkflow(v1alpha1.Identity{K8sServiceAccount: "admin"}, &v1alpha1.Identity{IamRole: "admin"}, nil)
+		assert.NoError(t, ValidateIdentities(wf, IdentityAllowlist{}))
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		wf := newWorkflow(v1alpha1.Identity{K8sServiceAccount: "admin"}, &v1alpha1.Identity{IamRole: "admin"},
+			&v1alpha1.Identity{K8sServiceAccount: "admin"})
+		err := ValidateIdentities(wf, allowlist)
+		assert.Error(t, err)
+
+		compileErrs, ok := err.(errors.CompileErrors)
+		assert.True(t, ok)
+		assert.Equal(t, 3, compileErrs.ErrorCount())
+		for _, e := range compileErrs.Errors().List() {
+			assert.Equal(t, errors.IdentityNotAllowed, e.Code())
+		}
+	})
+}
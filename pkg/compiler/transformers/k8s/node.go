@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginsUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/common"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
@@ -91,6 +92,17 @@ func buildNodeSpec(n *core.Node, tasks []*core.CompiledTask, errs errors.Compile
 
 	switch v := n.GetTarget().(type) {
 	case *core.Node_TaskNode:
+		if task.GetType() == v1alpha1.GateTaskType {
+			gateNode, ok := buildGateNodeSpec(n.GetId(), task, errs.NewScope())
+			if !ok {
+				return nil, !errs.HasErrors()
+			}
+
+			nodeSpec.Kind = v1alpha1.NodeKindGate
+			nodeSpec.GateNode = gateNode
+			break
+		}
+
 		nodeSpec.Kind = v1alpha1.NodeKindTask
 		nodeSpec.TaskRef = refStr(n.GetTaskNode().GetReferenceId().String())
 	case *core.Node_WorkflowNode:
@@ -139,6 +151,41 @@ func buildNodeSpec(n *core.Node, tasks []*core.CompiledTask, errs errors.Compile
 	return []*v1alpha1.NodeSpec{nodeSpec}, !errs.HasErrors()
 }
 
+// Builds the gate spec of a node that references a gate task. The IDL has no gate nodes, gates are declared as task
+// templates of type gate that hold the GateNodeSpec in their custom field.
+func buildGateNodeSpec(nodeID string, task *core.TaskTemplate, errs errors.CompileErrors) (*v1alpha1.GateNodeSpec, bool) {
+	if task.GetCustom() == nil {
+		errs.Collect(errors.NewValueRequiredErr(nodeID, "gate:custom"))
+		return nil, !errs.HasErrors()
+	}
+
+	gateNode := &v1alpha1.GateNodeSpec{}
+	if err := pluginsUtils.UnmarshalStructToObj(task.GetCustom(), gateNode); err != nil {
+		errs.Collect(errors.NewSyntaxError(nodeID, "gate:custom", err))
+		return nil, !errs.HasErrors()
+	}
+
+	switch gateNode.GetGateKind() {
+	case v1alpha1.GateNodeKindSleep:
+		if gateNode.GetSleepDuration() <= 0 {
+			errs.Collect(errors.NewValueRequiredErr(nodeID, "gate:sleep"))
+		}
+	case v1alpha1.GateNodeKindSignal, v1alpha1.GateNodeKindApprove:
+		if len(gateNode.GetSignalID()) == 0 {
+			errs.Collect(errors.NewValueRequiredErr(nodeID, "gate:signalId"))
+		}
+	default:
+		errs.Collect(errors.NewUnrecognizedValueErr(nodeID, string(gateNode.GetGateKind())))
+	}
+
+	// Gates do not produce outputs, downstream nodes could never bind to them.
+	if len(task.GetInterface().GetOutputs().GetVariables()) > 0 {
+		errs.Collect(errors.NewInvalidValueErr(nodeID, "gate:interface:outputs"))
+	}
+
+	return gateNode, !errs.HasErrors()
+}
+
 func buildIfBlockSpec(block *core.IfBlock, tasks []*core.CompiledTask, errs errors.CompileErrors) (*v1alpha1.IfBlock, []*v1alpha1.NodeSpec) {
 	nodeSpecs, ok := buildNodeSpec(block.ThenNode, tasks, errs)
 	if !ok {
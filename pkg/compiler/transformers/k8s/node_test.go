@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -211,6 +212,81 @@ func TestBuildNodeSpec(t *testing.T) {
 
 }
 
+func TestBuildNodeSpec_Gate(t *testing.T) {
+	gateTask := func(t testing.TB, custom map[string]interface{}, outputs *core.VariableMap) []*core.CompiledTask {
+		customStruct, err := structpb.NewStruct(custom)
+		assert.NoError(t, err)
+		return []*core.CompiledTask{
+			{
+				Template: &core.TaskTemplate{
+					Id:        &core.Identifier{Name: "gate_1"},
+					Type:      v1alpha1.GateTaskType,
+					Custom:    customStruct,
+					Interface: &core.TypedInterface{Outputs: outputs},
+				},
+			},
+		}
+	}
+
+	n := &core.Node{
+		Id: "n_1",
+		Target: &core.Node_TaskNode{
+			TaskNode: &core.TaskNode{
+				Reference: &core.TaskNode_ReferenceId{
+					ReferenceId: &core.Identifier{Name: "gate_1"},
+				},
+			},
+		},
+	}
+
+	t.Run("Sleep", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		specs, ok := buildNodeSpec(n, gateTask(t, map[string]interface{}{"kind": "sleep", "sleep": "10m"}, nil), errs)
+		assert.True(t, ok)
+		assert.False(t, errs.HasErrors())
+		if assert.Len(t, specs, 1) {
+			assert.Equal(t, v1alpha1.NodeKindGate, specs[0].Kind)
+			assert.Nil(t, specs[0].TaskRef)
+			assert.Equal(t, v1alpha1.GateNodeKindSleep, specs[0].GetGateNode().GetGateKind())
+			assert.Equal(t, 10*time.Minute, specs[0].GetGateNode().GetSleepDuration())
+		}
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		specs, ok := buildNodeSpec(n, gateTask(t, map[string]interface{}{"kind": "approve", "signalId": "deploy"}, nil), errs)
+		assert.True(t, ok)
+		assert.False(t, errs.HasErrors())
+		if assert.Len(t, specs, 1) {
+			assert.Equal(t, v1alpha1.NodeKindGate, specs[0].Kind)
+			assert.Equal(t, v1alpha1.GateNodeKindApprove, specs[0].GetGateNode().GetGateKind())
+			assert.Equal(t, "deploy", specs[0].GetGateNode().GetSignalID())
+		}
+	})
+
+	t.Run("Signal without id", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		_, ok := buildNodeSpec(n, gateTask(t, map[string]interface{}{"kind": "signal"}, nil), errs)
+		assert.False(t, ok)
+		assert.True(t, errs.HasErrors())
+	})
+
+	t.Run("Unknown kind", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		_, ok := buildNodeSpec(n, gateTask(t, map[string]interface{}{"kind": "wait"}, nil), errs)
+		assert.False(t, ok)
+		assert.True(t, errs.HasErrors())
+	})
+
+	t.Run("Outputs", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		outputs := &core.VariableMap{Variables: map[string]*core.Variable{"o": {Type: &core.LiteralType{}}}}
+		_, ok := buildNodeSpec(n, gateTask(t, map[string]interface{}{"kind": "signal", "signalId": "s"}, outputs), errs)
+		assert.False(t, ok)
+		assert.True(t, errs.HasErrors())
+	})
+}
+
 func TestBuildTasks(t *testing.T) {
 
 	withoutAnnotations := make(map[string]*core.Variable)
@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// The key under the offloading prefix task templates are stored at.
+const taskTemplatesKey = "task-templates"
+
+// Returns the content-addressed location of a task template under the given prefix. Equal templates get the same
+// location, no matter which execution they belong to.
+func taskTemplateReference(ctx context.Context, store *storage.DataStore, prefix storage.DataReference,
+	t *core.TaskTemplate) (storage.DataReference, error) {
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(t); err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b.Bytes())
+	return store.ConstructReference(ctx, prefix, taskTemplatesKey, hex.EncodeToString(sum[:]))
+}
+
+// OffloadTaskTemplates moves the templates of the tasks of a FlyteWorkflow to content-addressed locations under the given
+// prefix in the DataStore, and only keeps references to them in the FlyteWorkflow. Templates shared by many executions
+// are then stored once rather than in every FlyteWorkflow CRD, and are read lazily while the workflow executes.
+func OffloadTaskTemplates(ctx context.Context, store *storage.DataStore, prefix storage.DataReference,
+	w *v1alpha1.FlyteWorkflow) error {
+	for id, t := range w.Tasks {
+		if t == nil || t.TaskTemplate == nil || len(t.TemplateRef) > 0 {
+			continue
+		}
+
+		ref, err := taskTemplateReference(ctx, store, prefix, t.TaskTemplate)
+		if err != nil {
+			return err
+		}
+
+		md, err := store.Head(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		if !md.Exists() {
+			if err := store.WriteProtobuf(ctx, ref, storage.Options{}, t.TaskTemplate); err != nil {
+				return err
+			}
+		}
+
+		w.Tasks[id] = &v1alpha1.TaskSpec{
+			TaskTemplate: &core.TaskTemplate{
+				Id:   t.GetId(),
+				Type: t.GetType(),
+			},
+			TemplateRef: ref,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func newOffloadingTestWorkflow() *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		Tasks: map[v1alpha1.TaskID]*v1alpha1.TaskSpec{
+			"t1": {TaskTemplate: &core.TaskTemplate{
+				Id:   &core.Identifier{ResourceType: core.ResourceType_TASK, Name: "t1"},
+				Type: "python-task",
+				Metadata: &core.TaskMetadata{
+					Retries: &core.RetryStrategy{Retries: 3},
+				},
+			}},
+			"t2": {TaskTemplate: &core.TaskTemplate{
+				Id:   &core.Identifier{ResourceType: core.ResourceType_TASK, Name: "t2"},
+				Type: "hive",
+			}},
+		},
+	}
+}
+
+func TestOffloadTaskTemplates(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	w1 := newOffloadingTestWorkflow()
+	original := proto.Clone(w1.Tasks["t1"].TaskTemplate)
+	assert.NoError(t, OffloadTaskTemplates(ctx, store, "s3://bucket/shared", w1))
+
+	t1 := w1.Tasks["t1"]
+	assert.NotEmpty(t, t1.GetTemplateRef())
+	assert.Equal(t, "python-task", t1.TaskType())
+	assert.Equal(t, "t1", t1.CoreTask().GetId().GetName())
+	assert.Nil(t, t1.CoreTask().GetMetadata())
+
+	stored := &core.TaskTemplate{}
+	assert.NoError(t, store.ReadProtobuf(ctx, t1.GetTemplateRef(), stored))
+	assert.True(t, proto.Equal(original, stored))
+
+	// Other executions of the same tasks reference the same templates.
+	w2 := newOffloadingTestWorkflow()
+	assert.NoError(t, OffloadTaskTemplates(ctx, store, "s3://bucket/shared", w2))
+	assert.Equal(t, t1.GetTemplateRef(), w2.Tasks["t1"].GetTemplateRef())
+	assert.NotEqual(t, w2.Tasks["t1"].GetTemplateRef(), w2.Tasks["t2"].GetTemplateRef())
+
+	// Offloading is idempotent.
+	ref := t1.GetTemplateRef()
+	assert.NoError(t, OffloadTaskTemplates(ctx, store, "s3://bucket/shared", w1))
+	assert.Equal(t, ref, w1.Tasks["t1"].GetTemplateRef())
+}
@@ -21,6 +21,9 @@ const (
 	DomainLabel = "domain"
 	// A concatenation of project, domain, workflow name, and a unique ID
 	ExecutionIDLabel = "execution-id"
+	// The name of the launch plan the FlyteWorkflow was launched from. The compiler does not know about launch plans, this
+	// label is set by the launcher of the execution, if at all.
+	LaunchPlanNameLabel = "launch-plan-name"
 	// The FlyteWorkflow project according to registration ownership
 	ProjectLabel = "project"
 	// Shard keys are used during FlytePropeller sharding, this value is set to a hash of the FlyteWorkflow ExecutionID.
@@ -111,6 +114,11 @@ func buildFlyteWorkflowSpec(wf *core.CompiledWorkflow, tasks []*core.CompiledTas
 		outputs = &v1alpha1.OutputVarMap{VariableMap: &core.VariableMap{}}
 	}
 
+	inputs := &v1alpha1.InputVarMap{VariableMap: &core.VariableMap{}}
+	if wf.Template.GetInterface().GetInputs() != nil {
+		inputs.VariableMap = wf.Template.GetInterface().GetInputs()
+	}
+
 	failurePolicy := v1alpha1.WorkflowOnFailurePolicy(core.WorkflowMetadata_FAIL_IMMEDIATELY)
 	if wf.Template != nil && wf.Template.Metadata != nil {
 		failurePolicy = v1alpha1.WorkflowOnFailurePolicy(wf.Template.Metadata.OnFailure)
@@ -122,6 +130,7 @@ func buildFlyteWorkflowSpec(wf *core.CompiledWorkflow, tasks []*core.CompiledTas
 		OnFailure:       failureN,
 		Nodes:           nodes,
 		Outputs:         outputs,
+		InputVars:       inputs,
 		OutputBindings:  outputBindings,
 		OnFailurePolicy: failurePolicy,
 		Connections:     connections,
@@ -156,9 +165,17 @@ func generateName(wfID *core.Identifier, execID *core.WorkflowExecutionIdentifie
 	}
 }
 
+// ComputeShardKey returns the value of the shard-key label of a FlyteWorkflow with the given execution-id label, a hash
+// of the execution-id within the shard keyspace.
+func ComputeShardKey(executionIDLabel string) string {
+	h := fnv.New32a()
+	h.Write([]byte(executionIDLabel))
+	return fmt.Sprint(h.Sum32() % v1alpha1.ShardKeyspaceSize)
+}
+
 // Builds v1alpha1.FlyteWorkflow resource. Returned error, if not nil, is of type errors.CompilerErrors.
 func BuildFlyteWorkflow(wfClosure *core.CompiledWorkflowClosure, inputs *core.LiteralMap,
-	executionID *core.WorkflowExecutionIdentifier, namespace string) (*v1alpha1.FlyteWorkflow, error) {
+	executionID *core.WorkflowExecutionIdentifier, namespace string, opts ...BuildOption) (*v1alpha1.FlyteWorkflow, error) {
 
 	errs := errors.NewCompileErrors()
 	if wfClosure == nil {
@@ -185,6 +202,16 @@ func BuildFlyteWorkflow(wfClosure *core.CompiledWorkflowClosure, inputs *core.Li
 		return nil, errs
 	}
 
+	options := &buildOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	markCachedLaunchPlanNodes(primarySpec, options.cachedLaunchPlans)
+	for _, spec := range subwfs {
+		markCachedLaunchPlanNodes(spec, options.cachedLaunchPlans)
+	}
+
 	wf := wfClosure.Primary.Template
 	tasks := wfClosure.Tasks
 	// Fill in inputs in the start node.
@@ -230,11 +257,7 @@ func BuildFlyteWorkflow(wfClosure *core.CompiledWorkflowClosure, inputs *core.Li
 	obj.ObjectMeta.Labels[DomainLabel] = domain
 	obj.ObjectMeta.Labels[WorkflowNameLabel] = utils.SanitizeLabelValue(WorkflowNameFromID(primarySpec.ID))
 
-	h := fnv.New32a()
-	h.Write([]byte(label))
-	hash := h.Sum32() % v1alpha1.ShardKeyspaceSize
-
-	obj.ObjectMeta.Labels[ShardKeyLabel] = fmt.Sprint(hash)
+	obj.ObjectMeta.Labels[ShardKeyLabel] = ComputeShardKey(label)
 
 	if obj.Nodes == nil || obj.Connections.Downstream == nil {
 		// If we come here, we'd better have an error generated earlier. Otherwise, add one to make sure build fails.
@@ -255,6 +278,45 @@ func BuildFlyteWorkflow(wfClosure *core.CompiledWorkflowClosure, inputs *core.Li
 	return obj, nil
 }
 
+// BuildOption configures the FlyteWorkflow built by BuildFlyteWorkflow beyond what the compiled workflow tells.
+type BuildOption func(options *buildOptions)
+
+type buildOptions struct {
+	// The discovery versions of the launch plans whose nodes are served from the catalog, keyed by launch plan id.
+	cachedLaunchPlans map[string]string
+}
+
+// WithCachedLaunchPlan makes the nodes that launch the launch plan discoverable, so that their outputs are served from,
+// and recorded to, the catalog under the discovery version. The compiled workflow does not know whether a launch plan
+// is cache-enabled, the launcher of the execution does.
+func WithCachedLaunchPlan(launchPlanID *core.Identifier, discoveryVersion string) BuildOption {
+	return func(options *buildOptions) {
+		if options.cachedLaunchPlans == nil {
+			options.cachedLaunchPlans = map[string]string{}
+		}
+
+		options.cachedLaunchPlans[launchPlanID.String()] = discoveryVersion
+	}
+}
+
+// Sets the discovery fields of the launch plan nodes of the workflow, including those nested in branches.
+func markCachedLaunchPlanNodes(spec *v1alpha1.WorkflowSpec, cachedLaunchPlans map[string]string) {
+	if len(cachedLaunchPlans) == 0 {
+		return
+	}
+
+	for _, n := range spec.Nodes {
+		if n.WorkflowNode == nil || n.WorkflowNode.LaunchPlanRefID == nil {
+			continue
+		}
+
+		if version, ok := cachedLaunchPlans[n.WorkflowNode.LaunchPlanRefID.String()]; ok {
+			n.WorkflowNode.Discoverable = true
+			n.WorkflowNode.DiscoveryVersion = version
+		}
+	}
+}
+
 func toMapOfLists(connections map[string]*core.ConnectionSet_IdList) map[string][]string {
 	res := make(map[string][]string, len(connections))
 	for key, val := range connections {
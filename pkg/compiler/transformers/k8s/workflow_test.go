@@ -3,10 +3,12 @@ package k8s
 import (
 	"bytes"
 	"io/ioutil"
+	"strconv"
 	"testing"
 
 	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/common"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
 	"github.com/golang/protobuf/jsonpb"
@@ -122,6 +124,59 @@ func TestBuildFlyteWorkflow(t *testing.T) {
 	errors.SetConfig(errors.Config{})
 }
 
+func TestBuildFlyteWorkflow_withCachedLaunchPlan(t *testing.T) {
+	lpID := &core.Identifier{ResourceType: core.ResourceType_LAUNCH_PLAN, Project: "p", Domain: "d", Name: "lp", Version: "v1"}
+	otherLpID := &core.Identifier{ResourceType: core.ResourceType_LAUNCH_PLAN, Project: "p", Domain: "d", Name: "other", Version: "v1"}
+	lpNode := func(id string, ref *core.Identifier) *core.Node {
+		return &core.Node{
+			Id: id,
+			Target: &core.Node_WorkflowNode{
+				WorkflowNode: &core.WorkflowNode{Reference: &core.WorkflowNode_LaunchplanRef{LaunchplanRef: ref}},
+			},
+		}
+	}
+
+	closure := &core.CompiledWorkflowClosure{
+		Primary: &core.CompiledWorkflow{
+			Template: &core.WorkflowTemplate{
+				Id: &core.Identifier{Name: "wf_1"},
+				Nodes: []*core.Node{
+					{Id: common.StartNodeID},
+					lpNode("cached", lpID),
+					lpNode("not_cached", otherLpID),
+				},
+			},
+			Connections: &core.ConnectionSet{
+				Downstream: map[string]*core.ConnectionSet_IdList{
+					common.StartNodeID: {Ids: []string{"cached", "not_cached"}},
+				},
+			},
+		},
+	}
+
+	wf, err := BuildFlyteWorkflow(closure, nil, nil, "", WithCachedLaunchPlan(lpID, "1.0"))
+	if assert.NoError(t, err) {
+		cached := wf.WorkflowSpec.Nodes["cached"].GetWorkflowNode()
+		assert.True(t, cached.IsDiscoverable())
+		assert.Equal(t, "1.0", cached.GetDiscoveryVersion())
+		assert.False(t, wf.WorkflowSpec.Nodes["not_cached"].GetWorkflowNode().IsDiscoverable())
+	}
+
+	wf, err = BuildFlyteWorkflow(closure, nil, nil, "")
+	if assert.NoError(t, err) {
+		assert.False(t, wf.WorkflowSpec.Nodes["cached"].GetWorkflowNode().IsDiscoverable())
+	}
+}
+
+func TestComputeShardKey(t *testing.T) {
+	assert.Equal(t, ComputeShardKey("exec-1"), ComputeShardKey("exec-1"))
+	for _, label := range []string{"", "exec-1", "project-domain-name"} {
+		key, err := strconv.Atoi(ComputeShardKey(label))
+		assert.NoError(t, err)
+		assert.True(t, key >= 0 && key < v1alpha1.ShardKeyspaceSize)
+	}
+}
+
 func TestBuildFlyteWorkflow_withInputs(t *testing.T) {
 	w := createSampleMockWorkflow()
 
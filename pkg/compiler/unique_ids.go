@@ -0,0 +1,101 @@
+package compiler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/encoding"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	c "github.com/flyteorg/flytepropeller/pkg/compiler/common"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+// The length the unique IDs of nodes are truncated to at execution, see common.GenerateUniqueID in the nodes package.
+const maxUniqueIDLength = 20
+
+// Tracks the unique IDs the nodes of a workflow execute with, by the path of the node they were generated for.
+type uniqueIDIndex struct {
+	paths map[string]string
+	errs  errors.CompileErrors
+}
+
+// Validates that no two nodes of the workflow execute with the same unique ID, across the nested subworkflows and
+// branches and every attempt of their parent nodes. Nodes nested in a subworkflow or a branch execute with an ID
+// generated from the unique ID and attempt of their parent, e.g. "n0-0-n1", which a user node ID may collide with.
+func validateUniqueNodeIDs(primary *core.WorkflowTemplate, subWorkflows c.WorkflowIndex, errs errors.CompileErrors) (ok bool) {
+	index := uniqueIDIndex{paths: map[string]string{}, errs: errs}
+	index.visitNodes(primary.GetNodes(), "", "", nil, subWorkflows, sets.NewString(primary.GetId().String()))
+	return !errs.HasErrors()
+}
+
+func (i uniqueIDIndex) visitNodes(nodes []*core.Node, parentUniqueID, parentAttempt string, parentPath []string,
+	subWorkflows c.WorkflowIndex, visitingWorkflows sets.String) {
+	for _, n := range nodes {
+		i.visitNode(n, parentUniqueID, parentAttempt, parentPath, subWorkflows, visitingWorkflows)
+	}
+}
+
+func (i uniqueIDIndex) visitNode(n *core.Node, parentUniqueID, parentAttempt string, parentPath []string,
+	subWorkflows c.WorkflowIndex, visitingWorkflows sets.String) {
+	if n == nil || n.Id == c.StartNodeID || n.Id == c.EndNodeID {
+		return
+	}
+
+	uniqueID, err := encoding.FixedLengthUniqueIDForParts(maxUniqueIDLength, parentUniqueID, parentAttempt, n.Id)
+	if err != nil {
+		i.errs.Collect(errors.NewWorkflowBuildError(err))
+		return
+	}
+
+	// The path alternates the IDs of the nodes with the attempts of their parents, e.g. "n0/0/n1".
+	path := append([]string{}, parentPath...)
+	if len(parentAttempt) > 0 {
+		path = append(path, parentAttempt)
+	}
+
+	path = append(path, n.Id)
+
+	pathStr := strings.Join(path, "/")
+	if existing, found := i.paths[uniqueID]; found {
+		i.errs.Collect(errors.NewUniqueNodeIDCollisionErr(n.Id, uniqueID, pathStr, existing))
+		return
+	}
+
+	i.paths[uniqueID] = pathStr
+
+	var children []*core.Node
+	var subWorkflowID string
+	if branch := n.GetBranchNode().GetIfElse(); branch != nil {
+		children = append(children, branch.GetCase().GetThenNode())
+		for _, other := range branch.GetOther() {
+			children = append(children, other.GetThenNode())
+		}
+
+		children = append(children, branch.GetElseNode())
+	} else if ref := n.GetWorkflowNode().GetSubWorkflowRef(); ref != nil {
+		subWorkflowID = ref.String()
+		if visitingWorkflows.Has(subWorkflowID) {
+			// Recursive subworkflows are reported by the validation of the requirements.
+			return
+		}
+
+		if wf, found := subWorkflows[subWorkflowID]; found {
+			children = wf.GetTemplate().GetNodes()
+		}
+	}
+
+	if len(children) == 0 {
+		return
+	}
+
+	if len(subWorkflowID) > 0 {
+		visitingWorkflows.Insert(subWorkflowID)
+		defer visitingWorkflows.Delete(subWorkflowID)
+	}
+
+	for attempt := uint32(0); attempt <= n.GetMetadata().GetRetries().GetRetries(); attempt++ {
+		i.visitNodes(children, uniqueID, strconv.Itoa(int(attempt)), path, subWorkflows, visitingWorkflows)
+	}
+}
@@ -0,0 +1,78 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	c "github.com/flyteorg/flytepropeller/pkg/compiler/common"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+func subWorkflowNode(id string, subWorkflowID *core.Identifier, retries uint32) *core.Node {
+	return &core.Node{
+		Id:       id,
+		Metadata: &core.NodeMetadata{Retries: &core.RetryStrategy{Retries: retries}},
+		Target: &core.Node_WorkflowNode{
+			WorkflowNode: &core.WorkflowNode{
+				Reference: &core.WorkflowNode_SubWorkflowRef{SubWorkflowRef: subWorkflowID},
+			},
+		},
+	}
+}
+
+func TestValidateUniqueNodeIDs(t *testing.T) {
+	subID := &core.Identifier{Name: "sub"}
+	subWorkflows := c.WorkflowIndex{
+		subID.String(): {Template: &core.WorkflowTemplate{Id: subID, Nodes: []*core.Node{{Id: "n1"}}}},
+	}
+
+	t.Run("no collision", func(t *testing.T) {
+		primary := &core.WorkflowTemplate{
+			Id:    &core.Identifier{Name: "wf"},
+			Nodes: []*core.Node{subWorkflowNode("n0", subID, 1), {Id: "n1"}, {Id: "n0-2-n1"}},
+		}
+
+		errs := errors.NewCompileErrors()
+		assert.True(t, validateUniqueNodeIDs(primary, subWorkflows, errs))
+	})
+
+	t.Run("collision with a retry", func(t *testing.T) {
+		primary := &core.WorkflowTemplate{
+			Id:    &core.Identifier{Name: "wf"},
+			Nodes: []*core.Node{subWorkflowNode("n0", subID, 1), {Id: "n0-1-n1"}},
+		}
+
+		errs := errors.NewCompileErrors()
+		assert.False(t, validateUniqueNodeIDs(primary, subWorkflows, errs))
+		if assert.Len(t, errs.Errors().List(), 1) {
+			err := errs.Errors().List()[0]
+			assert.Equal(t, errors.UniqueNodeIDCollision, err.Code())
+			assert.Contains(t, err.Error(), "[n0-1-n1]")
+			assert.Contains(t, err.Error(), "[n0/1/n1]")
+		}
+	})
+
+	t.Run("collision in a branch", func(t *testing.T) {
+		branch := &core.Node{
+			Id: "b",
+			Target: &core.Node_BranchNode{
+				BranchNode: &core.BranchNode{
+					IfElse: &core.IfElseBlock{
+						Case:    &core.IfBlock{ThenNode: &core.Node{Id: "t"}},
+						Default: &core.IfElseBlock_ElseNode{ElseNode: &core.Node{Id: "e"}},
+					},
+				},
+			},
+		}
+
+		primary := &core.WorkflowTemplate{
+			Id:    &core.Identifier{Name: "wf"},
+			Nodes: []*core.Node{branch, {Id: "b-0-e"}},
+		}
+
+		errs := errors.NewCompileErrors()
+		assert.False(t, validateUniqueNodeIDs(primary, subWorkflows, errs))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	flyte "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	c "github.com/flyteorg/flytepropeller/pkg/compiler/common"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
 )
@@ -32,11 +33,61 @@ func validateOperand(node c.NodeBuilder, paramName string, operand *flyte.Operan
 	return literalType, !errs.HasErrors()
 }
 
+func isSimpleType(t *flyte.LiteralType, simple flyte.SimpleType) bool {
+	return t.GetSimple() == simple
+}
+
+// Validates comparisons that use one of the operators propeller evaluates on complex literals. These compare operands of
+// different types (e.g. the length of a collection against an integer), so the operand types are checked against the
+// operator instead of against each other.
+func validateComplexComparison(node c.NodeBuilder, expr *flyte.ComparisonExpression, requireParamType bool,
+	errs errors.CompileErrors) {
+	if expr.GetLeftValue().GetPrimitive() != nil {
+		errs.Collect(errors.NewInvalidValueErr(node.GetId(), "LeftValue"))
+		return
+	}
+
+	lType, lValid := validateOperand(node, "LeftValue", expr.GetLeftValue(), requireParamType, errs.NewScope())
+	op := expr.GetOperator()
+	if op == v1alpha1.ComparisonExpressionIsNone || op == v1alpha1.ComparisonExpressionIsNotNone {
+		return
+	}
+
+	rType, rValid := validateOperand(node, "RightValue", expr.GetRightValue(), requireParamType, errs.NewScope())
+	if !lValid || !rValid || lType == nil || rType == nil {
+		return
+	}
+
+	if _, isLength := v1alpha1.GetLengthComparisonOperator(op); isLength {
+		if lType.GetCollectionType() == nil && lType.GetMapValueType() == nil {
+			errs.Collect(errors.NewMismatchingTypesErr(node.GetId(), "LeftValue", lType.String(), "collection or map"))
+		}
+
+		if !isSimpleType(rType, flyte.SimpleType_INTEGER) {
+			errs.Collect(errors.NewMismatchingTypesErr(node.GetId(), "RightValue", rType.String(),
+				flyte.SimpleType_INTEGER.String()))
+		}
+
+		return
+	}
+
+	if lType.GetMapValueType() == nil {
+		errs.Collect(errors.NewMismatchingTypesErr(node.GetId(), "LeftValue", lType.String(), "map"))
+	}
+
+	if !isSimpleType(rType, flyte.SimpleType_STRING) {
+		errs.Collect(errors.NewMismatchingTypesErr(node.GetId(), "RightValue", rType.String(),
+			flyte.SimpleType_STRING.String()))
+	}
+}
+
 func ValidateBooleanExpression(w c.WorkflowBuilder, node c.NodeBuilder, expr *flyte.BooleanExpression, requireParamType bool, errs errors.CompileErrors) (ok bool) {
 	if expr == nil {
 		errs.Collect(errors.NewBranchNodeHasNoCondition(node.GetId()))
 	} else {
-		if expr.GetComparison() != nil {
+		if expr.GetComparison() != nil && v1alpha1.IsComplexComparisonOperator(expr.GetComparison().GetOperator()) {
+			validateComplexComparison(node, expr.GetComparison(), requireParamType, errs.NewScope())
+		} else if expr.GetComparison() != nil {
 			op1Type, op1Valid := validateOperand(node, "RightValue",
 				expr.GetComparison().GetRightValue(), requireParamType, errs.NewScope())
 			op2Type, op2Valid := validateOperand(node, "LeftValue",
@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/common/mocks"
+	compilerErrors "github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+func TestValidateBooleanExpression_ComplexComparison(t *testing.T) {
+	wf := &mocks.WorkflowBuilder{}
+	n := &mocks.NodeBuilder{}
+	n.OnGetId().Return("n1")
+	n.OnGetInputs().Return([]*core.Binding{})
+	n.OnGetInterface().Return(&core.TypedInterface{
+		Inputs: &core.VariableMap{
+			Variables: map[string]*core.Variable{
+				"list": {Type: &core.LiteralType{Type: &core.LiteralType_CollectionType{
+					CollectionType: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}},
+				}}},
+				"dict": {Type: &core.LiteralType{Type: &core.LiteralType_MapValueType{
+					MapValueType: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}},
+				}}},
+				"x": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+			},
+		},
+	})
+
+	varOperand := func(name string) *core.Operand {
+		return &core.Operand{Val: &core.Operand_Var{Var: name}}
+	}
+
+	primitiveOperand := func(p *core.Primitive) *core.Operand {
+		return &core.Operand{Val: &core.Operand_Primitive{Primitive: p}}
+	}
+
+	intOperand := primitiveOperand(&core.Primitive{Value: &core.Primitive_Integer{Integer: 1}})
+	stringOperand := primitiveOperand(&core.Primitive{Value: &core.Primitive_StringValue{StringValue: "k"}})
+
+	tests := []struct {
+		name  string
+		op    core.ComparisonExpression_Operator
+		left  *core.Operand
+		right *core.Operand
+		valid bool
+	}{
+		{"length of collection", v1alpha1.ComparisonExpressionLengthGT, varOperand("list"), intOperand, true},
+		{"length of map", v1alpha1.ComparisonExpressionLengthEQ, varOperand("dict"), varOperand("x"), true},
+		{"length of primitive", v1alpha1.ComparisonExpressionLengthEQ, varOperand("x"), intOperand, false},
+		{"length against string", v1alpha1.ComparisonExpressionLengthEQ, varOperand("list"), stringOperand, false},
+		{"has key", v1alpha1.ComparisonExpressionHasKey, varOperand("dict"), stringOperand, true},
+		{"has key on collection", v1alpha1.ComparisonExpressionNotHasKey, varOperand("list"), stringOperand, false},
+		{"has integer key", v1alpha1.ComparisonExpressionHasKey, varOperand("dict"), intOperand, false},
+		{"is none", v1alpha1.ComparisonExpressionIsNone, varOperand("x"), nil, true},
+		{"is not none", v1alpha1.ComparisonExpressionIsNotNone, varOperand("list"), nil, true},
+		{"is none of unknown variable", v1alpha1.ComparisonExpressionIsNone, varOperand("unknown"), nil, false},
+		{"primitive LHS", v1alpha1.ComparisonExpressionIsNone, intOperand, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := compilerErrors.NewCompileErrors()
+			ok := ValidateBooleanExpression(wf, n, &core.BooleanExpression{
+				Expr: &core.BooleanExpression_Comparison{
+					Comparison: &core.ComparisonExpression{
+						Operator:   test.op,
+						LeftValue:  test.left,
+						RightValue: test.right,
+					},
+				},
+			}, true, errs)
+
+			assert.Equal(t, test.valid, ok)
+			assert.Equal(t, !test.valid, errs.HasErrors())
+		})
+	}
+}
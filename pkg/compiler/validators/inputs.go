@@ -0,0 +1,71 @@
+package validators
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	c "github.com/flyteorg/flytepropeller/pkg/compiler/common"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+// ValidateInputs validates the resolved inputs of a node against the input variables of the interface it executes,
+// with the same casting rules the bindings are compiled with, e.g. a schema is accepted for a structured dataset with a
+// subset of its columns. Every variable of the interface that is missing from the inputs, or whose input is of a type
+// that can't be cast to the variable, is reported. Inputs the interface doesn't declare are ignored.
+func ValidateInputs(nodeID c.NodeID, inputs *core.LiteralMap, expectedInputs *core.VariableMap, errs errors.CompileErrors) (ok bool) {
+	for name, v := range expectedInputs.GetVariables() {
+		l, found := inputs.GetLiterals()[name]
+		if !found {
+			errs.Collect(errors.NewParameterNotBoundErr(nodeID, name))
+			continue
+		}
+
+		if !isLiteralCastable(l, v.GetType()) {
+			errs.Collect(errors.NewMismatchingBindingsErr(nodeID, name, v.GetType().String(), LiteralTypeForLiteral(l).String()))
+		}
+	}
+
+	return !errs.HasErrors()
+}
+
+// Returns whether the literal can be cast to the expected type. The elements of collections and maps are checked one by
+// one against the element type, e.g. [1, None] is accepted for a list of optional integers, although no single type can
+// be guessed for it. The type of other literals is only guessed from their value, so literals whose type is unknown are
+// accepted.
+func isLiteralCastable(l *core.Literal, expectedType *core.LiteralType) bool {
+	switch l.GetValue().(type) {
+	case *core.Literal_Collection:
+		if elementType := expectedType.GetCollectionType(); elementType != nil {
+			return areLiteralsCastable(l.GetCollection().GetLiterals(), elementType)
+		}
+	case *core.Literal_Map:
+		if valueType := expectedType.GetMapValueType(); valueType != nil {
+			values := make([]*core.Literal, 0, len(l.GetMap().GetLiterals()))
+			for _, x := range l.GetMap().GetLiterals() {
+				values = append(values, x)
+			}
+
+			return areLiteralsCastable(values, valueType)
+		}
+	default:
+		literalType := LiteralTypeForLiteral(l)
+		return literalType == nil || AreTypesCastable(literalType, expectedType)
+	}
+
+	// Otherwise, a collection or map is only accepted for a union it can be cast to a variant of.
+	for _, variant := range expectedType.GetUnionType().GetVariants() {
+		if isLiteralCastable(l, variant) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func areLiteralsCastable(literals []*core.Literal, expectedType *core.LiteralType) bool {
+	for _, x := range literals {
+		if !isLiteralCastable(x, expectedType) {
+			return false
+		}
+	}
+
+	return true
+}
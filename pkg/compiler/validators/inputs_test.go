@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+func TestValidateInputs(t *testing.T) {
+	intType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}
+	expectedInputs := &core.VariableMap{
+		Variables: map[string]*core.Variable{
+			"x":    {Type: intType},
+			"list": {Type: &core.LiteralType{Type: &core.LiteralType_CollectionType{CollectionType: intType}}},
+			"sd": {Type: &core.LiteralType{Type: &core.LiteralType_StructuredDatasetType{StructuredDatasetType: &core.StructuredDatasetType{
+				Columns: []*core.StructuredDatasetType_DatasetColumn{{Name: "a", LiteralType: intType}},
+				Format:  "parquet",
+			}}}},
+		},
+	}
+
+	schema := &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_Schema{Schema: &core.Schema{
+		Uri: "s3://bucket/schema",
+		Type: &core.SchemaType{Columns: []*core.SchemaType_SchemaColumn{
+			{Name: "a", Type: core.SchemaType_SchemaColumn_INTEGER},
+			{Name: "b", Type: core.SchemaType_SchemaColumn_STRING},
+		}},
+	}}}}}
+
+	t.Run("matching", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		inputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": 1, "list": []interface{}{}, "other": "a"}).GetMap()
+		inputs.Literals["sd"] = schema
+		assert.True(t, ValidateInputs("n0", inputs, expectedInputs, errs))
+	})
+
+	t.Run("mismatching", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		inputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": "1", "list": []interface{}{"a"}}).GetMap()
+		assert.False(t, ValidateInputs("n0", inputs, expectedInputs, errs))
+		// x and list have different types and sd is missing.
+		assert.Equal(t, 3, errs.ErrorCount())
+	})
+	t.Run("optional elements", func(t *testing.T) {
+		noneType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_NONE}}
+		optionalInt := &core.LiteralType{Type: &core.LiteralType_UnionType{UnionType: &core.UnionType{
+			Variants: []*core.LiteralType{intType, noneType},
+		}}}
+
+		expectedInputs := &core.VariableMap{
+			Variables: map[string]*core.Variable{
+				"list": {Type: &core.LiteralType{Type: &core.LiteralType_CollectionType{CollectionType: optionalInt}}},
+				"map":  {Type: &core.LiteralType{Type: &core.LiteralType_MapValueType{MapValueType: optionalInt}}},
+				"union": {Type: &core.LiteralType{Type: &core.LiteralType_UnionType{UnionType: &core.UnionType{
+					Variants: []*core.LiteralType{
+						{Type: &core.LiteralType_CollectionType{CollectionType: optionalInt}},
+						noneType,
+					},
+				}}}},
+			},
+		}
+
+		none := &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_NoneType{NoneType: &core.Void{}}}}}
+		list := &core.Literal{Value: &core.Literal_Collection{Collection: &core.LiteralCollection{
+			Literals: []*core.Literal{coreutils.MustMakeLiteral(1), none},
+		}}}
+
+		errs := errors.NewCompileErrors()
+		inputs := &core.LiteralMap{Literals: map[string]*core.Literal{
+			"list": list,
+			"map": {Value: &core.Literal_Map{Map: &core.LiteralMap{
+				Literals: map[string]*core.Literal{"a": coreutils.MustMakeLiteral(1), "b": none},
+			}}},
+			"union": list,
+		}}
+		assert.True(t, ValidateInputs("n0", inputs, expectedInputs, errs))
+
+		errs = errors.NewCompileErrors()
+		mismatching := &core.Literal{Value: &core.Literal_Collection{Collection: &core.LiteralCollection{
+			Literals: []*core.Literal{coreutils.MustMakeLiteral(1), none, coreutils.MustMakeLiteral("a")},
+		}}}
+		inputs = &core.LiteralMap{Literals: map[string]*core.Literal{
+			"list": mismatching,
+			"map": {Value: &core.Literal_Map{Map: &core.LiteralMap{
+				Literals: map[string]*core.Literal{"a": none, "b": coreutils.MustMakeLiteral("b")},
+			}}},
+			"union": mismatching,
+		}}
+		assert.False(t, ValidateInputs("n0", inputs, expectedInputs, errs))
+		assert.Equal(t, 3, errs.ErrorCount())
+	})
+}
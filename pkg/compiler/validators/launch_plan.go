@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	c "github.com/flyteorg/flytepropeller/pkg/compiler/common"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+const (
+	missingVariable = "<missing>"
+	unboundVariable = "<not bound>"
+)
+
+// ValidateLaunchPlanInputs validates that a launch plan still accepts the inputs a node binds to it. The interface of
+// the launch plan may have drifted since the workflow of the node was compiled against it, in which case every bound
+// input the launch plan no longer accepts, and every required input of the launch plan that is not bound, is reported
+// with the type the workflow binds and the type the launch plan expects.
+func ValidateLaunchPlanInputs(nodeID c.NodeID, inputs *core.LiteralMap, expectedInputs *core.ParameterMap, errs errors.CompileErrors) (ok bool) {
+	parameters := expectedInputs.GetParameters()
+	for name, l := range inputs.GetLiterals() {
+		inputType := LiteralTypeForLiteral(l)
+		p, found := parameters[name]
+		if !found {
+			errs.Collect(errors.NewLaunchPlanInterfaceDriftErr(nodeID, name, inputType.String(), missingVariable))
+			continue
+		}
+
+		if !AreTypesCastable(inputType, p.GetVar().GetType()) {
+			errs.Collect(errors.NewLaunchPlanInterfaceDriftErr(nodeID, name, inputType.String(), p.GetVar().GetType().String()))
+		}
+	}
+
+	for name, p := range parameters {
+		if _, bound := inputs.GetLiterals()[name]; !bound && p.GetRequired() {
+			errs.Collect(errors.NewLaunchPlanInterfaceDriftErr(nodeID, name, unboundVariable, p.GetVar().GetType().String()))
+		}
+	}
+
+	return !errs.HasErrors()
+}
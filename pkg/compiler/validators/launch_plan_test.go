@@ -0,0 +1,38 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+)
+
+func TestValidateLaunchPlanInputs(t *testing.T) {
+	intType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}
+	expectedInputs := &core.ParameterMap{
+		Parameters: map[string]*core.Parameter{
+			"x": {Var: &core.Variable{Type: intType}, Behavior: &core.Parameter_Required{Required: true}},
+			"y": {Var: &core.Variable{Type: intType}, Behavior: &core.Parameter_Default{Default: coreutils.MustMakeLiteral(1)}},
+		},
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		inputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap()
+		assert.True(t, ValidateLaunchPlanInputs("n0", inputs, expectedInputs, errs))
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		errs := errors.NewCompileErrors()
+		inputs := coreutils.MustMakeLiteral(map[string]interface{}{"y": "1", "z": 1}).GetMap()
+		assert.False(t, ValidateLaunchPlanInputs("n0", inputs, expectedInputs, errs))
+		// x is not bound, y has a different type and z is no longer accepted.
+		assert.Equal(t, 3, errs.ErrorCount())
+		for _, err := range errs.Errors().List() {
+			assert.Equal(t, errors.LaunchPlanInterfaceDrift, err.Code())
+		}
+	})
+}
@@ -338,6 +338,10 @@ func CompileWorkflow(primaryWf *core.WorkflowTemplate, subworkflows []*core.Work
 
 	validatedWf, ok := gb.ValidateWorkflow(compiledWf, errs.NewScope())
 	if ok {
+		if !validateUniqueNodeIDs(validatedWf.GetCoreWorkflow().Template, wfIndex, errs.NewScope()) {
+			return nil, errs
+		}
+
 		compiledTasks := make([]*core.CompiledTask, 0, len(taskBuilders))
 		for _, t := range taskBuilders {
 			compiledTasks = append(compiledTasks, &core.CompiledTask{Template: t.GetCoreTask()})
@@ -0,0 +1,211 @@
+// Package archive stores terminated workflows in blob storage before they are deleted, and maintains an index of the
+// metadata of the archived executions, so that historical executions can be analyzed without Admin's database.
+//
+// Workflows are stored as JSON under <prefix>/workflows/<namespace>/<name>-<uid>.json. The index is partitioned by
+// namespace and the day the workflows stopped, every partition is a file of JSON lines under
+// <prefix>/index/<namespace>/<yyyy-mm-dd>.jsonl.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+const dayLayout = "2006-01-02"
+
+// Record is the metadata of an archived execution, as stored in the index.
+type Record struct {
+	Namespace       string                `json:"namespace"`
+	Name            string                `json:"name"`
+	Project         string                `json:"project,omitempty"`
+	Domain          string                `json:"domain,omitempty"`
+	ExecutionName   string                `json:"executionName,omitempty"`
+	Phase           string                `json:"phase"`
+	StartedAt       *time.Time            `json:"startedAt,omitempty"`
+	StoppedAt       time.Time             `json:"stoppedAt"`
+	DurationSeconds float64               `json:"durationSeconds"`
+	FailureCode     string                `json:"failureCode,omitempty"`
+	WorkflowRef     storage.DataReference `json:"workflowRef"`
+}
+
+// NewRecord returns the index record of the terminated workflow, archived at the given reference.
+func NewRecord(w *v1alpha1.FlyteWorkflow, workflowRef storage.DataReference) Record {
+	r := Record{
+		Namespace:   w.GetNamespace(),
+		Name:        w.GetName(),
+		Phase:       w.Status.Phase.String(),
+		StoppedAt:   w.GetCreationTimestamp().Time,
+		WorkflowRef: workflowRef,
+	}
+
+	if execID := w.GetExecutionID(); execID.WorkflowExecutionIdentifier != nil {
+		r.Project = execID.Project
+		r.Domain = execID.Domain
+		r.ExecutionName = execID.Name
+	}
+
+	if w.Status.LastUpdatedAt != nil {
+		r.StoppedAt = w.Status.LastUpdatedAt.Time
+	}
+
+	if w.Status.StoppedAt != nil {
+		r.StoppedAt = w.Status.StoppedAt.Time
+	}
+
+	if w.Status.StartedAt != nil {
+		startedAt := w.Status.StartedAt.Time
+		r.StartedAt = &startedAt
+		r.DurationSeconds = r.StoppedAt.Sub(startedAt).Seconds()
+	}
+
+	if w.Status.Error != nil && w.Status.Error.ExecutionError != nil {
+		r.FailureCode = w.Status.Error.Code
+	}
+
+	return r
+}
+
+// IndexReference returns the reference of the partition of the index that holds the records of the workflows of the
+// namespace that stopped on the given day.
+func IndexReference(ctx context.Context, store *storage.DataStore, prefix storage.DataReference, namespace string,
+	day time.Time) (storage.DataReference, error) {
+	return store.ConstructReference(ctx, prefix, "index", namespace, day.UTC().Format(dayLayout)+".jsonl")
+}
+
+// ReadIndex reads the records of a partition of the index. A partition that doesn't exist holds no records.
+func ReadIndex(ctx context.Context, store *storage.DataStore, ref storage.DataReference) ([]Record, error) {
+	metadata, err := store.Head(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metadata.Exists() {
+		return nil, nil
+	}
+
+	rc, err := store.ReadRaw(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rc.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close reader for [%v]. Error: %v", ref, err)
+		}
+	}()
+
+	var records []Record
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		r := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("failed to decode record of index [%v]: %w", ref, err)
+		}
+
+		records = append(records, r)
+	}
+
+	return records, scanner.Err()
+}
+
+func writeIndex(ctx context.Context, store *storage.DataStore, ref storage.DataReference, records []Record) error {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+
+	return store.WriteRaw(ctx, ref, int64(buf.Len()), storage.Options{}, buf)
+}
+
+// Archiver archives terminated workflows. Writing a partition of the index rewrites it, so the records of archived
+// workflows are buffered and appended to their partitions by Flush. Every partition is written by the propeller that
+// handles the namespace only.
+type Archiver struct {
+	store   *storage.DataStore
+	prefix  storage.DataReference
+	lock    sync.Mutex
+	pending map[storage.DataReference][]Record
+}
+
+// Archive stores the terminated workflow and buffers its record to be added to the index by the next Flush.
+func (a *Archiver) Archive(ctx context.Context, w *v1alpha1.FlyteWorkflow) error {
+	raw, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	workflowRef, err := a.store.ConstructReference(ctx, a.prefix, "workflows", w.GetNamespace(),
+		fmt.Sprintf("%s-%s.json", w.GetName(), w.GetUID()))
+	if err != nil {
+		return err
+	}
+
+	if err := a.store.WriteRaw(ctx, workflowRef, int64(len(raw)), storage.Options{}, bytes.NewReader(raw)); err != nil {
+		return err
+	}
+
+	r := NewRecord(w, workflowRef)
+	indexRef, err := IndexReference(ctx, a.store, a.prefix, r.Namespace, r.StoppedAt)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.pending[indexRef] = append(a.pending[indexRef], r)
+	return nil
+}
+
+// Flush appends the buffered records to their partitions of the index. Records that could not be written are kept
+// buffered for the next Flush.
+func (a *Archiver) Flush(ctx context.Context) error {
+	a.lock.Lock()
+	pending := a.pending
+	a.pending = map[storage.DataReference][]Record{}
+	a.lock.Unlock()
+
+	var lastErr error
+	for ref, records := range pending {
+		existing, err := ReadIndex(ctx, a.store, ref)
+		if err == nil {
+			err = writeIndex(ctx, a.store, ref, append(existing, records...))
+		}
+
+		if err != nil {
+			logger.Errorf(ctx, "Failed to add [%d] records to the archive index [%v]. Error: %v", len(records), ref, err)
+			lastErr = err
+			a.lock.Lock()
+			a.pending[ref] = append(records, a.pending[ref]...)
+			a.lock.Unlock()
+		}
+	}
+
+	return lastErr
+}
+
+// NewArchiver creates an Archiver that archives workflows under the given prefix of the store.
+func NewArchiver(store *storage.DataStore, prefix string) *Archiver {
+	return &Archiver{
+		store:   store,
+		prefix:  storage.DataReference(prefix),
+		pending: map[storage.DataReference][]Record{},
+	}
+}
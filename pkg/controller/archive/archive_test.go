@@ -0,0 +1,90 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func newWorkflow(name string, phase v1alpha1.WorkflowPhase, startedAt, stoppedAt time.Time) *v1alpha1.FlyteWorkflow {
+	started := v1.NewTime(startedAt)
+	stopped := v1.NewTime(stoppedAt)
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "ns", UID: "uid"},
+		ExecutionID: v1alpha1.ExecutionID{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: name},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:     phase,
+			StartedAt: &started,
+			StoppedAt: &stopped,
+		},
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	stoppedAt := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	w := newWorkflow("w", v1alpha1.WorkflowPhaseFailed, stoppedAt.Add(-time.Minute), stoppedAt)
+	w.Status.Error = &v1alpha1.ExecutionError{ExecutionError: &core.ExecutionError{Code: "OOMKilled"}}
+
+	r := NewRecord(w, "s3://bucket/w.json")
+	assert.Equal(t, "ns", r.Namespace)
+	assert.Equal(t, "w", r.Name)
+	assert.Equal(t, "p", r.Project)
+	assert.Equal(t, "d", r.Domain)
+	assert.Equal(t, "w", r.ExecutionName)
+	assert.Equal(t, "Failed", r.Phase)
+	assert.Equal(t, stoppedAt, r.StoppedAt)
+	assert.Equal(t, float64(60), r.DurationSeconds)
+	assert.Equal(t, "OOMKilled", r.FailureCode)
+	assert.Equal(t, storage.DataReference("s3://bucket/w.json"), r.WorkflowRef)
+}
+
+func TestArchiver(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	a := NewArchiver(store, "s3://bucket/archive")
+	day := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	ref, err := IndexReference(ctx, store, "s3://bucket/archive", "ns", day)
+	assert.NoError(t, err)
+	records, err := ReadIndex(ctx, store, ref)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	assert.NoError(t, a.Archive(ctx, newWorkflow("a", v1alpha1.WorkflowPhaseSuccess, day.Add(-time.Hour), day)))
+	assert.NoError(t, a.Flush(ctx))
+	assert.NoError(t, a.Archive(ctx, newWorkflow("b", v1alpha1.WorkflowPhaseAborted, day.Add(-time.Hour), day)))
+	assert.NoError(t, a.Archive(ctx, newWorkflow("c", v1alpha1.WorkflowPhaseSuccess, day, day.Add(24*time.Hour))))
+	assert.NoError(t, a.Flush(ctx))
+
+	records, err = ReadIndex(ctx, store, ref)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "a", records[0].Name)
+		assert.Equal(t, "b", records[1].Name)
+	}
+
+	archived := &v1alpha1.FlyteWorkflow{}
+	raw, err := store.ReadRaw(ctx, records[0].WorkflowRef)
+	assert.NoError(t, err)
+	assert.NoError(t, json.NewDecoder(raw).Decode(archived))
+	assert.Equal(t, "a", archived.GetName())
+	assert.Equal(t, v1alpha1.WorkflowPhaseSuccess, archived.Status.Phase)
+
+	ref, err = IndexReference(ctx, store, "s3://bucket/archive", "ns", day.Add(24*time.Hour))
+	assert.NoError(t, err)
+	records, err = ReadIndex(ctx, store, ref)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"golang.org/x/time/rate"
+	corev1Types "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	abortSelectorAnnotation = "flyte.org/abort-selector"
+	abortReportAnnotation   = "flyte.org/abort-report"
+	// The number of failures that are listed in an abort report.
+	maxAbortReportErrors = 10
+)
+
+// AbortReport summarizes the processing of an abort selector. It is written to the namespace as JSON, once all matching
+// executions have been aborted.
+type AbortReport struct {
+	Selector    string    `json:"selector"`
+	Matched     int       `json:"matched"`
+	Aborted     int       `json:"aborted"`
+	Failed      int       `json:"failed"`
+	Errors      []string  `json:"errors,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+type batchAbortMetrics struct {
+	requestsProcessed labeled.Counter
+	workflowsAborted  labeled.Counter
+	abortFailures     labeled.Counter
+}
+
+// BatchAborter is a background service that aborts all executions of a namespace matching the label selector in the
+// abort selector annotation of the namespace, for example to stop all executions of a project during an incident.
+// Executions are aborted by deleting their FlyteWorkflow, at a limited rate to not overwhelm the KubeAPI.
+type BatchAborter struct {
+	wfClient        v1alpha1.FlyteworkflowV1alpha1Interface
+	namespaceClient corev1.NamespaceInterface
+	enabled         bool
+	interval        time.Duration
+	limiter         *rate.Limiter
+	clk             clock.Clock
+	metrics         *batchAbortMetrics
+	namespace       string
+}
+
+// Combines the abort selector with a requirement that ignores completed workflows, which have nothing left to abort.
+func abortSelector(selector string) (labels.Selector, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty selector is more likely a mistake than an intent to abort all executions, which is still possible with
+	// a selector like "execution-id".
+	if s.Empty() {
+		return nil, fmt.Errorf("selector is empty")
+	}
+
+	r, err := labels.NewRequirement(workflowTerminationStatusKey, selection.NotIn, []string{workflowTerminatedValue})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Add(*r), nil
+}
+
+// Aborts the executions of the namespace matching the selector and reports the outcome.
+func (b *BatchAborter) abortWorkflows(ctx context.Context, namespace, selector string) *AbortReport {
+	report := &AbortReport{Selector: selector, StartedAt: b.clk.Now()}
+	addError := func(err error) {
+		if len(report.Errors) < maxAbortReportErrors {
+			report.Errors = append(report.Errors, err.Error())
+		}
+	}
+
+	defer func() {
+		report.CompletedAt = b.clk.Now()
+	}()
+
+	s, err := abortSelector(selector)
+	if err != nil {
+		addError(fmt.Errorf("invalid selector: %w", err))
+		return report
+	}
+
+	workflows, err := b.wfClient.FlyteWorkflows(namespace).List(ctx, v1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		addError(fmt.Errorf("failed to list executions: %w", err))
+		return report
+	}
+
+	for _, w := range workflows.Items {
+		// Workflows that are already being deleted are aborting already.
+		if w.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		report.Matched++
+		if err := b.limiter.Wait(ctx); err != nil {
+			report.Failed++
+			addError(err)
+			continue
+		}
+
+		err := b.wfClient.FlyteWorkflows(namespace).Delete(ctx, w.GetName(), v1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			b.metrics.abortFailures.Inc(ctx)
+			report.Failed++
+			addError(fmt.Errorf("failed to abort execution [%s]: %w", w.GetName(), err))
+			continue
+		}
+
+		b.metrics.workflowsAborted.Inc(ctx)
+		report.Aborted++
+	}
+
+	return report
+}
+
+// Processes the abort selector annotation of the namespace, if any, and replaces it with the report.
+func (b *BatchAborter) processNamespace(ctx context.Context, namespace *corev1Types.Namespace) error {
+	selector, ok := namespace.GetAnnotations()[abortSelectorAnnotation]
+	if !ok {
+		return nil
+	}
+
+	ctx = contextutils.WithNamespace(ctx, namespace.GetName())
+	logger.Infof(ctx, "Aborting executions of namespace [%s] matching selector [%s]", namespace.GetName(), selector)
+	report := b.abortWorkflows(ctx, namespace.GetName(), selector)
+	logger.Infof(ctx, "Aborted [%d/%d] executions of namespace [%s] matching selector [%s], [%d] failed",
+		report.Aborted, report.Matched, namespace.GetName(), selector, report.Failed)
+	b.metrics.requestsProcessed.Inc(ctx)
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	// If the update conflicts, the selector is processed again in the next round. Aborting is idempotent.
+	updated := namespace.DeepCopy()
+	delete(updated.Annotations, abortSelectorAnnotation)
+	updated.Annotations[abortReportAnnotation] = string(raw)
+	_, err = b.namespaceClient.Update(ctx, updated, v1.UpdateOptions{})
+	return err
+}
+
+func (b *BatchAborter) processNamespaces(ctx context.Context) error {
+	var namespaces []corev1Types.Namespace
+	if b.namespace == "" || strings.ToLower(b.namespace) == "all" || strings.ToLower(b.namespace) == "all-namespaces" {
+		namespaceList, err := b.namespaceClient.List(ctx, v1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		namespaces = namespaceList.Items
+	} else {
+		namespace, err := b.namespaceClient.Get(ctx, b.namespace, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		namespaces = []corev1Types.Namespace{*namespace}
+	}
+
+	for i := range namespaces {
+		if err := b.processNamespace(ctx, &namespaces[i]); err != nil {
+			logger.Errorf(ctx, "Failed to process abort selector of namespace [%s]. Error: %v", namespaces[i].GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (b *BatchAborter) run(ctx context.Context, ticker clock.Ticker) {
+	logger.Infof(ctx, "Background batch abort started, with interval [%s]", b.interval.String())
+
+	ctx = contextutils.WithGoroutineLabel(ctx, "batch-abort-worker")
+	pprof.SetGoroutineLabels(ctx)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			if err := b.processNamespaces(ctx); err != nil {
+				logger.Errorf(ctx, "Failed to process abort selectors in this round. Error: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Infof(ctx, "Batch abort stopping")
+			return
+		}
+	}
+}
+
+// Use this method to start the background batch abort routine. Use the context to signal an exit signal
+func (b *BatchAborter) Start(ctx context.Context) error {
+	if !b.enabled {
+		logger.Infof(ctx, "Batch abort is disabled")
+		return nil
+	}
+
+	go b.run(ctx, b.clk.NewTicker(b.interval))
+	return nil
+}
+
+func NewBatchAborter(cfg *config.Config, scope promutils.Scope, clk clock.Clock, namespaceClient corev1.NamespaceInterface, wfClient v1alpha1.FlyteworkflowV1alpha1Interface) *BatchAborter {
+	r := cfg.BatchAbort.Rate
+	if r <= 0 {
+		r = 1
+	}
+
+	return &BatchAborter{
+		wfClient:        wfClient,
+		namespaceClient: namespaceClient,
+		enabled:         cfg.BatchAbort.Enabled,
+		interval:        cfg.BatchAbort.Interval.Duration,
+		limiter:         rate.NewLimiter(rate.Limit(r), int(r)),
+		clk:             clk,
+		metrics: &batchAbortMetrics{
+			requestsProcessed: labeled.NewCounter("batch_abort_requests", "Abort selectors processed", scope),
+			workflowsAborted:  labeled.NewCounter("batch_abort_workflows", "Workflows aborted because they matched an abort selector", scope),
+			abortFailures:     labeled.NewCounter("batch_abort_failures", "Failures to abort a workflow that matched an abort selector", scope),
+		},
+		namespace: cfg.LimitNamespace,
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	corev1Types "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func newAbortTestWorkflow(name string, labels map[string]string) runtime.Object {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+	}
+}
+
+func TestAbortSelector(t *testing.T) {
+	s, err := abortSelector("project=p")
+	assert.NoError(t, err)
+	assert.Equal(t, "project=p,termination-status notin (terminated)", s.String())
+
+	_, err = abortSelector("")
+	assert.Error(t, err)
+
+	_, err = abortSelector("project=(")
+	assert.Error(t, err)
+}
+
+func TestBatchAborter_processNamespaces(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+
+	setup := func(annotations map[string]string) (*BatchAborter, *fake.Clientset, *kubeFake.Clientset) {
+		wfClient := fake.NewSimpleClientset(
+			newAbortTestWorkflow("a", map[string]string{"project": "p"}),
+			newAbortTestWorkflow("b", map[string]string{"project": "p"}),
+			newAbortTestWorkflow("c", map[string]string{"project": "p", workflowTerminationStatusKey: workflowTerminatedValue}),
+			newAbortTestWorkflow("d", map[string]string{"project": "q"}),
+		)
+		kubeClient := kubeFake.NewSimpleClientset(&corev1Types.Namespace{
+			ObjectMeta: v1.ObjectMeta{Name: "ns", Annotations: annotations},
+		})
+		cfg := &config2.Config{
+			LimitNamespace: "all",
+			BatchAbort:     config2.BatchAbortConfig{Enabled: true, Rate: 100},
+		}
+		b := NewBatchAborter(cfg, promutils.NewTestScope(), clock.NewFakeClock(now), kubeClient.CoreV1().Namespaces(),
+			wfClient.FlyteworkflowV1alpha1())
+		return b, wfClient, kubeClient
+	}
+
+	report := func(t *testing.T, kubeClient *kubeFake.Clientset) *AbortReport {
+		ns, err := kubeClient.CoreV1().Namespaces().Get(ctx, "ns", v1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NotContains(t, ns.Annotations, abortSelectorAnnotation)
+		r := &AbortReport{}
+		assert.NoError(t, json.Unmarshal([]byte(ns.Annotations[abortReportAnnotation]), r))
+		return r
+	}
+
+	remaining := func(t *testing.T, wfClient *fake.Clientset) []string {
+		l, err := wfClient.FlyteworkflowV1alpha1().FlyteWorkflows("ns").List(ctx, v1.ListOptions{})
+		assert.NoError(t, err)
+		var names []string
+		for _, w := range l.Items {
+			names = append(names, w.Name)
+		}
+		return names
+	}
+
+	t.Run("no annotation", func(t *testing.T) {
+		b, wfClient, _ := setup(nil)
+		assert.NoError(t, b.processNamespaces(ctx))
+		assert.Len(t, remaining(t, wfClient), 4)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		b, wfClient, kubeClient := setup(map[string]string{abortSelectorAnnotation: "project=p"})
+		assert.NoError(t, b.processNamespaces(ctx))
+		assert.ElementsMatch(t, []string{"c", "d"}, remaining(t, wfClient))
+		r := report(t, kubeClient)
+		assert.Equal(t, "project=p", r.Selector)
+		assert.Equal(t, 2, r.Matched)
+		assert.Equal(t, 2, r.Aborted)
+		assert.Equal(t, 0, r.Failed)
+	})
+
+	t.Run("failures are reported", func(t *testing.T) {
+		b, wfClient, kubeClient := setup(map[string]string{abortSelectorAnnotation: "project=p"})
+		wfClient.PrependReactor("delete", "flyteworkflows", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			if action.(k8sTesting.DeleteAction).GetName() == "a" {
+				return true, nil, fmt.Errorf("delete failed")
+			}
+			return false, nil, nil
+		})
+		assert.NoError(t, b.processNamespaces(ctx))
+		r := report(t, kubeClient)
+		assert.Equal(t, 2, r.Matched)
+		assert.Equal(t, 1, r.Aborted)
+		assert.Equal(t, 1, r.Failed)
+		assert.Len(t, r.Errors, 1)
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		b, wfClient, kubeClient := setup(map[string]string{abortSelectorAnnotation: ""})
+		assert.NoError(t, b.processNamespaces(ctx))
+		assert.Len(t, remaining(t, wfClient), 4)
+		r := report(t, kubeClient)
+		assert.Equal(t, 0, r.Matched)
+		assert.Len(t, r.Errors, 1)
+	})
+
+	t.Run("limited namespace", func(t *testing.T) {
+		b, wfClient, kubeClient := setup(map[string]string{abortSelectorAnnotation: "project=q"})
+		b.namespace = "ns"
+		assert.NoError(t, b.processNamespaces(ctx))
+		assert.Len(t, remaining(t, wfClient), 3)
+		assert.Equal(t, 1, report(t, kubeClient).Aborted)
+	})
+}
+
+func TestBatchAborter_Start(t *testing.T) {
+	b := NewBatchAborter(&config2.Config{}, promutils.NewTestScope(), nil, nil, nil)
+	// Disabled, the clock is not used.
+	assert.NoError(t, b.Start(context.TODO()))
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/events"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type circuitBreakerMetrics struct {
+	Tripped       labeled.Counter
+	Held          labeled.Counter
+	AlertFailures labeled.Counter
+}
+
+// The recent failures of the workflows of a launch plan in a namespace, and until when the launch plan is paused.
+type launchPlanFailures struct {
+	failedAt    []time.Time
+	pausedUntil time.Time
+}
+
+// circuitBreaker pauses the launch plans of a namespace whose workflows failed repeatedly within a short window, e.g.
+// because they reference a bad image. New workflows of a paused launch plan are not admitted until the pause ends, and
+// an alert event is emitted when a launch plan is paused. The failures are only tracked in memory, so every propeller
+// pauses launch plans based on the workflows it evaluated itself.
+type circuitBreaker struct {
+	cfg       config.CircuitBreakerConfig
+	sink      events.EventSink
+	clusterID string
+	clk       clock.Clock
+	metrics   *circuitBreakerMetrics
+
+	lock sync.Mutex
+	// Keyed by the namespace and the name of the launch plan, see launchPlanKey.
+	launchPlans map[string]*launchPlanFailures
+}
+
+// Returns the key of the launch plan of the workflow, or false if the workflow was not launched from a launch plan.
+func launchPlanKey(w *v1alpha1.FlyteWorkflow) (string, bool) {
+	launchPlan := w.GetLabels()[k8s.LaunchPlanNameLabel]
+	if len(launchPlan) == 0 {
+		return "", false
+	}
+
+	return w.GetNamespace() + "/" + launchPlan, true
+}
+
+// Admit decides whether the given workflow, which has not started yet, is allowed to start. If its launch plan is
+// paused, the returned message says until when.
+func (b *circuitBreaker) Admit(ctx context.Context, w *v1alpha1.FlyteWorkflow) (bool, string) {
+	if b == nil {
+		return true, ""
+	}
+
+	key, ok := launchPlanKey(w)
+	if !ok {
+		return true, ""
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	lp, ok := b.launchPlans[key]
+	if !ok {
+		return true, ""
+	}
+
+	now := b.clk.Now()
+	if now.Before(lp.pausedUntil) {
+		b.metrics.Held.Inc(ctx)
+		return false, fmt.Sprintf("Workflow queued, launch plan [%s] is paused until [%s] because its workflows failed repeatedly",
+			w.GetLabels()[k8s.LaunchPlanNameLabel], lp.pausedUntil.UTC().Format(time.RFC3339))
+	}
+
+	lp.failedAt = b.recentFailures(lp.failedAt, now)
+	if len(lp.failedAt) == 0 {
+		delete(b.launchPlans, key)
+	}
+
+	return true, ""
+}
+
+// Drops the failures that happened before the window.
+func (b *circuitBreaker) recentFailures(failedAt []time.Time, now time.Time) []time.Time {
+	windowStart := now.Add(-b.cfg.Window.Duration)
+	i := 0
+	for i < len(failedAt) && failedAt[i].Before(windowStart) {
+		i++
+	}
+
+	return failedAt[i:]
+}
+
+// Record counts the workflow, that terminated in this round, towards the failures of its launch plan. A succeeded
+// workflow clears the failures of its launch plan, a failed one pauses the launch plan once the failure threshold is
+// reached within the window.
+func (b *circuitBreaker) Record(ctx context.Context, w *v1alpha1.FlyteWorkflow) {
+	if b == nil {
+		return
+	}
+
+	key, ok := launchPlanKey(w)
+	if !ok {
+		return
+	}
+
+	pause, paused := b.record(ctx, key, w)
+	if !paused {
+		return
+	}
+
+	logger.Warnf(ctx, "Launch plan [%s] paused until [%v] after [%d] of its workflows failed within [%v]",
+		key, pause.PausedUntil, pause.Failures, pause.Window)
+	if err := b.sink.Sink(ctx, events.NewLaunchPlanPausedEvent(pause)); err != nil {
+		logger.Errorf(ctx, "Failed to emit the alert for paused launch plan [%s]. Error: %v", key, err)
+		b.metrics.AlertFailures.Inc(ctx)
+	}
+}
+
+// Records the terminated workflow and returns the pause, if the workflow paused its launch plan.
+func (b *circuitBreaker) record(ctx context.Context, key string, w *v1alpha1.FlyteWorkflow) (events.LaunchPlanPause, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	lp, ok := b.launchPlans[key]
+	switch w.GetExecutionStatus().GetPhase() {
+	case v1alpha1.WorkflowPhaseSuccess:
+		if ok {
+			lp.failedAt = nil
+		}
+		return events.LaunchPlanPause{}, false
+	case v1alpha1.WorkflowPhaseFailed:
+	default:
+		return events.LaunchPlanPause{}, false
+	}
+
+	if !ok {
+		lp = &launchPlanFailures{}
+		b.launchPlans[key] = lp
+	}
+
+	now := b.clk.Now()
+	lp.failedAt = append(b.recentFailures(lp.failedAt, now), now)
+	// Workflows that started before the launch plan was paused may still fail while it's paused, they do not extend
+	// the pause.
+	if len(lp.failedAt) < b.cfg.FailureThreshold || now.Before(lp.pausedUntil) {
+		return events.LaunchPlanPause{}, false
+	}
+
+	b.metrics.Tripped.Inc(ctx)
+	pause := events.LaunchPlanPause{
+		ExecutionID: w.GetExecutionID().WorkflowExecutionIdentifier,
+		ProducerID:  b.clusterID,
+		OccurredAt:  now,
+		Namespace:   w.GetNamespace(),
+		LaunchPlan:  w.GetLabels()[k8s.LaunchPlanNameLabel],
+		Failures:    len(lp.failedAt),
+		Window:      b.cfg.Window.Duration,
+		PausedUntil: now.Add(b.cfg.PauseDuration.Duration),
+	}
+
+	lp.pausedUntil = pause.PausedUntil
+	// The launch plan starts over once the pause ends.
+	lp.failedAt = nil
+	return pause, true
+}
+
+// Creates the circuitBreaker, or returns nil if pausing launch plans is disabled.
+func newCircuitBreaker(cfg config.CircuitBreakerConfig, clusterID string, sink events.EventSink, clk clock.Clock,
+	scope promutils.Scope) *circuitBreaker {
+	if !cfg.Enabled || cfg.FailureThreshold <= 0 {
+		return nil
+	}
+
+	return &circuitBreaker{
+		cfg:       cfg,
+		sink:      sink,
+		clusterID: clusterID,
+		clk:       clk,
+		metrics: &circuitBreakerMetrics{
+			Tripped:       labeled.NewCounter("tripped", "Number of times a launch plan was paused because its workflows failed repeatedly", scope),
+			Held:          labeled.NewCounter("held", "Number of times a workflow was held back because its launch plan is paused", scope),
+			AlertFailures: labeled.NewCounter("alert_failures", "Alerts for paused launch plans that failed to be emitted", scope),
+		},
+		launchPlans: map[string]*launchPlanFailures{},
+	}
+}
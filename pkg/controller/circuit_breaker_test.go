@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/events"
+	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func newLaunchPlanWorkflow(namespace, launchPlan, name string, phase v1alpha1.WorkflowPhase) *v1alpha1.FlyteWorkflow {
+	w := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{}},
+		ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: name},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: phase},
+	}
+
+	if len(launchPlan) > 0 {
+		w.Labels[k8s.LaunchPlanNameLabel] = launchPlan
+	}
+
+	return w
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	cfg := config.CircuitBreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 3,
+		Window:           stdConfig.Duration{Duration: 10 * time.Minute},
+		PauseDuration:    stdConfig.Duration{Duration: 30 * time.Minute},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		b := newCircuitBreaker(config.CircuitBreakerConfig{}, "c1", &eventMocks.EventSink{}, clock.NewFakeClock(now),
+			promutils.NewTestScope())
+		assert.Nil(t, b)
+		b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseFailed))
+		admitted, _ := b.Admit(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseReady))
+		assert.True(t, admitted)
+	})
+
+	t.Run("pauses after repeated failures", func(t *testing.T) {
+		fakeClock := clock.NewFakeClock(now)
+		sink := &eventMocks.EventSink{}
+		var sunk []*events.LaunchPlanPausedEvent
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			sunk = append(sunk, args.Get(1).(*events.LaunchPlanPausedEvent))
+		}).Return(nil)
+
+		b := newCircuitBreaker(cfg, "c1", sink, fakeClock, promutils.NewTestScope())
+		for i := 0; i < 3; i++ {
+			admitted, _ := b.Admit(ctx, newLaunchPlanWorkflow("ns", "lp", "new", v1alpha1.WorkflowPhaseReady))
+			assert.True(t, admitted)
+			b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", fmt.Sprintf("wf%d", i), v1alpha1.WorkflowPhaseFailed))
+			fakeClock.Step(time.Minute)
+		}
+
+		assert.Len(t, sunk, 1)
+		assert.Equal(t, "wf2", sunk[0].GetExecutionId().GetName())
+		assert.Equal(t, "lp", sunk[0].GetFields()["launchPlan"].GetStringValue())
+		assert.Equal(t, float64(3), sunk[0].GetFields()["failures"].GetNumberValue())
+		assert.Equal(t, "2021-06-01T15:36:05Z", sunk[0].GetFields()["pausedUntil"].GetStringValue())
+
+		admitted, msg := b.Admit(ctx, newLaunchPlanWorkflow("ns", "lp", "new", v1alpha1.WorkflowPhaseReady))
+		assert.False(t, admitted)
+		assert.Contains(t, msg, "launch plan [lp] is paused until [2021-06-01T15:36:05Z]")
+
+		// Other launch plans and namespaces are not paused
+		admitted, _ = b.Admit(ctx, newLaunchPlanWorkflow("ns", "other", "new", v1alpha1.WorkflowPhaseReady))
+		assert.True(t, admitted)
+		admitted, _ = b.Admit(ctx, newLaunchPlanWorkflow("other", "lp", "new", v1alpha1.WorkflowPhaseReady))
+		assert.True(t, admitted)
+
+		// Failures of workflows that started before the pause do not extend it
+		for i := 0; i < 3; i++ {
+			b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "running", v1alpha1.WorkflowPhaseFailed))
+		}
+		assert.Len(t, sunk, 1)
+
+		fakeClock.Step(30 * time.Minute)
+		admitted, _ = b.Admit(ctx, newLaunchPlanWorkflow("ns", "lp", "new", v1alpha1.WorkflowPhaseReady))
+		assert.True(t, admitted)
+	})
+
+	t.Run("failures outside the window", func(t *testing.T) {
+		fakeClock := clock.NewFakeClock(now)
+		b := newCircuitBreaker(cfg, "c1", &eventMocks.EventSink{}, fakeClock, promutils.NewTestScope())
+		for i := 0; i < 3; i++ {
+			b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseFailed))
+			fakeClock.Step(6 * time.Minute)
+		}
+
+		admitted, _ := b.Admit(ctx, newLaunchPlanWorkflow("ns", "lp", "new", v1alpha1.WorkflowPhaseReady))
+		assert.True(t, admitted)
+	})
+
+	t.Run("success clears failures", func(t *testing.T) {
+		b := newCircuitBreaker(cfg, "c1", &eventMocks.EventSink{}, clock.NewFakeClock(now), promutils.NewTestScope())
+		b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseFailed))
+		b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseFailed))
+		b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseSuccess))
+		b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseFailed))
+		b.Record(ctx, newLaunchPlanWorkflow("ns", "lp", "wf", v1alpha1.WorkflowPhaseAborted))
+
+		admitted, _ := b.Admit(ctx, newLaunchPlanWorkflow("ns", "lp", "new", v1alpha1.WorkflowPhaseReady))
+		assert.True(t, admitted)
+	})
+
+	t.Run("workflows without launch plan", func(t *testing.T) {
+		b := newCircuitBreaker(cfg, "c1", &eventMocks.EventSink{}, clock.NewFakeClock(now), promutils.NewTestScope())
+		for i := 0; i < 3; i++ {
+			b.Record(ctx, newLaunchPlanWorkflow("ns", "", "wf", v1alpha1.WorkflowPhaseFailed))
+		}
+
+		assert.Empty(t, b.launchPlans)
+	})
+}
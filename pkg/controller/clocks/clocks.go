@@ -0,0 +1,56 @@
+// Package clocks carries the clock the evaluation of workflows reads the time from. The clock is carried by the context
+// of a round, so that the node executor, its timeout checks and the back-offs of task plugins can be driven by a fake
+// clock in unit tests, or by a frozen clock when replaying and simulating workflows, without threading it through every
+// handler.
+package clocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type contextKey struct{}
+
+// WithClock returns a context that carries the clock. If the clock is nil, the context is returned as is, which leaves
+// it on the real clock.
+func WithClock(ctx context.Context, clk clock.Clock) context.Context {
+	if clk == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey{}, clk)
+}
+
+// FromContext returns the clock carried by the context, or the real clock if it doesn't carry any.
+func FromContext(ctx context.Context) clock.Clock {
+	if clk, ok := ctx.Value(contextKey{}).(clock.Clock); ok {
+		return clk
+	}
+
+	return clock.RealClock{}
+}
+
+// Now returns the current time of the clock carried by the context.
+func Now(ctx context.Context) time.Time {
+	return FromContext(ctx).Now()
+}
+
+// NewClock creates the clock configured for propeller, which is a fake clock frozen at the configured time or the real
+// clock.
+func NewClock(cfg config.ClockConfig) (clock.Clock, error) {
+	if len(cfg.FrozenAt) == 0 {
+		return clock.RealClock{}, nil
+	}
+
+	frozenAt, err := time.Parse(time.RFC3339, cfg.FrozenAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid frozen time [%s] of the clock, expected an RFC3339 time: %w", cfg.FrozenAt, err)
+	}
+
+	return clock.NewFakeClock(frozenAt), nil
+}
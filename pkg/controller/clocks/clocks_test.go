@@ -0,0 +1,49 @@
+package clocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("real clock by default", func(t *testing.T) {
+		assert.Equal(t, clock.RealClock{}, FromContext(context.TODO()))
+		assert.Equal(t, clock.RealClock{}, FromContext(WithClock(context.TODO(), nil)))
+	})
+
+	t.Run("fake clock", func(t *testing.T) {
+		now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+		fakeClock := clock.NewFakeClock(now)
+		ctx := WithClock(context.TODO(), fakeClock)
+		assert.Equal(t, now, Now(ctx))
+
+		fakeClock.Step(time.Minute)
+		assert.Equal(t, now.Add(time.Minute), Now(ctx))
+	})
+}
+
+func TestNewClock(t *testing.T) {
+	t.Run("real clock", func(t *testing.T) {
+		clk, err := NewClock(config.ClockConfig{})
+		assert.NoError(t, err)
+		assert.Equal(t, clock.RealClock{}, clk)
+	})
+
+	t.Run("frozen clock", func(t *testing.T) {
+		clk, err := NewClock(config.ClockConfig{FrozenAt: "2021-06-01T15:04:05Z"})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC), clk.Now().UTC())
+		assert.Equal(t, clk.Now(), clk.Now())
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		_, err := NewClock(config.ClockConfig{FrozenAt: "yesterday"})
+		assert.Error(t, err)
+	})
+}
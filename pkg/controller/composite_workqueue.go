@@ -9,6 +9,7 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -146,10 +147,17 @@ func (b *BatchingWorkQueue) AddToSubQueueRateLimited(item interface{}) {
 }
 
 func NewCompositeWorkQueue(ctx context.Context, cfg config.CompositeQueueConfig, scope promutils.Scope) (CompositeWorkQueue, error) {
-	workQ, err := NewWorkQueue(ctx, cfg.Queue, scope.NewScopedMetricName("main"))
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create WorkQueue in CompositeQueue type Batch")
+	var workQ workqueue.RateLimitingInterface
+	if cfg.Fair.Enabled {
+		workQ = newFairWorkQueue(ctx, cfg.Queue, cfg.Fair, clock.RealClock{}, scope.NewSubScope("fair_queue"))
+	} else {
+		var err error
+		workQ, err = NewWorkQueue(ctx, cfg.Queue, scope.NewScopedMetricName("main"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create WorkQueue in CompositeQueue type Batch")
+		}
 	}
+
 	switch cfg.Type {
 	case config.CompositeQueueBatch:
 		subQ, err := NewWorkQueue(ctx, cfg.Sub, scope.NewScopedMetricName("sub"))
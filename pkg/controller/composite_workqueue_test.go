@@ -48,6 +48,16 @@ func TestNewCompositeWorkQueue(t *testing.T) {
 			assert.FailNow(t, "BatchWorkQueue expected")
 		}
 	})
+
+	t.Run("fair", func(t *testing.T) {
+		testScope := promutils.NewScope("test3")
+		cfg := config2.CompositeQueueConfig{Fair: config2.FairQueueConfig{Enabled: true}}
+		q, err := NewCompositeWorkQueue(ctx, cfg, testScope)
+		assert.NoError(t, err)
+		if assert.IsType(t, &SimpleWorkQueue{}, q) {
+			assert.IsType(t, &fairWorkQueue{}, q.(*SimpleWorkQueue).RateLimitingInterface)
+		}
+	})
 }
 
 func TestSimpleWorkQueue(t *testing.T) {
@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// Admitted workflows are counted as running until the informer observes them as started, or for at most this long.
+const admissionGracePeriod = 5 * time.Minute
+
+type workflowAdmissionState int
+
+const (
+	// The workflow does not count towards any limit, it terminated, or it was deleted before it was admitted.
+	admissionStateDone workflowAdmissionState = iota
+	admissionStateWaiting
+	admissionStateRunning
+)
+
+type concurrencyGateMetrics struct {
+	Queued   labeled.Counter
+	Admitted labeled.Counter
+}
+
+// A concurrency scope is a set of workflows, i.e. a namespace, a launch plan, or a launch plan in a namespace, the
+// number of concurrently running workflows of which is limited.
+type concurrencyScope struct {
+	namespace  string
+	launchPlan string
+	limit      int
+}
+
+func (s concurrencyScope) matches(w *v1alpha1.FlyteWorkflow) bool {
+	if len(s.namespace) > 0 && s.namespace != w.GetNamespace() {
+		return false
+	}
+
+	return len(s.launchPlan) == 0 || s.launchPlan == w.GetLabels()[k8s.LaunchPlanNameLabel]
+}
+
+// Identifies the counters of the scope.
+func (s concurrencyScope) key() string {
+	return s.namespace + "/" + s.launchPlan
+}
+
+func (s concurrencyScope) String() string {
+	if len(s.launchPlan) == 0 {
+		return fmt.Sprintf("namespace [%s]", s.namespace)
+	}
+
+	if len(s.namespace) == 0 {
+		return fmt.Sprintf("launch plan [%s]", s.launchPlan)
+	}
+
+	return fmt.Sprintf("launch plan [%s] in namespace [%s]", s.launchPlan, s.namespace)
+}
+
+// trackedWorkflow is the last state the gate observed of a workflow, together with the scopes it counts towards.
+type trackedWorkflow struct {
+	workflow *v1alpha1.FlyteWorkflow
+	state    workflowAdmissionState
+	scopes   []concurrencyScope
+}
+
+// concurrencyGate holds newly observed workflows back while the concurrency limits of their namespace or launch plan
+// are reached. The gate counts the running and waiting workflows of every scope from the events of the workflow
+// informer, so that admission decisions do not have to walk all workflows. Because the informer lags behind the
+// workflows admitted by this gate, the gate counts the workflows it admitted as running until the informer observes
+// them as started.
+type concurrencyGate struct {
+	cfg     config.WorkflowConcurrencyConfig
+	metrics *concurrencyGateMetrics
+
+	// Guards all fields below and makes sure admission decisions are taken one at a time.
+	lock     sync.Mutex
+	admitted map[string]time.Time
+	tracked  map[string]*trackedWorkflow
+	// Number of running workflows by scope key.
+	running map[string]int
+	// Waiting workflows by scope key and workflow key.
+	waiting map[string]map[string]*v1alpha1.FlyteWorkflow
+}
+
+func workflowKey(w *v1alpha1.FlyteWorkflow) string {
+	return w.GetNamespace() + "/" + w.GetName()
+}
+
+// Returns true for workflows that have not been admitted yet.
+func isWaitingForAdmission(w *v1alpha1.FlyteWorkflow) bool {
+	p := w.GetExecutionStatus().GetPhase()
+	return p == v1alpha1.WorkflowPhaseReady || p == v1alpha1.WorkflowPhaseQueued
+}
+
+// Returns true if workflow a was created before workflow b and should therefore be admitted first.
+func isQueuedBefore(a, b *v1alpha1.FlyteWorkflow) bool {
+	createdA, createdB := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if createdA.Equal(&createdB) {
+		return workflowKey(a) < workflowKey(b)
+	}
+
+	return createdA.Before(&createdB)
+}
+
+// Returns the scopes that limit the given workflow.
+func (g *concurrencyGate) scopes(w *v1alpha1.FlyteWorkflow) []concurrencyScope {
+	namespaceLimit := g.cfg.DefaultNamespaceLimit
+	var scopes []concurrencyScope
+	for _, l := range g.cfg.Limits {
+		s := concurrencyScope{namespace: l.Namespace, launchPlan: l.LaunchPlan, limit: l.Limit}
+		if len(s.launchPlan) == 0 {
+			if s.namespace == w.GetNamespace() {
+				namespaceLimit = s.limit
+			}
+			continue
+		}
+
+		if s.matches(w) {
+			scopes = append(scopes, s)
+		}
+	}
+
+	if namespaceLimit > 0 {
+		scopes = append(scopes, concurrencyScope{namespace: w.GetNamespace(), limit: namespaceLimit})
+	}
+
+	return scopes
+}
+
+func (g *concurrencyGate) admissionState(w *v1alpha1.FlyteWorkflow) workflowAdmissionState {
+	if w.GetExecutionStatus().IsTerminated() {
+		return admissionStateDone
+	}
+
+	if _, ok := g.admitted[workflowKey(w)]; ok || !isWaitingForAdmission(w) {
+		return admissionStateRunning
+	}
+
+	if w.GetDeletionTimestamp() != nil {
+		return admissionStateDone
+	}
+
+	return admissionStateWaiting
+}
+
+// Removes the workflow from the counters of its scopes.
+func (g *concurrencyGate) untrack(key string) {
+	t, ok := g.tracked[key]
+	if !ok {
+		return
+	}
+
+	for _, s := range t.scopes {
+		switch t.state {
+		case admissionStateRunning:
+			g.running[s.key()]--
+		case admissionStateWaiting:
+			delete(g.waiting[s.key()], key)
+		}
+	}
+
+	delete(g.tracked, key)
+}
+
+// Updates the counters of the scopes of the workflow with its latest state.
+func (g *concurrencyGate) track(w *v1alpha1.FlyteWorkflow) {
+	key := workflowKey(w)
+	g.untrack(key)
+	if !isWaitingForAdmission(w) {
+		delete(g.admitted, key)
+	}
+
+	t := &trackedWorkflow{workflow: w, state: g.admissionState(w), scopes: g.scopes(w)}
+	if t.state == admissionStateDone || len(t.scopes) == 0 {
+		delete(g.admitted, key)
+		return
+	}
+
+	for _, s := range t.scopes {
+		switch t.state {
+		case admissionStateRunning:
+			g.running[s.key()]++
+		case admissionStateWaiting:
+			if _, ok := g.waiting[s.key()]; !ok {
+				g.waiting[s.key()] = map[string]*v1alpha1.FlyteWorkflow{}
+			}
+
+			g.waiting[s.key()][key] = w
+		}
+	}
+
+	g.tracked[key] = t
+}
+
+// Forgets admitted workflows that the informer did not observe as started within the grace period.
+func (g *concurrencyGate) pruneAdmitted(now time.Time) {
+	for key, admittedAt := range g.admitted {
+		if now.Sub(admittedAt) <= admissionGracePeriod {
+			continue
+		}
+
+		delete(g.admitted, key)
+		if t, ok := g.tracked[key]; ok {
+			g.track(t.workflow)
+		}
+	}
+}
+
+func (g *concurrencyGate) onUpdate(obj interface{}) {
+	w, ok := obj.(*v1alpha1.FlyteWorkflow)
+	if !ok {
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.track(w)
+}
+
+func (g *concurrencyGate) onDelete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Errorf(context.TODO(), "Unable to get key for deleted obj. Error[%v]", err)
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.untrack(key)
+	delete(g.admitted, key)
+}
+
+// EventHandler returns the handler that keeps the counters of the gate up to date with the workflow informer.
+func (g *concurrencyGate) EventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    g.onUpdate,
+		UpdateFunc: func(old, new interface{}) { g.onUpdate(new) },
+		DeleteFunc: g.onDelete,
+	}
+}
+
+// Admit decides whether the given workflow, which has not started yet, is allowed to start. If it is not, the returned
+// message describes the limit that holds it back.
+func (g *concurrencyGate) Admit(ctx context.Context, w *v1alpha1.FlyteWorkflow) (bool, string, error) {
+	if g == nil {
+		return true, "", nil
+	}
+
+	scopes := g.scopes(w)
+	if len(scopes) == 0 {
+		return true, "", nil
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	now := time.Now()
+	g.pruneAdmitted(now)
+	key := workflowKey(w)
+	if _, ok := g.admitted[key]; ok {
+		return true, "", nil
+	}
+
+	self := g.tracked[key]
+	for _, s := range scopes {
+		running := g.running[s.key()]
+		if self != nil && self.state == admissionStateRunning {
+			running--
+		}
+
+		queuedBefore := 0
+		for otherKey, other := range g.waiting[s.key()] {
+			if otherKey != key && isQueuedBefore(other, w) {
+				queuedBefore++
+			}
+		}
+
+		if running+queuedBefore >= s.limit {
+			logger.Infof(ctx, "Workflow queued, [%d] workflows of %v are running and [%d] are queued before it, limit [%d]",
+				running, s, queuedBefore, s.limit)
+			g.metrics.Queued.Inc(ctx)
+			return false, fmt.Sprintf("Workflow queued, concurrency limit [%d] of %v reached", s.limit, s), nil
+		}
+	}
+
+	g.admitted[key] = now
+	g.track(w)
+	g.metrics.Admitted.Inc(ctx)
+	return true, "", nil
+}
+
+// Returns a gate for the configured limits, or nil if no limits are configured. The counters of the gate have to be fed
+// by registering its EventHandler with the workflow informer.
+func newConcurrencyGate(cfg config.WorkflowConcurrencyConfig, scope promutils.Scope) *concurrencyGate {
+	if cfg.DefaultNamespaceLimit <= 0 && len(cfg.Limits) == 0 {
+		return nil
+	}
+
+	return &concurrencyGate{
+		cfg: cfg,
+		metrics: &concurrencyGateMetrics{
+			Queued:   labeled.NewCounter("queued", "Number of times a workflow was held back by a concurrency limit", scope),
+			Admitted: labeled.NewCounter("admitted", "Number of workflows admitted by the concurrency limits", scope),
+		},
+		admitted: map[string]time.Time{},
+		tracked:  map[string]*trackedWorkflow{},
+		running:  map[string]int{},
+		waiting:  map[string]map[string]*v1alpha1.FlyteWorkflow{},
+	}
+}
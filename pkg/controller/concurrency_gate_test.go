@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// Feeds the workflows to the gate as the informer would when it observes them.
+func observeWorkflows(g *concurrencyGate, workflows ...*v1alpha1.FlyteWorkflow) {
+	for _, w := range workflows {
+		g.EventHandler().OnUpdate(w, w)
+	}
+}
+
+func newConcurrencyTestWorkflow(namespace, name, launchPlan string, phase v1alpha1.WorkflowPhase, createdAt time.Time) *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			Labels:            map[string]string{k8s.LaunchPlanNameLabel: launchPlan},
+			CreationTimestamp: v1.NewTime(createdAt),
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: phase},
+	}
+}
+
+func TestConcurrencyGate_Admit(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+
+	t.Run("nil gate", func(t *testing.T) {
+		var g *concurrencyGate
+		admitted, _, err := g.Admit(ctx, newConcurrencyTestWorkflow("ns", "a", "", v1alpha1.WorkflowPhaseReady, now))
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		assert.Nil(t, newConcurrencyGate(config.WorkflowConcurrencyConfig{}, promutils.NewTestScope()))
+	})
+
+	t.Run("namespace limit", func(t *testing.T) {
+		g := newConcurrencyGate(config.WorkflowConcurrencyConfig{
+			DefaultNamespaceLimit: 1,
+			Limits:                []config.WorkflowConcurrencyLimit{{Namespace: "big", Limit: 2}},
+		}, promutils.NewTestScope())
+
+		a := newConcurrencyTestWorkflow("ns", "a", "", v1alpha1.WorkflowPhaseReady, now)
+		b := newConcurrencyTestWorkflow("ns", "b", "", v1alpha1.WorkflowPhaseReady, now.Add(time.Second))
+		c := newConcurrencyTestWorkflow("ns", "c", "", v1alpha1.WorkflowPhaseQueued, now.Add(-time.Second))
+		other := newConcurrencyTestWorkflow("other", "d", "", v1alpha1.WorkflowPhaseRunning, now)
+		observeWorkflows(g, a, b, c, other)
+
+		// c was created first and is admitted first, even though it is evaluated last.
+		admitted, msg, err := g.Admit(ctx, a)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+		assert.Equal(t, "Workflow queued, concurrency limit [1] of namespace [ns] reached", msg)
+
+		admitted, _, err = g.Admit(ctx, c)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+
+		// c is counted as running although the informer has not observed it as started yet.
+		admitted, _, err = g.Admit(ctx, a)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+
+		// Admitting c again is idempotent.
+		admitted, _, err = g.Admit(ctx, c)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+
+		c.Status.Phase = v1alpha1.WorkflowPhaseSuccess
+		observeWorkflows(g, c)
+		admitted, _, err = g.Admit(ctx, b)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+
+		admitted, _, err = g.Admit(ctx, a)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+
+		e := newConcurrencyTestWorkflow("big", "e", "", v1alpha1.WorkflowPhaseReady, now)
+		f := newConcurrencyTestWorkflow("big", "f", "", v1alpha1.WorkflowPhaseRunning, now)
+		observeWorkflows(g, e, f)
+		admitted, _, err = g.Admit(ctx, e)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	})
+
+	t.Run("counters follow informer events", func(t *testing.T) {
+		g := newConcurrencyGate(config.WorkflowConcurrencyConfig{DefaultNamespaceLimit: 1}, promutils.NewTestScope())
+		running := newConcurrencyTestWorkflow("ns", "a", "", v1alpha1.WorkflowPhaseRunning, now)
+		w := newConcurrencyTestWorkflow("ns", "b", "", v1alpha1.WorkflowPhaseReady, now)
+		observeWorkflows(g, running, w)
+		assert.Equal(t, 1, g.running["ns/"])
+		assert.Len(t, g.waiting["ns/"], 1)
+
+		admitted, _, err := g.Admit(ctx, w)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+
+		g.EventHandler().OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/a", Obj: running})
+		assert.Equal(t, 0, g.running["ns/"])
+
+		admitted, _, err = g.Admit(ctx, w)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+		assert.Equal(t, 1, g.running["ns/"])
+		assert.Empty(t, g.waiting["ns/"])
+
+		// The informer still lags behind, the admitted workflow keeps counting as running.
+		observeWorkflows(g, w)
+		assert.Equal(t, 1, g.running["ns/"])
+
+		// Admitted workflows that are not observed as started within the grace period are waiting again.
+		g.admitted["ns/b"] = time.Now().Add(-2 * admissionGracePeriod)
+		g.pruneAdmitted(time.Now())
+		assert.Equal(t, 0, g.running["ns/"])
+		assert.Len(t, g.waiting["ns/"], 1)
+	})
+
+	t.Run("launch plan limit", func(t *testing.T) {
+		g := newConcurrencyGate(config.WorkflowConcurrencyConfig{
+			Limits: []config.WorkflowConcurrencyLimit{{LaunchPlan: "lp", Limit: 1}},
+		}, promutils.NewTestScope())
+
+		running := newConcurrencyTestWorkflow("ns1", "a", "lp", v1alpha1.WorkflowPhaseRunning, now)
+		queued := newConcurrencyTestWorkflow("ns2", "b", "lp", v1alpha1.WorkflowPhaseReady, now)
+		unlimited := newConcurrencyTestWorkflow("ns2", "c", "other", v1alpha1.WorkflowPhaseReady, now)
+		observeWorkflows(g, running, queued, unlimited)
+
+		admitted, msg, err := g.Admit(ctx, queued)
+		assert.NoError(t, err)
+		assert.False(t, admitted)
+		assert.Equal(t, "Workflow queued, concurrency limit [1] of launch plan [lp] reached", msg)
+
+		admitted, _, err = g.Admit(ctx, unlimited)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	})
+}
+
+func TestPropeller_admitWorkflow(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	running := newConcurrencyTestWorkflow("ns", "a", "", v1alpha1.WorkflowPhaseRunning, now)
+	w := newConcurrencyTestWorkflow("ns", "b", "", v1alpha1.WorkflowPhaseReady, now)
+	g := newConcurrencyGate(config.WorkflowConcurrencyConfig{DefaultNamespaceLimit: 1}, promutils.NewTestScope())
+	observeWorkflows(g, running, w)
+	p := &Propeller{concurrencyGate: g}
+
+	admitted, err := p.admitWorkflow(ctx, w)
+	assert.NoError(t, err)
+	assert.False(t, admitted)
+	assert.Equal(t, v1alpha1.WorkflowPhaseQueued, w.GetExecutionStatus().GetPhase())
+	assert.Nil(t, w.GetExecutionStatus().GetStartedAt())
+
+	running.Status.Phase = v1alpha1.WorkflowPhaseSuccess
+	observeWorkflows(g, running)
+	admitted, err = p.admitWorkflow(ctx, w)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+	assert.Equal(t, v1alpha1.WorkflowPhaseReady, w.GetExecutionStatus().GetPhase())
+	assert.Nil(t, w.GetExecutionStatus().GetStartedAt())
+}
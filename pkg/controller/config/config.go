@@ -53,6 +53,7 @@ var (
 			Duration: 30 * time.Second,
 		},
 		MaxWorkflowRetries: 10,
+		MaxWorkflowPanics:  3,
 		MaxTTLInHours:      23,
 		GCInterval: config.Duration{
 			Duration: 30 * time.Minute,
@@ -64,6 +65,9 @@ var (
 				Duration: time.Second,
 			},
 			BatchSize: -1,
+			Fair: FairQueueConfig{
+				StarvationThreshold: config.Duration{Duration: time.Minute},
+			},
 			Queue: WorkqueueConfig{
 				Type:      WorkqueueTypeMaxOfRateLimiter,
 				BaseDelay: config.Duration{Duration: time.Second * 5},
@@ -96,6 +100,38 @@ var (
 			},
 			MaxNodeRetriesOnSystemFailures: 3,
 			InterruptibleFailureThreshold:  1,
+			MaxEvaluationParallelism:       1,
+			LiteralOffloading: LiteralOffloadingConfig{
+				MaxSizeBytes: 2 * 1024 * 1024,
+			},
+			ParallelismBudget: ParallelismBudgetConfig{
+				SubWorkflowWeight: 1,
+				DynamicWeight:     1,
+				ArrayWeight:       1,
+			},
+			InputPrefetch: InputPrefetchConfig{
+				CacheSize:      1000,
+				TTL:            config.Duration{Duration: time.Minute},
+				MaxConcurrency: 10,
+			},
+			Caches: NodeCachesConfig{
+				BindingPlansMaxSizeBytes:  64 * 1024 * 1024,
+				TaskTemplatesMaxSizeBytes: 128 * 1024 * 1024,
+			},
+			SystemRetryBackoff: RetryBackoffConfig{
+				BaseDelay:    config.Duration{Duration: 5 * time.Second},
+				Multiplier:   2,
+				MaxDelay:     config.Duration{Duration: 5 * time.Minute},
+				JitterFactor: 0.2,
+			},
+			RawOutput: RawOutputConfig{
+				ShardPrefixLength: 2,
+			},
+			Abort: NodeAbortConfig{
+				MaxDepth:    50,
+				Parallelism: 10,
+				Timeout:     config.Duration{Duration: 30 * time.Second},
+			},
 		},
 		MaxStreakLength: 8, // Turbo mode is enabled by default
 		ProfilerPort: config.Port{
@@ -107,9 +143,68 @@ var (
 		MetricsPrefix:       "flyte",
 		EventConfig: EventConfig{
 			RawOutputPolicy: RawOutputPolicyReference,
+			QoS:             EventQoSAll,
 		},
 		ClusterID:              "propeller",
 		CreateFlyteWorkflowCRD: false,
+		BatchAbort: BatchAbortConfig{
+			Interval: config.Duration{Duration: 30 * time.Second},
+			Rate:     10,
+		},
+		EvaluationCache: EvaluationCacheConfig{
+			Size:         1000,
+			MaxSizeBytes: 512 * 1024 * 1024,
+			TTL:          config.Duration{Duration: time.Hour},
+		},
+		LeakDetection: LeakDetectionConfig{
+			Interval:          config.Duration{Duration: 10 * time.Minute},
+			TerminatedTTL:     config.Duration{Duration: 24 * time.Hour},
+			InactivityTimeout: config.Duration{Duration: time.Hour},
+		},
+		WatchHealth: WatchHealthConfig{
+			Interval:           config.Duration{Duration: time.Minute},
+			StalenessThreshold: config.Duration{Duration: 5 * time.Minute},
+			SampleSize:         100,
+		},
+		VerboseTracing: VerboseTracingConfig{
+			Rate:  100,
+			Burst: 1000,
+		},
+		DataPlanes: DataPlanesConfig{
+			HealthCheckInterval: config.Duration{Duration: 30 * time.Second},
+		},
+		StoreResilience: StoreResilienceConfig{
+			MaxRetries:       3,
+			InitialBackoff:   config.Duration{Duration: 100 * time.Millisecond},
+			MaxBackoff:       config.Duration{Duration: 2 * time.Second},
+			Timeout:          config.Duration{Duration: 10 * time.Second},
+			FailureThreshold: 10,
+			Cooldown:         config.Duration{Duration: 30 * time.Second},
+		},
+		ContentAddressed: ContentAddressedConfig{
+			DeduplicationWindow: config.Duration{Duration: 24 * time.Hour},
+		},
+		OpenTelemetry: OpenTelemetryConfig{
+			Endpoint:      "localhost:4317",
+			SamplingRatio: 1,
+		},
+		TTLGarbageCollector: TTLGarbageCollectorConfig{
+			Interval:   config.Duration{Duration: 5 * time.Minute},
+			SuccessTTL: config.Duration{Duration: 24 * time.Hour},
+			FailureTTL: config.Duration{Duration: 72 * time.Hour},
+			Rate:       10,
+		},
+		WorkflowProgress: WorkflowProgressConfig{
+			Interval: config.Duration{Duration: 30 * time.Second},
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: 5,
+			Window:           config.Duration{Duration: 10 * time.Minute},
+			PauseDuration:    config.Duration{Duration: 30 * time.Minute},
+		},
+		Maintenance: MaintenanceConfig{
+			Interval: config.Duration{Duration: 30 * time.Second},
+		},
 	}
 )
 
@@ -117,36 +212,55 @@ var (
 // the base configuration to start propeller
 // NOTE: when adding new fields, do not mark them as "omitempty" if it's desirable to read the value from env variables.
 type Config struct {
-	KubeConfigPath         string               `json:"kube-config" pflag:",Path to kubernetes client config file."`
-	MasterURL              string               `json:"master"`
-	Workers                int                  `json:"workers" pflag:",Number of threads to process workflows"`
-	WorkflowReEval         config.Duration      `json:"workflow-reeval-duration" pflag:",Frequency of re-evaluating workflows"`
-	DownstreamEval         config.Duration      `json:"downstream-eval-duration" pflag:",Frequency of re-evaluating downstream tasks"`
-	LimitNamespace         string               `json:"limit-namespace" pflag:",Namespaces to watch for this propeller"`
-	ProfilerPort           config.Port          `json:"prof-port" pflag:",Profiler port"`
-	MetadataPrefix         string               `json:"metadata-prefix,omitempty" pflag:",MetadataPrefix should be used if all the metadata for Flyte executions should be stored under a specific prefix in CloudStorage. If not specified, the data will be stored in the base container directly."`
-	DefaultRawOutputPrefix string               `json:"rawoutput-prefix" pflag:",a fully qualified storage path of the form s3://flyte/abc/..., where all data sandboxes should be stored."`
-	Queue                  CompositeQueueConfig `json:"queue,omitempty" pflag:",Workflow workqueue configuration, affects the way the work is consumed from the queue."`
-	MetricsPrefix          string               `json:"metrics-prefix" pflag:",An optional prefix for all published metrics."`
-	EnableAdminLauncher    bool                 `json:"enable-admin-launcher" pflag:"Enable remote Workflow launcher to Admin"`
-	MaxWorkflowRetries     int                  `json:"max-workflow-retries" pflag:"Maximum number of retries per workflow"`
-	MaxTTLInHours          int                  `json:"max-ttl-hours" pflag:"Maximum number of hours a completed workflow should be retained. Number between 1-23 hours"`
-	GCInterval             config.Duration      `json:"gc-interval" pflag:"Run periodic GC every 30 minutes"`
-	LeaderElection         LeaderElectionConfig `json:"leader-election,omitempty" pflag:",Config for leader election."`
-	PublishK8sEvents       bool                 `json:"publish-k8s-events" pflag:",Enable events publishing to K8s events API."`
-	MaxDatasetSizeBytes    int64                `json:"max-output-size-bytes" pflag:",Maximum size of outputs per task"`
-	KubeConfig             KubeClientConfig     `json:"kube-client-config" pflag:",Configuration to control the Kubernetes client"`
-	NodeConfig             NodeConfig           `json:"node-config,omitempty" pflag:",config for a workflow node"`
-	MaxStreakLength        int                  `json:"max-streak-length" pflag:",Maximum number of consecutive rounds that one propeller worker can use for one workflow - >1 => turbo-mode is enabled."`
-	EventConfig            EventConfig          `json:"event-config,omitempty" pflag:",Configures execution event behavior."`
-	IncludeShardKeyLabel   []string             `json:"include-shard-key-label" pflag:",Include the specified shard key label in the k8s FlyteWorkflow CRD label selector"`
-	ExcludeShardKeyLabel   []string             `json:"exclude-shard-key-label" pflag:",Exclude the specified shard key label from the k8s FlyteWorkflow CRD label selector"`
-	IncludeProjectLabel    []string             `json:"include-project-label" pflag:",Include the specified project label in the k8s FlyteWorkflow CRD label selector"`
-	ExcludeProjectLabel    []string             `json:"exclude-project-label" pflag:",Exclude the specified project label from the k8s FlyteWorkflow CRD label selector"`
-	IncludeDomainLabel     []string             `json:"include-domain-label" pflag:",Include the specified domain label in the k8s FlyteWorkflow CRD label selector"`
-	ExcludeDomainLabel     []string             `json:"exclude-domain-label" pflag:",Exclude the specified domain label from the k8s FlyteWorkflow CRD label selector"`
-	ClusterID              string               `json:"cluster-id" pflag:",Unique cluster id running this flytepropeller instance with which to annotate execution events"`
-	CreateFlyteWorkflowCRD bool                 `json:"create-flyteworkflow-crd" pflag:",Enable creation of the FlyteWorkflow CRD on startup"`
+	KubeConfigPath         string                    `json:"kube-config" pflag:",Path to kubernetes client config file."`
+	MasterURL              string                    `json:"master"`
+	Workers                int                       `json:"workers" pflag:",Number of threads to process workflows"`
+	WorkflowReEval         config.Duration           `json:"workflow-reeval-duration" pflag:",Frequency of re-evaluating workflows"`
+	DownstreamEval         config.Duration           `json:"downstream-eval-duration" pflag:",Frequency of re-evaluating downstream tasks"`
+	LimitNamespace         string                    `json:"limit-namespace" pflag:",Namespaces to watch for this propeller"`
+	ProfilerPort           config.Port               `json:"prof-port" pflag:",Profiler port"`
+	MetadataPrefix         string                    `json:"metadata-prefix,omitempty" pflag:",MetadataPrefix should be used if all the metadata for Flyte executions should be stored under a specific prefix in CloudStorage. If not specified, the data will be stored in the base container directly."`
+	DefaultRawOutputPrefix string                    `json:"rawoutput-prefix" pflag:",a fully qualified storage path of the form s3://flyte/abc/..., where all data sandboxes should be stored."`
+	Queue                  CompositeQueueConfig      `json:"queue,omitempty" pflag:",Workflow workqueue configuration, affects the way the work is consumed from the queue."`
+	MetricsPrefix          string                    `json:"metrics-prefix" pflag:",An optional prefix for all published metrics."`
+	EnableAdminLauncher    bool                      `json:"enable-admin-launcher" pflag:"Enable remote Workflow launcher to Admin"`
+	MaxWorkflowRetries     int                       `json:"max-workflow-retries" pflag:"Maximum number of retries per workflow"`
+	MaxWorkflowPanics      int                       `json:"max-workflow-panics" pflag:",Number of rounds of a workflow that may panic before the workflow is quarantined and no longer evaluated, until the quarantined label is removed. 0 disables quarantining."`
+	MaxTTLInHours          int                       `json:"max-ttl-hours" pflag:"Maximum number of hours a completed workflow should be retained. Number between 1-23 hours"`
+	GCInterval             config.Duration           `json:"gc-interval" pflag:"Run periodic GC every 30 minutes"`
+	LeaderElection         LeaderElectionConfig      `json:"leader-election,omitempty" pflag:",Config for leader election."`
+	PublishK8sEvents       bool                      `json:"publish-k8s-events" pflag:",Enable events publishing to K8s events API."`
+	MaxDatasetSizeBytes    int64                     `json:"max-output-size-bytes" pflag:",Maximum size of outputs per task"`
+	KubeConfig             KubeClientConfig          `json:"kube-client-config" pflag:",Configuration to control the Kubernetes client"`
+	NodeConfig             NodeConfig                `json:"node-config,omitempty" pflag:",config for a workflow node"`
+	MaxStreakLength        int                       `json:"max-streak-length" pflag:",Maximum number of consecutive rounds that one propeller worker can use for one workflow - >1 => turbo-mode is enabled."`
+	EventConfig            EventConfig               `json:"event-config,omitempty" pflag:",Configures execution event behavior."`
+	IncludeShardKeyLabel   []string                  `json:"include-shard-key-label" pflag:",Include the specified shard key label in the k8s FlyteWorkflow CRD label selector"`
+	ExcludeShardKeyLabel   []string                  `json:"exclude-shard-key-label" pflag:",Exclude the specified shard key label from the k8s FlyteWorkflow CRD label selector"`
+	IncludeProjectLabel    []string                  `json:"include-project-label" pflag:",Include the specified project label in the k8s FlyteWorkflow CRD label selector"`
+	ExcludeProjectLabel    []string                  `json:"exclude-project-label" pflag:",Exclude the specified project label from the k8s FlyteWorkflow CRD label selector"`
+	IncludeDomainLabel     []string                  `json:"include-domain-label" pflag:",Include the specified domain label in the k8s FlyteWorkflow CRD label selector"`
+	ExcludeDomainLabel     []string                  `json:"exclude-domain-label" pflag:",Exclude the specified domain label from the k8s FlyteWorkflow CRD label selector"`
+	ClusterID              string                    `json:"cluster-id" pflag:",Unique cluster id running this flytepropeller instance with which to annotate execution events"`
+	CreateFlyteWorkflowCRD bool                      `json:"create-flyteworkflow-crd" pflag:",Enable creation of the FlyteWorkflow and Signal CRDs on startup"`
+	WorkflowConcurrency    WorkflowConcurrencyConfig `json:"workflow-concurrency,omitempty" pflag:",Limits the number of concurrently running workflows"`
+	BatchAbort             BatchAbortConfig          `json:"batch-abort,omitempty" pflag:",Config for aborting all executions matching a label selector at once"`
+	EvaluationCache        EvaluationCacheConfig     `json:"evaluation-cache,omitempty" pflag:",Config for caching the immutable sections of workflows across rounds"`
+	LeakDetection          LeakDetectionConfig       `json:"leak-detection,omitempty" pflag:",Config for exporting metrics about leaked executions and resources"`
+	WatchHealth            WatchHealthConfig         `json:"watch-health,omitempty" pflag:",Config for detecting and recovering from a stale FlyteWorkflow informer cache"`
+	VerboseTracing         VerboseTracingConfig      `json:"verbose-tracing,omitempty" pflag:",Config for tracing the evaluation of single workflows that opt in through an annotation"`
+	TTLGarbageCollector    TTLGarbageCollectorConfig `json:"ttl-gc,omitempty" pflag:",Config for deleting terminated workflows once they outlived the TTL of their namespace"`
+	RoundBudget            RoundBudgetConfig         `json:"round-budget,omitempty" pflag:",Config for capping the blob reads and kube writes of a single round of a workflow"`
+	Clock                  ClockConfig               `json:"clock,omitempty" pflag:",Config for the clock workflows are evaluated with"`
+	WorkflowProgress       WorkflowProgressConfig    `json:"workflow-progress,omitempty" pflag:",Config for emitting events with the progress of running workflows"`
+	CircuitBreaker         CircuitBreakerConfig      `json:"circuit-breaker,omitempty" pflag:",Config for pausing launch plans whose workflows fail repeatedly"`
+	Maintenance            MaintenanceConfig         `json:"maintenance,omitempty" pflag:",Config for the maintenance mode, in which propeller does not launch new task pods"`
+	NodeOutcomes           NodeOutcomesConfig        `json:"node-outcomes,omitempty" pflag:",Config for recording the outcomes of the nodes of executions, to reuse them in relaunches"`
+	OpenTelemetry          OpenTelemetryConfig       `json:"open-telemetry,omitempty" pflag:",Config for exporting OpenTelemetry spans of the evaluation rounds of workflows"`
+	PhaseMetrics           PhaseMetricsConfig        `json:"phase-metrics,omitempty" pflag:",Config for recording the time workflows and nodes spend in each of their phases"`
+	DataPlanes             DataPlanesConfig          `json:"data-planes,omitempty" pflag:",Config for routing the pods and CRDs of tasks to remote data plane clusters"`
+	StoreResilience        StoreResilienceConfig     `json:"store-resilience,omitempty" pflag:",Config for retrying the blob storage calls of the node executor and failing them fast during outages"`
+	ContentAddressed       ContentAddressedConfig    `json:"content-addressed,omitempty" pflag:",Config for storing the outputs of nodes once per content in blob storage"`
 }
 
 // KubeClientConfig contains the configuration used by flytepropeller to configure its internal Kubernetes Client.
@@ -175,6 +289,18 @@ type CompositeQueueConfig struct {
 	Sub              WorkqueueConfig    `json:"sub-queue,omitempty" pflag:",SubQueue configuration, affects the way the nodes cause the top-level Work to be re-evaluated."`
 	BatchingInterval config.Duration    `json:"batching-interval" pflag:",Duration for which downstream updates are buffered"`
 	BatchSize        int                `json:"batch-size" pflag:"-1,Number of downstream triggered top-level objects to re-enqueue every duration. -1 indicates all available."`
+	Fair             FairQueueConfig    `json:"fair,omitempty" pflag:",Config for serving the workflows of all namespaces fairly from the WorkQueue"`
+}
+
+// FairQueueConfig configures the fair-queuing of the WorkQueue. Workflows are queued per namespace and the namespaces with
+// queued workflows are served round-robin, so that a namespace that enqueues many workflows, or a workflow that is
+// re-enqueued rapidly, can't starve the workflows of other namespaces. The number of workflows of a namespace that are
+// evaluated concurrently can be capped, in which case further workflows of the namespace wait for one of them to finish
+// its round.
+type FairQueueConfig struct {
+	Enabled                 bool            `json:"enabled" pflag:",Serve the workflows of all namespaces fairly from the WorkQueue"`
+	MaxInflightPerNamespace int             `json:"max-inflight-per-namespace" pflag:",Max number of workflows of a namespace that are evaluated concurrently. 0 leaves them uncapped."`
+	StarvationThreshold     config.Duration `json:"starvation-threshold" pflag:",Workflows that wait longer than this in the WorkQueue are counted as starved"`
 }
 
 type WorkqueueType = string
@@ -198,9 +324,101 @@ type WorkqueueConfig struct {
 
 // NodeConfig contains configuration that is useful for every node execution
 type NodeConfig struct {
-	DefaultDeadlines               DefaultDeadlines `json:"default-deadlines,omitempty" pflag:",Default value for timeouts"`
-	MaxNodeRetriesOnSystemFailures int64            `json:"max-node-retries-system-failures" pflag:"2,Maximum number of retries per node for node failure due to infra issues"`
-	InterruptibleFailureThreshold  int64            `json:"interruptible-failure-threshold" pflag:"1,number of failures for a node to be still considered interruptible'"`
+	DefaultDeadlines               DefaultDeadlines        `json:"default-deadlines,omitempty" pflag:",Default value for timeouts"`
+	MaxNodeRetriesOnSystemFailures int64                   `json:"max-node-retries-system-failures" pflag:"2,Maximum number of retries per node for node failure due to infra issues"`
+	InterruptibleFailureThreshold  int64                   `json:"interruptible-failure-threshold" pflag:"1,number of failures for a node to be still considered interruptible'"`
+	MaxEvaluationParallelism       int                     `json:"max-evaluation-parallelism" pflag:",Maximum number of ready nodes of a workflow that are evaluated concurrently within a round. 1 evaluates nodes serially"`
+	DefaultRetryPolicies           []RetryPolicy           `json:"default-retry-policies,omitempty" pflag:"-,Platform wide retry policies by error kind and code, used when a node does not declare a matching policy"`
+	LiteralOffloading              LiteralOffloadingConfig `json:"literal-offloading,omitempty" pflag:",Offloading of large literals to blob storage"`
+	ParallelismBudget              ParallelismBudgetConfig `json:"parallelism-budget,omitempty" pflag:",Subdivision of the max parallelism of executions across nested parent nodes"`
+	InputPrefetch                  InputPrefetchConfig     `json:"input-prefetch,omitempty" pflag:",Prefetching of the inputs of nodes that become ready in the next round"`
+	SystemRetryBackoff             RetryBackoffConfig      `json:"system-retry-backoff,omitempty" pflag:",Exponential backoff between the attempts of nodes that failed with system errors"`
+	SystemErrorCodes               []string                `json:"system-error-codes" pflag:",Error codes of failures that count against the system retries of nodes instead of their user retries, whatever kind they were reported with. E.g. ImagePullBackOff"`
+	Caches                         NodeCachesConfig        `json:"caches,omitempty" pflag:",Bounds of the in-memory caches of the node executor"`
+	Identity                       NodeIdentityConfig      `json:"identity,omitempty" pflag:",Identities the pods and child executions of nodes may run as"`
+	Abort                          NodeAbortConfig         `json:"abort,omitempty" pflag:",Propagation of aborts to the nodes of nested sub workflows"`
+	RawOutput                      RawOutputConfig         `json:"raw-output,omitempty" pflag:",Layout of the raw outputs of nodes in blob storage"`
+}
+
+// LiteralOffloadingConfig configures offloading literals that exceed a size to blob storage, so that the inputs sent
+// inline to admin when launching child executions do not exceed the gRPC message size limit. Offloaded literals are
+// replaced by references and transparently rehydrated when the outputs of nodes are resolved.
+type LiteralOffloadingConfig struct {
+	Enabled      bool  `json:"enabled" pflag:",Enables offloading literals that exceed the max size to blob storage"`
+	MaxSizeBytes int64 `json:"max-size-bytes" pflag:",Size in bytes of the serialized inputs of a node above which its largest literals are offloaded"`
+}
+
+// ParallelismBudgetConfig configures the share of the max parallelism of an execution that the child nodes of sub
+// workflow, dynamic and array nodes may use. Every such parent node is limited to its weight, a share between 0 and 1,
+// of the parallelism remaining to it when it's evaluated, so that nested fan-outs can't starve their siblings. A weight
+// of 1 lets a parent node use all of the remaining parallelism.
+type ParallelismBudgetConfig struct {
+	SubWorkflowWeight float64 `json:"sub-workflow-weight" pflag:",Share of the remaining parallelism the nodes of a sub workflow may use"`
+	DynamicWeight     float64 `json:"dynamic-weight" pflag:",Share of the remaining parallelism the nodes of a dynamic workflow may use"`
+	ArrayWeight       float64 `json:"array-weight" pflag:",Share of the remaining parallelism the sub nodes of an array node may use"`
+}
+
+// InputPrefetchConfig configures reading the outputs of nodes ahead of time, once they succeeded, so that the inputs
+// of the downstream nodes that become ready in the next round are resolved from memory.
+type InputPrefetchConfig struct {
+	Enabled        bool            `json:"enabled" pflag:",Enables prefetching the inputs of nodes that become ready in the next round"`
+	CacheSize      int             `json:"cache-size" pflag:",Number of prefetched node outputs kept in memory, across all workflows"`
+	TTL            config.Duration `json:"ttl" pflag:",Time prefetched node outputs are kept in memory"`
+	MaxConcurrency int             `json:"max-concurrency" pflag:",Maximum number of node outputs read concurrently, further prefetches are dropped"`
+}
+
+// NodeCachesConfig bounds the memory taken up by the caches of the node executor. Once the estimated size of a cache
+// exceeds its bound, its least recently used entries are evicted. 0 leaves a cache bounded by its number of entries
+// only.
+type NodeCachesConfig struct {
+	BindingPlansMaxSizeBytes  int64 `json:"binding-plans-max-size-bytes" pflag:",Max estimated size in bytes of the cached binding plans of end nodes, across all workflows"`
+	TaskTemplatesMaxSizeBytes int64 `json:"task-templates-max-size-bytes" pflag:",Max estimated size in bytes of the cached task templates read from the DataStore"`
+}
+
+// NodeIdentityConfig configures the identities, service accounts and IAM roles, that the execution config and the nodes
+// of a workflow may override the identity of its execution with. Workflows overriding an identity that is not allowed
+// fail before any of their nodes run. An empty list allows any value.
+type NodeIdentityConfig struct {
+	IamRoleAnnotation      string   `json:"iam-role-annotation" pflag:",Annotation set to the IAM role of the node on its pods, e.g. iam.amazonaws.com/role. The IAM role is not set on pods if empty"`
+	AllowedServiceAccounts []string `json:"allowed-service-accounts" pflag:",Service accounts nodes may run as"`
+	AllowedIamRoles        []string `json:"allowed-iam-roles" pflag:",IAM roles nodes may run as"`
+}
+
+// RawOutputConfig configures where the raw outputs of nodes are written to under the raw output data prefix of their
+// execution. The raw outputs are sharded by base36 hash prefixes of the given length, e.g. 2 spreads them across 1296
+// prefixes, so that object stores don't hot-spot on a single prefix for large executions.
+type RawOutputConfig struct {
+	ShardPrefixLength     int  `json:"shard-prefix-length" pflag:",Number of base36 characters, between 1 and 3, of the hash prefixes raw outputs are sharded by"`
+	PerExecutionDirectory bool `json:"per-execution-directory" pflag:",Writes the raw outputs of every execution to a directory named after the execution under the raw output data prefix"`
+}
+
+// NodeAbortConfig configures how aborts are propagated to the nodes of a workflow and, recursively, to the nodes of its
+// sub workflows. Aborts are propagated no deeper than the max depth, so that sub workflows referencing themselves can't
+// recurse indefinitely. Once the timeout elapses, the nodes not aborted yet are aborted in a later round.
+type NodeAbortConfig struct {
+	MaxDepth    int             `json:"max-depth" pflag:",Maximum number of nested sub workflows an abort is propagated through. 0 does not limit the depth"`
+	Parallelism int             `json:"parallelism" pflag:",Maximum number of downstream nodes aborted concurrently. 1 aborts nodes serially"`
+	Timeout     config.Duration `json:"timeout" pflag:",Time the abort of a workflow, including its sub workflows and child executions, may take within a round. 0 disables the timeout"`
+}
+
+// RetryBackoffConfig configures the exponential backoff between the attempts of a node that failed with a system
+// error, so that repeated infrastructure failures do not retry nodes in a hot loop. The first retry is delayed by the
+// base delay, every subsequent one by the previous delay times the multiplier, up to the max delay. Up to the jitter
+// factor of every delay is added to it at random, so that nodes failing together are not retried together.
+type RetryBackoffConfig struct {
+	Enabled      bool            `json:"enabled" pflag:",Enables backing off between the attempts of nodes that failed with system errors"`
+	BaseDelay    config.Duration `json:"base-delay" pflag:",Delay of the first retry of a node that failed with a system error"`
+	Multiplier   int             `json:"multiplier" pflag:",Factor every subsequent delay is scaled by"`
+	MaxDelay     config.Duration `json:"max-delay" pflag:",Cap of the delay between attempts"`
+	JitterFactor float64         `json:"jitter-factor" pflag:",Share of every delay that is added to it at random"`
+}
+
+// RetryPolicy overrides the number of retries for node failures matching an error kind and/or code
+type RetryPolicy struct {
+	Kind                        string `json:"kind,omitempty" pflag:",Error kind (USER or SYSTEM) the policy applies to. Empty matches all kinds"`
+	Code                        string `json:"code,omitempty" pflag:",Error code the policy applies to. Empty matches all codes"`
+	Retries                     int    `json:"retries" pflag:",Maximum number of retries for matching failures"`
+	ExecutionDeadlineMultiplier int    `json:"execution-deadline-multiplier,omitempty" pflag:",Scales the execution deadline of every subsequent attempt if greater than 1"`
 }
 
 // DefaultDeadlines contains default values for timeouts
@@ -229,6 +447,211 @@ type LeaderElectionConfig struct {
 	RetryPeriod config.Duration `json:"retry-period" pflag:",Duration the LeaderElector clients should wait between tries of actions."`
 }
 
+// WorkflowConcurrencyConfig limits the number of workflows that run concurrently. Newly observed workflows that would
+// exceed a limit are held in the Queued phase, in order of creation, until running workflows complete.
+type WorkflowConcurrencyConfig struct {
+	DefaultNamespaceLimit int                        `json:"default-namespace-limit" pflag:",Maximum number of concurrently running workflows per namespace. 0 means unlimited."`
+	Limits                []WorkflowConcurrencyLimit `json:"limits,omitempty" pflag:"-,Concurrency limits of specific namespaces and launch plans"`
+}
+
+// BatchAbortConfig configures aborting all executions of a namespace that match the label selector in the
+// flyte.org/abort-selector annotation of the namespace. Once processed, the annotation is replaced by a report of the
+// aborted executions in the flyte.org/abort-report annotation.
+type BatchAbortConfig struct {
+	Enabled  bool            `json:"enabled" pflag:",Enables aborting the executions matching the abort selector annotation of their namespace."`
+	Interval config.Duration `json:"interval" pflag:",Frequency of checking namespaces for abort selector annotations."`
+	Rate     int64           `json:"rate" pflag:",Max number of executions aborted per second."`
+}
+
+// EvaluationCacheConfig configures caching, per workflow, a copy of the immutable sections of the FlyteWorkflow (the
+// spec of the workflow, its subworkflows and tasks, and its inputs). The copy is reused by the rounds of the workflow
+// until its resource version changes other than through propeller's own status updates, sparing deep copying and
+// deriving the spec every round.
+type EvaluationCacheConfig struct {
+	Enabled      bool            `json:"enabled" pflag:",Enables caching the immutable sections of workflows across rounds."`
+	Size         int             `json:"size" pflag:",Max number of workflows whose immutable sections are cached."`
+	MaxSizeBytes int64           `json:"max-size-bytes" pflag:",Max estimated size in bytes of the cached sections of all workflows. 0 bounds the cache by its number of workflows only."`
+	TTL          config.Duration `json:"ttl" pflag:",Duration after which the cached sections of a workflow that is no longer evaluated are evicted."`
+}
+
+// LeakDetectionConfig configures periodically scanning for leaked executions and resources, exporting how many were
+// found per namespace as gauges: terminated executions that were not garbage collected, executions holding on to their
+// finalizer and pods owned by executions that no longer exist.
+type LeakDetectionConfig struct {
+	Enabled           bool            `json:"enabled" pflag:",Enables periodically scanning for leaked executions and resources."`
+	Interval          config.Duration `json:"interval" pflag:",Frequency of scanning for leaked executions and resources."`
+	TerminatedTTL     config.Duration `json:"terminated-ttl" pflag:",Duration after which terminated executions that still exist are reported as not garbage collected."`
+	InactivityTimeout config.Duration `json:"inactivity-timeout" pflag:",Duration after which terminated or deleted executions that still have a finalizer are reported as leaking it."`
+}
+
+// TTLGarbageCollectorConfig configures deleting terminated workflows once they outlived a TTL, separately for workflows
+// that succeeded and ones that failed or were aborted. The TTLs can be overridden per namespace.
+type TTLGarbageCollectorConfig struct {
+	Enabled           bool                 `json:"enabled" pflag:",Enables deleting terminated workflows once they outlived their TTL."`
+	Interval          config.Duration      `json:"interval" pflag:",Frequency of scanning for terminated workflows that outlived their TTL."`
+	SuccessTTL        config.Duration      `json:"success-ttl" pflag:",Duration after which workflows that succeeded are deleted. 0 keeps them."`
+	FailureTTL        config.Duration      `json:"failure-ttl" pflag:",Duration after which workflows that failed or were aborted are deleted. 0 keeps them."`
+	NamespacePolicies map[string]TTLPolicy `json:"namespace-policies,omitempty" pflag:"-,TTLs per namespace, overriding the default ones."`
+	Rate              int64                `json:"rate" pflag:",Max number of workflows deleted per second."`
+	ArchivePrefix     string               `json:"archive-prefix" pflag:",If set, workflows are archived under this prefix of the data store, and indexed, before they are deleted."`
+}
+
+// TTLPolicy configures the TTLs of the terminated workflows of a namespace. TTLs that are not set fall back to the
+// default ones.
+type TTLPolicy struct {
+	SuccessTTL config.Duration `json:"success-ttl" pflag:",Duration after which workflows that succeeded are deleted."`
+	FailureTTL config.Duration `json:"failure-ttl" pflag:",Duration after which workflows that failed or were aborted are deleted."`
+}
+
+// WatchHealthConfig configures periodically checking whether the informer cache of FlyteWorkflows went stale, i.e. it
+// received no events for a while and workflows of the apiserver are missing from or outdated in the cache, which happens
+// when watch events are silently missed after apiserver or network disruptions. The informer then re-lists all
+// workflows.
+type WatchHealthConfig struct {
+	Enabled            bool            `json:"enabled" pflag:",Enables detecting and recovering from a stale FlyteWorkflow informer cache."`
+	Interval           config.Duration `json:"interval" pflag:",Frequency of checking whether the informer cache is stale."`
+	StalenessThreshold config.Duration `json:"staleness-threshold" pflag:",Duration without events after which workflows of the apiserver are compared to the ones of the informer cache."`
+	SampleSize         int64           `json:"sample-size" pflag:",Number of workflows listed from the apiserver to compare to the ones of the informer cache."`
+}
+
+// VerboseTracingConfig configures tracing the evaluation of single workflows, which logs the evaluation of the workflow
+// and its nodes regardless of the log level while the workflow is annotated with flyte.org/trace-until and the
+// annotated time has not passed. The trace lines of all workflows are rate limited, so that tracing a large workflow
+// can't flood the logs.
+type VerboseTracingConfig struct {
+	Enabled bool    `json:"enabled" pflag:",Enables tracing workflows annotated with flyte.org/trace-until."`
+	Rate    float64 `json:"rate" pflag:",Number of trace lines per second logged across all traced workflows."`
+	Burst   int     `json:"burst" pflag:",Maximum number of trace lines logged at once across all traced workflows."`
+}
+
+// OpenTelemetryConfig configures exporting spans of the evaluation rounds of workflows via OTLP. Every round is a trace
+// of its own, covering the workflow and node executors, the task handler and the calls to blob storage, Admin and the
+// catalog made during the round. The rounds of an execution are linked to one another by the ID of the execution.
+type OpenTelemetryConfig struct {
+	Enabled       bool    `json:"enabled" pflag:",Enables exporting OpenTelemetry spans of the evaluation rounds of workflows"`
+	Endpoint      string  `json:"endpoint" pflag:",Address of the OTLP gRPC collector spans are exported to"`
+	Insecure      bool    `json:"insecure" pflag:",Exports spans to the collector without TLS"`
+	SamplingRatio float64 `json:"sampling-ratio" pflag:",Share of the evaluation rounds that are traced, between 0 and 1"`
+}
+
+// PhaseMetricsConfig configures recording the time workflows and nodes spend in each of their phases as histograms,
+// labeled by the phase they left and the one they entered, along with counters of the transitions by their cause.
+type PhaseMetricsConfig struct {
+	Enabled bool `json:"enabled" pflag:",Enables recording the time workflows and nodes spend in each of their phases"`
+}
+
+// DataPlanesConfig configures routing the pods and CRDs created for tasks to remote data plane clusters, chosen by the
+// project and domain of their execution. An execution is pinned to the first healthy cluster, in the order they are
+// configured, whose projects and domains match it when it starts, and to the cluster propeller runs in if none does. All
+// of its tasks run in that cluster, even if it becomes unhealthy later. Only the resources watched by the k8s plugins
+// are routed, and they are watched in the data plane clusters as well. The resources in the data plane clusters are
+// owned by FlyteWorkflows that don't exist there, so the FlyteWorkflow CRD must not be installed in them, for their
+// garbage collector to leave the resources alone.
+type DataPlanesConfig struct {
+	Enabled             bool               `json:"enabled" pflag:",Enables routing the pods and CRDs of tasks to remote data plane clusters"`
+	HealthCheckInterval config.Duration    `json:"health-check-interval" pflag:",Interval the health of the data plane clusters is checked at. New executions are not pinned to unhealthy clusters"`
+	Clusters            []DataPlaneCluster `json:"clusters" pflag:"-,Data plane clusters, in the order executions are pinned to them"`
+}
+
+// DataPlaneCluster is a remote cluster the pods and CRDs of tasks can be routed to. An empty list of projects or domains
+// matches all of them.
+type DataPlaneCluster struct {
+	Name           string   `json:"name"`
+	KubeConfigPath string   `json:"kube-config-path"`
+	Projects       []string `json:"projects"`
+	Domains        []string `json:"domains"`
+}
+
+// StoreResilienceConfig configures retrying the blob storage calls made by the node executor to read and write the
+// inputs and outputs of nodes. Every attempt is bounded by the timeout, and failed attempts are retried with an
+// exponential backoff. After the threshold of consecutive failed calls, the circuit breaker opens and calls fail fast
+// until the cooldown has passed, failing the nodes that make them with a retryable system error instead of holding up
+// the evaluation rounds of their workflows during an object store outage.
+type StoreResilienceConfig struct {
+	Enabled          bool            `json:"enabled" pflag:",Enables retrying the blob storage calls of the node executor and the circuit breaker"`
+	MaxRetries       int             `json:"max-retries" pflag:",Max number of times a failed blob storage call is retried"`
+	InitialBackoff   config.Duration `json:"initial-backoff" pflag:",Backoff before the first retry of a blob storage call, doubled on every retry"`
+	MaxBackoff       config.Duration `json:"max-backoff" pflag:",Max backoff between retries of a blob storage call"`
+	Timeout          config.Duration `json:"timeout" pflag:",Timeout of every attempt of a blob storage call. 0 disables the timeout."`
+	FailureThreshold int             `json:"failure-threshold" pflag:",Number of consecutive failed blob storage calls that opens the circuit breaker"`
+	Cooldown         config.Duration `json:"cooldown" pflag:",Time the circuit breaker stays open before blob storage calls are attempted again"`
+}
+
+// ContentAddressedConfig configures writing the outputs of nodes to content-addressed paths, named by the hash of their
+// literal map, and a small pointer object to the conventional outputs path of the node. Identical outputs, e.g. of
+// retried attempts, cache hits or subworkflows that pass the outputs of their nodes through, are then stored once.
+// Only propeller follows the pointers, so it requires events to carry the outputs inline, without falling back to
+// references, and other readers of the outputs files are not supported.
+//
+// Propeller never deletes content-addressed outputs. They're grouped by the deduplication window they were first written
+// in, and outputs are only deduplicated within a window, so a lifecycle rule of the blob store that expires the objects
+// under the prefix once they're older than the retention of the metadata plus the deduplication window never removes
+// outputs that are still pointed to.
+type ContentAddressedConfig struct {
+	Enabled             bool            `json:"enabled" pflag:",Enables writing the outputs of nodes to content-addressed paths"`
+	Prefix              string          `json:"prefix" pflag:",Prefix of the content-addressed outputs. Defaults to content-addressed/ under the base container of the metadata store."`
+	DeduplicationWindow config.Duration `json:"deduplication-window" pflag:",Period within which identical outputs share a content-addressed object"`
+}
+
+// RoundBudgetConfig caps the blob storage reads and kube writes of a single evaluation round of a workflow. Once either
+// is used up, the round yields and the workflow is re-enqueued, so that a single enormous workflow can't monopolize the
+// shared client rate limits and a worker. Nodes that are already being handled complete their step, so the caps are
+// soft.
+type RoundBudgetConfig struct {
+	MaxBlobReads  int64 `json:"max-blob-reads" pflag:",Max number of blob storage reads of a round of a workflow before it yields. 0 disables the cap."`
+	MaxKubeWrites int64 `json:"max-kube-writes" pflag:",Max number of writes to the kube api of a round of a workflow before it yields. 0 disables the cap."`
+}
+
+// ClockConfig configures the clock the node executor, its timeout checks and back-offs read the time from. Freezing the
+// clock makes the evaluation of workflows deterministic, which is meant for replaying and simulating workflows and must
+// not be used for production workloads, as timeouts never expire and back-offs never elapse on a frozen clock.
+type ClockConfig struct {
+	FrozenAt string `json:"frozen-at" pflag:",Freezes the clock at the given RFC3339 time, e.g. 2021-06-01T15:04:05Z. Empty uses the real clock."`
+}
+
+// WorkflowProgressConfig configures emitting WorkflowProgressEvents, which roll up the phases of the nodes of running
+// workflows, to the event sinks. Progress events are emitted after rounds that changed the workflow, at most once per
+// interval for every workflow.
+type WorkflowProgressConfig struct {
+	Enabled  bool            `json:"enabled" pflag:",Enables emitting events with the progress of running workflows."`
+	Interval config.Duration `json:"interval" pflag:",Min interval between two progress events of a workflow."`
+}
+
+// CircuitBreakerConfig configures pausing the launch plans of a namespace whose workflows failed repeatedly within a
+// short window, e.g. because they reference a bad image. New workflows of a paused launch plan are held in the Queued
+// phase until the pause ends, so that crash-looping submissions do not take up the shared capacity.
+type CircuitBreakerConfig struct {
+	Enabled          bool            `json:"enabled" pflag:",Enables pausing launch plans whose workflows fail repeatedly."`
+	FailureThreshold int             `json:"failure-threshold" pflag:",Number of failed workflows of a launch plan within the window that pauses it."`
+	Window           config.Duration `json:"window" pflag:",Window within which the failed workflows of a launch plan are counted."`
+	PauseDuration    config.Duration `json:"pause-duration" pflag:",Time new workflows of a paused launch plan are held back for."`
+}
+
+// MaintenanceConfig configures the maintenance mode of propeller. In maintenance mode, propeller keeps evaluating
+// workflows and tracking running tasks, but holds back tasks that would launch new pods, so that the cluster can be
+// drained safely without aborting workflows. Besides this config, the maintenance mode is enabled by setting the
+// flyte.org/maintenance annotation to true on the namespace propeller runs in.
+type MaintenanceConfig struct {
+	Enabled  bool            `json:"enabled" pflag:",Puts propeller in maintenance mode, regardless of the annotation of its namespace."`
+	Interval config.Duration `json:"interval" pflag:",Frequency of checking the maintenance annotation of the namespace of propeller. 0 disables checking the annotation."`
+}
+
+// NodeOutcomesConfig configures recording the outcomes of the top-level nodes of executions once they terminate. A
+// relaunch of a failed execution that sets the flyte.org/reuse-results-of annotation to the name of the failed execution
+// reuses the outputs of its succeeded nodes, as long as they are called with the same inputs, instead of running them
+// again.
+type NodeOutcomesConfig struct {
+	Enabled bool `json:"enabled" pflag:",Enables recording the outcomes of the nodes of terminated executions and reusing them in relaunches."`
+}
+
+// WorkflowConcurrencyLimit caps the number of concurrently running workflows of a namespace, a launch plan or a launch
+// plan in a namespace
+type WorkflowConcurrencyLimit struct {
+	Namespace  string `json:"namespace,omitempty" pflag:",Namespace the limit applies to. Empty matches all namespaces"`
+	LaunchPlan string `json:"launch-plan,omitempty" pflag:",Name of the launch plan the limit applies to. Empty limits the namespace as a whole"`
+	Limit      int    `json:"limit" pflag:",Maximum number of concurrently running workflows"`
+}
+
 // Defines how output data should be passed along in execution events.
 type RawOutputPolicy = string
 
@@ -239,9 +662,23 @@ const (
 	RawOutputPolicyInline RawOutputPolicy = "inline"
 )
 
+// Defines which node and task events of an execution are recorded. Workflow events are always recorded.
+type EventQoS = string
+
+const (
+	// Record all node and task events.
+	EventQoSAll EventQoS = "ALL"
+	// Record only the node and task events of terminal phases, along with all events of parent nodes that the events
+	// of their child nodes refer to.
+	EventQoSTerminalOnly EventQoS = "TERMINAL_ONLY"
+	// Record no node or task events.
+	EventQoSNone EventQoS = "NONE"
+)
+
 type EventConfig struct {
 	RawOutputPolicy           RawOutputPolicy `json:"raw-output-policy" pflag:",How output data should be passed along in execution events."`
 	FallbackToOutputReference bool            `json:"fallback-to-output-reference" pflag:",Whether output data should be sent by reference when it is too large to be sent inline in execution events."`
+	QoS                       EventQoS        `json:"qos" pflag:",Which node and task events are recorded, one of ALL, TERMINAL_ONLY or NONE. Executions may override it."`
 }
 
 // GetConfig extracts the Configuration from the global config module in flytestdlib and returns the corresponding type-casted object.
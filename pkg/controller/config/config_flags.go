@@ -72,9 +72,13 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "queue.sub-queue.capacity"), defaultConfig.Queue.Sub.Capacity, "Bucket capacity as number of items")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "queue.batching-interval"), defaultConfig.Queue.BatchingInterval.String(), "Duration for which downstream updates are buffered")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "queue.batch-size"), defaultConfig.Queue.BatchSize, "Number of downstream triggered top-level objects to re-enqueue every duration. -1 indicates all available.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "queue.fair.enabled"), defaultConfig.Queue.Fair.Enabled, "Serve the workflows of all namespaces fairly from the WorkQueue")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "queue.fair.max-inflight-per-namespace"), defaultConfig.Queue.Fair.MaxInflightPerNamespace, "Max number of workflows of a namespace that are evaluated concurrently. 0 leaves them uncapped.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "queue.fair.starvation-threshold"), defaultConfig.Queue.Fair.StarvationThreshold.String(), "Workflows that wait longer than this in the WorkQueue are counted as starved")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "metrics-prefix"), defaultConfig.MetricsPrefix, "An optional prefix for all published metrics.")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "enable-admin-launcher"), defaultConfig.EnableAdminLauncher, "")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-workflow-retries"), defaultConfig.MaxWorkflowRetries, "")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-workflow-panics"), defaultConfig.MaxWorkflowPanics, "Number of rounds of a workflow that may panic before the workflow is quarantined and no longer evaluated, until the quarantined label is removed. 0 disables quarantining.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-ttl-hours"), defaultConfig.MaxTTLInHours, "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "gc-interval"), defaultConfig.GCInterval.String(), "")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "leader-election.enabled"), defaultConfig.LeaderElection.Enabled, "Enables/Disables leader election.")
@@ -92,9 +96,36 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "node-config.default-deadlines.workflow-active-deadline"), defaultConfig.NodeConfig.DefaultDeadlines.DefaultWorkflowActiveDeadline.String(), "Default value of workflow timeout")
 	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "node-config.max-node-retries-system-failures"), defaultConfig.NodeConfig.MaxNodeRetriesOnSystemFailures, "Maximum number of retries per node for node failure due to infra issues")
 	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "node-config.interruptible-failure-threshold"), defaultConfig.NodeConfig.InterruptibleFailureThreshold, "number of failures for a node to be still considered interruptible'")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.max-evaluation-parallelism"), defaultConfig.NodeConfig.MaxEvaluationParallelism, "Maximum number of ready nodes of a workflow that are evaluated concurrently within a round. 1 evaluates nodes serially")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "node-config.literal-offloading.enabled"), defaultConfig.NodeConfig.LiteralOffloading.Enabled, "Enables offloading literals that exceed the max size to blob storage")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "node-config.literal-offloading.max-size-bytes"), defaultConfig.NodeConfig.LiteralOffloading.MaxSizeBytes, "Size in bytes of the serialized inputs of a node above which its largest literals are offloaded")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "node-config.parallelism-budget.sub-workflow-weight"), defaultConfig.NodeConfig.ParallelismBudget.SubWorkflowWeight, "Share of the remaining parallelism the nodes of a sub workflow may use")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "node-config.parallelism-budget.dynamic-weight"), defaultConfig.NodeConfig.ParallelismBudget.DynamicWeight, "Share of the remaining parallelism the nodes of a dynamic workflow may use")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "node-config.parallelism-budget.array-weight"), defaultConfig.NodeConfig.ParallelismBudget.ArrayWeight, "Share of the remaining parallelism the sub nodes of an array node may use")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "node-config.input-prefetch.enabled"), defaultConfig.NodeConfig.InputPrefetch.Enabled, "Enables prefetching the inputs of nodes that become ready in the next round")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.input-prefetch.cache-size"), defaultConfig.NodeConfig.InputPrefetch.CacheSize, "Number of prefetched node outputs kept in memory, across all workflows")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "node-config.input-prefetch.ttl"), defaultConfig.NodeConfig.InputPrefetch.TTL.String(), "Time prefetched node outputs are kept in memory")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.input-prefetch.max-concurrency"), defaultConfig.NodeConfig.InputPrefetch.MaxConcurrency, "Maximum number of node outputs read concurrently, further prefetches are dropped")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "node-config.system-retry-backoff.enabled"), defaultConfig.NodeConfig.SystemRetryBackoff.Enabled, "Enables backing off between the attempts of nodes that failed with system errors")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "node-config.system-retry-backoff.base-delay"), defaultConfig.NodeConfig.SystemRetryBackoff.BaseDelay.String(), "Delay of the first retry of a node that failed with a system error")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.system-retry-backoff.multiplier"), defaultConfig.NodeConfig.SystemRetryBackoff.Multiplier, "Factor every subsequent delay is scaled by")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "node-config.system-retry-backoff.max-delay"), defaultConfig.NodeConfig.SystemRetryBackoff.MaxDelay.String(), "Cap of the delay between attempts")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "node-config.system-retry-backoff.jitter-factor"), defaultConfig.NodeConfig.SystemRetryBackoff.JitterFactor, "Share of every delay that is added to it at random")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "node-config.system-error-codes"), defaultConfig.NodeConfig.SystemErrorCodes, "Error codes of failures that count against the system retries of nodes instead of their user retries, whatever kind they were reported with. E.g. ImagePullBackOff")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "node-config.caches.binding-plans-max-size-bytes"), defaultConfig.NodeConfig.Caches.BindingPlansMaxSizeBytes, "Max estimated size in bytes of the cached binding plans of end nodes, across all workflows")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "node-config.caches.task-templates-max-size-bytes"), defaultConfig.NodeConfig.Caches.TaskTemplatesMaxSizeBytes, "Max estimated size in bytes of the cached task templates read from the DataStore")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "node-config.identity.iam-role-annotation"), defaultConfig.NodeConfig.Identity.IamRoleAnnotation, "Annotation set to the IAM role of the node on its pods, e.g. iam.amazonaws.com/role. The IAM role is not set on pods if empty")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "node-config.identity.allowed-service-accounts"), defaultConfig.NodeConfig.Identity.AllowedServiceAccounts, "Service accounts nodes may run as")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "node-config.identity.allowed-iam-roles"), defaultConfig.NodeConfig.Identity.AllowedIamRoles, "IAM roles nodes may run as")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.raw-output.shard-prefix-length"), defaultConfig.NodeConfig.RawOutput.ShardPrefixLength, "Number of base36 characters, between 1 and 3, of the hash prefixes raw outputs are sharded by")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "node-config.raw-output.per-execution-directory"), defaultConfig.NodeConfig.RawOutput.PerExecutionDirectory, "Writes the raw outputs of every execution to a directory named after the execution under the raw output data prefix")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.abort.max-depth"), defaultConfig.NodeConfig.Abort.MaxDepth, "Maximum number of nested sub workflows an abort is propagated through. 0 does not limit the depth")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "node-config.abort.parallelism"), defaultConfig.NodeConfig.Abort.Parallelism, "Maximum number of downstream nodes aborted concurrently. 1 aborts nodes serially")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "node-config.abort.timeout"), defaultConfig.NodeConfig.Abort.Timeout.String(), "Time the abort of a workflow, including its sub workflows and child executions, may take within a round. 0 disables the timeout")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "max-streak-length"), defaultConfig.MaxStreakLength, "Maximum number of consecutive rounds that one propeller worker can use for one workflow - >1 => turbo-mode is enabled.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "event-config.raw-output-policy"), defaultConfig.EventConfig.RawOutputPolicy, "How output data should be passed along in execution events.")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "event-config.fallback-to-output-reference"), defaultConfig.EventConfig.FallbackToOutputReference, "Whether output data should be sent by reference when it is too large to be sent inline in execution events.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "event-config.qos"), defaultConfig.EventConfig.QoS, "Which node and task events are recorded, one of ALL, TERMINAL_ONLY or NONE. Executions may override it.")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "include-shard-key-label"), defaultConfig.IncludeShardKeyLabel, "Include the specified shard key label in the k8s FlyteWorkflow CRD label selector")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "exclude-shard-key-label"), defaultConfig.ExcludeShardKeyLabel, "Exclude the specified shard key label from the k8s FlyteWorkflow CRD label selector")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "include-project-label"), defaultConfig.IncludeProjectLabel, "Include the specified project label in the k8s FlyteWorkflow CRD label selector")
@@ -102,6 +133,60 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "include-domain-label"), defaultConfig.IncludeDomainLabel, "Include the specified domain label in the k8s FlyteWorkflow CRD label selector")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "exclude-domain-label"), defaultConfig.ExcludeDomainLabel, "Exclude the specified domain label from the k8s FlyteWorkflow CRD label selector")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "cluster-id"), defaultConfig.ClusterID, "Unique cluster id running this flytepropeller instance with which to annotate execution events")
-	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "create-flyteworkflow-crd"), defaultConfig.CreateFlyteWorkflowCRD, "Enable creation of the FlyteWorkflow CRD on startup")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "create-flyteworkflow-crd"), defaultConfig.CreateFlyteWorkflowCRD, "Enable creation of the FlyteWorkflow and Signal CRDs on startup")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "workflow-concurrency.default-namespace-limit"), defaultConfig.WorkflowConcurrency.DefaultNamespaceLimit, "Maximum number of concurrently running workflows per namespace. 0 means unlimited.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "batch-abort.enabled"), defaultConfig.BatchAbort.Enabled, "Enables aborting the executions matching the abort selector annotation of their namespace.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "batch-abort.interval"), defaultConfig.BatchAbort.Interval.String(), "Frequency of checking namespaces for abort selector annotations.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "batch-abort.rate"), defaultConfig.BatchAbort.Rate, "Max number of executions aborted per second.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "evaluation-cache.enabled"), defaultConfig.EvaluationCache.Enabled, "Enables caching the immutable sections of workflows across rounds.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "evaluation-cache.size"), defaultConfig.EvaluationCache.Size, "Max number of workflows whose immutable sections are cached.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "evaluation-cache.max-size-bytes"), defaultConfig.EvaluationCache.MaxSizeBytes, "Max estimated size in bytes of the cached sections of all workflows. 0 bounds the cache by its number of workflows only.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "evaluation-cache.ttl"), defaultConfig.EvaluationCache.TTL.String(), "Duration after which the cached sections of a workflow that is no longer evaluated are evicted.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "leak-detection.enabled"), defaultConfig.LeakDetection.Enabled, "Enables periodically scanning for leaked executions and resources.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "leak-detection.interval"), defaultConfig.LeakDetection.Interval.String(), "Frequency of scanning for leaked executions and resources.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "leak-detection.terminated-ttl"), defaultConfig.LeakDetection.TerminatedTTL.String(), "Duration after which terminated executions that still exist are reported as not garbage collected.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "leak-detection.inactivity-timeout"), defaultConfig.LeakDetection.InactivityTimeout.String(), "Duration after which terminated or deleted executions that still have a finalizer are reported as leaking it.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "watch-health.enabled"), defaultConfig.WatchHealth.Enabled, "Enables detecting and recovering from a stale FlyteWorkflow informer cache.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "watch-health.interval"), defaultConfig.WatchHealth.Interval.String(), "Frequency of checking whether the informer cache is stale.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "watch-health.staleness-threshold"), defaultConfig.WatchHealth.StalenessThreshold.String(), "Duration without events after which workflows of the apiserver are compared to the ones of the informer cache.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "watch-health.sample-size"), defaultConfig.WatchHealth.SampleSize, "Number of workflows listed from the apiserver to compare to the ones of the informer cache.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "verbose-tracing.enabled"), defaultConfig.VerboseTracing.Enabled, "Enables tracing workflows annotated with flyte.org/trace-until.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "verbose-tracing.rate"), defaultConfig.VerboseTracing.Rate, "Number of trace lines per second logged across all traced workflows.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "verbose-tracing.burst"), defaultConfig.VerboseTracing.Burst, "Maximum number of trace lines logged at once across all traced workflows.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "ttl-gc.enabled"), defaultConfig.TTLGarbageCollector.Enabled, "Enables deleting terminated workflows once they outlived their TTL.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "ttl-gc.interval"), defaultConfig.TTLGarbageCollector.Interval.String(), "Frequency of scanning for terminated workflows that outlived their TTL.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "ttl-gc.success-ttl"), defaultConfig.TTLGarbageCollector.SuccessTTL.String(), "Duration after which workflows that succeeded are deleted. 0 keeps them.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "ttl-gc.failure-ttl"), defaultConfig.TTLGarbageCollector.FailureTTL.String(), "Duration after which workflows that failed or were aborted are deleted. 0 keeps them.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "ttl-gc.rate"), defaultConfig.TTLGarbageCollector.Rate, "Max number of workflows deleted per second.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "ttl-gc.archive-prefix"), defaultConfig.TTLGarbageCollector.ArchivePrefix, "If set, workflows are archived under this prefix of the data store, and indexed, before they are deleted.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "round-budget.max-blob-reads"), defaultConfig.RoundBudget.MaxBlobReads, "Max number of blob storage reads of a round of a workflow before it yields. 0 disables the cap.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "round-budget.max-kube-writes"), defaultConfig.RoundBudget.MaxKubeWrites, "Max number of writes to the kube api of a round of a workflow before it yields. 0 disables the cap.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "clock.frozen-at"), defaultConfig.Clock.FrozenAt, "Freezes the clock at the given RFC3339 time, e.g. 2021-06-01T15:04:05Z. Empty uses the real clock.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "workflow-progress.enabled"), defaultConfig.WorkflowProgress.Enabled, "Enables emitting events with the progress of running workflows.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "workflow-progress.interval"), defaultConfig.WorkflowProgress.Interval.String(), "Min interval between two progress events of a workflow.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "circuit-breaker.enabled"), defaultConfig.CircuitBreaker.Enabled, "Enables pausing launch plans whose workflows fail repeatedly.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "circuit-breaker.failure-threshold"), defaultConfig.CircuitBreaker.FailureThreshold, "Number of failed workflows of a launch plan within the window that pauses it.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "circuit-breaker.window"), defaultConfig.CircuitBreaker.Window.String(), "Window within which the failed workflows of a launch plan are counted.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "circuit-breaker.pause-duration"), defaultConfig.CircuitBreaker.PauseDuration.String(), "Time new workflows of a paused launch plan are held back for.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "maintenance.enabled"), defaultConfig.Maintenance.Enabled, "Puts propeller in maintenance mode, regardless of the annotation of its namespace.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "maintenance.interval"), defaultConfig.Maintenance.Interval.String(), "Frequency of checking the maintenance annotation of the namespace of propeller. 0 disables checking the annotation.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "node-outcomes.enabled"), defaultConfig.NodeOutcomes.Enabled, "Enables recording the outcomes of the nodes of terminated executions and reusing them in relaunches.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "open-telemetry.enabled"), defaultConfig.OpenTelemetry.Enabled, "Enables exporting OpenTelemetry spans of the evaluation rounds of workflows")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "open-telemetry.endpoint"), defaultConfig.OpenTelemetry.Endpoint, "Address of the OTLP gRPC collector spans are exported to")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "open-telemetry.insecure"), defaultConfig.OpenTelemetry.Insecure, "Exports spans to the collector without TLS")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "open-telemetry.sampling-ratio"), defaultConfig.OpenTelemetry.SamplingRatio, "Share of the evaluation rounds that are traced, between 0 and 1")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "phase-metrics.enabled"), defaultConfig.PhaseMetrics.Enabled, "Enables recording the time workflows and nodes spend in each of their phases")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "data-planes.enabled"), defaultConfig.DataPlanes.Enabled, "Enables routing the pods and CRDs of tasks to remote data plane clusters")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "data-planes.health-check-interval"), defaultConfig.DataPlanes.HealthCheckInterval.String(), "Interval the health of the data plane clusters is checked at. New executions are not pinned to unhealthy clusters")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "store-resilience.enabled"), defaultConfig.StoreResilience.Enabled, "Enables retrying the blob storage calls of the node executor and the circuit breaker")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "store-resilience.max-retries"), defaultConfig.StoreResilience.MaxRetries, "Max number of times a failed blob storage call is retried")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "store-resilience.initial-backoff"), defaultConfig.StoreResilience.InitialBackoff.String(), "Backoff before the first retry of a blob storage call, doubled on every retry")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "store-resilience.max-backoff"), defaultConfig.StoreResilience.MaxBackoff.String(), "Max backoff between retries of a blob storage call")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "store-resilience.timeout"), defaultConfig.StoreResilience.Timeout.String(), "Timeout of every attempt of a blob storage call. 0 disables the timeout.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "store-resilience.failure-threshold"), defaultConfig.StoreResilience.FailureThreshold, "Number of consecutive failed blob storage calls that opens the circuit breaker")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "store-resilience.cooldown"), defaultConfig.StoreResilience.Cooldown.String(), "Time the circuit breaker stays open before blob storage calls are attempted again")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "content-addressed.enabled"), defaultConfig.ContentAddressed.Enabled, "Enables writing the outputs of nodes to content-addressed paths")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "content-addressed.prefix"), defaultConfig.ContentAddressed.Prefix, "Prefix of the content-addressed outputs. Defaults to content-addressed/ under the base container of the metadata store.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "content-addressed.deduplication-window"), defaultConfig.ContentAddressed.DeduplicationWindow.String(), "Period within which identical outputs share a content-addressed object")
 	return cmdFlags
 }
@@ -449,6 +449,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_max-workflow-panics", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("max-workflow-panics", testValue)
+			if vInt, err := cmdFlags.GetInt("max-workflow-panics"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.MaxWorkflowPanics)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_max-ttl-hours", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -687,6 +701,230 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_node-config.max-evaluation-parallelism", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.max-evaluation-parallelism", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.max-evaluation-parallelism"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.MaxEvaluationParallelism)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.literal-offloading.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.literal-offloading.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("node-config.literal-offloading.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.NodeConfig.LiteralOffloading.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.literal-offloading.max-size-bytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.literal-offloading.max-size-bytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("node-config.literal-offloading.max-size-bytes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.NodeConfig.LiteralOffloading.MaxSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.parallelism-budget.sub-workflow-weight", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.parallelism-budget.sub-workflow-weight", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("node-config.parallelism-budget.sub-workflow-weight"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.NodeConfig.ParallelismBudget.SubWorkflowWeight)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.parallelism-budget.dynamic-weight", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.parallelism-budget.dynamic-weight", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("node-config.parallelism-budget.dynamic-weight"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.NodeConfig.ParallelismBudget.DynamicWeight)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.parallelism-budget.array-weight", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.parallelism-budget.array-weight", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("node-config.parallelism-budget.array-weight"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.NodeConfig.ParallelismBudget.ArrayWeight)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.input-prefetch.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.input-prefetch.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("node-config.input-prefetch.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.NodeConfig.InputPrefetch.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.input-prefetch.cache-size", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.input-prefetch.cache-size", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.input-prefetch.cache-size"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.InputPrefetch.CacheSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.input-prefetch.ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.NodeConfig.InputPrefetch.TTL.String()
+
+			cmdFlags.Set("node-config.input-prefetch.ttl", testValue)
+			if vString, err := cmdFlags.GetString("node-config.input-prefetch.ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.NodeConfig.InputPrefetch.TTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.input-prefetch.max-concurrency", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.input-prefetch.max-concurrency", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.input-prefetch.max-concurrency"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.InputPrefetch.MaxConcurrency)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.system-retry-backoff.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.system-retry-backoff.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("node-config.system-retry-backoff.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.NodeConfig.SystemRetryBackoff.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.system-retry-backoff.base-delay", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.NodeConfig.SystemRetryBackoff.BaseDelay.String()
+
+			cmdFlags.Set("node-config.system-retry-backoff.base-delay", testValue)
+			if vString, err := cmdFlags.GetString("node-config.system-retry-backoff.base-delay"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.NodeConfig.SystemRetryBackoff.BaseDelay)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.system-retry-backoff.multiplier", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.system-retry-backoff.multiplier", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.system-retry-backoff.multiplier"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.SystemRetryBackoff.Multiplier)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.system-retry-backoff.max-delay", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.NodeConfig.SystemRetryBackoff.MaxDelay.String()
+
+			cmdFlags.Set("node-config.system-retry-backoff.max-delay", testValue)
+			if vString, err := cmdFlags.GetString("node-config.system-retry-backoff.max-delay"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.NodeConfig.SystemRetryBackoff.MaxDelay)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.system-retry-backoff.jitter-factor", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.system-retry-backoff.jitter-factor", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("node-config.system-retry-backoff.jitter-factor"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.NodeConfig.SystemRetryBackoff.JitterFactor)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.system-error-codes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(defaultConfig.NodeConfig.SystemErrorCodes, ",")
+
+			cmdFlags.Set("node-config.system-error-codes", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("node-config.system-error-codes"); err == nil {
+				testDecodeJson_Config(t, join_Config(vStringSlice, ","), &actual.NodeConfig.SystemErrorCodes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_max-streak-length", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -841,4 +1079,956 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_workflow-concurrency.default-namespace-limit", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("workflow-concurrency.default-namespace-limit", testValue)
+			if vInt, err := cmdFlags.GetInt("workflow-concurrency.default-namespace-limit"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.WorkflowConcurrency.DefaultNamespaceLimit)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_batch-abort.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("batch-abort.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("batch-abort.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.BatchAbort.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_batch-abort.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.BatchAbort.Interval.String()
+
+			cmdFlags.Set("batch-abort.interval", testValue)
+			if vString, err := cmdFlags.GetString("batch-abort.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.BatchAbort.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_batch-abort.rate", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("batch-abort.rate", testValue)
+			if vInt64, err := cmdFlags.GetInt64("batch-abort.rate"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.BatchAbort.Rate)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_evaluation-cache.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("evaluation-cache.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("evaluation-cache.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.EvaluationCache.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_evaluation-cache.size", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("evaluation-cache.size", testValue)
+			if vInt, err := cmdFlags.GetInt("evaluation-cache.size"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.EvaluationCache.Size)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_evaluation-cache.ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.EvaluationCache.TTL.String()
+
+			cmdFlags.Set("evaluation-cache.ttl", testValue)
+			if vString, err := cmdFlags.GetString("evaluation-cache.ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.EvaluationCache.TTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_leak-detection.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("leak-detection.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("leak-detection.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LeakDetection.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_leak-detection.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.LeakDetection.Interval.String()
+
+			cmdFlags.Set("leak-detection.interval", testValue)
+			if vString, err := cmdFlags.GetString("leak-detection.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LeakDetection.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_leak-detection.terminated-ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.LeakDetection.TerminatedTTL.String()
+
+			cmdFlags.Set("leak-detection.terminated-ttl", testValue)
+			if vString, err := cmdFlags.GetString("leak-detection.terminated-ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LeakDetection.TerminatedTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_leak-detection.inactivity-timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.LeakDetection.InactivityTimeout.String()
+
+			cmdFlags.Set("leak-detection.inactivity-timeout", testValue)
+			if vString, err := cmdFlags.GetString("leak-detection.inactivity-timeout"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LeakDetection.InactivityTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_watch-health.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("watch-health.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("watch-health.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.WatchHealth.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_watch-health.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WatchHealth.Interval.String()
+
+			cmdFlags.Set("watch-health.interval", testValue)
+			if vString, err := cmdFlags.GetString("watch-health.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WatchHealth.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_watch-health.staleness-threshold", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WatchHealth.StalenessThreshold.String()
+
+			cmdFlags.Set("watch-health.staleness-threshold", testValue)
+			if vString, err := cmdFlags.GetString("watch-health.staleness-threshold"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WatchHealth.StalenessThreshold)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_watch-health.sample-size", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("watch-health.sample-size", testValue)
+			if vInt64, err := cmdFlags.GetInt64("watch-health.sample-size"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.WatchHealth.SampleSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_verbose-tracing.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("verbose-tracing.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("verbose-tracing.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.VerboseTracing.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_verbose-tracing.rate", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("verbose-tracing.rate", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("verbose-tracing.rate"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.VerboseTracing.Rate)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_verbose-tracing.burst", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("verbose-tracing.burst", testValue)
+			if vInt, err := cmdFlags.GetInt("verbose-tracing.burst"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.VerboseTracing.Burst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ttl-gc.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ttl-gc.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("ttl-gc.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.TTLGarbageCollector.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ttl-gc.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.TTLGarbageCollector.Interval.String()
+
+			cmdFlags.Set("ttl-gc.interval", testValue)
+			if vString, err := cmdFlags.GetString("ttl-gc.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TTLGarbageCollector.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ttl-gc.success-ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.TTLGarbageCollector.SuccessTTL.String()
+
+			cmdFlags.Set("ttl-gc.success-ttl", testValue)
+			if vString, err := cmdFlags.GetString("ttl-gc.success-ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TTLGarbageCollector.SuccessTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ttl-gc.failure-ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.TTLGarbageCollector.FailureTTL.String()
+
+			cmdFlags.Set("ttl-gc.failure-ttl", testValue)
+			if vString, err := cmdFlags.GetString("ttl-gc.failure-ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TTLGarbageCollector.FailureTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ttl-gc.rate", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ttl-gc.rate", testValue)
+			if vInt64, err := cmdFlags.GetInt64("ttl-gc.rate"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.TTLGarbageCollector.Rate)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ttl-gc.archive-prefix", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ttl-gc.archive-prefix", testValue)
+			if vString, err := cmdFlags.GetString("ttl-gc.archive-prefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TTLGarbageCollector.ArchivePrefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_round-budget.max-blob-reads", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("round-budget.max-blob-reads", testValue)
+			if vInt64, err := cmdFlags.GetInt64("round-budget.max-blob-reads"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.RoundBudget.MaxBlobReads)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_round-budget.max-kube-writes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("round-budget.max-kube-writes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("round-budget.max-kube-writes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.RoundBudget.MaxKubeWrites)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_clock.frozen-at", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("clock.frozen-at", testValue)
+			if vString, err := cmdFlags.GetString("clock.frozen-at"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Clock.FrozenAt)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_workflow-progress.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("workflow-progress.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("workflow-progress.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.WorkflowProgress.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_workflow-progress.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WorkflowProgress.Interval.String()
+
+			cmdFlags.Set("workflow-progress.interval", testValue)
+			if vString, err := cmdFlags.GetString("workflow-progress.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WorkflowProgress.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuit-breaker.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuit-breaker.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("circuit-breaker.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.CircuitBreaker.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuit-breaker.failure-threshold", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuit-breaker.failure-threshold", testValue)
+			if vInt, err := cmdFlags.GetInt("circuit-breaker.failure-threshold"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.CircuitBreaker.FailureThreshold)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuit-breaker.window", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.CircuitBreaker.Window.String()
+
+			cmdFlags.Set("circuit-breaker.window", testValue)
+			if vString, err := cmdFlags.GetString("circuit-breaker.window"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CircuitBreaker.Window)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuit-breaker.pause-duration", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.CircuitBreaker.PauseDuration.String()
+
+			cmdFlags.Set("circuit-breaker.pause-duration", testValue)
+			if vString, err := cmdFlags.GetString("circuit-breaker.pause-duration"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CircuitBreaker.PauseDuration)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_maintenance.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("maintenance.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("maintenance.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Maintenance.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_maintenance.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Maintenance.Interval.String()
+
+			cmdFlags.Set("maintenance.interval", testValue)
+			if vString, err := cmdFlags.GetString("maintenance.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Maintenance.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-outcomes.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-outcomes.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("node-outcomes.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.NodeOutcomes.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.caches.binding-plans-max-size-bytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.caches.binding-plans-max-size-bytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("node-config.caches.binding-plans-max-size-bytes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.NodeConfig.Caches.BindingPlansMaxSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.caches.task-templates-max-size-bytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.caches.task-templates-max-size-bytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("node-config.caches.task-templates-max-size-bytes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.NodeConfig.Caches.TaskTemplatesMaxSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_evaluation-cache.max-size-bytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("evaluation-cache.max-size-bytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("evaluation-cache.max-size-bytes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.EvaluationCache.MaxSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.identity.iam-role-annotation", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.identity.iam-role-annotation", testValue)
+			if vString, err := cmdFlags.GetString("node-config.identity.iam-role-annotation"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.NodeConfig.Identity.IamRoleAnnotation)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.identity.allowed-service-accounts", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(defaultConfig.NodeConfig.Identity.AllowedServiceAccounts, ",")
+
+			cmdFlags.Set("node-config.identity.allowed-service-accounts", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("node-config.identity.allowed-service-accounts"); err == nil {
+				testDecodeJson_Config(t, join_Config(vStringSlice, ","), &actual.NodeConfig.Identity.AllowedServiceAccounts)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.identity.allowed-iam-roles", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(defaultConfig.NodeConfig.Identity.AllowedIamRoles, ",")
+
+			cmdFlags.Set("node-config.identity.allowed-iam-roles", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("node-config.identity.allowed-iam-roles"); err == nil {
+				testDecodeJson_Config(t, join_Config(vStringSlice, ","), &actual.NodeConfig.Identity.AllowedIamRoles)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_open-telemetry.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("open-telemetry.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("open-telemetry.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.OpenTelemetry.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_open-telemetry.endpoint", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("open-telemetry.endpoint", testValue)
+			if vString, err := cmdFlags.GetString("open-telemetry.endpoint"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.OpenTelemetry.Endpoint)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_open-telemetry.insecure", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("open-telemetry.insecure", testValue)
+			if vBool, err := cmdFlags.GetBool("open-telemetry.insecure"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.OpenTelemetry.Insecure)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_open-telemetry.sampling-ratio", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("open-telemetry.sampling-ratio", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("open-telemetry.sampling-ratio"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.OpenTelemetry.SamplingRatio)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_phase-metrics.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("phase-metrics.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("phase-metrics.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.PhaseMetrics.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.abort.max-depth", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.abort.max-depth", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.abort.max-depth"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.Abort.MaxDepth)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.abort.parallelism", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.abort.parallelism", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.abort.parallelism"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.Abort.Parallelism)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.abort.timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.NodeConfig.Abort.Timeout.String()
+
+			cmdFlags.Set("node-config.abort.timeout", testValue)
+			if vString, err := cmdFlags.GetString("node-config.abort.timeout"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.NodeConfig.Abort.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_event-config.qos", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("event-config.qos", testValue)
+			if vString, err := cmdFlags.GetString("event-config.qos"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.EventConfig.QoS)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.raw-output.shard-prefix-length", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.raw-output.shard-prefix-length", testValue)
+			if vInt, err := cmdFlags.GetInt("node-config.raw-output.shard-prefix-length"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeConfig.RawOutput.ShardPrefixLength)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_node-config.raw-output.per-execution-directory", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("node-config.raw-output.per-execution-directory", testValue)
+			if vBool, err := cmdFlags.GetBool("node-config.raw-output.per-execution-directory"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.NodeConfig.RawOutput.PerExecutionDirectory)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_data-planes.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("data-planes.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("data-planes.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.DataPlanes.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_data-planes.health-check-interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.DataPlanes.HealthCheckInterval.String()
+
+			cmdFlags.Set("data-planes.health-check-interval", testValue)
+			if vString, err := cmdFlags.GetString("data-planes.health-check-interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.DataPlanes.HealthCheckInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("store-resilience.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("store-resilience.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.StoreResilience.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.max-retries", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("store-resilience.max-retries", testValue)
+			if vInt, err := cmdFlags.GetInt("store-resilience.max-retries"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.StoreResilience.MaxRetries)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.initial-backoff", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StoreResilience.InitialBackoff.String()
+
+			cmdFlags.Set("store-resilience.initial-backoff", testValue)
+			if vString, err := cmdFlags.GetString("store-resilience.initial-backoff"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.StoreResilience.InitialBackoff)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.max-backoff", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StoreResilience.MaxBackoff.String()
+
+			cmdFlags.Set("store-resilience.max-backoff", testValue)
+			if vString, err := cmdFlags.GetString("store-resilience.max-backoff"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.StoreResilience.MaxBackoff)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StoreResilience.Timeout.String()
+
+			cmdFlags.Set("store-resilience.timeout", testValue)
+			if vString, err := cmdFlags.GetString("store-resilience.timeout"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.StoreResilience.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.failure-threshold", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("store-resilience.failure-threshold", testValue)
+			if vInt, err := cmdFlags.GetInt("store-resilience.failure-threshold"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.StoreResilience.FailureThreshold)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_store-resilience.cooldown", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StoreResilience.Cooldown.String()
+
+			cmdFlags.Set("store-resilience.cooldown", testValue)
+			if vString, err := cmdFlags.GetString("store-resilience.cooldown"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.StoreResilience.Cooldown)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_content-addressed.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("content-addressed.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("content-addressed.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.ContentAddressed.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_content-addressed.prefix", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("content-addressed.prefix", testValue)
+			if vString, err := cmdFlags.GetString("content-addressed.prefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ContentAddressed.Prefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_content-addressed.deduplication-window", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ContentAddressed.DeduplicationWindow.String()
+
+			cmdFlags.Set("content-addressed.deduplication-window", testValue)
+			if vString, err := cmdFlags.GetString("content-addressed.deduplication-window"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ContentAddressed.DeduplicationWindow)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_queue.fair.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("queue.fair.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("queue.fair.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Queue.Fair.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_queue.fair.max-inflight-per-namespace", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("queue.fair.max-inflight-per-namespace", testValue)
+			if vInt, err := cmdFlags.GetInt("queue.fair.max-inflight-per-namespace"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.Queue.Fair.MaxInflightPerNamespace)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_queue.fair.starvation-threshold", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Queue.Fair.StarvationThreshold.String()
+
+			cmdFlags.Set("queue.fair.starvation-threshold", testValue)
+			if vString, err := cmdFlags.GetString("queue.fair.starvation-threshold"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Queue.Fair.StarvationThreshold)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
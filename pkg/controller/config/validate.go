@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Validate checks the constraints between fields of the configuration that cannot be expressed through the config
+// schema alone. All violations found are returned.
+func (c Config) Validate() []error {
+	var errs []error
+	if c.Workers <= 0 {
+		errs = append(errs, fmt.Errorf("workers must be greater than 0, found [%d]", c.Workers))
+	}
+
+	if c.WorkflowReEval.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("workflow-reeval-duration must be greater than 0, found [%v]", c.WorkflowReEval.Duration))
+	}
+
+	if c.DownstreamEval.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("downstream-eval-duration must be greater than 0, found [%v]", c.DownstreamEval.Duration))
+	}
+
+	if c.MaxTTLInHours < 1 || c.MaxTTLInHours > 23 {
+		errs = append(errs, fmt.Errorf("max-ttl-hours must be between 1 and 23, found [%d]", c.MaxTTLInHours))
+	}
+
+	if c.KubeConfig.QPS < 0 || c.KubeConfig.Burst < 0 {
+		errs = append(errs, fmt.Errorf("kube-client-config qps and burst must not be negative, found qps [%v] burst [%d]",
+			c.KubeConfig.QPS, c.KubeConfig.Burst))
+	}
+
+	errs = append(errs, c.Queue.Queue.validate("queue.queue")...)
+	errs = append(errs, c.Queue.Sub.validate("queue.sub-queue")...)
+
+	if c.LeaderElection.Enabled {
+		errs = append(errs, c.LeaderElection.validate()...)
+	}
+
+	return errs
+}
+
+func (w WorkqueueConfig) validate(key string) []error {
+	var errs []error
+	switch w.Type {
+	case WorkqueueTypeBucketRateLimiter, WorkqueueTypeMaxOfRateLimiter:
+		if w.Rate <= 0 || w.Capacity <= 0 {
+			errs = append(errs, fmt.Errorf("%s rate and capacity must be greater than 0, found rate [%d] capacity [%d]",
+				key, w.Rate, w.Capacity))
+		}
+	}
+
+	switch w.Type {
+	case WorkqueueTypeExponentialFailureRateLimiter, WorkqueueTypeMaxOfRateLimiter:
+		if w.BaseDelay.Duration <= 0 || w.MaxDelay.Duration < w.BaseDelay.Duration {
+			errs = append(errs, fmt.Errorf("%s base-delay must be greater than 0 and not exceed max-delay, found base-delay [%v] max-delay [%v]",
+				key, w.BaseDelay.Duration, w.MaxDelay.Duration))
+		}
+	}
+
+	return errs
+}
+
+func (l LeaderElectionConfig) validate() []error {
+	var errs []error
+	if len(l.LockConfigMap.Name) == 0 || len(l.LockConfigMap.Namespace) == 0 {
+		errs = append(errs, fmt.Errorf("leader-election lock-config-map namespace and name are required when leader election is enabled"))
+	}
+
+	if l.LeaseDuration.Duration <= l.RenewDeadline.Duration {
+		errs = append(errs, fmt.Errorf("leader-election lease-duration [%v] must be greater than renew-deadline [%v]",
+			l.LeaseDuration.Duration, l.RenewDeadline.Duration))
+	}
+
+	if l.RenewDeadline.Duration <= l.RetryPeriod.Duration {
+		errs = append(errs, fmt.Errorf("leader-election renew-deadline [%v] must be greater than retry-period [%v]",
+			l.RenewDeadline.Duration, l.RetryPeriod.Duration))
+	}
+
+	return errs
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		assert.Empty(t, defaultConfig.Validate())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := *defaultConfig
+		cfg.Workers = 0
+		cfg.MaxTTLInHours = 24
+		cfg.Queue.Queue.Rate = 0
+		cfg.Queue.Queue.BaseDelay = config.Duration{Duration: time.Minute}
+		cfg.Queue.Queue.MaxDelay = config.Duration{Duration: time.Second}
+		assert.Len(t, cfg.Validate(), 4)
+	})
+
+	t.Run("leader election", func(t *testing.T) {
+		cfg := *defaultConfig
+		cfg.LeaderElection.Enabled = true
+		assert.Len(t, cfg.Validate(), 1)
+
+		cfg.LeaderElection.LockConfigMap = types.NamespacedName{Namespace: "flyte", Name: "propeller-leader"}
+		assert.Empty(t, cfg.Validate())
+
+		cfg.LeaderElection.RenewDeadline = cfg.LeaderElection.LeaseDuration
+		assert.Len(t, cfg.Validate(), 1)
+	})
+}
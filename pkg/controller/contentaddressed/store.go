@@ -0,0 +1,173 @@
+// Package contentaddressed stores the outputs of nodes once per content. The literal map written to the outputs file of
+// a node is stored at a path named by its hash, and the outputs file holds a small pointer to it, which the reads of
+// the outputs follow. Identical outputs, e.g. of retried attempts, cache hits or subworkflows that pass the outputs of
+// their nodes through, then share a single object in blob storage.
+//
+// Only the reads through this store follow the pointers, so events have to carry the outputs inline. The objects are
+// grouped by the deduplication window they were first written in and never shared across windows, which lets a
+// lifecycle rule of the blob store expire them once no outputs file can point to them anymore.
+package contentaddressed
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// The key of the literal that holds the content address in the pointer written to outputs files. A pointer is a
+// literal map with this single literal.
+const contentAddressKey = "_content_address"
+
+// The name outputs files end with.
+var outputsFileSuffix = string(v1alpha1.GetOutputsFile(""))
+
+type contentAddressedStore struct {
+	storage.ComposedProtobufStore
+	referenceConstructor storage.ReferenceConstructor
+	prefix               storage.DataReference
+	window               time.Duration
+	clock                clock.Clock
+	written              prometheus.Counter
+	deduplicated         prometheus.Counter
+}
+
+// newPointer returns the pointer to the content-addressed outputs.
+func newPointer(contentRef storage.DataReference) *core.LiteralMap {
+	return &core.LiteralMap{Literals: map[string]*core.Literal{
+		contentAddressKey: {Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_Primitive{
+			Primitive: &core.Primitive{Value: &core.Primitive_StringValue{StringValue: contentRef.String()}},
+		}}}},
+	}}
+}
+
+// contentAddress returns the reference the literal map points to, if it's a pointer.
+func contentAddress(outputs *core.LiteralMap) (storage.DataReference, bool) {
+	if len(outputs.GetLiterals()) != 1 {
+		return "", false
+	}
+
+	contentRef := outputs.GetLiterals()[contentAddressKey].GetScalar().GetPrimitive().GetStringValue()
+	return storage.DataReference(contentRef), len(contentRef) > 0
+}
+
+// WriteProtobuf writes the outputs of nodes to their content-addressed path in the current deduplication window, unless
+// an object with the same content exists there already, and the pointer to it to the outputs file. Any other message is
+// written as is.
+func (s contentAddressedStore) WriteProtobuf(ctx context.Context, reference storage.DataReference, opts storage.Options, msg proto.Message) error {
+	outputs, ok := msg.(*core.LiteralMap)
+	if !ok || len(outputs.GetLiterals()) == 0 || !strings.HasSuffix(reference.String(), outputsFileSuffix) {
+		return s.ComposedProtobufStore.WriteProtobuf(ctx, reference, opts, msg)
+	}
+
+	// The marshalling has to be deterministic for identical outputs to hash the same, maps are not ordered otherwise.
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(outputs); err != nil {
+		return errors.Wrapf(err, "failed to marshal the outputs written to [%v]", reference)
+	}
+
+	raw := b.Bytes()
+	sum := sha256.Sum256(raw)
+	window := strconv.FormatInt(s.clock.Now().Truncate(s.window).Unix(), 10)
+	contentRef, err := s.referenceConstructor.ConstructReference(ctx, s.prefix, window, hex.EncodeToString(sum[:])+".pb")
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct the content-addressed reference of [%v]", reference)
+	}
+
+	metadata, err := s.ComposedProtobufStore.Head(ctx, contentRef)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get metadata of [%v]", contentRef)
+	}
+
+	if metadata.Exists() {
+		s.deduplicated.Inc()
+		logger.Debugf(ctx, "Outputs written to [%v] exist at [%v] already", reference, contentRef)
+	} else {
+		if err := s.ComposedProtobufStore.WriteRaw(ctx, contentRef, int64(len(raw)), opts, bytes.NewReader(raw)); err != nil {
+			return errors.Wrapf(err, "failed to write the content-addressed outputs of [%v] to [%v]", reference, contentRef)
+		}
+
+		s.written.Inc()
+	}
+
+	return s.ComposedProtobufStore.WriteProtobuf(ctx, reference, opts, newPointer(contentRef))
+}
+
+// ReadProtobuf follows the pointer, if the literal map read is one. Since pointers may be copied along with outputs
+// files, e.g. to the outputs of the parent node of a subworkflow, they are followed at any path.
+func (s contentAddressedStore) ReadProtobuf(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+	if err := s.ComposedProtobufStore.ReadProtobuf(ctx, reference, msg); err != nil {
+		return err
+	}
+
+	outputs, ok := msg.(*core.LiteralMap)
+	if !ok {
+		return nil
+	}
+
+	contentRef, ok := contentAddress(outputs)
+	if !ok {
+		return nil
+	}
+
+	outputs.Reset()
+	if err := s.ComposedProtobufStore.ReadProtobuf(ctx, contentRef, outputs); err != nil {
+		return errors.Wrapf(err, "failed to read the content-addressed outputs of [%v] from [%v]", reference, contentRef)
+	}
+
+	return nil
+}
+
+// NewDataStore wraps the store so that the outputs of nodes are stored once per content. If disabled, the store is
+// returned as is. Since only propeller follows the pointers, events have to carry the outputs inline.
+func NewDataStore(ctx context.Context, store *storage.DataStore, cfg config.ContentAddressedConfig, eventConfig *config.EventConfig,
+	scope promutils.Scope) (*storage.DataStore, error) {
+	if !cfg.Enabled {
+		return store, nil
+	}
+
+	if eventConfig.RawOutputPolicy != config.RawOutputPolicyInline || eventConfig.FallbackToOutputReference {
+		return nil, errors.Errorf("content-addressed outputs require the [%v] raw output policy without falling back to references, found [%v]",
+			config.RawOutputPolicyInline, eventConfig.RawOutputPolicy)
+	}
+
+	if cfg.DeduplicationWindow.Duration <= 0 {
+		return nil, errors.Errorf("the deduplication window of content-addressed outputs has to be positive, found [%v]", cfg.DeduplicationWindow.Duration)
+	}
+
+	prefix := storage.DataReference(cfg.Prefix)
+	if len(prefix) == 0 {
+		var err error
+		prefix, err = store.ConstructReference(ctx, store.GetBaseContainerFQN(ctx), "content-addressed")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to construct the prefix of content-addressed outputs")
+		}
+	}
+
+	logger.Infof(ctx, "Writing the outputs of nodes to content-addressed paths under [%v], deduplicated within [%v]", prefix, cfg.DeduplicationWindow.Duration)
+	return storage.NewCompositeDataStore(store.ReferenceConstructor, contentAddressedStore{
+		ComposedProtobufStore: store.ComposedProtobufStore,
+		referenceConstructor:  store.ReferenceConstructor,
+		prefix:                prefix,
+		window:                cfg.DeduplicationWindow.Duration,
+		clock:                 clock.RealClock{},
+		written:               scope.MustNewCounter("written", "Outputs written to a new content-addressed path"),
+		deduplicated:          scope.MustNewCounter("deduplicated", "Outputs whose content-addressed path existed already"),
+	}), nil
+}
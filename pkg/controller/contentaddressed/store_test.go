@@ -0,0 +1,133 @@
+package contentaddressed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+var inlineEvents = &config.EventConfig{RawOutputPolicy: config.RawOutputPolicyInline}
+
+func intOutputs(i int64) *core.LiteralMap {
+	return &core.LiteralMap{Literals: map[string]*core.Literal{
+		"o0": {Value: &core.Literal_Scalar{Scalar: &core.Scalar{
+			Value: &core.Scalar_Primitive{Primitive: &core.Primitive{Value: &core.Primitive_Integer{Integer: i}}},
+		}}},
+	}}
+}
+
+func enabledConfig() config.ContentAddressedConfig {
+	return config.ContentAddressedConfig{
+		Enabled:             true,
+		Prefix:              "s3://bucket/cas",
+		DeduplicationWindow: stdConfig.Duration{Duration: time.Hour},
+	}
+}
+
+func TestNewDataStore_Disabled(t *testing.T) {
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	s, err := NewDataStore(context.TODO(), store, config.ContentAddressedConfig{}, &config.EventConfig{}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.Equal(t, store, s)
+}
+
+func TestNewDataStore_InvalidConfig(t *testing.T) {
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	t.Run("reference events", func(t *testing.T) {
+		_, err := NewDataStore(context.TODO(), store, enabledConfig(), &config.EventConfig{RawOutputPolicy: config.RawOutputPolicyReference}, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("fallback to references", func(t *testing.T) {
+		eventConfig := &config.EventConfig{RawOutputPolicy: config.RawOutputPolicyInline, FallbackToOutputReference: true}
+		_, err := NewDataStore(context.TODO(), store, enabledConfig(), eventConfig, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		cfg := enabledConfig()
+		cfg.DeduplicationWindow.Duration = 0
+		_, err := NewDataStore(context.TODO(), store, cfg, inlineEvents, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+}
+
+func TestNewDataStore(t *testing.T) {
+	ctx := context.TODO()
+	rawStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	store, err := NewDataStore(ctx, rawStore, enabledConfig(), inlineEvents, promutils.NewTestScope())
+	assert.NoError(t, err)
+	cas := store.ComposedProtobufStore.(contentAddressedStore)
+	now := time.Date(2021, 10, 1, 12, 30, 0, 0, time.UTC)
+	clk := clock.NewFakeClock(now)
+	cas.clock = clk
+	store = storage.NewCompositeDataStore(rawStore.ReferenceConstructor, cas)
+
+	t.Run("outputs", func(t *testing.T) {
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/n0/0/outputs.pb", storage.Options{}, intOutputs(1)))
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/n0/1/outputs.pb", storage.Options{}, intOutputs(1)))
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/n1/0/outputs.pb", storage.Options{}, intOutputs(2)))
+		assert.Equal(t, float64(2), testutil.ToFloat64(cas.written))
+		assert.Equal(t, float64(1), testutil.ToFloat64(cas.deduplicated))
+
+		raw, err := proto.Marshal(intOutputs(1))
+		assert.NoError(t, err)
+		sum := sha256.Sum256(raw)
+		window := now.Truncate(time.Hour).Unix()
+		pointer := &core.LiteralMap{}
+		assert.NoError(t, rawStore.ReadProtobuf(ctx, "s3://bucket/n0/1/outputs.pb", pointer))
+		contentRef, ok := contentAddress(pointer)
+		assert.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("s3://bucket/cas/%v/%v.pb", window, hex.EncodeToString(sum[:])), contentRef.String())
+
+		outputs := &core.LiteralMap{}
+		assert.NoError(t, store.ReadProtobuf(ctx, "s3://bucket/n0/1/outputs.pb", outputs))
+		assert.True(t, proto.Equal(intOutputs(1), outputs))
+
+		// Copied pointers are followed too.
+		assert.NoError(t, store.CopyRaw(ctx, "s3://bucket/n1/0/outputs.pb", "s3://bucket/wf/outputs.pb", storage.Options{}))
+		assert.NoError(t, store.ReadProtobuf(ctx, "s3://bucket/wf/outputs.pb", outputs))
+		assert.True(t, proto.Equal(intOutputs(2), outputs))
+	})
+
+	t.Run("next window", func(t *testing.T) {
+		clk.Step(time.Hour)
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/n0/2/outputs.pb", storage.Options{}, intOutputs(1)))
+		assert.Equal(t, float64(3), testutil.ToFloat64(cas.written))
+		assert.Equal(t, float64(1), testutil.ToFloat64(cas.deduplicated))
+
+		pointer := &core.LiteralMap{}
+		assert.NoError(t, rawStore.ReadProtobuf(ctx, "s3://bucket/n0/2/outputs.pb", pointer))
+		contentRef, ok := contentAddress(pointer)
+		assert.True(t, ok)
+		assert.Contains(t, contentRef.String(), fmt.Sprintf("s3://bucket/cas/%v/", now.Add(time.Hour).Truncate(time.Hour).Unix()))
+	})
+
+	t.Run("other messages", func(t *testing.T) {
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/n0/0/inputs.pb", storage.Options{}, intOutputs(3)))
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/n2/0/outputs.pb", storage.Options{}, &core.LiteralMap{}))
+
+		outputs := &core.LiteralMap{}
+		assert.NoError(t, rawStore.ReadProtobuf(ctx, "s3://bucket/n0/0/inputs.pb", outputs))
+		assert.True(t, proto.Equal(intOutputs(3), outputs))
+		assert.Equal(t, float64(3), testutil.ToFloat64(cas.written))
+	})
+}
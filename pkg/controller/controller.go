@@ -12,6 +12,7 @@ import (
 	"github.com/flyteorg/flyteidl/clients/go/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 
+	pluginMachinery "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	flyteK8sConfig "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 
@@ -22,13 +23,20 @@ import (
 	informers "github.com/flyteorg/flytepropeller/pkg/client/informers/externalversions"
 	lister "github.com/flyteorg/flytepropeller/pkg/client/listers/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/archive"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/contentaddressed"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes"
 	errors3 "github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/catalog"
+	"github.com/flyteorg/flytepropeller/pkg/controller/resilientstore"
+	"github.com/flyteorg/flytepropeller/pkg/controller/roundbudget"
+	"github.com/flyteorg/flytepropeller/pkg/controller/storagerouter"
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 	"github.com/flyteorg/flytepropeller/pkg/controller/workflow"
 	"github.com/flyteorg/flytepropeller/pkg/controller/workflowstore"
 	leader "github.com/flyteorg/flytepropeller/pkg/leaderelection"
@@ -47,6 +55,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -60,6 +69,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/record"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -68,6 +78,7 @@ const (
 	missing                           = "missing"
 	podDefaultNamespace               = "flyte"
 	podNamespaceEnvVar                = "POD_NAMESPACE"
+	podNameEnvVar                     = "POD_NAME"
 )
 
 type metrics struct {
@@ -82,6 +93,11 @@ type Controller struct {
 	flyteworkflowSynced cache.InformerSynced
 	workQueue           CompositeWorkQueue
 	gc                  *GarbageCollector
+	ttlGC               *TTLGarbageCollector
+	batchAborter        *BatchAborter
+	leakDetector        *LeakDetector
+	watchHealthMonitor  *WatchHealthMonitor
+	maintenanceMonitor  *MaintenanceMonitor
 	numWorkers          int
 	workflowStore       workflowstore.FlyteWorkflow
 	// recorder is an event recorder for recording Event resources to the
@@ -119,6 +135,36 @@ func (c *Controller) run(ctx context.Context) error {
 		return err
 	}
 
+	// Start deleting terminated workflows that outlived their TTL
+	if err := c.ttlGC.Start(ctx); err != nil {
+		logger.Errorf(ctx, "failed to start background TTL GC")
+		return err
+	}
+
+	// Start aborting executions matching the abort selectors of their namespaces
+	if err := c.batchAborter.Start(ctx); err != nil {
+		logger.Errorf(ctx, "failed to start background batch abort")
+		return err
+	}
+
+	// Start exporting metrics about leaked executions and resources
+	if err := c.leakDetector.Start(ctx); err != nil {
+		logger.Errorf(ctx, "failed to start background leak detection")
+		return err
+	}
+
+	// Start re-listing workflows if the informer cache goes stale
+	if err := c.watchHealthMonitor.Start(ctx); err != nil {
+		logger.Errorf(ctx, "failed to start background watch health monitoring")
+		return err
+	}
+
+	// Start tracking the maintenance mode, before workers may launch task pods
+	if err := c.maintenanceMonitor.Start(ctx); err != nil {
+		logger.Errorf(ctx, "failed to start background maintenance monitoring")
+		return err
+	}
+
 	// Start the collector process
 	c.levelMonitor.RunCollector(ctx)
 
@@ -305,7 +351,8 @@ func newControllerMetrics(scope promutils.Scope) *metrics {
 
 func getAdminClient(ctx context.Context) (client service.AdminServiceClient, opt grpc.DialOption, err error) {
 	cfg := admin.GetConfig(ctx)
-	clients, err := admin.NewClientsetBuilder().WithConfig(cfg).Build(ctx)
+	clients, err := admin.NewClientsetBuilder().WithConfig(cfg).
+		WithDialOptions(grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor())).Build(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize clientset. Error: %w", err)
 	}
@@ -351,16 +398,24 @@ func New(ctx context.Context, cfg *config.Config, kubeclientset kubernetes.Inter
 		return nil, errors.Wrapf(err, "failed to initialize WF GC")
 	}
 
+	ttlGC := NewTTLGarbageCollector(cfg, scope, clock.RealClock{}, kubeclientset.CoreV1().Namespaces(), flytepropellerClientset.FlyteworkflowV1alpha1())
+	batchAborter := NewBatchAborter(cfg, scope, clock.RealClock{}, kubeclientset.CoreV1().Namespaces(), flytepropellerClientset.FlyteworkflowV1alpha1())
+	leakDetector := NewLeakDetector(cfg, scope, clock.RealClock{}, kubeclientset.CoreV1().Namespaces(), kubeclientset.CoreV1(),
+		flytepropellerClientset.FlyteworkflowV1alpha1())
+
 	eventRecorder, err := utils.NewK8sEventRecorder(ctx, kubeclientset, controllerAgentName, cfg.PublishK8sEvents)
 	if err != nil {
 		logger.Errorf(ctx, "failed to event recorder %v", err)
 		return nil, errors.Wrapf(err, "failed to initialize resource lock.")
 	}
 	controller := &Controller{
-		metrics:    newControllerMetrics(scope),
-		recorder:   eventRecorder,
-		gc:         gc,
-		numWorkers: cfg.Workers,
+		metrics:      newControllerMetrics(scope),
+		recorder:     eventRecorder,
+		gc:           gc,
+		ttlGC:        ttlGC,
+		batchAborter: batchAborter,
+		leakDetector: leakDetector,
+		numWorkers:   cfg.Workers,
 	}
 
 	lock, err := leader.NewResourceLock(kubeclientset.CoreV1(), kubeclientset.CoordinationV1(), eventRecorder, cfg.LeaderElection)
@@ -386,6 +441,10 @@ func New(ctx context.Context, cfg *config.Config, kubeclientset kubernetes.Inter
 	// Set Client Metrics Provider
 	// setClientMetricsProvider(scope.NewSubScope("k8s_client"))
 
+	// The watch health monitor expires the watches of the FlyteWorkflow informer to force it to re-list workflows.
+	workflowWatches := newExpirableWatches()
+	flyteworkflowInformerFactory.InformerFor(&v1alpha1.FlyteWorkflow{}, workflowWatches.newInformerFunc(cfg))
+
 	// obtain references to shared index informers for FlyteWorkflow.
 	flyteworkflowInformer := flyteworkflowInformerFactory.Flyteworkflow().V1alpha1().FlyteWorkflows()
 	controller.flyteworkflowSynced = flyteworkflowInformer.Informer().HasSynced
@@ -400,16 +459,34 @@ func New(ctx context.Context, cfg *config.Config, kubeclientset kubernetes.Inter
 
 	flytek8s.DefaultPodTemplateStore.SetDefaultNamespace(podNamespace)
 
+	controller.maintenanceMonitor = NewMaintenanceMonitor(cfg, scope, clock.RealClock{}, kubeclientset.CoreV1().Namespaces(),
+		kubeclientset.CoreV1(), podNamespace, os.Getenv(podNameEnvVar))
+
 	sCfg := storage.GetConfig()
 	if sCfg == nil {
 		logger.Errorf(ctx, "Storage configuration missing.")
 	}
 
-	store, err := storage.NewDataStore(sCfg, scope.NewSubScope("metastore"))
+	store, err := storagerouter.NewDataStore(ctx, sCfg, storagerouter.GetConfig(), scope)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to create Metadata storage")
 	}
 
+	// The calls to blob storage are recorded as spans of the rounds they are made in.
+	store = tracing.NewDataStore(store)
+
+	// The outputs of nodes are stored once per content, and their outputs files point to it.
+	store, err = contentaddressed.NewDataStore(ctx, store, cfg.ContentAddressed, &cfg.EventConfig, scope.NewSubScope("content_addressed"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create the content-addressed storage")
+	}
+
+	if prefix := cfg.TTLGarbageCollector.ArchivePrefix; len(prefix) > 0 {
+		logger.Infof(ctx, "Archiving workflows under [%v] before they are deleted", prefix)
+		ttlGC.archiver = archive.NewArchiver(store, prefix)
+		ttlGC.dataStore = store
+	}
+
 	logger.Info(ctx, "Setting up Catalog client.")
 	catalogClient, err := catalog.NewCatalogClient(ctx, authOpts)
 	if err != nil {
@@ -422,16 +499,43 @@ func New(ctx context.Context, cfg *config.Config, kubeclientset kubernetes.Inter
 	}
 	controller.workQueue = workQ
 
-	controller.workflowStore, err = workflowstore.NewWorkflowStore(ctx, workflowstore.GetConfig(), flyteworkflowInformer.Lister(), flytepropellerClientset.FlyteworkflowV1alpha1(), scope)
+	controller.workflowStore, err = workflowstore.NewWorkflowStore(ctx, workflowstore.GetConfig(), flyteworkflowInformer.Lister(), flytepropellerClientset.FlyteworkflowV1alpha1(), store, scope)
 	if err != nil {
 		return nil, stdErrs.Wrapf(errors3.CausedByError, err, "failed to initialize workflow store")
 	}
 
 	controller.levelMonitor = NewResourceLevelMonitor(scope.NewSubScope("collector"), flyteworkflowInformer.Lister())
 
-	nodeExecutor, err := nodes.NewExecutor(ctx, cfg.NodeConfig, store, controller.enqueueWorkflowForNodeUpdates, eventSink,
+	clk, err := clocks.NewClock(cfg.Clock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create the clock")
+	}
+
+	if len(cfg.Clock.FrozenAt) > 0 {
+		logger.Warnf(ctx, "Evaluating workflows with a clock frozen at [%v], timeouts and back-offs never elapse", cfg.Clock.FrozenAt)
+	}
+
+	// The node executor and the propeller handler read the time from the clock carried by the context.
+	ctx = clocks.WithClock(ctx, clk)
+	// The pods and CRDs of tasks are created in the data plane cluster their execution is pinned to, if any. Only the
+	// resources watched by the k8s plugins are routed.
+	var pluginResources []client.Object
+	for _, entry := range pluginMachinery.PluginRegistry().GetK8sPlugins() {
+		pluginResources = append(pluginResources, entry.ResourceToWatch)
+	}
+
+	kubeClient, err = executors.NewDataPlaneClient(ctx, kubeClient, cfg.DataPlanes, cfg.KubeConfig, pluginResources,
+		scope.NewSubScope("data_planes"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create the data plane clients")
+	}
+
+	// The blob reads and kube writes made while evaluating workflows count towards the budget of their rounds, and the
+	// blob storage calls are retried and fail fast during outages of the store.
+	nodeStore := roundbudget.NewDataStore(resilientstore.NewDataStore(store, cfg.StoreResilience, scope.NewSubScope("store_resilience")))
+	nodeExecutor, err := nodes.NewExecutor(ctx, cfg.NodeConfig, nodeStore, controller.enqueueWorkflowForNodeUpdates, eventSink,
 		launchPlanActor, launchPlanActor, cfg.MaxDatasetSizeBytes,
-		storage.DataReference(cfg.DefaultRawOutputPrefix), kubeClient, catalogClient, recovery.NewClient(adminClient), &cfg.EventConfig, cfg.ClusterID, scope)
+		storage.DataReference(cfg.DefaultRawOutputPrefix), roundbudget.NewKubeClient(kubeClient), catalogClient, recovery.NewClient(adminClient), &cfg.EventConfig, cfg.ClusterID, scope)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to create Controller.")
 	}
@@ -442,19 +546,38 @@ func New(ctx context.Context, cfg *config.Config, kubeclientset kubernetes.Inter
 	}
 
 	handler := NewPropellerHandler(ctx, cfg, controller.workflowStore, workflowExecutor, scope)
+	handler.concurrencyGate = newConcurrencyGate(cfg.WorkflowConcurrency, scope.NewSubScope("concurrency"))
+	handler.requeueWorkflow = func(namespace, name string) {
+		workQ.Add(namespace + "/" + name)
+	}
+	handler.progressReporter = newWorkflowProgressReporter(cfg.WorkflowProgress, cfg.ClusterID, eventSink, clk,
+		scope.NewSubScope("workflow_progress"))
+	handler.circuitBreaker = newCircuitBreaker(cfg.CircuitBreaker, cfg.ClusterID, eventSink, clk,
+		scope.NewSubScope("circuit_breaker"))
+	handler.maintenance = controller.maintenanceMonitor
+	if dataPlanes, ok := kubeClient.(executors.DataPlaneSelector); ok {
+		handler.dataPlanes = dataPlanes
+	}
 	controller.workerPool = NewWorkerPool(ctx, scope, workQ, handler)
 
 	logger.Info(ctx, "Setting up event handlers")
 	// Set up an event handler for when FlyteWorkflow resources change
 	flyteworkflowInformer.Informer().AddEventHandler(controller.getWorkflowUpdatesHandler())
 
+	controller.watchHealthMonitor = NewWatchHealthMonitor(cfg, scope, clock.RealClock{}, flyteworkflowInformer.Informer(),
+		workflowWatches, flytepropellerClientset.FlyteworkflowV1alpha1())
+	flyteworkflowInformer.Informer().AddEventHandler(controller.watchHealthMonitor.EventHandler())
+	if handler.concurrencyGate != nil {
+		flyteworkflowInformer.Informer().AddEventHandler(handler.concurrencyGate.EventHandler())
+	}
+
 	updateHandler := flytek8s.GetPodTemplateUpdatesHandler(&flytek8s.DefaultPodTemplateStore, flyteK8sConfig.GetK8sPluginConfig().DefaultPodTemplateName)
 	podTemplateInformer.Informer().AddEventHandler(updateHandler)
 	return controller, nil
 }
 
-// SharedInformerOptions creates informer options to work with FlytePropeller Sharding
-func SharedInformerOptions(cfg *config.Config, defaultNamespace string) []informers.SharedInformerOption {
+// Returns the label selector of the FlyteWorkflows this propeller handles, according to the sharding config.
+func workflowsLabelSelector(cfg *config.Config) string {
 	selectors := []struct {
 		label     string
 		operation v1.LabelSelectorOperator
@@ -469,6 +592,7 @@ func SharedInformerOptions(cfg *config.Config, defaultNamespace string) []inform
 	}
 
 	labelSelector := IgnoreCompletedWorkflowsLabelSelector()
+	labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, IgnoreQuarantinedWorkflowsLabelSelectorRequirement())
 	for _, selector := range selectors {
 		if len(selector.values) > 0 {
 			labelSelectorRequirement := v1.LabelSelectorRequirement{
@@ -481,9 +605,15 @@ func SharedInformerOptions(cfg *config.Config, defaultNamespace string) []inform
 		}
 	}
 
+	return v1.FormatLabelSelector(labelSelector)
+}
+
+// SharedInformerOptions creates informer options to work with FlytePropeller Sharding
+func SharedInformerOptions(cfg *config.Config, defaultNamespace string) []informers.SharedInformerOption {
+	labelSelector := workflowsLabelSelector(cfg)
 	opts := []informers.SharedInformerOption{
 		informers.WithTweakListOptions(func(options *v1.ListOptions) {
-			options.LabelSelector = v1.FormatLabelSelector(labelSelector)
+			options.LabelSelector = labelSelector
 		}),
 	}
 
@@ -534,20 +664,23 @@ func StartController(ctx context.Context, cfg *config.Config, defaultNamespace s
 		return errors.Wrapf(err, "error building FlyteWorkflow clientset")
 	}
 
-	// Create FlyteWorkflow CRD if it does not exist
+	// Create FlyteWorkflow and Signal CRDs if they do not exist
 	if cfg.CreateFlyteWorkflowCRD {
-		logger.Infof(ctx, "creating FlyteWorkflow CRD")
 		apiextensionsClient, err := apiextensionsclientset.NewForConfig(kubecfg)
 		if err != nil {
 			return errors.Wrapf(err, "error building apiextensions clientset")
 		}
 
-		_, err = apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Create(ctx, &flyteworkflow.CRD, v1.CreateOptions{})
-		if err != nil {
-			if apierrors.IsAlreadyExists(err) {
-				logger.Warnf(ctx, "FlyteWorkflow CRD already exists")
-			} else {
-				return errors.Wrapf(err, "failed to create FlyteWorkflow CRD")
+		for _, crd := range []*apiextensionsv1.CustomResourceDefinition{&flyteworkflow.CRD, &flyteworkflow.SignalCRD} {
+			kind := crd.Spec.Names.Kind
+			logger.Infof(ctx, "creating %v CRD", kind)
+			_, err = apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Create(ctx, crd, v1.CreateOptions{})
+			if err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					logger.Warnf(ctx, "%v CRD already exists", kind)
+				} else {
+					return errors.Wrapf(err, "failed to create %v CRD", kind)
+				}
 			}
 		}
 	}
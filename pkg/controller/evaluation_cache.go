@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/sizedcache"
+)
+
+// The size of the cached sections of a workflow that cannot be serialized to estimate their size.
+const defaultEvaluationCacheEntrySize = 64 * 1024
+
+type evaluationCacheMetrics struct {
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+type evaluationCacheEntry struct {
+	resourceVersion string
+	// A private copy of the workflow that only holds its immutable sections, its metadata and status are empty.
+	spec *v1alpha1.FlyteWorkflow
+	// The estimated size of the copy in memory.
+	sizeBytes int64
+}
+
+// workflowEvaluationCache keeps, per workflow, a private copy of the immutable sections of the FlyteWorkflow: the spec of
+// the workflow, its subworkflows and tasks, and its inputs. The status is the only section of the workflow propeller
+// mutates, so the copy is shared by the mutable copies of the workflow that are evaluated every round, and only the
+// metadata and status are deep copied. Derived structures, like the connections of the (sub)workflows, are computed
+// once when the copy is made.
+//
+// An entry is only valid for the resource version it was made from. Propeller's own updates do not change the spec, so
+// the entry follows the resource version of the workflow as it is updated; any other change to the workflow causes the
+// entry to be made again. The cache is bounded by the estimated size of the copies as well as their number, since the
+// immutable sections of large workflows take up orders of magnitude more memory than the ones of small workflows.
+type workflowEvaluationCache struct {
+	entries *sizedcache.Cache
+	ttl     time.Duration
+	metrics *evaluationCacheMetrics
+}
+
+func evaluationCacheKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Derives the connections of the (sub)workflows once, before their nodes may be evaluated concurrently.
+func deriveConnections(w *v1alpha1.FlyteWorkflow) {
+	if w.WorkflowSpec != nil {
+		w.WorkflowSpec.GetConnections()
+	}
+
+	for _, subWorkflow := range w.SubWorkflows {
+		if subWorkflow != nil {
+			subWorkflow.GetConnections()
+		}
+	}
+}
+
+// Returns a copy of the immutable sections of the workflow, with its connections derived.
+func copySpec(w *v1alpha1.FlyteWorkflow) *v1alpha1.FlyteWorkflow {
+	spec := *w
+	spec.ObjectMeta = metav1.ObjectMeta{}
+	spec.Status = v1alpha1.WorkflowStatus{}
+	c := spec.DeepCopy()
+	deriveConnections(c)
+	return c
+}
+
+// Estimates the size in memory of the immutable sections of the workflow by the size of their serialized form.
+func estimateSpecSize(ctx context.Context, spec *v1alpha1.FlyteWorkflow) int64 {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to estimate the size of the immutable sections of the workflow. Error: %v", err)
+		return defaultEvaluationCacheEntrySize
+	}
+
+	return int64(len(raw))
+}
+
+// DeepCopy returns a copy of the workflow that may be mutated by a round. It shares the cached immutable sections of the
+// workflow if they were copied from the same resource version, or caches them otherwise. A nil cache deep copies the
+// whole workflow. The connections of the (sub)workflows are derived either way.
+func (c *workflowEvaluationCache) DeepCopy(ctx context.Context, w *v1alpha1.FlyteWorkflow) *v1alpha1.FlyteWorkflow {
+	if c == nil {
+		mutableW := w.DeepCopy()
+		deriveConnections(mutableW)
+		return mutableW
+	}
+
+	key := evaluationCacheKey(w.Namespace, w.Name)
+	var spec *v1alpha1.FlyteWorkflow
+	if e, ok := c.entries.Get(key); ok && e.(*evaluationCacheEntry).resourceVersion == w.ResourceVersion {
+		c.metrics.hits.Inc()
+		spec = e.(*evaluationCacheEntry).spec
+	} else {
+		c.metrics.misses.Inc()
+		logger.Debugf(ctx, "Caching the immutable sections of resource version [%v] of the workflow", w.ResourceVersion)
+		spec = copySpec(w)
+		sizeBytes := estimateSpecSize(ctx, spec)
+		c.entries.Add(key, &evaluationCacheEntry{resourceVersion: w.ResourceVersion, spec: spec, sizeBytes: sizeBytes}, sizeBytes, c.ttl)
+	}
+
+	mutableW := *spec
+	w.ObjectMeta.DeepCopyInto(&mutableW.ObjectMeta)
+	w.Status.DeepCopyInto(&mutableW.Status)
+	return &mutableW
+}
+
+// Advance moves the entry of the workflow to the resource version the workflow was updated to by propeller, as long as
+// the update was made from the resource version the entry is valid for.
+func (c *workflowEvaluationCache) Advance(namespace, name, fromResourceVersion, toResourceVersion string) {
+	if c == nil {
+		return
+	}
+
+	key := evaluationCacheKey(namespace, name)
+	if e, ok := c.entries.Get(key); ok && e.(*evaluationCacheEntry).resourceVersion == fromResourceVersion {
+		entry := e.(*evaluationCacheEntry)
+		c.entries.Add(key, &evaluationCacheEntry{resourceVersion: toResourceVersion, spec: entry.spec, sizeBytes: entry.sizeBytes},
+			entry.sizeBytes, c.ttl)
+	}
+}
+
+// Evict removes the entry of the workflow, e.g. once it is terminated.
+func (c *workflowEvaluationCache) Evict(namespace, name string) {
+	if c == nil {
+		return
+	}
+
+	c.entries.Remove(evaluationCacheKey(namespace, name))
+}
+
+func newWorkflowEvaluationCache(size int, maxSizeBytes int64, ttl time.Duration, scope promutils.Scope) *workflowEvaluationCache {
+	return &workflowEvaluationCache{
+		entries: sizedcache.New(size, maxSizeBytes, clock.RealClock{}, scope),
+		ttl:     ttl,
+		metrics: &evaluationCacheMetrics{
+			hits:   scope.MustNewCounter("hits", "Number of rounds that reused the cached immutable sections of the workflow"),
+			misses: scope.MustNewCounter("misses", "Number of rounds that copied the immutable sections of the workflow"),
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func newEvaluationCacheTestWorkflow(resourceVersion string) *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            "wf",
+			Namespace:       "ns",
+			ResourceVersion: resourceVersion,
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "w1",
+			Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+				v1alpha1.StartNodeID: {ID: v1alpha1.StartNodeID},
+				"n1":                 {ID: "n1"},
+			},
+			DeprecatedConnections: v1alpha1.DeprecatedConnections{
+				DownstreamEdges: map[v1alpha1.NodeID][]v1alpha1.NodeID{v1alpha1.StartNodeID: {"n1"}},
+				UpstreamEdges:   map[v1alpha1.NodeID][]v1alpha1.NodeID{"n1": {v1alpha1.StartNodeID}},
+			},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowPhaseRunning,
+		},
+	}
+}
+
+func TestWorkflowEvaluationCache(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *workflowEvaluationCache
+		w := newEvaluationCacheTestWorkflow("1")
+		mutableW := c.DeepCopy(ctx, w)
+		assert.Equal(t, w, mutableW)
+		assert.False(t, w.WorkflowSpec == mutableW.WorkflowSpec)
+		c.Advance("ns", "wf", "1", "2")
+		c.Evict("ns", "wf")
+	})
+
+	t.Run("reused for the same resource version", func(t *testing.T) {
+		c := newWorkflowEvaluationCache(10, 0, time.Hour, promutils.NewTestScope())
+		w := newEvaluationCacheTestWorkflow("1")
+		first := c.DeepCopy(ctx, w)
+		assert.False(t, w.WorkflowSpec == first.WorkflowSpec)
+		assert.Equal(t, []v1alpha1.NodeID{"n1"}, first.GetConnections().Downstream[v1alpha1.StartNodeID])
+		// The connections of the workflow are derived on the cached copy only.
+		assert.Empty(t, w.WorkflowSpec.Connections.Downstream)
+
+		first.Status.Phase = v1alpha1.WorkflowPhaseSucceeding
+		first.Labels = map[string]string{"a": "b"}
+		second := c.DeepCopy(ctx, w)
+		assert.True(t, first.WorkflowSpec == second.WorkflowSpec)
+		assert.Equal(t, v1alpha1.WorkflowPhaseRunning, second.Status.Phase)
+		assert.Empty(t, second.Labels)
+		assert.Equal(t, v1alpha1.WorkflowPhaseRunning, w.Status.Phase)
+	})
+
+	t.Run("copied again for another resource version", func(t *testing.T) {
+		c := newWorkflowEvaluationCache(10, 0, time.Hour, promutils.NewTestScope())
+		first := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("1"))
+		second := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("2"))
+		assert.False(t, first.WorkflowSpec == second.WorkflowSpec)
+		assert.Equal(t, "2", second.ResourceVersion)
+	})
+
+	t.Run("advanced by propeller updates", func(t *testing.T) {
+		c := newWorkflowEvaluationCache(10, 0, time.Hour, promutils.NewTestScope())
+		first := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("1"))
+		c.Advance("ns", "wf", "1", "2")
+		second := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("2"))
+		assert.True(t, first.WorkflowSpec == second.WorkflowSpec)
+
+		// Updates made from another version do not advance the entry.
+		c.Advance("ns", "wf", "1", "3")
+		third := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("3"))
+		assert.False(t, second.WorkflowSpec == third.WorkflowSpec)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		c := newWorkflowEvaluationCache(10, 0, time.Hour, promutils.NewTestScope())
+		first := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("1"))
+		c.Evict("ns", "wf")
+		second := c.DeepCopy(ctx, newEvaluationCacheTestWorkflow("1"))
+		assert.False(t, first.WorkflowSpec == second.WorkflowSpec)
+	})
+	t.Run("bounded by size", func(t *testing.T) {
+		w := newEvaluationCacheTestWorkflow("1")
+		size := estimateSpecSize(ctx, copySpec(w))
+		assert.True(t, size > 0)
+
+		c := newWorkflowEvaluationCache(10, size, time.Hour, promutils.NewTestScope())
+		first := c.DeepCopy(ctx, w)
+		second := c.DeepCopy(ctx, w)
+		assert.True(t, first.WorkflowSpec == second.WorkflowSpec)
+		assert.Equal(t, size, c.entries.SizeBytes())
+
+		// Caching another workflow of the same size evicts the least recently used one.
+		other := newEvaluationCacheTestWorkflow("1")
+		other.Name = "other"
+		c.DeepCopy(ctx, other)
+		assert.Equal(t, 1, c.entries.Len())
+		third := c.DeepCopy(ctx, w)
+		assert.False(t, first.WorkflowSpec == third.WorkflowSpec)
+	})
+}
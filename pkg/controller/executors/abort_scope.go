@@ -0,0 +1,104 @@
+package executors
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+type abortScopeKey struct{}
+
+// AbortScope is the path of nested sub workflows an abort is propagated through, from the top-level workflow to the sub
+// workflow whose nodes are being aborted.
+type AbortScope struct {
+	parent   *AbortScope
+	workflow v1alpha1.WorkflowID
+	depth    int
+	cyclic   bool
+}
+
+// Depth returns the number of sub workflows the abort was propagated through, 0 for the nodes of the top-level workflow.
+func (s *AbortScope) Depth() int {
+	if s == nil {
+		return 0
+	}
+
+	return s.depth
+}
+
+// IsCyclic returns true if the sub workflow whose nodes are being aborted is already being aborted further up the path,
+// i.e. the sub workflow references itself.
+func (s *AbortScope) IsCyclic() bool {
+	return s != nil && s.cyclic
+}
+
+// GetAbortScope returns the scope of the abort propagated in the context, or nil if no abort is being propagated.
+func GetAbortScope(ctx context.Context) *AbortScope {
+	if s, ok := ctx.Value(abortScopeKey{}).(*AbortScope); ok {
+		return s
+	}
+
+	return nil
+}
+
+// WithRootAbortScope returns a context that propagates an abort into the nodes of the top-level workflow.
+func WithRootAbortScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, abortScopeKey{}, &AbortScope{})
+}
+
+// WithAbortScope returns a context that propagates an abort into the sub workflow with the given ID, one level deeper
+// than the scope in the context.
+func WithAbortScope(ctx context.Context, subWorkflowID v1alpha1.WorkflowID) context.Context {
+	parent := GetAbortScope(ctx)
+	s := &AbortScope{parent: parent, workflow: subWorkflowID, depth: parent.Depth() + 1}
+	for p := parent; p != nil; p = p.parent {
+		if p.workflow == subWorkflowID {
+			s.cyclic = true
+			break
+		}
+	}
+
+	return context.WithValue(ctx, abortScopeKey{}, s)
+}
+
+type terminationTrackerKey struct{}
+
+// TerminationTracker tracks whether any of the nodes aborted within a context still waits for an execution it
+// terminated to reach a terminal phase. The abort of such nodes is incomplete and repeated on a later round, rather than
+// failed.
+type TerminationTracker struct {
+	parent  *TerminationTracker
+	pending int32
+}
+
+// IsPending returns true if a node aborted within the context of the tracker waits for a terminated execution.
+func (t *TerminationTracker) IsPending() bool {
+	return atomic.LoadInt32(&t.pending) != 0
+}
+
+// WithTerminationTracker returns a context that tracks the pending terminations of the nodes aborted within it. Pending
+// terminations are also tracked by the tracker of the given context, if any.
+func WithTerminationTracker(ctx context.Context) (context.Context, *TerminationTracker) {
+	t := &TerminationTracker{}
+	if parent, ok := ctx.Value(terminationTrackerKey{}).(*TerminationTracker); ok {
+		t.parent = parent
+	}
+
+	return context.WithValue(ctx, terminationTrackerKey{}, t), t
+}
+
+// SetTerminationPending reports that a node aborted within the context waits for an execution it terminated to reach a
+// terminal phase. It returns false if the context doesn't track terminations, in which case the node can't wait.
+func SetTerminationPending(ctx context.Context) bool {
+	t, ok := ctx.Value(terminationTrackerKey{}).(*TerminationTracker)
+	if !ok {
+		return false
+	}
+
+	for ; t != nil; t = t.parent {
+		atomic.StoreInt32(&t.pending, 1)
+	}
+
+	return true
+}
@@ -0,0 +1,49 @@
+package executors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAbortScope(t *testing.T) {
+	ctx := context.TODO()
+	assert.Nil(t, GetAbortScope(ctx))
+	assert.Equal(t, 0, GetAbortScope(ctx).Depth())
+	assert.False(t, GetAbortScope(ctx).IsCyclic())
+
+	ctx = WithRootAbortScope(ctx)
+	assert.Equal(t, 0, GetAbortScope(ctx).Depth())
+
+	sub := WithAbortScope(ctx, "sub-1")
+	assert.Equal(t, 1, GetAbortScope(sub).Depth())
+	assert.False(t, GetAbortScope(sub).IsCyclic())
+
+	// The same sub workflow may be used by sibling nodes
+	sibling := WithAbortScope(ctx, "sub-1")
+	assert.False(t, GetAbortScope(sibling).IsCyclic())
+
+	nested := WithAbortScope(sub, "sub-2")
+	assert.Equal(t, 2, GetAbortScope(nested).Depth())
+	assert.False(t, GetAbortScope(nested).IsCyclic())
+
+	cycle := WithAbortScope(nested, "sub-1")
+	assert.Equal(t, 3, GetAbortScope(cycle).Depth())
+	assert.True(t, GetAbortScope(cycle).IsCyclic())
+}
+
+func TestWithTerminationTracker(t *testing.T) {
+	ctx := context.TODO()
+	assert.False(t, SetTerminationPending(ctx))
+
+	ctx, workflow := WithTerminationTracker(ctx)
+	node1, tracker1 := WithTerminationTracker(ctx)
+	_, tracker2 := WithTerminationTracker(ctx)
+	assert.False(t, workflow.IsPending())
+
+	assert.True(t, SetTerminationPending(node1))
+	assert.True(t, tracker1.IsPending())
+	assert.False(t, tracker2.IsPending())
+	assert.True(t, workflow.IsPending())
+}
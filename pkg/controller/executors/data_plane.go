@@ -0,0 +1,504 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+const (
+	dataPlaneLabel = "data_plane"
+	// DataPlaneAnnotation is the annotation of a FlyteWorkflow naming the data plane cluster its execution is pinned to.
+	// An empty name pins the execution to the local cluster.
+	DataPlaneAnnotation = "flyte.org/data-plane"
+)
+
+type dataPlaneKey struct{}
+
+// WithDataPlane returns a context that routes the pods and CRDs of tasks to the named data plane cluster, or to the
+// local cluster if the name is empty.
+func WithDataPlane(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, dataPlaneKey{}, name)
+}
+
+func dataPlaneFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(dataPlaneKey{}).(string)
+	return name
+}
+
+// DataPlaneSelector chooses the data plane cluster executions are pinned to.
+type DataPlaneSelector interface {
+	// SelectDataPlane returns the name of the first healthy data plane cluster matching the project and domain, or an
+	// empty name if the execution should run in the local cluster.
+	SelectDataPlane(ctx context.Context, project, domain string) string
+}
+
+// A remote cluster the pods and CRDs of tasks are routed to.
+type dataPlane struct {
+	name   string
+	client client.Client
+	cache  cache.Cache
+	// The projects and domains of the executions that are pinned to the cluster.
+	projects sets.String
+	domains  sets.String
+	// Checks that the API server of the cluster is reachable.
+	check   func(ctx context.Context) error
+	healthy int32
+}
+
+func (p *dataPlane) matches(project, domain string) bool {
+	return (p.projects.Len() == 0 || p.projects.Has(project)) && (p.domains.Len() == 0 || p.domains.Has(domain))
+}
+
+func (p *dataPlane) isHealthy() bool {
+	return atomic.LoadInt32(&p.healthy) == 1
+}
+
+// Sets the health of the cluster, returning true if it changed.
+func (p *dataPlane) setHealthy(healthy bool) bool {
+	var v int32
+	if healthy {
+		v = 1
+	}
+
+	return atomic.SwapInt32(&p.healthy, v) != v
+}
+
+// dataPlaneRouter routes the calls made to the kube API for the resources of the k8s plugins to the data plane cluster
+// the execution they are made for is pinned to, which is carried by the context. All other calls go to the local cluster.
+type dataPlaneRouter struct {
+	local  Client
+	planes []*dataPlane
+	scheme *runtime.Scheme
+	// The kinds of the resources that are routed, the ones watched by the k8s plugins.
+	routed    map[schema.GroupKind]bool
+	healthy   *prometheus.GaugeVec
+	failovers *prometheus.CounterVec
+}
+
+func (r *dataPlaneRouter) SelectDataPlane(ctx context.Context, project, domain string) string {
+	for _, p := range r.planes {
+		if !p.matches(project, domain) {
+			continue
+		}
+
+		if p.isHealthy() {
+			return p.name
+		}
+
+		logger.Debugf(ctx, "Data plane cluster [%s] is unhealthy, failing over to the next matching one", p.name)
+		r.failovers.WithLabelValues(p.name).Inc()
+	}
+
+	return ""
+}
+
+// Returns the data plane cluster the calls made for the object in the context are routed to, or nil if they go to the
+// local cluster. Executions stay in the cluster they are pinned to, even if it becomes unhealthy.
+func (r *dataPlaneRouter) planeFor(ctx context.Context, obj runtime.Object) (*dataPlane, error) {
+	name := dataPlaneFromContext(ctx)
+	if len(name) == 0 {
+		return nil, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.isRouted(gvk, meta.IsListType(obj)) {
+		return nil, nil
+	}
+
+	for _, p := range r.planes {
+		if p.name == name {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the execution is pinned to data plane cluster [%s], which is not configured", name)
+}
+
+func (r *dataPlaneRouter) isRouted(gvk schema.GroupVersionKind, isList bool) bool {
+	kind := gvk.GroupKind()
+	if isList {
+		kind.Kind = strings.TrimSuffix(kind.Kind, "List")
+	}
+
+	return r.routed[kind]
+}
+
+func (r *dataPlaneRouter) checkHealth(ctx context.Context) {
+	for _, p := range r.planes {
+		err := p.check(ctx)
+		if p.setHealthy(err == nil) {
+			if err != nil {
+				logger.Errorf(ctx, "Data plane cluster [%s] became unhealthy. Error: %v", p.name, err)
+			} else {
+				logger.Infof(ctx, "Data plane cluster [%s] became healthy", p.name)
+			}
+		}
+
+		if err == nil {
+			r.healthy.WithLabelValues(p.name).Set(1)
+		} else {
+			r.healthy.WithLabelValues(p.name).Set(0)
+		}
+	}
+}
+
+func (r *dataPlaneRouter) GetClient() client.Client {
+	return routedClient{Client: r.local.GetClient(), router: r}
+}
+
+// GetCache returns a cache that reads the routed resources from the cache of the data plane cluster of the execution,
+// and whose informers deliver the events of all clusters.
+func (r *dataPlaneRouter) GetCache() cache.Cache {
+	return routedCache{Cache: r.local.GetCache(), router: r}
+}
+
+// routedClient makes the calls of a client to the data plane cluster of the execution they are made for.
+type routedClient struct {
+	client.Client
+	router *dataPlaneRouter
+}
+
+func (c routedClient) clientFor(ctx context.Context, obj runtime.Object) (client.Client, error) {
+	p, err := c.router.planeFor(ctx, obj)
+	if err != nil || p == nil {
+		return c.Client, err
+	}
+
+	return p.client, nil
+}
+
+func (c routedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	cl, err := c.clientFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return cl.Get(ctx, key, obj)
+}
+
+func (c routedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	cl, err := c.clientFor(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	return cl.List(ctx, list, opts...)
+}
+
+func (c routedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	cl, err := c.clientFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return cl.Create(ctx, obj, opts...)
+}
+
+func (c routedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	cl, err := c.clientFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return cl.Update(ctx, obj, opts...)
+}
+
+func (c routedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	cl, err := c.clientFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return cl.Patch(ctx, obj, patch, opts...)
+}
+
+func (c routedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	cl, err := c.clientFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return cl.Delete(ctx, obj, opts...)
+}
+
+func (c routedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	cl, err := c.clientFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return cl.DeleteAllOf(ctx, obj, opts...)
+}
+
+// routedCache reads the routed resources from the cache of the data plane cluster of the execution they are read for,
+// and everything else from the cache of the local cluster.
+type routedCache struct {
+	cache.Cache
+	router *dataPlaneRouter
+}
+
+func (c routedCache) readerFor(ctx context.Context, obj runtime.Object) (client.Reader, error) {
+	p, err := c.router.planeFor(ctx, obj)
+	if err != nil || p == nil {
+		return c.Cache, err
+	}
+
+	return p.cache, nil
+}
+
+func (c routedCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	reader, err := c.readerFor(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	return reader.Get(ctx, key, obj)
+}
+
+func (c routedCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	reader, err := c.readerFor(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	return reader.List(ctx, list, opts...)
+}
+
+func (c routedCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.router.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getInformer(gvk, func(informers cache.Cache) (cache.Informer, error) {
+		return informers.GetInformer(ctx, obj)
+	})
+}
+
+func (c routedCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	return c.getInformer(gvk, func(informers cache.Cache) (cache.Informer, error) {
+		return informers.GetInformerForKind(ctx, gvk)
+	})
+}
+
+// Returns the informer of the local cluster. For routed resources, the event handlers added to it are added to the
+// informers of the data plane clusters as well.
+func (c routedCache) getInformer(gvk schema.GroupVersionKind, get func(informers cache.Cache) (cache.Informer, error)) (
+	cache.Informer, error) {
+	local, err := get(c.Cache)
+	if err != nil || !c.router.isRouted(gvk, false) {
+		return local, err
+	}
+
+	sharedInformer, ok := local.(toolscache.SharedIndexInformer)
+	if !ok {
+		return nil, fmt.Errorf("the informer of [%v] in the local cluster is not a shared index informer", gvk)
+	}
+
+	remote := make([]cache.Informer, 0, len(c.router.planes))
+	for _, p := range c.router.planes {
+		informer, err := get(p.cache)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the informer of [%v] in data plane cluster [%s]", gvk, p.name)
+		}
+
+		remote = append(remote, informer)
+	}
+
+	return multiClusterInformer{SharedIndexInformer: sharedInformer, remote: remote}, nil
+}
+
+func (c routedCache) WaitForCacheSync(ctx context.Context) bool {
+	synced := c.Cache.WaitForCacheSync(ctx)
+	for _, p := range c.router.planes {
+		synced = p.cache.WaitForCacheSync(ctx) && synced
+	}
+
+	return synced
+}
+
+func (c routedCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	if err := c.Cache.IndexField(ctx, obj, field, extractValue); err != nil {
+		return err
+	}
+
+	for _, p := range c.router.planes {
+		if err := p.cache.IndexField(ctx, obj, field, extractValue); err != nil {
+			return errors.Wrapf(err, "failed to index field [%s] in data plane cluster [%s]", field, p.name)
+		}
+	}
+
+	return nil
+}
+
+// multiClusterInformer is the shared informer of a resource in the local cluster, whose event handlers receive the
+// events of the resource in the data plane clusters as well. Its store only holds the objects of the local cluster.
+type multiClusterInformer struct {
+	toolscache.SharedIndexInformer
+	remote []cache.Informer
+}
+
+func (i multiClusterInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.SharedIndexInformer.AddEventHandler(handler)
+	for _, informer := range i.remote {
+		informer.AddEventHandler(handler)
+	}
+}
+
+func (i multiClusterInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	for _, informer := range i.remote {
+		informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+func (i multiClusterInformer) HasSynced() bool {
+	for _, informer := range i.remote {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+
+	return i.SharedIndexInformer.HasSynced()
+}
+
+func newDataPlane(cluster config.DataPlaneCluster, kubeConfig config.KubeClientConfig, options client.Options) (*dataPlane, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", os.ExpandEnv(cluster.KubeConfigPath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build the kubeconfig of data plane cluster [%s]", cluster.Name)
+	}
+
+	restConfig.QPS = kubeConfig.QPS
+	restConfig.Burst = kubeConfig.Burst
+	restConfig.Timeout = kubeConfig.Timeout.Duration
+
+	informerCache, err := cache.New(restConfig, cache.Options{Scheme: options.Scheme})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the cache of data plane cluster [%s]", cluster.Name)
+	}
+
+	apiClient, err := client.New(restConfig, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the client of data plane cluster [%s]", cluster.Name)
+	}
+
+	c, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+		Client: apiClient,
+		CacheReader: fallbackClientReader{
+			orderedClients: []client.Reader{informerCache, apiClient},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the client of data plane cluster [%s]", cluster.Name)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the discovery client of data plane cluster [%s]", cluster.Name)
+	}
+
+	return &dataPlane{
+		name:     cluster.Name,
+		client:   c,
+		cache:    informerCache,
+		projects: sets.NewString(cluster.Projects...),
+		domains:  sets.NewString(cluster.Domains...),
+		check: func(context.Context) error {
+			_, err := discoveryClient.ServerVersion()
+			return err
+		},
+	}, nil
+}
+
+func newDataPlaneRouter(local Client, planes []*dataPlane, routed []client.Object, scope promutils.Scope) (*dataPlaneRouter, error) {
+	scheme := local.GetClient().Scheme()
+	kinds := make(map[schema.GroupKind]bool, len(routed))
+	for _, obj := range routed {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the kind of the routed resource [%T]", obj)
+		}
+
+		kinds[gvk.GroupKind()] = true
+	}
+
+	return &dataPlaneRouter{
+		local:     local,
+		planes:    planes,
+		scheme:    scheme,
+		routed:    kinds,
+		healthy:   scope.MustNewGaugeVec("healthy", "Whether the data plane cluster is healthy", dataPlaneLabel),
+		failovers: scope.MustNewCounterVec("failovers", "Executions not pinned to the unhealthy data plane cluster", dataPlaneLabel),
+	}, nil
+}
+
+// NewDataPlaneClient returns a client that routes the calls made for the given resources, the ones watched by the k8s
+// plugins, to the data plane cluster the execution they are made for is pinned to, see WithDataPlane. The returned
+// client is a DataPlaneSelector to pin executions with. The caches of the data plane clusters are run and their health
+// is checked until the context is done. If routing to data planes is disabled, the local client is returned as is.
+func NewDataPlaneClient(ctx context.Context, local Client, cfg config.DataPlanesConfig, kubeConfig config.KubeClientConfig,
+	routed []client.Object, scope promutils.Scope) (Client, error) {
+	if !cfg.Enabled || len(cfg.Clusters) == 0 {
+		return local, nil
+	}
+
+	options := client.Options{Scheme: local.GetClient().Scheme()}
+	planes := make([]*dataPlane, 0, len(cfg.Clusters))
+	for _, cluster := range cfg.Clusters {
+		p, err := newDataPlane(cluster, kubeConfig, options)
+		if err != nil {
+			return nil, err
+		}
+
+		planes = append(planes, p)
+	}
+
+	router, err := newDataPlaneRouter(local, planes, routed, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range planes {
+		go func(p *dataPlane) {
+			if err := p.cache.Start(ctx); err != nil {
+				logger.Errorf(ctx, "The cache of data plane cluster [%s] stopped. Error: %v", p.name, err)
+			}
+		}(p)
+	}
+
+	router.checkHealth(ctx)
+	interval := cfg.HealthCheckInterval.Duration
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	go wait.Until(func() { router.checkHealth(ctx) }, interval, ctx.Done())
+	logger.Infof(ctx, "Routing the pods and CRDs of tasks to [%d] data plane clusters", len(planes))
+	return router, nil
+}
@@ -0,0 +1,196 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type localClient struct {
+	client client.Client
+	cache  cache.Cache
+}
+
+func (l localClient) GetClient() client.Client {
+	return l.client
+}
+
+func (l localClient) GetCache() cache.Cache {
+	return l.cache
+}
+
+func TestDataPlaneRouter(t *testing.T) {
+	ctx := context.TODO()
+	local := localClient{client: fake.NewClientBuilder().Build(), cache: &informertest.FakeInformers{}}
+	var primaryErr error
+	primary := &dataPlane{
+		name:     "primary",
+		client:   fake.NewClientBuilder().Build(),
+		cache:    &informertest.FakeInformers{},
+		projects: sets.NewString("flytesnacks"),
+		domains:  sets.NewString(),
+		check:    func(context.Context) error { return primaryErr },
+	}
+
+	secondary := &dataPlane{
+		name:     "secondary",
+		client:   fake.NewClientBuilder().Build(),
+		cache:    &informertest.FakeInformers{},
+		projects: sets.NewString(),
+		domains:  sets.NewString("production"),
+		check:    func(context.Context) error { return nil },
+	}
+
+	router, err := newDataPlaneRouter(local, []*dataPlane{primary, secondary}, []client.Object{&v1.Pod{}}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	router.checkHealth(ctx)
+	c := router.GetClient()
+
+	create := func(ctx context.Context, obj client.Object, name string) {
+		obj.SetName(name)
+		obj.SetNamespace("ns")
+		assert.NoError(t, c.Create(ctx, obj))
+	}
+
+	exists := func(c client.Client, obj client.Object, name string) bool {
+		return c.Get(ctx, client.ObjectKey{Name: name, Namespace: "ns"}, obj) == nil
+	}
+
+	t.Run("select by project", func(t *testing.T) {
+		assert.Equal(t, "primary", router.SelectDataPlane(ctx, "flytesnacks", "production"))
+	})
+
+	t.Run("select by domain", func(t *testing.T) {
+		assert.Equal(t, "secondary", router.SelectDataPlane(ctx, "other", "production"))
+	})
+
+	t.Run("select no match", func(t *testing.T) {
+		assert.Equal(t, "", router.SelectDataPlane(ctx, "other", "development"))
+	})
+
+	t.Run("select failover", func(t *testing.T) {
+		primaryErr = fmt.Errorf("unreachable")
+		router.checkHealth(ctx)
+		assert.False(t, primary.isHealthy())
+		assert.Equal(t, "secondary", router.SelectDataPlane(ctx, "flytesnacks", "production"))
+		assert.Equal(t, "", router.SelectDataPlane(ctx, "flytesnacks", "development"))
+
+		primaryErr = nil
+		router.checkHealth(ctx)
+		assert.True(t, primary.isHealthy())
+		assert.Equal(t, "primary", router.SelectDataPlane(ctx, "flytesnacks", "production"))
+	})
+
+	t.Run("routed to the pinned cluster", func(t *testing.T) {
+		create(WithDataPlane(ctx, "primary"), &v1.Pod{}, "a")
+		assert.True(t, exists(primary.client, &v1.Pod{}, "a"))
+		assert.False(t, exists(secondary.client, &v1.Pod{}, "a"))
+		assert.False(t, exists(local.client, &v1.Pod{}, "a"))
+
+		pods := &v1.PodList{}
+		assert.NoError(t, c.List(WithDataPlane(ctx, "primary"), pods))
+		assert.Len(t, pods.Items, 1)
+	})
+
+	t.Run("sticky", func(t *testing.T) {
+		primaryErr = fmt.Errorf("unreachable")
+		router.checkHealth(ctx)
+		create(WithDataPlane(ctx, "primary"), &v1.Pod{}, "b")
+		assert.True(t, exists(primary.client, &v1.Pod{}, "b"))
+
+		primaryErr = nil
+		router.checkHealth(ctx)
+	})
+
+	t.Run("not pinned", func(t *testing.T) {
+		create(ctx, &v1.Pod{}, "c")
+		assert.True(t, exists(local.client, &v1.Pod{}, "c"))
+		create(WithDataPlane(ctx, ""), &v1.Pod{}, "d")
+		assert.True(t, exists(local.client, &v1.Pod{}, "d"))
+	})
+
+	t.Run("not a plugin resource", func(t *testing.T) {
+		create(WithDataPlane(ctx, "primary"), &v1.ConfigMap{}, "e")
+		assert.True(t, exists(local.client, &v1.ConfigMap{}, "e"))
+		assert.False(t, exists(primary.client, &v1.ConfigMap{}, "e"))
+	})
+
+	t.Run("unknown cluster", func(t *testing.T) {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "f", Namespace: "ns"}}
+		assert.Error(t, c.Create(WithDataPlane(ctx, "removed"), pod))
+	})
+}
+
+type countingHandler struct {
+	added int
+}
+
+func (h *countingHandler) OnAdd(interface{}) {
+	h.added++
+}
+
+func (h *countingHandler) OnUpdate(interface{}, interface{}) {}
+
+func (h *countingHandler) OnDelete(interface{}) {}
+
+func TestDataPlaneRouter_GetCache(t *testing.T) {
+	ctx := context.TODO()
+	local := localClient{client: fake.NewClientBuilder().Build(), cache: &informertest.FakeInformers{}}
+	primary := &dataPlane{
+		name:     "primary",
+		client:   fake.NewClientBuilder().Build(),
+		cache:    &informertest.FakeInformers{},
+		projects: sets.NewString(),
+		domains:  sets.NewString(),
+		check:    func(context.Context) error { return nil },
+	}
+
+	router, err := newDataPlaneRouter(local, []*dataPlane{primary}, []client.Object{&v1.Pod{}}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	t.Run("plugin resource", func(t *testing.T) {
+		informer, err := router.GetCache().GetInformer(ctx, &v1.Pod{})
+		assert.NoError(t, err)
+		// The plugin manager watches the resources through shared index informers.
+		_, ok := informer.(toolscache.SharedIndexInformer)
+		assert.True(t, ok)
+
+		handler := &countingHandler{}
+		informer.AddEventHandler(handler)
+		localInformer, err := local.cache.(*informertest.FakeInformers).FakeInformerFor(&v1.Pod{})
+		assert.NoError(t, err)
+		remoteInformer, err := primary.cache.(*informertest.FakeInformers).FakeInformerFor(&v1.Pod{})
+		assert.NoError(t, err)
+
+		localInformer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"}})
+		remoteInformer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns"}})
+		assert.Equal(t, 2, handler.added)
+	})
+
+	t.Run("other resource", func(t *testing.T) {
+		informer, err := router.GetCache().GetInformer(ctx, &v1.ConfigMap{})
+		assert.NoError(t, err)
+		_, ok := informer.(multiClusterInformer)
+		assert.False(t, ok)
+	})
+}
+
+func TestNewDataPlaneClient_Disabled(t *testing.T) {
+	local := localClient{client: fake.NewClientBuilder().Build()}
+	c, err := NewDataPlaneClient(context.TODO(), local, config.DataPlanesConfig{}, config.KubeClientConfig{}, nil, promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.Equal(t, local, c)
+}
@@ -1,6 +1,9 @@
 package executors
 
 import (
+	"math"
+	"sync"
+
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
@@ -31,6 +34,15 @@ type ImmutableParentInfo interface {
 type ControlFlow interface {
 	CurrentParallelism() uint32
 	IncrementParallelism() uint32
+	// RemainingParallelism returns how many more task and launch plan nodes may be started within the given max
+	// parallelism of the execution and the parallelism budgets of the parents of the nodes.
+	RemainingParallelism(maxParallelism uint32) uint32
+	// ReserveParallelism reserves one of the remaining parallelism for a task or launch plan node while it's handled, so
+	// that nodes handled concurrently can't exceed the max parallelism together. It returns false if no parallelism
+	// remains. Reservations count towards the remaining parallelism until they're released with ReleaseParallelism.
+	// See NewExecutionContextWithReservedParallelism for reserving the parallelism of a single node.
+	ReserveParallelism(maxParallelism uint32) bool
+	ReleaseParallelism()
 }
 
 type ExecutionContext interface {
@@ -67,20 +79,166 @@ func (p *parentExecutionInfo) CurrentAttempt() uint32 {
 }
 
 type controlFlow struct {
-	// We could use atomic.Uint32, but this is not required for current Propeller. As every round is run in a single
-	// thread and using atomic will introduce memory barriers
-	v uint32
+	// Guards the counters, since the ready nodes of a workflow may be evaluated concurrently within a round.
+	lock     sync.Mutex
+	v        uint32
+	reserved uint32
 }
 
 func (c *controlFlow) CurrentParallelism() uint32 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	return c.v
 }
 
 func (c *controlFlow) IncrementParallelism() uint32 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	c.v = c.v + 1
 	return c.v
 }
 
+func (c *controlFlow) RemainingParallelism(maxParallelism uint32) uint32 {
+	if maxParallelism == 0 {
+		return math.MaxUint32
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if used := c.v + c.reserved; used < maxParallelism {
+		return maxParallelism - used
+	}
+
+	return 0
+}
+
+func (c *controlFlow) ReserveParallelism(maxParallelism uint32) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if maxParallelism > 0 && c.v+c.reserved >= maxParallelism {
+		return false
+	}
+
+	c.reserved++
+	return true
+}
+
+func (c *controlFlow) ReleaseParallelism() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.reserved > 0 {
+		c.reserved--
+	}
+}
+
+// budgetedControlFlow limits the nodes started by a parent node, i.e. a sub workflow, dynamic or array node, to a
+// budget carved out of the parallelism remaining to the parent, while still counting them towards the parallelism of
+// the whole execution. Budgets of nested parents are carved out of each other, which forms a tree of budgets rooted at
+// the max parallelism of the execution.
+type budgetedControlFlow struct {
+	parent ControlFlow
+	budget uint32
+	// Guards the counters of the budget, like the ones of the execution.
+	lock     sync.Mutex
+	v        uint32
+	reserved uint32
+}
+
+// CurrentParallelism returns the parallelism of the whole execution.
+func (c *budgetedControlFlow) CurrentParallelism() uint32 {
+	return c.parent.CurrentParallelism()
+}
+
+func (c *budgetedControlFlow) IncrementParallelism() uint32 {
+	c.lock.Lock()
+	c.v++
+	c.lock.Unlock()
+	return c.parent.IncrementParallelism()
+}
+
+func (c *budgetedControlFlow) RemainingParallelism(maxParallelism uint32) uint32 {
+	c.lock.Lock()
+	remaining := uint32(0)
+	if used := c.v + c.reserved; used < c.budget {
+		remaining = c.budget - used
+	}
+	c.lock.Unlock()
+
+	if parentRemaining := c.parent.RemainingParallelism(maxParallelism); parentRemaining < remaining {
+		return parentRemaining
+	}
+
+	return remaining
+}
+
+// ReserveParallelism reserves the parallelism within the budget as well as within the budgets of the parents. The lock
+// of the budget is held while reserving from the parents, which are always locked after their children.
+func (c *budgetedControlFlow) ReserveParallelism(maxParallelism uint32) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.v+c.reserved >= c.budget || !c.parent.ReserveParallelism(maxParallelism) {
+		return false
+	}
+
+	c.reserved++
+	return true
+}
+
+func (c *budgetedControlFlow) ReleaseParallelism() {
+	c.lock.Lock()
+	if c.reserved > 0 {
+		c.reserved--
+	}
+	c.lock.Unlock()
+	c.parent.ReleaseParallelism()
+}
+
+// reservedControlFlow holds the parallelism reserved for a single task or launch plan node while it's handled. The
+// reservation turns into the parallelism counted for the node once the node increments the parallelism, so that a
+// started node isn't counted twice for the rest of the round.
+type reservedControlFlow struct {
+	ControlFlow
+	lock     sync.Mutex
+	reserved bool
+}
+
+func (c *reservedControlFlow) IncrementParallelism() uint32 {
+	// The node is counted before the reservation is released, so the parallelism is never undercounted in between.
+	v := c.ControlFlow.IncrementParallelism()
+	c.ReleaseParallelism()
+	return v
+}
+
+func (c *reservedControlFlow) ReleaseParallelism() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.reserved {
+		c.reserved = false
+		c.ControlFlow.ReleaseParallelism()
+	}
+}
+
+// NewBudgetedControlFlow returns a ControlFlow for the child nodes of a parent node that limits them to the given
+// weight, a share between 0 and 1, of the parallelism remaining to the parent, but to at least one node. Parents
+// evaluated later within the round get a share of what is left, so nested fan-outs can't use up the parallelism of
+// their siblings. The parent ControlFlow is returned as is if the max parallelism of the execution is disabled or the
+// weight doesn't limit the parallelism.
+func NewBudgetedControlFlow(parent ControlFlow, maxParallelism uint32, weight float64) ControlFlow {
+	if maxParallelism == 0 || weight <= 0 || weight >= 1 {
+		return parent
+	}
+
+	budget := uint32(math.Ceil(float64(parent.RemainingParallelism(maxParallelism)) * weight))
+	if budget == 0 {
+		budget = 1
+	}
+
+	return &budgetedControlFlow{
+		parent: parent,
+		budget: budget,
+	}
+}
+
 func NewExecutionContextWithTasksGetter(prevExecContext ExecutionContext, taskGetter TaskDetailsGetter) ExecutionContext {
 	return NewExecutionContext(prevExecContext, taskGetter, prevExecContext, prevExecContext.GetParentInfo(), prevExecContext)
 }
@@ -93,6 +251,36 @@ func NewExecutionContextWithParentInfo(prevExecContext ExecutionContext, parentI
 	return NewExecutionContext(prevExecContext, prevExecContext, prevExecContext, parentInfo, prevExecContext)
 }
 
+// NewControlFlowWithParallelismBudget returns the ControlFlow for the child nodes of a parent node executed within the
+// given execution context, limited by the given weight. See NewBudgetedControlFlow.
+func NewControlFlowWithParallelismBudget(prevExecContext ExecutionContext, weight float64) ControlFlow {
+	if weight <= 0 || weight >= 1 {
+		return prevExecContext
+	}
+
+	return NewBudgetedControlFlow(prevExecContext, prevExecContext.GetExecutionConfig().MaxParallelism, weight)
+}
+
+// NewExecutionContextWithParallelismBudget returns the execution context for the child nodes of a parent node, with
+// the given parent info and the parallelism of the child nodes limited by the given weight.
+func NewExecutionContextWithParallelismBudget(prevExecContext ExecutionContext, parentInfo ImmutableParentInfo, weight float64) ExecutionContext {
+	return NewExecutionContext(prevExecContext, prevExecContext, prevExecContext, parentInfo,
+		NewControlFlowWithParallelismBudget(prevExecContext, weight))
+}
+
+// NewExecutionContextWithReservedParallelism reserves one of the remaining parallelism of the given execution context
+// for a task or launch plan node and returns the execution context to handle the node with. It returns false if no
+// parallelism remains. The reservation becomes the parallelism of the node when the node increments the parallelism
+// and is otherwise released by ReleaseParallelism of the returned execution context.
+func NewExecutionContextWithReservedParallelism(prevExecContext ExecutionContext) (ExecutionContext, bool) {
+	if !prevExecContext.ReserveParallelism(prevExecContext.GetExecutionConfig().MaxParallelism) {
+		return nil, false
+	}
+
+	return NewExecutionContext(prevExecContext, prevExecContext, prevExecContext, prevExecContext.GetParentInfo(),
+		&reservedControlFlow{ControlFlow: prevExecContext, reserved: true}), true
+}
+
 func NewExecutionContext(immExecContext ImmutableExecutionContext, tasksGetter TaskDetailsGetter, workflowGetter SubWorkflowGetter, parentInfo ImmutableParentInfo, flow ControlFlow) ExecutionContext {
 	return execContext{
 		ImmutableExecutionContext: immExecContext,
@@ -1,15 +1,27 @@
 package executors
 
 import (
+	"math"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
 type immExecContext struct {
 	ImmutableExecutionContext
 }
 
+type limitedExecContext struct {
+	ImmutableExecutionContext
+}
+
+func (limitedExecContext) GetExecutionConfig() v1alpha1.ExecutionConfig {
+	return v1alpha1.ExecutionConfig{MaxParallelism: 10}
+}
+
 type tdGetter struct {
 	TaskDetailsGetter
 }
@@ -63,3 +75,116 @@ func TestExecutionContext(t *testing.T) {
 	assert.Equal(t, typed.TaskDetailsGetter, taskGetter)
 	assert.Equal(t, typed.GetParentInfo(), immutableParentInfo2)
 }
+
+func TestControlFlow_RemainingParallelism(t *testing.T) {
+	cf := InitializeControlFlow()
+	assert.Equal(t, uint32(math.MaxUint32), cf.RemainingParallelism(0))
+	assert.Equal(t, uint32(2), cf.RemainingParallelism(2))
+
+	cf.IncrementParallelism()
+	cf.IncrementParallelism()
+	assert.Equal(t, uint32(0), cf.RemainingParallelism(2))
+	cf.IncrementParallelism()
+	assert.Equal(t, uint32(0), cf.RemainingParallelism(2))
+}
+
+func TestControlFlow_ReserveParallelism(t *testing.T) {
+	t.Run("sequential", func(t *testing.T) {
+		cf := InitializeControlFlow()
+		assert.True(t, cf.ReserveParallelism(0))
+		cf.ReleaseParallelism()
+
+		assert.True(t, cf.ReserveParallelism(2))
+		assert.Equal(t, uint32(1), cf.RemainingParallelism(2))
+		cf.IncrementParallelism()
+		assert.Equal(t, uint32(0), cf.RemainingParallelism(2))
+		assert.False(t, cf.ReserveParallelism(2))
+
+		// The reservation is replaced by the node it was made for once it's released.
+		cf.ReleaseParallelism()
+		assert.Equal(t, uint32(1), cf.RemainingParallelism(2))
+		assert.Equal(t, uint32(1), cf.CurrentParallelism())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		cf := InitializeControlFlow()
+		child := NewBudgetedControlFlow(cf, 10, 0.5)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if reserved, ok := NewExecutionContextWithReservedParallelism(execContext{ControlFlow: child, ImmutableExecutionContext: limitedExecContext{}}); ok {
+					reserved.IncrementParallelism()
+					reserved.ReleaseParallelism()
+				}
+			}()
+		}
+
+		wg.Wait()
+		// The reservations become the parallelism of the nodes, so exactly the budget is started.
+		assert.Equal(t, uint32(5), cf.CurrentParallelism())
+		assert.Equal(t, uint32(0), child.RemainingParallelism(10))
+	})
+
+	t.Run("budgeted", func(t *testing.T) {
+		cf := InitializeControlFlow()
+		child := NewBudgetedControlFlow(cf, 10, 0.2)
+		assert.True(t, child.ReserveParallelism(10))
+		assert.True(t, child.ReserveParallelism(10))
+		assert.False(t, child.ReserveParallelism(10))
+		assert.Equal(t, uint32(8), cf.RemainingParallelism(10))
+
+		child.ReleaseParallelism()
+		child.ReleaseParallelism()
+		assert.Equal(t, uint32(10), cf.RemainingParallelism(10))
+	})
+}
+
+func TestNewBudgetedControlFlow(t *testing.T) {
+	t.Run("not limited", func(t *testing.T) {
+		cf := InitializeControlFlow()
+		assert.Equal(t, cf, NewBudgetedControlFlow(cf, 0, 0.5))
+		assert.Equal(t, cf, NewBudgetedControlFlow(cf, 10, 1))
+		assert.Equal(t, cf, NewBudgetedControlFlow(cf, 10, 0))
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		cf := InitializeControlFlow()
+		parent := NewBudgetedControlFlow(cf, 10, 0.5)
+		assert.Equal(t, uint32(5), parent.RemainingParallelism(10))
+
+		child := NewBudgetedControlFlow(parent, 10, 0.5)
+		assert.Equal(t, uint32(3), child.RemainingParallelism(10))
+
+		// Nodes count towards the budgets of all their parents and the execution.
+		assert.Equal(t, uint32(1), child.IncrementParallelism())
+		assert.Equal(t, uint32(1), cf.CurrentParallelism())
+		assert.Equal(t, uint32(1), child.CurrentParallelism())
+		assert.Equal(t, uint32(2), child.RemainingParallelism(10))
+		assert.Equal(t, uint32(4), parent.RemainingParallelism(10))
+		assert.Equal(t, uint32(9), cf.RemainingParallelism(10))
+
+		child.IncrementParallelism()
+		child.IncrementParallelism()
+		assert.Equal(t, uint32(0), child.RemainingParallelism(10))
+		assert.Equal(t, uint32(2), parent.RemainingParallelism(10))
+
+		// A sibling gets a share of what is left to the parent.
+		sibling := NewBudgetedControlFlow(parent, 10, 0.5)
+		assert.Equal(t, uint32(1), sibling.RemainingParallelism(10))
+	})
+
+	t.Run("execution budget exhausted", func(t *testing.T) {
+		cf := InitializeControlFlow()
+		child := NewBudgetedControlFlow(cf, 2, 0.5)
+		cf.IncrementParallelism()
+		cf.IncrementParallelism()
+		assert.Equal(t, uint32(0), child.RemainingParallelism(2))
+
+		// Parents get a budget of at least one node, which the parallelism of the execution still bounds.
+		child = NewBudgetedControlFlow(cf, 2, 0.5)
+		assert.Equal(t, uint32(0), child.RemainingParallelism(2))
+	})
+}
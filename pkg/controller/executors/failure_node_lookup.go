@@ -0,0 +1,90 @@
+package executors
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// ErrorContext captures the details of the failure that caused a workflow (or subworkflow) to execute its failure node.
+type ErrorContext struct {
+	// ID of the node whose failure caused the workflow to fail. This may be empty if the failure was not caused by a
+	// node, e.g. the workflow failed before any node could be started.
+	FailedNodeID v1alpha1.NodeID
+	Error        *core.ExecutionError
+	OccurredAt   time.Time
+}
+
+// FailureNodeLookup is a NodeLookup that is used while executing the failure node of a workflow. Along with the regular
+// node lookup it makes the context of the original failure available, so that it can be passed to the failure node.
+type FailureNodeLookup interface {
+	NodeLookup
+	GetErrorContext() ErrorContext
+}
+
+type failureNodeLookup struct {
+	NodeLookup
+	errCtx ErrorContext
+}
+
+func (f failureNodeLookup) GetErrorContext() ErrorContext {
+	return f.errCtx
+}
+
+// NodeIDsGetter is used to enumerate all the nodes in a workflow
+type NodeIDsGetter interface {
+	GetNodes() []v1alpha1.NodeID
+}
+
+// Finds the node that caused the workflow to fail. If multiple nodes have failed (this is possible with
+// FAIL_AFTER_EXECUTABLE_NODES_COMPLETE), the most recently stopped node is picked.
+func findFailedNode(ctx context.Context, w NodeIDsGetter, nl NodeLookup) (v1alpha1.NodeID, time.Time) {
+	var failedNodeID v1alpha1.NodeID
+	var failedAt time.Time
+	for _, nodeID := range w.GetNodes() {
+		status := nl.GetNodeExecutionStatus(ctx, nodeID)
+		if status == nil {
+			continue
+		}
+
+		p := status.GetPhase()
+		if p != v1alpha1.NodePhaseFailed && p != v1alpha1.NodePhaseTimedOut {
+			continue
+		}
+
+		var stoppedAt time.Time
+		if status.GetStoppedAt() != nil {
+			stoppedAt = status.GetStoppedAt().Time
+		} else if status.GetLastUpdatedAt() != nil {
+			stoppedAt = status.GetLastUpdatedAt().Time
+		}
+
+		if len(failedNodeID) == 0 || stoppedAt.After(failedAt) {
+			failedNodeID = nodeID
+			failedAt = stoppedAt
+		}
+	}
+
+	return failedNodeID, failedAt
+}
+
+// NewFailureNodeLookup creates a FailureNodeLookup for the given workflow. The error context is built from the provided
+// execution error and the statuses of the nodes in the workflow.
+func NewFailureNodeLookup(ctx context.Context, w NodeIDsGetter, nl NodeLookup, err *core.ExecutionError) FailureNodeLookup {
+	failedNodeID, failedAt := findFailedNode(ctx, w, nl)
+	if failedAt.IsZero() {
+		failedAt = time.Now()
+	}
+
+	return failureNodeLookup{
+		NodeLookup: nl,
+		errCtx: ErrorContext{
+			FailedNodeID: failedNodeID,
+			Error:        err,
+			OccurredAt:   failedAt,
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package executors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+)
+
+type nodeIDs []v1alpha1.NodeID
+
+func (n nodeIDs) GetNodes() []v1alpha1.NodeID {
+	return n
+}
+
+func newNodeStatus(phase v1alpha1.NodePhase, stoppedAt *v1.Time) *mocks.ExecutableNodeStatus {
+	s := &mocks.ExecutableNodeStatus{}
+	s.OnGetPhase().Return(phase)
+	s.OnGetStoppedAt().Return(stoppedAt)
+	s.OnGetLastUpdatedAt().Return(stoppedAt)
+	return s
+}
+
+func TestNewFailureNodeLookup(t *testing.T) {
+	ctx := context.TODO()
+	execErr := &core.ExecutionError{Code: "code", Message: "msg", Kind: core.ExecutionError_USER}
+
+	t.Run("most-recent-failure", func(t *testing.T) {
+		early := v1.NewTime(time.Now().Add(-time.Minute))
+		late := v1.NewTime(time.Now())
+		nl := NewTestNodeLookup(nil, map[v1alpha1.NodeID]v1alpha1.ExecutableNodeStatus{
+			"n1": newNodeStatus(v1alpha1.NodePhaseSucceeded, &late),
+			"n2": newNodeStatus(v1alpha1.NodePhaseFailed, &early),
+			"n3": newNodeStatus(v1alpha1.NodePhaseTimedOut, &late),
+		})
+
+		fl := NewFailureNodeLookup(ctx, nodeIDs{"n1", "n2", "n3"}, nl, execErr)
+		errCtx := fl.GetErrorContext()
+		assert.Equal(t, "n3", errCtx.FailedNodeID)
+		assert.Equal(t, execErr, errCtx.Error)
+		assert.Equal(t, late.Time, errCtx.OccurredAt)
+	})
+
+	t.Run("no-failed-node", func(t *testing.T) {
+		nl := NewTestNodeLookup(nil, map[v1alpha1.NodeID]v1alpha1.ExecutableNodeStatus{
+			"n1": newNodeStatus(v1alpha1.NodePhaseRunning, nil),
+		})
+
+		fl := NewFailureNodeLookup(ctx, nodeIDs{"n1", "missing"}, nl, execErr)
+		errCtx := fl.GetErrorContext()
+		assert.Empty(t, errCtx.FailedNodeID)
+		assert.False(t, errCtx.OccurredAt.IsZero())
+	})
+}
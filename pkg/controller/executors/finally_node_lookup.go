@@ -0,0 +1,55 @@
+package executors
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// TerminalStatus captures the terminal state a workflow reached before executing its finally nodes.
+type TerminalStatus struct {
+	Phase v1alpha1.WorkflowPhase
+	// Error the workflow failed with, nil unless the workflow failed.
+	Error *core.ExecutionError
+}
+
+// FinallyNodeLookup is a NodeLookup that is used while executing the finally nodes of a workflow. The finally nodes are
+// not part of the DAG, so along with the regular node lookup it finds them, and it makes the terminal status of the
+// workflow available so that it can be passed to them.
+type FinallyNodeLookup interface {
+	NodeLookup
+	GetTerminalStatus() TerminalStatus
+}
+
+type finallyNodeLookup struct {
+	NodeLookup
+	finallyNodes map[v1alpha1.NodeID]v1alpha1.ExecutableNode
+	status       TerminalStatus
+}
+
+func (f finallyNodeLookup) GetNode(nodeID v1alpha1.NodeID) (v1alpha1.ExecutableNode, bool) {
+	if n, ok := f.finallyNodes[nodeID]; ok {
+		return n, true
+	}
+
+	return f.NodeLookup.GetNode(nodeID)
+}
+
+func (f finallyNodeLookup) GetTerminalStatus() TerminalStatus {
+	return f.status
+}
+
+// NewFinallyNodeLookup creates a FinallyNodeLookup for the given finally nodes of a workflow that reached the terminal
+// status.
+func NewFinallyNodeLookup(nl NodeLookup, finallyNodes []v1alpha1.ExecutableNode, status TerminalStatus) FinallyNodeLookup {
+	nodes := make(map[v1alpha1.NodeID]v1alpha1.ExecutableNode, len(finallyNodes))
+	for _, n := range finallyNodes {
+		nodes[n.GetID()] = n
+	}
+
+	return finallyNodeLookup{
+		NodeLookup:   nl,
+		finallyNodes: nodes,
+		status:       status,
+	}
+}
@@ -0,0 +1,34 @@
+package executors
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestNewFinallyNodeLookup(t *testing.T) {
+	n1 := &v1alpha1.NodeSpec{ID: "n1"}
+	finally := &v1alpha1.NodeSpec{ID: "finally"}
+	status := TerminalStatus{
+		Phase: v1alpha1.WorkflowPhaseFailed,
+		Error: &core.ExecutionError{Code: "code", Message: "msg", Kind: core.ExecutionError_USER},
+	}
+
+	nl := NewFinallyNodeLookup(NewTestNodeLookup(map[v1alpha1.NodeID]v1alpha1.ExecutableNode{"n1": n1}, nil),
+		[]v1alpha1.ExecutableNode{finally}, status)
+	assert.Equal(t, status, nl.GetTerminalStatus())
+
+	n, ok := nl.GetNode("finally")
+	assert.True(t, ok)
+	assert.Equal(t, finally, n)
+
+	n, ok = nl.GetNode("n1")
+	assert.True(t, ok)
+	assert.Equal(t, n1, n)
+
+	_, ok = nl.GetNode("n2")
+	assert.False(t, ok)
+}
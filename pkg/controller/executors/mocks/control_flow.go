@@ -72,3 +72,86 @@ func (_m *ControlFlow) IncrementParallelism() uint32 {
 
 	return r0
 }
+
+type ControlFlow_ReleaseParallelism struct {
+	*mock.Call
+}
+
+func (_m *ControlFlow) OnReleaseParallelism() *ControlFlow_ReleaseParallelism {
+	c_call := _m.On("ReleaseParallelism")
+	return &ControlFlow_ReleaseParallelism{Call: c_call}
+}
+
+func (_m *ControlFlow) OnReleaseParallelismMatch(matchers ...interface{}) *ControlFlow_ReleaseParallelism {
+	c_call := _m.On("ReleaseParallelism", matchers...)
+	return &ControlFlow_ReleaseParallelism{Call: c_call}
+}
+
+// ReleaseParallelism provides a mock function with given fields:
+func (_m *ControlFlow) ReleaseParallelism() {
+	_m.Called()
+}
+
+type ControlFlow_RemainingParallelism struct {
+	*mock.Call
+}
+
+func (_m ControlFlow_RemainingParallelism) Return(_a0 uint32) *ControlFlow_RemainingParallelism {
+	return &ControlFlow_RemainingParallelism{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ControlFlow) OnRemainingParallelism(maxParallelism uint32) *ControlFlow_RemainingParallelism {
+	c_call := _m.On("RemainingParallelism", maxParallelism)
+	return &ControlFlow_RemainingParallelism{Call: c_call}
+}
+
+func (_m *ControlFlow) OnRemainingParallelismMatch(matchers ...interface{}) *ControlFlow_RemainingParallelism {
+	c_call := _m.On("RemainingParallelism", matchers...)
+	return &ControlFlow_RemainingParallelism{Call: c_call}
+}
+
+// RemainingParallelism provides a mock function with given fields: maxParallelism
+func (_m *ControlFlow) RemainingParallelism(maxParallelism uint32) uint32 {
+	ret := _m.Called(maxParallelism)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(uint32) uint32); ok {
+		r0 = rf(maxParallelism)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+type ControlFlow_ReserveParallelism struct {
+	*mock.Call
+}
+
+func (_m ControlFlow_ReserveParallelism) Return(_a0 bool) *ControlFlow_ReserveParallelism {
+	return &ControlFlow_ReserveParallelism{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ControlFlow) OnReserveParallelism(maxParallelism uint32) *ControlFlow_ReserveParallelism {
+	c_call := _m.On("ReserveParallelism", maxParallelism)
+	return &ControlFlow_ReserveParallelism{Call: c_call}
+}
+
+func (_m *ControlFlow) OnReserveParallelismMatch(matchers ...interface{}) *ControlFlow_ReserveParallelism {
+	c_call := _m.On("ReserveParallelism", matchers...)
+	return &ControlFlow_ReserveParallelism{Call: c_call}
+}
+
+// ReserveParallelism provides a mock function with given fields: maxParallelism
+func (_m *ControlFlow) ReserveParallelism(maxParallelism uint32) bool {
+	ret := _m.Called(maxParallelism)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint32) bool); ok {
+		r0 = rf(maxParallelism)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -708,3 +708,86 @@ func (_m *ExecutionContext) IsInterruptible() bool {
 
 	return r0
 }
+
+type ExecutionContext_ReleaseParallelism struct {
+	*mock.Call
+}
+
+func (_m *ExecutionContext) OnReleaseParallelism() *ExecutionContext_ReleaseParallelism {
+	c_call := _m.On("ReleaseParallelism")
+	return &ExecutionContext_ReleaseParallelism{Call: c_call}
+}
+
+func (_m *ExecutionContext) OnReleaseParallelismMatch(matchers ...interface{}) *ExecutionContext_ReleaseParallelism {
+	c_call := _m.On("ReleaseParallelism", matchers...)
+	return &ExecutionContext_ReleaseParallelism{Call: c_call}
+}
+
+// ReleaseParallelism provides a mock function with given fields:
+func (_m *ExecutionContext) ReleaseParallelism() {
+	_m.Called()
+}
+
+type ExecutionContext_RemainingParallelism struct {
+	*mock.Call
+}
+
+func (_m ExecutionContext_RemainingParallelism) Return(_a0 uint32) *ExecutionContext_RemainingParallelism {
+	return &ExecutionContext_RemainingParallelism{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutionContext) OnRemainingParallelism(maxParallelism uint32) *ExecutionContext_RemainingParallelism {
+	c_call := _m.On("RemainingParallelism", maxParallelism)
+	return &ExecutionContext_RemainingParallelism{Call: c_call}
+}
+
+func (_m *ExecutionContext) OnRemainingParallelismMatch(matchers ...interface{}) *ExecutionContext_RemainingParallelism {
+	c_call := _m.On("RemainingParallelism", matchers...)
+	return &ExecutionContext_RemainingParallelism{Call: c_call}
+}
+
+// RemainingParallelism provides a mock function with given fields: maxParallelism
+func (_m *ExecutionContext) RemainingParallelism(maxParallelism uint32) uint32 {
+	ret := _m.Called(maxParallelism)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(uint32) uint32); ok {
+		r0 = rf(maxParallelism)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+type ExecutionContext_ReserveParallelism struct {
+	*mock.Call
+}
+
+func (_m ExecutionContext_ReserveParallelism) Return(_a0 bool) *ExecutionContext_ReserveParallelism {
+	return &ExecutionContext_ReserveParallelism{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ExecutionContext) OnReserveParallelism(maxParallelism uint32) *ExecutionContext_ReserveParallelism {
+	c_call := _m.On("ReserveParallelism", maxParallelism)
+	return &ExecutionContext_ReserveParallelism{Call: c_call}
+}
+
+func (_m *ExecutionContext) OnReserveParallelismMatch(matchers ...interface{}) *ExecutionContext_ReserveParallelism {
+	c_call := _m.On("ReserveParallelism", matchers...)
+	return &ExecutionContext_ReserveParallelism{Call: c_call}
+}
+
+// ReserveParallelism provides a mock function with given fields: maxParallelism
+func (_m *ExecutionContext) ReserveParallelism(maxParallelism uint32) bool {
+	ret := _m.Called(maxParallelism)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint32) bool); ok {
+		r0 = rf(maxParallelism)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -119,3 +119,8 @@ var NodeStatusRecovered = NodeStatus{NodePhase: NodePhaseRecovered}
 func NodeStatusFailed(err *core.ExecutionError) NodeStatus {
 	return NodeStatus{NodePhase: NodePhaseFailed, Err: err}
 }
+
+// NodeStatusTimedOutErr is NodeStatusTimedOut with the error that describes which deadline of the node expired.
+func NodeStatusTimedOutErr(err *core.ExecutionError) NodeStatus {
+	return NodeStatus{NodePhase: NodePhaseTimedOut, Err: err}
+}
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type fairWorkQueueMetrics struct {
+	depth     *prometheus.GaugeVec
+	inflight  *prometheus.GaugeVec
+	throttled *prometheus.CounterVec
+	starved   *prometheus.CounterVec
+}
+
+// fairWorkQueue is a rate limited workqueue that queues items per namespace and serves the namespaces with queued items
+// round-robin, so that a namespace with many queued workflows can't starve the workflows of other namespaces. Like the
+// workqueue of client-go, an item is queued at most once, and an item that is added while it's being processed is
+// queued again once it's done, so that no two workers process the same item simultaneously.
+type fairWorkQueue struct {
+	cond *sync.Cond
+	// Namespaces with queued items, in the order they are served next. A namespace is in the ring iff it has queued
+	// items.
+	ring   []string
+	queues map[string][]interface{}
+	// Time the queued items were added at.
+	addedAt      map[interface{}]time.Time
+	processing   map[interface{}]struct{}
+	inflight     map[string]int
+	length       int
+	shuttingDown bool
+	// Items added with a delay wait here until they are ready. It keeps one entry per item, with the earliest time it's
+	// ready at, and a single timer for all of them.
+	delayed workqueue.DelayingInterface
+
+	maxInflight         int
+	starvationThreshold time.Duration
+	rateLimiter         workqueue.RateLimiter
+	clk                 clock.Clock
+	metrics             fairWorkQueueMetrics
+}
+
+// Returns the namespace of the item, which is expected to be a namespace/name key.
+func namespaceOfItem(item interface{}) string {
+	key, ok := item.(string)
+	if !ok {
+		return ""
+	}
+
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return ""
+	}
+
+	return namespace
+}
+
+func (q *fairWorkQueue) push(item interface{}) {
+	namespace := namespaceOfItem(item)
+	if len(q.queues[namespace]) == 0 {
+		q.ring = append(q.ring, namespace)
+	}
+
+	q.queues[namespace] = append(q.queues[namespace], item)
+	q.length++
+	q.metrics.depth.WithLabelValues(namespace).Inc()
+	q.cond.Signal()
+}
+
+// pop returns the next item of the first namespace in the ring that is below its inflight cap, and moves the namespace
+// to the back of the ring.
+func (q *fairWorkQueue) pop() (interface{}, bool) {
+	for i := len(q.ring); i > 0; i-- {
+		namespace := q.ring[0]
+		q.ring = q.ring[1:]
+		if q.maxInflight > 0 && q.inflight[namespace] >= q.maxInflight {
+			q.metrics.throttled.WithLabelValues(namespace).Inc()
+			q.ring = append(q.ring, namespace)
+			continue
+		}
+
+		item := q.queues[namespace][0]
+		if rest := q.queues[namespace][1:]; len(rest) > 0 {
+			q.queues[namespace] = rest
+			q.ring = append(q.ring, namespace)
+		} else {
+			delete(q.queues, namespace)
+		}
+
+		q.length--
+		q.metrics.depth.WithLabelValues(namespace).Dec()
+		q.inflight[namespace]++
+		q.metrics.inflight.WithLabelValues(namespace).Inc()
+		if q.starvationThreshold > 0 && q.clk.Since(q.addedAt[item]) > q.starvationThreshold {
+			q.metrics.starved.WithLabelValues(namespace).Inc()
+		}
+
+		delete(q.addedAt, item)
+		q.processing[item] = struct{}{}
+		return item, true
+	}
+
+	return nil, false
+}
+
+func (q *fairWorkQueue) Add(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+
+	if _, queued := q.addedAt[item]; queued {
+		return
+	}
+
+	q.addedAt[item] = q.clk.Now()
+	if _, processing := q.processing[item]; processing {
+		// Queued again once it's done.
+		return
+	}
+
+	q.push(item)
+}
+
+func (q *fairWorkQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.length
+}
+
+func (q *fairWorkQueue) Get() (item interface{}, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for {
+		if item, ok := q.pop(); ok {
+			return item, false
+		}
+
+		if q.shuttingDown {
+			return nil, true
+		}
+
+		q.cond.Wait()
+	}
+}
+
+func (q *fairWorkQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if _, processing := q.processing[item]; !processing {
+		return
+	}
+
+	delete(q.processing, item)
+	namespace := namespaceOfItem(item)
+	q.inflight[namespace]--
+	q.metrics.inflight.WithLabelValues(namespace).Dec()
+	if q.inflight[namespace] <= 0 {
+		delete(q.inflight, namespace)
+	}
+
+	if _, queued := q.addedAt[item]; queued {
+		q.push(item)
+	}
+
+	// A namespace that reached its inflight cap may be served again.
+	q.cond.Broadcast()
+}
+
+func (q *fairWorkQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+
+	q.shuttingDown = true
+	q.delayed.ShutDown()
+	q.cond.Broadcast()
+}
+
+func (q *fairWorkQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
+
+func (q *fairWorkQueue) AddAfter(item interface{}, duration time.Duration) {
+	if q.ShuttingDown() {
+		return
+	}
+
+	if duration <= 0 {
+		q.Add(item)
+		return
+	}
+
+	q.delayed.AddAfter(item, duration)
+}
+
+// Moves the items that are ready from the delaying queue to the queue of their namespace, until the queue is shut down.
+func (q *fairWorkQueue) addReady() {
+	for {
+		item, shutdown := q.delayed.Get()
+		if shutdown {
+			return
+		}
+
+		q.Add(item)
+		q.delayed.Done(item)
+	}
+}
+
+func (q *fairWorkQueue) AddRateLimited(item interface{}) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *fairWorkQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+func (q *fairWorkQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+func newFairWorkQueue(ctx context.Context, cfg config.WorkqueueConfig, fairCfg config.FairQueueConfig, clk clock.Clock,
+	scope promutils.Scope) *fairWorkQueue {
+	logger.Infof(ctx, "Serving namespaces fairly from the WorkQueue, max inflight per namespace [%v]", fairCfg.MaxInflightPerNamespace)
+	q := &fairWorkQueue{
+		cond:                sync.NewCond(&sync.Mutex{}),
+		queues:              map[string][]interface{}{},
+		addedAt:             map[interface{}]time.Time{},
+		processing:          map[interface{}]struct{}{},
+		inflight:            map[string]int{},
+		delayed:             workqueue.NewDelayingQueueWithCustomClock(clk, ""),
+		maxInflight:         fairCfg.MaxInflightPerNamespace,
+		starvationThreshold: fairCfg.StarvationThreshold.Duration,
+		rateLimiter:         newRateLimiter(ctx, cfg),
+		clk:                 clk,
+		metrics: fairWorkQueueMetrics{
+			depth:     scope.MustNewGaugeVec("depth", "Workflows queued per namespace", namespaceLabel),
+			inflight:  scope.MustNewGaugeVec("inflight", "Workflows being evaluated per namespace", namespaceLabel),
+			throttled: scope.MustNewCounterVec("throttled", "Times a namespace was skipped because it reached its inflight cap", namespaceLabel),
+			starved: scope.MustNewCounterVec("starved", "Workflows that waited in the queue for longer than the starvation threshold",
+				namespaceLabel),
+		},
+	}
+
+	go q.addReady()
+	return q
+}
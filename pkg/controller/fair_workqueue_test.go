@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func newTestFairWorkQueue(fairCfg config2.FairQueueConfig, clk clock.Clock) *fairWorkQueue {
+	return newFairWorkQueue(context.TODO(), config2.WorkqueueConfig{}, fairCfg, clk, promutils.NewTestScope())
+}
+
+func getItem(t *testing.T, q *fairWorkQueue) interface{} {
+	item, shutdown := q.Get()
+	assert.False(t, shutdown)
+	return item
+}
+
+func TestFairWorkQueue_RoundRobin(t *testing.T) {
+	q := newTestFairWorkQueue(config2.FairQueueConfig{}, clock.RealClock{})
+	for _, item := range []string{"a/1", "a/2", "a/3", "b/1", "a/1"} {
+		q.Add(item)
+	}
+
+	assert.Equal(t, 4, q.Len())
+	for _, expected := range []string{"a/1", "b/1", "a/2", "a/3"} {
+		item := getItem(t, q)
+		assert.Equal(t, expected, item)
+		q.Done(item)
+	}
+
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestFairWorkQueue_AddWhileProcessing(t *testing.T) {
+	q := newTestFairWorkQueue(config2.FairQueueConfig{}, clock.RealClock{})
+	q.Add("a/1")
+	item := getItem(t, q)
+
+	// The item is queued again once it's done, never processed by two workers simultaneously.
+	q.Add("a/1")
+	assert.Equal(t, 0, q.Len())
+	q.Done(item)
+	assert.Equal(t, 1, q.Len())
+	assert.Equal(t, "a/1", getItem(t, q))
+}
+
+func TestFairWorkQueue_MaxInflight(t *testing.T) {
+	q := newTestFairWorkQueue(config2.FairQueueConfig{MaxInflightPerNamespace: 1}, clock.RealClock{})
+	q.Add("a/1")
+	q.Add("a/2")
+	q.Add("b/1")
+
+	first := getItem(t, q)
+	assert.Equal(t, "a/1", first)
+	assert.Equal(t, "b/1", getItem(t, q))
+
+	got := make(chan interface{})
+	go func() {
+		item, _ := q.Get()
+		got <- item
+	}()
+
+	select {
+	case item := <-got:
+		assert.FailNow(t, "namespace served beyond its inflight cap", "got [%v]", item)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	q.Done(first)
+	assert.Equal(t, "a/2", <-got)
+	assert.Equal(t, float64(1), testutil.ToFloat64(q.metrics.inflight.WithLabelValues("a")))
+}
+
+func TestFairWorkQueue_Starvation(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	q := newTestFairWorkQueue(config2.FairQueueConfig{StarvationThreshold: config.Duration{Duration: time.Minute}}, fakeClock)
+	q.Add("a/1")
+	q.Add("a/2")
+	q.Done(getItem(t, q))
+
+	fakeClock.Step(2 * time.Minute)
+	q.Done(getItem(t, q))
+	assert.Equal(t, float64(1), testutil.ToFloat64(q.metrics.starved.WithLabelValues("a")))
+}
+
+func TestFairWorkQueue_ShutDown(t *testing.T) {
+	q := newTestFairWorkQueue(config2.FairQueueConfig{}, clock.RealClock{})
+	q.AddAfter("a/1", time.Hour)
+	q.ShutDown()
+	assert.True(t, q.ShuttingDown())
+
+	item, shutdown := q.Get()
+	assert.Nil(t, item)
+	assert.True(t, shutdown)
+
+	q.Add("a/1")
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestFairWorkQueue_AddAfter(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	q := newTestFairWorkQueue(config2.FairQueueConfig{}, fakeClock)
+	defer q.ShutDown()
+
+	// Only the earliest time an item waits for is kept.
+	for i := 0; i < 1000; i++ {
+		q.AddAfter("a/1", time.Minute)
+	}
+
+	q.AddAfter("a/1", 10*time.Second)
+	q.AddAfter("b/1", time.Minute)
+	assert.Equal(t, 0, q.Len())
+
+	fakeClock.Step(10 * time.Second)
+	assert.Eventually(t, func() bool { return q.Len() == 1 }, time.Second, time.Millisecond)
+	item := getItem(t, q)
+	assert.Equal(t, "a/1", item)
+	q.Done(item)
+
+	fakeClock.Step(time.Minute)
+	assert.Eventually(t, func() bool { return q.Len() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, "b/1", getItem(t, q))
+	assert.Equal(t, 0, q.Len())
+}
@@ -12,14 +12,21 @@ import (
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
+	"github.com/flyteorg/flytepropeller/events"
 	eventsErr "github.com/flyteorg/flytepropeller/events/errors"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/introspection"
+	"github.com/flyteorg/flytepropeller/pkg/controller/maintenance"
+	"github.com/flyteorg/flytepropeller/pkg/controller/roundbudget"
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 	"github.com/flyteorg/flytepropeller/pkg/controller/workflowstore"
 
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 )
@@ -33,6 +40,7 @@ type propellerMetrics struct {
 	SystemError              labeled.Counter
 	AbortError               labeled.Counter
 	PanicObserved            labeled.Counter
+	WorkflowQuarantined      labeled.Counter
 	RoundSkipped             prometheus.Counter
 	WorkflowNotFound         prometheus.Counter
 	StreakLength             labeled.Counter
@@ -48,6 +56,7 @@ func newPropellerMetrics(scope promutils.Scope) *propellerMetrics {
 		SystemError:              labeled.NewCounter("system_error", "Failure to reconcile a workflow, system error", roundScope, labeled.EmitUnlabeledMetric),
 		AbortError:               labeled.NewCounter("abort_error", "Failure to abort a workflow, system error", roundScope, labeled.EmitUnlabeledMetric),
 		PanicObserved:            labeled.NewCounter("panic", "Panic during handling or aborting workflow", roundScope, labeled.EmitUnlabeledMetric),
+		WorkflowQuarantined:      labeled.NewCounter("quarantined", "Workflow quarantined because it panicked repeatedly", roundScope, labeled.EmitUnlabeledMetric),
 		RoundSkipped:             roundScope.MustNewCounter("skipped", "Round Skipped because of stale workflow"),
 		WorkflowNotFound:         roundScope.MustNewCounter("not_found", "workflow not found in the cache"),
 		StreakLength:             labeled.NewCounter("streak_length", "Number of consecutive rounds used in fast follow mode", roundScope, labeled.EmitUnlabeledMetric),
@@ -71,11 +80,31 @@ type Propeller struct {
 	workflowExecutor executors.Workflow
 	metrics          *propellerMetrics
 	cfg              *config.Config
+	recorder         *introspection.Recorder
+	concurrencyGate  *concurrencyGate
+	evaluationCache  *workflowEvaluationCache
+	tracer           *tracing.Tracer
+	roundBudgets     *roundbudget.Budgets
+	// clk is the clock workflows are evaluated with, it's carried to the node executor by the context of every round.
+	clk clock.Clock
+	// progressReporter emits the progress of running workflows after rounds that updated them, if enabled.
+	progressReporter *workflowProgressReporter
+	// circuitBreaker holds back new workflows of launch plans whose workflows failed repeatedly, if enabled.
+	circuitBreaker *circuitBreaker
+	// maintenance tells whether propeller is in maintenance mode, it's carried to the task plugins by the context of
+	// every round.
+	maintenance *MaintenanceMonitor
+	// requeueWorkflow adds the workflow back to the end of the work queue, it's used to resume rounds that yielded
+	// because they used up their budget.
+	requeueWorkflow func(namespace, name string)
+	// dataPlanes pins workflows to the data plane cluster their tasks run in when they start, if routing to data planes
+	// is enabled.
+	dataPlanes executors.DataPlaneSelector
 }
 
 // Initializes all downstream executors
 func (p *Propeller) Initialize(ctx context.Context) error {
-	return p.workflowExecutor.Initialize(ctx)
+	return p.workflowExecutor.Initialize(clocks.WithClock(ctx, p.clk))
 }
 
 // TryMutateWorkflow will try to mutate the workflow by traversing it and reconciling the desired and actual state.
@@ -83,13 +112,19 @@ func (p *Propeller) Initialize(ctx context.Context) error {
 func (p *Propeller) TryMutateWorkflow(ctx context.Context, originalW *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
 
 	t := p.metrics.DeepCopyTime.Start()
-	mutableW := originalW.DeepCopy()
+	mutableW := p.evaluationCache.DeepCopy(ctx, originalW)
 	t.Stop()
 	ctx = contextutils.WithWorkflowID(ctx, mutableW.GetID())
 	if execID := mutableW.GetExecutionID(); execID.WorkflowExecutionIdentifier != nil {
 		ctx = contextutils.WithProjectDomain(ctx, mutableW.GetExecutionID().Project, mutableW.GetExecutionID().Domain)
 	}
 	ctx = contextutils.WithResourceVersion(ctx, mutableW.GetResourceVersion())
+	ctx = events.WithExecutionLabels(ctx, mutableW.GetLabels())
+	ctx = events.WithEventQoS(ctx, mutableW.GetExecutionConfig().EventQoS)
+	ctx = p.tracer.WithTracing(ctx, mutableW.GetAnnotations())
+	ctx, span := tracing.StartRound(ctx, mutableW)
+	defer span.End()
+	ctx = executors.WithDataPlane(ctx, mutableW.GetAnnotations()[executors.DataPlaneAnnotation])
 
 	maxRetries := uint32(p.cfg.MaxWorkflowRetries)
 	if IsDeleted(mutableW) || (mutableW.Status.FailedAttempts > maxRetries) {
@@ -97,8 +132,7 @@ func (p *Propeller) TryMutateWorkflow(ctx context.Context, originalW *v1alpha1.F
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					stack := debug.Stack()
-					err = fmt.Errorf("panic when aborting workflow, Stack: [%s]", string(stack))
+					err = &workflowPanicError{operation: "aborting", stack: debug.Stack()}
 					logger.Errorf(ctx, err.Error())
 					p.metrics.PanicObserved.Inc(ctx)
 				}
@@ -116,13 +150,27 @@ func (p *Propeller) TryMutateWorkflow(ctx context.Context, originalW *v1alpha1.F
 		var err error
 		SetFinalizerIfEmpty(mutableW, FinalizerKey)
 
+		if isWaitingForAdmission(mutableW) {
+			admitted, err := p.admitWorkflow(ctx, mutableW)
+			if err != nil {
+				logger.Errorf(ctx, "Failed to check the concurrency limits of the workflow. Error [%v]", err)
+				p.metrics.SystemError.Inc(ctx)
+				return nil, err
+			}
+
+			if !admitted {
+				return mutableW, nil
+			}
+
+			ctx = p.pinDataPlane(ctx, mutableW)
+		}
+
 		func() {
 			t := p.metrics.RawWorkflowTraversalTime.Start(ctx)
 			defer func() {
 				t.Stop()
 				if r := recover(); r != nil {
-					stack := debug.Stack()
-					err = fmt.Errorf("panic when reconciling workflow, Stack: [%s]", string(stack))
+					err = &workflowPanicError{operation: "reconciling", stack: debug.Stack()}
 					logger.Errorf(ctx, err.Error())
 					p.metrics.PanicObserved.Inc(ctx)
 				}
@@ -141,6 +189,76 @@ func (p *Propeller) TryMutateWorkflow(ctx context.Context, originalW *v1alpha1.F
 	return mutableW, nil
 }
 
+// pinDataPlane pins a workflow that is about to start to the data plane cluster its tasks run in, and returns the
+// context of the round routed to it. The cluster is recorded in an annotation, so that all tasks of the workflow run in
+// the same cluster, even if its health changes. Workflows that started before they were pinned run in the local cluster.
+func (p *Propeller) pinDataPlane(ctx context.Context, w *v1alpha1.FlyteWorkflow) context.Context {
+	if p.dataPlanes == nil {
+		return ctx
+	}
+
+	if _, pinned := w.GetAnnotations()[executors.DataPlaneAnnotation]; pinned {
+		return ctx
+	}
+
+	name := p.dataPlanes.SelectDataPlane(ctx, w.GetExecutionID().GetProject(), w.GetExecutionID().GetDomain())
+	annotations := make(map[string]string, len(w.GetAnnotations())+1)
+	for k, v := range w.GetAnnotations() {
+		annotations[k] = v
+	}
+
+	annotations[executors.DataPlaneAnnotation] = name
+	w.SetAnnotations(annotations)
+	return executors.WithDataPlane(ctx, name)
+}
+
+// recordPanic counts a round of the workflow that panicked, and quarantines the workflow once too many of its rounds
+// panicked. A workflow that panics on every round would otherwise be evaluated over and over again, taking up workers and
+// flooding the logs, without ever making progress.
+func (p *Propeller) recordPanic(ctx context.Context, w *v1alpha1.FlyteWorkflow) {
+	w.GetExecutionStatus().IncPanics()
+	maxPanics := uint32(p.cfg.MaxWorkflowPanics)
+	if maxPanics == 0 || w.Status.Panics < maxPanics {
+		return
+	}
+
+	logger.Errorf(ctx, "Workflow panicked [%d] times, quarantining it.", w.Status.Panics)
+	p.metrics.WorkflowQuarantined.Inc(ctx)
+	SetQuarantinedLabel(w)
+	w.GetExecutionStatus().SetMessage(fmt.Sprintf(
+		"Workflow quarantined after panicking [%d] times, remove the [%s] label to evaluate it again. Last error: %s",
+		w.Status.Panics, workflowQuarantinedKey, w.GetExecutionStatus().GetMessage()))
+	// A workflow that is released from quarantine gets to panic as many times again before it is quarantined again.
+	w.Status.Panics = 0
+}
+
+// admitWorkflow checks whether a workflow that has not started yet is allowed to start by the circuit breaker and the
+// concurrency limits. A workflow that is not allowed to start is moved to the Queued phase, a queued workflow that is
+// allowed to start is moved back to the Ready phase.
+func (p *Propeller) admitWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) (bool, error) {
+	if admitted, msg := p.circuitBreaker.Admit(ctx, w); !admitted {
+		w.Status.UpdatePhase(v1alpha1.WorkflowPhaseQueued, msg, nil)
+		return false, nil
+	}
+
+	admitted, msg, err := p.concurrencyGate.Admit(ctx, w)
+	if err != nil {
+		return false, err
+	}
+
+	if !admitted {
+		w.Status.UpdatePhase(v1alpha1.WorkflowPhaseQueued, msg, nil)
+		return false, nil
+	}
+
+	if w.GetExecutionStatus().GetPhase() == v1alpha1.WorkflowPhaseQueued {
+		logger.Infof(ctx, "Queued workflow admitted.")
+		w.Status.UpdatePhase(v1alpha1.WorkflowPhaseReady, "", nil)
+	}
+
+	return true, nil
+}
+
 // Handle method is the entry point for the reconciler.
 // It compares the actual state with the desired, and attempts to
 // converge the two. It then updates the GetExecutionStatus block of the FlyteWorkflow resource
@@ -151,7 +269,13 @@ func (p *Propeller) TryMutateWorkflow(ctx context.Context, originalW *v1alpha1.F
 // The return value should be an error, in the case, we wish to retry this workflow
 // <pre>
 //
-//     +--------+        +---------+        +------------+     +---------+
+//     +--------+
+//     |        |
+//     | Queued |
+//     |        |
+//     +--^--+--+
+//        |  |
+//     +--+--v--+        +---------+        +------------+     +---------+
 //     |        |        |         |        |            |     |         |
 //     | Ready  +--------> Running +--------> Succeeding +-----> Success |
 //     |        |        |         |        |            |     |         |
@@ -185,6 +309,11 @@ func (p *Propeller) Handle(ctx context.Context, namespace, name string) error {
 		return fetchErr
 	}
 
+	if IsQuarantined(w) {
+		logger.Warningf(ctx, "Workflow namespace[%v]/name[%v] is quarantined, skipping.", namespace, name)
+		return nil
+	}
+
 	if w.GetExecutionStatus().IsTerminated() {
 		if HasCompletedLabel(w) && !HasFinalizer(w) {
 			logger.Debugf(ctx, "Workflow is terminated.")
@@ -202,12 +331,18 @@ func (p *Propeller) Handle(ctx context.Context, namespace, name string) error {
 
 	for streak = 0; streak < maxLength; streak++ {
 		t := p.metrics.RoundTime.Start(ctx)
-		mutatedWf, err := p.TryMutateWorkflow(ctx, w)
+		roundCtx := clocks.WithClock(p.roundBudgets.WithBudget(ctx), p.clk)
+		roundCtx = maintenance.WithMaintenance(roundCtx, p.maintenance.Enabled())
+		mutatedWf, err := p.TryMutateWorkflow(roundCtx, w)
+		p.recorder.Record(w, mutatedWf, err)
 		if err != nil {
 			// NOTE We are overriding the deepcopy here, as we are essentially ingnoring all mutations
 			// We only want to increase failed attempts and discard any other partial changes to the CRD.
 			mutatedWf = RecordSystemError(w, err)
 			p.metrics.SystemError.Inc(ctx)
+			if IsWorkflowPanic(err) {
+				p.recordPanic(ctx, mutatedWf)
+			}
 		} else if mutatedWf == nil {
 			logger.Errorf(ctx, "Should not happen! Mutation resulted in a nil workflow!")
 			return nil
@@ -217,6 +352,7 @@ func (p *Propeller) Handle(ctx context.Context, namespace, name string) error {
 				if mutatedWf.Status.Equals(&w.Status) {
 					logger.Info(ctx, "WF hasn't been updated in this round.")
 					t.Stop()
+					p.requeueIfYielded(roundCtx, namespace, name)
 					return nil
 				}
 			}
@@ -303,6 +439,16 @@ func (p *Propeller) Handle(ctx context.Context, namespace, name string) error {
 			// An error was encountered during the round. Let us return, so that we can back-off gracefully
 			return err
 		}
+		p.progressReporter.Report(ctx, mutatedWf)
+		if !w.GetExecutionStatus().IsTerminated() && mutatedWf.GetExecutionStatus().IsTerminated() {
+			p.circuitBreaker.Record(ctx, mutatedWf)
+		}
+		if mutatedWf.GetExecutionStatus().IsTerminated() {
+			p.evaluationCache.Evict(namespace, name)
+		} else {
+			p.evaluationCache.Advance(namespace, name, mutatedWf.ResourceVersion, newWf.ResourceVersion)
+		}
+
 		if mutatedWf.GetExecutionStatus().IsTerminated() || newWf.ResourceVersion == mutatedWf.ResourceVersion {
 			// Workflow is terminated (no need to continue) or no status was changed, we can wait
 			logger.Infof(ctx, "Will not fast follow, Reason: Wf terminated? %v, Version matched? %v",
@@ -310,6 +456,14 @@ func (p *Propeller) Handle(ctx context.Context, namespace, name string) error {
 			t.Stop()
 			return nil
 		}
+		if roundbudget.IsExhausted(roundCtx) {
+			// The workflow used up the budget of the round, it gets its next round once the other queued workflows had
+			// theirs.
+			logger.Infof(ctx, "Will not fast follow, Reason: Round budget exhausted. StreakLength [%d]", streak)
+			p.requeueIfYielded(roundCtx, namespace, name)
+			t.Stop()
+			return nil
+		}
 		logger.Infof(ctx, "FastFollow Enabled. Detected State change, we will try another round. StreakLength [%d]", streak)
 		w = newWf
 		t.Stop()
@@ -318,14 +472,32 @@ func (p *Propeller) Handle(ctx context.Context, namespace, name string) error {
 	return nil
 }
 
+// Requeues the workflow if its round yielded before it evaluated all its nodes.
+func (p *Propeller) requeueIfYielded(roundCtx context.Context, namespace, name string) {
+	if p.requeueWorkflow != nil && roundbudget.IsExhausted(roundCtx) {
+		p.requeueWorkflow(namespace, name)
+	}
+}
+
 // NewPropellerHandler creates a new Propeller and initializes metrics
-func NewPropellerHandler(_ context.Context, cfg *config.Config, wfStore workflowstore.FlyteWorkflow, executor executors.Workflow, scope promutils.Scope) *Propeller {
+func NewPropellerHandler(ctx context.Context, cfg *config.Config, wfStore workflowstore.FlyteWorkflow, executor executors.Workflow, scope promutils.Scope) *Propeller {
 
 	metrics := newPropellerMetrics(scope)
+	var evaluationCache *workflowEvaluationCache
+	if cfg.EvaluationCache.Enabled {
+		evaluationCache = newWorkflowEvaluationCache(cfg.EvaluationCache.Size, cfg.EvaluationCache.MaxSizeBytes,
+			cfg.EvaluationCache.TTL.Duration, scope.NewSubScope("evaluation_cache"))
+	}
+
 	return &Propeller{
 		metrics:          metrics,
 		wfStore:          wfStore,
 		workflowExecutor: executor,
 		cfg:              cfg,
+		recorder:         introspection.DefaultRecorder(),
+		evaluationCache:  evaluationCache,
+		tracer:           tracing.NewTracer(cfg.VerboseTracing, scope.NewSubScope("verbose_tracing"), clocks.FromContext(ctx)),
+		roundBudgets:     roundbudget.NewBudgets(cfg.RoundBudget, scope.NewSubScope("round_budget")),
+		clk:              clocks.FromContext(ctx),
 	}
 }
@@ -15,6 +15,7 @@ import (
 
 	eventErrors "github.com/flyteorg/flytepropeller/events/errors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	workflowErrors "github.com/flyteorg/flytepropeller/pkg/controller/workflow/errors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/workflowstore"
 
@@ -731,6 +732,114 @@ func TestPropeller_Handle_TurboMode(t *testing.T) {
 
 }
 
+func TestPropeller_Handle_Quarantine(t *testing.T) {
+	scope := promutils.NewTestScope()
+	ctx := context.TODO()
+	s := workflowstore.NewInMemoryWorkflowStore()
+	exec := &mockExecutor{}
+	cfg := &config.Config{
+		MaxWorkflowRetries: 10,
+		MaxWorkflowPanics:  2,
+	}
+
+	p := NewPropellerHandler(ctx, cfg, s, exec, scope)
+
+	const namespace = "test"
+	const name = "123"
+	assert.NoError(t, s.Create(ctx, &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "w1",
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowPhaseRunning,
+		},
+	}))
+
+	handled := 0
+	exec.HandleCb = func(ctx context.Context, w *v1alpha1.FlyteWorkflow) error {
+		handled++
+		panic("error")
+	}
+
+	assert.Error(t, p.Handle(ctx, namespace, name))
+	r, err := s.Get(ctx, namespace, name)
+	assert.NoError(t, err)
+	assert.False(t, IsQuarantined(r))
+	assert.Equal(t, uint32(1), r.Status.Panics)
+
+	assert.Error(t, p.Handle(ctx, namespace, name))
+	r, err = s.Get(ctx, namespace, name)
+	assert.NoError(t, err)
+	assert.True(t, IsQuarantined(r))
+	assert.Equal(t, uint32(0), r.Status.Panics)
+	assert.Equal(t, uint32(2), r.Status.FailedAttempts)
+	assert.Equal(t, v1alpha1.WorkflowPhaseRunning, r.GetExecutionStatus().GetPhase())
+	assert.Contains(t, r.GetExecutionStatus().GetMessage(), "quarantined")
+
+	// Quarantined workflows are no longer evaluated.
+	assert.NoError(t, p.Handle(ctx, namespace, name))
+	assert.Equal(t, 2, handled)
+}
+
+type fakeDataPlaneSelector struct {
+	selected int
+}
+
+func (f *fakeDataPlaneSelector) SelectDataPlane(ctx context.Context, project, domain string) string {
+	f.selected++
+	return project + "-" + domain
+}
+
+func TestPropeller_Handle_DataPlane(t *testing.T) {
+	scope := promutils.NewTestScope()
+	ctx := context.TODO()
+	s := workflowstore.NewInMemoryWorkflowStore()
+	exec := &mockExecutor{}
+	cfg := &config.Config{
+		MaxWorkflowRetries: 0,
+	}
+
+	p := NewPropellerHandler(ctx, cfg, s, exec, scope)
+	selector := &fakeDataPlaneSelector{}
+	p.dataPlanes = selector
+
+	const namespace = "test"
+	const name = "123"
+	assert.NoError(t, s.Create(ctx, &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "w1",
+		},
+		ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "flytesnacks", Domain: "production", Name: name},
+		},
+	}))
+
+	exec.HandleCb = func(ctx context.Context, w *v1alpha1.FlyteWorkflow) error {
+		w.GetExecutionStatus().UpdatePhase(v1alpha1.WorkflowPhaseRunning, "running", nil)
+		return nil
+	}
+
+	assert.NoError(t, p.Handle(ctx, namespace, name))
+	r, err := s.Get(ctx, namespace, name)
+	assert.NoError(t, err)
+	assert.Equal(t, "flytesnacks-production", r.GetAnnotations()[executors.DataPlaneAnnotation])
+
+	// Started workflows stay in the cluster they are pinned to.
+	assert.NoError(t, p.Handle(ctx, namespace, name))
+	r, err = s.Get(ctx, namespace, name)
+	assert.NoError(t, err)
+	assert.Equal(t, "flytesnacks-production", r.GetAnnotations()[executors.DataPlaneAnnotation])
+	assert.Equal(t, 1, selector.selected)
+}
+
 func TestPropellerHandler_Initialize(t *testing.T) {
 	scope := promutils.NewTestScope()
 	ctx := context.TODO()
@@ -0,0 +1,34 @@
+package introspection
+
+import (
+	ctrlConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = &Config{
+		Enabled:        false,
+		MaxWorkflows:   1000,
+		MaxTransitions: 50,
+	}
+
+	configSection = ctrlConfig.MustRegisterSubSection("introspection", defaultConfig)
+)
+
+// Config for the introspection endpoint that is served on the profiling port. It exposes the last evaluated state of
+// workflows to debug executions that are stuck without reporting any errors.
+type Config struct {
+	Enabled        bool   `json:"enabled" pflag:",Enables recording of evaluated workflows and serving them on the profiling port."`
+	TokenPath      string `json:"tokenPath" pflag:",Path to a file holding the bearer token required to query the introspection endpoint."`
+	MaxWorkflows   int    `json:"maxWorkflows" pflag:",Maximum number of workflows to keep the last evaluated state for."`
+	MaxTransitions int    `json:"maxTransitions" pflag:",Maximum number of node transitions to keep per workflow."`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
+
+func SetConfig(cfg *Config) error {
+	return configSection.SetConfig(*cfg)
+}
@@ -0,0 +1,91 @@
+package introspection
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// WorkflowsPath lists all recorded workflows, or returns the snapshot of a single workflow if the namespace and
+	// name query parameters are set.
+	WorkflowsPath = "/debug/workflows"
+
+	bearerPrefix = "Bearer "
+)
+
+type handler struct {
+	recorder *Recorder
+	token    []byte
+}
+
+func (h handler) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, bearerPrefix)), h.token) == 1
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	if len(name) == 0 {
+		writeJSON(r.Context(), w, h.recorder.List())
+		return
+	}
+
+	snapshot, ok := h.recorder.Get(namespace, name)
+	if !ok {
+		http.Error(w, "workflow has not been evaluated by this instance", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(r.Context(), w, snapshot)
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warnf(ctx, "Failed to write introspection response. Error: %v", err)
+	}
+}
+
+// NewHandlers returns the handlers to register on the profiling server. No handlers are returned if introspection is
+// disabled. The endpoint exposes plugin states, so a token is required whenever it is enabled.
+func NewHandlers(ctx context.Context, cfg *Config, recorder *Recorder) (map[string]http.Handler, error) {
+	if !cfg.Enabled || recorder == nil {
+		return nil, nil
+	}
+
+	if len(cfg.TokenPath) == 0 {
+		return nil, errors.New("introspection is enabled but no token path is configured")
+	}
+
+	raw, err := ioutil.ReadFile(cfg.TokenPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read introspection token from [%v]", cfg.TokenPath)
+	}
+
+	token := strings.TrimSpace(string(raw))
+	if len(token) == 0 {
+		return nil, errors.Errorf("introspection token in [%v] is empty", cfg.TokenPath)
+	}
+
+	logger.Infof(ctx, "Serving workflow introspection on [%v]", WorkflowsPath)
+	return map[string]http.Handler{
+		WorkflowsPath: handler{recorder: recorder, token: []byte(token)},
+	}, nil
+}
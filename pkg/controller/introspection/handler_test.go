@@ -0,0 +1,78 @@
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestNewHandlers(t *testing.T) {
+	ctx := context.TODO()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, ioutil.WriteFile(tokenPath, []byte("secret\n"), 0600))
+
+	t.Run("disabled", func(t *testing.T) {
+		handlers, err := NewHandlers(ctx, &Config{}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, handlers)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		cfg := &Config{Enabled: true}
+		_, err := NewHandlers(ctx, cfg, NewRecorder(cfg))
+		assert.Error(t, err)
+	})
+
+	cfg := &Config{Enabled: true, TokenPath: tokenPath}
+	r := NewRecorder(cfg)
+	r.Record(newWorkflow("wf", v1alpha1.WorkflowPhaseReady, nil), newWorkflow("wf", v1alpha1.WorkflowPhaseRunning, nil), nil)
+	handlers, err := NewHandlers(ctx, cfg, r)
+	assert.NoError(t, err)
+	h := handlers[WorkflowsPath]
+	assert.NotNil(t, h)
+
+	serve := func(target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(WorkflowsPath, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(WorkflowsPath, "wrong").Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		w := serve(WorkflowsPath, "secret")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var keys []string
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &keys))
+		assert.Equal(t, []string{"ns/wf"}, keys)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		w := serve(WorkflowsPath+"?namespace=ns&name=wf", "secret")
+		assert.Equal(t, http.StatusOK, w.Code)
+		s := WorkflowSnapshot{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &s))
+		assert.Equal(t, v1alpha1.WorkflowPhaseRunning, s.Status.Phase)
+		assert.Len(t, s.Transitions, 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(WorkflowsPath+"?namespace=ns&name=other", "secret").Code)
+	})
+}
@@ -0,0 +1,262 @@
+// Package introspection keeps the last evaluated state of workflows in memory and serves it on the profiling port.
+// This is meant for debugging executions that are stuck in production without reporting any errors.
+package introspection
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// TransitionKind identifies which part of the status transitioned.
+type TransitionKind = string
+
+const (
+	TransitionKindWorkflow TransitionKind = "workflow"
+	TransitionKindNode     TransitionKind = "node"
+	// TransitionKindTask is a change of the plugin phase or phase version of a task node.
+	TransitionKindTask TransitionKind = "task"
+)
+
+// Transition is a change of phase observed between two consecutive evaluations of a workflow.
+type Transition struct {
+	Kind TransitionKind `json:"kind"`
+	// NodeID is the fully qualified node id, sub nodes are separated from their parents with a '/'. It is empty for
+	// workflow transitions.
+	NodeID     string    `json:"nodeId,omitempty"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Message    string    `json:"message,omitempty"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+// WorkflowSnapshot is the state of a workflow as of its last evaluation round.
+type WorkflowSnapshot struct {
+	Namespace       string                   `json:"namespace"`
+	Name            string                   `json:"name"`
+	ResourceVersion string                   `json:"resourceVersion"`
+	EvaluatedAt     time.Time                `json:"evaluatedAt"`
+	Rounds          int                      `json:"rounds"`
+	Error           string                   `json:"error,omitempty"`
+	Status          *v1alpha1.WorkflowStatus `json:"status"`
+	// Transitions observed in the most recent rounds, oldest first.
+	Transitions []Transition `json:"transitions"`
+}
+
+// Recorder keeps the last evaluated state of a bounded number of workflows. A nil Recorder is valid and records
+// nothing.
+type Recorder struct {
+	lock           sync.RWMutex
+	maxWorkflows   int
+	maxTransitions int
+	workflows      map[string]*WorkflowSnapshot
+}
+
+func workflowKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Record stores the result of an evaluation round. before is the workflow as it was read from the store and after
+// is the workflow after the round, which is nil if the round failed.
+func (r *Recorder) Record(before, after *v1alpha1.FlyteWorkflow, evalErr error) {
+	if r == nil || before == nil {
+		return
+	}
+
+	now := time.Now()
+	var transitions []Transition
+	if after == nil {
+		after = before
+	} else {
+		transitions = diffWorkflowStatus(&before.Status, &after.Status, now)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := workflowKey(after.GetNamespace(), after.GetName())
+	snapshot, ok := r.workflows[key]
+	if !ok {
+		r.evictIfFull()
+		snapshot = &WorkflowSnapshot{Namespace: after.GetNamespace(), Name: after.GetName()}
+		r.workflows[key] = snapshot
+	}
+
+	snapshot.ResourceVersion = after.GetResourceVersion()
+	snapshot.EvaluatedAt = now
+	snapshot.Rounds++
+	snapshot.Status = after.Status.DeepCopy()
+	snapshot.Error = ""
+	if evalErr != nil {
+		snapshot.Error = evalErr.Error()
+	}
+
+	snapshot.Transitions = append(snapshot.Transitions, transitions...)
+	if extra := len(snapshot.Transitions) - r.maxTransitions; extra > 0 {
+		snapshot.Transitions = append([]Transition(nil), snapshot.Transitions[extra:]...)
+	}
+}
+
+// Get returns a copy of the last recorded snapshot of the workflow.
+func (r *Recorder) Get(namespace, name string) (WorkflowSnapshot, bool) {
+	if r == nil {
+		return WorkflowSnapshot{}, false
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	snapshot, ok := r.workflows[workflowKey(namespace, name)]
+	if !ok {
+		return WorkflowSnapshot{}, false
+	}
+
+	res := *snapshot
+	res.Transitions = append([]Transition(nil), snapshot.Transitions...)
+	return res, true
+}
+
+// List returns the namespace/name keys of all workflows that have a recorded snapshot.
+func (r *Recorder) List() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	keys := make([]string, 0, len(r.workflows))
+	for key := range r.workflows {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// evictIfFull drops the snapshot that was evaluated the longest time ago. Must be called with the lock held.
+func (r *Recorder) evictIfFull() {
+	if len(r.workflows) < r.maxWorkflows {
+		return
+	}
+
+	oldestKey := ""
+	var oldest time.Time
+	for key, s := range r.workflows {
+		if len(oldestKey) == 0 || s.EvaluatedAt.Before(oldest) {
+			oldestKey = key
+			oldest = s.EvaluatedAt
+		}
+	}
+
+	delete(r.workflows, oldestKey)
+}
+
+func diffWorkflowStatus(before, after *v1alpha1.WorkflowStatus, now time.Time) []Transition {
+	var transitions []Transition
+	if before.Phase != after.Phase {
+		transitions = append(transitions, Transition{
+			Kind:       TransitionKindWorkflow,
+			From:       before.Phase.String(),
+			To:         after.Phase.String(),
+			Message:    after.Message,
+			ObservedAt: now,
+		})
+	}
+
+	return diffNodeStatuses("", before.NodeStatus, after.NodeStatus, now, transitions)
+}
+
+func diffNodeStatuses(parent string, before, after map[v1alpha1.NodeID]*v1alpha1.NodeStatus, now time.Time, transitions []Transition) []Transition {
+	for nodeID, afterStatus := range after {
+		if afterStatus == nil {
+			continue
+		}
+
+		fqID := nodeID
+		if len(parent) > 0 {
+			fqID = parent + "/" + nodeID
+		}
+
+		beforeStatus := before[nodeID]
+		if beforeStatus == nil {
+			beforeStatus = &v1alpha1.NodeStatus{}
+		}
+
+		if beforeStatus.Phase != afterStatus.Phase {
+			transitions = append(transitions, Transition{
+				Kind:       TransitionKindNode,
+				NodeID:     fqID,
+				From:       beforeStatus.Phase.String(),
+				To:         afterStatus.Phase.String(),
+				Message:    afterStatus.Message,
+				ObservedAt: now,
+			})
+		}
+
+		if afterStatus.TaskNodeStatus != nil {
+			from := taskPhase(beforeStatus.TaskNodeStatus)
+			to := taskPhase(afterStatus.TaskNodeStatus)
+			if from != to {
+				transitions = append(transitions, Transition{
+					Kind:       TransitionKindTask,
+					NodeID:     fqID,
+					From:       from,
+					To:         to,
+					ObservedAt: now,
+				})
+			}
+		}
+
+		transitions = diffNodeStatuses(fqID, beforeStatus.SubNodeStatus, afterStatus.SubNodeStatus, now, transitions)
+	}
+
+	return transitions
+}
+
+func taskPhase(s *v1alpha1.TaskNodeStatus) string {
+	if s == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d/%d", s.Phase, s.PhaseVersion)
+}
+
+// NewRecorder creates a recorder from the config, it returns nil if introspection is disabled.
+func NewRecorder(cfg *Config) *Recorder {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	r := &Recorder{
+		maxWorkflows:   cfg.MaxWorkflows,
+		maxTransitions: cfg.MaxTransitions,
+		workflows:      make(map[string]*WorkflowSnapshot),
+	}
+
+	if r.maxWorkflows <= 0 {
+		r.maxWorkflows = defaultConfig.MaxWorkflows
+	}
+
+	if r.maxTransitions <= 0 {
+		r.maxTransitions = defaultConfig.MaxTransitions
+	}
+
+	return r
+}
+
+var (
+	defaultRecorder     *Recorder
+	defaultRecorderOnce sync.Once
+)
+
+// DefaultRecorder returns the process wide recorder, shared by the workflow evaluation loop and the profiling server.
+// It must only be called once the configuration has been loaded.
+func DefaultRecorder() *Recorder {
+	defaultRecorderOnce.Do(func() {
+		defaultRecorder = NewRecorder(GetConfig())
+	})
+
+	return defaultRecorder
+}
@@ -0,0 +1,96 @@
+package introspection
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func newWorkflow(name string, phase v1alpha1.WorkflowPhase, nodes map[v1alpha1.NodeID]*v1alpha1.NodeStatus) *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{Namespace: "ns", Name: name},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:      phase,
+			NodeStatus: nodes,
+		},
+	}
+}
+
+func TestNewRecorder(t *testing.T) {
+	assert.Nil(t, NewRecorder(&Config{}))
+	assert.NotNil(t, NewRecorder(&Config{Enabled: true}))
+
+	var r *Recorder
+	r.Record(newWorkflow("wf", v1alpha1.WorkflowPhaseReady, nil), nil, nil)
+	_, ok := r.Get("ns", "wf")
+	assert.False(t, ok)
+	assert.Empty(t, r.List())
+}
+
+func TestRecorder_Record(t *testing.T) {
+	r := NewRecorder(&Config{Enabled: true, MaxWorkflows: 2, MaxTransitions: 3})
+
+	before := newWorkflow("wf", v1alpha1.WorkflowPhaseRunning, map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+		"n0": {Phase: v1alpha1.NodePhaseRunning, TaskNodeStatus: &v1alpha1.TaskNodeStatus{Phase: 1}},
+	})
+	after := newWorkflow("wf", v1alpha1.WorkflowPhaseRunning, map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+		"n0": {
+			Phase:          v1alpha1.NodePhaseRunning,
+			TaskNodeStatus: &v1alpha1.TaskNodeStatus{Phase: 2, PluginState: []byte("state")},
+			SubNodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				"n1": {Phase: v1alpha1.NodePhaseQueued},
+			},
+		},
+	})
+
+	t.Run("transitions", func(t *testing.T) {
+		r.Record(before, after, nil)
+		s, ok := r.Get("ns", "wf")
+		assert.True(t, ok)
+		assert.Equal(t, 1, s.Rounds)
+		assert.Empty(t, s.Error)
+		assert.Equal(t, []byte("state"), s.Status.NodeStatus["n0"].TaskNodeStatus.PluginState)
+		assert.Len(t, s.Transitions, 2)
+		for _, tr := range s.Transitions {
+			switch tr.Kind {
+			case TransitionKindTask:
+				assert.Equal(t, "n0", tr.NodeID)
+				assert.Equal(t, "1/0", tr.From)
+				assert.Equal(t, "2/0", tr.To)
+			case TransitionKindNode:
+				assert.Equal(t, "n0/n1", tr.NodeID)
+				assert.Equal(t, v1alpha1.NodePhaseQueued.String(), tr.To)
+			default:
+				assert.Fail(t, "unexpected transition", tr)
+			}
+		}
+	})
+
+	t.Run("failed round", func(t *testing.T) {
+		r.Record(after, nil, fmt.Errorf("boom"))
+		s, ok := r.Get("ns", "wf")
+		assert.True(t, ok)
+		assert.Equal(t, 2, s.Rounds)
+		assert.Equal(t, "boom", s.Error)
+		assert.Len(t, s.Transitions, 2)
+	})
+
+	t.Run("bounded transitions", func(t *testing.T) {
+		r.Record(after, newWorkflow("wf", v1alpha1.WorkflowPhaseSucceeding, after.Status.NodeStatus), nil)
+		r.Record(after, newWorkflow("wf", v1alpha1.WorkflowPhaseFailing, after.Status.NodeStatus), nil)
+		s, _ := r.Get("ns", "wf")
+		assert.Len(t, s.Transitions, 3)
+		assert.Equal(t, TransitionKindWorkflow, s.Transitions[2].Kind)
+		assert.Equal(t, v1alpha1.WorkflowPhaseFailing.String(), s.Transitions[2].To)
+	})
+
+	t.Run("bounded workflows", func(t *testing.T) {
+		r.Record(before, newWorkflow("wf2", v1alpha1.WorkflowPhaseRunning, nil), nil)
+		r.Record(before, newWorkflow("wf3", v1alpha1.WorkflowPhaseRunning, nil), nil)
+		assert.Equal(t, []string{"ns/wf2", "ns/wf3"}, r.List())
+	})
+}
@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1Types "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	flyteworkflowv1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+const namespaceLabel = "namespace"
+
+type leakDetectionMetrics struct {
+	staleTerminated *prometheus.GaugeVec
+	finalizerLeaks  *prometheus.GaugeVec
+	orphanedPods    *prometheus.GaugeVec
+	scanFailures    prometheus.Counter
+	scanTime        promutils.StopWatch
+}
+
+// LeakDetector is a background service that periodically scans for leaked executions and resources and exports how many
+// it found per namespace as gauges, so that leaks show up in dashboards. It reports terminated executions that still
+// exist after the TTL (i.e. that were not garbage collected), terminated or deleted executions that still have a
+// finalizer after a while (i.e. that propeller no longer handles) and pods owned by executions that no longer exist.
+type LeakDetector struct {
+	wfClient          v1alpha1.FlyteworkflowV1alpha1Interface
+	namespaceClient   corev1.NamespaceInterface
+	podsClient        corev1.PodsGetter
+	enabled           bool
+	interval          time.Duration
+	terminatedTTL     time.Duration
+	inactivityTimeout time.Duration
+	clk               clock.Clock
+	metrics           *leakDetectionMetrics
+	namespace         string
+}
+
+// Returns the time the workflow was last updated by propeller, falling back to its creation time.
+func lastActivity(w *flyteworkflowv1alpha1.FlyteWorkflow) time.Time {
+	if w.Status.LastUpdatedAt != nil {
+		return w.Status.LastUpdatedAt.Time
+	}
+
+	return w.CreationTimestamp.Time
+}
+
+// Returns whether the workflow terminated more than the TTL ago.
+func (l *LeakDetector) isStaleTerminated(w *flyteworkflowv1alpha1.FlyteWorkflow) bool {
+	if !w.Status.IsTerminated() {
+		return false
+	}
+
+	stoppedAt := lastActivity(w)
+	if w.Status.StoppedAt != nil {
+		stoppedAt = w.Status.StoppedAt.Time
+	}
+
+	return l.clk.Since(stoppedAt) > l.terminatedTTL
+}
+
+// Returns whether the workflow still has a finalizer, a while after it terminated or was deleted. Propeller removes the
+// finalizer of workflows once they terminate, or once they are aborted when deleted.
+func (l *LeakDetector) isLeakingFinalizer(w *flyteworkflowv1alpha1.FlyteWorkflow) bool {
+	if !HasFinalizer(w) {
+		return false
+	}
+
+	if w.GetDeletionTimestamp() != nil {
+		return l.clk.Since(w.GetDeletionTimestamp().Time) > l.inactivityTimeout
+	}
+
+	return w.Status.IsTerminated() && l.clk.Since(lastActivity(w)) > l.inactivityTimeout
+}
+
+// Counts the pods of executions that are owned by a FlyteWorkflow that does not exist anymore.
+func (l *LeakDetector) countOrphanedPods(ctx context.Context, namespace string, workflows map[types.UID]bool) (int, error) {
+	pods, err := l.podsClient.Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: k8s.ExecutionIDLabel})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, p := range pods.Items {
+		for _, ref := range p.GetOwnerReferences() {
+			if strings.EqualFold(ref.Kind, flyteworkflowv1alpha1.FlyteWorkflowKind) && !workflows[ref.UID] {
+				count++
+				break
+			}
+		}
+	}
+
+	return count, nil
+}
+
+func (l *LeakDetector) scanNamespace(ctx context.Context, namespace string) error {
+	workflows, err := l.wfClient.FlyteWorkflows(namespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	staleTerminated := 0
+	finalizerLeaks := 0
+	uids := make(map[types.UID]bool, len(workflows.Items))
+	for i := range workflows.Items {
+		w := &workflows.Items[i]
+		uids[w.GetUID()] = true
+		if l.isStaleTerminated(w) {
+			staleTerminated++
+		}
+
+		if l.isLeakingFinalizer(w) {
+			finalizerLeaks++
+		}
+	}
+
+	orphanedPods, err := l.countOrphanedPods(ctx, namespace, uids)
+	if err != nil {
+		return err
+	}
+
+	if staleTerminated > 0 || finalizerLeaks > 0 || orphanedPods > 0 {
+		logger.Infof(ctx, "Found [%d] terminated executions that were not garbage collected, [%d] executions leaking their finalizer and [%d] orphaned pods in namespace [%s]",
+			staleTerminated, finalizerLeaks, orphanedPods, namespace)
+	}
+
+	l.metrics.staleTerminated.WithLabelValues(namespace).Set(float64(staleTerminated))
+	l.metrics.finalizerLeaks.WithLabelValues(namespace).Set(float64(finalizerLeaks))
+	l.metrics.orphanedPods.WithLabelValues(namespace).Set(float64(orphanedPods))
+	return nil
+}
+
+func (l *LeakDetector) scan(ctx context.Context) error {
+	t := l.metrics.scanTime.Start()
+	defer t.Stop()
+
+	var namespaces []string
+	if l.namespace == "" || strings.ToLower(l.namespace) == "all" || strings.ToLower(l.namespace) == "all-namespaces" {
+		namespaceList, err := l.namespaceClient.List(ctx, v1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, n := range namespaceList.Items {
+			if n.Status.Phase != corev1Types.NamespaceTerminating {
+				namespaces = append(namespaces, n.GetName())
+			}
+		}
+	} else {
+		namespaces = []string{l.namespace}
+	}
+
+	// Namespaces that no longer exist should not keep reporting leaks.
+	l.metrics.staleTerminated.Reset()
+	l.metrics.finalizerLeaks.Reset()
+	l.metrics.orphanedPods.Reset()
+	for _, namespace := range namespaces {
+		if err := l.scanNamespace(contextutils.WithNamespace(ctx, namespace), namespace); err != nil {
+			l.metrics.scanFailures.Inc()
+			logger.Errorf(ctx, "Failed to scan namespace [%s] for leaks. Error: %v", namespace, err)
+		}
+	}
+
+	return nil
+}
+
+func (l *LeakDetector) run(ctx context.Context, ticker clock.Ticker) {
+	logger.Infof(ctx, "Background leak detection started, with interval [%s]", l.interval.String())
+
+	ctx = contextutils.WithGoroutineLabel(ctx, "leak-detection-worker")
+	pprof.SetGoroutineLabels(ctx)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			if err := l.scan(ctx); err != nil {
+				l.metrics.scanFailures.Inc()
+				logger.Errorf(ctx, "Failed to scan for leaks in this round. Error: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Infof(ctx, "Leak detection stopping")
+			return
+		}
+	}
+}
+
+// Use this method to start the background leak detection routine. Use the context to signal an exit signal
+func (l *LeakDetector) Start(ctx context.Context) error {
+	if !l.enabled {
+		logger.Infof(ctx, "Leak detection is disabled")
+		return nil
+	}
+
+	go l.run(ctx, l.clk.NewTicker(l.interval))
+	return nil
+}
+
+func NewLeakDetector(cfg *config.Config, scope promutils.Scope, clk clock.Clock, namespaceClient corev1.NamespaceInterface,
+	podsClient corev1.PodsGetter, wfClient v1alpha1.FlyteworkflowV1alpha1Interface) *LeakDetector {
+	leakScope := scope.NewSubScope("leaks")
+	return &LeakDetector{
+		wfClient:          wfClient,
+		namespaceClient:   namespaceClient,
+		podsClient:        podsClient,
+		enabled:           cfg.LeakDetection.Enabled,
+		interval:          cfg.LeakDetection.Interval.Duration,
+		terminatedTTL:     cfg.LeakDetection.TerminatedTTL.Duration,
+		inactivityTimeout: cfg.LeakDetection.InactivityTimeout.Duration,
+		clk:               clk,
+		metrics: &leakDetectionMetrics{
+			staleTerminated: leakScope.MustNewGaugeVec("stale_terminated_executions", "Terminated executions that were not garbage collected", namespaceLabel),
+			finalizerLeaks:  leakScope.MustNewGaugeVec("finalizer_leaks", "Terminated or deleted executions that still have a finalizer", namespaceLabel),
+			orphanedPods:    leakScope.MustNewGaugeVec("orphaned_pods", "Pods owned by executions that no longer exist", namespaceLabel),
+			scanFailures:    leakScope.MustNewCounter("scan_failures", "Failures to scan for leaks"),
+			scanTime:        leakScope.MustNewStopWatch("scan_latency", "Time taken to scan for leaks", time.Millisecond),
+		},
+		namespace: cfg.LimitNamespace,
+	}
+}
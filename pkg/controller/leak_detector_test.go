@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1Types "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestLeakDetector_scan(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	at := func(d time.Duration) *v1.Time {
+		ts := v1.NewTime(now.Add(-d))
+		return &ts
+	}
+
+	newWorkflow := func(name string, phase v1alpha1.WorkflowPhase, stoppedAt *v1.Time, finalizers []string) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{
+				Name:       name,
+				Namespace:  "ns",
+				UID:        types.UID(name),
+				Finalizers: finalizers,
+			},
+			Status: v1alpha1.WorkflowStatus{
+				Phase:         phase,
+				StoppedAt:     stoppedAt,
+				LastUpdatedAt: stoppedAt,
+			},
+		}
+	}
+
+	newPod := func(name, owner string) *corev1Types.Pod {
+		return &corev1Types.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    map[string]string{k8s.ExecutionIDLabel: owner},
+				OwnerReferences: []v1.OwnerReference{
+					{Kind: v1alpha1.FlyteWorkflowKind, Name: owner, UID: types.UID(owner)},
+				},
+			},
+		}
+	}
+
+	running := newWorkflow("running", v1alpha1.WorkflowPhaseRunning, nil, []string{FinalizerKey})
+	running.Status.LastUpdatedAt = at(48 * time.Hour)
+	deleted := newWorkflow("deleted", v1alpha1.WorkflowPhaseRunning, nil, []string{FinalizerKey})
+	deleted.DeletionTimestamp = at(2 * time.Hour)
+
+	wfClient := fake.NewSimpleClientset(
+		running,
+		deleted,
+		newWorkflow("recently-succeeded", v1alpha1.WorkflowPhaseSuccess, at(time.Hour), nil),
+		newWorkflow("old-succeeded", v1alpha1.WorkflowPhaseSuccess, at(48*time.Hour), nil),
+		newWorkflow("old-failed", v1alpha1.WorkflowPhaseFailed, at(48*time.Hour), []string{FinalizerKey}),
+	)
+	kubeClient := kubeFake.NewSimpleClientset(
+		&corev1Types.Namespace{ObjectMeta: v1.ObjectMeta{Name: "ns"}},
+		newPod("running-pod", "running"),
+		newPod("orphaned-pod", "gone"),
+		&corev1Types.Pod{ObjectMeta: v1.ObjectMeta{Name: "other-pod", Namespace: "ns"}},
+	)
+
+	cfg := &config2.Config{
+		LimitNamespace: "all",
+		LeakDetection: config2.LeakDetectionConfig{
+			Enabled:           true,
+			TerminatedTTL:     config.Duration{Duration: 24 * time.Hour},
+			InactivityTimeout: config.Duration{Duration: time.Hour},
+		},
+	}
+
+	l := NewLeakDetector(cfg, promutils.NewTestScope(), clock.NewFakeClock(now), kubeClient.CoreV1().Namespaces(),
+		kubeClient.CoreV1(), wfClient.FlyteworkflowV1alpha1())
+	assert.NoError(t, l.scan(ctx))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(l.metrics.staleTerminated.WithLabelValues("ns")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(l.metrics.finalizerLeaks.WithLabelValues("ns")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(l.metrics.orphanedPods.WithLabelValues("ns")))
+}
@@ -0,0 +1,28 @@
+// Package maintenance carries whether propeller is in maintenance mode. While propeller is in maintenance mode, it keeps
+// evaluating workflows, so that running tasks keep being tracked and reported, but it does not launch new task pods.
+// The mode is carried by the context of a round, so that a round that started before the mode changed finishes the way
+// it started.
+package maintenance
+
+import (
+	"context"
+)
+
+// Annotation puts propeller in maintenance mode when it's set to true on the namespace propeller runs in.
+const Annotation = "flyte.org/maintenance"
+
+// Message is the reason reported for tasks that are not launched because propeller is in maintenance mode.
+const Message = "Propeller is in maintenance mode, new tasks are launched once the maintenance ends"
+
+type contextKey struct{}
+
+// WithMaintenance returns a context that carries whether propeller is in maintenance mode.
+func WithMaintenance(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, contextKey{}, enabled)
+}
+
+// IsEnabled returns true if the context carries that propeller is in maintenance mode.
+func IsEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(contextKey{}).(bool)
+	return enabled
+}
@@ -0,0 +1,15 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEnabled(t *testing.T) {
+	ctx := context.TODO()
+	assert.False(t, IsEnabled(ctx))
+	assert.True(t, IsEnabled(WithMaintenance(ctx, true)))
+	assert.False(t, IsEnabled(WithMaintenance(WithMaintenance(ctx, true), false)))
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1Types "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/maintenance"
+)
+
+// MaintenancePodCondition is the condition of the pod of propeller that advertises whether it is in maintenance mode.
+const MaintenancePodCondition corev1Types.PodConditionType = "flyte.org/Maintenance"
+
+type maintenanceMetrics struct {
+	enabled       prometheus.Gauge
+	transitions   prometheus.Counter
+	checkFailures prometheus.Counter
+}
+
+// MaintenanceMonitor tracks whether propeller is in maintenance mode, either because it's configured or because the
+// namespace propeller runs in has the maintenance annotation. The mode is advertised through a gauge and, if the name of
+// the pod of propeller is known, through a condition of the pod.
+type MaintenanceMonitor struct {
+	namespaceClient corev1.NamespaceInterface
+	podsClient      corev1.PodsGetter
+	configured      bool
+	interval        time.Duration
+	namespace       string
+	podName         string
+	clk             clock.Clock
+	// 1 while propeller is in maintenance mode.
+	enabled int32
+	// Whether the condition of the pod has yet to be updated to the current mode.
+	conditionOutdated bool
+	metrics           *maintenanceMetrics
+}
+
+// Enabled returns true while propeller is in maintenance mode.
+func (m *MaintenanceMonitor) Enabled() bool {
+	if m == nil {
+		return false
+	}
+
+	return atomic.LoadInt32(&m.enabled) == 1
+}
+
+func (m *MaintenanceMonitor) check(ctx context.Context) error {
+	enabled := m.configured
+	if !enabled && m.interval > 0 {
+		ns, err := m.namespaceClient.Get(ctx, m.namespace, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if value, ok := ns.GetAnnotations()[maintenance.Annotation]; ok {
+			if enabled, err = strconv.ParseBool(value); err != nil {
+				logger.Warnf(ctx, "Ignoring the invalid maintenance annotation [%s] of namespace [%s]", value, m.namespace)
+			}
+		}
+	}
+
+	if m.Enabled() != enabled {
+		if enabled {
+			logger.Warnf(ctx, "Entering maintenance mode, new task pods are not launched until the maintenance ends")
+			atomic.StoreInt32(&m.enabled, 1)
+			m.metrics.enabled.Set(1)
+		} else {
+			logger.Infof(ctx, "Leaving maintenance mode")
+			atomic.StoreInt32(&m.enabled, 0)
+			m.metrics.enabled.Set(0)
+		}
+
+		m.metrics.transitions.Inc()
+		m.conditionOutdated = true
+	}
+
+	if m.conditionOutdated {
+		if err := m.updatePodCondition(ctx, enabled); err != nil {
+			return err
+		}
+
+		m.conditionOutdated = false
+	}
+
+	return nil
+}
+
+// Sets the maintenance condition of the pod of propeller, if its name is known.
+func (m *MaintenanceMonitor) updatePodCondition(ctx context.Context, enabled bool) error {
+	if len(m.podName) == 0 {
+		return nil
+	}
+
+	pod, err := m.podsClient.Pods(m.namespace).Get(ctx, m.podName, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	condition := corev1Types.PodCondition{
+		Type:               MaintenancePodCondition,
+		Status:             corev1Types.ConditionFalse,
+		LastTransitionTime: v1.NewTime(m.clk.Now()),
+	}
+
+	if enabled {
+		condition.Status = corev1Types.ConditionTrue
+		condition.Reason = "MaintenanceMode"
+		condition.Message = maintenance.Message
+	}
+
+	replaced := false
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == MaintenancePodCondition {
+			pod.Status.Conditions[i] = condition
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	}
+
+	_, err = m.podsClient.Pods(m.namespace).UpdateStatus(ctx, pod, v1.UpdateOptions{})
+	return err
+}
+
+func (m *MaintenanceMonitor) run(ctx context.Context, ticker clock.Ticker) {
+	logger.Infof(ctx, "Background maintenance monitoring started, with interval [%s]", m.interval.String())
+
+	ctx = contextutils.WithGoroutineLabel(ctx, "maintenance-monitor-worker")
+	pprof.SetGoroutineLabels(ctx)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			if err := m.check(ctx); err != nil {
+				m.metrics.checkFailures.Inc()
+				logger.Errorf(ctx, "Failed to check the maintenance mode. Error: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Infof(ctx, "Maintenance monitoring stopping")
+			return
+		}
+	}
+}
+
+// Use this method to start the background maintenance monitoring routine. The mode is checked once before it returns,
+// so that no task pods are launched before propeller knows whether it's in maintenance mode. Use the context to signal
+// an exit signal
+func (m *MaintenanceMonitor) Start(ctx context.Context) error {
+	if err := m.check(ctx); err != nil {
+		m.metrics.checkFailures.Inc()
+		logger.Errorf(ctx, "Failed to check the maintenance mode. Error: %v", err)
+	}
+
+	if m.interval <= 0 {
+		logger.Infof(ctx, "Maintenance annotation monitoring is disabled")
+		return nil
+	}
+
+	go m.run(ctx, m.clk.NewTicker(m.interval))
+	return nil
+}
+
+func NewMaintenanceMonitor(cfg *config.Config, scope promutils.Scope, clk clock.Clock, namespaceClient corev1.NamespaceInterface,
+	podsClient corev1.PodsGetter, namespace, podName string) *MaintenanceMonitor {
+	maintenanceScope := scope.NewSubScope("maintenance")
+	return &MaintenanceMonitor{
+		namespaceClient: namespaceClient,
+		podsClient:      podsClient,
+		configured:      cfg.Maintenance.Enabled,
+		interval:        cfg.Maintenance.Interval.Duration,
+		namespace:       namespace,
+		podName:         podName,
+		clk:             clk,
+		// The condition is set on the first check, whether or not propeller is in maintenance mode.
+		conditionOutdated: true,
+		metrics: &maintenanceMetrics{
+			enabled:       maintenanceScope.MustNewGauge("enabled", "1 while propeller is in maintenance mode and does not launch new task pods"),
+			transitions:   maintenanceScope.MustNewCounter("transitions", "Number of times propeller entered or left maintenance mode"),
+			checkFailures: maintenanceScope.MustNewCounter("check_failures", "Failures to check the maintenance mode"),
+		},
+	}
+}
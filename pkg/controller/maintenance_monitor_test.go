@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1Types "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/maintenance"
+)
+
+func TestMaintenanceMonitor_check(t *testing.T) {
+	ctx := context.TODO()
+	cfg := &config2.Config{Maintenance: config2.MaintenanceConfig{Interval: config.Duration{Duration: time.Minute}}}
+	getCondition := func(t *testing.T, client *kubeFake.Clientset) corev1Types.PodCondition {
+		pod, err := client.CoreV1().Pods("flyte").Get(ctx, "propeller", v1.GetOptions{})
+		assert.NoError(t, err)
+		for _, c := range pod.Status.Conditions {
+			if c.Type == MaintenancePodCondition {
+				return c
+			}
+		}
+
+		assert.FailNow(t, "maintenance condition not found")
+		return corev1Types.PodCondition{}
+	}
+
+	t.Run("annotation", func(t *testing.T) {
+		ns := &corev1Types.Namespace{ObjectMeta: v1.ObjectMeta{Name: "flyte"}}
+		client := kubeFake.NewSimpleClientset(ns, &corev1Types.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "flyte", Name: "propeller"}})
+		m := NewMaintenanceMonitor(cfg, promutils.NewTestScope(), clock.NewFakeClock(time.Now()), client.CoreV1().Namespaces(),
+			client.CoreV1(), "flyte", "propeller")
+
+		assert.NoError(t, m.check(ctx))
+		assert.False(t, m.Enabled())
+		assert.Equal(t, corev1Types.ConditionFalse, getCondition(t, client).Status)
+
+		ns.Annotations = map[string]string{maintenance.Annotation: "true"}
+		_, err := client.CoreV1().Namespaces().Update(ctx, ns, v1.UpdateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, m.check(ctx))
+		assert.True(t, m.Enabled())
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.metrics.enabled))
+		condition := getCondition(t, client)
+		assert.Equal(t, corev1Types.ConditionTrue, condition.Status)
+		assert.Equal(t, maintenance.Message, condition.Message)
+
+		ns.Annotations = map[string]string{maintenance.Annotation: "false"}
+		_, err = client.CoreV1().Namespaces().Update(ctx, ns, v1.UpdateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, m.check(ctx))
+		assert.False(t, m.Enabled())
+		assert.Equal(t, float64(0), testutil.ToFloat64(m.metrics.enabled))
+		assert.Equal(t, float64(2), testutil.ToFloat64(m.metrics.transitions))
+		assert.Equal(t, corev1Types.ConditionFalse, getCondition(t, client).Status)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		client := kubeFake.NewSimpleClientset()
+		m := NewMaintenanceMonitor(&config2.Config{Maintenance: config2.MaintenanceConfig{Enabled: true}}, promutils.NewTestScope(),
+			clock.NewFakeClock(time.Now()), client.CoreV1().Namespaces(), client.CoreV1(), "flyte", "")
+
+		assert.NoError(t, m.Start(ctx))
+		assert.True(t, m.Enabled())
+	})
+
+	t.Run("missing namespace", func(t *testing.T) {
+		client := kubeFake.NewSimpleClientset()
+		m := NewMaintenanceMonitor(cfg, promutils.NewTestScope(), clock.NewFakeClock(time.Now()), client.CoreV1().Namespaces(),
+			client.CoreV1(), "flyte", "")
+
+		assert.Error(t, m.check(ctx))
+		assert.False(t, m.Enabled())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var m *MaintenanceMonitor
+		assert.False(t, m.Enabled())
+	})
+}
@@ -0,0 +1,110 @@
+package nodes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+)
+
+const (
+	abortOutcomeLabel = "outcome"
+
+	// The outcomes of propagating an abort to a node.
+	abortOutcomeAborted       = "aborted"
+	abortOutcomePending       = "pending"
+	abortOutcomeFailed        = "failed"
+	abortOutcomeTimedOut      = "timed_out"
+	abortOutcomeCycle         = "cycle"
+	abortOutcomeDepthExceeded = "depth_exceeded"
+)
+
+// The nodes of a workflow an abort was propagated to. A node downstream of several complete nodes is aborted only once.
+type abortedNodes struct {
+	lock  sync.Mutex
+	nodes sets.String
+}
+
+// Adds the node, returning false if the abort was already propagated to it.
+func (a *abortedNodes) add(nodeID v1alpha1.NodeID) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.nodes.Has(nodeID) {
+		return false
+	}
+
+	a.nodes.Insert(nodeID)
+	return true
+}
+
+func newAbortedNodes() *abortedNodes {
+	return &abortedNodes{nodes: sets.NewString()}
+}
+
+func (c *nodeExecutor) recordAbortOutcome(ctx context.Context, nodeID v1alpha1.NodeID, outcome string) {
+	logger.Infof(ctx, "Propagated abort to node [%s], outcome [%s]", nodeID, outcome)
+	if c.metrics != nil && c.metrics.AbortOutcomes != nil {
+		c.metrics.AbortOutcomes.WithLabelValues(outcome).Inc()
+	}
+}
+
+// Aborts the nodes downstream of a complete node, concurrently up to the abort parallelism. The aborts of all of them
+// are attempted, even if some fail.
+func (c *nodeExecutor) abortDownstreamNodes(ctx context.Context, execContext executors.ExecutionContext, dag executors.DAGStructure,
+	nl executors.NodeLookup, nodeID v1alpha1.NodeID, downstreamNodeIDs []v1alpha1.NodeID, reason string, aborted *abortedNodes) error {
+	downstreamNodes := make([]v1alpha1.ExecutableNode, 0, len(downstreamNodeIDs))
+	for _, d := range downstreamNodeIDs {
+		downstreamNode, ok := nl.GetNode(d)
+		if !ok {
+			return errors.Errorf(errors.BadSpecificationError, nodeID, "Unable to find Downstream Node [%v]", d)
+		}
+
+		if aborted.add(d) {
+			downstreamNodes = append(downstreamNodes, downstreamNode)
+		}
+	}
+
+	abortErrs := make([]error, len(downstreamNodes))
+	if c.abortParallelism <= 1 || len(downstreamNodes) <= 1 {
+		for i, n := range downstreamNodes {
+			abortErrs[i] = c.abortNode(ctx, execContext, dag, nl, n, reason, aborted)
+		}
+	} else {
+		sem := make(chan struct{}, c.abortParallelism)
+		var wg sync.WaitGroup
+		for i, n := range downstreamNodes {
+			i, n := i, n
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				defer c.recoverEvaluationPanic(ctx, n.GetID(), &abortErrs[i])
+
+				abortErrs[i] = c.abortNode(ctx, execContext, dag, nl, n, reason, aborted)
+			}()
+		}
+
+		wg.Wait()
+	}
+
+	errs := make([]error, 0, len(downstreamNodes))
+	for i, err := range abortErrs {
+		if err != nil {
+			logger.Infof(ctx, "Failed to abort node [%v]. Error: %v", downstreamNodes[i].GetID(), err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.ErrorCollection{Errors: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	mocks4 "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	nodeHandlerMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+	mocks2 "github.com/flyteorg/flytepropeller/pkg/controller/nodes/mocks"
+)
+
+func addAbortTestNode(nl *mocks4.NodeLookup, id v1alpha1.NodeID, phase v1alpha1.NodePhase) {
+	n := &mocks.ExecutableNode{}
+	n.OnGetID().Return(id)
+	n.OnGetKind().Return(v1alpha1.NodeKindStart)
+	interruptible := false
+	n.OnIsInterruptible().Return(&interruptible)
+	ns := &mocks.ExecutableNodeStatus{}
+	ns.OnGetPhase().Return(phase)
+	ns.OnGetDataDir().Return(storage.DataReference("s3:/foo"))
+	nl.OnGetNodeExecutionStatusMatch(mock.Anything, id).Return(ns)
+	nl.OnGetNode(id).Return(n, true)
+}
+
+func newAbortTestExecutor() (*nodeExecutor, *nodeHandlerMocks.Node) {
+	h := &nodeHandlerMocks.Node{}
+	h.OnAbortMatch(mock.Anything, mock.Anything, "aborting").Return(nil)
+	h.OnFinalizeMatch(mock.Anything, mock.Anything).Return(nil)
+	hf := &mocks2.HandlerFactory{}
+	hf.OnGetHandlerMatch(v1alpha1.NodeKindStart).Return(h, nil)
+
+	return &nodeExecutor{
+		nodeRecorder:       fakeNodeEventRecorder{},
+		nodeHandlerFactory: hf,
+		metrics: &nodeMetrics{
+			AbortOutcomes: promutils.NewTestScope().MustNewCounterVec("abort_outcomes", "", abortOutcomeLabel),
+		},
+		abortParallelism: 2,
+	}, h
+}
+
+func newAbortTestExecutionContext() *mocks4.ExecutionContext {
+	execContext := &mocks4.ExecutionContext{}
+	execContext.OnIsInterruptible().Return(false)
+	execContext.OnGetRawOutputDataConfig().Return(v1alpha1.RawOutputDataConfig{})
+	execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{})
+	execContext.OnGetExecutionID().Return(v1alpha1.WorkflowExecutionIdentifier{})
+	execContext.OnGetLabels().Return(nil)
+	execContext.OnGetEventVersion().Return(v1alpha1.EventVersion0)
+	return execContext
+}
+
+func TestNodeExecutor_AbortHandler_Propagation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("downstream-nodes-aborted-once", func(t *testing.T) {
+		nl := &mocks4.NodeLookup{}
+		addAbortTestNode(nl, "start", v1alpha1.NodePhaseSucceeded)
+		addAbortTestNode(nl, "a", v1alpha1.NodePhaseSucceeded)
+		addAbortTestNode(nl, "b", v1alpha1.NodePhaseSucceeded)
+		addAbortTestNode(nl, "c", v1alpha1.NodePhaseRunning)
+		start, _ := nl.GetNode("start")
+
+		dag := &mocks4.DAGStructure{}
+		dag.OnFromNode("start").Return([]v1alpha1.NodeID{"a", "b"}, nil)
+		dag.OnFromNode("a").Return([]v1alpha1.NodeID{"c"}, nil)
+		dag.OnFromNode("b").Return([]v1alpha1.NodeID{"c"}, nil)
+
+		exec, h := newAbortTestExecutor()
+		assert.NoError(t, exec.AbortHandler(ctx, newAbortTestExecutionContext(), dag, nl, start, "aborting"))
+		h.AssertNumberOfCalls(t, "Abort", 1)
+		assert.Equal(t, float64(1), testutil.ToFloat64(exec.metrics.AbortOutcomes.WithLabelValues(abortOutcomeAborted)))
+	})
+
+	t.Run("timed-out", func(t *testing.T) {
+		nl := &mocks4.NodeLookup{}
+		addAbortTestNode(nl, "c", v1alpha1.NodePhaseRunning)
+		c, _ := nl.GetNode("c")
+
+		exec, h := newAbortTestExecutor()
+		abortCtx, cancel := context.WithCancel(executors.WithRootAbortScope(ctx))
+		cancel()
+		err := exec.AbortHandler(abortCtx, newAbortTestExecutionContext(), &mocks4.DAGStructure{}, nl, c, "aborting")
+		assert.True(t, errors.Matches(err, errors.AbortTimedOut))
+		h.AssertNotCalled(t, "Abort", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, float64(1), testutil.ToFloat64(exec.metrics.AbortOutcomes.WithLabelValues(abortOutcomeTimedOut)))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		n := &mocks.ExecutableNode{}
+		n.OnGetID().Return("n")
+
+		exec, _ := newAbortTestExecutor()
+		abortCtx := executors.WithAbortScope(executors.WithAbortScope(executors.WithRootAbortScope(ctx), "sub"), "sub")
+		assert.NoError(t, exec.AbortHandler(abortCtx, nil, nil, &mocks4.NodeLookup{}, n, "aborting"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(exec.metrics.AbortOutcomes.WithLabelValues(abortOutcomeCycle)))
+	})
+
+	t.Run("depth-exceeded", func(t *testing.T) {
+		n := &mocks.ExecutableNode{}
+		n.OnGetID().Return("n")
+
+		exec, _ := newAbortTestExecutor()
+		exec.maxAbortDepth = 1
+		abortCtx := executors.WithAbortScope(executors.WithAbortScope(executors.WithRootAbortScope(ctx), "sub-1"), "sub-2")
+		assert.NoError(t, exec.AbortHandler(abortCtx, nil, nil, &mocks4.NodeLookup{}, n, "aborting"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(exec.metrics.AbortOutcomes.WithLabelValues(abortOutcomeDepthExceeded)))
+	})
+}
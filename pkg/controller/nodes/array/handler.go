@@ -0,0 +1,561 @@
+package array
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/bitarray"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+)
+
+const (
+	// SubNodesFailed is the error code of array nodes that did not reach their minimum number of successful sub nodes.
+	SubNodesFailed = "SubNodesFailed"
+
+	// Attempts and system failures of a sub node are capped at this value in the status, the retry budget of the sub
+	// node itself is enforced by the node executor.
+	maxSubNodeAttempts = math.MaxUint8
+)
+
+type metrics struct {
+	subNodesSucceeded labeled.Counter
+	subNodesFailed    labeled.Counter
+}
+
+// arrayNodeHandler runs the sub node of an array node once for every element of its list inputs. The sub nodes are
+// driven through the node executor, just like the nodes of a sub workflow, but their status is not kept as regular
+// node statuses. The phase, task phase, attempts and system failures of every sub node are persisted, packed into
+// compact bit arrays, so that the size of the status stays small for large arrays. The full task state of a sub node,
+// including the state of its plugin, is persisted only while the sub node is in flight, which the parallelism of the
+// array node bounds.
+type arrayNodeHandler struct {
+	nodeExecutor executors.Node
+	metrics      metrics
+	// Share of the remaining parallelism the sub nodes of the array node may use.
+	parallelismWeight float64
+}
+
+func (a arrayNodeHandler) FinalizeRequired() bool {
+	// All sub nodes are terminal, hence finalized by the node executor, before the array node leaves its running phase.
+	return false
+}
+
+func (a arrayNodeHandler) Setup(_ context.Context, _ handler.SetupContext) error {
+	return nil
+}
+
+func (a arrayNodeHandler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext) (handler.Transition, error) {
+	arrayNode := nCtx.Node().GetArrayNode()
+	if arrayNode == nil || arrayNode.GetSubNodeSpec() == nil {
+		return handler.UnknownTransition, errors.Errorf(errors.BadSpecificationError, nCtx.NodeID(), "array node is missing its sub node spec")
+	}
+
+	inputs, err := nCtx.InputReader().Get(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed to read input. Error [%s]", err)
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.RuntimeExecutionError, errMsg, nil)), nil
+	}
+
+	state := nCtx.NodeStateReader().GetArrayNodeState()
+	var trns handler.Transition
+	switch state.Phase {
+	case v1alpha1.ArrayNodePhaseNone:
+		size, err := arraySize(inputs)
+		if err != nil {
+			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER, errors.BadSpecificationError, err.Error(), nil)), nil
+		}
+
+		if state, err = newArrayNodeState(size); err != nil {
+			return handler.UnknownTransition, err
+		}
+
+		logger.Infof(ctx, "Starting array node with [%d] sub nodes", size)
+		trns = handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil))
+	case v1alpha1.ArrayNodePhaseExecuting:
+		if trns, err = a.handleSubNodes(ctx, nCtx, arrayNode, inputs, &state); err != nil {
+			return trns, err
+		}
+	case v1alpha1.ArrayNodePhaseFailing:
+		if err := a.abortSubNodes(ctx, nCtx, arrayNode, inputs, state, state.Error.GetMessage()); err != nil {
+			return handler.UnknownTransition, err
+		}
+
+		trns = handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailureErr(state.Error, nil))
+	case v1alpha1.ArrayNodePhaseSucceeding:
+		outputInfo, err := a.gatherOutputs(ctx, nCtx, arrayNode, state)
+		if err != nil {
+			return handler.UnknownTransition, err
+		}
+
+		trns = handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoSuccess(&handler.ExecutionInfo{
+			OutputInfo: outputInfo,
+		}))
+	default:
+		return handler.UnknownTransition, errors.Errorf(errors.IllegalStateError, nCtx.NodeID(), "unknown array node phase [%v]", state.Phase)
+	}
+
+	if err := nCtx.NodeStateWriter().PutArrayNodeState(state); err != nil {
+		return handler.UnknownTransition, err
+	}
+
+	return trns, nil
+}
+
+// Drives all sub nodes that are allowed to run one step further and moves the array node to succeeding or failing once
+// its outcome is known.
+func (a arrayNodeHandler) handleSubNodes(ctx context.Context, nCtx handler.NodeExecutionContext, arrayNode v1alpha1.ExecutableArrayNode,
+	inputs *core.LiteralMap, state *handler.ArrayNodeState) (handler.Transition, error) {
+
+	size := int(state.SubNodePhases.ItemsCount)
+	inFlight := 0
+	for i := 0; i < size; i++ {
+		if phase := v1alpha1.NodePhase(state.SubNodePhases.GetItem(i)); phase != v1alpha1.NodePhaseNotYetStarted && !v1alpha1.IsPhaseTerminal(phase) {
+			inFlight++
+		}
+	}
+
+	parallelism := int(arrayNode.GetParallelism())
+	// The sub nodes share the parallelism budget of the array node.
+	flow := executors.NewControlFlowWithParallelismBudget(nCtx.ExecutionContext(), a.parallelismWeight)
+	succeeded, failed := 0, 0
+	for i := 0; i < size; i++ {
+		phase := v1alpha1.NodePhase(state.SubNodePhases.GetItem(i))
+		if phase == v1alpha1.NodePhaseNotYetStarted {
+			if parallelism > 0 && inFlight >= parallelism {
+				continue
+			}
+
+			inFlight++
+		}
+
+		if !v1alpha1.IsPhaseTerminal(phase) {
+			subNode, subNodeStatus, execContext, err := a.buildSubNode(ctx, nCtx, arrayNode, inputs, *state, i, flow)
+			if err != nil {
+				return handler.UnknownTransition, err
+			}
+
+			nl := newSubNodeLookup(subNode, subNodeStatus)
+			if _, err := a.nodeExecutor.RecursiveNodeHandler(ctx, execContext, executors.NewLeafNodeDAGStructure(subNode.GetID()), nl, subNode); err != nil {
+				return handler.UnknownTransition, err
+			}
+
+			phase = subNodeStatus.GetPhase()
+			updateSubNodeState(state, i, subNodeStatus)
+			if phase == v1alpha1.NodePhaseSucceeded || phase == v1alpha1.NodePhaseRecovered {
+				a.metrics.subNodesSucceeded.Inc(ctx)
+			} else if v1alpha1.IsPhaseTerminal(phase) {
+				a.metrics.subNodesFailed.Inc(ctx)
+			}
+		}
+
+		switch phase {
+		case v1alpha1.NodePhaseSucceeded, v1alpha1.NodePhaseRecovered:
+			succeeded++
+		case v1alpha1.NodePhaseFailed, v1alpha1.NodePhaseTimedOut, v1alpha1.NodePhaseSkipped:
+			failed++
+		}
+	}
+
+	required := minSuccesses(arrayNode, size)
+	if failed > size-required {
+		logger.Infof(ctx, "[%d] of [%d] sub nodes failed, array node is failing", failed, size)
+		state.Phase = v1alpha1.ArrayNodePhaseFailing
+		state.Error = &core.ExecutionError{
+			Kind:    core.ExecutionError_USER,
+			Code:    SubNodesFailed,
+			Message: fmt.Sprintf("[%d] of [%d] sub nodes failed, at least [%d] have to succeed", failed, size, required),
+		}
+	} else if succeeded+failed == size {
+		logger.Infof(ctx, "[%d] of [%d] sub nodes succeeded, array node is succeeding", succeeded, size)
+		state.Phase = v1alpha1.ArrayNodePhaseSucceeding
+	}
+
+	if state.Phase != v1alpha1.ArrayNodePhaseExecuting {
+		if err := nCtx.EnqueueOwnerFunc()(); err != nil {
+			return handler.UnknownTransition, err
+		}
+	}
+
+	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil
+}
+
+// Builds the sub node at the given index together with a node status that is restored from the array node state.
+func (a arrayNodeHandler) buildSubNode(ctx context.Context, nCtx handler.NodeExecutionContext, arrayNode v1alpha1.ExecutableArrayNode,
+	inputs *core.LiteralMap, state handler.ArrayNodeState, index int, flow executors.ControlFlow) (*v1alpha1.NodeSpec, *v1alpha1.NodeStatus, executors.ExecutionContext, error) {
+
+	subNode := *arrayNode.GetSubNodeSpec()
+	subNode.ID = strconv.Itoa(index)
+	inputBindings, err := subNodeInputBindings(inputs, index)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(errors.BadSpecificationError, nCtx.NodeID(), err, "failed to bind the inputs of sub node [%d]", index)
+	}
+
+	subNode.InputBindings = inputBindings
+
+	store := nCtx.DataStore()
+	dataDir, err := store.ConstructReference(ctx, nCtx.NodeStatus().GetOutputDir(), subNode.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	attempts := uint32(state.SubNodeRetryAttempts.GetItem(index))
+	outputDir, err := store.ConstructReference(ctx, dataDir, strconv.FormatUint(uint64(attempts), 10))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The node executor updates the task state in place, it is only written back to the array node state once the sub
+	// node has been handled.
+	taskNodeStatus := state.SubNodeTaskStates[index].DeepCopy()
+	if taskNodeStatus == nil {
+		taskNodeStatus = &v1alpha1.TaskNodeStatus{
+			Phase: int(state.SubNodeTaskPhases.GetItem(index)),
+		}
+	}
+
+	subNodeStatus := &v1alpha1.NodeStatus{
+		Phase:                    v1alpha1.NodePhase(state.SubNodePhases.GetItem(index)),
+		DataDir:                  dataDir,
+		OutputDir:                outputDir,
+		Attempts:                 attempts,
+		SystemFailures:           uint32(state.SubNodeSystemFailures.GetItem(index)),
+		TaskNodeStatus:           taskNodeStatus,
+		DataReferenceConstructor: store,
+	}
+
+	// The array node becomes the parent of its sub nodes, this keeps the ids of the sub node executions unique.
+	parentInfo, err := common.CreateParentInfo(nCtx.ExecutionContext().GetParentInfo(), nCtx.NodeID(), nCtx.CurrentAttempt())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	eCtx := nCtx.ExecutionContext()
+	return &subNode, subNodeStatus, executors.NewExecutionContext(eCtx, eCtx, eCtx, parentInfo, flow), nil
+}
+
+func (a arrayNodeHandler) abortSubNodes(ctx context.Context, nCtx handler.NodeExecutionContext, arrayNode v1alpha1.ExecutableArrayNode,
+	inputs *core.LiteralMap, state handler.ArrayNodeState, reason string) error {
+
+	for i := 0; i < int(state.SubNodePhases.ItemsCount); i++ {
+		phase := v1alpha1.NodePhase(state.SubNodePhases.GetItem(i))
+		if phase == v1alpha1.NodePhaseNotYetStarted || v1alpha1.IsPhaseTerminal(phase) {
+			continue
+		}
+
+		subNode, subNodeStatus, execContext, err := a.buildSubNode(ctx, nCtx, arrayNode, inputs, state, i, nCtx.ExecutionContext())
+		if err != nil {
+			return err
+		}
+
+		nl := newSubNodeLookup(subNode, subNodeStatus)
+		if err := a.nodeExecutor.AbortHandler(ctx, execContext, executors.NewLeafNodeDAGStructure(subNode.GetID()), nl, subNode, reason); err != nil {
+			logger.Errorf(ctx, "Failed to abort sub node [%d] of array node. Error: %v", i, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Collects the outputs of all sub nodes into list outputs of the array node. Sub nodes that did not succeed contribute
+// a none value, so that the outputs stay aligned with the inputs.
+func (a arrayNodeHandler) gatherOutputs(ctx context.Context, nCtx handler.NodeExecutionContext, arrayNode v1alpha1.ExecutableArrayNode,
+	state handler.ArrayNodeState) (*handler.OutputInfo, error) {
+
+	store := nCtx.DataStore()
+	size := int(state.SubNodePhases.ItemsCount)
+	outputVariables, err := subNodeOutputVariables(nCtx, arrayNode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every declared output becomes a list output, even if no sub node produced it, e.g. for empty list inputs.
+	collections := make(map[string][]*core.Literal, len(outputVariables))
+	for name := range outputVariables {
+		collections[name] = make([]*core.Literal, size)
+	}
+
+	for i := 0; i < size; i++ {
+		phase := v1alpha1.NodePhase(state.SubNodePhases.GetItem(i))
+		if phase != v1alpha1.NodePhaseSucceeded && phase != v1alpha1.NodePhaseRecovered {
+			continue
+		}
+
+		dataDir, err := store.ConstructReference(ctx, nCtx.NodeStatus().GetOutputDir(), strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+
+		outputDir, err := store.ConstructReference(ctx, dataDir, strconv.FormatUint(uint64(state.SubNodeRetryAttempts.GetItem(i)), 10))
+		if err != nil {
+			return nil, err
+		}
+
+		outputsFile := v1alpha1.GetOutputsFile(outputDir)
+		metadata, err := store.Head(ctx, outputsFile)
+		if err != nil {
+			return nil, errors.Wrapf(errors.StorageError, nCtx.NodeID(), err, "failed to check outputs of sub node [%d]", i)
+		}
+
+		if !metadata.Exists() {
+			continue
+		}
+
+		outputs := &core.LiteralMap{}
+		if err := store.ReadProtobuf(ctx, outputsFile, outputs); err != nil {
+			return nil, errors.Wrapf(errors.StorageError, nCtx.NodeID(), err, "failed to read outputs of sub node [%d]", i)
+		}
+
+		for name, literal := range outputs.GetLiterals() {
+			if _, ok := collections[name]; !ok {
+				collections[name] = make([]*core.Literal, size)
+			}
+
+			collections[name][i] = literal
+		}
+	}
+
+	if len(collections) == 0 {
+		return nil, nil
+	}
+
+	outputs := &core.LiteralMap{Literals: make(map[string]*core.Literal, len(collections))}
+	for name, literals := range collections {
+		for i, literal := range literals {
+			if literal == nil {
+				literals[i] = &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_NoneType{NoneType: &core.Void{}}}}}
+			}
+		}
+
+		outputs.Literals[name] = &core.Literal{Value: &core.Literal_Collection{Collection: &core.LiteralCollection{Literals: literals}}}
+	}
+
+	outputsFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
+	if err := store.WriteProtobuf(ctx, outputsFile, storage.Options{}, outputs); err != nil {
+		return nil, errors.Wrapf(errors.StorageError, nCtx.NodeID(), err, "failed to write array node outputs")
+	}
+
+	return &handler.OutputInfo{OutputURI: outputsFile}, nil
+}
+
+func (a arrayNodeHandler) Abort(ctx context.Context, nCtx handler.NodeExecutionContext, reason string) error {
+	arrayNode := nCtx.Node().GetArrayNode()
+	state := nCtx.NodeStateReader().GetArrayNodeState()
+	if arrayNode == nil || (state.Phase != v1alpha1.ArrayNodePhaseExecuting && state.Phase != v1alpha1.ArrayNodePhaseFailing) {
+		return nil
+	}
+
+	inputs, err := nCtx.InputReader().Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Aborting array node at RetryAttempt [%d]", nCtx.CurrentAttempt())
+	return a.abortSubNodes(ctx, nCtx, arrayNode, inputs, state, reason)
+}
+
+func (a arrayNodeHandler) Finalize(_ context.Context, _ handler.NodeExecutionContext) error {
+	return nil
+}
+
+// Returns the outputs the sub node declares, as far as they are known to the execution. Sub nodes that launch other
+// executions do not declare their outputs in the workflow, the outputs of the array node are then made up of the
+// outputs its sub nodes produced.
+func subNodeOutputVariables(nCtx handler.NodeExecutionContext, arrayNode v1alpha1.ExecutableArrayNode) (map[string]*core.Variable, error) {
+	subNode := arrayNode.GetSubNodeSpec()
+	if subNode.GetTaskID() != nil {
+		task, err := nCtx.ExecutionContext().GetTask(*subNode.GetTaskID())
+		if err != nil {
+			return nil, errors.Wrapf(errors.BadSpecificationError, nCtx.NodeID(), err, "failed to find the task of the sub node")
+		}
+
+		return task.CoreTask().GetInterface().GetOutputs().GetVariables(), nil
+	}
+
+	if workflowNode := subNode.GetWorkflowNode(); workflowNode != nil && workflowNode.GetSubWorkflowRef() != nil {
+		subWorkflow := nCtx.ExecutionContext().FindSubWorkflow(*workflowNode.GetSubWorkflowRef())
+		if subWorkflow == nil {
+			return nil, errors.Errorf(errors.BadSpecificationError, nCtx.NodeID(), "failed to find the sub workflow of the sub node")
+		}
+
+		if outputs := subWorkflow.GetOutputs(); outputs != nil {
+			return outputs.GetVariables(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Returns the number of elements of the list inputs, all list inputs have to be of the same length.
+func arraySize(inputs *core.LiteralMap) (int, error) {
+	size := -1
+	for name, literal := range inputs.GetLiterals() {
+		collection := literal.GetCollection()
+		if collection == nil {
+			continue
+		}
+
+		if size >= 0 && len(collection.GetLiterals()) != size {
+			return 0, fmt.Errorf("list input [%v] has [%d] elements, expected [%d]", name, len(collection.GetLiterals()), size)
+		}
+
+		size = len(collection.GetLiterals())
+	}
+
+	if size < 0 {
+		return 0, fmt.Errorf("array node requires at least one list input")
+	}
+
+	return size, nil
+}
+
+func newArrayNodeState(size int) (handler.ArrayNodeState, error) {
+	state := handler.ArrayNodeState{Phase: v1alpha1.ArrayNodePhaseExecuting}
+	var err error
+	if state.SubNodePhases, err = bitarray.NewCompactArray(uint(size), bitarray.Item(v1alpha1.NodePhaseRecovered)); err != nil {
+		return state, err
+	}
+
+	if state.SubNodeTaskPhases, err = bitarray.NewCompactArray(uint(size), bitarray.Item(pluginCore.PhasePermanentFailure)); err != nil {
+		return state, err
+	}
+
+	if state.SubNodeRetryAttempts, err = bitarray.NewCompactArray(uint(size), maxSubNodeAttempts); err != nil {
+		return state, err
+	}
+
+	state.SubNodeSystemFailures, err = bitarray.NewCompactArray(uint(size), maxSubNodeAttempts)
+	return state, err
+}
+
+func updateSubNodeState(state *handler.ArrayNodeState, index int, subNodeStatus *v1alpha1.NodeStatus) {
+	state.SubNodePhases.SetItem(index, bitarray.Item(subNodeStatus.GetPhase()))
+	taskPhase := 0
+	if subNodeStatus.TaskNodeStatus != nil {
+		taskPhase = subNodeStatus.TaskNodeStatus.GetPhase()
+	}
+
+	// The full task state is kept only as long as the sub node may still need it.
+	if subNodeStatus.TaskNodeStatus != nil && !v1alpha1.IsPhaseTerminal(subNodeStatus.GetPhase()) {
+		if state.SubNodeTaskStates == nil {
+			state.SubNodeTaskStates = map[int]*v1alpha1.TaskNodeStatus{}
+		}
+
+		state.SubNodeTaskStates[index] = subNodeStatus.TaskNodeStatus
+	} else {
+		delete(state.SubNodeTaskStates, index)
+	}
+
+	state.SubNodeTaskPhases.SetItem(index, bitarray.Item(taskPhase))
+	state.SubNodeRetryAttempts.SetItem(index, capAttempts(subNodeStatus.GetAttempts()))
+	state.SubNodeSystemFailures.SetItem(index, capAttempts(subNodeStatus.GetSystemFailures()))
+}
+
+func capAttempts(attempts uint32) bitarray.Item {
+	if attempts > maxSubNodeAttempts {
+		return maxSubNodeAttempts
+	}
+
+	return bitarray.Item(attempts)
+}
+
+// Returns the number of sub nodes that have to succeed, all of them unless the array node allows failures.
+func minSuccesses(arrayNode v1alpha1.ExecutableArrayNode, size int) int {
+	if minSuccesses := arrayNode.GetMinSuccesses(); minSuccesses != nil {
+		if int(*minSuccesses) < size {
+			return int(*minSuccesses)
+		}
+
+		return size
+	}
+
+	if ratio := arrayNode.GetMinSuccessRatio(); ratio != nil && *ratio < 1 {
+		return int(math.Ceil(float64(*ratio) * float64(size)))
+	}
+
+	return size
+}
+
+// Binds the elements at the given index of all list inputs to the sub node, all other inputs are passed on as they are.
+func subNodeInputBindings(inputs *core.LiteralMap, index int) ([]*v1alpha1.Binding, error) {
+	bindings := make([]*v1alpha1.Binding, 0, len(inputs.GetLiterals()))
+	for name, literal := range inputs.GetLiterals() {
+		if collection := literal.GetCollection(); collection != nil {
+			literal = collection.GetLiterals()[index]
+		}
+
+		bindingData, err := literalToBindingData(literal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind input [%v]: %w", name, err)
+		}
+
+		bindings = append(bindings, &v1alpha1.Binding{
+			Binding: &core.Binding{
+				Var:     name,
+				Binding: bindingData,
+			},
+		})
+	}
+
+	return bindings, nil
+}
+
+func literalToBindingData(literal *core.Literal) (*core.BindingData, error) {
+	switch v := literal.GetValue().(type) {
+	case *core.Literal_Scalar:
+		return &core.BindingData{Value: &core.BindingData_Scalar{Scalar: v.Scalar}}, nil
+	case *core.Literal_Collection:
+		bindings := make([]*core.BindingData, 0, len(v.Collection.GetLiterals()))
+		for _, l := range v.Collection.GetLiterals() {
+			binding, err := literalToBindingData(l)
+			if err != nil {
+				return nil, err
+			}
+
+			bindings = append(bindings, binding)
+		}
+
+		return &core.BindingData{Value: &core.BindingData_Collection{Collection: &core.BindingDataCollection{Bindings: bindings}}}, nil
+	case *core.Literal_Map:
+		bindings := make(map[string]*core.BindingData, len(v.Map.GetLiterals()))
+		for k, l := range v.Map.GetLiterals() {
+			binding, err := literalToBindingData(l)
+			if err != nil {
+				return nil, err
+			}
+
+			bindings[k] = binding
+		}
+
+		return &core.BindingData{Value: &core.BindingData_Map{Map: &core.BindingDataMap{Bindings: bindings}}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported literal [%T]", literal.GetValue())
+	}
+}
+
+// New creates a handler for array nodes that executes the sub nodes through the given node executor.
+func New(nodeExecutor executors.Node, scope promutils.Scope) handler.Node {
+	arrayScope := scope.NewSubScope("array")
+	return &arrayNodeHandler{
+		nodeExecutor:      nodeExecutor,
+		parallelismWeight: config.GetConfig().NodeConfig.ParallelismBudget.ArrayWeight,
+		metrics: metrics{
+			subNodesSucceeded: labeled.NewCounter("sub_nodes_succeeded", "Sub nodes of array nodes that succeeded", arrayScope),
+			subNodesFailed:    labeled.NewCounter("sub_nodes_failed", "Sub nodes of array nodes that failed", arrayScope),
+		},
+	}
+}
@@ -0,0 +1,392 @@
+package array
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flytestdlib/bitarray"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	coreMocks "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	execMocks "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.NodeIDKey)
+}
+
+const arrayOutputDir = "s3://bucket/array"
+
+type arrayNodeStateHolder struct {
+	s handler.ArrayNodeState
+}
+
+func (t *arrayNodeStateHolder) PutTaskNodeState(s handler.TaskNodeState) error {
+	panic("not implemented")
+}
+
+func (t *arrayNodeStateHolder) PutBranchNode(s handler.BranchNodeState) error {
+	panic("not implemented")
+}
+
+func (t *arrayNodeStateHolder) PutWorkflowNodeState(s handler.WorkflowNodeState) error {
+	panic("not implemented")
+}
+
+func (t *arrayNodeStateHolder) PutDynamicNodeState(s handler.DynamicNodeState) error {
+	panic("not implemented")
+}
+
+func (t *arrayNodeStateHolder) PutArrayNodeState(s handler.ArrayNodeState) error {
+	t.s = s
+	return nil
+}
+
+func newInputs() *core.LiteralMap {
+	return &core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"x":      coreutils.MustMakeLiteral([]interface{}{1, 2, 3}),
+			"prefix": coreutils.MustMakeLiteral("p"),
+		},
+	}
+}
+
+func newArrayNodeSpec() *v1alpha1.ArrayNodeSpec {
+	return &v1alpha1.ArrayNodeSpec{
+		SubNodeSpec: &v1alpha1.NodeSpec{ID: "sub", Kind: v1alpha1.NodeKindTask},
+	}
+}
+
+func newState(t *testing.T, phases ...v1alpha1.NodePhase) handler.ArrayNodeState {
+	state, err := newArrayNodeState(len(phases))
+	assert.NoError(t, err)
+	for i, phase := range phases {
+		state.SubNodePhases.SetItem(i, bitarray.Item(phase))
+	}
+
+	return state
+}
+
+func newNodeExecutionContext(t *testing.T, arrayNode *v1alpha1.ArrayNodeSpec, state handler.ArrayNodeState, holder *arrayNodeStateHolder) (*mocks.NodeExecutionContext, *storage.DataStore) {
+	n := &coreMocks.ExecutableNode{}
+	if arrayNode != nil {
+		n.OnGetArrayNode().Return(arrayNode)
+	} else {
+		n.OnGetArrayNode().Return(nil)
+	}
+
+	ir := &ioMocks.InputReader{}
+	ir.OnGetMatch(mock.Anything).Return(newInputs(), nil)
+
+	ns := &coreMocks.ExecutableNodeStatus{}
+	ns.OnGetOutputDir().Return(arrayOutputDir)
+
+	sr := &mocks.NodeStateReader{}
+	sr.OnGetArrayNodeState().Return(state)
+
+	execContext := &execMocks.ExecutionContext{}
+	execContext.OnGetParentInfo().Return(nil)
+
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	nCtx := &mocks.NodeExecutionContext{}
+	nCtx.OnNode().Return(n)
+	nCtx.OnNodeID().Return("array")
+	nCtx.OnCurrentAttempt().Return(0)
+	nCtx.OnInputReader().Return(ir)
+	nCtx.OnNodeStatus().Return(ns)
+	nCtx.OnNodeStateReader().Return(sr)
+	nCtx.OnNodeStateWriter().Return(holder)
+	nCtx.OnExecutionContext().Return(execContext)
+	nCtx.OnDataStore().Return(store)
+	nCtx.OnEnqueueOwnerFunc().Return(func() error { return nil })
+	return nCtx, store
+}
+
+// Moves every sub node handed to the node executor to the given phase.
+func newNodeExecutor(phase v1alpha1.NodePhase) *execMocks.Node {
+	nodeExec := &execMocks.Node{}
+	nodeExec.OnRecursiveNodeHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(executors.NodeStatusComplete, nil).
+		Run(func(args mock.Arguments) {
+			nl := args.Get(3).(executors.NodeLookup)
+			subNode := args.Get(4).(v1alpha1.ExecutableNode)
+			nl.GetNodeExecutionStatus(context.TODO(), subNode.GetID()).UpdatePhase(phase, metav1.Now(), "", nil)
+		})
+	return nodeExec
+}
+
+func TestArrayNodeHandler_Handle(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("missing spec", func(t *testing.T) {
+		nCtx, _ := newNodeExecutionContext(t, nil, handler.ArrayNodeState{}, &arrayNodeStateHolder{})
+		_, err := New(&execMocks.Node{}, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.Error(t, err)
+	})
+
+	t.Run("start", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), handler.ArrayNodeState{}, holder)
+		tr, err := New(&execMocks.Node{}, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRunning, tr.Info().GetPhase())
+		assert.Equal(t, v1alpha1.ArrayNodePhaseExecuting, holder.s.Phase)
+		assert.Equal(t, uint(3), holder.s.SubNodePhases.ItemsCount)
+	})
+
+	t.Run("parallelism", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		arrayNode := newArrayNodeSpec()
+		arrayNode.Parallelism = 2
+		nodeExec := newNodeExecutor(v1alpha1.NodePhaseRunning)
+		nCtx, _ := newNodeExecutionContext(t, arrayNode, newState(t, v1alpha1.NodePhaseNotYetStarted, v1alpha1.NodePhaseNotYetStarted, v1alpha1.NodePhaseNotYetStarted), holder)
+		tr, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRunning, tr.Info().GetPhase())
+		nodeExec.AssertNumberOfCalls(t, "RecursiveNodeHandler", 2)
+		assert.Equal(t, v1alpha1.ArrayNodePhaseExecuting, holder.s.Phase)
+		assert.Equal(t, bitarray.Item(v1alpha1.NodePhaseRunning), holder.s.SubNodePhases.GetItem(1))
+		assert.Equal(t, bitarray.Item(v1alpha1.NodePhaseNotYetStarted), holder.s.SubNodePhases.GetItem(2))
+	})
+
+	t.Run("succeeding", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		nodeExec := newNodeExecutor(v1alpha1.NodePhaseSucceeded)
+		nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), newState(t, v1alpha1.NodePhaseSucceeded, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseQueued), holder)
+		_, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		nodeExec.AssertNumberOfCalls(t, "RecursiveNodeHandler", 2)
+		assert.Equal(t, v1alpha1.ArrayNodePhaseSucceeding, holder.s.Phase)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		nodeExec := newNodeExecutor(v1alpha1.NodePhaseRunning)
+		nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), newState(t, v1alpha1.NodePhaseFailed, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseRunning), holder)
+		_, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, v1alpha1.ArrayNodePhaseFailing, holder.s.Phase)
+		assert.Equal(t, SubNodesFailed, holder.s.Error.GetCode())
+	})
+
+	t.Run("min success ratio", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		arrayNode := newArrayNodeSpec()
+		ratio := float32(0.5)
+		arrayNode.MinSuccessRatio = &ratio
+		nodeExec := newNodeExecutor(v1alpha1.NodePhaseSucceeded)
+		nCtx, _ := newNodeExecutionContext(t, arrayNode, newState(t, v1alpha1.NodePhaseFailed, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseRunning), holder)
+		_, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, v1alpha1.ArrayNodePhaseSucceeding, holder.s.Phase)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		nodeExec := &execMocks.Node{}
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		state := newState(t, v1alpha1.NodePhaseFailed, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseSucceeded)
+		state.Phase = v1alpha1.ArrayNodePhaseFailing
+		state.Error = &core.ExecutionError{Code: SubNodesFailed}
+		nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), state, holder)
+		tr, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseFailed, tr.Info().GetPhase())
+		nodeExec.AssertNumberOfCalls(t, "AbortHandler", 1)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		state := newState(t, v1alpha1.NodePhaseSucceeded, v1alpha1.NodePhaseFailed, v1alpha1.NodePhaseSucceeded)
+		state.Phase = v1alpha1.ArrayNodePhaseSucceeding
+		state.SubNodeRetryAttempts.SetItem(2, 1)
+		nCtx, store := newNodeExecutionContext(t, newArrayNodeSpec(), state, holder)
+		for i, ref := range []storage.DataReference{arrayOutputDir + "/0/0/outputs.pb", arrayOutputDir + "/2/1/outputs.pb"} {
+			assert.NoError(t, store.WriteProtobuf(ctx, ref, storage.Options{}, &core.LiteralMap{
+				Literals: map[string]*core.Literal{"y": coreutils.MustMakeLiteral(i)},
+			}))
+		}
+
+		tr, err := New(&execMocks.Node{}, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseSuccess, tr.Info().GetPhase())
+		outputs := &core.LiteralMap{}
+		assert.NoError(t, store.ReadProtobuf(ctx, tr.Info().GetInfo().OutputInfo.OutputURI, outputs))
+		literals := outputs.GetLiterals()["y"].GetCollection().GetLiterals()
+		assert.Len(t, literals, 3)
+		assert.Equal(t, int64(0), literals[0].GetScalar().GetPrimitive().GetInteger())
+		assert.NotNil(t, literals[1].GetScalar().GetNoneType())
+		assert.Equal(t, int64(1), literals[2].GetScalar().GetPrimitive().GetInteger())
+	})
+}
+
+func TestArrayNodeHandler_TaskState(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("persisted while in flight", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		nodeExec := &execMocks.Node{}
+		nodeExec.OnRecursiveNodeHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(executors.NodeStatusPending, nil).
+			Run(func(args mock.Arguments) {
+				nl := args.Get(3).(executors.NodeLookup)
+				subNode := args.Get(4).(v1alpha1.ExecutableNode)
+				subNodeStatus := nl.GetNodeExecutionStatus(context.TODO(), subNode.GetID())
+				phase := v1alpha1.NodePhaseRunning
+				if subNode.GetID() == "2" {
+					phase = v1alpha1.NodePhaseSucceeded
+				}
+
+				subNodeStatus.UpdatePhase(phase, metav1.Now(), "", nil)
+				subNodeStatus.GetOrCreateTaskStatus().SetPluginState([]byte("state-" + subNode.GetID()))
+			})
+
+		state := newState(t, v1alpha1.NodePhaseNotYetStarted, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseRunning)
+		state.SubNodeTaskStates = map[int]*v1alpha1.TaskNodeStatus{2: {PluginState: []byte("stale")}}
+		nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), state, holder)
+		_, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Len(t, holder.s.SubNodeTaskStates, 2)
+		assert.Equal(t, []byte("state-0"), holder.s.SubNodeTaskStates[0].GetPluginState())
+		assert.Equal(t, []byte("state-1"), holder.s.SubNodeTaskStates[1].GetPluginState())
+		assert.NotContains(t, holder.s.SubNodeTaskStates, 2)
+	})
+
+	t.Run("restored", func(t *testing.T) {
+		holder := &arrayNodeStateHolder{}
+		var restored []byte
+		nodeExec := &execMocks.Node{}
+		nodeExec.OnRecursiveNodeHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(executors.NodeStatusPending, nil).
+			Run(func(args mock.Arguments) {
+				nl := args.Get(3).(executors.NodeLookup)
+				subNode := args.Get(4).(v1alpha1.ExecutableNode)
+				restored = nl.GetNodeExecutionStatus(context.TODO(), subNode.GetID()).GetTaskNodeStatus().GetPluginState()
+			})
+
+		state := newState(t, v1alpha1.NodePhaseRunning)
+		state.SubNodeTaskStates = map[int]*v1alpha1.TaskNodeStatus{0: {PluginState: []byte("state-0")}}
+		nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), state, holder)
+		_, err := New(nodeExec, promutils.NewTestScope()).Handle(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("state-0"), restored)
+	})
+}
+
+func TestArrayNodeHandler_EmptyInputs(t *testing.T) {
+	ctx := context.TODO()
+	holder := &arrayNodeStateHolder{}
+	arrayNode := newArrayNodeSpec()
+	taskID := "task-1"
+	arrayNode.SubNodeSpec.TaskRef = &taskID
+	state := newState(t)
+	state.Phase = v1alpha1.ArrayNodePhaseSucceeding
+	nCtx, store := newNodeExecutionContext(t, arrayNode, state, holder)
+
+	task := &coreMocks.ExecutableTask{}
+	task.OnCoreTask().Return(&core.TaskTemplate{
+		Interface: &core.TypedInterface{
+			Outputs: &core.VariableMap{Variables: map[string]*core.Variable{
+				"y": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+			}},
+		},
+	})
+	nCtx.ExecutionContext().(*execMocks.ExecutionContext).OnGetTask(taskID).Return(task, nil)
+
+	tr, err := New(&execMocks.Node{}, promutils.NewTestScope()).Handle(ctx, nCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseSuccess, tr.Info().GetPhase())
+	if assert.NotNil(t, tr.Info().GetInfo().OutputInfo) {
+		outputs := &core.LiteralMap{}
+		assert.NoError(t, store.ReadProtobuf(ctx, tr.Info().GetInfo().OutputInfo.OutputURI, outputs))
+		if assert.NotNil(t, outputs.GetLiterals()["y"].GetCollection()) {
+			assert.Empty(t, outputs.GetLiterals()["y"].GetCollection().GetLiterals())
+		}
+	}
+}
+
+func TestArrayNodeHandler_Abort(t *testing.T) {
+	nodeExec := &execMocks.Node{}
+	nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "reason").Return(nil)
+	state := newState(t, v1alpha1.NodePhaseNotYetStarted, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseQueued)
+	state.Phase = v1alpha1.ArrayNodePhaseExecuting
+	nCtx, _ := newNodeExecutionContext(t, newArrayNodeSpec(), state, &arrayNodeStateHolder{})
+	assert.NoError(t, New(nodeExec, promutils.NewTestScope()).Abort(context.TODO(), nCtx, "reason"))
+	nodeExec.AssertNumberOfCalls(t, "AbortHandler", 2)
+}
+
+func TestArraySize(t *testing.T) {
+	size, err := arraySize(newInputs())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, size)
+
+	_, err = arraySize(&core.LiteralMap{Literals: map[string]*core.Literal{"prefix": coreutils.MustMakeLiteral("p")}})
+	assert.Error(t, err)
+
+	_, err = arraySize(&core.LiteralMap{Literals: map[string]*core.Literal{
+		"x": coreutils.MustMakeLiteral([]interface{}{1, 2}),
+		"y": coreutils.MustMakeLiteral([]interface{}{1}),
+	}})
+	assert.Error(t, err)
+}
+
+func TestMinSuccesses(t *testing.T) {
+	arrayNode := newArrayNodeSpec()
+	assert.Equal(t, 10, minSuccesses(arrayNode, 10))
+
+	ratio := float32(0.25)
+	arrayNode.MinSuccessRatio = &ratio
+	assert.Equal(t, 3, minSuccesses(arrayNode, 10))
+
+	successes := uint32(20)
+	arrayNode.MinSuccesses = &successes
+	assert.Equal(t, 10, minSuccesses(arrayNode, 10))
+}
+
+func TestSubNodeInputBindings(t *testing.T) {
+	bindings, err := subNodeInputBindings(newInputs(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, bindings, 2)
+	for _, b := range bindings {
+		switch b.GetVar() {
+		case "x":
+			assert.Equal(t, int64(2), b.GetBinding().GetScalar().GetPrimitive().GetInteger())
+		case "prefix":
+			assert.Equal(t, "p", b.GetBinding().GetScalar().GetPrimitive().GetStringValue())
+		default:
+			assert.Fail(t, "unexpected binding", b.GetVar())
+		}
+	}
+}
+
+func TestLiteralToBindingData(t *testing.T) {
+	binding, err := literalToBindingData(coreutils.MustMakeLiteral(map[string]interface{}{"a": []interface{}{1}}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), binding.GetMap().GetBindings()["a"].GetCollection().GetBindings()[0].GetScalar().GetPrimitive().GetInteger())
+
+	_, err = literalToBindingData(&core.Literal{})
+	assert.Error(t, err)
+
+	_, err = literalToBindingData(&core.Literal{Value: &core.Literal_Collection{Collection: &core.LiteralCollection{
+		Literals: []*core.Literal{{}},
+	}}})
+	assert.Error(t, err)
+
+	_, err = subNodeInputBindings(&core.LiteralMap{Literals: map[string]*core.Literal{"x": {}}}, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package array
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+)
+
+// subNodeLookup exposes a single sub node of an array node, together with its restored status, to the node executor.
+type subNodeLookup struct {
+	subNode       v1alpha1.ExecutableNode
+	subNodeStatus v1alpha1.ExecutableNodeStatus
+}
+
+func (s subNodeLookup) GetNode(nodeID v1alpha1.NodeID) (v1alpha1.ExecutableNode, bool) {
+	if nodeID == s.subNode.GetID() {
+		return s.subNode, true
+	}
+
+	return nil, false
+}
+
+func (s subNodeLookup) GetNodeExecutionStatus(_ context.Context, id v1alpha1.NodeID) v1alpha1.ExecutableNodeStatus {
+	if id == s.subNode.GetID() {
+		return s.subNodeStatus
+	}
+
+	return nil
+}
+
+func newSubNodeLookup(subNode v1alpha1.ExecutableNode, subNodeStatus v1alpha1.ExecutableNodeStatus) executors.NodeLookup {
+	return subNodeLookup{
+		subNode:       subNode,
+		subNodeStatus: subNodeStatus,
+	}
+}
@@ -0,0 +1,201 @@
+package nodes
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/sizedcache"
+)
+
+const (
+	// Number of binding plans that are kept in memory, across all workflows.
+	defaultBindingPlanCacheSize = 10000
+	// Binding plans are only useful while a workflow is being evaluated, they expire so that the plans of finished
+	// workflows do not linger in the cache.
+	defaultBindingPlanTTL = time.Hour
+	// The estimated size in memory of a planned output, besides the names of its node and variables.
+	plannedOutputOverheadBytes = 96
+)
+
+type promiseRef struct {
+	nodeID    v1alpha1.NodeID
+	bindToVar VarName
+}
+
+type plannedOutput struct {
+	// Index of the subtask whose output is read, nil if the output of the node is read as is.
+	index *int
+	// The output variable of the upstream node, with output aliases resolved.
+	varName VarName
+}
+
+// bindingPlan records which outputs of which upstream nodes feed the variables of a set of bindings, e.g. the output
+// bindings of a (sub)workflow. Building the plan parses every promise and resolves the output aliases of the upstream
+// nodes once, resolving the bindings with the plan then reads the outputs of every upstream node only once, no matter
+// how many variables are bound to them.
+type bindingPlan struct {
+	outputs map[promiseRef]plannedOutput
+}
+
+func newBindingPlan(ctx context.Context, nl executors.NodeLookup, nodeID v1alpha1.NodeID, bindings []*v1alpha1.Binding) (
+	*bindingPlan, error) {
+	plan := &bindingPlan{
+		outputs: map[promiseRef]plannedOutput{},
+	}
+
+	for _, binding := range bindings {
+		_, err := resolveBindingData(ctx, binding.GetBinding(), func(promise *core.OutputReference) (*core.Literal, error) {
+			ref := promiseRef{nodeID: promise.GetNodeId(), bindToVar: promise.GetVar()}
+			if _, ok := plan.outputs[ref]; ok {
+				return nil, nil
+			}
+
+			n, err := getUpstreamNode(nl, promise)
+			if err != nil {
+				return nil, err
+			}
+
+			index, varName, err := parseOutputVar(ctx, n, promise.GetVar())
+			if err != nil {
+				return nil, err
+			}
+
+			plan.outputs[ref] = plannedOutput{index: index, varName: varName}
+			return nil, nil
+		})
+
+		if err != nil {
+			return nil, errors.Wrapf(errors.BindingResolutionError, nodeID, err, "Error binding Var [%v].[%v]", "wf", binding.GetVar())
+		}
+	}
+
+	return plan, nil
+}
+
+// Estimates the size of the plan in memory.
+func (p *bindingPlan) sizeBytes() int64 {
+	size := int64(0)
+	for ref, output := range p.outputs {
+		size += int64(len(ref.nodeID)+len(ref.bindToVar)+len(output.varName)) + plannedOutputOverheadBytes
+	}
+
+	return size
+}
+
+// Resolves the bindings the plan was built for to literals.
+func (p *bindingPlan) Resolve(ctx context.Context, store storage.ProtobufStore, nl executors.NodeLookup,
+	nodeID v1alpha1.NodeID, bindings []*v1alpha1.Binding) (*core.LiteralMap, error) {
+	upstreamOutputs := make(map[v1alpha1.NodeID]*core.LiteralMap)
+	resolvePromise := func(promise *core.OutputReference) (*core.Literal, error) {
+		upstreamNodeID := promise.GetNodeId()
+		output, ok := p.outputs[promiseRef{nodeID: upstreamNodeID, bindToVar: promise.GetVar()}]
+		if !ok {
+			return nil, errors.Errorf(errors.IllegalStateError, upstreamNodeID,
+				"No binding plan for variable [%s]", promise.GetVar())
+		}
+
+		d, ok := upstreamOutputs[upstreamNodeID]
+		if !ok {
+			nodeStatus := nl.GetNodeExecutionStatus(ctx, upstreamNodeID)
+			var err error
+			d, err = readOutputs(ctx, store, upstreamNodeID, v1alpha1.GetOutputsFile(nodeStatus.GetOutputDir()))
+			if err != nil {
+				return nil, err
+			}
+
+			upstreamOutputs[upstreamNodeID] = d
+		}
+
+		if output.index == nil {
+			return getSingleOutput(upstreamNodeID, d, output.varName)
+		}
+
+		return getSubtaskOutput(upstreamNodeID, d, *output.index, output.varName)
+	}
+
+	literalMap := make(map[string]*core.Literal, len(bindings))
+	for _, binding := range bindings {
+		l, err := resolveBindingData(ctx, binding.GetBinding(), resolvePromise)
+		if err != nil {
+			return nil, errors.Wrapf(errors.BindingResolutionError, nodeID, err, "Error binding Var [%v].[%v]", "wf", binding.GetVar())
+		}
+
+		literalMap[binding.GetVar()] = l
+	}
+
+	return &core.LiteralMap{
+		Literals: literalMap,
+	}, nil
+}
+
+// bindingPlanCache keeps the binding plans of end nodes, so that they are built once per (sub)workflow execution
+// rather than every time the end node is evaluated. Plans are not modified once they're built, so they may be used by
+// nodes that are evaluated concurrently.
+type bindingPlanCache struct {
+	plans *sizedcache.Cache
+	// Nodes that are evaluated concurrently and miss the same plan wait for one of them to build it.
+	builds singleflight.Group
+	ttl    time.Duration
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+// Returns the binding plan cached for the node, or builds and caches it on first use. A nil cache builds a new plan
+// every time.
+func (c *bindingPlanCache) GetOrCreate(ctx context.Context, md handler.NodeExecutionMetadata, nl executors.NodeLookup,
+	nodeID v1alpha1.NodeID, bindings []*v1alpha1.Binding) (*bindingPlan, error) {
+	if c == nil {
+		return newBindingPlan(ctx, nl, nodeID, bindings)
+	}
+
+	key := bindingPlanKey(md)
+	if plan, ok := c.plans.Get(key); ok {
+		c.hits.Inc()
+		return plan.(*bindingPlan), nil
+	}
+
+	c.misses.Inc()
+	plan, err, _ := c.builds.Do(key, func() (interface{}, error) {
+		plan, err := newBindingPlan(ctx, nl, nodeID, bindings)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Debugf(ctx, "Caching binding plan for [%v]", key)
+		c.plans.Add(key, plan, plan.sizeBytes(), c.ttl)
+		return plan, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.(*bindingPlan), nil
+}
+
+// The unique id of a node execution includes the ids and attempts of all its parents, so every (sub)workflow, and every
+// attempt of a dynamic node that may generate a different workflow, gets a plan of its own.
+func bindingPlanKey(md handler.NodeExecutionMetadata) string {
+	return string(md.GetOwnerReference().UID) + "/" + md.GetNodeExecutionID().GetNodeId()
+}
+
+func newBindingPlanCache(size int, maxSizeBytes int64, ttl time.Duration, scope promutils.Scope) *bindingPlanCache {
+	return &bindingPlanCache{
+		plans:  sizedcache.New(size, maxSizeBytes, clock.RealClock{}, scope),
+		ttl:    ttl,
+		hits:   scope.MustNewCounter("hits", "Number of binding plans served from the cache"),
+		misses: scope.MustNewCounter("misses", "Number of binding plans built because they were not cached"),
+	}
+}
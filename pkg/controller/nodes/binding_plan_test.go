@@ -0,0 +1,165 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+	flyteassert "github.com/flyteorg/flytepropeller/pkg/utils/assert"
+)
+
+type readCountingStore struct {
+	storage.ProtobufStore
+	reads int
+}
+
+func (s *readCountingStore) ReadProtobuf(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+	s.reads++
+	return s.ProtobufStore.ReadProtobuf(ctx, reference, msg)
+}
+
+func TestBindingPlan(t *testing.T) {
+	ctx := context.Background()
+	n1 := &v1alpha1.NodeSpec{
+		ID: "n1",
+		OutputAliases: []v1alpha1.Alias{
+			{Alias: core.Alias{
+				Var:   "x",
+				Alias: "m",
+			}},
+		},
+	}
+	n2 := &v1alpha1.NodeSpec{ID: "n2"}
+
+	w := &dummyBaseWorkflow{
+		Status: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+			"n1": {OutputDir: "n1"},
+			"n2": {OutputDir: "n2"},
+		},
+		GetNodeCb: func(nodeId v1alpha1.NodeID) (v1alpha1.ExecutableNode, bool) {
+			switch nodeId {
+			case "n1":
+				return n1, true
+			case "n2":
+				return n2, true
+			}
+			return nil, false
+		},
+	}
+
+	bindings := []*v1alpha1.Binding{
+		{Binding: utils.MakeBinding("x", utils.MakeBindingDataPromise("n1", "x"))},
+		{Binding: utils.MakeBinding("aliased", utils.MakeBindingDataPromise("n1", "m"))},
+		{Binding: utils.MakeBinding("collection", utils.MakeBindingDataCollection(
+			utils.MakeBindingDataPromise("n1", "y"),
+			utils.MakeBindingDataPromise("n2", "[1].array"),
+		))},
+		{Binding: utils.MakeBinding("simple", utils.MustMakePrimitiveBindingData(5))},
+	}
+
+	t.Run("Resolve", func(t *testing.T) {
+		store := createInmemoryDataStore(t, testScope.NewSubScope("plan_resolve"))
+		assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetOutputsFile("n1"), storage.Options{},
+			coreutils.MustMakeLiteral(map[string]interface{}{"x": 1, "y": 2}).GetMap()))
+		assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetOutputsFile("n2"), storage.Options{},
+			coreutils.MustMakeLiteral(map[string]interface{}{"array": []interface{}{3, 4}}).GetMap()))
+
+		plan, err := newBindingPlan(ctx, w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		assert.Len(t, plan.outputs, 4)
+		assert.Equal(t, "x", plan.outputs[promiseRef{nodeID: "n1", bindToVar: "m"}].varName)
+
+		countingStore := &readCountingStore{ProtobufStore: store}
+		l, err := plan.Resolve(ctx, countingStore, w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		expected, err := coreutils.MakeLiteralMap(map[string]interface{}{
+			"x":          1,
+			"aliased":    1,
+			"collection": []interface{}{2, 4},
+			"simple":     5,
+		})
+		assert.NoError(t, err)
+		flyteassert.EqualLiteralMap(t, expected, l)
+		// The outputs of every upstream node are read once.
+		assert.Equal(t, 2, countingStore.reads)
+	})
+
+	t.Run("ResolveMissingOutputs", func(t *testing.T) {
+		store := createInmemoryDataStore(t, testScope.NewSubScope("plan_missing"))
+		plan, err := newBindingPlan(ctx, w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		_, err = plan.Resolve(ctx, store, w, v1alpha1.EndNodeID, bindings)
+		assert.Error(t, err)
+	})
+
+	t.Run("UndefinedNode", func(t *testing.T) {
+		_, err := newBindingPlan(ctx, w, v1alpha1.EndNodeID, []*v1alpha1.Binding{
+			{Binding: utils.MakeBinding("x", utils.MakeBindingDataPromise("n3", "x"))},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestBindingPlanCache(t *testing.T) {
+	ctx := context.Background()
+	n1 := &v1alpha1.NodeSpec{ID: "n1"}
+	w := &dummyBaseWorkflow{
+		GetNodeCb: func(nodeId v1alpha1.NodeID) (v1alpha1.ExecutableNode, bool) {
+			return n1, nodeId == "n1"
+		},
+	}
+
+	bindings := []*v1alpha1.Binding{
+		{Binding: utils.MakeBinding("x", utils.MakeBindingDataPromise("n1", "x"))},
+	}
+
+	newMetadata := func(uid, nodeID string) *mocks.NodeExecutionMetadata {
+		md := &mocks.NodeExecutionMetadata{}
+		md.OnGetOwnerReference().Return(v1.OwnerReference{UID: types.UID(uid)})
+		md.OnGetNodeExecutionID().Return(&core.NodeExecutionIdentifier{NodeId: nodeID})
+		return md
+	}
+
+	t.Run("CachedPerNodeExecution", func(t *testing.T) {
+		c := newBindingPlanCache(10, 0, time.Hour, promutils.NewTestScope())
+		p1, err := c.GetOrCreate(ctx, newMetadata("uid", "sub-end-node"), w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		p2, err := c.GetOrCreate(ctx, newMetadata("uid", "sub-end-node"), w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		assert.True(t, p1 == p2)
+
+		p3, err := c.GetOrCreate(ctx, newMetadata("uid", "other-end-node"), w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		assert.False(t, p1 == p3)
+	})
+
+	t.Run("FailedPlansAreNotCached", func(t *testing.T) {
+		c := newBindingPlanCache(10, 0, time.Hour, promutils.NewTestScope())
+		invalid := []*v1alpha1.Binding{
+			{Binding: utils.MakeBinding("x", utils.MakeBindingDataPromise("n3", "x"))},
+		}
+		_, err := c.GetOrCreate(ctx, newMetadata("uid", "end-node"), w, v1alpha1.EndNodeID, invalid)
+		assert.Error(t, err)
+		_, err = c.GetOrCreate(ctx, newMetadata("uid", "end-node"), w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+	})
+
+	t.Run("NilCache", func(t *testing.T) {
+		var c *bindingPlanCache
+		p, err := c.GetOrCreate(ctx, newMetadata("uid", "end-node"), w, v1alpha1.EndNodeID, bindings)
+		assert.NoError(t, err)
+		assert.NotNil(t, p)
+	})
+}
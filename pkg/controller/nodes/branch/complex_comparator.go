@@ -0,0 +1,92 @@
+package branch
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func isNoneLiteral(l *core.Literal) bool {
+	return l == nil || l.GetScalar().GetNoneType() != nil
+}
+
+// Returns the number of elements of a collection or map literal.
+func literalLength(l *core.Literal) (int, error) {
+	switch v := l.GetValue().(type) {
+	case *core.Literal_Collection:
+		return len(v.Collection.GetLiterals()), nil
+	case *core.Literal_Map:
+		return len(v.Map.GetLiterals()), nil
+	}
+
+	return 0, errors.Errorf(ErrorCodeMalformedBranch, "Length is only defined for collections and maps. LHS Variable is neither.")
+}
+
+// Returns the primitive of the right operand, which is either a primitive or a variable holding a primitive.
+func getRightPrimitive(expr *core.ComparisonExpression, nodeInputs *core.LiteralMap) (*core.Primitive, error) {
+	if expr.GetRightValue().GetPrimitive() != nil {
+		return expr.GetRightValue().GetPrimitive(), nil
+	}
+
+	rValue := nodeInputs.GetLiterals()[expr.GetRightValue().GetVar()]
+	if rValue == nil {
+		return nil, errors.Errorf(ErrorCodeMalformedBranch, "Failed to find Value for Variable [%v]", expr.GetRightValue().GetVar())
+	}
+
+	if rValue.GetScalar().GetPrimitive() == nil {
+		return nil, errors.Errorf(ErrorCodeMalformedBranch, "Only primitives can be compared. RHS Variable is non primitive.")
+	}
+
+	return rValue.GetScalar().GetPrimitive(), nil
+}
+
+// EvaluateComplexComparison evaluates the comparisons propeller supports on complex literals: comparing the length of a
+// collection or map, checking whether a map has a key and checking whether a variable is None.
+func EvaluateComplexComparison(expr *core.ComparisonExpression, nodeInputs *core.LiteralMap) (bool, error) {
+	if expr.GetLeftValue().GetPrimitive() != nil {
+		return false, errors.Errorf(ErrorCodeMalformedBranch, "Operator [%v] requires a variable on the LHS.", expr.GetOperator())
+	}
+
+	lValue := nodeInputs.GetLiterals()[expr.GetLeftValue().GetVar()]
+	switch expr.GetOperator() {
+	case v1alpha1.ComparisonExpressionIsNone:
+		return isNoneLiteral(lValue), nil
+	case v1alpha1.ComparisonExpressionIsNotNone:
+		return !isNoneLiteral(lValue), nil
+	}
+
+	if lValue == nil {
+		return false, errors.Errorf(ErrorCodeMalformedBranch, "Failed to find Value for Variable [%v]", expr.GetLeftValue().GetVar())
+	}
+
+	rPrim, err := getRightPrimitive(expr, nodeInputs)
+	if err != nil {
+		return false, err
+	}
+
+	if op, ok := v1alpha1.GetLengthComparisonOperator(expr.GetOperator()); ok {
+		length, err := literalLength(lValue)
+		if err != nil {
+			return false, err
+		}
+
+		return Evaluate(&core.Primitive{Value: &core.Primitive_Integer{Integer: int64(length)}}, rPrim, op)
+	}
+
+	switch expr.GetOperator() {
+	case v1alpha1.ComparisonExpressionHasKey, v1alpha1.ComparisonExpressionNotHasKey:
+		if lValue.GetMap() == nil {
+			return false, errors.Errorf(ErrorCodeMalformedBranch, "Key existence is only defined for maps. LHS Variable is not a map.")
+		}
+
+		if _, ok := rPrim.GetValue().(*core.Primitive_StringValue); !ok {
+			return false, errors.Errorf(ErrorCodeMalformedBranch, "Map keys are strings. RHS is not a string.")
+		}
+
+		_, hasKey := lValue.GetMap().GetLiterals()[rPrim.GetStringValue()]
+		return hasKey == (expr.GetOperator() == v1alpha1.ComparisonExpressionHasKey), nil
+	}
+
+	return false, errors.Errorf(ErrorCodeMalformedBranch, "Unsupported operator type in Propeller. System error.")
+}
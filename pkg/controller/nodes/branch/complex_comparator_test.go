@@ -0,0 +1,141 @@
+package branch
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func getComplexComparisonExpression(op core.ComparisonExpression_Operator, rV interface{}) *core.ComparisonExpression {
+	exp := &core.ComparisonExpression{
+		LeftValue: &core.Operand{
+			Val: &core.Operand_Var{
+				Var: "x",
+			},
+		},
+		Operator: op,
+	}
+
+	if rV != nil {
+		exp.RightValue = &core.Operand{
+			Val: &core.Operand_Primitive{
+				Primitive: coreutils.MustMakePrimitive(rV),
+			},
+		}
+	}
+
+	return exp
+}
+
+func TestEvaluateComplexComparison(t *testing.T) {
+	collection := &core.Literal{
+		Value: &core.Literal_Collection{
+			Collection: &core.LiteralCollection{
+				Literals: []*core.Literal{coreutils.MustMakeLiteral(1), coreutils.MustMakeLiteral(2)},
+			},
+		},
+	}
+	literalMap := &core.Literal{
+		Value: &core.Literal_Map{
+			Map: &core.LiteralMap{
+				Literals: map[string]*core.Literal{"k": coreutils.MustMakeLiteral(1)},
+			},
+		},
+	}
+	none := &core.Literal{
+		Value: &core.Literal_Scalar{
+			Scalar: &core.Scalar{Value: &core.Scalar_NoneType{NoneType: &core.Void{}}},
+		},
+	}
+	inputs := func(x *core.Literal) *core.LiteralMap {
+		return &core.LiteralMap{Literals: map[string]*core.Literal{"x": x, "y": coreutils.MustMakeLiteral(2)}}
+	}
+
+	t.Run("Length", func(t *testing.T) {
+		tests := []struct {
+			op       core.ComparisonExpression_Operator
+			x        *core.Literal
+			expected bool
+		}{
+			{v1alpha1.ComparisonExpressionLengthEQ, collection, true},
+			{v1alpha1.ComparisonExpressionLengthNEQ, collection, false},
+			{v1alpha1.ComparisonExpressionLengthGT, collection, false},
+			{v1alpha1.ComparisonExpressionLengthGTE, collection, true},
+			{v1alpha1.ComparisonExpressionLengthLT, literalMap, true},
+			{v1alpha1.ComparisonExpressionLengthLTE, literalMap, true},
+		}
+
+		for _, test := range tests {
+			v, err := EvaluateComparison(getComplexComparisonExpression(test.op, 2), inputs(test.x))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, v, "operator [%v]", test.op)
+		}
+
+		// The RHS may be a variable too.
+		exp := getComplexComparisonExpression(v1alpha1.ComparisonExpressionLengthEQ, nil)
+		exp.RightValue = &core.Operand{Val: &core.Operand_Var{Var: "y"}}
+		v, err := EvaluateComparison(exp, inputs(collection))
+		assert.NoError(t, err)
+		assert.True(t, v)
+
+		_, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionLengthEQ, 2), inputs(coreutils.MustMakeLiteral(2)))
+		assert.Error(t, err)
+
+		_, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionLengthEQ, "2"), inputs(collection))
+		assert.Error(t, err)
+	})
+
+	t.Run("HasKey", func(t *testing.T) {
+		v, err := EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionHasKey, "k"), inputs(literalMap))
+		assert.NoError(t, err)
+		assert.True(t, v)
+
+		v, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionHasKey, "other"), inputs(literalMap))
+		assert.NoError(t, err)
+		assert.False(t, v)
+
+		v, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionNotHasKey, "other"), inputs(literalMap))
+		assert.NoError(t, err)
+		assert.True(t, v)
+
+		_, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionHasKey, "k"), inputs(collection))
+		assert.Error(t, err)
+
+		_, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionHasKey, 1), inputs(literalMap))
+		assert.Error(t, err)
+	})
+
+	t.Run("IsNone", func(t *testing.T) {
+		v, err := EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionIsNone, nil), inputs(none))
+		assert.NoError(t, err)
+		assert.True(t, v)
+
+		v, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionIsNone, nil), &core.LiteralMap{})
+		assert.NoError(t, err)
+		assert.True(t, v)
+
+		v, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionIsNone, nil), nil)
+		assert.NoError(t, err)
+		assert.True(t, v)
+
+		v, err = EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionIsNotNone, nil), inputs(collection))
+		assert.NoError(t, err)
+		assert.True(t, v)
+	})
+
+	t.Run("PrimitiveLHS", func(t *testing.T) {
+		exp := getComplexComparisonExpression(v1alpha1.ComparisonExpressionIsNone, nil)
+		exp.LeftValue = &core.Operand{Val: &core.Operand_Primitive{Primitive: coreutils.MustMakePrimitive(1)}}
+		_, err := EvaluateComparison(exp, inputs(collection))
+		assert.Error(t, err)
+	})
+
+	t.Run("MissingVariable", func(t *testing.T) {
+		_, err := EvaluateComparison(getComplexComparisonExpression(v1alpha1.ComparisonExpressionLengthEQ, 2), &core.LiteralMap{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,114 @@
+package branch
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// The data the message template of an ElseFail is executed with.
+type elseFailTemplateData struct {
+	// Values of the variables compared by the conditions of the branch node, by name.
+	Values map[string]string
+}
+
+// Returns the code and the kind of the error the branch node fails with when no branch is satisfied.
+func elseFailErrorCode(node v1alpha1.ExecutableBranchNode) (string, core.ExecutionError_ErrorKind) {
+	code := ErrorCodeUserProvidedError
+	kind := core.ExecutionError_USER
+	if md := node.GetElseFailMetadata(); md != nil {
+		if len(md.Code) > 0 {
+			code = md.Code
+		}
+
+		if md.Kind != core.ExecutionError_UNKNOWN {
+			kind = md.Kind
+		}
+	}
+
+	return code, kind
+}
+
+// Returns the error of the ElseFail of the branch node, with the message rendered from its template if it has one.
+func elseFailError(node v1alpha1.ExecutableBranchNode, nodeInputs *core.LiteralMap) error {
+	code, _ := elseFailErrorCode(node)
+	message := node.GetElseFail().GetMessage()
+	if md := node.GetElseFailMetadata(); md != nil && len(md.MessageTemplate) > 0 {
+		rendered, err := renderElseFailMessage(md.MessageTemplate, node, nodeInputs)
+		if err != nil {
+			return errors.Errorf(ErrorCodeMalformedBranch, "Failed to render the message of the else-fail error: %v", err)
+		}
+
+		message = rendered
+	}
+
+	return errors.Errorf(code, "%s", message)
+}
+
+func renderElseFailMessage(messageTemplate string, node v1alpha1.ExecutableBranchNode, nodeInputs *core.LiteralMap) (string, error) {
+	t, err := template.New("elseFail").Option("missingkey=zero").Parse(messageTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	data := elseFailTemplateData{Values: map[string]string{}}
+	conditions := []*core.BooleanExpression{node.GetIf().GetCondition()}
+	for _, block := range node.GetElseIf() {
+		conditions = append(conditions, block.GetCondition())
+	}
+
+	for _, condition := range conditions {
+		collectComparedValues(condition, nodeInputs, data.Values)
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// Collects the values of the variables compared by the expression.
+func collectComparedValues(expr *core.BooleanExpression, nodeInputs *core.LiteralMap, values map[string]string) {
+	if c := expr.GetConjunction(); c != nil {
+		collectComparedValues(c.GetLeftExpression(), nodeInputs, values)
+		collectComparedValues(c.GetRightExpression(), nodeInputs, values)
+		return
+	}
+
+	for _, operand := range []*core.Operand{expr.GetComparison().GetLeftValue(), expr.GetComparison().GetRightValue()} {
+		if name := operand.GetVar(); len(name) > 0 {
+			if l, ok := nodeInputs.GetLiterals()[name]; ok {
+				values[name] = literalString(l)
+			}
+		}
+	}
+}
+
+// Returns the value of a primitive literal as it's written by users, and the text of the proto of any other literal.
+func literalString(l *core.Literal) string {
+	p := l.GetScalar().GetPrimitive()
+	if p == nil {
+		return proto.CompactTextString(l)
+	}
+
+	switch v := p.GetValue().(type) {
+	case *core.Primitive_Integer:
+		return fmt.Sprint(v.Integer)
+	case *core.Primitive_FloatValue:
+		return fmt.Sprint(v.FloatValue)
+	case *core.Primitive_StringValue:
+		return v.StringValue
+	case *core.Primitive_Boolean:
+		return fmt.Sprint(v.Boolean)
+	default:
+		return proto.CompactTextString(p)
+	}
+}
@@ -0,0 +1,64 @@
+package branch
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestElseFailError(t *testing.T) {
+	n1 := "n1"
+	exp, inputs := getComparisonExpression(int64(3), core.ComparisonExpression_GT, int64(5))
+	branchNode := &v1alpha1.BranchNodeSpec{
+		If: v1alpha1.IfBlock{
+			Condition: v1alpha1.BooleanExpression{
+				BooleanExpression: &core.BooleanExpression{
+					Expr: &core.BooleanExpression_Comparison{Comparison: exp},
+				},
+			},
+			ThenNode: &n1,
+		},
+		ElseFail: &v1alpha1.Error{Error: &core.Error{Message: "User error"}},
+	}
+
+	t.Run("no metadata", func(t *testing.T) {
+		err := elseFailError(branchNode, inputs)
+		code, _ := errors.GetErrorCode(err)
+		assert.Equal(t, ErrorCodeUserProvidedError, code)
+		assert.Equal(t, "[UserProvidedError] User error", err.Error())
+
+		code, kind := elseFailErrorCode(branchNode)
+		assert.Equal(t, ErrorCodeUserProvidedError, code)
+		assert.Equal(t, core.ExecutionError_USER, kind)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		b := *branchNode
+		b.ElseFailMetadata = &v1alpha1.ElseFailMetadata{
+			Code:            "SizeTooSmall",
+			MessageTemplate: "size {{ .Values.x }} is not greater than {{ .Values.y }}{{ .Values.z }}",
+			Kind:            core.ExecutionError_SYSTEM,
+		}
+
+		err := elseFailError(&b, inputs)
+		code, _ := errors.GetErrorCode(err)
+		assert.Equal(t, "SizeTooSmall", code)
+		assert.Equal(t, "[SizeTooSmall] size 3 is not greater than 5", err.Error())
+
+		code, kind := elseFailErrorCode(&b)
+		assert.Equal(t, "SizeTooSmall", code)
+		assert.Equal(t, core.ExecutionError_SYSTEM, kind)
+	})
+
+	t.Run("malformed template", func(t *testing.T) {
+		b := *branchNode
+		b.ElseFailMetadata = &v1alpha1.ElseFailMetadata{MessageTemplate: "{{ .Values.x"}
+		err := elseFailError(&b, inputs)
+		code, _ := errors.GetErrorCode(err)
+		assert.Equal(t, ErrorCodeMalformedBranch, code)
+	})
+}
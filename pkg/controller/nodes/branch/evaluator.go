@@ -18,6 +18,10 @@ const ErrorCodeCompilerError = "CompilerError"
 const ErrorCodeFailedFetchOutputs = "FailedFetchOutputs"
 
 func EvaluateComparison(expr *core.ComparisonExpression, nodeInputs *core.LiteralMap) (bool, error) {
+	if v1alpha1.IsComplexComparisonOperator(expr.GetOperator()) {
+		return EvaluateComplexComparison(expr, nodeInputs)
+	}
+
 	var lValue *core.Literal
 	var rValue *core.Literal
 	var lPrim *core.Primitive
@@ -134,7 +138,7 @@ func DecideBranch(ctx context.Context, nl executors.NodeLookup, nodeID v1alpha1.
 
 	if selectedNodeID == nil {
 		if node.GetElseFail() != nil {
-			return nil, errors.Errorf(ErrorCodeUserProvidedError, node.GetElseFail().Message)
+			return nil, elseFailError(node, nodeInputs)
 		}
 		return nil, errors.Errorf(ErrorCodeMalformedBranch, "No branch satisfied")
 	}
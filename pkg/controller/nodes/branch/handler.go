@@ -3,10 +3,15 @@ package branch
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/flyteorg/flytepropeller/events"
+	eventsErr "github.com/flyteorg/flytepropeller/events/errors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
 	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -23,9 +28,11 @@ type metrics struct {
 }
 
 type branchHandler struct {
-	nodeExecutor executors.Node
-	m            metrics
-	eventConfig  *config.EventConfig
+	nodeExecutor  executors.Node
+	m             metrics
+	eventConfig   *config.EventConfig
+	eventRecorder events.NodeEventRecorder
+	clusterID     string
 }
 
 func (b *branchHandler) FinalizeRequired() bool {
@@ -48,14 +55,29 @@ func (b *branchHandler) HandleBranchNode(ctx context.Context, branchNode v1alpha
 		if err != nil {
 			ec, ok := stdErrors.GetErrorCode(err)
 			if ok {
-				if ec == ErrorCodeMalformedBranch || ec == ErrorCodeUserProvidedError {
-					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER, ec, err.Error(), nil)), nil
+				elseFailCode, elseFailKind := elseFailErrorCode(branchNode)
+				if ec == ErrorCodeMalformedBranch || (branchNode.GetElseFail() != nil && ec == elseFailCode) {
+					// No node was taken, they were all skipped.
+					if recordErr := b.recordSkippedNodes(ctx, nCtx, branchNode, nil); recordErr != nil {
+						logger.Warningf(ctx, "Failed to record events of the nodes skipped by the branch. Error: %v", recordErr)
+					}
+
+					kind := core.ExecutionError_USER
+					if ec == elseFailCode {
+						kind = elseFailKind
+					}
+
+					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(kind, ec, err.Error(), nil)), nil
 				}
 			}
 			errMsg := fmt.Sprintf("Branch evaluation failed. Error [%s]", err)
 			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.IllegalStateError, errMsg, nil)), nil
 		}
 
+		if err := b.recordSkippedNodes(ctx, nCtx, branchNode, finalNodeID); err != nil {
+			return handler.UnknownTransition, err
+		}
+
 		branchNodeState := handler.BranchNodeState{FinalizedNodeID: finalNodeID, Phase: v1alpha1.BranchNodeSuccess}
 		err = nCtx.NodeStateWriter().PutBranchNode(branchNodeState)
 		if err != nil {
@@ -124,6 +146,91 @@ func (b *branchHandler) getExecutionContextForDownstream(nCtx handler.NodeExecut
 	return executors.NewExecutionContextWithParentInfo(nCtx.ExecutionContext(), newParentInfo), nil
 }
 
+// branchNodeIDs returns the IDs of all the nodes the branch may take.
+func branchNodeIDs(branchNode v1alpha1.ExecutableBranchNode) []v1alpha1.NodeID {
+	var ids []v1alpha1.NodeID
+	if branchNode.GetIf() != nil && branchNode.GetIf().GetThenNode() != nil {
+		ids = append(ids, *branchNode.GetIf().GetThenNode())
+	}
+
+	for _, block := range branchNode.GetElseIf() {
+		if block.GetThenNode() != nil {
+			ids = append(ids, *block.GetThenNode())
+		}
+	}
+
+	if branchNode.GetElse() != nil {
+		ids = append(ids, *branchNode.GetElse())
+	}
+
+	return ids
+}
+
+// recordSkippedNodes records an event for every node the branch did not take, so that they do not remain in an unknown
+// phase. selectedNodeID is nil if the branch took no node.
+func (b *branchHandler) recordSkippedNodes(ctx context.Context, nCtx handler.NodeExecutionContext,
+	branchNode v1alpha1.ExecutableBranchNode, selectedNodeID *v1alpha1.NodeID) error {
+	execContext, err := b.getExecutionContextForDownstream(nCtx)
+	if err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("Node skipped as no condition of branch node [%v] was satisfied", nCtx.NodeID())
+	if selectedNodeID != nil {
+		reason = fmt.Sprintf("Node skipped as branch node [%v] took node [%v]", nCtx.NodeID(), *selectedNodeID)
+	}
+
+	parentInfo := execContext.GetParentInfo()
+	occurredAt := ptypes.TimestampNow()
+	for _, nodeID := range branchNodeIDs(branchNode) {
+		if selectedNodeID != nil && nodeID == *selectedNodeID {
+			continue
+		}
+
+		nev := &event.NodeExecutionEvent{
+			Id: &core.NodeExecutionIdentifier{
+				ExecutionId: nCtx.NodeExecutionMetadata().GetNodeExecutionID().GetExecutionId(),
+				NodeId:      nodeID,
+			},
+			Phase:      core.NodeExecution_SKIPPED,
+			OccurredAt: occurredAt,
+			ProducerId: b.clusterID,
+			OutputResult: &event.NodeExecutionEvent_Error{
+				Error: &core.ExecutionError{
+					Kind:    core.ExecutionError_UNKNOWN,
+					Code:    handler.SkipReasonBranchNotTaken,
+					Message: reason,
+				},
+			},
+			EventVersion: common.NodeExecutionEventVersion,
+		}
+
+		if execContext.GetEventVersion() != v1alpha1.EventVersion0 {
+			currentNodeUniqueID, err := common.GenerateUniqueID(parentInfo, nodeID)
+			if err != nil {
+				return err
+			}
+
+			nev.Id.NodeId = currentNodeUniqueID
+			nev.ParentNodeMetadata = &event.ParentNodeExecutionMetadata{
+				NodeId: parentInfo.GetUniqueID(),
+			}
+			nev.RetryGroup = strconv.Itoa(int(parentInfo.CurrentAttempt()))
+			nev.SpecNodeId = nodeID
+			if n, ok := nCtx.ContextualNodeLookup().GetNode(nodeID); ok {
+				nev.NodeName = n.GetName()
+			}
+		}
+
+		err = b.eventRecorder.RecordNodeEvent(ctx, nev, b.eventConfig)
+		if err != nil && !eventsErr.IsAlreadyExists(err) && !eventsErr.IsEventAlreadyInTerminalStateError(err) {
+			return errors.Wrapf(errors.EventRecordingFailed, nodeID, err, "failed to record skipped node event")
+		}
+	}
+
+	return nil
+}
+
 func (b *branchHandler) recurseDownstream(ctx context.Context, nCtx handler.NodeExecutionContext, nodeStatus v1alpha1.ExecutableNodeStatus, branchTakenNode v1alpha1.ExecutableNode) (handler.Transition, error) {
 	// TODO we should replace the call to RecursiveNodeHandler with a call to SingleNode Handler. The inputs are also already known ahead of time
 	// There is no DAGStructure for the branch nodes, the branch taken node is the leaf node. The node itself may be arbitrarily complex, but in that case the node should reference a subworkflow etc
@@ -244,10 +351,13 @@ func (b *branchHandler) Finalize(ctx context.Context, nCtx handler.NodeExecution
 	return b.nodeExecutor.FinalizeHandler(ctx, execContext, dag, nCtx.ContextualNodeLookup(), branchTakenNode)
 }
 
-func New(executor executors.Node, eventConfig *config.EventConfig, scope promutils.Scope) handler.Node {
+func New(executor executors.Node, eventConfig *config.EventConfig, eventRecorder events.NodeEventRecorder, clusterID string,
+	scope promutils.Scope) handler.Node {
 	return &branchHandler{
-		nodeExecutor: executor,
-		m:            metrics{scope: scope},
-		eventConfig:  eventConfig,
+		nodeExecutor:  executor,
+		m:             metrics{scope: scope},
+		eventConfig:   eventConfig,
+		eventRecorder: eventRecorder,
+		clusterID:     clusterID,
 	}
 }
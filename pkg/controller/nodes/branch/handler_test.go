@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"testing"
 
+	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	mocks3 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -54,6 +56,10 @@ func (t branchNodeStateHolder) PutDynamicNodeState(s handler.DynamicNodeState) e
 	panic("not implemented")
 }
 
+func (t branchNodeStateHolder) PutArrayNodeState(s handler.ArrayNodeState) error {
+	panic("not implemented")
+}
+
 type parentInfo struct {
 }
 
@@ -203,7 +209,7 @@ func TestBranchHandler_RecurseDownstream(t *testing.T) {
 				childNodeStatus.On("SetDataDir", storage.DataReference("parent-data-dir")).Once()
 				childNodeStatus.On("SetOutputDir", storage.DataReference("parent-output-dir")).Once()
 			}
-			branch := New(mockNodeExecutor, eventConfig, promutils.NewTestScope()).(*branchHandler)
+			branch := New(mockNodeExecutor, eventConfig, &eventMocks.NodeEventRecorder{}, "", promutils.NewTestScope()).(*branchHandler)
 			h, err := branch.recurseDownstream(ctx, nCtx, test.nodeStatus, test.branchTakenNode)
 			if test.isErr {
 				assert.Error(t, err)
@@ -284,7 +290,7 @@ func TestBranchHandler_AbortNode(t *testing.T) {
 		eCtx := &execMocks.ExecutionContext{}
 		eCtx.OnGetParentInfo().Return(nil)
 		nCtx, _ := createNodeContext(v1alpha1.BranchNodeError, nil, n, nil, nil, eCtx)
-		branch := New(mockNodeExecutor, eventConfig, promutils.NewTestScope())
+		branch := New(mockNodeExecutor, eventConfig, &eventMocks.NodeEventRecorder{}, "", promutils.NewTestScope())
 		err := branch.Abort(ctx, nCtx, "")
 		assert.NoError(t, err)
 	})
@@ -302,7 +308,7 @@ func TestBranchHandler_AbortNode(t *testing.T) {
 			mock.Anything,
 			mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		nl.OnGetNode(*s.s.FinalizedNodeID).Return(n, true)
-		branch := New(mockNodeExecutor, eventConfig, promutils.NewTestScope())
+		branch := New(mockNodeExecutor, eventConfig, &eventMocks.NodeEventRecorder{}, "", promutils.NewTestScope())
 		err := branch.Abort(ctx, nCtx, "")
 		assert.NoError(t, err)
 	})
@@ -311,7 +317,7 @@ func TestBranchHandler_AbortNode(t *testing.T) {
 func TestBranchHandler_Initialize(t *testing.T) {
 	ctx := context.TODO()
 	mockNodeExecutor := &execMocks.Node{}
-	branch := New(mockNodeExecutor, eventConfig, promutils.NewTestScope())
+	branch := New(mockNodeExecutor, eventConfig, &eventMocks.NodeEventRecorder{}, "", promutils.NewTestScope())
 	assert.NoError(t, branch.Setup(ctx, nil))
 }
 
@@ -319,7 +325,7 @@ func TestBranchHandler_Initialize(t *testing.T) {
 func TestBranchHandler_HandleNode(t *testing.T) {
 	ctx := context.TODO()
 	mockNodeExecutor := &execMocks.Node{}
-	branch := New(mockNodeExecutor, eventConfig, promutils.NewTestScope())
+	branch := New(mockNodeExecutor, eventConfig, &eventMocks.NodeEventRecorder{}, "", promutils.NewTestScope())
 	childNodeID := "child"
 	childDatadir := v1alpha1.DataReference("test")
 	w := &v1alpha1.FlyteWorkflow{
@@ -368,6 +374,86 @@ func TestBranchHandler_HandleNode(t *testing.T) {
 	}
 }
 
+func TestBranchHandler_RecordSkippedNodes(t *testing.T) {
+	ctx := context.TODO()
+	n1 := "n1"
+	n2 := "n2"
+	n3 := "n3"
+	branchNode := &v1alpha1.BranchNodeSpec{
+		If: v1alpha1.IfBlock{
+			ThenNode: &n1,
+		},
+		ElseIf: []*v1alpha1.IfBlock{
+			{
+				ThenNode: &n2,
+			},
+		},
+		Else: &n3,
+	}
+
+	n := &v1alpha1.NodeSpec{
+		ID:         "b1",
+		BranchNode: branchNode,
+	}
+
+	nl := &execMocks.NodeLookup{}
+	nl.OnGetNode(n2).Return(&v1alpha1.NodeSpec{ID: n2, Name: "second"}, true)
+	nl.OnGetNode(n3).Return(&v1alpha1.NodeSpec{ID: n3, Name: "third"}, true)
+
+	newBranch := func(recorded map[string]*event.NodeExecutionEvent) *branchHandler {
+		recorder := &eventMocks.NodeEventRecorder{}
+		recorder.OnRecordNodeEventMatch(mock.Anything, mock.Anything, eventConfig).Run(func(args mock.Arguments) {
+			nev := args.Get(1).(*event.NodeExecutionEvent)
+			recorded[nev.GetSpecNodeId()+nev.GetId().GetNodeId()] = nev
+		}).Return(nil)
+		return New(&execMocks.Node{}, eventConfig, recorder, "cluster", promutils.NewTestScope()).(*branchHandler)
+	}
+
+	t.Run("branch taken", func(t *testing.T) {
+		eCtx := &execMocks.ExecutionContext{}
+		eCtx.OnGetParentInfo().Return(parentInfo{})
+		eCtx.OnGetEventVersion().Return(v1alpha1.EventVersion1)
+		nCtx, _ := createNodeContext(v1alpha1.BranchNodeNotYetEvaluated, nil, n, nil, nl, eCtx)
+
+		recorded := map[string]*event.NodeExecutionEvent{}
+		assert.NoError(t, newBranch(recorded).recordSkippedNodes(ctx, nCtx, branchNode, &n1))
+		assert.Len(t, recorded, 2)
+
+		newParentInfo, err := common.CreateParentInfo(parentInfo{}, nCtx.NodeID(), nCtx.CurrentAttempt())
+		assert.NoError(t, err)
+		uniqueID, err := common.GenerateUniqueID(newParentInfo, n2)
+		assert.NoError(t, err)
+		nev, ok := recorded[n2+uniqueID]
+		if assert.True(t, ok) {
+			assert.Equal(t, core.NodeExecution_SKIPPED, nev.GetPhase())
+			assert.Equal(t, handler.SkipReasonBranchNotTaken, nev.GetError().GetCode())
+			assert.Equal(t, "Node skipped as branch node [n1] took node [n1]", nev.GetError().GetMessage())
+			assert.Equal(t, newParentInfo.GetUniqueID(), nev.GetParentNodeMetadata().GetNodeId())
+			assert.Equal(t, "1", nev.GetRetryGroup())
+			assert.Equal(t, "second", nev.GetNodeName())
+			assert.Equal(t, "cluster", nev.GetProducerId())
+		}
+	})
+
+	t.Run("no branch taken", func(t *testing.T) {
+		eCtx := &execMocks.ExecutionContext{}
+		eCtx.OnGetParentInfo().Return(nil)
+		eCtx.OnGetEventVersion().Return(v1alpha1.EventVersion0)
+		nCtx, _ := createNodeContext(v1alpha1.BranchNodeNotYetEvaluated, nil, n, nil, nl, eCtx)
+
+		recorded := map[string]*event.NodeExecutionEvent{}
+		assert.NoError(t, newBranch(recorded).recordSkippedNodes(ctx, nCtx, branchNode, nil))
+		assert.Len(t, recorded, 3)
+		for _, id := range []string{n1, n2, n3} {
+			nev, ok := recorded[id]
+			if assert.True(t, ok) {
+				assert.Equal(t, core.NodeExecution_SKIPPED, nev.GetPhase())
+				assert.Equal(t, handler.SkipReasonBranchNotTaken, nev.GetError().GetCode())
+			}
+		}
+	})
+}
+
 func init() {
 	labeled.SetMetricKeys(contextutils.ProjectKey, contextutils.DomainKey, contextutils.WorkflowIDKey, contextutils.TaskIDKey)
 }
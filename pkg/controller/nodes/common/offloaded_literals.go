@@ -0,0 +1,120 @@
+package common
+
+import (
+	"context"
+	"sort"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+)
+
+// OffloadedLiteralFormat is the format of the blob that references a literal offloaded to blob storage. The blob holds
+// the serialized literal.
+const OffloadedLiteralFormat = "flyte-offloaded-literal"
+
+// IsOffloadedLiteral returns whether the literal references a literal offloaded to blob storage.
+func IsOffloadedLiteral(l *core.Literal) bool {
+	return l.GetScalar().GetBlob().GetMetadata().GetType().GetFormat() == OffloadedLiteralFormat
+}
+
+func newOffloadedLiteral(ref storage.DataReference) *core.Literal {
+	return &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_Blob{Blob: &core.Blob{
+		Metadata: &core.BlobMetadata{Type: &core.BlobType{
+			Format:         OffloadedLiteralFormat,
+			Dimensionality: core.BlobType_SINGLE,
+		}},
+		Uri: ref.String(),
+	}}}}}
+}
+
+// OffloadLiterals writes the largest literals of the map to blob storage under the given directory and replaces them by
+// references until the serialized map does not exceed maxSize. It returns the names of the offloaded literals.
+func OffloadLiterals(ctx context.Context, store *storage.DataStore, literals *core.LiteralMap, dir storage.DataReference,
+	maxSize int64) ([]string, error) {
+	if int64(proto.Size(literals)) <= maxSize {
+		return nil, nil
+	}
+
+	sizes := make(map[string]int, len(literals.GetLiterals()))
+	names := make([]string, 0, len(literals.GetLiterals()))
+	for name, l := range literals.GetLiterals() {
+		if IsOffloadedLiteral(l) {
+			continue
+		}
+
+		sizes[name] = proto.Size(l)
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return sizes[names[i]] > sizes[names[j]]
+	})
+
+	var offloaded []string
+	for _, name := range names {
+		if int64(proto.Size(literals)) <= maxSize {
+			break
+		}
+
+		ref, err := store.ConstructReference(ctx, dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := store.WriteProtobuf(ctx, ref, storage.Options{}, literals.Literals[name]); err != nil {
+			return nil, err
+		}
+
+		logger.Debugf(ctx, "Offloaded literal [%s] of [%d] bytes to [%s]", name, sizes[name], ref)
+		literals.Literals[name] = newOffloadedLiteral(ref)
+		offloaded = append(offloaded, name)
+	}
+
+	return offloaded, nil
+}
+
+// RehydrateLiteral returns the literal with all literals it references, including the ones nested in collections and
+// maps, read back from blob storage.
+func RehydrateLiteral(ctx context.Context, store storage.ProtobufStore, l *core.Literal) (*core.Literal, error) {
+	switch {
+	case IsOffloadedLiteral(l):
+		rehydrated := &core.Literal{}
+		if err := store.ReadProtobuf(ctx, storage.DataReference(l.GetScalar().GetBlob().GetUri()), rehydrated); err != nil {
+			return nil, err
+		}
+
+		return rehydrated, nil
+	case l.GetCollection() != nil:
+		for i, item := range l.GetCollection().GetLiterals() {
+			rehydrated, err := RehydrateLiteral(ctx, store, item)
+			if err != nil {
+				return nil, err
+			}
+
+			l.GetCollection().Literals[i] = rehydrated
+		}
+	case l.GetMap() != nil:
+		if err := RehydrateLiterals(ctx, store, l.GetMap()); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// RehydrateLiterals replaces the literals of the map that reference literals offloaded to blob storage by the
+// offloaded literals.
+func RehydrateLiterals(ctx context.Context, store storage.ProtobufStore, literals *core.LiteralMap) error {
+	for name, l := range literals.GetLiterals() {
+		rehydrated, err := RehydrateLiteral(ctx, store, l)
+		if err != nil {
+			return err
+		}
+
+		literals.Literals[name] = rehydrated
+	}
+
+	return nil
+}
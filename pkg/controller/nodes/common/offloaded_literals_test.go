@@ -0,0 +1,81 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffloadLiterals(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	large := coreutils.MustMakeLiteral(strings.Repeat("a", 1000))
+	medium := coreutils.MustMakeLiteral(strings.Repeat("b", 500))
+	small := coreutils.MustMakeLiteral(1)
+	newInputs := func() *core.LiteralMap {
+		return &core.LiteralMap{Literals: map[string]*core.Literal{
+			"large":  proto.Clone(large).(*core.Literal),
+			"medium": proto.Clone(medium).(*core.Literal),
+			"small":  proto.Clone(small).(*core.Literal),
+		}}
+	}
+
+	t.Run("below max size", func(t *testing.T) {
+		inputs := newInputs()
+		offloaded, err := OffloadLiterals(ctx, store, inputs, "s3://bucket/below", 10000)
+		assert.NoError(t, err)
+		assert.Empty(t, offloaded)
+		assert.True(t, proto.Equal(newInputs(), inputs))
+	})
+
+	t.Run("largest offloaded", func(t *testing.T) {
+		inputs := newInputs()
+		offloaded, err := OffloadLiterals(ctx, store, inputs, "s3://bucket/largest", 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"large"}, offloaded)
+		assert.True(t, IsOffloadedLiteral(inputs.Literals["large"]))
+		assert.False(t, IsOffloadedLiteral(inputs.Literals["medium"]))
+		assert.Equal(t, "s3://bucket/largest/large", inputs.Literals["large"].GetScalar().GetBlob().GetUri())
+
+		assert.NoError(t, RehydrateLiterals(ctx, store, inputs))
+		assert.True(t, proto.Equal(newInputs(), inputs))
+	})
+
+	t.Run("all offloaded", func(t *testing.T) {
+		inputs := newInputs()
+		offloaded, err := OffloadLiterals(ctx, store, inputs, "s3://bucket/all", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"large", "medium", "small"}, offloaded)
+
+		assert.NoError(t, RehydrateLiterals(ctx, store, inputs))
+		assert.True(t, proto.Equal(newInputs(), inputs))
+	})
+}
+
+func TestRehydrateLiteral(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.WriteProtobuf(ctx, "s3://bucket/nested", storage.Options{}, coreutils.MustMakeLiteral(1)))
+	nested := &core.Literal{Value: &core.Literal_Collection{Collection: &core.LiteralCollection{Literals: []*core.Literal{
+		coreutils.MustMakeLiteral(2),
+		newOffloadedLiteral("s3://bucket/nested"),
+	}}}}
+
+	rehydrated, err := RehydrateLiteral(ctx, store, nested)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rehydrated.GetCollection().GetLiterals()[1].GetScalar().GetPrimitive().GetInteger())
+
+	_, err = RehydrateLiteral(ctx, store, newOffloadedLiteral("s3://bucket/missing"))
+	assert.Error(t, err)
+}
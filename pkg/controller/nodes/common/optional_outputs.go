@@ -0,0 +1,66 @@
+package common
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// IsOptional returns whether variables of the type may be left unset, i.e. whether the type is a union with a none
+// variant, as flytekit declares Optional types.
+func IsOptional(t *core.LiteralType) bool {
+	for _, variant := range t.GetUnionType().GetVariants() {
+		if variant.GetSimple() == core.SimpleType_NONE {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasOptionalVariables returns whether any of the variables is optional.
+func HasOptionalVariables(variables *core.VariableMap) bool {
+	for _, v := range variables.GetVariables() {
+		if IsOptional(v.GetType()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the literal an optional variable of the type is set to if it's missing, i.e. the none variant of the union.
+func noneLiteral(t *core.LiteralType) *core.Literal {
+	none := &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_NoneType{NoneType: &core.Void{}}}}}
+	for _, variant := range t.GetUnionType().GetVariants() {
+		if variant.GetSimple() == core.SimpleType_NONE {
+			return &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_Union{
+				Union: &core.Union{Value: none, Type: variant},
+			}}}}
+		}
+	}
+
+	return none
+}
+
+// FillOptionalOutputs sets the optional variables missing in the outputs to none, so that bindings downstream that refer
+// to them resolve. It returns whether any variable was set and the names of the required variables that are missing.
+func FillOptionalOutputs(outputs *core.LiteralMap, variables *core.VariableMap) (filled bool, missingRequired []string) {
+	for name, v := range variables.GetVariables() {
+		if _, ok := outputs.GetLiterals()[name]; ok {
+			continue
+		}
+
+		if !IsOptional(v.GetType()) {
+			missingRequired = append(missingRequired, name)
+			continue
+		}
+
+		if outputs.Literals == nil {
+			outputs.Literals = make(map[string]*core.Literal, len(variables.GetVariables()))
+		}
+
+		outputs.Literals[name] = noneLiteral(v.GetType())
+		filled = true
+	}
+
+	return filled, missingRequired
+}
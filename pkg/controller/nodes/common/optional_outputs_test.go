@@ -0,0 +1,63 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillOptionalOutputs(t *testing.T) {
+	intType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}
+	noneType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_NONE}}
+	optionalType := &core.LiteralType{Type: &core.LiteralType_UnionType{UnionType: &core.UnionType{
+		Variants: []*core.LiteralType{intType, noneType},
+	}}}
+	variables := &core.VariableMap{Variables: map[string]*core.Variable{
+		"required": {Type: intType},
+		"optional": {Type: optionalType},
+	}}
+
+	assert.True(t, IsOptional(optionalType))
+	assert.False(t, IsOptional(intType))
+	assert.True(t, HasOptionalVariables(variables))
+	assert.False(t, HasOptionalVariables(&core.VariableMap{Variables: map[string]*core.Variable{"required": {Type: intType}}}))
+
+	t.Run("all set", func(t *testing.T) {
+		outputs := &core.LiteralMap{Literals: map[string]*core.Literal{
+			"required": coreutils.MustMakeLiteral(1),
+			"optional": coreutils.MustMakeLiteral(2),
+		}}
+		filled, missingRequired := FillOptionalOutputs(outputs, variables)
+		assert.False(t, filled)
+		assert.Empty(t, missingRequired)
+		assert.Equal(t, int64(2), outputs.Literals["optional"].GetScalar().GetPrimitive().GetInteger())
+	})
+
+	t.Run("optional missing", func(t *testing.T) {
+		outputs := &core.LiteralMap{Literals: map[string]*core.Literal{"required": coreutils.MustMakeLiteral(1)}}
+		filled, missingRequired := FillOptionalOutputs(outputs, variables)
+		assert.True(t, filled)
+		assert.Empty(t, missingRequired)
+		union := outputs.Literals["optional"].GetScalar().GetUnion()
+		if assert.NotNil(t, union) {
+			assert.NotNil(t, union.GetValue().GetScalar().GetNoneType())
+			assert.Equal(t, noneType, union.GetType())
+		}
+	})
+
+	t.Run("nothing written", func(t *testing.T) {
+		outputs := &core.LiteralMap{}
+		filled, missingRequired := FillOptionalOutputs(outputs, variables)
+		assert.True(t, filled)
+		assert.Equal(t, []string{"required"}, missingRequired)
+		assert.Len(t, outputs.Literals, 1)
+	})
+
+	t.Run("no variables", func(t *testing.T) {
+		filled, missingRequired := FillOptionalOutputs(&core.LiteralMap{}, nil)
+		assert.False(t, filled)
+		assert.Empty(t, missingRequired)
+	})
+}
@@ -3,7 +3,9 @@ package common
 import (
 	"strconv"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/encoding"
+	"github.com/golang/protobuf/proto"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
@@ -11,6 +13,9 @@ import (
 
 const maxUniqueIDLength = 20
 
+// NodeExecutionEventVersion is the version of the node execution events sent by propeller.
+const NodeExecutionEventVersion = int32(1)
+
 // The UniqueId of a node is unique within a given workflow execution.
 // In order to achieve that we track the lineage of the node.
 // To compute the uniqueID of a node, we use the uniqueID and retry attempt of the parent node
@@ -36,3 +41,77 @@ func CreateParentInfo(grandParentInfo executors.ImmutableParentInfo, nodeID stri
 	return executors.NewParentInfo(uniqueID, parentAttempt), nil
 
 }
+
+// Merges the maps into a new one, the values of later maps override the ones of earlier maps.
+func mergeMaps(maps ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// PropagatedLabels returns the labels set on the pods and child executions created by the node. These are the labels
+// of the workflow, overridden by the ones of the execution config and then by the ones set on the node.
+func PropagatedLabels(execContext executors.ImmutableExecutionContext, node v1alpha1.ExecutableNode) map[string]string {
+	var nodeLabels map[string]string
+	if n, ok := node.(v1alpha1.NodeMetadataOverrides); ok {
+		nodeLabels = n.GetLabels()
+	}
+
+	return mergeMaps(execContext.GetLabels(), execContext.GetExecutionConfig().Labels, nodeLabels)
+}
+
+// PropagatedAnnotations returns the annotations set on the pods and child executions created by the node, merged the
+// same way as the labels.
+func PropagatedAnnotations(execContext executors.ImmutableExecutionContext, node v1alpha1.ExecutableNode) map[string]string {
+	var nodeAnnotations map[string]string
+	if n, ok := node.(v1alpha1.NodeMetadataOverrides); ok {
+		nodeAnnotations = n.GetAnnotations()
+	}
+
+	return mergeMaps(execContext.GetAnnotations(), execContext.GetExecutionConfig().Annotations, nodeAnnotations)
+}
+
+// Applies the fields the identity sets to the identity of a security context.
+func applyIdentity(runAs *core.Identity, identity v1alpha1.Identity) {
+	if len(identity.K8sServiceAccount) > 0 {
+		runAs.K8SServiceAccount = identity.K8sServiceAccount
+	}
+
+	if len(identity.IamRole) > 0 {
+		runAs.IamRole = identity.IamRole
+	}
+}
+
+// PropagatedSecurityContext returns the security context the pods and child executions created by the node run with.
+// Its identity is the one of the execution, overridden by the identity of the execution config and then by the one set
+// on the node.
+func PropagatedSecurityContext(execContext executors.ImmutableExecutionContext, node v1alpha1.ExecutableNode) core.SecurityContext {
+	securityContext := execContext.GetSecurityContext()
+	override := execContext.GetExecutionConfig().Identity
+	var nodeOverride *v1alpha1.Identity
+	if n, ok := node.(v1alpha1.NodeIdentityOverride); ok {
+		nodeOverride = n.GetIdentity()
+	}
+
+	if override.IsEmpty() && (nodeOverride == nil || nodeOverride.IsEmpty()) {
+		return securityContext
+	}
+
+	runAs := &core.Identity{}
+	if securityContext.RunAs != nil {
+		runAs = proto.Clone(securityContext.RunAs).(*core.Identity)
+	}
+
+	applyIdentity(runAs, override)
+	if nodeOverride != nil {
+		applyIdentity(runAs, *nodeOverride)
+	}
+
+	securityContext.RunAs = runAs
+	return securityContext
+}
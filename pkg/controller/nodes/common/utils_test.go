@@ -3,7 +3,11 @@ package common
 import (
 	"testing"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	nodeMocks "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,3 +61,63 @@ func TestCreateParentInfoNil(t *testing.T) {
 	assert.Equal(t, "n1", parent.GetUniqueID())
 	assert.Equal(t, uint32(1), parent.CurrentAttempt())
 }
+
+func TestPropagatedLabelsAndAnnotations(t *testing.T) {
+	execContext := &mocks.ExecutionContext{}
+	execContext.OnGetLabels().Return(map[string]string{"team": "wf", "workflow": "w"})
+	execContext.OnGetAnnotations().Return(map[string]string{"owner": "wf"})
+	execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{
+		Labels:      map[string]string{"team": "exec", "cost-center": "exec"},
+		Annotations: map[string]string{"owner": "exec", "policy": "exec"},
+	})
+
+	t.Run("node overrides", func(t *testing.T) {
+		node := &v1alpha1.NodeSpec{
+			Labels:      map[string]string{"cost-center": "node"},
+			Annotations: map[string]string{"policy": "node"},
+		}
+
+		assert.Equal(t, map[string]string{"team": "exec", "workflow": "w", "cost-center": "node"},
+			PropagatedLabels(execContext, node))
+		assert.Equal(t, map[string]string{"owner": "exec", "policy": "node"}, PropagatedAnnotations(execContext, node))
+	})
+
+	t.Run("no node overrides", func(t *testing.T) {
+		node := &nodeMocks.ExecutableNode{}
+		assert.Equal(t, map[string]string{"team": "exec", "workflow": "w", "cost-center": "exec"},
+			PropagatedLabels(execContext, node))
+		assert.Equal(t, map[string]string{"owner": "exec", "policy": "exec"}, PropagatedAnnotations(execContext, node))
+	})
+}
+
+func TestPropagatedSecurityContext(t *testing.T) {
+	securityContext := core.SecurityContext{
+		RunAs: &core.Identity{K8SServiceAccount: "wf-sa", IamRole: "wf-role"},
+	}
+
+	t.Run("no overrides", func(t *testing.T) {
+		execContext := &mocks.ExecutionContext{}
+		execContext.OnGetSecurityContext().Return(securityContext)
+		execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{})
+
+		actual := PropagatedSecurityContext(execContext, &nodeMocks.ExecutableNode{})
+		assert.Equal(t, "wf-sa", actual.GetRunAs().GetK8SServiceAccount())
+		assert.Equal(t, "wf-role", actual.GetRunAs().GetIamRole())
+	})
+
+	t.Run("node overrides", func(t *testing.T) {
+		execContext := &mocks.ExecutionContext{}
+		execContext.OnGetSecurityContext().Return(securityContext)
+		execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{
+			Identity: v1alpha1.Identity{K8sServiceAccount: "exec-sa", IamRole: "exec-role"},
+		})
+
+		node := &v1alpha1.NodeSpec{Identity: &v1alpha1.Identity{IamRole: "node-role"}}
+		actual := PropagatedSecurityContext(execContext, node)
+		assert.Equal(t, "exec-sa", actual.GetRunAs().GetK8SServiceAccount())
+		assert.Equal(t, "node-role", actual.GetRunAs().GetIamRole())
+
+		// The identity of the execution is left untouched
+		assert.Equal(t, "wf-role", securityContext.GetRunAs().GetIamRole())
+	})
+}
@@ -0,0 +1,151 @@
+package dynamic
+
+import (
+	"path"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+)
+
+// An output binding of the futures file declares the aggregation of the outputs of its nodes with a promise whose node
+// ID is of the form "<operator>:<pattern>", e.g. {NodeId: "collect:n*", Var: "o0"}. The pattern is matched against the
+// IDs of the nodes in the futures file, using the syntax of path.Match.
+const (
+	// Collects the output of the matched nodes into a list, in the order of the nodes in the futures file.
+	aggregateCollect = "collect"
+	// Merges the map outputs of the matched nodes into a single map. Keys of later nodes override the ones of earlier
+	// nodes, in the order of the nodes in the futures file.
+	aggregateMerge = "merge"
+)
+
+// Returns the operator and pattern of the aggregation declared by the node ID of a promise, if any.
+func parseAggregation(nodeID string) (operator, pattern string, ok bool) {
+	parts := strings.SplitN(nodeID, ":", 2)
+	if len(parts) != 2 || (parts[0] != aggregateCollect && parts[0] != aggregateMerge) {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// Replaces the aggregations declared by the output bindings of the futures file with the collections of the promises of
+// the matched nodes, so that the end-node of the dynamic workflow gathers the outputs without an extra aggregation
+// task. It returns the names of the outputs to merge once the end-node has produced them.
+func expandOutputAggregations(djSpec *core.DynamicJobSpec) (sets.String, error) {
+	merged := sets.NewString()
+	for _, o := range djSpec.Outputs {
+		promise := o.GetBinding().GetPromise()
+		if promise == nil {
+			continue
+		}
+
+		operator, pattern, ok := parseAggregation(promise.NodeId)
+		if !ok {
+			continue
+		}
+
+		bindings := make([]*core.BindingData, 0, len(djSpec.Nodes))
+		for _, node := range djSpec.Nodes {
+			matched, err := path.Match(pattern, node.Id)
+			if err != nil {
+				return nil, errors.Wrapf(utils.ErrorCodeUser, err, "malformed pattern [%s] of the aggregation of output [%s]", pattern, o.Var)
+			}
+
+			if matched {
+				bindings = append(bindings, &core.BindingData{
+					Value: &core.BindingData_Promise{
+						Promise: &core.OutputReference{NodeId: node.Id, Var: promise.Var},
+					},
+				})
+			}
+		}
+
+		o.Binding = &core.BindingData{
+			Value: &core.BindingData_Collection{
+				Collection: &core.BindingDataCollection{Bindings: bindings},
+			},
+		}
+
+		if operator == aggregateMerge {
+			merged.Insert(o.Var)
+		}
+	}
+
+	return merged, nil
+}
+
+// Returns a copy of the interface of the dynamic workflow where the outputs to merge are lists of the maps produced by
+// the matched nodes.
+func mergeInterface(iface *core.TypedInterface, merged sets.String) (*core.TypedInterface, error) {
+	if merged.Len() == 0 {
+		return iface, nil
+	}
+
+	outputs := &core.VariableMap{Variables: make(map[string]*core.Variable, len(iface.GetOutputs().GetVariables()))}
+	for name, v := range iface.GetOutputs().GetVariables() {
+		outputs.Variables[name] = v
+	}
+
+	for _, name := range merged.List() {
+		v, ok := outputs.Variables[name]
+		if !ok || v.GetType().GetMapValueType() == nil {
+			return nil, errors.Errorf(utils.ErrorCodeUser, "output [%s] merges maps but is not declared as a map", name)
+		}
+
+		outputs.Variables[name] = &core.Variable{
+			Type:        &core.LiteralType{Type: &core.LiteralType_CollectionType{CollectionType: v.GetType()}},
+			Description: v.GetDescription(),
+		}
+	}
+
+	return &core.TypedInterface{Inputs: iface.GetInputs(), Outputs: outputs}, nil
+}
+
+// Returns the names of the outputs of the dynamic workflow that are lists of maps where the task declares maps. These
+// are the outputs whose maps are merged once the end-node has produced them.
+func outputsToMerge(workflowOutputs, taskOutputs *core.VariableMap) []string {
+	var merged []string
+	for name, v := range workflowOutputs.GetVariables() {
+		mapType := v.GetType().GetCollectionType()
+		if mapType.GetMapValueType() == nil {
+			continue
+		}
+
+		if t, ok := taskOutputs.GetVariables()[name]; ok && t.GetType().GetMapValueType() != nil {
+			merged = append(merged, name)
+		}
+	}
+
+	return merged
+}
+
+// Merges the maps of the collection of the given outputs into a single map.
+func mergeOutputMaps(outputs *core.LiteralMap, names []string) error {
+	for _, name := range names {
+		l, ok := outputs.GetLiterals()[name]
+		if !ok {
+			continue
+		}
+
+		literals := make(map[string]*core.Literal)
+		for _, item := range l.GetCollection().GetLiterals() {
+			if item.GetMap() == nil {
+				return errors.Errorf(utils.ErrorCodeUser, "output [%s] merges maps but a node produced a [%T]", name, item.GetValue())
+			}
+
+			for k, v := range item.GetMap().GetLiterals() {
+				literals[k] = v
+			}
+		}
+
+		outputs.Literals[name] = &core.Literal{
+			Value: &core.Literal_Map{Map: &core.LiteralMap{Literals: literals}},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+)
+
+func promiseBinding(v, nodeID string) *core.Binding {
+	return &core.Binding{
+		Var: v,
+		Binding: &core.BindingData{
+			Value: &core.BindingData_Promise{Promise: &core.OutputReference{NodeId: nodeID, Var: "o0"}},
+		},
+	}
+}
+
+func TestExpandOutputAggregations(t *testing.T) {
+	djSpec := &core.DynamicJobSpec{
+		Nodes: []*core.Node{{Id: "n1"}, {Id: "n0"}, {Id: "other"}},
+		Outputs: []*core.Binding{
+			promiseBinding("list", "collect:n*"),
+			promiseBinding("map", "merge:*"),
+			promiseBinding("plain", "n0"),
+		},
+	}
+
+	merged, err := expandOutputAggregations(djSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"map"}, merged.List())
+
+	collected := djSpec.Outputs[0].GetBinding().GetCollection().GetBindings()
+	if assert.Len(t, collected, 2) {
+		assert.Equal(t, "n1", collected[0].GetPromise().NodeId)
+		assert.Equal(t, "n0", collected[1].GetPromise().NodeId)
+		assert.Equal(t, "o0", collected[1].GetPromise().Var)
+	}
+
+	assert.Len(t, djSpec.Outputs[1].GetBinding().GetCollection().GetBindings(), 3)
+	assert.Equal(t, "n0", djSpec.Outputs[2].GetBinding().GetPromise().NodeId)
+
+	_, err = expandOutputAggregations(&core.DynamicJobSpec{
+		Nodes:   []*core.Node{{Id: "n0"}},
+		Outputs: []*core.Binding{promiseBinding("list", "collect:[")},
+	})
+	assert.Error(t, err)
+}
+
+func TestMergeInterface(t *testing.T) {
+	mapType := &core.LiteralType{Type: &core.LiteralType_MapValueType{MapValueType: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}}}
+	intType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}
+	iface := &core.TypedInterface{
+		Outputs: &core.VariableMap{Variables: map[string]*core.Variable{
+			"map": {Type: mapType},
+			"int": {Type: intType},
+		}},
+	}
+
+	merged, err := mergeInterface(iface, sets.NewString("map"))
+	assert.NoError(t, err)
+	assert.Equal(t, mapType, merged.Outputs.Variables["map"].Type.GetCollectionType())
+	assert.Equal(t, intType, merged.Outputs.Variables["int"].Type)
+	// The interface of the task is not modified
+	assert.Equal(t, mapType, iface.Outputs.Variables["map"].Type)
+
+	assert.Equal(t, []string{"map"}, outputsToMerge(merged.Outputs, iface.Outputs))
+	assert.Empty(t, outputsToMerge(iface.Outputs, iface.Outputs))
+
+	_, err = mergeInterface(iface, sets.NewString("int"))
+	assert.True(t, errors.IsCausedBy(err, utils.ErrorCodeUser))
+}
+
+func TestMergeOutputMaps(t *testing.T) {
+	intLiteral := func(i int64) *core.Literal {
+		return &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{
+			Value: &core.Scalar_Primitive{Primitive: &core.Primitive{Value: &core.Primitive_Integer{Integer: i}}},
+		}}}
+	}
+
+	mapLiteral := func(literals map[string]*core.Literal) *core.Literal {
+		return &core.Literal{Value: &core.Literal_Map{Map: &core.LiteralMap{Literals: literals}}}
+	}
+
+	outputs := &core.LiteralMap{Literals: map[string]*core.Literal{
+		"map": {Value: &core.Literal_Collection{Collection: &core.LiteralCollection{Literals: []*core.Literal{
+			mapLiteral(map[string]*core.Literal{"a": intLiteral(1), "b": intLiteral(2)}),
+			mapLiteral(map[string]*core.Literal{"b": intLiteral(3)}),
+		}}}},
+	}}
+
+	assert.NoError(t, mergeOutputMaps(outputs, []string{"map"}))
+	assert.Equal(t, mapLiteral(map[string]*core.Literal{"a": intLiteral(1), "b": intLiteral(3)}), outputs.Literals["map"])
+
+	outputs = &core.LiteralMap{Literals: map[string]*core.Literal{
+		"map": {Value: &core.Literal_Collection{Collection: &core.LiteralCollection{Literals: []*core.Literal{intLiteral(1)}}}},
+	}}
+	assert.Error(t, mergeOutputMaps(outputs, []string{"map"}))
+}
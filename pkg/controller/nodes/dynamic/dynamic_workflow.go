@@ -30,6 +30,8 @@ type dynamicWorkflowContext struct {
 	subWorkflowClosure *core.CompiledWorkflowClosure
 	nodeLookup         executors.NodeLookup
 	isDynamic          bool
+	// checksum of the compiled dynamic workflow offloaded to blob storage
+	checksum string
 }
 
 const dynamicWfNameTemplate = "dynamic_%s"
@@ -160,9 +162,14 @@ func (d dynamicNodeTaskNodeHandler) buildContextualDynamicWorkflow(ctx context.C
 	} else if ok {
 		// It exists, load and return it
 		workflowCacheContents, err := f.RetrieveCache(ctx)
+		expectedChecksum := nCtx.NodeStateReader().GetDynamicNodeState().WorkflowChecksum
 		if err != nil {
 			logger.Warnf(ctx, "Failed to load cached flyte workflow, this will cause the dynamic workflow to be recompiled. Error: %v", err)
 			d.metrics.CacheError.Inc(ctx)
+		} else if len(expectedChecksum) > 0 && workflowCacheContents.Checksum != expectedChecksum {
+			logger.Warnf(ctx, "Checksum [%s] of cached flyte workflow does not match the expected checksum [%s], this will cause the dynamic workflow to be recompiled.",
+				workflowCacheContents.Checksum, expectedChecksum)
+			d.metrics.CacheError.Inc(ctx)
 		} else {
 			// We know for sure that futures file was generated. Lets read it
 			djSpec, err := f.Read(ctx)
@@ -181,15 +188,21 @@ func (d dynamicNodeTaskNodeHandler) buildContextualDynamicWorkflow(ctx context.C
 			}
 
 			compiledWf := workflowCacheContents.WorkflowCRD
+			// The cached workflow was not necessarily compiled by this version of propeller.
+			if err := validateNoCycles(compiledWf); err != nil {
+				return dynamicWorkflowContext{}, err
+			}
 
 			cacheHitStopWatch.Stop()
 
+			flow := executors.NewControlFlowWithParallelismBudget(nCtx.ExecutionContext(), d.parallelismWeight)
 			return dynamicWorkflowContext{
 				isDynamic:          true,
 				subWorkflow:        compiledWf,
 				subWorkflowClosure: workflowCacheContents.CompiledWorkflow,
-				execContext:        executors.NewExecutionContext(nCtx.ExecutionContext(), compiledWf, compiledWf, newParentInfo, nCtx.ExecutionContext()),
+				execContext:        executors.NewExecutionContext(nCtx.ExecutionContext(), compiledWf, compiledWf, newParentInfo, flow),
 				nodeLookup:         executors.NewNodeLookup(compiledWf, dynamicNodeStatus),
+				checksum:           workflowCacheContents.Checksum,
 			}, nil
 		}
 	}
@@ -206,8 +219,10 @@ func (d dynamicNodeTaskNodeHandler) buildContextualDynamicWorkflow(ctx context.C
 		return workflowContext, err
 	}
 
-	if err := f.Cache(ctx, dynamicWf, closure); err != nil {
+	checksum, err := f.Cache(ctx, dynamicWf, closure)
+	if err != nil {
 		logger.Errorf(ctx, "Failed to cache Dynamic workflow [%s]", err.Error())
+		checksum = ""
 	}
 
 	// The current node would end up becoming the parent for the dynamic task nodes.
@@ -216,22 +231,36 @@ func (d dynamicNodeTaskNodeHandler) buildContextualDynamicWorkflow(ctx context.C
 	if err != nil {
 		return dynamicWorkflowContext{}, errors.Wrapf(utils.ErrorCodeSystem, err, "failed to generate uniqueID")
 	}
+
+	flow := executors.NewControlFlowWithParallelismBudget(nCtx.ExecutionContext(), d.parallelismWeight)
 	return dynamicWorkflowContext{
 		isDynamic:          true,
 		subWorkflow:        dynamicWf,
 		subWorkflowClosure: closure,
-		execContext:        executors.NewExecutionContext(nCtx.ExecutionContext(), dynamicWf, dynamicWf, newParentInfo, nCtx.ExecutionContext()),
+		execContext:        executors.NewExecutionContext(nCtx.ExecutionContext(), dynamicWf, dynamicWf, newParentInfo, flow),
 		nodeLookup:         executors.NewNodeLookup(dynamicWf, dynamicNodeStatus),
+		checksum:           checksum,
 	}, nil
 }
 
 func (d dynamicNodeTaskNodeHandler) buildDynamicWorkflow(ctx context.Context, nCtx handler.NodeExecutionContext,
 	djSpec *core.DynamicJobSpec, dynamicNodeStatus v1alpha1.ExecutableNodeStatus) (*core.CompiledWorkflowClosure, *v1alpha1.FlyteWorkflow, dynamicWorkflowContext, error) {
+	// The aggregations match the IDs of the nodes in the futures file, before they are modified to include lineage.
+	merged, err := expandOutputAggregations(djSpec)
+	if err != nil {
+		return nil, nil, dynamicWorkflowContext{}, err
+	}
+
 	wf, err := d.buildDynamicWorkflowTemplate(ctx, djSpec, nCtx, dynamicNodeStatus)
 	if err != nil {
 		return nil, nil, dynamicWorkflowContext{}, errors.Wrapf(utils.ErrorCodeSystem, err, "failed to build dynamic workflow template")
 	}
 
+	wf.Interface, err = mergeInterface(wf.Interface, merged)
+	if err != nil {
+		return nil, nil, dynamicWorkflowContext{}, err
+	}
+
 	compiledTasks, err := compileTasks(ctx, djSpec.Tasks)
 	if err != nil {
 		return nil, nil, dynamicWorkflowContext{}, errors.Wrapf(utils.ErrorCodeUser, err, "failed to compile dynamic tasks")
@@ -265,6 +294,10 @@ func (d dynamicNodeTaskNodeHandler) buildDynamicWorkflow(ctx context.Context, nC
 		return nil, nil, dynamicWorkflowContext{}, errors.Wrapf(utils.ErrorCodeSystem, err, "failed to build workflow")
 	}
 
+	if err := validateNoCycles(dynamicWf); err != nil {
+		return nil, nil, dynamicWorkflowContext{}, err
+	}
+
 	return closure, dynamicWf, dynamicWorkflowContext{}, nil
 }
 
@@ -302,24 +335,59 @@ func (d dynamicNodeTaskNodeHandler) progressDynamicWorkflow(ctx context.Context,
 			}
 
 			sourcePath := v1alpha1.GetOutputsFile(endNodeStatus.GetOutputDir())
-			if metadata, err := nCtx.DataStore().Head(ctx, sourcePath); err == nil {
-				if !metadata.Exists() {
+			metadata, err := nCtx.DataStore().Head(ctx, sourcePath)
+			if err != nil {
+				return handler.UnknownTransition, prevState, err
+			}
+
+			destinationPath := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
+			merged, err := d.outputsToMerge(ctx, nCtx, dynamicWorkflow)
+			if err != nil {
+				return handler.UnknownTransition, prevState, err
+			}
+
+			if metadata.Exists() && len(merged) > 0 {
+				outputs := &core.LiteralMap{}
+				if err := nCtx.DataStore().ReadProtobuf(ctx, sourcePath, outputs); err != nil {
+					return handler.UnknownTransition, prevState, err
+				}
+
+				if err := mergeOutputMaps(outputs, merged); err != nil {
+					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER, "MalformedDynamicWorkflowOutputs", err.Error(), nil)),
+						handler.DynamicNodeState{Phase: v1alpha1.DynamicNodePhaseFailing, Reason: "Failed to merge dynamic workflow outputs"},
+						nil
+				}
+
+				if err := nCtx.DataStore().WriteProtobuf(ctx, destinationPath, storage.Options{}, outputs); err != nil {
+					return handler.UnknownTransition, prevState, err
+				}
+			} else if metadata.Exists() {
+				if err := nCtx.DataStore().CopyRaw(ctx, sourcePath, destinationPath, storage.Options{}); err != nil {
+					return handler.DoTransition(handler.TransitionTypeEphemeral,
+							handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, "OutputsNotFound",
+								fmt.Sprintf("Failed to copy subworkflow outputs from [%v] to [%v]. Error: %s", sourcePath, destinationPath, err.Error()), nil),
+						), handler.DynamicNodeState{Phase: v1alpha1.DynamicNodePhaseFailing, Reason: "Failed to copy subworkflow outputs"},
+						nil
+				}
+			} else {
+				// Dynamic workflows whose outputs are all optional may not produce any.
+				var variables *core.VariableMap
+				if outputVars := dynamicWorkflow.GetOutputs(); outputVars != nil {
+					variables = outputVars.VariableMap
+				}
+
+				outputs := &core.LiteralMap{}
+				if _, missingRequired := node_common.FillOptionalOutputs(outputs, variables); len(missingRequired) > 0 {
 					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRetryableFailure(core.ExecutionError_SYSTEM, "DynamicWorkflowOutputsNotFound", fmt.Sprintf(" is expected to produce outputs but no outputs file was written to %v.", sourcePath), nil)),
 						handler.DynamicNodeState{Phase: v1alpha1.DynamicNodePhaseFailing, Reason: "DynamicWorkflow is expected to produce outputs but no outputs file was written"},
 						nil
 				}
-			} else {
-				return handler.UnknownTransition, prevState, err
-			}
 
-			destinationPath := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
-			if err := nCtx.DataStore().CopyRaw(ctx, sourcePath, destinationPath, storage.Options{}); err != nil {
-				return handler.DoTransition(handler.TransitionTypeEphemeral,
-						handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, "OutputsNotFound",
-							fmt.Sprintf("Failed to copy subworkflow outputs from [%v] to [%v]. Error: %s", sourcePath, destinationPath, err.Error()), nil),
-					), handler.DynamicNodeState{Phase: v1alpha1.DynamicNodePhaseFailing, Reason: "Failed to copy subworkflow outputs"},
-					nil
+				if err := nCtx.DataStore().WriteProtobuf(ctx, destinationPath, storage.Options{}, outputs); err != nil {
+					return handler.UnknownTransition, prevState, err
+				}
 			}
+
 			o = &handler.OutputInfo{OutputURI: destinationPath}
 		}
 
@@ -337,6 +405,22 @@ func (d dynamicNodeTaskNodeHandler) progressDynamicWorkflow(ctx context.Context,
 	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoDynamicRunning(nil)), prevState, nil
 }
 
+// Returns the names of the outputs of the dynamic workflow whose maps are merged before they are written as the outputs
+// of the node.
+func (d dynamicNodeTaskNodeHandler) outputsToMerge(ctx context.Context, nCtx handler.NodeExecutionContext, dynamicWorkflow v1alpha1.ExecutableWorkflow) ([]string, error) {
+	outputVars := dynamicWorkflow.GetOutputs()
+	if outputVars == nil || nCtx.TaskReader().GetTaskID() == nil {
+		return nil, nil
+	}
+
+	iface, err := underlyingInterface(ctx, nCtx.TaskReader())
+	if err != nil {
+		return nil, err
+	}
+
+	return outputsToMerge(outputVars.VariableMap, iface.Outputs), nil
+}
+
 func (d dynamicNodeTaskNodeHandler) getLaunchPlanInterfaces(ctx context.Context, launchPlanIDs []compiler.LaunchPlanRefIdentifier) (
 	[]common.InterfaceProvider, error) {
 
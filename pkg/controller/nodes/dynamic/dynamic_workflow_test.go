@@ -3,6 +3,8 @@ package dynamic
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"testing"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/storage"
 	storageMocks "github.com/flyteorg/flytestdlib/storage/mocks"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -372,7 +375,7 @@ func Test_dynamicNodeHandler_buildContextualDynamicWorkflow_withLaunchPlans(t *t
 
 		f, err = nCtx.DataStore().ConstructReference(ctx, nCtx.NodeStatus().GetOutputDir(), "dynamic_compiled.pb")
 		assert.NoError(t, err)
-		assert.NoError(t, nCtx.DataStore().WriteProtobuf(context.TODO(), f, storage.Options{}, &core.CompiledWorkflowClosure{
+		rawClosure, err := proto.Marshal(&core.CompiledWorkflowClosure{
 			Primary: &core.CompiledWorkflow{
 				Template: &core.WorkflowTemplate{
 					Id: &core.Identifier{
@@ -380,7 +383,9 @@ func Test_dynamicNodeHandler_buildContextualDynamicWorkflow_withLaunchPlans(t *t
 					},
 				},
 			},
-		}))
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, nCtx.DataStore().WriteRaw(context.TODO(), f, int64(len(rawClosure)), storage.Options{}, bytes.NewReader(rawClosure)))
 
 		mockLPLauncher := &mocks5.Reader{}
 		var callsAdmin = false
@@ -435,6 +440,90 @@ func Test_dynamicNodeHandler_buildContextualDynamicWorkflow_withLaunchPlans(t *t
 		assert.Equal(t, expectedParentUniqueID, dCtx.execContext.GetParentInfo().GetUniqueID())
 		assert.Equal(t, uint32(1), dCtx.execContext.GetParentInfo().CurrentAttempt())
 		assert.NotNil(t, dCtx.nodeLookup)
+		// The checksum covers both the workflow CRD and the compiled workflow closure.
+		crdChecksum := sha256.Sum256(rawDynamicWf)
+		closureChecksum := sha256.Sum256(rawClosure)
+		checksum := sha256.Sum256([]byte(hex.EncodeToString(crdChecksum[:]) + hex.EncodeToString(closureChecksum[:])))
+		assert.Equal(t, hex.EncodeToString(checksum[:]), dCtx.checksum)
+	})
+
+	t.Run("dynamic wf cache checksum mismatch", func(t *testing.T) {
+		ctx := context.Background()
+		lpID := &core.Identifier{
+			ResourceType: core.ResourceType_LAUNCH_PLAN,
+			Name:         "my_plan",
+			Project:      "p",
+			Domain:       "d",
+		}
+		djSpec := createDynamicJobSpecWithLaunchPlans()
+		finalOutput := storage.DataReference("/subnode")
+		nCtx := createNodeContext("test", finalOutput, nil)
+
+		// Replace the node state with one that references a different offloaded workflow
+		var expectedCalls []*mock.Call
+		for _, c := range nCtx.ExpectedCalls {
+			if c.Method != "NodeStateReader" {
+				expectedCalls = append(expectedCalls, c)
+			}
+		}
+		nCtx.ExpectedCalls = expectedCalls
+		r := &mocks.NodeStateReader{}
+		r.OnGetDynamicNodeState().Return(handler.DynamicNodeState{
+			Phase:            v1alpha1.DynamicNodePhaseExecuting,
+			WorkflowChecksum: "stale",
+		})
+		nCtx.OnNodeStateReader().Return(r)
+
+		rawDynamicWf, err := json.Marshal(&v1alpha1.FlyteWorkflow{ServiceAccountName: "sa"})
+		assert.NoError(t, err)
+		assert.NoError(t, nCtx.DataStore().WriteRaw(context.TODO(), "/output-dir/futures_compiled.pb", int64(len(rawDynamicWf)), storage.Options{}, bytes.NewReader(rawDynamicWf)))
+		assert.NoError(t, nCtx.DataStore().WriteProtobuf(context.TODO(), "/output-dir/dynamic_compiled.pb", storage.Options{}, &core.CompiledWorkflowClosure{}))
+		assert.NoError(t, nCtx.DataStore().WriteProtobuf(context.TODO(), "/output-dir/futures.pb", storage.Options{}, djSpec))
+
+		mockLPLauncher := &mocks5.Reader{}
+		var callsAdmin = false
+		mockLPLauncher.OnGetLaunchPlanMatch(ctx, lpID).Run(func(args mock.Arguments) {
+			// The offloaded workflow can't be trusted, so the dynamic workflow is compiled again.
+			callsAdmin = true
+		}).Return(&admin.LaunchPlan{
+			Id: lpID,
+			Closure: &admin.LaunchPlanClosure{
+				ExpectedInputs: &core.ParameterMap{},
+				ExpectedOutputs: &core.VariableMap{
+					Variables: map[string]*core.Variable{
+						"x": {
+							Type: &core.LiteralType{
+								Type: &core.LiteralType_Simple{
+									Simple: core.SimpleType_INTEGER,
+								},
+							},
+							Description: "output of the launch plan",
+						},
+					},
+				},
+			},
+		}, nil)
+		d := dynamicNodeTaskNodeHandler{
+			TaskNodeHandler: &mocks6.TaskNodeHandler{},
+			nodeExecutor:    &mocks4.Node{},
+			lpReader:        mockLPLauncher,
+			metrics:         newMetrics(promutils.NewTestScope()),
+		}
+
+		execContext := &mocks4.ExecutionContext{}
+		immutableParentInfo := mocks4.ImmutableParentInfo{}
+		immutableParentInfo.OnGetUniqueID().Return("c1")
+		immutableParentInfo.OnCurrentAttempt().Return(uint32(2))
+		execContext.OnGetParentInfo().Return(&immutableParentInfo)
+		execContext.OnGetEventVersion().Return(v1alpha1.EventVersion1)
+		nCtx.OnExecutionContext().Return(execContext)
+
+		dCtx, err := d.buildContextualDynamicWorkflow(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.True(t, callsAdmin)
+		assert.True(t, dCtx.isDynamic)
+		assert.NotEmpty(t, dCtx.checksum)
+		assert.NotEqual(t, "stale", dCtx.checksum)
 	})
 
 	t.Run("dynamic wf cache read fails", func(t *testing.T) {
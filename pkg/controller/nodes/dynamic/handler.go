@@ -63,6 +63,8 @@ type dynamicNodeTaskNodeHandler struct {
 	nodeExecutor executors.Node
 	lpReader     launchplan.Reader
 	eventConfig  *config.EventConfig
+	// Share of the remaining parallelism the nodes of the dynamic workflow may use.
+	parallelismWeight float64
 }
 
 func (d dynamicNodeTaskNodeHandler) handleParentNode(ctx context.Context, prevState handler.DynamicNodeState, nCtx handler.NodeExecutionContext) (handler.Transition, handler.DynamicNodeState, error) {
@@ -116,7 +118,7 @@ func (d dynamicNodeTaskNodeHandler) produceDynamicWorkflow(ctx context.Context,
 		}
 	}
 
-	nextState := handler.DynamicNodeState{Phase: v1alpha1.DynamicNodePhaseExecuting}
+	nextState := handler.DynamicNodeState{Phase: v1alpha1.DynamicNodePhaseExecuting, WorkflowChecksum: dCtx.checksum}
 	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoDynamicRunning(&handler.ExecutionInfo{
 		TaskNodeInfo: &handler.TaskNodeInfo{
 			TaskNodeMetadata: taskNodeInfoMetadata,
@@ -141,6 +143,9 @@ func (d dynamicNodeTaskNodeHandler) handleDynamicSubNodes(ctx context.Context, n
 		return handler.UnknownTransition, prevState, err
 	}
 
+	// The dynamic workflow may have been recompiled in this round, keep track of the checksum of the offloaded workflow.
+	newState.WorkflowChecksum = dCtx.checksum
+
 	if trns.Info().GetPhase() == handler.EPhaseSuccess {
 		logger.Infof(ctx, "dynamic workflow node has succeeded, will call on success handler for parent node [%s]", nCtx.NodeID())
 		// These outputPaths only reads the output metadata. So the sandbox is completely optional here and hence it is nil.
@@ -250,6 +255,7 @@ func (d dynamicNodeTaskNodeHandler) Abort(ctx context.Context, nCtx handler.Node
 			return nil
 		}
 
+		ctx = executors.WithAbortScope(ctx, dCtx.subWorkflow.GetID())
 		return d.nodeExecutor.AbortHandler(ctx, dCtx.execContext, dCtx.subWorkflow, dCtx.nodeLookup, dCtx.subWorkflow.StartNode(), reason)
 	default:
 		logger.Infof(ctx, "Aborting regular node RetryAttempt [%d]", nCtx.CurrentAttempt())
@@ -304,10 +310,11 @@ func (d dynamicNodeTaskNodeHandler) Finalize(ctx context.Context, nCtx handler.N
 func New(underlying TaskNodeHandler, nodeExecutor executors.Node, launchPlanReader launchplan.Reader, eventConfig *config.EventConfig, scope promutils.Scope) handler.Node {
 
 	return &dynamicNodeTaskNodeHandler{
-		TaskNodeHandler: underlying,
-		metrics:         newMetrics(scope),
-		nodeExecutor:    nodeExecutor,
-		lpReader:        launchPlanReader,
-		eventConfig:     eventConfig,
+		TaskNodeHandler:   underlying,
+		metrics:           newMetrics(scope),
+		nodeExecutor:      nodeExecutor,
+		lpReader:          launchPlanReader,
+		eventConfig:       eventConfig,
+		parallelismWeight: config.GetConfig().NodeConfig.ParallelismBudget.DynamicWeight,
 	}
 }
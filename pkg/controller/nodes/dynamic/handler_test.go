@@ -55,6 +55,10 @@ func (t *dynamicNodeStateHolder) PutDynamicNodeState(s handler.DynamicNodeState)
 	return nil
 }
 
+func (t dynamicNodeStateHolder) PutArrayNodeState(s handler.ArrayNodeState) error {
+	panic("not implemented")
+}
+
 var tID = "task-1"
 
 var eventConfig = &config.EventConfig{
@@ -2,14 +2,19 @@ package dynamic
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/encoding"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/compiler"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
 )
 
 // Constructs the expected interface of a given node.
@@ -92,3 +97,74 @@ func makeArrayInterface(varMap *core.VariableMap) *core.VariableMap {
 
 	return varMap
 }
+
+// Looks for a cycle in the downstream edges of a workflow. It returns the path of the first cycle found, starting and
+// ending with the same node, or nil if the workflow is acyclic. Every node is visited, including the ones not reachable
+// from the start node, so that no cycle is missed.
+func findCycle(connections *v1alpha1.Connections) []v1alpha1.NodeID {
+	// This is a set of nodes that were ever visited.
+	visited := sets.NewString()
+	// This is the path of in-progress visiting nodes.
+	var path []v1alpha1.NodeID
+	var detector func(nodeID v1alpha1.NodeID) []v1alpha1.NodeID
+	detector = func(nodeID v1alpha1.NodeID) []v1alpha1.NodeID {
+		for i, n := range path {
+			if n == nodeID {
+				return append(append([]v1alpha1.NodeID{}, path[i:]...), nodeID)
+			}
+		}
+
+		if visited.Has(nodeID) {
+			return nil
+		}
+
+		visited.Insert(nodeID)
+		path = append(path, nodeID)
+		for _, nextID := range connections.Downstream[nodeID] {
+			if cycle := detector(nextID); cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		return nil
+	}
+
+	nodeIDs := make([]string, 0, len(connections.Downstream))
+	for nodeID := range connections.Downstream {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	// Sorted for the same cycle to be reported every time.
+	sort.Strings(nodeIDs)
+	for _, nodeID := range nodeIDs {
+		if cycle := detector(nodeID); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// Validates that neither the dynamic workflow nor its subworkflows contain cycles, as the nodes in a cycle would wait on
+// each other forever.
+func validateNoCycles(w *v1alpha1.FlyteWorkflow) error {
+	specs := make([]*v1alpha1.WorkflowSpec, 0, len(w.SubWorkflows)+1)
+	specs = append(specs, w.WorkflowSpec)
+	for _, s := range w.SubWorkflows {
+		specs = append(specs, s)
+	}
+
+	for _, s := range specs {
+		if s == nil {
+			continue
+		}
+
+		if cycle := findCycle(s.GetConnections()); cycle != nil {
+			return errors.Errorf(utils.ErrorCodeUser, "cycle detected in dynamic workflow [%s]: %s", s.ID,
+				strings.Join(cycle, " -> "))
+		}
+	}
+
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	mocks2 "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
@@ -66,3 +69,66 @@ func TestUnderlyingInterface(t *testing.T) {
 	assert.NotNil(t, iface)
 	assert.Nil(t, iface.Outputs)
 }
+
+func TestFindCycle(t *testing.T) {
+	t.Run("acyclic", func(t *testing.T) {
+		assert.Nil(t, findCycle(&v1alpha1.Connections{
+			Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{
+				v1alpha1.StartNodeID: {"n1", "n2"},
+				"n1":                 {"n2"},
+				"n2":                 {v1alpha1.EndNodeID},
+			},
+		}))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		assert.Equal(t, []v1alpha1.NodeID{"n1", "n2", "n3", "n1"}, findCycle(&v1alpha1.Connections{
+			Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{
+				v1alpha1.StartNodeID: {"n1"},
+				"n1":                 {"n2"},
+				"n2":                 {"n3"},
+				"n3":                 {"n1", v1alpha1.EndNodeID},
+			},
+		}))
+	})
+
+	t.Run("unreachable-cycle", func(t *testing.T) {
+		assert.Equal(t, []v1alpha1.NodeID{"n1", "n2", "n1"}, findCycle(&v1alpha1.Connections{
+			Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{
+				v1alpha1.StartNodeID: {v1alpha1.EndNodeID},
+				"n1":                 {"n2"},
+				"n2":                 {"n1"},
+			},
+		}))
+	})
+}
+
+func TestValidateNoCycles(t *testing.T) {
+	acyclic := &v1alpha1.WorkflowSpec{
+		ID: "wf",
+		Connections: v1alpha1.Connections{
+			Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{v1alpha1.StartNodeID: {v1alpha1.EndNodeID}},
+		},
+	}
+
+	t.Run("acyclic", func(t *testing.T) {
+		assert.NoError(t, validateNoCycles(&v1alpha1.FlyteWorkflow{WorkflowSpec: acyclic}))
+	})
+
+	t.Run("cyclic-subworkflow", func(t *testing.T) {
+		err := validateNoCycles(&v1alpha1.FlyteWorkflow{
+			WorkflowSpec: acyclic,
+			SubWorkflows: map[v1alpha1.WorkflowID]*v1alpha1.WorkflowSpec{
+				"sub": {
+					ID: "sub",
+					Connections: v1alpha1.Connections{
+						Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{"n1": {"n1"}},
+					},
+				},
+			},
+		})
+		assert.Error(t, err)
+		assert.True(t, stdErrors.IsCausedBy(err, utils.ErrorCodeUser))
+		assert.Contains(t, err.Error(), "n1 -> n1")
+	})
+}
@@ -25,4 +25,8 @@ const (
 	StorageError                       ErrorCode = "StorageError"
 	EventRecordingFailed               ErrorCode = "EventRecordingFailed"
 	CatalogCallFailed                  ErrorCode = "CatalogCallFailed"
+	HandlerPanic                       ErrorCode = "HandlerPanic"
+	AbortTimedOut                      ErrorCode = "AbortTimedOut"
+	LaunchPlanInterfaceDrift           ErrorCode = "LaunchPlanInterfaceDrift"
+	MismatchingInputsError             ErrorCode = "MismatchingInputs"
 )
@@ -19,9 +19,11 @@ package nodes
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"time"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -40,13 +42,21 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/outcomes"
+	"github.com/flyteorg/flytepropeller/pkg/controller/phasemetrics"
+	"github.com/flyteorg/flytepropeller/pkg/controller/resilientstore"
+	"github.com/flyteorg/flytepropeller/pkg/controller/roundbudget"
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
@@ -69,6 +79,7 @@ type nodeMetrics struct {
 	ResolutionFailure             labeled.Counter
 	InputsWriteFailure            labeled.Counter
 	TimedOutFailure               labeled.Counter
+	HandlerPanic                  labeled.Counter
 
 	InterruptedThresholdHit      labeled.Counter
 	InterruptibleNodesRunning    labeled.Counter
@@ -81,6 +92,12 @@ type nodeMetrics struct {
 	QueuingLatency         labeled.StopWatch
 	NodeExecutionTime      labeled.StopWatch
 	NodeInputGatherLatency labeled.StopWatch
+	// Measures the delays nodes back off for before retrying system failures
+	RetryBackoffDelay labeled.StopWatch
+	// Nodes that reused the outputs of a previous execution instead of running again
+	ReusedOutcomes labeled.Counter
+	// Nodes an abort was propagated to, by the outcome of aborting them
+	AbortOutcomes *prometheus.CounterVec
 }
 
 // Implements the executors.Node interface
@@ -93,15 +110,29 @@ type nodeExecutor struct {
 	metrics                         *nodeMetrics
 	maxDatasetSizeBytes             int64
 	outputResolver                  OutputResolver
+	bindingPlans                    *bindingPlanCache
+	inputPrefetcher                 *inputPrefetcher
+	taskTemplates                   *taskTemplateCache
 	defaultExecutionDeadline        time.Duration
 	defaultActiveDeadline           time.Duration
 	maxNodeRetriesForSystemFailures uint32
+	defaultRetryPolicies            []v1alpha1.RetryPolicy
+	systemErrorCodes                sets.String
+	systemRetryBackoff              config.RetryBackoffConfig
 	interruptibleFailureThreshold   uint32
+	maxEvaluationParallelism        int
 	defaultDataSandbox              storage.DataReference
 	shardSelector                   ioutils.ShardSelector
+	rawOutputPerExecution           bool
 	recoveryClient                  recovery.Client
 	eventConfig                     *config.EventConfig
 	clusterID                       string
+	nodeOutcomes                    *outcomes.Store
+	iamRoleAnnotation               string
+	phaseMetrics                    *phasemetrics.Recorder
+	maxAbortDepth                   int
+	abortParallelism                int
+	abortTimeout                    time.Duration
 }
 
 func (c *nodeExecutor) RecordTransitionLatency(ctx context.Context, dag executors.DAGStructure, nl executors.NodeLookup, node v1alpha1.ExecutableNode, nodeStatus v1alpha1.ExecutableNodeStatus) {
@@ -113,10 +144,10 @@ func (c *nodeExecutor) RecordTransitionLatency(ctx context.Context, dag executor
 			return
 		}
 		if !t.IsZero() {
-			c.metrics.TransitionLatency.Observe(ctx, t.Time, time.Now())
+			c.metrics.TransitionLatency.Observe(ctx, t.Time, clocks.Now(ctx))
 		}
 	} else if nodeStatus.GetPhase() == v1alpha1.NodePhaseRetryableFailure && nodeStatus.GetLastUpdatedAt() != nil {
-		c.metrics.TransitionLatency.Observe(ctx, nodeStatus.GetLastUpdatedAt().Time, time.Now())
+		c.metrics.TransitionLatency.Observe(ctx, nodeStatus.GetLastUpdatedAt().Time, clocks.Now(ctx))
 	}
 }
 
@@ -206,39 +237,36 @@ func (c *nodeExecutor) attemptRecovery(ctx context.Context, nCtx handler.NodeExe
 				errors.StorageError, nCtx.NodeID(), err, "Failed to store inputs for Node. InputsFile [%s]", nCtx.InputReader().GetInputPath())
 		}
 	} else if len(recovered.InputUri) > 0 {
-		// If the inputs are too large they won't be returned inline in the RecoverData call. We must fetch them before copying them.
-		nodeInputs := &core.LiteralMap{}
-		if recoveredData.FullInputs == nil {
-			if err := c.store.ReadProtobuf(ctx, storage.DataReference(recovered.InputUri), nodeInputs); err != nil {
-				return handler.PhaseInfoUndefined, errors.Wrapf(errors.InputsNotFoundError, nCtx.NodeID(), err, "failed to read data from dataDir [%v].", recovered.InputUri)
-			}
-		}
-
-		if err := c.store.WriteProtobuf(ctx, nCtx.InputReader().GetInputPath(), storage.Options{}, nodeInputs); err != nil {
+		// If the inputs are too large they won't be returned inline in the RecoverData call. They are copied from the
+		// original node as is, without reading them into memory.
+		if err := c.store.CopyRaw(ctx, storage.DataReference(recovered.InputUri), nCtx.InputReader().GetInputPath(), storage.Options{}); err != nil {
 			c.metrics.InputsWriteFailure.Inc(ctx)
-			logger.Errorf(ctx, "Failed to move recovered inputs for Node. Error [%v]. InputsFile [%s]", err, nCtx.InputReader().GetInputPath())
+			logger.Errorf(ctx, "Failed to copy recovered inputs for Node. Error [%v]. InputsFile [%s]", err, nCtx.InputReader().GetInputPath())
 			return handler.PhaseInfoUndefined, errors.Wrapf(
-				errors.StorageError, nCtx.NodeID(), err, "Failed to store inputs for Node. InputsFile [%s]", nCtx.InputReader().GetInputPath())
+				errors.StorageError, nCtx.NodeID(), err, "Failed to copy inputs [%v] for Node. InputsFile [%s]", recovered.InputUri, nCtx.InputReader().GetInputPath())
 		}
 	}
 	// Similarly, copy outputs' reference
-	so := storage.Options{}
-	var outputs = &core.LiteralMap{}
-	if recoveredData.FullOutputs != nil {
-		outputs = recoveredData.FullOutputs
-	} else if recovered.Closure.GetOutputData() != nil {
-		outputs = recovered.Closure.GetOutputData()
-	} else if len(recovered.Closure.GetOutputUri()) > 0 {
-		if err := c.store.ReadProtobuf(ctx, storage.DataReference(recovered.Closure.GetOutputUri()), outputs); err != nil {
-			return handler.PhaseInfoUndefined, errors.Wrapf(errors.InputsNotFoundError, nCtx.NodeID(), err, "failed to read output data [%v].", recovered.Closure.GetOutputUri())
+	outputFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
+	if recoveredData.FullOutputs == nil && recovered.Closure.GetOutputData() == nil && len(recovered.Closure.GetOutputUri()) > 0 {
+		if err := c.store.CopyRaw(ctx, storage.DataReference(recovered.Closure.GetOutputUri()), outputFile, storage.Options{}); err != nil {
+			logger.Errorf(ctx, "Failed to copy recovered outputs. Error [%v]", err)
+			return handler.PhaseInfoUndefined, errors.Wrapf(errors.CausedByError, nCtx.NodeID(), err, "Failed to copy recovered node execution outputs [%v]", recovered.Closure.GetOutputUri())
 		}
 	} else {
-		logger.Debugf(ctx, "No outputs found for recovered node [%+v]", nCtx.NodeExecutionMetadata().GetNodeExecutionID())
-	}
-	outputFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
-	if err := c.store.WriteProtobuf(ctx, outputFile, so, outputs); err != nil {
-		logger.Errorf(ctx, "Failed to write protobuf (metadata). Error [%v]", err)
-		return handler.PhaseInfoUndefined, errors.Wrapf(errors.CausedByError, nCtx.NodeID(), err, "Failed to store recovered node execution outputs")
+		var outputs = &core.LiteralMap{}
+		if recoveredData.FullOutputs != nil {
+			outputs = recoveredData.FullOutputs
+		} else if recovered.Closure.GetOutputData() != nil {
+			outputs = recovered.Closure.GetOutputData()
+		} else {
+			logger.Debugf(ctx, "No outputs found for recovered node [%+v]", nCtx.NodeExecutionMetadata().GetNodeExecutionID())
+		}
+
+		if err := c.store.WriteProtobuf(ctx, outputFile, storage.Options{}, outputs); err != nil {
+			logger.Errorf(ctx, "Failed to write protobuf (metadata). Error [%v]", err)
+			return handler.PhaseInfoUndefined, errors.Wrapf(errors.CausedByError, nCtx.NodeID(), err, "Failed to store recovered node execution outputs")
+		}
 	}
 
 	info := &handler.ExecutionInfo{
@@ -269,15 +297,28 @@ func (c *nodeExecutor) attemptRecovery(ctx context.Context, nCtx handler.NodeExe
 	return handler.PhaseInfoRecovered(info), nil
 }
 
+// The end node binds the outputs of a (sub)workflow. Its bindings may refer to the outputs of many nodes, so they are
+// resolved through a binding plan that is built once per (sub)workflow and reads the outputs of every node only once.
+func (c *nodeExecutor) resolveEndNodeInputs(ctx context.Context, nCtx handler.NodeExecutionContext) (*core.LiteralMap, error) {
+	node := nCtx.Node()
+	nl := nCtx.ContextualNodeLookup()
+	plan, err := c.bindingPlans.GetOrCreate(ctx, nCtx.NodeExecutionMetadata(), nl, node.GetID(), node.GetInputBindings())
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.Resolve(ctx, c.store, nl, node.GetID(), node.GetInputBindings())
+}
+
 // In this method we check if the queue is ready to be processed and if so, we prime it in Admin as queued
 // Before we start the node execution, we need to transition this Node status to Queued.
 // This is because a node execution has to exist before task/wf executions can start.
 func (c *nodeExecutor) preExecute(ctx context.Context, dag executors.DAGStructure, nCtx handler.NodeExecutionContext) (handler.PhaseInfo, error) {
-	logger.Debugf(ctx, "Node not yet started")
+	tracing.Tracef(ctx, "Node not yet started")
 	// Query the nodes information to figure out if it can be executed.
 	predicatePhase, err := CanExecute(ctx, dag, nCtx.ContextualNodeLookup(), nCtx.Node())
 	if err != nil {
-		logger.Debugf(ctx, "Node failed in CanExecute. Error [%s]", err)
+		tracing.Tracef(ctx, "Node failed in CanExecute. Error [%s]", err)
 		return handler.PhaseInfoUndefined, err
 	}
 
@@ -299,7 +340,11 @@ func (c *nodeExecutor) preExecute(ctx context.Context, dag executors.DAGStructur
 			defer t.Stop()
 			// Can execute
 			var err error
-			nodeInputs, err = Resolve(ctx, c.outputResolver, nCtx.ContextualNodeLookup(), node.GetID(), node.GetInputBindings())
+			if node.GetID() == v1alpha1.EndNodeID {
+				nodeInputs, err = c.resolveEndNodeInputs(ctx, nCtx)
+			} else {
+				nodeInputs, err = Resolve(ctx, c.outputResolver, nCtx.ContextualNodeLookup(), node.GetID(), node.GetInputBindings())
+			}
 			// TODO we need to handle retryable, network errors here!!
 			if err != nil {
 				c.metrics.ResolutionFailure.Inc(ctx)
@@ -307,6 +352,22 @@ func (c *nodeExecutor) preExecute(ctx context.Context, dag executors.DAGStructur
 				return handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, "BindingResolutionFailure", err.Error(), nil), nil
 			}
 
+			// A failure node receives the context of the original failure as inputs
+			if fl, ok := nCtx.ContextualNodeLookup().(executors.FailureNodeLookup); ok && nodeInputs != nil {
+				if err := addErrorContextInputs(ctx, nCtx, fl.GetErrorContext(), nodeInputs); err != nil {
+					logger.Warningf(ctx, "Failed to add error context inputs for failure node. Error [%v]", err)
+					return handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, "BindingResolutionFailure", err.Error(), nil), nil
+				}
+			}
+
+			// A finally node receives the terminal status of the workflow as inputs
+			if fl, ok := nCtx.ContextualNodeLookup().(executors.FinallyNodeLookup); ok && nodeInputs != nil {
+				if err := addTerminalStatusInputs(ctx, nCtx, fl.GetTerminalStatus(), nodeInputs); err != nil {
+					logger.Warningf(ctx, "Failed to add terminal status inputs for finally node. Error [%v]", err)
+					return handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, "BindingResolutionFailure", err.Error(), nil), nil
+				}
+			}
+
 			if nodeInputs != nil {
 				inputsFile := v1alpha1.GetInputsFile(dataDir)
 				if err := c.store.WriteProtobuf(ctx, inputsFile, storage.Options{}, nodeInputs); err != nil {
@@ -317,6 +378,11 @@ func (c *nodeExecutor) preExecute(ctx context.Context, dag executors.DAGStructur
 				}
 			}
 
+			phaseInfo, err := c.attemptReuse(ctx, nCtx, nodeInputs)
+			if err != nil || phaseInfo.GetPhase() == handler.EPhaseRecovered {
+				return phaseInfo, err
+			}
+
 			logger.Debugf(ctx, "Node Data Directory [%s].", nodeStatus.GetDataDir())
 		}
 
@@ -326,18 +392,19 @@ func (c *nodeExecutor) preExecute(ctx context.Context, dag executors.DAGStructur
 	// Now that we have resolved the inputs, we can record as a transition latency. This is because we have completed
 	// all the overhead that we have to compute. Any failures after this will incur this penalty, but it could be due
 	// to various external reasons - like queuing, overuse of quota, plugin overhead etc.
-	logger.Debugf(ctx, "preExecute completed in phase [%s]", predicatePhase.String())
+	tracing.Tracef(ctx, "preExecute completed in phase [%s]", predicatePhase.String())
 	if predicatePhase == PredicatePhaseSkip {
-		return handler.PhaseInfoSkip(nil, "Node Skipped as parent node was skipped"), nil
+		code, reason := GetSkipReason(ctx, dag, nCtx.ContextualNodeLookup(), nCtx.Node())
+		return handler.PhaseInfoSkipWithReason(nil, code, reason), nil
 	}
 
 	return handler.PhaseInfoNotReady("predecessor node not yet complete"), nil
 }
 
-func isTimeoutExpired(queuedAt *metav1.Time, timeout time.Duration) bool {
+func isTimeoutExpired(queuedAt *metav1.Time, timeout time.Duration, now time.Time) bool {
 	if !queuedAt.IsZero() && timeout != 0 {
 		deadline := queuedAt.Add(timeout)
-		if deadline.Before(time.Now()) {
+		if deadline.Before(now) {
 			return true
 		}
 	}
@@ -345,6 +412,23 @@ func isTimeoutExpired(queuedAt *metav1.Time, timeout time.Duration) bool {
 }
 
 func (c *nodeExecutor) isEligibleForRetry(nCtx *nodeExecContext, nodeStatus v1alpha1.ExecutableNodeStatus, err *core.ExecutionError) (currentAttempt, maxAttempts uint32, isEligible bool) {
+	if policy := c.getRetryPolicy(nCtx.Node(), err); policy != nil {
+		retries := uint32(0)
+		if policy.Retries > 0 {
+			retries = uint32(policy.Retries)
+		}
+
+		if err.Kind == core.ExecutionError_SYSTEM {
+			currentAttempt = nodeStatus.GetSystemFailures()
+			maxAttempts = retries
+		} else {
+			currentAttempt = (nodeStatus.GetAttempts() + 1) - nodeStatus.GetSystemFailures()
+			maxAttempts = retries + 1
+		}
+		isEligible = currentAttempt < maxAttempts
+		return
+	}
+
 	if err.Kind == core.ExecutionError_SYSTEM {
 		currentAttempt = nodeStatus.GetSystemFailures()
 		maxAttempts = c.maxNodeRetriesForSystemFailures
@@ -360,48 +444,109 @@ func (c *nodeExecutor) isEligibleForRetry(nCtx *nodeExecContext, nodeStatus v1al
 	return
 }
 
+// The maximum size of the stack of a panic in a node handler that is recorded in the status of the node.
+const maxPanicStackSize = 4096
+
+// Recovers from a panic in a node handler and converts it to an error, so that a single misbehaving node does not take
+// down the evaluation of the whole workflow. Must be deferred directly.
+func (c *nodeExecutor) recoverHandlerPanic(ctx context.Context, nCtx handler.NodeExecutionContext, operation string, err *error) {
+	if r := recover(); r != nil {
+		c.metrics.HandlerPanic.Inc(ctx)
+		stack := debug.Stack()
+		logger.Errorf(ctx, "Panic in node handler when trying to %s the node. Panic: %v, Stack: [%s]", operation, r, string(stack))
+		*err = errors.Errorf(errors.HandlerPanic, nCtx.NodeID(), "panic when trying to %s the node: %v, Stack: [%s]", operation, r,
+			truncatePanicStack(stack))
+	}
+}
+
+// Truncates the stack of a panic, so that recording it in the status of the node does not blow up the size of the
+// FlyteWorkflow CRD.
+func truncatePanicStack(stack []byte) string {
+	if len(stack) > maxPanicStackSize {
+		return string(stack[:maxPanicStackSize]) + "..."
+	}
+
+	return string(stack)
+}
+
+// Calls the handler of the node. A panic in the handler fails the node, with a non-recoverable system error that holds
+// the stack of the panic, instead of failing the evaluation of the whole workflow.
+func (c *nodeExecutor) handle(ctx context.Context, h handler.Node, nCtx *nodeExecContext) (handler.Transition, error) {
+	var t handler.Transition
+	var panicErr error
+	err := func() (err error) {
+		defer c.recoverHandlerPanic(ctx, nCtx, "handle", &panicErr)
+		t, err = h.Handle(ctx, nCtx)
+		return err
+	}()
+
+	if panicErr != nil {
+		return handler.DoTransition(handler.TransitionTypeEphemeral,
+			handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.HandlerPanic, panicErr.Error(), nil)), nil
+	}
+
+	return t, err
+}
+
 func (c *nodeExecutor) execute(ctx context.Context, h handler.Node, nCtx *nodeExecContext, nodeStatus v1alpha1.ExecutableNodeStatus) (handler.PhaseInfo, error) {
-	logger.Debugf(ctx, "Executing node")
-	defer logger.Debugf(ctx, "Node execution round complete")
+	tracing.Tracef(ctx, "Executing node")
+	defer tracing.Tracef(ctx, "Node execution round complete")
 
-	t, err := h.Handle(ctx, nCtx)
+	var phase handler.PhaseInfo
+	t, err := c.handle(ctx, h, nCtx)
 	if err != nil {
-		return handler.PhaseInfoUndefined, err
+		if !resilientstore.IsUnavailable(err) {
+			return handler.PhaseInfoUndefined, err
+		}
+
+		// Rather than failing the round over and over while the blob storage is unavailable, the attempt fails with a
+		// retryable system error, which the system retries of the node cover.
+		logger.Warnf(ctx, "Failing the attempt of the node, blob storage is unavailable. Error: %v", err)
+		phase = handler.PhaseInfoRetryableFailure(core.ExecutionError_SYSTEM, errors.StorageError, err.Error(), nil)
+	} else {
+		phase = t.Info()
 	}
 
-	phase := t.Info()
 	// check for timeout for non-terminal phases
 	if !phase.GetPhase().IsTerminal() {
 		activeDeadline := c.defaultActiveDeadline
 		if nCtx.Node().GetActiveDeadline() != nil && *nCtx.Node().GetActiveDeadline() > 0 {
 			activeDeadline = *nCtx.Node().GetActiveDeadline()
 		}
-		if isTimeoutExpired(nodeStatus.GetQueuedAt(), activeDeadline) {
+		// The active deadline covers the whole life of the node, including the time it spent queued, and is not retried
+		if isTimeoutExpired(nodeStatus.GetQueuedAt(), activeDeadline, clocks.Now(ctx)) {
 			logger.Infof(ctx, "Node has timed out; timeout configured: %v", activeDeadline)
-			return handler.PhaseInfoTimedOut(nil, fmt.Sprintf("task active timeout [%s] expired", activeDeadline.String())), nil
+			return handler.PhaseInfoTimedOutErr(&core.ExecutionError{
+				Code:    activeTimeoutExpiredErrorCode,
+				Message: fmt.Sprintf("task active timeout [%s] expired", activeDeadline.String()),
+				Kind:    core.ExecutionError_USER,
+			}, nil), nil
 		}
 
-		// Execution timeout is a retry-able error
-		executionDeadline := c.defaultExecutionDeadline
-		if nCtx.Node().GetExecutionDeadline() != nil && *nCtx.Node().GetExecutionDeadline() > 0 {
-			executionDeadline = *nCtx.Node().GetExecutionDeadline()
-		}
-		if isTimeoutExpired(nodeStatus.GetLastAttemptStartedAt(), executionDeadline) {
+		// Execution timeout is a retry-able error, it only covers the current attempt since it started running
+		executionDeadline := c.getExecutionDeadline(nCtx.Node(), nodeStatus)
+		if isTimeoutExpired(nodeStatus.GetLastAttemptStartedAt(), executionDeadline, clocks.Now(ctx)) {
 			logger.Infof(ctx, "Current execution for the node timed out; timeout configured: %v", executionDeadline)
-			executionErr := &core.ExecutionError{Code: "TimeoutExpired", Message: fmt.Sprintf("task execution timeout [%s] expired", executionDeadline.String()), Kind: core.ExecutionError_USER}
+			executionErr := &core.ExecutionError{Code: timeoutExpiredErrorCode, Message: fmt.Sprintf("task execution timeout [%s] expired", executionDeadline.String()), Kind: core.ExecutionError_USER}
 			phase = handler.PhaseInfoRetryableFailureErr(executionErr, nil)
 		}
 	}
 
 	if phase.GetPhase() == handler.EPhaseRetryableFailure {
+		if err := c.toSystemError(phase.GetErr()); err != phase.GetErr() {
+			phase = handler.PhaseInfoRetryableFailureErr(err, phase.GetInfo())
+		}
+
 		currentAttempt, maxAttempts, isEligible := c.isEligibleForRetry(nCtx, nodeStatus, phase.GetErr())
 		if !isEligible {
+			// The kind of the last failure is kept, so that nodes that exhausted their system retries fail with a system
+			// error
 			return handler.PhaseInfoFailure(
-				core.ExecutionError_USER,
+				phase.GetErr().Kind,
 				fmt.Sprintf("RetriesExhausted|%s", phase.GetErr().Code),
 				fmt.Sprintf("[%d/%d] currentAttempt done. Last Error: %s::%s", currentAttempt, maxAttempts, phase.GetErr().Kind.String(), phase.GetErr().Message),
 				phase.GetInfo(),
-			), nil
+			).WithReasonCode(handler.ReasonCodeRetriesExhausted), nil
 		}
 
 		// Retrying to clearing all status
@@ -413,24 +558,30 @@ func (c *nodeExecutor) execute(ctx context.Context, h handler.Node, nCtx *nodeEx
 
 func (c *nodeExecutor) abort(ctx context.Context, h handler.Node, nCtx handler.NodeExecutionContext, reason string) error {
 	logger.Debugf(ctx, "Calling aborting & finalize")
-	if err := h.Abort(ctx, nCtx, reason); err != nil {
-		finalizeErr := h.Finalize(ctx, nCtx)
+	abortErr := func() (err error) {
+		defer c.recoverHandlerPanic(ctx, nCtx, "abort", &err)
+		return h.Abort(ctx, nCtx, reason)
+	}()
+
+	if abortErr != nil {
+		finalizeErr := c.finalize(ctx, h, nCtx)
 		if finalizeErr != nil {
-			return errors.ErrorCollection{Errors: []error{err, finalizeErr}}
+			return errors.ErrorCollection{Errors: []error{abortErr, finalizeErr}}
 		}
-		return err
+		return abortErr
 	}
 
-	return h.Finalize(ctx, nCtx)
+	return c.finalize(ctx, h, nCtx)
 }
 
-func (c *nodeExecutor) finalize(ctx context.Context, h handler.Node, nCtx handler.NodeExecutionContext) error {
+func (c *nodeExecutor) finalize(ctx context.Context, h handler.Node, nCtx handler.NodeExecutionContext) (err error) {
+	defer c.recoverHandlerPanic(ctx, nCtx, "finalize", &err)
 	return h.Finalize(ctx, nCtx)
 }
 
 func (c *nodeExecutor) handleNotYetStartedNode(ctx context.Context, dag executors.DAGStructure, nCtx *nodeExecContext, _ handler.Node) (executors.NodeStatus, error) {
-	logger.Debugf(ctx, "Node not yet started, running pre-execute")
-	defer logger.Debugf(ctx, "Node pre-execute completed")
+	tracing.Tracef(ctx, "Node not yet started, running pre-execute")
+	defer tracing.Tracef(ctx, "Node pre-execute completed")
 	p, err := c.preExecute(ctx, dag, nCtx)
 	if err != nil {
 		logger.Errorf(ctx, "failed preExecute for node. Error: %s", err.Error())
@@ -487,8 +638,8 @@ func (c *nodeExecutor) handleQueuedOrRunningNode(ctx context.Context, nCtx *node
 	currentPhase := nodeStatus.GetPhase()
 
 	// case v1alpha1.NodePhaseQueued, v1alpha1.NodePhaseRunning:
-	logger.Debugf(ctx, "node executing, current phase [%s]", currentPhase)
-	defer logger.Debugf(ctx, "node execution completed")
+	tracing.Tracef(ctx, "node executing, current phase [%s]", currentPhase)
+	defer tracing.Tracef(ctx, "node execution completed")
 
 	// Since we reset node status inside execute for retryable failure, we use lastAttemptStartTime to carry that information
 	// across execute which is used to emit metrics
@@ -514,12 +665,16 @@ func (c *nodeExecutor) handleQueuedOrRunningNode(ctx context.Context, nCtx *node
 	execErr := p.GetErr()
 	if execErr != nil && (currentPhase == v1alpha1.NodePhaseRunning || currentPhase == v1alpha1.NodePhaseQueued ||
 		currentPhase == v1alpha1.NodePhaseDynamicRunning) {
-		endTime := time.Now()
+		endTime := clocks.Now(ctx)
 		startTime := endTime
 		if lastAttemptStartTime != nil {
 			startTime = lastAttemptStartTime.Time
 		}
 
+		if execErr.GetCode() == timeoutExpiredErrorCode {
+			nodeStatus.IncrementTimeoutFailures()
+		}
+
 		if execErr.GetKind() == core.ExecutionError_SYSTEM {
 			nodeStatus.IncrementSystemFailures()
 			c.metrics.SystemErrorDuration.Observe(ctx, startTime, endTime)
@@ -532,7 +687,6 @@ func (c *nodeExecutor) handleQueuedOrRunningNode(ctx context.Context, nCtx *node
 		// the entire workflow is failed.
 		if np == v1alpha1.NodePhaseFailing {
 			if execErr.GetKind() == core.ExecutionError_SYSTEM {
-				nodeStatus.IncrementSystemFailures()
 				c.metrics.PermanentSystemErrorDuration.Observe(ctx, startTime, endTime)
 			} else if execErr.GetKind() == core.ExecutionError_USER {
 				c.metrics.PermanentUserErrorDuration.Observe(ctx, startTime, endTime)
@@ -551,7 +705,7 @@ func (c *nodeExecutor) handleQueuedOrRunningNode(ctx context.Context, nCtx *node
 	if np == v1alpha1.NodePhaseTimingOut && !h.FinalizeRequired() {
 		logger.Infof(ctx, "Finalize not required, moving node to TimedOut")
 		np = v1alpha1.NodePhaseTimedOut
-		finalStatus = executors.NodeStatusTimedOut
+		finalStatus = executors.NodeStatusTimedOutErr(p.GetErr())
 	}
 
 	if np == v1alpha1.NodePhaseSucceeding && !h.FinalizeRequired() {
@@ -609,7 +763,7 @@ func (c *nodeExecutor) handleQueuedOrRunningNode(ctx context.Context, nCtx *node
 		// We reach here only when transitioning from Queued to Running. In this case, the startedAt is not set.
 		if np == v1alpha1.NodePhaseRunning {
 			if nodeStatus.GetQueuedAt() != nil {
-				c.metrics.QueuingLatency.Observe(ctx, nodeStatus.GetQueuedAt().Time, time.Now())
+				c.metrics.QueuingLatency.Observe(ctx, nodeStatus.GetQueuedAt().Time, clocks.Now(ctx))
 			}
 		}
 	}
@@ -620,29 +774,87 @@ func (c *nodeExecutor) handleQueuedOrRunningNode(ctx context.Context, nCtx *node
 
 func (c *nodeExecutor) handleRetryableFailure(ctx context.Context, nCtx *nodeExecContext, h handler.Node) (executors.NodeStatus, error) {
 	nodeStatus := nCtx.NodeStatus()
-	logger.Debugf(ctx, "node failed with retryable failure, aborting and finalizing, message: %s", nodeStatus.GetMessage())
-	if err := c.abort(ctx, h, nCtx, nodeStatus.GetMessage()); err != nil {
-		return executors.NodeStatusUndefined, err
+	isSystemFailure := nodeStatus.GetExecutionError().GetKind() == core.ExecutionError_SYSTEM
+	backoff := nodeStatus.GetRetryBackoff()
+	if backoff != nil && backoff.NextAttemptAt != nil {
+		// The node was already aborted when it started backing off
+		if clocks.Now(ctx).Before(backoff.NextAttemptAt.Time) {
+			tracing.Tracef(ctx, "node backing off until [%v] before retrying", backoff.NextAttemptAt.Time)
+			return executors.NodeStatusRunning, nil
+		}
+	} else {
+		tracing.Tracef(ctx, "node failed with retryable failure, aborting and finalizing, message: %s", nodeStatus.GetMessage())
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		if err := c.abort(abortCtx, h, nCtx, nodeStatus.GetMessage()); err != nil {
+			return executors.NodeStatusUndefined, err
+		}
+
+		if terminations.IsPending() {
+			tracing.Tracef(ctx, "node waiting for the executions it terminated before retrying")
+			return executors.NodeStatusRunning, nil
+		}
+
+		// Repeated system failures are retried with an exponential backoff, so that infrastructure issues are not
+		// retried in a hot loop
+		if c.systemRetryBackoff.Enabled && isSystemFailure {
+			now := clocks.Now(ctx)
+			backoff = nextRetryBackoff(c.systemRetryBackoff, backoff, now)
+			nodeStatus.SetRetryBackoff(backoff)
+			c.metrics.RetryBackoffDelay.Observe(ctx, now, backoff.NextAttemptAt.Time)
+			logger.Infof(ctx, "Node failed with a system error, backing off until [%v] before retrying", backoff.NextAttemptAt.Time)
+			return executors.NodeStatusRunning, nil
+		}
+	}
+
+	if backoff != nil {
+		if isSystemFailure {
+			// The delay is kept, so that the backoff keeps growing if the next attempt fails with a system error too
+			backoff = backoff.DeepCopy()
+			backoff.NextAttemptAt = nil
+			nodeStatus.SetRetryBackoff(backoff)
+		} else {
+			nodeStatus.SetRetryBackoff(nil)
+		}
 	}
 
 	// NOTE: It is important to increment attempts only after abort has been called. Increment attempt mutates the state
 	// Attempt is used throughout the system to determine the idempotent resource version.
 	nodeStatus.IncrementAttempts()
-	nodeStatus.UpdatePhase(v1alpha1.NodePhaseRunning, v1.Now(), "retrying", nil)
+	nodeStatus.UpdatePhase(v1alpha1.NodePhaseRunning, v1.NewTime(clocks.Now(ctx)), "retrying", nil)
 	// We are going to retry in the next round, so we should clear all current state
 	nodeStatus.ClearSubNodeStatus()
 	nodeStatus.ClearTaskStatus()
 	nodeStatus.ClearWorkflowStatus()
 	nodeStatus.ClearDynamicNodeStatus()
+	nodeStatus.ClearArrayNodeStatus()
 	return executors.NodeStatusPending, nil
 }
 
-func (c *nodeExecutor) handleNode(ctx context.Context, dag executors.DAGStructure, nCtx *nodeExecContext, h handler.Node) (executors.NodeStatus, error) {
-	logger.Debugf(ctx, "Handling Node [%s]", nCtx.NodeID())
-	defer logger.Debugf(ctx, "Completed node [%s]", nCtx.NodeID())
+// Returns the cause of the transition of the node to its current phase. The error of a node is kept once it's retried,
+// so only the transitions to the phases of failures are attributed to it.
+func transitionCause(nodeStatus v1alpha1.ExecutableNodeStatus) string {
+	switch nodeStatus.GetPhase() {
+	case v1alpha1.NodePhaseRetryableFailure, v1alpha1.NodePhaseFailing, v1alpha1.NodePhaseFailed:
+		return phasemetrics.Cause(nodeStatus.GetExecutionError())
+	}
+
+	return phasemetrics.CauseNone
+}
+
+func (c *nodeExecutor) handleNode(ctx context.Context, dag executors.DAGStructure, nCtx *nodeExecContext, h handler.Node) (_ executors.NodeStatus, err error) {
+	ctx, span := tracing.StartSpan(ctx, "node.handle", attribute.String("flyte.node_id", nCtx.NodeID()),
+		attribute.String("flyte.node_phase", nCtx.NodeStatus().GetPhase().String()))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tracing.Tracef(ctx, "Handling Node [%s]", nCtx.NodeID())
+	defer tracing.Tracef(ctx, "Completed node [%s]", nCtx.NodeID())
 
 	nodeStatus := nCtx.NodeStatus()
 	currentPhase := nodeStatus.GetPhase()
+	enteredAt := nodeStatus.GetLastUpdatedAt()
+	defer func() {
+		c.phaseMetrics.Record(ctx, currentPhase, nodeStatus.GetPhase(), enteredAt, nodeStatus.GetLastUpdatedAt(), transitionCause(nodeStatus))
+	}()
 
 	// Optimization!
 	// If it is start node we directly move it to Queued without needing to run preExecute
@@ -651,18 +863,18 @@ func (c *nodeExecutor) handleNode(ctx context.Context, dag executors.DAGStructur
 		if err != nil {
 			return p, err
 		}
-		if p.NodePhase == executors.NodePhaseQueued {
+		if p.NodePhase == executors.NodePhaseQueued && isParallelismLimited(nCtx.Node()) {
 			logger.Infof(ctx, "Node was queued, parallelism is now [%d]", nCtx.ExecutionContext().IncrementParallelism())
 		}
 		return p, err
 	}
 
 	if currentPhase == v1alpha1.NodePhaseFailing {
-		logger.Debugf(ctx, "node failing")
+		tracing.Tracef(ctx, "node failing")
 		if err := c.finalize(ctx, h, nCtx); err != nil {
 			return executors.NodeStatusUndefined, err
 		}
-		nodeStatus.UpdatePhase(v1alpha1.NodePhaseFailed, v1.Now(), nodeStatus.GetMessage(), nodeStatus.GetExecutionError())
+		nodeStatus.UpdatePhase(v1alpha1.NodePhaseFailed, v1.NewTime(clocks.Now(ctx)), nodeStatus.GetMessage(), nodeStatus.GetExecutionError())
 		c.metrics.FailureDuration.Observe(ctx, nodeStatus.GetStartedAt().Time, nodeStatus.GetStoppedAt().Time)
 		if nCtx.md.IsInterruptible() {
 			c.metrics.InterruptibleNodesTerminated.Inc(ctx)
@@ -671,26 +883,32 @@ func (c *nodeExecutor) handleNode(ctx context.Context, dag executors.DAGStructur
 	}
 
 	if currentPhase == v1alpha1.NodePhaseTimingOut {
-		logger.Debugf(ctx, "node timing out")
-		if err := c.abort(ctx, h, nCtx, "node timed out"); err != nil {
+		tracing.Tracef(ctx, "node timing out")
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		if err := c.abort(abortCtx, h, nCtx, "node timed out"); err != nil {
 			return executors.NodeStatusUndefined, err
 		}
 
+		if terminations.IsPending() {
+			tracing.Tracef(ctx, "node waiting for the executions it terminated before timing out")
+			return executors.NodeStatusRunning, nil
+		}
+
 		nodeStatus.ClearSubNodeStatus()
-		nodeStatus.UpdatePhase(v1alpha1.NodePhaseTimedOut, v1.Now(), nodeStatus.GetMessage(), nodeStatus.GetExecutionError())
+		nodeStatus.UpdatePhase(v1alpha1.NodePhaseTimedOut, v1.NewTime(clocks.Now(ctx)), nodeStatus.GetMessage(), nodeStatus.GetExecutionError())
 		c.metrics.TimedOutFailure.Inc(ctx)
 		if nCtx.md.IsInterruptible() {
 			c.metrics.InterruptibleNodesTerminated.Inc(ctx)
 		}
-		return executors.NodeStatusTimedOut, nil
+		return executors.NodeStatusTimedOutErr(nodeStatus.GetExecutionError()), nil
 	}
 
 	if currentPhase == v1alpha1.NodePhaseSucceeding {
-		logger.Debugf(ctx, "node succeeding")
+		tracing.Tracef(ctx, "node succeeding")
 		if err := c.finalize(ctx, h, nCtx); err != nil {
 			return executors.NodeStatusUndefined, err
 		}
-		t := v1.Now()
+		t := v1.NewTime(clocks.Now(ctx))
 
 		started := nodeStatus.GetStartedAt()
 		if started == nil {
@@ -724,7 +942,7 @@ func (c *nodeExecutor) handleNode(ctx context.Context, dag executors.DAGStructur
 // The space search for the next node to execute is implemented like a DFS algorithm. handleDownstream visits all the nodes downstream from
 // the currentNode. Visit a node is the RecursiveNodeHandler. A visit may be partial, complete or may result in a failure.
 func (c *nodeExecutor) handleDownstream(ctx context.Context, execContext executors.ExecutionContext, dag executors.DAGStructure, nl executors.NodeLookup, currentNode v1alpha1.ExecutableNode) (executors.NodeStatus, error) {
-	logger.Debugf(ctx, "Handling downstream Nodes")
+	tracing.Tracef(ctx, "Handling downstream Nodes")
 	// This node is success. Handle all downstream nodes
 	downstreamNodes, err := dag.FromNode(currentNode.GetID())
 	if err != nil {
@@ -736,12 +954,19 @@ func (c *nodeExecutor) handleDownstream(ctx context.Context, execContext executo
 		}), nil
 	}
 	if len(downstreamNodes) == 0 {
-		logger.Debugf(ctx, "No downstream nodes found. Complete.")
+		tracing.Tracef(ctx, "No downstream nodes found. Complete.")
 		return executors.NodeStatusComplete, nil
 	}
 	// If any downstream node is failed, fail, all
 	// Else if all are success then success
 	// Else if any one is running then Downstream is still running
+	// Downstream nodes that are ready and independent of each other are evaluated concurrently up front, the remaining
+	// ones serially while traversing them.
+	evaluated, err := c.evaluateConcurrently(ctx, execContext, dag, nl, downstreamNodes)
+	if err != nil {
+		return executors.NodeStatusUndefined, err
+	}
+
 	allCompleted := true
 	partialNodeCompletion := false
 	onFailurePolicy := execContext.GetOnFailurePolicy()
@@ -756,13 +981,16 @@ func (c *nodeExecutor) handleDownstream(ctx context.Context, execContext executo
 			}), nil
 		}
 
-		state, err := c.RecursiveNodeHandler(ctx, execContext, dag, nl, downstreamNode)
-		if err != nil {
-			return executors.NodeStatusUndefined, err
+		state, ok := evaluated[downstreamNodeName]
+		if !ok {
+			state, err = c.RecursiveNodeHandler(ctx, execContext, dag, nl, downstreamNode)
+			if err != nil {
+				return executors.NodeStatusUndefined, err
+			}
 		}
 
 		if state.HasFailed() || state.HasTimedOut() {
-			logger.Debugf(ctx, "Some downstream node has failed. Failed: [%v]. TimedOut: [%v]. Error: [%s]", state.HasFailed(), state.HasTimedOut(), state.Err)
+			tracing.Tracef(ctx, "Some downstream node has failed. Failed: [%v]. TimedOut: [%v]. Error: [%s]", state.HasFailed(), state.HasTimedOut(), state.Err)
 			if onFailurePolicy == v1alpha1.WorkflowOnFailurePolicy(core.WorkflowMetadata_FAIL_AFTER_EXECUTABLE_NODES_COMPLETE) {
 				// If the failure policy allows other nodes to continue running, do not exit the loop,
 				// Keep track of the last failed state in the loop since it'll be the one to return.
@@ -784,11 +1012,14 @@ func (c *nodeExecutor) handleDownstream(ctx context.Context, execContext executo
 			// This implies that one of the downstream nodes has just succeeded and workflow is ready for propagation
 			// We do not propagate in current cycle to make it possible to store the state between transitions
 			partialNodeCompletion = true
+			// The inputs of the nodes that become ready are read ahead of the next cycle, once the evaluation of the
+			// nodes that may run concurrently completed.
+			c.inputPrefetcher.PrefetchDownstream(ctx, dag, nl, downstreamNodeName)
 		}
 	}
 
 	if allCompleted {
-		logger.Debugf(ctx, "All downstream nodes completed")
+		tracing.Tracef(ctx, "All downstream nodes completed")
 		return stateOnComplete, nil
 	}
 
@@ -835,6 +1066,13 @@ func canHandleNode(phase v1alpha1.NodePhase) bool {
 		phase == v1alpha1.NodePhaseDynamicRunning
 }
 
+// Returns whether the node counts towards the max parallelism of the execution, which limits the task and launch plan
+// nodes that run concurrently. Other nodes only drive the execution of their child nodes, which are counted themselves.
+func isParallelismLimited(node v1alpha1.ExecutableNode) bool {
+	return node.GetKind() == v1alpha1.NodeKindTask ||
+		(node.GetKind() == v1alpha1.NodeKindWorkflow && node.GetWorkflowNode() != nil && node.GetWorkflowNode().GetLaunchPlanRefID() != nil)
+}
+
 // IsMaxParallelismAchieved checks if we have already achieved max parallelism. It returns true, if the desired max parallelism
 // value is achieved, false otherwise
 // MaxParallelism is defined as the maximum number of TaskNodes and LaunchPlans (together) that can be executed concurrently
@@ -847,16 +1085,16 @@ func IsMaxParallelismAchieved(ctx context.Context, currentNode v1alpha1.Executab
 		return false
 	}
 
-	if currentNode.GetKind() == v1alpha1.NodeKindTask ||
-		(currentNode.GetKind() == v1alpha1.NodeKindWorkflow && currentNode.GetWorkflowNode() != nil && currentNode.GetWorkflowNode().GetLaunchPlanRefID() != nil) {
+	if isParallelismLimited(currentNode) {
 		// If we are queued, let us see if we can proceed within the node parallelism bounds
-		if execContext.CurrentParallelism() >= maxParallelism {
-			logger.Infof(ctx, "Maximum Parallelism for task/launch-plan nodes achieved [%d] >= Max [%d], Round will be short-circuited.", execContext.CurrentParallelism(), maxParallelism)
+		// The parallelism budgets of the parents of the node may be used up before the max parallelism of the execution.
+		if execContext.RemainingParallelism(maxParallelism) == 0 {
+			logger.Infof(ctx, "Maximum Parallelism for task/launch-plan nodes achieved, Current [%d], Max [%d], Round will be short-circuited.", execContext.CurrentParallelism(), maxParallelism)
 			return true
 		}
-		// We know that Propeller goes through each workflow in a single thread, thus every node is really processed
-		// sequentially. So, we can continue - now that we know we are under the parallelism limits and increment the
-		// parallelism if the node, enters a running state
+		// We can continue - now that we know we are under the parallelism limits and increment the parallelism if the
+		// node, enters a running state. Nodes that are evaluated concurrently reserve the parallelism while they're
+		// handled, see reserveParallelism.
 		logger.Debugf(ctx, "Parallelism criteria not met, Current [%d], Max [%d]", execContext.CurrentParallelism(), maxParallelism)
 	} else {
 		logger.Debugf(ctx, "NodeKind: %s in status [%s]. Parallelism control is not applicable. Current Parallelism [%d]",
@@ -865,6 +1103,32 @@ func IsMaxParallelismAchieved(ctx context.Context, currentNode v1alpha1.Executab
 	return false
 }
 
+// reserveParallelism reserves the parallelism for a task or launch plan node while it's handled, since nodes that are
+// evaluated concurrently could all pass IsMaxParallelismAchieved before any of them is counted. It returns false if the
+// max parallelism was achieved in the meantime, or the execution context to handle the node with and a function that
+// releases the reservation otherwise. The reservation becomes the parallelism of the node once the node is counted.
+// Dynamic nodes past their parent task don't reserve, since their sub nodes reserve the parallelism themselves.
+func reserveParallelism(ctx context.Context, currentNode v1alpha1.ExecutableNode, nodeStatus v1alpha1.ExecutableNodeStatus,
+	execContext executors.ExecutionContext) (reserved executors.ExecutionContext, release func(), ok bool) {
+	maxParallelism := execContext.GetExecutionConfig().MaxParallelism
+	if maxParallelism == 0 || !isParallelismLimited(currentNode) {
+		return execContext, func() {}, true
+	}
+
+	if dynamicNodeStatus := nodeStatus.GetDynamicNodeStatus(); dynamicNodeStatus != nil &&
+		dynamicNodeStatus.GetDynamicNodePhase() != v1alpha1.DynamicNodePhaseNone {
+		return execContext, func() {}, true
+	}
+
+	reserved, ok = executors.NewExecutionContextWithReservedParallelism(execContext)
+	if !ok {
+		logger.Infof(ctx, "Maximum Parallelism for task/launch-plan nodes reserved by concurrently evaluated nodes, Max [%d], Round will be short-circuited.", maxParallelism)
+		return nil, nil, false
+	}
+
+	return reserved, reserved.ReleaseParallelism, true
+}
+
 // RecursiveNodeHandler This is the entrypoint of executing a node in a workflow. A workflow consists of nodes, that are
 // nested within other nodes. The system follows an actor model, where the parent nodes control the execution of nested nodes
 // The recursive node-handler uses a modified depth-first type of algorithm to execute non-blocked nodes.
@@ -884,7 +1148,7 @@ func (c *nodeExecutor) RecursiveNodeHandler(ctx context.Context, execContext exe
 		// 4. The Downstream nodes handler will Resolve the Inputs
 		// 5. the method will delegate all other node handling to HandleNode.
 		// 6. Thus we can get rid of SetInputs for StartNode as well
-		logger.Debugf(currentNodeCtx, "Handling node Status [%v]", nodeStatus.GetPhase().String())
+		tracing.Tracef(currentNodeCtx, "Handling node Status [%v]", nodeStatus.GetPhase().String())
 
 		t := c.metrics.NodeExecutionTime.Start(ctx)
 		defer t.Stop()
@@ -899,7 +1163,18 @@ func (c *nodeExecutor) RecursiveNodeHandler(ctx context.Context, execContext exe
 			return executors.NodeStatusRunning, nil
 		}
 
-		nCtx, err := c.newNodeExecContextDefault(ctx, currentNode.GetID(), execContext, nl)
+		nodeExecContext, release, ok := reserveParallelism(ctx, currentNode, nodeStatus, execContext)
+		if !ok {
+			return executors.NodeStatusRunning, nil
+		}
+		defer release()
+
+		if roundbudget.IsExhausted(ctx) {
+			tracing.Tracef(currentNodeCtx, "Round budget exhausted, node left for the next round")
+			return executors.NodeStatusRunning, nil
+		}
+
+		nCtx, err := c.newNodeExecContextDefault(ctx, currentNode.GetID(), nodeExecContext, nl)
 		if err != nil {
 			// NodeExecution creation failure is a permanent fail / system error.
 			// Should a system failure always return an err?
@@ -922,10 +1197,10 @@ func (c *nodeExecutor) RecursiveNodeHandler(ctx context.Context, execContext exe
 		// Currently we treat either Skip or Success the same way. In this approach only one node will be skipped
 		// at a time. As we iterate down, further nodes will be skipped
 	} else if nodePhase == v1alpha1.NodePhaseSucceeded || nodePhase == v1alpha1.NodePhaseSkipped || nodePhase == v1alpha1.NodePhaseRecovered {
-		logger.Debugf(currentNodeCtx, "Node has [%v], traversing downstream.", nodePhase)
+		tracing.Tracef(currentNodeCtx, "Node has [%v], traversing downstream.", nodePhase)
 		return c.handleDownstream(ctx, execContext, dag, nl, currentNode)
 	} else if nodePhase == v1alpha1.NodePhaseFailed {
-		logger.Debugf(currentNodeCtx, "Node has failed, traversing downstream.")
+		tracing.Tracef(currentNodeCtx, "Node has failed, traversing downstream.")
 		_, err := c.handleDownstream(ctx, execContext, dag, nl, currentNode)
 		if err != nil {
 			return executors.NodeStatusUndefined, err
@@ -933,13 +1208,13 @@ func (c *nodeExecutor) RecursiveNodeHandler(ctx context.Context, execContext exe
 
 		return executors.NodeStatusFailed(nodeStatus.GetExecutionError()), nil
 	} else if nodePhase == v1alpha1.NodePhaseTimedOut {
-		logger.Debugf(currentNodeCtx, "Node has timed out, traversing downstream.")
+		tracing.Tracef(currentNodeCtx, "Node has timed out, traversing downstream.")
 		_, err := c.handleDownstream(ctx, execContext, dag, nl, currentNode)
 		if err != nil {
 			return executors.NodeStatusUndefined, err
 		}
 
-		return executors.NodeStatusTimedOut, nil
+		return executors.NodeStatusTimedOutErr(nodeStatus.GetExecutionError()), nil
 	}
 
 	return executors.NodeStatusUndefined, errors.Errorf(errors.IllegalStateError, currentNode.GetID(),
@@ -1006,6 +1281,33 @@ func (c *nodeExecutor) FinalizeHandler(ctx context.Context, execContext executor
 }
 
 func (c *nodeExecutor) AbortHandler(ctx context.Context, execContext executors.ExecutionContext, dag executors.DAGStructure, nl executors.NodeLookup, currentNode v1alpha1.ExecutableNode, reason string) error {
+	scope := executors.GetAbortScope(ctx)
+	if scope == nil {
+		// The abort of the top-level workflow, which is propagated to its sub workflows and child executions within the
+		// abort timeout.
+		ctx = executors.WithRootAbortScope(ctx)
+		if c.abortTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.abortTimeout)
+			defer cancel()
+		}
+	} else if scope.IsCyclic() {
+		logger.Warnf(ctx, "Sub workflow references itself, not propagating the abort to node [%s] again", currentNode.GetID())
+		c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomeCycle)
+		return nil
+	} else if c.maxAbortDepth > 0 && scope.Depth() > c.maxAbortDepth {
+		logger.Errorf(ctx, "Not propagating the abort to node [%s], sub workflows are nested deeper than [%d]", currentNode.GetID(), c.maxAbortDepth)
+		c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomeDepthExceeded)
+		return nil
+	}
+
+	aborted := newAbortedNodes()
+	aborted.add(currentNode.GetID())
+	return c.abortNode(ctx, execContext, dag, nl, currentNode, reason, aborted)
+}
+
+// Aborts the node if it's running, or the nodes downstream of it if it's complete.
+func (c *nodeExecutor) abortNode(ctx context.Context, execContext executors.ExecutionContext, dag executors.DAGStructure, nl executors.NodeLookup, currentNode v1alpha1.ExecutableNode, reason string, aborted *abortedNodes) error {
 	nodeStatus := nl.GetNodeExecutionStatus(ctx, currentNode.GetID())
 	nodePhase := nodeStatus.GetPhase()
 
@@ -1028,11 +1330,31 @@ func (c *nodeExecutor) AbortHandler(ctx context.Context, execContext executors.E
 		if err != nil {
 			return err
 		}
+
+		if ctx.Err() != nil {
+			c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomeTimedOut)
+			return errors.Wrapf(errors.AbortTimedOut, currentNode.GetID(), ctx.Err(), "abort timed out before the node was aborted")
+		}
+
 		// Abort this node
-		err = c.abort(ctx, h, nCtx, reason)
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		err = c.abort(abortCtx, h, nCtx, reason)
 		if err != nil {
+			if ctx.Err() != nil {
+				c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomeTimedOut)
+			} else {
+				c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomeFailed)
+			}
 			return err
 		}
+
+		// The node is aborted once the executions it terminated reached a terminal phase. The pending termination is
+		// also tracked by the caller, which repeats the abort on a later round.
+		if terminations.IsPending() {
+			c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomePending)
+			return nil
+		}
+		c.recordAbortOutcome(ctx, currentNode.GetID(), abortOutcomeAborted)
 		nodeExecutionID := &core.NodeExecutionIdentifier{
 			ExecutionId: nCtx.NodeExecutionMetadata().GetNodeExecutionID().ExecutionId,
 			NodeId:      nCtx.NodeExecutionMetadata().GetNodeExecutionID().NodeId,
@@ -1073,24 +1395,7 @@ func (c *nodeExecutor) AbortHandler(ctx context.Context, execContext executors.E
 			return nil
 		}
 
-		errs := make([]error, 0, len(downstreamNodes))
-		for _, d := range downstreamNodes {
-			downstreamNode, ok := nl.GetNode(d)
-			if !ok {
-				return errors.Errorf(errors.BadSpecificationError, currentNode.GetID(), "Unable to find Downstream Node [%v]", d)
-			}
-
-			if err := c.AbortHandler(ctx, execContext, dag, nl, downstreamNode, reason); err != nil {
-				logger.Infof(ctx, "Failed to abort node [%v]. Error: %v", d, err)
-				errs = append(errs, err)
-			}
-		}
-
-		if len(errs) > 0 {
-			return errors.ErrorCollection{Errors: errs}
-		}
-
-		return nil
+		return c.abortDownstreamNodes(ctx, execContext, dag, nl, currentNode.GetID(), downstreamNodes, reason, aborted)
 	} else {
 		ctx = contextutils.WithNodeID(ctx, currentNode.GetID())
 		logger.Warnf(ctx, "Trying to abort a node in state [%s]", nodeStatus.GetPhase().String())
@@ -1110,13 +1415,22 @@ func NewExecutor(ctx context.Context, nodeConfig config.NodeConfig, store *stora
 	defaultRawOutputPrefix storage.DataReference, kubeClient executors.Client,
 	catalogClient catalog.Client, recoveryClient recovery.Client, eventConfig *config.EventConfig, clusterID string, scope promutils.Scope) (executors.Node, error) {
 
-	// TODO we may want to make this configurable.
-	shardSelector, err := ioutils.NewBase36PrefixShardSelector(ctx)
+	shardSelector, err := newRawOutputShardSelector(ctx, nodeConfig.RawOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeOutcomes, err := outcomes.NewStore(ctx, config.GetConfig().NodeOutcomes, store, config.GetConfig().MetadataPrefix)
 	if err != nil {
 		return nil, err
 	}
 
 	nodeScope := scope.NewSubScope("node")
+	prefetcher := newInputPrefetcher(nodeConfig.InputPrefetch, store, nodeScope)
+	bindingPlans := newBindingPlanCache(defaultBindingPlanCacheSize, nodeConfig.Caches.BindingPlansMaxSizeBytes,
+		defaultBindingPlanTTL, nodeScope.NewSubScope("binding_plan_cache"))
+	taskTemplates := newTaskTemplateCache(store, defaultTaskTemplateCacheSize, nodeConfig.Caches.TaskTemplatesMaxSizeBytes,
+		defaultTaskTemplateTTL, nodeScope.NewSubScope("task_template_cache"))
 	exec := &nodeExecutor{
 		store:               store,
 		enqueueWorkflow:     enQWorkflow,
@@ -1136,6 +1450,7 @@ func NewExecutor(ctx context.Context, nodeConfig config.NodeConfig, store *stora
 			PermanentUnknownErrorDuration: labeled.NewStopWatch("perma_unknown_error_duration", "Indicates the total execution time before non recoverable unknown error", time.Millisecond, nodeScope, labeled.EmitUnlabeledMetric),
 			InputsWriteFailure:            labeled.NewCounter("inputs_write_fail", "Indicates failure in writing node inputs to metastore", nodeScope),
 			TimedOutFailure:               labeled.NewCounter("timeout_fail", "Indicates failure due to timeout", nodeScope),
+			HandlerPanic:                  labeled.NewCounter("handler_panic", "Indicates failure due to a panic in a node handler", nodeScope),
 			InterruptedThresholdHit:       labeled.NewCounter("interrupted_threshold", "Indicates the node interruptible disabled because it hit max failure count", nodeScope),
 			InterruptibleNodesRunning:     labeled.NewCounter("interruptible_nodes_running", "number of interruptible nodes running", nodeScope),
 			InterruptibleNodesTerminated:  labeled.NewCounter("interruptible_nodes_terminated", "number of interruptible nodes finished running", nodeScope),
@@ -1144,19 +1459,36 @@ func NewExecutor(ctx context.Context, nodeConfig config.NodeConfig, store *stora
 			QueuingLatency:                labeled.NewStopWatch("queueing_latency", "Measures the latency between the time a node's been queued to the time the handler reported the executable moved to running state", time.Millisecond, nodeScope, labeled.EmitUnlabeledMetric),
 			NodeExecutionTime:             labeled.NewStopWatch("node_exec_latency", "Measures the time taken to execute one node, a node can be complex so it may encompass sub-node latency.", time.Microsecond, nodeScope, labeled.EmitUnlabeledMetric),
 			NodeInputGatherLatency:        labeled.NewStopWatch("node_input_latency", "Measures the latency to aggregate inputs and check readiness of a node", time.Millisecond, nodeScope, labeled.EmitUnlabeledMetric),
+			RetryBackoffDelay:             labeled.NewStopWatch("retry_backoff_delay", "Measures the delays nodes back off for before retrying system failures", time.Millisecond, nodeScope, labeled.EmitUnlabeledMetric),
+			ReusedOutcomes:                labeled.NewCounter("reused_outcomes", "Number of nodes that reused the outputs of a previous execution", nodeScope),
+			AbortOutcomes:                 nodeScope.MustNewCounterVec("abort_outcomes", "Number of nodes an abort was propagated to, by the outcome of aborting them", abortOutcomeLabel),
 		},
-		outputResolver:                  NewRemoteFileOutputResolver(store),
+		outputResolver:                  remoteFileOutputResolver{store: store, prefetched: prefetcher},
+		inputPrefetcher:                 prefetcher,
+		bindingPlans:                    bindingPlans,
+		taskTemplates:                   taskTemplates,
 		defaultExecutionDeadline:        nodeConfig.DefaultDeadlines.DefaultNodeExecutionDeadline.Duration,
 		defaultActiveDeadline:           nodeConfig.DefaultDeadlines.DefaultNodeActiveDeadline.Duration,
 		maxNodeRetriesForSystemFailures: uint32(nodeConfig.MaxNodeRetriesOnSystemFailures),
+		defaultRetryPolicies:            toRetryPolicies(nodeConfig.DefaultRetryPolicies),
+		systemErrorCodes:                sets.NewString(nodeConfig.SystemErrorCodes...),
+		systemRetryBackoff:              nodeConfig.SystemRetryBackoff,
 		interruptibleFailureThreshold:   uint32(nodeConfig.InterruptibleFailureThreshold),
+		maxEvaluationParallelism:        nodeConfig.MaxEvaluationParallelism,
 		defaultDataSandbox:              defaultRawOutputPrefix,
 		shardSelector:                   shardSelector,
+		rawOutputPerExecution:           nodeConfig.RawOutput.PerExecutionDirectory,
 		recoveryClient:                  recoveryClient,
 		eventConfig:                     eventConfig,
 		clusterID:                       clusterID,
-	}
-	nodeHandlerFactory, err := NewHandlerFactory(ctx, exec, workflowLauncher, launchPlanReader, kubeClient, catalogClient, recoveryClient, eventConfig, clusterID, nodeScope)
+		nodeOutcomes:                    nodeOutcomes,
+		iamRoleAnnotation:               nodeConfig.Identity.IamRoleAnnotation,
+		phaseMetrics:                    phasemetrics.NewRecorder(config.GetConfig().PhaseMetrics, nodeScope),
+		maxAbortDepth:                   nodeConfig.Abort.MaxDepth,
+		abortParallelism:                nodeConfig.Abort.Parallelism,
+		abortTimeout:                    nodeConfig.Abort.Timeout.Duration,
+	}
+	nodeHandlerFactory, err := NewHandlerFactory(ctx, exec, workflowLauncher, launchPlanReader, kubeClient, catalogClient, recoveryClient, exec.nodeRecorder, eventConfig, clusterID, nodeScope)
 	exec.nodeHandlerFactory = nodeHandlerFactory
 	return exec, err
 }
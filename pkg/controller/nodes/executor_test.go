@@ -24,11 +24,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/flyteorg/flytepropeller/events"
 	eventsErr "github.com/flyteorg/flytepropeller/events/errors"
 	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 	mocks4 "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	nodeHandlerMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
@@ -42,6 +45,7 @@ import (
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	nodeErrors "github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
 	recoveryMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan"
 	flyteassert "github.com/flyteorg/flytepropeller/pkg/utils/assert"
@@ -579,11 +583,18 @@ func TestNodeExecutor_RecursiveNodeHandler_Recurse(t *testing.T) {
 			mockN2Status.OnGetWorkflowNodeStatus().Return(nil)
 
 			mockN2Status.OnGetStoppedAt().Return(nil)
-			var ee *core.ExecutionError
-			mockN2Status.On("UpdatePhase", expectedN2Phase, mock.Anything, mock.AnythingOfType("string"), ee)
+			mockN2Status.On("SetReasonCode", mock.AnythingOfType("string"))
+			if expectedN2Phase == v1alpha1.NodePhaseSkipped {
+				mockN2Status.On("UpdatePhase", expectedN2Phase, mock.Anything, mock.AnythingOfType("string"),
+					mock.MatchedBy(func(ee *core.ExecutionError) bool { return ee.GetCode() == handler.SkipReasonUpstreamSkipped }))
+			} else {
+				var ee *core.ExecutionError
+				mockN2Status.On("UpdatePhase", expectedN2Phase, mock.Anything, mock.AnythingOfType("string"), ee)
+			}
 			mockN2Status.OnIsDirty().Return(false)
 			mockN2Status.OnGetTaskNodeStatus().Return(nil)
 			mockN2Status.On("ClearDynamicNodeStatus").Return(nil)
+			mockN2Status.On("ClearArrayNodeStatus").Return(nil)
 			mockN2Status.OnGetAttempts().Return(uint32(0))
 			if expectedN2Phase == v1alpha1.NodePhaseFailed {
 				mockN2Status.OnGetExecutionError().Return(&core.ExecutionError{
@@ -627,6 +638,7 @@ func TestNodeExecutor_RecursiveNodeHandler_Recurse(t *testing.T) {
 
 			tk := &mocks.ExecutableTask{}
 			tk.OnCoreTask().Return(&core.TaskTemplate{})
+			tk.OnGetTemplateRef().Return("")
 			mockWfStatus := &mocks.ExecutableWorkflowStatus{}
 			mockWf := &mocks.ExecutableWorkflow{}
 			mockWf.OnStartNode().Return(mockNodeN0)
@@ -1278,6 +1290,7 @@ func TestNodeExecutor_RecursiveNodeHandler_BranchNode(t *testing.T) {
 
 				tk := &mocks.ExecutableTask{}
 				tk.OnCoreTask().Return(&core.TaskTemplate{})
+				tk.OnGetTemplateRef().Return("")
 
 				tid := "tid"
 				eCtx := &mocks4.ExecutionContext{}
@@ -1307,6 +1320,7 @@ func TestNodeExecutor_RecursiveNodeHandler_BranchNode(t *testing.T) {
 				branchTakeNodeStatus.OnGetPhase().Return(test.currentNodePhase)
 				branchTakeNodeStatus.OnIsDirty().Return(false)
 				branchTakeNodeStatus.OnGetSystemFailures().Return(1)
+				branchTakeNodeStatus.OnGetTimeoutFailures().Return(0)
 				branchTakeNodeStatus.OnGetDataDir().Return("data")
 				branchTakeNodeStatus.OnGetParentNodeID().Return(&parentBranchNodeID)
 				branchTakeNodeStatus.OnGetParentTaskID().Return(nil)
@@ -1316,6 +1330,7 @@ func TestNodeExecutor_RecursiveNodeHandler_BranchNode(t *testing.T) {
 				if test.phaseUpdateExpected {
 					var ee *core.ExecutionError
 					branchTakeNodeStatus.On("UpdatePhase", v1alpha1.NodePhaseQueued, mock.Anything, mock.Anything, ee).Return()
+					branchTakeNodeStatus.On("SetReasonCode", string(handler.ReasonCodeQueued)).Return()
 				}
 
 				leafDag := executors.NewLeafNodeDAGStructure(branchTakenNodeID, parentBranchNodeID)
@@ -1410,6 +1425,7 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 		retries           int
 		err               error
 		expectedReason    string
+		expectedErrCode   string
 	}{
 		{
 			name:              "timeout",
@@ -1418,6 +1434,8 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 			activeDeadline:    time.Second * 5,
 			executionDeadline: time.Second * 5,
 			err:               nil,
+			expectedReason:    "task active timeout [5s] expired",
+			expectedErrCode:   activeTimeoutExpiredErrorCode,
 		},
 		{
 			name:              "default_execution_timeout",
@@ -1428,6 +1446,7 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 			retries:           2,
 			err:               nil,
 			expectedReason:    "task execution timeout [1s] expired",
+			expectedErrCode:   timeoutExpiredErrorCode,
 		},
 		{
 			name:              "retryable-failure",
@@ -1437,6 +1456,7 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 			executionDeadline: time.Second * 5,
 			retries:           2,
 			err:               nil,
+			expectedErrCode:   timeoutExpiredErrorCode,
 		},
 		{
 			name:              "retries-exhausted",
@@ -1446,6 +1466,7 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 			executionDeadline: time.Second * 5,
 			retries:           1,
 			err:               nil,
+			expectedErrCode:   "RetriesExhausted|" + timeoutExpiredErrorCode,
 		},
 		{
 			name:              "expired-but-terminal-phase",
@@ -1480,6 +1501,7 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 	ns.On("GetLastAttemptStartedAt").Return(queuedAtTime)
 	ns.OnGetAttempts().Return(0)
 	ns.OnGetSystemFailures().Return(0)
+	ns.OnGetTimeoutFailures().Return(0)
 	ns.On("ClearLastAttemptStartedAt").Return()
 
 	for _, tt := range tests {
@@ -1523,10 +1545,103 @@ func Test_nodeExecutor_timeout(t *testing.T) {
 			if tt.expectedReason != "" {
 				assert.Equal(t, tt.expectedReason, phaseInfo.GetReason())
 			}
+			if tt.expectedErrCode != "" {
+				assert.Equal(t, tt.expectedErrCode, phaseInfo.GetErr().GetCode())
+			}
 		})
 	}
 }
 
+func Test_nodeExecutor_executionDeadlineExcludesQueueing(t *testing.T) {
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	ctx := clocks.WithClock(context.TODO(), fakeClock)
+
+	// The node was queued 20s ago and only started running 2s ago.
+	ns := &mocks.ExecutableNodeStatus{}
+	ns.On("GetQueuedAt").Return(&v1.Time{Time: now.Add(-20 * time.Second)})
+	ns.On("GetLastAttemptStartedAt").Return(&v1.Time{Time: now.Add(-2 * time.Second)})
+	ns.OnGetAttempts().Return(0)
+	ns.OnGetSystemFailures().Return(0)
+	ns.OnGetTimeoutFailures().Return(0)
+	ns.On("ClearLastAttemptStartedAt").Return()
+
+	activeDeadline := time.Minute
+	executionDeadline := time.Second * 5
+	retries := 2
+	mockNode := &mocks.ExecutableNode{}
+	mockNode.On("GetID").Return("node")
+	mockNode.On("GetKind").Return(v1alpha1.NodeKindTask)
+	mockNode.On("GetActiveDeadline").Return(&activeDeadline)
+	mockNode.On("GetExecutionDeadline").Return(&executionDeadline)
+	mockNode.OnGetRetryStrategy().Return(&v1alpha1.RetryStrategy{MinAttempts: &retries})
+
+	h := &nodeHandlerMocks.Node{}
+	h.On("Handle", mock.Anything, mock.Anything).Return(
+		handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil)
+
+	c := &nodeExecutor{}
+	nCtx := &nodeExecContext{node: mockNode, nsm: &nodeStateManager{nodeStatus: ns}}
+
+	phaseInfo, err := c.execute(ctx, h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseRunning.String(), phaseInfo.GetPhase().String())
+
+	fakeClock.Step(time.Second * 4)
+	phaseInfo, err = c.execute(ctx, h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseRetryableFailure.String(), phaseInfo.GetPhase().String())
+	assert.Equal(t, timeoutExpiredErrorCode, phaseInfo.GetErr().GetCode())
+
+	fakeClock.Step(time.Minute)
+	phaseInfo, err = c.execute(ctx, h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseTimedout.String(), phaseInfo.GetPhase().String())
+	assert.Equal(t, activeTimeoutExpiredErrorCode, phaseInfo.GetErr().GetCode())
+}
+
+func Test_nodeExecutor_timeoutFakeClock(t *testing.T) {
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	ctx := clocks.WithClock(context.TODO(), fakeClock)
+
+	ns := &mocks.ExecutableNodeStatus{}
+	ns.On("GetQueuedAt").Return(&v1.Time{Time: now})
+	ns.On("GetLastAttemptStartedAt").Return(&v1.Time{Time: now})
+	ns.OnGetAttempts().Return(0)
+	ns.OnGetSystemFailures().Return(0)
+	ns.OnGetTimeoutFailures().Return(0)
+
+	activeDeadline := time.Second * 5
+	executionDeadline := time.Second * 10
+	mockNode := &mocks.ExecutableNode{}
+	mockNode.On("GetID").Return("node")
+	mockNode.On("GetKind").Return(v1alpha1.NodeKindTask)
+	mockNode.On("GetActiveDeadline").Return(&activeDeadline)
+	mockNode.On("GetExecutionDeadline").Return(&executionDeadline)
+
+	h := &nodeHandlerMocks.Node{}
+	h.On("Handle", mock.Anything, mock.Anything).Return(
+		handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil)
+
+	c := &nodeExecutor{}
+	nCtx := &nodeExecContext{node: mockNode, nsm: &nodeStateManager{nodeStatus: ns}}
+
+	phaseInfo, err := c.execute(ctx, h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseRunning.String(), phaseInfo.GetPhase().String())
+
+	fakeClock.Step(activeDeadline)
+	phaseInfo, err = c.execute(ctx, h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseRunning.String(), phaseInfo.GetPhase().String())
+
+	fakeClock.Step(time.Second)
+	phaseInfo, err = c.execute(ctx, h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseTimedout.String(), phaseInfo.GetPhase().String())
+}
+
 func Test_nodeExecutor_system_error(t *testing.T) {
 	phaseInfo := handler.PhaseInfoRetryableFailureErr(&core.ExecutionError{Code: "Interrupted", Message: "test", Kind: core.ExecutionError_SYSTEM}, nil)
 
@@ -1534,6 +1649,7 @@ func Test_nodeExecutor_system_error(t *testing.T) {
 	ns := &mocks.ExecutableNodeStatus{}
 	ns.OnGetAttempts().Return(0)
 	ns.OnGetSystemFailures().Return(0)
+	ns.OnGetTimeoutFailures().Return(0)
 	ns.On("GetQueuedAt").Return(&v1.Time{Time: time.Now()})
 	ns.On("GetLastAttemptStartedAt").Return(&v1.Time{Time: time.Now()})
 
@@ -1568,6 +1684,74 @@ func Test_nodeExecutor_system_error(t *testing.T) {
 	assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.GetErr().GetKind())
 }
 
+func Test_nodeExecutor_systemErrorCodes(t *testing.T) {
+	phaseInfo := handler.PhaseInfoRetryableFailure(core.ExecutionError_USER, "ImagePullBackOff", "test", nil)
+	h := &nodeHandlerMocks.Node{}
+	h.OnHandleMatch(mock.Anything, mock.Anything).Return(handler.DoTransition(handler.TransitionTypeEphemeral, phaseInfo), nil)
+
+	retries := 1
+	mockNode := &mocks.ExecutableNode{}
+	mockNode.On("GetID").Return("node")
+	mockNode.On("GetActiveDeadline").Return(nil)
+	mockNode.On("GetExecutionDeadline").Return(nil)
+	mockNode.OnGetRetryStrategy().Return(&v1alpha1.RetryStrategy{MinAttempts: &retries})
+
+	c := &nodeExecutor{maxNodeRetriesForSystemFailures: 2, systemErrorCodes: sets.NewString("ImagePullBackOff")}
+	for _, tt := range []struct {
+		name           string
+		systemFailures uint32
+		expectedPhase  handler.EPhase
+		expectedCode   string
+	}{
+		{"system-retry", 1, handler.EPhaseRetryableFailure, "ImagePullBackOff"},
+		{"system-retries-exhausted", 2, handler.EPhaseFailed, "RetriesExhausted|ImagePullBackOff"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &mocks.ExecutableNodeStatus{}
+			ns.OnGetAttempts().Return(tt.systemFailures)
+			ns.OnGetSystemFailures().Return(tt.systemFailures)
+			ns.OnGetTimeoutFailures().Return(0)
+			ns.On("GetQueuedAt").Return(&v1.Time{Time: time.Now()})
+			ns.On("GetLastAttemptStartedAt").Return(&v1.Time{Time: time.Now()})
+			ns.On("ClearLastAttemptStartedAt").Return()
+
+			nCtx := &nodeExecContext{node: mockNode, nsm: &nodeStateManager{nodeStatus: ns}}
+			p, err := c.execute(context.TODO(), h, nCtx, ns)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPhase, p.GetPhase())
+			assert.Equal(t, core.ExecutionError_SYSTEM, p.GetErr().GetKind())
+			assert.Equal(t, tt.expectedCode, p.GetErr().GetCode())
+		})
+	}
+}
+
+func Test_nodeExecutor_handler_panic(t *testing.T) {
+	ns := &mocks.ExecutableNodeStatus{}
+	ns.On("GetQueuedAt").Return(&v1.Time{Time: time.Now()})
+	ns.On("GetLastAttemptStartedAt").Return(&v1.Time{Time: time.Now()})
+
+	c := &nodeExecutor{
+		metrics: &nodeMetrics{
+			HandlerPanic: labeled.NewCounter("handler_panic", "", promutils.NewTestScope()),
+		},
+	}
+	h := &nodeHandlerMocks.Node{}
+	h.OnHandleMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		panic("handler panic")
+	})
+
+	mockNode := &mocks.ExecutableNode{}
+	mockNode.OnGetID().Return("node")
+
+	nCtx := &nodeExecContext{node: mockNode, nsm: &nodeStateManager{nodeStatus: ns}}
+	phaseInfo, err := c.execute(context.TODO(), h, nCtx, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.EPhaseFailed, phaseInfo.GetPhase())
+	assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.GetErr().GetKind())
+	assert.Equal(t, nodeErrors.HandlerPanic, phaseInfo.GetErr().GetCode())
+	assert.Contains(t, phaseInfo.GetErr().GetMessage(), "handler panic")
+}
+
 func Test_nodeExecutor_abort(t *testing.T) {
 	ctx := context.Background()
 	exec := nodeExecutor{}
@@ -1601,6 +1785,31 @@ func Test_nodeExecutor_abort(t *testing.T) {
 		assert.True(t, called)
 	})
 
+	t.Run("abort panic calls finalize", func(t *testing.T) {
+		exec := nodeExecutor{
+			metrics: &nodeMetrics{
+				HandlerPanic: labeled.NewCounter("abort_panic", "", promutils.NewTestScope()),
+			},
+		}
+		mockNode := &mocks.ExecutableNode{}
+		mockNode.OnGetID().Return("node")
+		nCtx := &nodeExecContext{node: mockNode}
+
+		h := &nodeHandlerMocks.Node{}
+		h.OnAbortMatch(mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			panic("abort panic")
+		})
+		var called bool
+		h.OnFinalizeMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			called = true
+		}).Return(nil)
+
+		err := exec.abort(ctx, h, nCtx, "testing")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "abort panic")
+		assert.True(t, called)
+	})
+
 	t.Run("abort calls finalize when no errors", func(t *testing.T) {
 		h := &nodeHandlerMocks.Node{}
 		h.OnAbortMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -1665,12 +1874,54 @@ func TestNodeExecutor_AbortHandler(t *testing.T) {
 		execContext.OnIsInterruptible().Return(false)
 		r := v1alpha1.RawOutputDataConfig{}
 		execContext.OnGetRawOutputDataConfig().Return(r)
+		execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{})
 		execContext.OnGetExecutionID().Return(v1alpha1.WorkflowExecutionIdentifier{})
 		execContext.OnGetLabels().Return(nil)
 		execContext.OnGetEventVersion().Return(v1alpha1.EventVersion0)
 
 		assert.NoError(t, nExec.AbortHandler(ctx, &execContext, &dag, nl, n, "aborting"))
 	})
+	t.Run("termination-pending", func(t *testing.T) {
+		id := "id"
+		n := &mocks.ExecutableNode{}
+		n.OnGetID().Return(id)
+		n.OnGetKind().Return(v1alpha1.NodeKindStart)
+		n.OnGetTaskID().Return(&id)
+		interruptible := false
+		n.OnIsInterruptible().Return(&interruptible)
+		nl := &mocks4.NodeLookup{}
+		ns := &mocks.ExecutableNodeStatus{}
+		ns.OnGetPhase().Return(v1alpha1.NodePhaseRunning)
+		ns.OnGetDataDir().Return(storage.DataReference("s3:/foo"))
+		nl.OnGetNodeExecutionStatusMatch(mock.Anything, id).Return(ns)
+		nl.OnGetNode(id).Return(n, true)
+
+		hf := &mocks2.HandlerFactory{}
+		h := &nodeHandlerMocks.Node{}
+		h.OnAbortMatch(mock.Anything, mock.Anything, "aborting").Run(func(args mock.Arguments) {
+			executors.SetTerminationPending(args.Get(0).(context.Context))
+		}).Return(nil)
+		h.OnFinalizeMatch(mock.Anything, mock.Anything).Return(nil)
+		hf.OnGetHandlerMatch(v1alpha1.NodeKindStart).Return(h, nil)
+
+		// The node isn't aborted yet, so no event is recorded.
+		nExec := nodeExecutor{
+			nodeRecorder:       fakeNodeEventRecorder{&eventsErr.EventError{Code: eventsErr.ResourceExhausted, Cause: fmt.Errorf("err")}},
+			nodeHandlerFactory: hf,
+		}
+
+		execContext := mocks4.ExecutionContext{}
+		execContext.OnIsInterruptible().Return(false)
+		execContext.OnGetRawOutputDataConfig().Return(v1alpha1.RawOutputDataConfig{})
+		execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{})
+		execContext.OnGetExecutionID().Return(v1alpha1.WorkflowExecutionIdentifier{})
+		execContext.OnGetLabels().Return(nil)
+		execContext.OnGetEventVersion().Return(v1alpha1.EventVersion0)
+
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		assert.NoError(t, nExec.AbortHandler(abortCtx, &execContext, &mocks4.DAGStructure{}, nl, n, "aborting"))
+		assert.True(t, terminations.IsPending())
+	})
 }
 
 func TestNodeExecutor_FinalizeHandler(t *testing.T) {
@@ -1942,6 +2193,68 @@ func TestNodeExecutor_RecursiveNodeHandler_ParallelismLimit(t *testing.T) {
 		assert.Equal(t, s.NodePhase.String(), executors.NodePhaseRunning.String())
 	})
 
+	t.Run("parallelism-reservation-counted-once", func(t *testing.T) {
+		mockWf, mockNode, _ := createSingleNodeWf(v1alpha1.NodePhaseQueued, 2)
+		cf := executors.InitializeControlFlow()
+		eCtx := executors.NewExecutionContext(mockWf, mockWf, nil, nil, cf)
+
+		hf := &mocks2.HandlerFactory{}
+		exec.nodeHandlerFactory = hf
+		h := &nodeHandlerMocks.Node{}
+		remaining := uint32(0)
+		h.OnHandleMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			nCtx := args.Get(1).(handler.NodeExecutionContext)
+			nCtx.ExecutionContext().IncrementParallelism()
+			remaining = nCtx.ExecutionContext().RemainingParallelism(2)
+		}).Return(handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil)
+		h.OnFinalizeRequired().Return(false)
+
+		hf.OnGetHandler(v1alpha1.NodeKindTask).Return(h, nil)
+
+		s, err := exec.RecursiveNodeHandler(ctx, eCtx, mockWf, mockWf, mockNode)
+		assert.NoError(t, err)
+		assert.Equal(t, s.NodePhase.String(), executors.NodePhaseRunning.String())
+		// The reservation of the node became its parallelism once it was counted.
+		assert.Equal(t, uint32(1), remaining)
+		assert.Equal(t, uint32(1), cf.CurrentParallelism())
+		assert.Equal(t, uint32(1), cf.RemainingParallelism(2))
+	})
+
+	t.Run("parallelism-dynamic-node", func(t *testing.T) {
+		mockWf, mockNode, ns := createSingleNodeWf(v1alpha1.NodePhaseRunning, 1)
+		ns.(*v1alpha1.NodeStatus).DynamicNodeStatus = &v1alpha1.DynamicNodeStatus{Phase: v1alpha1.DynamicNodePhaseExecuting}
+		cf := executors.InitializeControlFlow()
+		eCtx := executors.NewExecutionContext(mockWf, mockWf, nil, nil, cf)
+
+		hf := &mocks2.HandlerFactory{}
+		exec.nodeHandlerFactory = hf
+		h := &nodeHandlerMocks.Node{}
+		subNodeStarted := false
+		// Like the dynamic handler, start a sub node within a budget of the parallelism of the dynamic node.
+		h.OnHandleMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			nCtx := args.Get(1).(handler.NodeExecutionContext)
+			subNodeCtx := executors.NewExecutionContextWithParallelismBudget(nCtx.ExecutionContext(), nil, 0.5)
+			if IsMaxParallelismAchieved(ctx, mockNode, v1alpha1.NodePhaseQueued, subNodeCtx) {
+				return
+			}
+
+			if reserved, ok := executors.NewExecutionContextWithReservedParallelism(subNodeCtx); ok {
+				reserved.IncrementParallelism()
+				subNodeStarted = true
+			}
+		}).Return(handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil)
+		h.OnFinalizeRequired().Return(false)
+
+		hf.OnGetHandler(v1alpha1.NodeKindTask).Return(h, nil)
+
+		s, err := exec.RecursiveNodeHandler(ctx, eCtx, mockWf, mockWf, mockNode)
+		assert.NoError(t, err)
+		assert.Equal(t, s.NodePhase.String(), executors.NodePhaseRunning.String())
+		assert.True(t, subNodeStarted)
+		assert.Equal(t, uint32(1), cf.CurrentParallelism())
+		assert.Equal(t, uint32(0), cf.RemainingParallelism(1))
+	})
+
 	t.Run("parallelism-disabled", func(t *testing.T) {
 		mockWf, mockNode, _ := createSingleNodeWf(v1alpha1.NodePhaseQueued, 0)
 		cf := executors.InitializeControlFlow()
@@ -2304,7 +2617,7 @@ func TestRecover(t *testing.T) {
 			return reference.String() == inputsPath || reference.String() == outputsPath
 		}), mock.Anything,
 			mock.Anything).Return(nil)
-		mockPBStore.On("ReadProtobuf", mock.Anything, storage.DataReference("inputuri"), &core.LiteralMap{}).Return(nil)
+		mockPBStore.On("CopyRaw", mock.Anything, storage.DataReference("inputuri"), storage.DataReference(inputsPath), mock.Anything).Return(nil)
 
 		storageClient := &storage.DataStore{
 			ComposedProtobufStore: mockPBStore,
@@ -2320,7 +2633,8 @@ func TestRecover(t *testing.T) {
 		phaseInfo, err := executor.attemptRecovery(context.TODO(), nCtx)
 		assert.NoError(t, err)
 		assert.Equal(t, phaseInfo.GetPhase(), handler.EPhaseRecovered)
-		mockPBStore.AssertNumberOfCalls(t, "ReadProtobuf", 1)
+		mockPBStore.AssertNumberOfCalls(t, "CopyRaw", 1)
+		mockPBStore.AssertNotCalled(t, "ReadProtobuf", mock.Anything, mock.Anything, mock.Anything)
 	})
 	t.Run("Fetch outputs", func(t *testing.T) {
 		recoveryClient := &recoveryMocks.RecoveryClient{}
@@ -2344,7 +2658,7 @@ func TestRecover(t *testing.T) {
 			return reference.String() == inputsPath || reference.String() == outputsPath
 		}), mock.Anything,
 			mock.Anything).Return(nil)
-		mockPBStore.On("ReadProtobuf", mock.Anything, storage.DataReference("outputuri.pb"), &core.LiteralMap{}).Return(nil)
+		mockPBStore.On("CopyRaw", mock.Anything, storage.DataReference("outputuri.pb"), storage.DataReference(outputsPath), mock.Anything).Return(nil)
 
 		storageClient := &storage.DataStore{
 			ComposedProtobufStore: mockPBStore,
@@ -2360,7 +2674,8 @@ func TestRecover(t *testing.T) {
 		phaseInfo, err := executor.attemptRecovery(context.TODO(), nCtx)
 		assert.NoError(t, err)
 		assert.Equal(t, phaseInfo.GetPhase(), handler.EPhaseRecovered)
-		mockPBStore.AssertNumberOfCalls(t, "ReadProtobuf", 1)
+		mockPBStore.AssertNumberOfCalls(t, "CopyRaw", 1)
+		mockPBStore.AssertNotCalled(t, "WriteProtobuf", mock.Anything, storage.DataReference(outputsPath), mock.Anything, mock.Anything)
 	})
 }
 
@@ -2373,6 +2688,11 @@ func TestIsMaxParallelismAchieved(t *testing.T) {
 			MaxParallelism: maxParallelism,
 		})
 		m.OnCurrentParallelism().Return(currentParallelism)
+		remaining := uint32(0)
+		if currentParallelism < maxParallelism {
+			remaining = maxParallelism - currentParallelism
+		}
+		m.OnRemainingParallelism(maxParallelism).Return(remaining)
 		return m
 	}
 
@@ -2425,6 +2745,26 @@ func TestIsMaxParallelismAchieved(t *testing.T) {
 	}
 }
 
+func TestIsParallelismLimited(t *testing.T) {
+	lpNode := &mocks.ExecutableWorkflowNode{}
+	lpNode.OnGetLaunchPlanRefID().Return(&v1alpha1.LaunchPlanRefID{})
+	subWorkflowNode := &mocks.ExecutableWorkflowNode{}
+	subWorkflowNode.OnGetLaunchPlanRefID().Return(nil)
+
+	createNode := func(kind v1alpha1.NodeKind, wn v1alpha1.ExecutableWorkflowNode) v1alpha1.ExecutableNode {
+		n := &mocks.ExecutableNode{}
+		n.OnGetKind().Return(kind)
+		n.OnGetWorkflowNode().Return(wn)
+		return n
+	}
+
+	assert.True(t, isParallelismLimited(createNode(v1alpha1.NodeKindTask, nil)))
+	assert.True(t, isParallelismLimited(createNode(v1alpha1.NodeKindWorkflow, lpNode)))
+	assert.False(t, isParallelismLimited(createNode(v1alpha1.NodeKindWorkflow, subWorkflowNode)))
+	assert.False(t, isParallelismLimited(createNode(v1alpha1.NodeKindBranch, nil)))
+	assert.False(t, isParallelismLimited(createNode(v1alpha1.NodeKindStart, nil)))
+}
+
 func init() {
 	labeled.SetMetricKeys(contextutils.ProjectKey, contextutils.DomainKey, contextutils.WorkflowIDKey, contextutils.TaskIDKey)
 }
@@ -0,0 +1,117 @@
+package nodes
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+)
+
+// Well known input variable names that are populated with the error context, when declared (and left unbound) in the
+// interface of a failure node.
+const (
+	// FailureNodeInputError is bound to a core.Error scalar with the failed node id and the error message.
+	FailureNodeInputError          = "err"
+	FailureNodeInputFailedNodeID   = "failed_node_id"
+	FailureNodeInputErrorCode      = "error_code"
+	FailureNodeInputErrorMessage   = "error_message"
+	FailureNodeInputErrorKind      = "error_kind"
+	FailureNodeInputErrorTimestamp = "error_timestamp"
+)
+
+func makeErrorContextLiterals(errCtx executors.ErrorContext) (map[string]*core.Literal, error) {
+	execErr := errCtx.Error
+	if execErr == nil {
+		execErr = &core.ExecutionError{}
+	}
+
+	literals := map[string]*core.Literal{
+		FailureNodeInputError: {
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_Error{
+						Error: &core.Error{
+							FailedNodeId: errCtx.FailedNodeID,
+							Message:      execErr.GetMessage(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	primitives := map[string]interface{}{
+		FailureNodeInputFailedNodeID:   errCtx.FailedNodeID,
+		FailureNodeInputErrorCode:      execErr.GetCode(),
+		FailureNodeInputErrorMessage:   execErr.GetMessage(),
+		FailureNodeInputErrorKind:      execErr.GetKind().String(),
+		FailureNodeInputErrorTimestamp: errCtx.OccurredAt,
+	}
+
+	for name, v := range primitives {
+		l, err := coreutils.MakePrimitiveLiteral(v)
+		if err != nil {
+			return nil, err
+		}
+
+		literals[name] = l
+	}
+
+	return literals, nil
+}
+
+// addErrorContextInputs populates the well known error context inputs for a failure node. Only the variables that are
+// declared in the interface of the failure node's task and have not been explicitly bound are populated.
+func addErrorContextInputs(ctx context.Context, nCtx handler.NodeExecutionContext, errCtx executors.ErrorContext,
+	inputs *core.LiteralMap) error {
+
+	literals, err := makeErrorContextLiterals(errCtx)
+	if err != nil {
+		return err
+	}
+
+	return addDeclaredInputs(ctx, nCtx, literals, inputs)
+}
+
+// addDeclaredInputs adds the literals to the inputs of a task node, for the variables that are declared in the interface
+// of its task and have not been explicitly bound.
+func addDeclaredInputs(ctx context.Context, nCtx handler.NodeExecutionContext, literals map[string]*core.Literal,
+	inputs *core.LiteralMap) error {
+
+	if nCtx.TaskReader() == nil {
+		logger.Debugf(ctx, "Node [%s] is not a task node, well known inputs will not be passed to it.", nCtx.NodeID())
+		return nil
+	}
+
+	tk, err := nCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	declared := tk.GetInterface().GetInputs().GetVariables()
+	if len(declared) == 0 {
+		return nil
+	}
+
+	if inputs.Literals == nil {
+		inputs.Literals = make(map[string]*core.Literal, len(literals))
+	}
+
+	for name, l := range literals {
+		if _, isDeclared := declared[name]; !isDeclared {
+			continue
+		}
+
+		if _, isBound := inputs.Literals[name]; isBound {
+			continue
+		}
+
+		inputs.Literals[name] = l
+	}
+
+	return nil
+}
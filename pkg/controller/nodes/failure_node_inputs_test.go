@@ -0,0 +1,84 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+)
+
+func TestAddErrorContextInputs(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	errCtx := executors.ErrorContext{
+		FailedNodeID: "n1",
+		Error:        &core.ExecutionError{Code: "OOM", Message: "out of memory", Kind: core.ExecutionError_USER},
+		OccurredAt:   now,
+	}
+
+	tk := &core.TaskTemplate{
+		Interface: &core.TypedInterface{
+			Inputs: &core.VariableMap{
+				Variables: map[string]*core.Variable{
+					FailureNodeInputError:        {},
+					FailureNodeInputFailedNodeID: {},
+					FailureNodeInputErrorCode:    {},
+					"x":                          {},
+				},
+			},
+		},
+	}
+
+	t.Run("task-node", func(t *testing.T) {
+		tr := &mocks.TaskReader{}
+		tr.OnRead(ctx).Return(tk, nil)
+		nCtx := &mocks.NodeExecutionContext{}
+		nCtx.OnTaskReader().Return(tr)
+		nCtx.OnNodeID().Return("failure-node")
+
+		inputs := coreutils.MustMakeLiteral(map[string]interface{}{
+			"x":                       1,
+			FailureNodeInputErrorCode: "overridden",
+		}).GetMap()
+
+		assert.NoError(t, addErrorContextInputs(ctx, nCtx, errCtx, inputs))
+		assert.Len(t, inputs.Literals, 4)
+		assert.Equal(t, "n1", inputs.Literals[FailureNodeInputError].GetScalar().GetError().GetFailedNodeId())
+		assert.Equal(t, "out of memory", inputs.Literals[FailureNodeInputError].GetScalar().GetError().GetMessage())
+		assert.Equal(t, "n1", inputs.Literals[FailureNodeInputFailedNodeID].GetScalar().GetPrimitive().GetStringValue())
+		// Explicit bindings take precedence over the error context
+		assert.Equal(t, "overridden", inputs.Literals[FailureNodeInputErrorCode].GetScalar().GetPrimitive().GetStringValue())
+		_, ok := inputs.Literals[FailureNodeInputErrorMessage]
+		assert.False(t, ok)
+	})
+
+	t.Run("non-task-node", func(t *testing.T) {
+		nCtx := &mocks.NodeExecutionContext{}
+		nCtx.OnTaskReader().Return(nil)
+		nCtx.OnNodeID().Return("failure-node")
+
+		inputs := &core.LiteralMap{}
+		assert.NoError(t, addErrorContextInputs(ctx, nCtx, errCtx, inputs))
+		assert.Empty(t, inputs.Literals)
+	})
+}
+
+func TestMakeErrorContextLiterals(t *testing.T) {
+	now := time.Now()
+	literals, err := makeErrorContextLiterals(executors.ErrorContext{
+		FailedNodeID: "n1",
+		Error:        &core.ExecutionError{Code: "OOM", Message: "out of memory", Kind: core.ExecutionError_SYSTEM},
+		OccurredAt:   now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "OOM", literals[FailureNodeInputErrorCode].GetScalar().GetPrimitive().GetStringValue())
+	assert.Equal(t, "out of memory", literals[FailureNodeInputErrorMessage].GetScalar().GetPrimitive().GetStringValue())
+	assert.Equal(t, core.ExecutionError_SYSTEM.String(), literals[FailureNodeInputErrorKind].GetScalar().GetPrimitive().GetStringValue())
+	assert.Equal(t, now.Unix(), literals[FailureNodeInputErrorTimestamp].GetScalar().GetPrimitive().GetDatetime().GetSeconds())
+}
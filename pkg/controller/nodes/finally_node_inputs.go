@@ -0,0 +1,53 @@
+package nodes
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+)
+
+// Well known input variable names that are populated with the terminal status of the workflow, when declared (and left
+// unbound) in the interface of a finally node.
+const (
+	// FinallyNodeInputWorkflowPhase is bound to the terminal phase of the workflow, i.e. Succeeded, Failed or Aborted.
+	FinallyNodeInputWorkflowPhase        = "workflow_phase"
+	FinallyNodeInputWorkflowErrorCode    = "workflow_error_code"
+	FinallyNodeInputWorkflowErrorMessage = "workflow_error_message"
+)
+
+func makeTerminalStatusLiterals(status executors.TerminalStatus) (map[string]*core.Literal, error) {
+	primitives := map[string]interface{}{
+		FinallyNodeInputWorkflowPhase:        status.Phase.String(),
+		FinallyNodeInputWorkflowErrorCode:    status.Error.GetCode(),
+		FinallyNodeInputWorkflowErrorMessage: status.Error.GetMessage(),
+	}
+
+	literals := make(map[string]*core.Literal, len(primitives))
+	for name, v := range primitives {
+		l, err := coreutils.MakePrimitiveLiteral(v)
+		if err != nil {
+			return nil, err
+		}
+
+		literals[name] = l
+	}
+
+	return literals, nil
+}
+
+// addTerminalStatusInputs populates the well known terminal status inputs for a finally node. Only the variables that
+// are declared in the interface of the finally node's task and have not been explicitly bound are populated.
+func addTerminalStatusInputs(ctx context.Context, nCtx handler.NodeExecutionContext, status executors.TerminalStatus,
+	inputs *core.LiteralMap) error {
+
+	literals, err := makeTerminalStatusLiterals(status)
+	if err != nil {
+		return err
+	}
+
+	return addDeclaredInputs(ctx, nCtx, literals, inputs)
+}
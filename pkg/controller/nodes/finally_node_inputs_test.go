@@ -0,0 +1,71 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+)
+
+func TestAddTerminalStatusInputs(t *testing.T) {
+	ctx := context.TODO()
+	status := executors.TerminalStatus{
+		Phase: v1alpha1.WorkflowPhaseFailed,
+		Error: &core.ExecutionError{Code: "OOM", Message: "out of memory", Kind: core.ExecutionError_USER},
+	}
+
+	tk := &core.TaskTemplate{
+		Interface: &core.TypedInterface{
+			Inputs: &core.VariableMap{
+				Variables: map[string]*core.Variable{
+					FinallyNodeInputWorkflowPhase:     {},
+					FinallyNodeInputWorkflowErrorCode: {},
+					"x":                               {},
+				},
+			},
+		},
+	}
+
+	t.Run("task-node", func(t *testing.T) {
+		tr := &mocks.TaskReader{}
+		tr.OnRead(ctx).Return(tk, nil)
+		nCtx := &mocks.NodeExecutionContext{}
+		nCtx.OnTaskReader().Return(tr)
+		nCtx.OnNodeID().Return("finally-node")
+
+		inputs := coreutils.MustMakeLiteral(map[string]interface{}{
+			"x": 1,
+		}).GetMap()
+
+		assert.NoError(t, addTerminalStatusInputs(ctx, nCtx, status, inputs))
+		assert.Len(t, inputs.Literals, 3)
+		assert.Equal(t, "Failed", inputs.Literals[FinallyNodeInputWorkflowPhase].GetScalar().GetPrimitive().GetStringValue())
+		assert.Equal(t, "OOM", inputs.Literals[FinallyNodeInputWorkflowErrorCode].GetScalar().GetPrimitive().GetStringValue())
+		_, ok := inputs.Literals[FinallyNodeInputWorkflowErrorMessage]
+		assert.False(t, ok)
+	})
+
+	t.Run("non-task-node", func(t *testing.T) {
+		nCtx := &mocks.NodeExecutionContext{}
+		nCtx.OnTaskReader().Return(nil)
+		nCtx.OnNodeID().Return("finally-node")
+
+		inputs := &core.LiteralMap{}
+		assert.NoError(t, addTerminalStatusInputs(ctx, nCtx, status, inputs))
+		assert.Empty(t, inputs.Literals)
+	})
+}
+
+func TestMakeTerminalStatusLiterals(t *testing.T) {
+	literals, err := makeTerminalStatusLiterals(executors.TerminalStatus{Phase: v1alpha1.WorkflowPhaseSuccess})
+	assert.NoError(t, err)
+	assert.Equal(t, "Succeeded", literals[FinallyNodeInputWorkflowPhase].GetScalar().GetPrimitive().GetStringValue())
+	assert.Equal(t, "", literals[FinallyNodeInputWorkflowErrorCode].GetScalar().GetPrimitive().GetStringValue())
+	assert.Equal(t, "", literals[FinallyNodeInputWorkflowErrorMessage].GetScalar().GetPrimitive().GetStringValue())
+}
@@ -0,0 +1,131 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+)
+
+const (
+	// GateRejected is the error code of approve gates whose signal did not approve them.
+	GateRejected = "GateRejected"
+)
+
+type metrics struct {
+	signalLookupFailed labeled.Counter
+	rejected           labeled.Counter
+}
+
+// gateHandler blocks the execution of the downstream nodes of a gate node until its sleep elapsed, or the Signal
+// resource it waits for has been created.
+type gateHandler struct {
+	signalReader client.Reader
+	clock        func() time.Time
+	metrics      metrics
+}
+
+func (g gateHandler) FinalizeRequired() bool {
+	return false
+}
+
+func (g gateHandler) Setup(_ context.Context, _ handler.SetupContext) error {
+	return nil
+}
+
+func (g gateHandler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext) (handler.Transition, error) {
+	gateNode := nCtx.Node().GetGateNode()
+	if gateNode == nil {
+		return handler.UnknownTransition, errors.Errorf(errors.BadSpecificationError, nCtx.NodeID(), "gate node is missing its gate spec")
+	}
+
+	switch gateNode.GetGateKind() {
+	case v1alpha1.GateNodeKindSleep:
+		return g.handleSleep(ctx, nCtx, gateNode.GetSleepDuration())
+	case v1alpha1.GateNodeKindSignal, v1alpha1.GateNodeKindApprove:
+		return g.handleSignal(ctx, nCtx, gateNode)
+	default:
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER,
+			errors.BadSpecificationError, fmt.Sprintf("unsupported gate kind [%v]", gateNode.GetGateKind()), nil)), nil
+	}
+}
+
+func (g gateHandler) handleSleep(ctx context.Context, nCtx handler.NodeExecutionContext, duration time.Duration) (handler.Transition, error) {
+	// The sleep starts once the node is running, the first round only moves the node to running.
+	startedAt := nCtx.NodeStatus().GetLastAttemptStartedAt()
+	if startedAt == nil {
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil
+	}
+
+	if remaining := startedAt.Add(duration).Sub(g.clock()); remaining > 0 {
+		logger.Debugf(ctx, "Gate node is sleeping for another [%v]", remaining)
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil
+	}
+
+	logger.Infof(ctx, "Gate node slept for [%v]", duration)
+	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoSuccess(nil)), nil
+}
+
+func (g gateHandler) handleSignal(ctx context.Context, nCtx handler.NodeExecutionContext, gateNode v1alpha1.ExecutableGateNode) (handler.Transition, error) {
+	if len(gateNode.GetSignalID()) == 0 {
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER,
+			errors.BadSpecificationError, "signal and approve gates require a signal id", nil)), nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: nCtx.NodeExecutionMetadata().GetNamespace(),
+		Name:      v1alpha1.SignalName(nCtx.NodeExecutionMetadata().GetOwnerID().Name, gateNode.GetSignalID()),
+	}
+
+	signal := &v1alpha1.Signal{}
+	if err := g.signalReader.Get(ctx, key, signal); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Debugf(ctx, "Gate node is waiting for signal [%v]", key)
+			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil
+		}
+
+		g.metrics.signalLookupFailed.Inc(ctx)
+		return handler.UnknownTransition, errors.Wrapf(errors.RuntimeExecutionError, nCtx.NodeID(), err, "failed to read signal [%v]", key)
+	}
+
+	if gateNode.GetGateKind() == v1alpha1.GateNodeKindApprove && !signal.Spec.Approved {
+		g.metrics.rejected.Inc(ctx)
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER,
+			GateRejected, fmt.Sprintf("gate was rejected by signal [%v]: %v", key, signal.Spec.Message), nil)), nil
+	}
+
+	logger.Infof(ctx, "Gate node received signal [%v]", key)
+	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoSuccess(nil)), nil
+}
+
+func (g gateHandler) Abort(_ context.Context, _ handler.NodeExecutionContext, _ string) error {
+	return nil
+}
+
+func (g gateHandler) Finalize(_ context.Context, _ handler.NodeExecutionContext) error {
+	return nil
+}
+
+// New creates a handler for gate nodes, signals are read through the given reader.
+func New(signalReader client.Reader, scope promutils.Scope) handler.Node {
+	gateScope := scope.NewSubScope("gate")
+	return &gateHandler{
+		signalReader: signalReader,
+		clock:        time.Now,
+		metrics: metrics{
+			signalLookupFailed: labeled.NewCounter("signal_lookup_failed", "Failures to read the signal of a gate node", gateScope),
+			rejected:           labeled.NewCounter("rejected", "Approve gates that were rejected", gateScope),
+		},
+	}
+}
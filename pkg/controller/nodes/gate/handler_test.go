@@ -0,0 +1,149 @@
+package gate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	mocks2 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.NodeIDKey)
+}
+
+func newSignalReader(t *testing.T, signals ...client.Object) client.Reader {
+	s := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(s))
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(signals...).Build()
+}
+
+func newNodeExecutionContext(gate *v1alpha1.GateNodeSpec, startedAt *v1.Time) *mocks.NodeExecutionContext {
+	n := &mocks2.ExecutableNode{}
+	if gate != nil {
+		n.OnGetGateNode().Return(gate)
+	} else {
+		n.OnGetGateNode().Return(nil)
+	}
+
+	ns := &mocks2.ExecutableNodeStatus{}
+	ns.OnGetLastAttemptStartedAt().Return(startedAt)
+
+	md := &mocks.NodeExecutionMetadata{}
+	md.OnGetNamespace().Return("ns")
+	md.OnGetOwnerID().Return(types.NamespacedName{Namespace: "ns", Name: "wf"})
+
+	nCtx := &mocks.NodeExecutionContext{}
+	nCtx.OnNode().Return(n)
+	nCtx.OnNodeID().Return("n1")
+	nCtx.OnNodeStatus().Return(ns)
+	nCtx.OnNodeExecutionMetadata().Return(md)
+	return nCtx
+}
+
+func newSignal(signalID string, approved bool) *v1alpha1.Signal {
+	return &v1alpha1.Signal{
+		ObjectMeta: v1.ObjectMeta{Namespace: "ns", Name: v1alpha1.SignalName("wf", signalID)},
+		Spec:       v1alpha1.SignalSpec{Approved: approved, Message: "reviewed"},
+	}
+}
+
+func TestGateHandler_Handle(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+
+	newHandler := func(t *testing.T, signals ...client.Object) *gateHandler {
+		h := New(newSignalReader(t, signals...), promutils.NewTestScope()).(*gateHandler)
+		h.clock = func() time.Time { return now }
+		return h
+	}
+
+	t.Run("missing spec", func(t *testing.T) {
+		_, err := newHandler(t).Handle(ctx, newNodeExecutionContext(nil, nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		tr, err := newHandler(t).Handle(ctx, newNodeExecutionContext(&v1alpha1.GateNodeSpec{Kind: "unknown"}, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseFailed, tr.Info().GetPhase())
+	})
+
+	sleep := &v1alpha1.GateNodeSpec{Kind: v1alpha1.GateNodeKindSleep, Sleep: &v1.Duration{Duration: time.Minute}}
+
+	t.Run("sleep not started", func(t *testing.T) {
+		tr, err := newHandler(t).Handle(ctx, newNodeExecutionContext(sleep, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRunning, tr.Info().GetPhase())
+	})
+
+	t.Run("sleeping", func(t *testing.T) {
+		startedAt := v1.NewTime(now.Add(-30 * time.Second))
+		tr, err := newHandler(t).Handle(ctx, newNodeExecutionContext(sleep, &startedAt))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRunning, tr.Info().GetPhase())
+	})
+
+	t.Run("slept", func(t *testing.T) {
+		startedAt := v1.NewTime(now.Add(-time.Minute))
+		tr, err := newHandler(t).Handle(ctx, newNodeExecutionContext(sleep, &startedAt))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseSuccess, tr.Info().GetPhase())
+	})
+
+	t.Run("signal missing id", func(t *testing.T) {
+		tr, err := newHandler(t).Handle(ctx, newNodeExecutionContext(&v1alpha1.GateNodeSpec{Kind: v1alpha1.GateNodeKindSignal}, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseFailed, tr.Info().GetPhase())
+	})
+
+	signal := &v1alpha1.GateNodeSpec{Kind: v1alpha1.GateNodeKindSignal, SignalID: "Go"}
+
+	t.Run("waiting for signal", func(t *testing.T) {
+		tr, err := newHandler(t).Handle(ctx, newNodeExecutionContext(signal, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRunning, tr.Info().GetPhase())
+	})
+
+	t.Run("signal received", func(t *testing.T) {
+		tr, err := newHandler(t, newSignal("go", false)).Handle(ctx, newNodeExecutionContext(signal, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseSuccess, tr.Info().GetPhase())
+	})
+
+	approve := &v1alpha1.GateNodeSpec{Kind: v1alpha1.GateNodeKindApprove, SignalID: "review"}
+
+	t.Run("approved", func(t *testing.T) {
+		tr, err := newHandler(t, newSignal("review", true)).Handle(ctx, newNodeExecutionContext(approve, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseSuccess, tr.Info().GetPhase())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		tr, err := newHandler(t, newSignal("review", false)).Handle(ctx, newNodeExecutionContext(approve, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseFailed, tr.Info().GetPhase())
+		assert.Equal(t, GateRejected, tr.Info().GetErr().GetCode())
+	})
+}
+
+func TestGateHandler_Noops(t *testing.T) {
+	h := New(nil, promutils.NewTestScope())
+	assert.False(t, h.FinalizeRequired())
+	assert.NoError(t, h.Setup(context.TODO(), nil))
+	assert.NoError(t, h.Abort(context.TODO(), nil, ""))
+	assert.NoError(t, h.Finalize(context.TODO(), nil))
+}
@@ -342,3 +342,35 @@ func (_m *NodeExecutionMetadata) IsInterruptible() bool {
 
 	return r0
 }
+
+type NodeExecutionMetadata_IsInterruptibleDemoted struct {
+	*mock.Call
+}
+
+func (_m NodeExecutionMetadata_IsInterruptibleDemoted) Return(_a0 bool) *NodeExecutionMetadata_IsInterruptibleDemoted {
+	return &NodeExecutionMetadata_IsInterruptibleDemoted{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *NodeExecutionMetadata) OnIsInterruptibleDemoted() *NodeExecutionMetadata_IsInterruptibleDemoted {
+	c_call := _m.On("IsInterruptibleDemoted")
+	return &NodeExecutionMetadata_IsInterruptibleDemoted{Call: c_call}
+}
+
+func (_m *NodeExecutionMetadata) OnIsInterruptibleDemotedMatch(matchers ...interface{}) *NodeExecutionMetadata_IsInterruptibleDemoted {
+	c_call := _m.On("IsInterruptibleDemoted", matchers...)
+	return &NodeExecutionMetadata_IsInterruptibleDemoted{Call: c_call}
+}
+
+// IsInterruptibleDemoted provides a mock function with given fields:
+func (_m *NodeExecutionMetadata) IsInterruptibleDemoted() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -12,6 +12,38 @@ type NodeStateReader struct {
 	mock.Mock
 }
 
+type NodeStateReader_GetArrayNodeState struct {
+	*mock.Call
+}
+
+func (_m NodeStateReader_GetArrayNodeState) Return(_a0 handler.ArrayNodeState) *NodeStateReader_GetArrayNodeState {
+	return &NodeStateReader_GetArrayNodeState{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *NodeStateReader) OnGetArrayNodeState() *NodeStateReader_GetArrayNodeState {
+	c_call := _m.On("GetArrayNodeState")
+	return &NodeStateReader_GetArrayNodeState{Call: c_call}
+}
+
+func (_m *NodeStateReader) OnGetArrayNodeStateMatch(matchers ...interface{}) *NodeStateReader_GetArrayNodeState {
+	c_call := _m.On("GetArrayNodeState", matchers...)
+	return &NodeStateReader_GetArrayNodeState{Call: c_call}
+}
+
+// GetArrayNodeState provides a mock function with given fields:
+func (_m *NodeStateReader) GetArrayNodeState() handler.ArrayNodeState {
+	ret := _m.Called()
+
+	var r0 handler.ArrayNodeState
+	if rf, ok := ret.Get(0).(func() handler.ArrayNodeState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(handler.ArrayNodeState)
+	}
+
+	return r0
+}
+
 type NodeStateReader_GetBranchNode struct {
 	*mock.Call
 }
@@ -12,6 +12,38 @@ type NodeStateWriter struct {
 	mock.Mock
 }
 
+type NodeStateWriter_PutArrayNodeState struct {
+	*mock.Call
+}
+
+func (_m NodeStateWriter_PutArrayNodeState) Return(_a0 error) *NodeStateWriter_PutArrayNodeState {
+	return &NodeStateWriter_PutArrayNodeState{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *NodeStateWriter) OnPutArrayNodeState(s handler.ArrayNodeState) *NodeStateWriter_PutArrayNodeState {
+	c_call := _m.On("PutArrayNodeState", s)
+	return &NodeStateWriter_PutArrayNodeState{Call: c_call}
+}
+
+func (_m *NodeStateWriter) OnPutArrayNodeStateMatch(matchers ...interface{}) *NodeStateWriter_PutArrayNodeState {
+	c_call := _m.On("PutArrayNodeState", matchers...)
+	return &NodeStateWriter_PutArrayNodeState{Call: c_call}
+}
+
+// PutArrayNodeState provides a mock function with given fields: s
+func (_m *NodeStateWriter) PutArrayNodeState(s handler.ArrayNodeState) error {
+	ret := _m.Called(s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(handler.ArrayNodeState) error); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NodeStateWriter_PutBranchNode struct {
 	*mock.Call
 }
@@ -39,6 +39,9 @@ type NodeExecutionMetadata interface {
 	GetK8sServiceAccount() string
 	GetSecurityContext() core.SecurityContext
 	IsInterruptible() bool
+	// IsInterruptibleDemoted returns whether the node is no longer interruptible because it hit the interruptible failure
+	// threshold.
+	IsInterruptibleDemoted() bool
 	GetInterruptibleFailureThreshold() uint32
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/bitarray"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
@@ -19,6 +20,8 @@ type TaskNodeState struct {
 	PluginStateVersion uint32
 	BarrierClockTick   uint32
 	LastPhaseUpdatedAt time.Time
+	// ExecutionEnvironment is the snapshot of the environment the task executed in, nil until it is known.
+	ExecutionEnvironment *v1alpha1.ExecutionEnvironment
 }
 
 type BranchNodeState struct {
@@ -29,9 +32,10 @@ type BranchNodeState struct {
 type DynamicNodePhase uint8
 
 type DynamicNodeState struct {
-	Phase  v1alpha1.DynamicNodePhase
-	Reason string
-	Error  *core.ExecutionError
+	Phase            v1alpha1.DynamicNodePhase
+	Reason           string
+	Error            *core.ExecutionError
+	WorkflowChecksum string
 }
 
 type WorkflowNodeState struct {
@@ -39,11 +43,22 @@ type WorkflowNodeState struct {
 	Error *core.ExecutionError
 }
 
+type ArrayNodeState struct {
+	Phase                 v1alpha1.ArrayNodePhase
+	Error                 *core.ExecutionError
+	SubNodePhases         bitarray.CompactArray
+	SubNodeTaskPhases     bitarray.CompactArray
+	SubNodeRetryAttempts  bitarray.CompactArray
+	SubNodeSystemFailures bitarray.CompactArray
+	SubNodeTaskStates     map[int]*v1alpha1.TaskNodeStatus
+}
+
 type NodeStateWriter interface {
 	PutTaskNodeState(s TaskNodeState) error
 	PutBranchNode(s BranchNodeState) error
 	PutDynamicNodeState(s DynamicNodeState) error
 	PutWorkflowNodeState(s WorkflowNodeState) error
+	PutArrayNodeState(s ArrayNodeState) error
 }
 
 type NodeStateReader interface {
@@ -51,4 +66,5 @@ type NodeStateReader interface {
 	GetBranchNode() BranchNodeState
 	GetDynamicNodeState() DynamicNodeState
 	GetWorkflowNodeState() WorkflowNodeState
+	GetArrayNodeState() ArrayNodeState
 }
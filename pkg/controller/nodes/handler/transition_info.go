@@ -27,6 +27,31 @@ const (
 	EPhaseRecovered
 )
 
+// Skip reasons are reported as the error code of the events of skipped nodes, alongside a human-readable message.
+const (
+	SkipReasonBranchNotTaken   = "BranchNotTaken"
+	SkipReasonUpstreamSkipped  = "UpstreamSkipped"
+	SkipReasonUpstreamFailed   = "UpstreamFailed"
+	SkipReasonUpstreamTimedOut = "UpstreamTimedOut"
+)
+
+// ReasonCode is the machine-readable reason of a transition, reported alongside its human-readable reason, so that
+// automation can act on transitions without parsing messages. The reason code of failures is the code of the error.
+type ReasonCode string
+
+const (
+	ReasonCodeUnknown          ReasonCode = ""
+	ReasonCodeNotReady         ReasonCode = "NotReady"
+	ReasonCodeQueued           ReasonCode = "Queued"
+	ReasonCodeRunning          ReasonCode = "Running"
+	ReasonCodeDynamicRunning   ReasonCode = "DynamicRunning"
+	ReasonCodeSucceeded        ReasonCode = "Succeeded"
+	ReasonCodeSkipped          ReasonCode = "Skipped"
+	ReasonCodeTimedOut         ReasonCode = "TimedOut"
+	ReasonCodeRecovered        ReasonCode = "Recovered"
+	ReasonCodeRetriesExhausted ReasonCode = "RetriesExhausted"
+)
+
 func (p EPhase) IsTerminal() bool {
 	if p == EPhaseFailed || p == EPhaseSuccess || p == EPhaseSkip || p == EPhaseTimedout || p == EPhaseRecovered {
 		return true
@@ -67,6 +92,7 @@ type PhaseInfo struct {
 	err        *core.ExecutionError
 	info       *ExecutionInfo
 	reason     string
+	reasonCode ReasonCode
 }
 
 func (p PhaseInfo) GetPhase() EPhase {
@@ -89,6 +115,10 @@ func (p PhaseInfo) GetReason() string {
 	return p.reason
 }
 
+func (p PhaseInfo) GetReasonCode() ReasonCode {
+	return p.reasonCode
+}
+
 func (p PhaseInfo) WithInfo(i *ExecutionInfo) PhaseInfo {
 	return PhaseInfo{
 		p:          p.p,
@@ -96,51 +126,70 @@ func (p PhaseInfo) WithInfo(i *ExecutionInfo) PhaseInfo {
 		err:        p.err,
 		info:       i,
 		reason:     p.reason,
+		reasonCode: p.reasonCode,
 	}
 }
 
+// WithReasonCode overrides the default reason code of the transition with a more specific one.
+func (p PhaseInfo) WithReasonCode(code ReasonCode) PhaseInfo {
+	p.reasonCode = code
+	return p
+}
+
 var PhaseInfoUndefined = PhaseInfo{p: EPhaseUndefined}
 
-func phaseInfo(p EPhase, err *core.ExecutionError, info *ExecutionInfo, reason string) PhaseInfo {
+func phaseInfo(p EPhase, err *core.ExecutionError, info *ExecutionInfo, reason string, reasonCode ReasonCode) PhaseInfo {
 	return PhaseInfo{
 		p:          p,
 		err:        err,
 		occurredAt: time.Now(),
 		info:       info,
 		reason:     reason,
+		reasonCode: reasonCode,
 	}
 }
 
 func PhaseInfoNotReady(reason string) PhaseInfo {
-	return phaseInfo(EPhaseNotReady, nil, nil, reason)
+	return phaseInfo(EPhaseNotReady, nil, nil, reason, ReasonCodeNotReady)
 }
 
 func PhaseInfoQueued(reason string) PhaseInfo {
-	return phaseInfo(EPhaseQueued, nil, nil, reason)
+	return phaseInfo(EPhaseQueued, nil, nil, reason, ReasonCodeQueued)
 }
 
 func PhaseInfoRunning(info *ExecutionInfo) PhaseInfo {
-	return phaseInfo(EPhaseRunning, nil, info, "running")
+	return phaseInfo(EPhaseRunning, nil, info, "running", ReasonCodeRunning)
 }
 
 func PhaseInfoDynamicRunning(info *ExecutionInfo) PhaseInfo {
-	return phaseInfo(EPhaseDynamicRunning, nil, info, "dynamic workflow running")
+	return phaseInfo(EPhaseDynamicRunning, nil, info, "dynamic workflow running", ReasonCodeDynamicRunning)
 }
 
 func PhaseInfoSuccess(info *ExecutionInfo) PhaseInfo {
-	return phaseInfo(EPhaseSuccess, nil, info, "successfully completed")
+	return phaseInfo(EPhaseSuccess, nil, info, "successfully completed", ReasonCodeSucceeded)
 }
 
 func PhaseInfoSkip(info *ExecutionInfo, reason string) PhaseInfo {
-	return phaseInfo(EPhaseSkip, nil, info, reason)
+	return phaseInfo(EPhaseSkip, nil, info, reason, ReasonCodeSkipped)
+}
+
+// PhaseInfoSkipWithReason skips the node with a structured reason, code being one of the SkipReason* constants.
+func PhaseInfoSkipWithReason(info *ExecutionInfo, code, reason string) PhaseInfo {
+	return phaseInfo(EPhaseSkip, &core.ExecutionError{Kind: core.ExecutionError_UNKNOWN, Code: code, Message: reason}, info, reason,
+		ReasonCode(code))
 }
 
 func PhaseInfoTimedOut(info *ExecutionInfo, reason string) PhaseInfo {
-	return phaseInfo(EPhaseTimedout, nil, info, reason)
+	return phaseInfo(EPhaseTimedout, nil, info, reason, ReasonCodeTimedOut)
+}
+
+// PhaseInfoTimedOutErr times out the node with the error that describes which deadline expired.
+func PhaseInfoTimedOutErr(err *core.ExecutionError, info *ExecutionInfo) PhaseInfo {
+	return phaseInfo(EPhaseTimedout, err, info, err.GetMessage(), ReasonCodeTimedOut)
 }
 
 func PhaseInfoRecovered(info *ExecutionInfo) PhaseInfo {
-	return phaseInfo(EPhaseRecovered, nil, info, "successfully recovered")
+	return phaseInfo(EPhaseRecovered, nil, info, "successfully recovered", ReasonCodeRecovered)
 }
 
 func phaseInfoFailed(p EPhase, err *core.ExecutionError, info *ExecutionInfo) PhaseInfo {
@@ -151,7 +200,7 @@ func phaseInfoFailed(p EPhase, err *core.ExecutionError, info *ExecutionInfo) Ph
 		}
 	}
 
-	return phaseInfo(p, err, info, err.Message)
+	return phaseInfo(p, err, info, err.Message, ReasonCode(err.Code))
 }
 
 func PhaseInfoFailure(kind core.ExecutionError_ErrorKind, code, reason string, info *ExecutionInfo) PhaseInfo {
@@ -95,6 +95,16 @@ func TestPhaseInfo(t *testing.T) {
 		assert.Equal(t, "reason", p.GetReason())
 	})
 
+	t.Run("timeout-err", func(t *testing.T) {
+		i := &ExecutionInfo{}
+		p := PhaseInfoTimedOutErr(&core.ExecutionError{Kind: core.ExecutionError_USER, Code: "code", Message: "reason"}, i)
+		assert.Equal(t, EPhaseTimedout, p.GetPhase())
+		assert.Equal(t, i, p.GetInfo())
+		assert.Equal(t, "code", p.GetErr().GetCode())
+		assert.Equal(t, ReasonCodeTimedOut, p.GetReasonCode())
+		assert.Equal(t, "reason", p.GetReason())
+	})
+
 	t.Run("failure", func(t *testing.T) {
 		i := &ExecutionInfo{}
 		p := PhaseInfoFailure(core.ExecutionError_SYSTEM, "code", "reason", i)
@@ -148,3 +158,18 @@ func TestPhaseInfo(t *testing.T) {
 		assert.NotNil(t, p.GetOccurredAt())
 	})
 }
+
+func TestPhaseInfo_ReasonCode(t *testing.T) {
+	assert.Equal(t, ReasonCodeQueued, PhaseInfoQueued("queued").GetReasonCode())
+	assert.Equal(t, ReasonCodeRunning, PhaseInfoRunning(nil).GetReasonCode())
+	assert.Equal(t, ReasonCodeSucceeded, PhaseInfoSuccess(nil).GetReasonCode())
+	assert.Equal(t, ReasonCodeSkipped, PhaseInfoSkip(nil, "skipped").GetReasonCode())
+	assert.Equal(t, ReasonCode(SkipReasonUpstreamFailed), PhaseInfoSkipWithReason(nil, SkipReasonUpstreamFailed, "upstream failed").GetReasonCode())
+	assert.Equal(t, ReasonCode("code"), PhaseInfoFailure(core.ExecutionError_USER, "code", "reason", nil).GetReasonCode())
+	assert.Equal(t, ReasonCode("Unknown"), PhaseInfoFailureErr(nil, nil).GetReasonCode())
+
+	p := PhaseInfoFailure(core.ExecutionError_USER, "code", "reason", nil).WithReasonCode(ReasonCodeRetriesExhausted)
+	assert.Equal(t, ReasonCodeRetriesExhausted, p.GetReasonCode())
+	assert.Equal(t, "code", p.GetErr().Code)
+	assert.Equal(t, ReasonCodeRetriesExhausted, p.WithInfo(&ExecutionInfo{}).GetReasonCode())
+}
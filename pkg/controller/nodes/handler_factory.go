@@ -3,6 +3,7 @@ package nodes
 import (
 	"context"
 
+	"github.com/flyteorg/flytepropeller/events"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery"
@@ -17,8 +18,10 @@ import (
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/array"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/branch"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/end"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/gate"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/start"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow"
@@ -56,7 +59,7 @@ func (f handlerFactory) Setup(ctx context.Context, setup handler.SetupContext) e
 
 func NewHandlerFactory(ctx context.Context, executor executors.Node, workflowLauncher launchplan.Executor,
 	launchPlanReader launchplan.Reader, kubeClient executors.Client, client catalog.Client, recoveryClient recovery.Client,
-	eventConfig *config.EventConfig, clusterID string, scope promutils.Scope) (HandlerFactory, error) {
+	nodeRecorder events.NodeEventRecorder, eventConfig *config.EventConfig, clusterID string, scope promutils.Scope) (HandlerFactory, error) {
 
 	t, err := task.New(ctx, kubeClient, client, eventConfig, clusterID, scope)
 	if err != nil {
@@ -65,11 +68,13 @@ func NewHandlerFactory(ctx context.Context, executor executors.Node, workflowLau
 
 	f := &handlerFactory{
 		handlers: map[v1alpha1.NodeKind]handler.Node{
-			v1alpha1.NodeKindBranch:   branch.New(executor, eventConfig, scope),
+			v1alpha1.NodeKindBranch:   branch.New(executor, eventConfig, nodeRecorder, clusterID, scope),
 			v1alpha1.NodeKindTask:     dynamic.New(t, executor, launchPlanReader, eventConfig, scope),
-			v1alpha1.NodeKindWorkflow: subworkflow.New(executor, workflowLauncher, recoveryClient, eventConfig, scope),
+			v1alpha1.NodeKindWorkflow: subworkflow.New(executor, workflowLauncher, launchPlanReader, client, recoveryClient, eventConfig, nodeRecorder, clusterID, scope),
 			v1alpha1.NodeKindStart:    start.New(),
 			v1alpha1.NodeKindEnd:      end.New(),
+			v1alpha1.NodeKindGate:     gate.New(kubeClient.GetClient(), scope),
+			v1alpha1.NodeKindArray:    array.New(executor, scope),
 		},
 	}
 
@@ -0,0 +1,196 @@
+package nodes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+)
+
+type inputPrefetchMetrics struct {
+	prefetched      prometheus.Counter
+	prefetchFailure prometheus.Counter
+	prefetchDropped prometheus.Counter
+	hit             prometheus.Counter
+	miss            prometheus.Counter
+}
+
+// inputPrefetcher reads the outputs of nodes in the background as soon as they succeeded, so that the inputs of the
+// downstream nodes that become ready in the next round are resolved without a round-trip to storage. Outputs are
+// immutable once a node succeeded, the cache is keyed by the outputs file they were read from.
+type inputPrefetcher struct {
+	store    storage.ProtobufStore
+	outputs  *cache.LRUExpireCache
+	ttl      time.Duration
+	inflight sync.Map
+	// Bounds the number of concurrent reads, prefetches beyond it are dropped rather than blocking the round.
+	workers chan struct{}
+	metrics *inputPrefetchMetrics
+}
+
+// Returns the outputs prefetched from the outputs file, if any. A nil prefetcher never has any outputs.
+func (p *inputPrefetcher) Get(outputsFileRef storage.DataReference) (*core.LiteralMap, bool) {
+	if p == nil {
+		return nil, false
+	}
+
+	if d, ok := p.outputs.Get(outputsFileRef); ok {
+		p.metrics.hit.Inc()
+		return d.(*core.LiteralMap), true
+	}
+
+	p.metrics.miss.Inc()
+	return nil, false
+}
+
+// PrefetchDownstream prefetches the inputs of the downstream nodes of a node that just succeeded, which are ready once
+// all of their upstream nodes completed. Node lookups are not safe for concurrent use, so the outputs files to read are
+// collected before reading them in the background.
+func (p *inputPrefetcher) PrefetchDownstream(ctx context.Context, dag executors.DAGStructure, nl executors.NodeLookup,
+	succeededNodeID v1alpha1.NodeID) {
+	if p == nil {
+		return
+	}
+
+	downstreamNodes, err := dag.FromNode(succeededNodeID)
+	if err != nil {
+		logger.Debugf(ctx, "Failed to find the downstream nodes of [%v] to prefetch inputs for, error: %v", succeededNodeID, err)
+		return
+	}
+
+	for _, downstreamNodeID := range downstreamNodes {
+		if !isNextReady(ctx, dag, nl, downstreamNodeID) {
+			continue
+		}
+
+		n, ok := nl.GetNode(downstreamNodeID)
+		if !ok {
+			continue
+		}
+
+		for outputsFileRef, upstreamNodeID := range upstreamOutputsFiles(ctx, nl, n.GetInputBindings()) {
+			p.prefetch(ctx, upstreamNodeID, outputsFileRef)
+		}
+	}
+}
+
+func (p *inputPrefetcher) prefetch(ctx context.Context, nodeID v1alpha1.NodeID, outputsFileRef storage.DataReference) {
+	if _, ok := p.outputs.Get(outputsFileRef); ok {
+		return
+	}
+
+	if _, loaded := p.inflight.LoadOrStore(outputsFileRef, struct{}{}); loaded {
+		return
+	}
+
+	select {
+	case p.workers <- struct{}{}:
+	default:
+		p.inflight.Delete(outputsFileRef)
+		p.metrics.prefetchDropped.Inc()
+		return
+	}
+
+	go func() {
+		defer func() {
+			<-p.workers
+			p.inflight.Delete(outputsFileRef)
+		}()
+
+		d, err := readOutputs(ctx, p.store, nodeID, outputsFileRef)
+		if err != nil {
+			// The read is retried when the inputs of the downstream node are resolved, which reports the failure.
+			logger.Debugf(ctx, "Failed to prefetch outputs [%v], error: %v", outputsFileRef, err)
+			p.metrics.prefetchFailure.Inc()
+			return
+		}
+
+		p.outputs.Add(outputsFileRef, d, p.ttl)
+		p.metrics.prefetched.Inc()
+	}()
+}
+
+// A node will be ready in the next round if it did not start yet and all of its upstream nodes completed. End nodes
+// are resolved through binding plans and are not prefetched for.
+func isNextReady(ctx context.Context, dag executors.DAGStructure, nl executors.NodeLookup, nodeID v1alpha1.NodeID) bool {
+	if nodeID == v1alpha1.EndNodeID || nl.GetNodeExecutionStatus(ctx, nodeID).GetPhase() != v1alpha1.NodePhaseNotYetStarted {
+		return false
+	}
+
+	upstreamNodes, err := dag.ToNode(nodeID)
+	if err != nil {
+		return false
+	}
+
+	for _, upstreamNodeID := range upstreamNodes {
+		switch nl.GetNodeExecutionStatus(ctx, upstreamNodeID).GetPhase() {
+		case v1alpha1.NodePhaseSucceeded, v1alpha1.NodePhaseSkipped, v1alpha1.NodePhaseRecovered:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the outputs files of the upstream nodes the bindings refer to, which succeeded or were recovered, mapped to
+// the upstream node they belong to.
+func upstreamOutputsFiles(ctx context.Context, nl executors.NodeLookup, bindings []*v1alpha1.Binding) map[storage.DataReference]v1alpha1.NodeID {
+	outputsFiles := make(map[storage.DataReference]v1alpha1.NodeID)
+	seen := make(map[v1alpha1.NodeID]bool)
+	for _, binding := range bindings {
+		// Resolving with a nil literal for every promise only visits the promises.
+		_, err := resolveBindingData(ctx, binding.GetBinding(), func(promise *core.OutputReference) (*core.Literal, error) {
+			upstreamNodeID := promise.GetNodeId()
+			if seen[upstreamNodeID] {
+				return nil, nil
+			}
+
+			seen[upstreamNodeID] = true
+			status := nl.GetNodeExecutionStatus(ctx, upstreamNodeID)
+			if phase := status.GetPhase(); (phase == v1alpha1.NodePhaseSucceeded || phase == v1alpha1.NodePhaseRecovered) &&
+				len(status.GetOutputDir()) > 0 {
+				outputsFiles[v1alpha1.GetOutputsFile(status.GetOutputDir())] = upstreamNodeID
+			}
+
+			return nil, nil
+		})
+
+		if err != nil {
+			logger.Debugf(ctx, "Failed to find the promises of binding [%v] to prefetch, error: %v", binding.GetVar(), err)
+		}
+	}
+
+	return outputsFiles
+}
+
+// Creates a prefetcher for the inputs of nodes, returns nil if prefetching is disabled.
+func newInputPrefetcher(cfg config.InputPrefetchConfig, store storage.ProtobufStore, scope promutils.Scope) *inputPrefetcher {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &inputPrefetcher{
+		store:   store,
+		outputs: cache.NewLRUExpireCache(cfg.CacheSize),
+		ttl:     cfg.TTL.Duration,
+		workers: make(chan struct{}, cfg.MaxConcurrency),
+		metrics: &inputPrefetchMetrics{
+			prefetched:      scope.MustNewCounter("input_prefetched", "Number of node outputs prefetched for the inputs of downstream nodes"),
+			prefetchFailure: scope.MustNewCounter("input_prefetch_failure", "Number of node outputs that failed to be prefetched"),
+			prefetchDropped: scope.MustNewCounter("input_prefetch_dropped", "Number of node outputs not prefetched since all prefetch workers were busy"),
+			hit:             scope.MustNewCounter("input_prefetch_hit", "Number of node outputs resolved from prefetched outputs"),
+			miss:            scope.MustNewCounter("input_prefetch_miss", "Number of node outputs that had not been prefetched when resolved"),
+		},
+	}
+}
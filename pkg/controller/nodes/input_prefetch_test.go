@@ -0,0 +1,114 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	controllerConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+)
+
+func TestNewInputPrefetcher(t *testing.T) {
+	assert.Nil(t, newInputPrefetcher(controllerConfig.InputPrefetchConfig{}, nil, testScope.NewSubScope("prefetch_disabled")))
+
+	var p *inputPrefetcher
+	_, ok := p.Get("ref")
+	assert.False(t, ok)
+	p.PrefetchDownstream(context.TODO(), nil, nil, "n1")
+}
+
+func TestInputPrefetcher_PrefetchDownstream(t *testing.T) {
+	ctx := context.Background()
+	store := createInmemoryDataStore(t, testScope.NewSubScope("prefetch_store"))
+	assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetOutputsFile("n1"), storage.Options{},
+		coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap()))
+	assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetOutputsFile("n2"), storage.Options{},
+		coreutils.MustMakeLiteral(map[string]interface{}{"y": 2}).GetMap()))
+
+	// n3 consumes the outputs of n1 and n2, n4 also waits for n5 to complete.
+	nodes := map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+		"n3": {ID: "n3", InputBindings: []*v1alpha1.Binding{
+			{Binding: utils.MakeBinding("a", utils.MakeBindingDataPromise("n1", "x"))},
+			{Binding: utils.MakeBinding("b", utils.MakeBindingDataCollection(utils.MakeBindingDataPromise("n2", "y")))},
+		}},
+		"n4": {ID: "n4", InputBindings: []*v1alpha1.Binding{
+			{Binding: utils.MakeBinding("a", utils.MakeBindingDataPromise("n5", "x"))},
+		}},
+	}
+
+	w := &dummyBaseWorkflow{
+		Status: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+			"n1": {Phase: v1alpha1.NodePhaseSucceeded, OutputDir: "n1"},
+			"n2": {Phase: v1alpha1.NodePhaseSucceeded, OutputDir: "n2"},
+			"n3": {Phase: v1alpha1.NodePhaseNotYetStarted},
+			"n4": {Phase: v1alpha1.NodePhaseNotYetStarted},
+			"n5": {Phase: v1alpha1.NodePhaseRunning, OutputDir: "n5"},
+		},
+		FromNodeCb: func(name v1alpha1.NodeID) ([]v1alpha1.NodeID, error) {
+			return []v1alpha1.NodeID{"n3", "n4"}, nil
+		},
+		ToNodeCb: func(name v1alpha1.NodeID) ([]v1alpha1.NodeID, error) {
+			if name == "n3" {
+				return []v1alpha1.NodeID{"n1", "n2"}, nil
+			}
+
+			return []v1alpha1.NodeID{"n1", "n5"}, nil
+		},
+		GetNodeCb: func(nodeId v1alpha1.NodeID) (v1alpha1.ExecutableNode, bool) {
+			n, ok := nodes[nodeId]
+			return n, ok
+		},
+	}
+
+	p := newInputPrefetcher(controllerConfig.InputPrefetchConfig{
+		Enabled:        true,
+		CacheSize:      10,
+		TTL:            config.Duration{Duration: time.Minute},
+		MaxConcurrency: 10,
+	}, store, testScope.NewSubScope("prefetch"))
+	p.PrefetchDownstream(ctx, w, w, "n1")
+
+	assert.Eventually(t, func() bool {
+		_, n1 := p.outputs.Get(v1alpha1.GetOutputsFile("n1"))
+		_, n2 := p.outputs.Get(v1alpha1.GetOutputsFile("n2"))
+		return n1 && n2
+	}, time.Second, 10*time.Millisecond)
+
+	// n4 is not ready, so the outputs of n5 are not read.
+	_, ok := p.outputs.Get(v1alpha1.GetOutputsFile("n5"))
+	assert.False(t, ok)
+
+	// The outputs are resolved from the prefetched outputs rather than the store.
+	assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetOutputsFile("n1"), storage.Options{},
+		coreutils.MustMakeLiteral(map[string]interface{}{"x": 5}).GetMap()))
+	r := remoteFileOutputResolver{store: store, prefetched: p}
+	l, err := r.ExtractOutput(ctx, w, &v1alpha1.NodeSpec{ID: "n1"}, "x")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), l.GetScalar().GetPrimitive().GetInteger())
+}
+
+func TestInputPrefetcher_Dropped(t *testing.T) {
+	ctx := context.Background()
+	store := createInmemoryDataStore(t, testScope.NewSubScope("prefetch_dropped_store"))
+	p := newInputPrefetcher(controllerConfig.InputPrefetchConfig{
+		Enabled:        true,
+		CacheSize:      10,
+		TTL:            config.Duration{Duration: time.Minute},
+		MaxConcurrency: 1,
+	}, store, testScope.NewSubScope("prefetch_dropped"))
+
+	// All workers are busy.
+	p.workers <- struct{}{}
+	p.prefetch(ctx, "n1", v1alpha1.GetOutputsFile("n1"))
+	_, inflight := p.inflight.Load(v1alpha1.GetOutputsFile("n1"))
+	assert.False(t, inflight)
+	_, ok := p.outputs.Get(v1alpha1.GetOutputsFile("n1"))
+	assert.False(t, ok)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/storage"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/flyteorg/flytepropeller/events"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	"github.com/flyteorg/flytepropeller/pkg/utils"
 )
@@ -27,18 +29,35 @@ type nodeExecMetadata struct {
 	v1alpha1.Meta
 	nodeExecID                    *core.NodeExecutionIdentifier
 	interrutptible                bool
+	interruptibleDemoted          bool
 	interruptibleFailureThreshold uint32
-	nodeLabels                    map[string]string
+	// Labels propeller sets on the pods of the node, on top of the propagated ones
+	nodeLabels  map[string]string
+	execContext executors.ImmutableExecutionContext
+	node        v1alpha1.ExecutableNode
+	// Annotation key under which the IAM role of the node is set on its pods, empty to not set it
+	iamRoleAnnotation string
 }
 
 func (e nodeExecMetadata) GetNodeExecutionID() *core.NodeExecutionIdentifier {
 	return e.nodeExecID
 }
 
+// GetK8sServiceAccount returns the service account of the identity the node runs with, or the one of the execution if
+// the identity does not set one.
 func (e nodeExecMetadata) GetK8sServiceAccount() string {
+	if sa := e.GetSecurityContext().GetRunAs().GetK8SServiceAccount(); len(sa) > 0 {
+		return sa
+	}
+
 	return e.Meta.GetServiceAccountName()
 }
 
+// GetSecurityContext returns the security context of the execution with the identity overrides applied.
+func (e nodeExecMetadata) GetSecurityContext() core.SecurityContext {
+	return common.PropagatedSecurityContext(e.execContext, e.node)
+}
+
 func (e nodeExecMetadata) GetOwnerID() types.NamespacedName {
 	return types.NamespacedName{Name: e.GetName(), Namespace: e.GetNamespace()}
 }
@@ -47,12 +66,34 @@ func (e nodeExecMetadata) IsInterruptible() bool {
 	return e.interrutptible
 }
 
+func (e nodeExecMetadata) IsInterruptibleDemoted() bool {
+	return e.interruptibleDemoted
+}
+
 func (e nodeExecMetadata) GetInterruptibleFailureThreshold() uint32 {
 	return e.interruptibleFailureThreshold
 }
 
+// GetLabels returns the labels propagated to the pods of the node, along with the ones propeller sets.
 func (e nodeExecMetadata) GetLabels() map[string]string {
-	return e.nodeLabels
+	labels := common.PropagatedLabels(e.execContext, e.node)
+	for k, v := range e.nodeLabels {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// GetAnnotations returns the annotations propagated to the pods of the node.
+func (e nodeExecMetadata) GetAnnotations() map[string]string {
+	annotations := common.PropagatedAnnotations(e.execContext, e.node)
+	if len(e.iamRoleAnnotation) > 0 {
+		if role := e.GetSecurityContext().GetRunAs().GetIamRole(); len(role) > 0 {
+			annotations[e.iamRoleAnnotation] = role
+		}
+	}
+
+	return annotations
 }
 
 type nodeExecContext struct {
@@ -153,13 +194,12 @@ func newNodeExecContext(_ context.Context, store *storage.DataStore, execContext
 		},
 		interrutptible:                interruptible,
 		interruptibleFailureThreshold: interruptibleFailureThreshold,
+		execContext:                   execContext,
+		node:                          node,
 	}
 
-	// Copy the wf labels before adding node specific labels.
+	// The labels of the execution are merged with the node specific labels once they're needed.
 	nodeLabels := make(map[string]string)
-	for k, v := range execContext.GetLabels() {
-		nodeLabels[k] = v
-	}
 	nodeLabels[NodeIDLabel] = utils.SanitizeLabelValue(node.GetID())
 	if tr != nil && tr.GetTaskID() != nil {
 		nodeLabels[TaskNameLabel] = utils.SanitizeLabelValue(tr.GetTaskID().Name)
@@ -201,7 +241,7 @@ func (c *nodeExecutor) newNodeExecContextDefault(ctx context.Context, currentNod
 		if err != nil {
 			return nil, err
 		}
-		tr = taskReader{TaskTemplate: tk.CoreTask()}
+		tr = taskReader{TaskTemplate: tk.CoreTask(), templateRef: tk.GetTemplateRef(), templates: c.taskTemplates}
 	}
 
 	workflowEnqueuer := func() error {
@@ -217,17 +257,20 @@ func (c *nodeExecutor) newNodeExecContextDefault(ctx context.Context, currentNod
 	s := nl.GetNodeExecutionStatus(ctx, currentNodeID)
 
 	// a node is not considered interruptible if the system failures have exceeded the configured threshold
+	interruptibleDemoted := false
 	if interruptible && s.GetSystemFailures() >= c.interruptibleFailureThreshold {
 		interruptible = false
+		interruptibleDemoted = true
 		c.metrics.InterruptedThresholdHit.Inc(ctx)
+		logger.Debugf(ctx, "Node [%s] is no longer interruptible after [%d] system failures", currentNodeID, s.GetSystemFailures())
 	}
 
-	rawOutputPrefix := c.defaultDataSandbox
-	if executionContext.GetRawOutputDataConfig().RawOutputDataConfig != nil && len(executionContext.GetRawOutputDataConfig().OutputLocationPrefix) > 0 {
-		rawOutputPrefix = storage.DataReference(executionContext.GetRawOutputDataConfig().OutputLocationPrefix)
+	rawOutputPrefix, err := c.getRawOutputPrefix(ctx, executionContext)
+	if err != nil {
+		return nil, err
 	}
 
-	return newNodeExecContext(ctx, c.store, executionContext, nl, n, s,
+	nCtx := newNodeExecContext(ctx, c.store, executionContext, nl, n, s,
 		ioutils.NewCachedInputReader(
 			ctx,
 			ioutils.NewRemoteFileInputReader(
@@ -249,5 +292,9 @@ func (c *nodeExecutor) newNodeExecContextDefault(ctx context.Context, currentNod
 		workflowEnqueuer,
 		rawOutputPrefix,
 		c.shardSelector,
-	), nil
+	)
+
+	nCtx.md.interruptibleDemoted = interruptibleDemoted
+	nCtx.md.iamRoleAnnotation = c.iamRoleAnnotation
+	return nCtx, nil
 }
@@ -11,8 +11,10 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
@@ -64,6 +66,73 @@ func Test_NodeContext(t *testing.T) {
 	assert.Equal(t, p, nCtx.ExecutionContext().GetParentInfo())
 }
 
+func Test_NodeContextPropagatedLabels(t *testing.T) {
+	w1 := &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Labels:      map[string]string{"workflow": "w", "team": "wf"},
+			Annotations: map[string]string{"owner": "wf"},
+		},
+		ExecutionConfig: v1alpha1.ExecutionConfig{
+			Labels:      map[string]string{"team": "exec", "node-id": "overridden"},
+			Annotations: map[string]string{"policy": "exec"},
+		},
+	}
+
+	n := &v1alpha1.NodeSpec{
+		ID:          "id",
+		Kind:        v1alpha1.NodeKindTask,
+		Labels:      map[string]string{"cost-center": "node"},
+		Annotations: map[string]string{"owner": "node"},
+	}
+	s, _ := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	execContext := executors.NewExecutionContext(w1, nil, nil, parentInfo{}, nil)
+	nCtx := newNodeExecContext(context.TODO(), s, execContext, w1, n, nil, nil, false, 0, 2, nil, TaskReader{}, nil, nil, "s3://bucket", ioutils.NewConstantShardSelector([]string{"x"}))
+
+	labels := nCtx.NodeExecutionMetadata().GetLabels()
+	assert.Equal(t, "w", labels["workflow"])
+	assert.Equal(t, "exec", labels["team"])
+	assert.Equal(t, "node", labels["cost-center"])
+	// The labels propeller sets can't be overridden.
+	assert.Equal(t, "id", labels[NodeIDLabel])
+	assert.Equal(t, map[string]string{"owner": "node", "policy": "exec"}, nCtx.NodeExecutionMetadata().GetAnnotations())
+}
+
+func Test_NodeContextIdentity(t *testing.T) {
+	w1 := &v1alpha1.FlyteWorkflow{
+		ServiceAccountName: "wf-sa",
+		SecurityContext: core.SecurityContext{
+			RunAs: &core.Identity{K8SServiceAccount: "wf-sa", IamRole: "wf-role"},
+		},
+	}
+
+	s, _ := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	execContext := executors.NewExecutionContext(w1, nil, nil, parentInfo{}, nil)
+
+	t.Run("node identity", func(t *testing.T) {
+		n := &v1alpha1.NodeSpec{
+			ID:       "id",
+			Kind:     v1alpha1.NodeKindTask,
+			Identity: &v1alpha1.Identity{K8sServiceAccount: "node-sa", IamRole: "node-role"},
+		}
+		nCtx := newNodeExecContext(context.TODO(), s, execContext, w1, n, nil, nil, false, 0, 2, nil, TaskReader{}, nil, nil, "s3://bucket", ioutils.NewConstantShardSelector([]string{"x"}))
+		nCtx.md.iamRoleAnnotation = "iam.amazonaws.com/role"
+
+		assert.Equal(t, "node-sa", nCtx.NodeExecutionMetadata().GetK8sServiceAccount())
+		assert.Equal(t, "node-role", nCtx.NodeExecutionMetadata().GetSecurityContext().GetRunAs().GetIamRole())
+		assert.Equal(t, "node-role", nCtx.NodeExecutionMetadata().GetAnnotations()["iam.amazonaws.com/role"])
+	})
+
+	t.Run("execution identity", func(t *testing.T) {
+		n := &v1alpha1.NodeSpec{ID: "id", Kind: v1alpha1.NodeKindTask}
+		nCtx := newNodeExecContext(context.TODO(), s, execContext, w1, n, nil, nil, false, 0, 2, nil, TaskReader{}, nil, nil, "s3://bucket", ioutils.NewConstantShardSelector([]string{"x"}))
+
+		assert.Equal(t, "wf-sa", nCtx.NodeExecutionMetadata().GetK8sServiceAccount())
+		assert.Equal(t, "wf-role", nCtx.NodeExecutionMetadata().GetSecurityContext().GetRunAs().GetIamRole())
+		// The IAM role is only set as an annotation if the annotation is configured
+		assert.Empty(t, nCtx.NodeExecutionMetadata().GetAnnotations())
+	})
+}
+
 func Test_NodeContextDefault(t *testing.T) {
 	ctx := context.Background()
 
@@ -121,3 +190,74 @@ func Test_NodeContextDefault(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "s3://bucket-b", nodeExecContext.rawOutputPrefix.String())
 }
+
+func Test_NodeContextDefault_InterruptibleDemotion(t *testing.T) {
+	ctx := context.Background()
+
+	w1 := &v1alpha1.FlyteWorkflow{
+		NodeDefaults: v1alpha1.NodeDefaults{Interruptible: true},
+		RawOutputDataConfig: v1alpha1.RawOutputDataConfig{RawOutputDataConfig: &admin.RawOutputDataConfig{
+			OutputLocationPrefix: ""},
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "some.workflow",
+		},
+	}
+	dataStore, _ := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	n := &v1alpha1.NodeSpec{
+		ID:   "id",
+		Kind: v1alpha1.NodeKindStart,
+	}
+
+	nodeExecutor := nodeExecutor{
+		interruptibleFailureThreshold: 2,
+		defaultDataSandbox:            "s3://bucket-a",
+		store:                         dataStore,
+		shardSelector:                 ioutils.NewConstantShardSelector([]string{"x"}),
+		enqueueWorkflow:               func(workflowID v1alpha1.WorkflowID) {},
+		metrics: &nodeMetrics{
+			InterruptedThresholdHit: labeled.NewCounter("interrupted_threshold", "", promutils.NewTestScope()),
+		},
+	}
+	execContext := executors.NewExecutionContext(w1, w1, w1, parentInfo{}, nil)
+
+	t.Run("below threshold", func(t *testing.T) {
+		nodeLookup := &mocks2.NodeLookup{}
+		nodeLookup.OnGetNode("node-a").Return(n, true)
+		nodeLookup.OnGetNodeExecutionStatus(ctx, "node-a").Return(&v1alpha1.NodeStatus{SystemFailures: 1})
+
+		nodeExecContext, err := nodeExecutor.newNodeExecContextDefault(ctx, "node-a", execContext, nodeLookup)
+		assert.NoError(t, err)
+		assert.True(t, nodeExecContext.NodeExecutionMetadata().IsInterruptible())
+		assert.False(t, nodeExecContext.NodeExecutionMetadata().IsInterruptibleDemoted())
+	})
+
+	t.Run("threshold hit", func(t *testing.T) {
+		nodeLookup := &mocks2.NodeLookup{}
+		nodeLookup.OnGetNode("node-a").Return(n, true)
+		nodeLookup.OnGetNodeExecutionStatus(ctx, "node-a").Return(&v1alpha1.NodeStatus{SystemFailures: 2})
+
+		nodeExecContext, err := nodeExecutor.newNodeExecContextDefault(ctx, "node-a", execContext, nodeLookup)
+		assert.NoError(t, err)
+		assert.False(t, nodeExecContext.NodeExecutionMetadata().IsInterruptible())
+		assert.True(t, nodeExecContext.NodeExecutionMetadata().IsInterruptibleDemoted())
+		assert.Equal(t, "false", nodeExecContext.NodeExecutionMetadata().GetLabels()[NodeInterruptibleLabel])
+	})
+
+	t.Run("not interruptible", func(t *testing.T) {
+		interruptible := false
+		nonInterruptibleNode := &v1alpha1.NodeSpec{
+			ID:           "id",
+			Kind:         v1alpha1.NodeKindStart,
+			Interruptibe: &interruptible,
+		}
+		nodeLookup := &mocks2.NodeLookup{}
+		nodeLookup.OnGetNode("node-a").Return(nonInterruptibleNode, true)
+		nodeLookup.OnGetNodeExecutionStatus(ctx, "node-a").Return(&v1alpha1.NodeStatus{SystemFailures: 2})
+
+		nodeExecContext, err := nodeExecutor.newNodeExecContextDefault(ctx, "node-a", execContext, nodeLookup)
+		assert.NoError(t, err)
+		assert.False(t, nodeExecContext.NodeExecutionMetadata().IsInterruptible())
+		assert.False(t, nodeExecContext.NodeExecutionMetadata().IsInterruptibleDemoted())
+	})
+}
@@ -0,0 +1,91 @@
+package nodes
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/outcomes"
+)
+
+// attemptReuse reuses the outputs of the node from the previous execution named by the reuse annotation of the
+// execution, if the node succeeded in the previous execution and is called with the same inputs. Only the task and
+// workflow nodes at the top level of the execution are reused. The node is not reused, and runs as usual, whenever the
+// outcome of the previous execution cannot be established.
+func (c *nodeExecutor) attemptReuse(ctx context.Context, nCtx handler.NodeExecutionContext, nodeInputs *core.LiteralMap) (handler.PhaseInfo, error) {
+	if c.nodeOutcomes == nil || nCtx.ExecutionContext().GetParentInfo() != nil {
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	if kind := nCtx.Node().GetKind(); kind != v1alpha1.NodeKindTask && kind != v1alpha1.NodeKindWorkflow {
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	previous, ok := nCtx.ExecutionContext().GetAnnotations()[outcomes.ReuseResultsAnnotation]
+	if !ok || len(previous) == 0 {
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	execID := nCtx.ExecutionContext().GetExecutionID()
+	manifest, err := c.nodeOutcomes.Read(ctx, execID.GetProject(), execID.GetDomain(), previous)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read the node outcomes of execution [%s]. Error: %v", previous, err)
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	if manifest == nil {
+		logger.Debugf(ctx, "Execution [%s] recorded no node outcomes", previous)
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	outcome, ok := manifest.Nodes[nCtx.NodeID()]
+	if !ok {
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	previousInputs := &core.LiteralMap{}
+	if err := c.store.ReadProtobuf(ctx, outcome.InputsURI, previousInputs); err != nil {
+		logger.Warnf(ctx, "Failed to read the inputs of node [%s] of execution [%s]. Error: %v", nCtx.NodeID(), previous, err)
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	if nodeInputs == nil {
+		nodeInputs = &core.LiteralMap{}
+	}
+
+	if !proto.Equal(previousInputs, nodeInputs) {
+		logger.Infof(ctx, "Not reusing node [%s] of execution [%s], its inputs changed", nCtx.NodeID(), previous)
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	metadata, err := c.store.Head(ctx, outcome.OutputsURI)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to find the outputs of node [%s] of execution [%s]. Error: %v", nCtx.NodeID(), previous, err)
+		return handler.PhaseInfoUndefined, nil
+	}
+
+	outputFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
+	if metadata.Exists() {
+		if err := c.store.CopyRaw(ctx, outcome.OutputsURI, outputFile, storage.Options{}); err != nil {
+			logger.Errorf(ctx, "Failed to copy reused outputs. Error [%v]", err)
+			return handler.PhaseInfoUndefined, errors.Wrapf(errors.StorageError, nCtx.NodeID(), err, "Failed to copy reused node outputs [%v]", outcome.OutputsURI)
+		}
+	} else if err := c.store.WriteProtobuf(ctx, outputFile, storage.Options{}, &core.LiteralMap{}); err != nil {
+		logger.Errorf(ctx, "Failed to write protobuf (metadata). Error [%v]", err)
+		return handler.PhaseInfoUndefined, errors.Wrapf(errors.StorageError, nCtx.NodeID(), err, "Failed to store reused node outputs")
+	}
+
+	logger.Infof(ctx, "Reusing the outputs of node [%s] of execution [%s]", nCtx.NodeID(), previous)
+	c.metrics.ReusedOutcomes.Inc(ctx)
+	return handler.PhaseInfoRecovered(&handler.ExecutionInfo{
+		OutputInfo: &handler.OutputInfo{
+			OutputURI: outputFile,
+		},
+	}), nil
+}
@@ -0,0 +1,143 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	execMocks "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	nodeHandlerMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/outcomes"
+)
+
+func TestAttemptReuse(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	nodeOutcomes, err := outcomes.NewStore(ctx, config.NodeOutcomesConfig{Enabled: true}, store, "metadata")
+	assert.NoError(t, err)
+
+	inputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap()
+	outputs := coreutils.MustMakeLiteral(map[string]interface{}{"y": "foo"}).GetMap()
+
+	// The previous execution, in which n0 succeeded and n1 succeeded without outputs
+	previous := &v1alpha1.FlyteWorkflow{
+		ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "failed"},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:   v1alpha1.WorkflowPhaseFailed,
+			DataDir: "s3://bucket/failed",
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				"n0": {Phase: v1alpha1.NodePhaseSucceeded},
+				"n1": {Phase: v1alpha1.NodePhaseSucceeded},
+				"n2": {Phase: v1alpha1.NodePhaseFailed},
+			},
+		},
+		DataReferenceConstructor: store,
+	}
+
+	for _, id := range []v1alpha1.NodeID{"n0", "n1"} {
+		ns := previous.GetNodeExecutionStatus(ctx, id)
+		assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetInputsFile(ns.GetDataDir()), storage.Options{}, inputs))
+	}
+	n0 := previous.GetNodeExecutionStatus(ctx, "n0")
+	assert.NoError(t, store.WriteProtobuf(ctx, v1alpha1.GetOutputsFile(n0.GetOutputDir()), storage.Options{}, outputs))
+	assert.NoError(t, nodeOutcomes.Write(ctx, previous))
+
+	c := &nodeExecutor{
+		store:        store,
+		nodeOutcomes: nodeOutcomes,
+		metrics: &nodeMetrics{
+			ReusedOutcomes: labeled.NewCounter("reused_outcomes", "", promutils.NewTestScope()),
+		},
+	}
+
+	newNodeCtx := func(id v1alpha1.NodeID, kind v1alpha1.NodeKind, annotations map[string]string) *nodeHandlerMocks.NodeExecutionContext {
+		execContext := &execMocks.ExecutionContext{}
+		execContext.OnGetParentInfo().Return(nil)
+		execContext.OnGetAnnotations().Return(annotations)
+		execContext.OnGetExecutionID().Return(v1alpha1.ExecutionID{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "relaunch"},
+		})
+
+		n := &mocks.ExecutableNode{}
+		n.OnGetKind().Return(kind)
+
+		ns := &mocks.ExecutableNodeStatus{}
+		ns.OnGetOutputDir().Return(storage.DataReference("s3://bucket/relaunch/" + id + "/data/0"))
+
+		nCtx := &nodeHandlerMocks.NodeExecutionContext{}
+		nCtx.OnExecutionContext().Return(execContext)
+		nCtx.OnNode().Return(n)
+		nCtx.OnNodeID().Return(id)
+		nCtx.OnNodeStatus().Return(ns)
+		return nCtx
+	}
+
+	reuse := map[string]string{outcomes.ReuseResultsAnnotation: "failed"}
+
+	t.Run("reused", func(t *testing.T) {
+		p, err := c.attemptReuse(ctx, newNodeCtx("n0", v1alpha1.NodeKindTask, reuse), inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRecovered, p.GetPhase())
+		assert.Equal(t, storage.DataReference("s3://bucket/relaunch/n0/data/0/outputs.pb"), p.GetInfo().OutputInfo.OutputURI)
+
+		actual := &core.LiteralMap{}
+		assert.NoError(t, store.ReadProtobuf(ctx, p.GetInfo().OutputInfo.OutputURI, actual))
+		assert.Equal(t, outputs.String(), actual.String())
+	})
+
+	t.Run("reused without outputs", func(t *testing.T) {
+		p, err := c.attemptReuse(ctx, newNodeCtx("n1", v1alpha1.NodeKindWorkflow, reuse), inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRecovered, p.GetPhase())
+
+		actual := &core.LiteralMap{}
+		assert.NoError(t, store.ReadProtobuf(ctx, p.GetInfo().OutputInfo.OutputURI, actual))
+		assert.Empty(t, actual.GetLiterals())
+	})
+
+	t.Run("changed inputs", func(t *testing.T) {
+		changed := coreutils.MustMakeLiteral(map[string]interface{}{"x": 2}).GetMap()
+		p, err := c.attemptReuse(ctx, newNodeCtx("n0", v1alpha1.NodeKindTask, reuse), changed)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseUndefined, p.GetPhase())
+	})
+
+	t.Run("failed node", func(t *testing.T) {
+		p, err := c.attemptReuse(ctx, newNodeCtx("n2", v1alpha1.NodeKindTask, reuse), inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseUndefined, p.GetPhase())
+	})
+
+	t.Run("unknown execution", func(t *testing.T) {
+		p, err := c.attemptReuse(ctx, newNodeCtx("n0", v1alpha1.NodeKindTask,
+			map[string]string{outcomes.ReuseResultsAnnotation: "unknown"}), inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseUndefined, p.GetPhase())
+	})
+
+	t.Run("not annotated", func(t *testing.T) {
+		p, err := c.attemptReuse(ctx, newNodeCtx("n0", v1alpha1.NodeKindTask, nil), inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseUndefined, p.GetPhase())
+	})
+
+	t.Run("branch node", func(t *testing.T) {
+		p, err := c.attemptReuse(ctx, newNodeCtx("n0", v1alpha1.NodeKindBranch, reuse), inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseUndefined, p.GetPhase())
+	})
+}
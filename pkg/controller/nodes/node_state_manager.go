@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"context"
+	"sync"
 
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 
@@ -9,50 +10,81 @@ import (
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 )
 
+// nodeStateManager buffers the handler specific state of a node until it is applied to the node status at the end of a
+// round. Its methods are safe to call from multiple goroutines, e.g. from asynchronous plugin callbacks.
 type nodeStateManager struct {
+	lock       sync.RWMutex
 	nodeStatus v1alpha1.ExecutableNodeStatus
 	t          *handler.TaskNodeState
 	b          *handler.BranchNodeState
 	d          *handler.DynamicNodeState
 	w          *handler.WorkflowNodeState
+	a          *handler.ArrayNodeState
 }
 
 func (n *nodeStateManager) PutTaskNodeState(s handler.TaskNodeState) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	n.t = &s
 	return nil
 }
 
 func (n *nodeStateManager) PutBranchNode(s handler.BranchNodeState) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	n.b = &s
 	return nil
 }
 
 func (n *nodeStateManager) PutDynamicNodeState(s handler.DynamicNodeState) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	n.d = &s
 	return nil
 }
 
 func (n *nodeStateManager) PutWorkflowNodeState(s handler.WorkflowNodeState) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	n.w = &s
 	return nil
 }
 
-func (n nodeStateManager) GetTaskNodeState() handler.TaskNodeState {
+func (n *nodeStateManager) PutArrayNodeState(s handler.ArrayNodeState) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.a = &s
+	return nil
+}
+
+func (n *nodeStateManager) GetTaskNodeState() handler.TaskNodeState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
 	tn := n.nodeStatus.GetTaskNodeStatus()
 	if tn != nil {
 		return handler.TaskNodeState{
-			PluginPhase:        pluginCore.Phase(tn.GetPhase()),
-			PluginPhaseVersion: tn.GetPhaseVersion(),
-			PluginStateVersion: tn.GetPluginStateVersion(),
-			PluginState:        tn.GetPluginState(),
-			BarrierClockTick:   tn.GetBarrierClockTick(),
-			LastPhaseUpdatedAt: tn.GetLastPhaseUpdatedAt(),
+			PluginPhase:          pluginCore.Phase(tn.GetPhase()),
+			PluginPhaseVersion:   tn.GetPhaseVersion(),
+			PluginStateVersion:   tn.GetPluginStateVersion(),
+			PluginState:          tn.GetPluginState(),
+			BarrierClockTick:     tn.GetBarrierClockTick(),
+			LastPhaseUpdatedAt:   tn.GetLastPhaseUpdatedAt(),
+			ExecutionEnvironment: tn.GetExecutionEnvironment(),
 		}
 	}
 	return handler.TaskNodeState{}
 }
 
-func (n nodeStateManager) GetBranchNode() handler.BranchNodeState {
+func (n *nodeStateManager) GetBranchNode() handler.BranchNodeState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
 	bn := n.nodeStatus.GetBranchStatus()
 	bs := handler.BranchNodeState{}
 	if bn != nil {
@@ -62,19 +94,26 @@ func (n nodeStateManager) GetBranchNode() handler.BranchNodeState {
 	return bs
 }
 
-func (n nodeStateManager) GetDynamicNodeState() handler.DynamicNodeState {
+func (n *nodeStateManager) GetDynamicNodeState() handler.DynamicNodeState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
 	dn := n.nodeStatus.GetDynamicNodeStatus()
 	ds := handler.DynamicNodeState{}
 	if dn != nil {
 		ds.Phase = dn.GetDynamicNodePhase()
 		ds.Reason = dn.GetDynamicNodeReason()
 		ds.Error = dn.GetExecutionError()
+		ds.WorkflowChecksum = dn.GetWorkflowChecksum()
 	}
 
 	return ds
 }
 
-func (n nodeStateManager) GetWorkflowNodeState() handler.WorkflowNodeState {
+func (n *nodeStateManager) GetWorkflowNodeState() handler.WorkflowNodeState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
 	wn := n.nodeStatus.GetWorkflowNodeStatus()
 	ws := handler.WorkflowNodeState{}
 	if wn != nil {
@@ -83,11 +122,34 @@ func (n nodeStateManager) GetWorkflowNodeState() handler.WorkflowNodeState {
 	return ws
 }
 
+func (n *nodeStateManager) GetArrayNodeState() handler.ArrayNodeState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	an := n.nodeStatus.GetArrayNodeStatus()
+	as := handler.ArrayNodeState{}
+	if an != nil {
+		as.Phase = an.GetArrayNodePhase()
+		as.Error = an.GetExecutionError()
+		as.SubNodePhases = an.GetSubNodePhases()
+		as.SubNodeTaskPhases = an.GetSubNodeTaskPhases()
+		as.SubNodeRetryAttempts = an.GetSubNodeRetryAttempts()
+		as.SubNodeSystemFailures = an.GetSubNodeSystemFailures()
+		as.SubNodeTaskStates = an.GetSubNodeTaskStates()
+	}
+
+	return as
+}
+
 func (n *nodeStateManager) clearNodeStatus() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	n.t = nil
 	n.b = nil
 	n.d = nil
 	n.w = nil
+	n.a = nil
 	n.nodeStatus.ClearLastAttemptStartedAt()
 }
 
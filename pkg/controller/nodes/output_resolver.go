@@ -8,6 +8,7 @@ import (
 	"github.com/flyteorg/flytestdlib/storage"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -36,6 +37,8 @@ func CreateAliasMap(aliases []v1alpha1.Alias) map[string]string {
 // A simple output resolver that expects an outputs.pb at the data directory of the node.
 type remoteFileOutputResolver struct {
 	store *storage.DataStore
+	// Outputs of upstream nodes read ahead of time, consulted before reading the outputs from the store.
+	prefetched *inputPrefetcher
 }
 
 func (r remoteFileOutputResolver) ExtractOutput(ctx context.Context, nl executors.NodeLookup, n v1alpha1.ExecutableNode,
@@ -43,15 +46,17 @@ func (r remoteFileOutputResolver) ExtractOutput(ctx context.Context, nl executor
 	nodeStatus := nl.GetNodeExecutionStatus(ctx, n.GetID())
 	outputsFileRef := v1alpha1.GetOutputsFile(nodeStatus.GetOutputDir())
 
-	index, actualVar, err := ParseVarName(bindToVar)
+	index, actualVar, err := parseOutputVar(ctx, n, bindToVar)
 	if err != nil {
 		return nil, err
 	}
 
-	aliasMap := CreateAliasMap(n.GetOutputAlias())
-	if variable, ok := aliasMap[actualVar]; ok {
-		logger.Debugf(ctx, "Mapping [%v].[%v] -> [%v].[%v]", n.GetID(), variable, n.GetID(), bindToVar)
-		actualVar = variable
+	if d, ok := r.prefetched.Get(outputsFileRef); ok {
+		if index == nil {
+			return getSingleOutput(n.GetID(), d, actualVar)
+		}
+
+		return getSubtaskOutput(n.GetID(), d, *index, actualVar)
 	}
 
 	if index == nil {
@@ -61,8 +66,45 @@ func (r remoteFileOutputResolver) ExtractOutput(ctx context.Context, nl executor
 	return resolveSubtaskOutput(ctx, r.store, n.GetID(), outputsFileRef, *index, actualVar)
 }
 
+// Parses the variable a promise binds to into the index of the subtask, if any, and the output variable of the node it
+// refers to, with output aliases of the node resolved.
+func parseOutputVar(ctx context.Context, n v1alpha1.ExecutableNode, bindToVar VarName) (index *int, actualVar VarName, err error) {
+	index, actualVar, err = ParseVarName(bindToVar)
+	if err != nil {
+		return nil, "", err
+	}
+
+	aliasMap := CreateAliasMap(n.GetOutputAlias())
+	if variable, ok := aliasMap[actualVar]; ok {
+		logger.Debugf(ctx, "Mapping [%v].[%v] -> [%v].[%v]", n.GetID(), variable, n.GetID(), bindToVar)
+		actualVar = variable
+	}
+
+	return index, actualVar, nil
+}
+
 func resolveSubtaskOutput(ctx context.Context, store storage.ProtobufStore, nodeID string, outputsFileRef storage.DataReference,
 	idx int, varName string) (*core.Literal, error) {
+	d, err := readOutputs(ctx, store, nodeID, outputsFileRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSubtaskOutput(nodeID, d, idx, varName)
+}
+
+func resolveSingleOutput(ctx context.Context, store storage.ProtobufStore, nodeID string, outputsFileRef storage.DataReference,
+	varName string) (*core.Literal, error) {
+	d, err := readOutputs(ctx, store, nodeID, outputsFileRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSingleOutput(nodeID, d, varName)
+}
+
+func readOutputs(ctx context.Context, store storage.ProtobufStore, nodeID string, outputsFileRef storage.DataReference) (
+	*core.LiteralMap, error) {
 	d := &core.LiteralMap{}
 	// TODO we should do a head before read and if head results in not found then fail
 	if err := store.ReadProtobuf(ctx, outputsFileRef, d); err != nil {
@@ -75,6 +117,15 @@ func resolveSubtaskOutput(ctx context.Context, store storage.ProtobufStore, node
 			"Outputs not found at [%v]", outputsFileRef)
 	}
 
+	if err := common.RehydrateLiterals(ctx, store, d); err != nil {
+		return nil, errors.Wrapf(errors.CausedByError, nodeID, err, "Failed to rehydrate offloaded outputs from [%v]",
+			outputsFileRef)
+	}
+
+	return d, nil
+}
+
+func getSubtaskOutput(nodeID string, d *core.LiteralMap, idx int, varName string) (*core.Literal, error) {
 	l, ok := d.Literals[varName]
 	if !ok {
 		return nil, errors.Errorf(errors.BadSpecificationError, nodeID, "Output of array tasks is expected to be "+
@@ -95,20 +146,7 @@ func resolveSubtaskOutput(ctx context.Context, store storage.ProtobufStore, node
 	return literals[idx], nil
 }
 
-func resolveSingleOutput(ctx context.Context, store storage.ProtobufStore, nodeID string, outputsFileRef storage.DataReference,
-	varName string) (*core.Literal, error) {
-
-	d := &core.LiteralMap{}
-	if err := store.ReadProtobuf(ctx, outputsFileRef, d); err != nil {
-		return nil, errors.Wrapf(errors.CausedByError, nodeID, err, "Failed to GetPrevious data from outputDir [%v]",
-			outputsFileRef)
-	}
-
-	if d.Literals == nil {
-		return nil, errors.Errorf(errors.OutputsNotFoundError, nodeID,
-			"Outputs not found at [%v]", outputsFileRef)
-	}
-
+func getSingleOutput(nodeID string, d *core.LiteralMap, varName string) (*core.Literal, error) {
 	l, ok := d.Literals[varName]
 	if !ok {
 		return nil, errors.Errorf(errors.OutputsNotFoundError, nodeID,
@@ -0,0 +1,146 @@
+package nodes
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+)
+
+// Returns the number of nodes of the execution that may be evaluated concurrently. Task and launch plan nodes reserve
+// the parallelism of the execution while they're handled, so the concurrency is limited to the remaining parallelism
+// budget, beyond which nodes would only wait for a reservation to be refused.
+func (c *nodeExecutor) getEvaluationParallelism(execContext executors.ExecutionContext) int {
+	parallelism := c.maxEvaluationParallelism
+	if maxParallelism := execContext.GetExecutionConfig().MaxParallelism; maxParallelism > 0 {
+		remaining := execContext.RemainingParallelism(maxParallelism)
+		if remaining == 0 {
+			return 1
+		}
+
+		if remaining < uint32(parallelism) {
+			parallelism = int(remaining)
+		}
+	}
+
+	return parallelism
+}
+
+// Returns the nodes, in the given order, that can be evaluated concurrently. These are the nodes that are ready to be
+// handled, rather than traversed to their downstream nodes, and that don't depend on any other of these nodes, since
+// handling a node reads the status of its upstream nodes.
+func concurrentlyEvaluableNodes(ctx context.Context, dag executors.DAGStructure, nl executors.NodeLookup,
+	nodeIDs []v1alpha1.NodeID) []v1alpha1.ExecutableNode {
+	candidates := make(map[v1alpha1.NodeID]v1alpha1.ExecutableNode, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		n, ok := nl.GetNode(nodeID)
+		if !ok {
+			continue
+		}
+
+		nodeStatus := nl.GetNodeExecutionStatus(ctx, nodeID)
+		if canHandleNode(nodeStatus.GetPhase()) && !nodeStatus.IsDirty() {
+			candidates[nodeID] = n
+		}
+	}
+
+	nodes := make([]v1alpha1.ExecutableNode, 0, len(candidates))
+	for _, nodeID := range nodeIDs {
+		n, ok := candidates[nodeID]
+		if !ok {
+			continue
+		}
+
+		upstreamNodes, err := dag.ToNode(nodeID)
+		if err != nil {
+			// Left to the serial evaluation, which reports the error.
+			continue
+		}
+
+		independent := true
+		for _, upstreamNodeID := range upstreamNodes {
+			if _, ok := candidates[upstreamNodeID]; ok {
+				independent = false
+				break
+			}
+		}
+
+		if independent {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+// Evaluates the nodes that are ready and independent of each other concurrently, bounded by the evaluation parallelism.
+// It returns the states of the evaluated nodes, the remaining nodes are evaluated serially when traversing the DAG.
+func (c *nodeExecutor) evaluateConcurrently(ctx context.Context, execContext executors.ExecutionContext,
+	dag executors.DAGStructure, nl executors.NodeLookup, nodeIDs []v1alpha1.NodeID) (map[v1alpha1.NodeID]executors.NodeStatus, error) {
+	if c.maxEvaluationParallelism <= 1 || len(nodeIDs) <= 1 {
+		return nil, nil
+	}
+
+	parallelism := c.getEvaluationParallelism(execContext)
+	if parallelism <= 1 {
+		return nil, nil
+	}
+
+	// The failure node receives the error context through its lookup and is always evaluated on its own.
+	if _, ok := nl.(executors.FailureNodeLookup); ok {
+		return nil, nil
+	}
+
+	nodes := concurrentlyEvaluableNodes(ctx, dag, nl, nodeIDs)
+	if len(nodes) <= 1 {
+		return nil, nil
+	}
+
+	// Every node only writes its own status. The statuses of the upstream nodes they read, and the status maps new
+	// statuses are added to, are guarded by per status locks.
+	logger.Debugf(ctx, "Evaluating [%d] nodes concurrently, with parallelism [%d]", len(nodes), parallelism)
+	states := make([]executors.NodeStatus, len(nodes))
+	sem := make(chan struct{}, parallelism)
+	// The nodes are not evaluated with a context that's cancelled when one of them fails, so that no evaluation is
+	// interrupted halfway.
+	var g errgroup.Group
+	for i, n := range nodes {
+		i, n := i, n
+		g.Go(func() (err error) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			defer c.recoverEvaluationPanic(ctx, n.GetID(), &err)
+
+			states[i], err = c.RecursiveNodeHandler(ctx, execContext, dag, nl, n)
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	evaluated := make(map[v1alpha1.NodeID]executors.NodeStatus, len(nodes))
+	for i, n := range nodes {
+		evaluated[n.GetID()] = states[i]
+	}
+
+	return evaluated, nil
+}
+
+// A panic in a goroutine evaluating a node can't be recovered by the caller evaluating the workflow, so it's returned as
+// an error instead.
+func (c *nodeExecutor) recoverEvaluationPanic(ctx context.Context, nodeID v1alpha1.NodeID, err *error) {
+	if r := recover(); r != nil {
+		c.metrics.HandlerPanic.Inc(ctx)
+		stack := debug.Stack()
+		logger.Errorf(ctx, "Panic when evaluating node [%s] concurrently. Panic: %v, Stack: [%s]", nodeID, r, string(stack))
+		*err = errors.Errorf(errors.HandlerPanic, nodeID, "panic when evaluating the node: %v, Stack: [%s]", r,
+			truncatePanicStack(stack))
+	}
+}
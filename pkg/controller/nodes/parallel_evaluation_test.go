@@ -0,0 +1,161 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	nodeHandlerMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+	nodeMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan"
+)
+
+func TestNodeExecutor_getEvaluationParallelism(t *testing.T) {
+	c := &nodeExecutor{maxEvaluationParallelism: 4}
+	newExecContext := func(maxParallelism, remaining uint32) *mocks.ExecutionContext {
+		execContext := &mocks.ExecutionContext{}
+		execContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{MaxParallelism: maxParallelism})
+		execContext.OnRemainingParallelism(maxParallelism).Return(remaining)
+		return execContext
+	}
+
+	assert.Equal(t, 4, c.getEvaluationParallelism(newExecContext(0, 0)))
+	assert.Equal(t, 4, c.getEvaluationParallelism(newExecContext(10, 8)))
+	assert.Equal(t, 2, c.getEvaluationParallelism(newExecContext(10, 2)))
+	assert.Equal(t, 1, c.getEvaluationParallelism(newExecContext(10, 0)))
+}
+
+func TestConcurrentlyEvaluableNodes(t *testing.T) {
+	ctx := context.TODO()
+	nodes := map[v1alpha1.NodeID]v1alpha1.ExecutableNode{}
+	statuses := map[v1alpha1.NodeID]v1alpha1.ExecutableNodeStatus{}
+	addNode := func(id v1alpha1.NodeID, phase v1alpha1.NodePhase) {
+		nodes[id] = &v1alpha1.NodeSpec{ID: id}
+		statuses[id] = &v1alpha1.NodeStatus{Phase: phase}
+	}
+
+	addNode("queued", v1alpha1.NodePhaseQueued)
+	addNode("running", v1alpha1.NodePhaseRunning)
+	addNode("succeeded", v1alpha1.NodePhaseSucceeded)
+	addNode("dependent", v1alpha1.NodePhaseNotYetStarted)
+	addNode("dirty", v1alpha1.NodePhaseRunning)
+	statuses["dirty"].(*v1alpha1.NodeStatus).SetDirty()
+
+	dag := &mocks.DAGStructure{}
+	dag.OnToNode("queued").Return([]v1alpha1.NodeID{v1alpha1.StartNodeID}, nil)
+	dag.OnToNode("running").Return([]v1alpha1.NodeID{v1alpha1.StartNodeID}, nil)
+	dag.OnToNode("dependent").Return([]v1alpha1.NodeID{v1alpha1.StartNodeID, "running"}, nil)
+
+	nl := executors.NewTestNodeLookup(nodes, statuses)
+	evaluable := concurrentlyEvaluableNodes(ctx, dag, nl, []v1alpha1.NodeID{"queued", "succeeded", "dependent", "running", "dirty", "unknown"})
+
+	ids := make([]v1alpha1.NodeID, 0, len(evaluable))
+	for _, n := range evaluable {
+		ids = append(ids, n.GetID())
+	}
+
+	// Succeeded nodes are traversed, dirty nodes were already evaluated in this round and dependent nodes read the
+	// status of the nodes they depend on.
+	assert.Equal(t, []v1alpha1.NodeID{"queued", "running"}, ids)
+}
+
+func TestNodeExecutor_evaluateConcurrently_Serial(t *testing.T) {
+	c := &nodeExecutor{maxEvaluationParallelism: 1}
+	evaluated, err := c.evaluateConcurrently(context.TODO(), &mocks.ExecutionContext{}, &mocks.DAGStructure{},
+		executors.NewTestNodeLookup(nil, nil), []v1alpha1.NodeID{"a", "b"})
+	assert.NoError(t, err)
+	assert.Nil(t, evaluated)
+}
+
+// Evaluates many ready siblings concurrently, meant to be run with -race.
+func TestNodeExecutor_evaluateConcurrently_ReadySiblings(t *testing.T) {
+	ctx := context.Background()
+	store := createInmemoryDataStore(t, promutils.NewTestScope())
+	adminClient := launchplan.NewFailFastLaunchPlanExecutor()
+	execIface, err := NewExecutor(ctx, config.GetConfig().NodeConfig, store, func(workflowID v1alpha1.WorkflowID) {},
+		eventMocks.NewMockEventSink(), adminClient, adminClient, 10, "s3://bucket", fakeKubeClient, catalogClient,
+		recoveryClient, eventConfig, testClusterID, promutils.NewTestScope())
+	assert.NoError(t, err)
+	exec := execIface.(*nodeExecutor)
+	exec.maxEvaluationParallelism = 16
+
+	// Handling a node starts it, like the task handler does.
+	h := &nodeHandlerMocks.Node{}
+	h.OnHandleMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(handler.NodeExecutionContext).ExecutionContext().IncrementParallelism()
+	}).Return(handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil)
+	h.OnFinalizeRequired().Return(false)
+	hf := &nodeMocks.HandlerFactory{}
+	hf.OnGetHandler(v1alpha1.NodeKindTask).Return(h, nil)
+	exec.nodeHandlerFactory = hf
+
+	const siblings = 64
+	const maxParallelism = 24
+	tID := taskID
+	wf := &v1alpha1.FlyteWorkflow{
+		Tasks: map[v1alpha1.TaskID]*v1alpha1.TaskSpec{
+			taskID: {TaskTemplate: &core.TaskTemplate{}},
+		},
+		ExecutionConfig: v1alpha1.ExecutionConfig{MaxParallelism: maxParallelism},
+		Status: v1alpha1.WorkflowStatus{
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				v1alpha1.StartNodeID: {Phase: v1alpha1.NodePhaseSucceeded},
+			},
+			DataDir: "data",
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "wf",
+			Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+				v1alpha1.StartNodeID: {ID: v1alpha1.StartNodeID, Kind: v1alpha1.NodeKindStart},
+			},
+			Connections: v1alpha1.Connections{
+				Upstream:   map[v1alpha1.NodeID][]v1alpha1.NodeID{},
+				Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{},
+			},
+		},
+		DataReferenceConstructor: store,
+		RawOutputDataConfig: v1alpha1.RawOutputDataConfig{
+			RawOutputDataConfig: &admin.RawOutputDataConfig{OutputLocationPrefix: ""},
+		},
+	}
+
+	for i := 0; i < siblings; i++ {
+		id := fmt.Sprintf("n%d", i)
+		wf.WorkflowSpec.Nodes[id] = &v1alpha1.NodeSpec{ID: id, TaskRef: &tID, Kind: v1alpha1.NodeKindTask}
+		wf.Status.NodeStatus[id] = &v1alpha1.NodeStatus{Phase: v1alpha1.NodePhaseQueued}
+		wf.WorkflowSpec.Connections.Upstream[id] = []v1alpha1.NodeID{v1alpha1.StartNodeID}
+		wf.WorkflowSpec.Connections.Downstream[v1alpha1.StartNodeID] = append(
+			wf.WorkflowSpec.Connections.Downstream[v1alpha1.StartNodeID], id)
+	}
+
+	cf := executors.InitializeControlFlow()
+	eCtx := executors.NewExecutionContext(wf, wf, nil, nil, cf)
+	startNode, _ := wf.GetNode(v1alpha1.StartNodeID)
+	s, err := exec.RecursiveNodeHandler(ctx, eCtx, wf, wf, startNode)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodePhaseRunning.String(), s.NodePhase.String())
+
+	// Nodes that could not reserve the parallelism are left queued for the next round.
+	running := uint32(0)
+	for i := 0; i < siblings; i++ {
+		if wf.Status.NodeStatus[fmt.Sprintf("n%d", i)].GetPhase() == v1alpha1.NodePhaseRunning {
+			running++
+		}
+	}
+
+	// The reservations of the started nodes became their parallelism, so the max parallelism is used up exactly.
+	assert.Equal(t, uint32(maxParallelism), running)
+	assert.Equal(t, running, cf.CurrentParallelism())
+}
@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/flyteorg/flytestdlib/logger"
@@ -10,6 +11,7 @@ import (
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 )
 
 // Special enum to indicate if the node under consideration is ready to be executed or should be skipped
@@ -109,6 +111,28 @@ func CanExecute(ctx context.Context, dag executors.DAGStructure, nl executors.No
 	return PredicatePhaseReady, nil
 }
 
+// GetSkipReason returns the structured reason why a node that cannot execute is skipped, i.e. the first of its upstream
+// nodes that was skipped, failed or timed out.
+func GetSkipReason(ctx context.Context, dag executors.DAGStructure, nl executors.NodeLookup, node v1alpha1.BaseNode) (code, message string) {
+	upstreamNodes, err := dag.ToNode(node.GetID())
+	if err != nil {
+		return handler.SkipReasonUpstreamSkipped, "Node skipped as an upstream node was skipped"
+	}
+
+	for _, upstreamNodeID := range upstreamNodes {
+		switch nl.GetNodeExecutionStatus(ctx, upstreamNodeID).GetPhase() {
+		case v1alpha1.NodePhaseFailed:
+			return handler.SkipReasonUpstreamFailed, fmt.Sprintf("Node skipped as upstream node [%v] failed", upstreamNodeID)
+		case v1alpha1.NodePhaseTimedOut:
+			return handler.SkipReasonUpstreamTimedOut, fmt.Sprintf("Node skipped as upstream node [%v] timed out", upstreamNodeID)
+		case v1alpha1.NodePhaseSkipped:
+			return handler.SkipReasonUpstreamSkipped, fmt.Sprintf("Node skipped as upstream node [%v] was skipped", upstreamNodeID)
+		}
+	}
+
+	return handler.SkipReasonUpstreamSkipped, "Node skipped as an upstream node was skipped"
+}
+
 func GetParentNodeMaxEndTime(ctx context.Context, dag executors.DAGStructure, nl executors.NodeLookup, node v1alpha1.BaseNode) (t v1.Time, err error) {
 	zeroTime := v1.NewTime(time.Time{})
 	nodeID := node.GetID()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -590,3 +591,43 @@ func TestCanExecute(t *testing.T) {
 		assert.Equal(t, PredicatePhaseNotReady, p)
 	})
 }
+
+func TestGetSkipReason(t *testing.T) {
+	ctx := context.Background()
+	mockNode := &mocks.BaseNode{}
+	mockNode.OnGetID().Return("n2")
+
+	newWorkflow := func(n0Phase, n1Phase v1alpha1.NodePhase) *mocks.ExecutableWorkflow {
+		mockN0Status := &mocks.ExecutableNodeStatus{}
+		mockN0Status.OnGetPhase().Return(n0Phase)
+		mockN1Status := &mocks.ExecutableNodeStatus{}
+		mockN1Status.OnGetPhase().Return(n1Phase)
+
+		mockWf := &mocks.ExecutableWorkflow{}
+		mockWf.OnGetNodeExecutionStatus(ctx, "n0").Return(mockN0Status)
+		mockWf.OnGetNodeExecutionStatus(ctx, "n1").Return(mockN1Status)
+		mockWf.OnToNode("n2").Return([]v1alpha1.NodeID{"n0", "n1"}, nil)
+		return mockWf
+	}
+
+	tests := []struct {
+		name    string
+		n0Phase v1alpha1.NodePhase
+		n1Phase v1alpha1.NodePhase
+		code    string
+		message string
+	}{
+		{"upstream failed", v1alpha1.NodePhaseSucceeded, v1alpha1.NodePhaseFailed, handler.SkipReasonUpstreamFailed, "Node skipped as upstream node [n1] failed"},
+		{"upstream timed out", v1alpha1.NodePhaseTimedOut, v1alpha1.NodePhaseSucceeded, handler.SkipReasonUpstreamTimedOut, "Node skipped as upstream node [n0] timed out"},
+		{"upstream skipped", v1alpha1.NodePhaseSkipped, v1alpha1.NodePhaseFailed, handler.SkipReasonUpstreamSkipped, "Node skipped as upstream node [n0] was skipped"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockWf := newWorkflow(test.n0Phase, test.n1Phase)
+			code, message := GetSkipReason(ctx, mockWf, mockWf, mockNode)
+			assert.Equal(t, test.code, code)
+			assert.Equal(t, test.message, message)
+		})
+	}
+}
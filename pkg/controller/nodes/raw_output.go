@@ -0,0 +1,77 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+)
+
+const (
+	minShardPrefixLength = 1
+	// Longer prefixes would precompute more than a million shards.
+	maxShardPrefixLength = 3
+	base36               = 36
+)
+
+// Returns all base36 shard prefixes of the given length, e.g. the 1296 prefixes of two characters.
+func base36ShardPrefixes(length int) []string {
+	count := 1
+	for i := 0; i < length; i++ {
+		count *= base36
+	}
+
+	prefixes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		prefix := strconv.FormatInt(int64(i), base36)
+		prefixes = append(prefixes, strings.Repeat("0", length-len(prefix))+prefix)
+	}
+
+	return prefixes
+}
+
+// Returns the selector of the shard prefixes the raw outputs of nodes are written under. The raw outputs are spread
+// across the shards by the hash of the unique IDs of the nodes, so that object stores don't hot-spot on a single prefix.
+func newRawOutputShardSelector(ctx context.Context, cfg config.RawOutputConfig) (ioutils.ShardSelector, error) {
+	if cfg.ShardPrefixLength < minShardPrefixLength || cfg.ShardPrefixLength > maxShardPrefixLength {
+		return nil, fmt.Errorf("shard prefix length [%d] of raw outputs must be between [%d] and [%d]",
+			cfg.ShardPrefixLength, minShardPrefixLength, maxShardPrefixLength)
+	}
+
+	if cfg.ShardPrefixLength == 2 {
+		return ioutils.NewBase36PrefixShardSelector(ctx)
+	}
+
+	return ioutils.NewConstantShardSelector(base36ShardPrefixes(cfg.ShardPrefixLength)), nil
+}
+
+// Returns the prefix the raw outputs of the nodes of the execution are written under. The raw output data prefix of the
+// execution config takes precedence over the raw output data config of the workflow, which takes precedence over the
+// default. If enabled, the raw outputs of every execution are written to a directory of its own under the prefix.
+func (c *nodeExecutor) getRawOutputPrefix(ctx context.Context, executionContext executors.ExecutionContext) (storage.DataReference, error) {
+	rawOutputPrefix := c.defaultDataSandbox
+	if executionContext.GetRawOutputDataConfig().RawOutputDataConfig != nil && len(executionContext.GetRawOutputDataConfig().OutputLocationPrefix) > 0 {
+		rawOutputPrefix = storage.DataReference(executionContext.GetRawOutputDataConfig().OutputLocationPrefix)
+	}
+
+	if prefix := executionContext.GetExecutionConfig().RawOutputDataPrefix; len(prefix) > 0 {
+		rawOutputPrefix = storage.DataReference(prefix)
+	}
+
+	if !c.rawOutputPerExecution {
+		return rawOutputPrefix, nil
+	}
+
+	executionID := executionContext.GetExecutionID()
+	if executionID.WorkflowExecutionIdentifier == nil {
+		return rawOutputPrefix, nil
+	}
+
+	return c.store.ConstructReference(ctx, rawOutputPrefix, executionID.GetName())
+}
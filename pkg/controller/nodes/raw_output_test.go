@@ -0,0 +1,82 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	mocks4 "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+)
+
+func TestBase36ShardPrefixes(t *testing.T) {
+	prefixes := base36ShardPrefixes(1)
+	assert.Len(t, prefixes, 36)
+	assert.Equal(t, "0", prefixes[0])
+	assert.Equal(t, "z", prefixes[35])
+
+	prefixes = base36ShardPrefixes(2)
+	assert.Len(t, prefixes, 1296)
+	assert.Equal(t, "00", prefixes[0])
+	assert.Equal(t, "0z", prefixes[35])
+	assert.Equal(t, "10", prefixes[36])
+	assert.Equal(t, "zz", prefixes[1295])
+}
+
+func TestNewRawOutputShardSelector(t *testing.T) {
+	ctx := context.TODO()
+	for _, length := range []int{1, 2, 3} {
+		s, err := newRawOutputShardSelector(ctx, config.RawOutputConfig{ShardPrefixLength: length})
+		assert.NoError(t, err)
+		shard, err := s.GetShardPrefix(ctx, []byte("n0-0-n1"))
+		assert.NoError(t, err)
+		assert.Len(t, shard, length)
+	}
+
+	_, err := newRawOutputShardSelector(ctx, config.RawOutputConfig{})
+	assert.Error(t, err)
+	_, err = newRawOutputShardSelector(ctx, config.RawOutputConfig{ShardPrefixLength: 4})
+	assert.Error(t, err)
+}
+
+func TestNodeExecutor_GetRawOutputPrefix(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	executionID := v1alpha1.WorkflowExecutionIdentifier{
+		WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "exec"},
+	}
+
+	execContext := func(rawOutputDataConfig v1alpha1.RawOutputDataConfig, executionConfig v1alpha1.ExecutionConfig) *mocks4.ExecutionContext {
+		e := &mocks4.ExecutionContext{}
+		e.OnGetRawOutputDataConfig().Return(rawOutputDataConfig)
+		e.OnGetExecutionConfig().Return(executionConfig)
+		e.OnGetExecutionID().Return(executionID)
+		return e
+	}
+
+	c := &nodeExecutor{store: store, defaultDataSandbox: "s3://default"}
+	prefix, err := c.getRawOutputPrefix(ctx, execContext(v1alpha1.RawOutputDataConfig{}, v1alpha1.ExecutionConfig{}))
+	assert.NoError(t, err)
+	assert.Equal(t, storage.DataReference("s3://default"), prefix)
+
+	workflowConfig := v1alpha1.RawOutputDataConfig{RawOutputDataConfig: &admin.RawOutputDataConfig{OutputLocationPrefix: "s3://workflow"}}
+	prefix, err = c.getRawOutputPrefix(ctx, execContext(workflowConfig, v1alpha1.ExecutionConfig{}))
+	assert.NoError(t, err)
+	assert.Equal(t, storage.DataReference("s3://workflow"), prefix)
+
+	prefix, err = c.getRawOutputPrefix(ctx, execContext(workflowConfig, v1alpha1.ExecutionConfig{RawOutputDataPrefix: "s3://execution"}))
+	assert.NoError(t, err)
+	assert.Equal(t, storage.DataReference("s3://execution"), prefix)
+
+	c.rawOutputPerExecution = true
+	prefix, err = c.getRawOutputPrefix(ctx, execContext(workflowConfig, v1alpha1.ExecutionConfig{}))
+	assert.NoError(t, err)
+	assert.Equal(t, storage.DataReference("s3://workflow/exec"), prefix)
+}
@@ -11,6 +11,45 @@ import (
 )
 
 func ResolveBindingData(ctx context.Context, outputResolver OutputResolver, nl executors.NodeLookup, bindingData *core.BindingData) (*core.Literal, error) {
+	return resolveBindingData(ctx, bindingData, func(promise *core.OutputReference) (*core.Literal, error) {
+		n, err := getUpstreamNode(nl, promise)
+		if err != nil {
+			return nil, err
+		}
+
+		return outputResolver.ExtractOutput(ctx, nl, n, promise.GetVar())
+	})
+}
+
+// Returns the upstream node whose output the promise refers to.
+func getUpstreamNode(nl executors.NodeLookup, promise *core.OutputReference) (v1alpha1.ExecutableNode, error) {
+	upstreamNodeID := promise.GetNodeId()
+	bindToVar := promise.GetVar()
+
+	if nl == nil {
+		return nil, errors.Errorf(errors.IllegalStateError, upstreamNodeID,
+			"Trying to resolve output from previous node, without providing the workflow for variable [%s]",
+			bindToVar)
+	}
+
+	if upstreamNodeID == "" {
+		return nil, errors.Errorf(errors.BadSpecificationError, "missing",
+			"No nodeId (missing) specified for binding in Workflow.")
+	}
+
+	n, ok := nl.GetNode(upstreamNodeID)
+	if !ok {
+		return nil, errors.Errorf(errors.IllegalStateError, "id", upstreamNodeID,
+			"Undefined node in Workflow")
+	}
+
+	return n, nil
+}
+
+// Resolves the binding data to a literal, the outputs of upstream nodes the binding data refers to are resolved by
+// resolvePromise.
+func resolveBindingData(ctx context.Context, bindingData *core.BindingData,
+	resolvePromise func(promise *core.OutputReference) (*core.Literal, error)) (*core.Literal, error) {
 	logger.Debugf(ctx, "Resolving binding data")
 
 	literal := &core.Literal{}
@@ -24,7 +63,7 @@ func ResolveBindingData(ctx context.Context, outputResolver OutputResolver, nl e
 		logger.Debugf(ctx, "bindingData.GetValue() [%v] is of type Collection", bindingData.GetValue())
 		literalCollection := make([]*core.Literal, 0, len(bindingData.GetCollection().GetBindings()))
 		for _, b := range bindingData.GetCollection().GetBindings() {
-			l, err := ResolveBindingData(ctx, outputResolver, nl, b)
+			l, err := resolveBindingData(ctx, b, resolvePromise)
 			if err != nil {
 				logger.Debugf(ctx, "Failed to resolve binding data. Error: [%v]", err)
 				return nil, err
@@ -42,7 +81,7 @@ func ResolveBindingData(ctx context.Context, outputResolver OutputResolver, nl e
 		logger.Debugf(ctx, "bindingData.GetValue() [%v] is of type Map", bindingData.GetValue())
 		literalMap := make(map[string]*core.Literal, len(bindingData.GetMap().GetBindings()))
 		for k, v := range bindingData.GetMap().GetBindings() {
-			l, err := ResolveBindingData(ctx, outputResolver, nl, v)
+			l, err := resolveBindingData(ctx, v, resolvePromise)
 			if err != nil {
 				logger.Debugf(ctx, "Failed to resolve binding data. Error: [%v]", err)
 				return nil, err
@@ -58,28 +97,7 @@ func ResolveBindingData(ctx context.Context, outputResolver OutputResolver, nl e
 		}
 	case *core.BindingData_Promise:
 		logger.Debugf(ctx, "bindingData.GetValue() [%v] is of type Promise", bindingData.GetValue())
-
-		upstreamNodeID := bindingData.GetPromise().GetNodeId()
-		bindToVar := bindingData.GetPromise().GetVar()
-
-		if nl == nil {
-			return nil, errors.Errorf(errors.IllegalStateError, upstreamNodeID,
-				"Trying to resolve output from previous node, without providing the workflow for variable [%s]",
-				bindToVar)
-		}
-
-		if upstreamNodeID == "" {
-			return nil, errors.Errorf(errors.BadSpecificationError, "missing",
-				"No nodeId (missing) specified for binding in Workflow.")
-		}
-
-		n, ok := nl.GetNode(upstreamNodeID)
-		if !ok {
-			return nil, errors.Errorf(errors.IllegalStateError, "id", upstreamNodeID,
-				"Undefined node in Workflow")
-		}
-
-		return outputResolver.ExtractOutput(ctx, nl, n, bindToVar)
+		return resolvePromise(bindingData.GetPromise())
 	case *core.BindingData_Scalar:
 		logger.Debugf(ctx, "bindingData.GetValue() [%v] is of type Scalar", bindingData.GetValue())
 		literal.Value = &core.Literal_Scalar{Scalar: bindingData.GetScalar()}
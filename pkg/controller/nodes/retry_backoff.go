@@ -0,0 +1,44 @@
+package nodes
+
+import (
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// nextRetryBackoff returns the backoff before the next attempt of a node that failed with a system error. The first
+// backoff of a node starts at the base delay and captures the multiplier and cap, every subsequent one scales the delay
+// of the previous backoff. The jitter is added to the time of the next attempt only, so that it does not compound.
+func nextRetryBackoff(cfg config.RetryBackoffConfig, prev *v1alpha1.RetryBackoff, now time.Time) *v1alpha1.RetryBackoff {
+	next := &v1alpha1.RetryBackoff{
+		Delay:      v1.Duration{Duration: cfg.BaseDelay.Duration},
+		Multiplier: uint32(cfg.Multiplier),
+		MaxDelay:   v1.Duration{Duration: cfg.MaxDelay.Duration},
+	}
+
+	if prev != nil {
+		next.Multiplier = prev.Multiplier
+		next.MaxDelay = prev.MaxDelay
+		next.Delay = prev.Delay
+		if prev.Multiplier > 1 {
+			next.Delay.Duration *= time.Duration(prev.Multiplier)
+		}
+	}
+
+	if next.MaxDelay.Duration > 0 && next.Delay.Duration > next.MaxDelay.Duration {
+		next.Delay = next.MaxDelay
+	}
+
+	delay := next.Delay.Duration
+	if cfg.JitterFactor > 0 {
+		delay = wait.Jitter(delay, cfg.JitterFactor)
+	}
+
+	nextAttemptAt := v1.NewTime(now.Add(delay))
+	next.NextAttemptAt = &nextAttemptAt
+	return next
+}
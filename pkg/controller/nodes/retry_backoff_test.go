@@ -0,0 +1,144 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	nodeHandlerMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+)
+
+func TestNextRetryBackoff(t *testing.T) {
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	cfg := config.RetryBackoffConfig{
+		BaseDelay:  stdConfig.Duration{Duration: 10 * time.Second},
+		Multiplier: 3,
+		MaxDelay:   stdConfig.Duration{Duration: time.Minute},
+	}
+
+	t.Run("first", func(t *testing.T) {
+		b := nextRetryBackoff(cfg, nil, now)
+		assert.Equal(t, 10*time.Second, b.Delay.Duration)
+		assert.Equal(t, uint32(3), b.Multiplier)
+		assert.Equal(t, time.Minute, b.MaxDelay.Duration)
+		assert.Equal(t, now.Add(10*time.Second), b.NextAttemptAt.Time)
+	})
+
+	t.Run("growing", func(t *testing.T) {
+		b := nextRetryBackoff(cfg, nextRetryBackoff(cfg, nil, now), now)
+		assert.Equal(t, 30*time.Second, b.Delay.Duration)
+		assert.Equal(t, now.Add(30*time.Second), b.NextAttemptAt.Time)
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		b := nextRetryBackoff(cfg, &v1alpha1.RetryBackoff{
+			Delay:      v1.Duration{Duration: 30 * time.Second},
+			Multiplier: 3,
+			MaxDelay:   v1.Duration{Duration: time.Minute},
+		}, now)
+		assert.Equal(t, time.Minute, b.Delay.Duration)
+	})
+
+	t.Run("captured-settings", func(t *testing.T) {
+		b := nextRetryBackoff(cfg, &v1alpha1.RetryBackoff{
+			Delay:      v1.Duration{Duration: 30 * time.Second},
+			Multiplier: 2,
+			MaxDelay:   v1.Duration{Duration: 2 * time.Minute},
+		}, now)
+		assert.Equal(t, time.Minute, b.Delay.Duration)
+		assert.Equal(t, uint32(2), b.Multiplier)
+		assert.Equal(t, 2*time.Minute, b.MaxDelay.Duration)
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		jittered := cfg
+		jittered.JitterFactor = 0.5
+		b := nextRetryBackoff(jittered, nil, now)
+		assert.Equal(t, 10*time.Second, b.Delay.Duration)
+		assert.False(t, b.NextAttemptAt.Time.Before(now.Add(10*time.Second)))
+		assert.False(t, b.NextAttemptAt.Time.After(now.Add(15*time.Second)))
+	})
+}
+
+func TestHandleRetryableFailure_Backoff(t *testing.T) {
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	ctx := clocks.WithClock(context.TODO(), fakeClock)
+
+	c := &nodeExecutor{
+		systemRetryBackoff: config.RetryBackoffConfig{
+			Enabled:    true,
+			BaseDelay:  stdConfig.Duration{Duration: 10 * time.Second},
+			Multiplier: 2,
+			MaxDelay:   stdConfig.Duration{Duration: 15 * time.Second},
+		},
+		metrics: &nodeMetrics{
+			RetryBackoffDelay: labeled.NewStopWatch("retry_backoff_delay", "", time.Millisecond, promutils.NewTestScope()),
+		},
+	}
+
+	h := &nodeHandlerMocks.Node{}
+	h.OnAbortMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	h.OnFinalizeMatch(mock.Anything, mock.Anything).Return(nil)
+
+	systemErr := &core.ExecutionError{Kind: core.ExecutionError_SYSTEM, Code: "Interrupted", Message: "m"}
+	ns := &v1alpha1.NodeStatus{}
+	ns.UpdatePhase(v1alpha1.NodePhaseRetryableFailure, v1.NewTime(now), "m", systemErr)
+	nCtx := &nodeExecContext{nodeStatus: ns, nsm: &nodeStateManager{nodeStatus: ns}}
+
+	s, err := c.handleRetryableFailure(ctx, nCtx, h)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodeStatusRunning, s)
+	assert.Equal(t, v1alpha1.NodePhaseRetryableFailure, ns.GetPhase())
+	assert.Equal(t, now.Add(10*time.Second), ns.GetRetryBackoff().NextAttemptAt.Time)
+
+	// Still backing off, the node is not aborted again
+	fakeClock.Step(5 * time.Second)
+	s, err = c.handleRetryableFailure(ctx, nCtx, h)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodeStatusRunning, s)
+	h.AssertNumberOfCalls(t, "Abort", 1)
+
+	fakeClock.Step(6 * time.Second)
+	s, err = c.handleRetryableFailure(ctx, nCtx, h)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodeStatusPending, s)
+	assert.Equal(t, v1alpha1.NodePhaseRunning, ns.GetPhase())
+	assert.Equal(t, uint32(1), ns.GetAttempts())
+	assert.Nil(t, ns.GetRetryBackoff().NextAttemptAt)
+	assert.Equal(t, 10*time.Second, ns.GetRetryBackoff().Delay.Duration)
+
+	// The next system failure backs off longer, up to the cap
+	ns.UpdatePhase(v1alpha1.NodePhaseRetryableFailure, v1.NewTime(fakeClock.Now()), "m", systemErr)
+	s, err = c.handleRetryableFailure(ctx, nCtx, h)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodeStatusRunning, s)
+	assert.Equal(t, fakeClock.Now().Add(15*time.Second), ns.GetRetryBackoff().NextAttemptAt.Time)
+
+	fakeClock.Step(16 * time.Second)
+	s, err = c.handleRetryableFailure(ctx, nCtx, h)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodeStatusPending, s)
+
+	// User failures are retried right away and reset the backoff
+	ns.UpdatePhase(v1alpha1.NodePhaseRetryableFailure, v1.NewTime(fakeClock.Now()), "m",
+		&core.ExecutionError{Kind: core.ExecutionError_USER, Code: "c", Message: "m"})
+	s, err = c.handleRetryableFailure(ctx, nCtx, h)
+	assert.NoError(t, err)
+	assert.Equal(t, executors.NodeStatusPending, s)
+	assert.Nil(t, ns.GetRetryBackoff())
+	assert.Equal(t, uint32(3), ns.GetAttempts())
+}
@@ -0,0 +1,79 @@
+package nodes
+
+import (
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+const (
+	// Error code used when the execution deadline of a single attempt expires. The execution deadline is measured from
+	// the moment the attempt started running, so time spent queued does not count against it.
+	timeoutExpiredErrorCode = "TimeoutExpired"
+	// Error code used when the active deadline of the node expires. The active deadline is measured from the moment the
+	// node was first queued, and spans all its attempts.
+	activeTimeoutExpiredErrorCode = "ActiveTimeoutExpired"
+)
+
+func toRetryPolicies(policies []config.RetryPolicy) []v1alpha1.RetryPolicy {
+	res := make([]v1alpha1.RetryPolicy, 0, len(policies))
+	for _, p := range policies {
+		res = append(res, v1alpha1.RetryPolicy{
+			Kind:                        p.Kind,
+			Code:                        p.Code,
+			Retries:                     p.Retries,
+			ExecutionDeadlineMultiplier: p.ExecutionDeadlineMultiplier,
+		})
+	}
+	return res
+}
+
+// toSystemError reclassifies failures with one of the configured system error codes as system failures, so that
+// infrastructure issues that plugins report as user errors, like failing to pull an image, do not use up the retries of
+// the user.
+func (c *nodeExecutor) toSystemError(err *core.ExecutionError) *core.ExecutionError {
+	if err == nil || err.Kind == core.ExecutionError_SYSTEM || !c.systemErrorCodes.Has(err.Code) {
+		return err
+	}
+
+	return &core.ExecutionError{Code: err.Code, Message: err.Message, Kind: core.ExecutionError_SYSTEM}
+}
+
+// getRetryPolicy returns the first retry policy matching the error. Policies declared on the node take precedence over
+// the platform defaults. nil is returned if no policy matches.
+func (c *nodeExecutor) getRetryPolicy(node v1alpha1.ExecutableNode, err *core.ExecutionError) *v1alpha1.RetryPolicy {
+	for _, policies := range [][]v1alpha1.RetryPolicy{node.GetRetryStrategy().GetPolicies(), c.defaultRetryPolicies} {
+		for i := range policies {
+			if policies[i].Matches(err) {
+				return &policies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// getExecutionDeadline returns the execution deadline for the current attempt of the node. If a retry policy for
+// execution timeouts declares a multiplier, the deadline is scaled once for every previous attempt that timed out.
+func (c *nodeExecutor) getExecutionDeadline(node v1alpha1.ExecutableNode, nodeStatus v1alpha1.ExecutableNodeStatus) time.Duration {
+	executionDeadline := c.defaultExecutionDeadline
+	if node.GetExecutionDeadline() != nil && *node.GetExecutionDeadline() > 0 {
+		executionDeadline = *node.GetExecutionDeadline()
+	}
+
+	if executionDeadline <= 0 || nodeStatus.GetTimeoutFailures() == 0 {
+		return executionDeadline
+	}
+
+	policy := c.getRetryPolicy(node, &core.ExecutionError{Code: timeoutExpiredErrorCode, Kind: core.ExecutionError_USER})
+	if policy == nil || policy.ExecutionDeadlineMultiplier <= 1 {
+		return executionDeadline
+	}
+
+	for i := uint32(0); i < nodeStatus.GetTimeoutFailures(); i++ {
+		executionDeadline *= time.Duration(policy.ExecutionDeadlineMultiplier)
+	}
+	return executionDeadline
+}
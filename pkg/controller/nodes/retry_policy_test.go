@@ -0,0 +1,135 @@
+package nodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestToRetryPolicies(t *testing.T) {
+	policies := toRetryPolicies([]config.RetryPolicy{{Kind: "SYSTEM", Code: "c", Retries: 5, ExecutionDeadlineMultiplier: 2}})
+	assert.Equal(t, []v1alpha1.RetryPolicy{{Kind: "SYSTEM", Code: "c", Retries: 5, ExecutionDeadlineMultiplier: 2}}, policies)
+	assert.Empty(t, toRetryPolicies(nil))
+}
+
+func TestGetRetryPolicy(t *testing.T) {
+	c := &nodeExecutor{defaultRetryPolicies: []v1alpha1.RetryPolicy{
+		{Kind: "SYSTEM", Retries: 5},
+		{Kind: "USER", Retries: 0},
+	}}
+
+	t.Run("node-policy-precedence", func(t *testing.T) {
+		node := &mocks.ExecutableNode{}
+		node.OnGetRetryStrategy().Return(&v1alpha1.RetryStrategy{Policies: []v1alpha1.RetryPolicy{
+			{Code: "OOM", Retries: 3},
+		}})
+		p := c.getRetryPolicy(node, &core.ExecutionError{Code: "OOM", Kind: core.ExecutionError_USER})
+		if assert.NotNil(t, p) {
+			assert.Equal(t, 3, p.Retries)
+		}
+
+		p = c.getRetryPolicy(node, &core.ExecutionError{Code: "other", Kind: core.ExecutionError_USER})
+		if assert.NotNil(t, p) {
+			assert.Equal(t, 0, p.Retries)
+		}
+	})
+
+	t.Run("platform-default", func(t *testing.T) {
+		node := &mocks.ExecutableNode{}
+		node.OnGetRetryStrategy().Return(nil)
+		p := c.getRetryPolicy(node, &core.ExecutionError{Code: "x", Kind: core.ExecutionError_SYSTEM})
+		if assert.NotNil(t, p) {
+			assert.Equal(t, 5, p.Retries)
+		}
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		node := &mocks.ExecutableNode{}
+		node.OnGetRetryStrategy().Return(nil)
+		assert.Nil(t, (&nodeExecutor{}).getRetryPolicy(node, &core.ExecutionError{Code: "x"}))
+	})
+}
+
+func TestIsEligibleForRetry_Policies(t *testing.T) {
+	minAttempts := 3
+	c := &nodeExecutor{
+		maxNodeRetriesForSystemFailures: 1,
+		defaultRetryPolicies: []v1alpha1.RetryPolicy{
+			{Kind: "SYSTEM", Retries: 5},
+			{Kind: "USER", Code: timeoutExpiredErrorCode, Retries: 2},
+			{Kind: "USER", Retries: 0},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		err            *core.ExecutionError
+		attempts       uint32
+		systemFailures uint32
+		isEligible     bool
+	}{
+		{"system-within-budget", &core.ExecutionError{Kind: core.ExecutionError_SYSTEM}, 3, 3, true},
+		{"system-exhausted", &core.ExecutionError{Kind: core.ExecutionError_SYSTEM}, 5, 5, false},
+		{"user-never-retried", &core.ExecutionError{Kind: core.ExecutionError_USER, Code: "x"}, 0, 0, false},
+		{"timeout-first-retry", &core.ExecutionError{Kind: core.ExecutionError_USER, Code: timeoutExpiredErrorCode}, 0, 0, true},
+		{"timeout-second-retry", &core.ExecutionError{Kind: core.ExecutionError_USER, Code: timeoutExpiredErrorCode}, 1, 0, true},
+		{"timeout-exhausted", &core.ExecutionError{Kind: core.ExecutionError_USER, Code: timeoutExpiredErrorCode}, 2, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &mocks.ExecutableNode{}
+			node.OnGetRetryStrategy().Return(&v1alpha1.RetryStrategy{MinAttempts: &minAttempts})
+			ns := &mocks.ExecutableNodeStatus{}
+			ns.OnGetAttempts().Return(tt.attempts)
+			ns.OnGetSystemFailures().Return(tt.systemFailures)
+
+			_, _, isEligible := c.isEligibleForRetry(&nodeExecContext{node: node}, ns, tt.err)
+			assert.Equal(t, tt.isEligible, isEligible)
+		})
+	}
+}
+
+func TestGetExecutionDeadline(t *testing.T) {
+	deadline := time.Minute
+	c := &nodeExecutor{
+		defaultExecutionDeadline: time.Second,
+		defaultRetryPolicies: []v1alpha1.RetryPolicy{
+			{Code: timeoutExpiredErrorCode, Retries: 2, ExecutionDeadlineMultiplier: 2},
+		},
+	}
+
+	node := &mocks.ExecutableNode{}
+	node.OnGetExecutionDeadline().Return(&deadline)
+	node.OnGetRetryStrategy().Return(nil)
+
+	tests := []struct {
+		name            string
+		timeoutFailures uint32
+		expected        time.Duration
+	}{
+		{"no-timeouts", 0, time.Minute},
+		{"one-timeout", 1, 2 * time.Minute},
+		{"two-timeouts", 2, 4 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &mocks.ExecutableNodeStatus{}
+			ns.OnGetTimeoutFailures().Return(tt.timeoutFailures)
+			assert.Equal(t, tt.expected, c.getExecutionDeadline(node, ns))
+		})
+	}
+
+	t.Run("no-policy", func(t *testing.T) {
+		ns := &mocks.ExecutableNodeStatus{}
+		ns.OnGetTimeoutFailures().Return(uint32(2))
+		assert.Equal(t, time.Minute, (&nodeExecutor{}).getExecutionDeadline(node, ns))
+	})
+}
@@ -3,11 +3,13 @@ package subworkflow
 import (
 	"context"
 
+	"github.com/flyteorg/flytepropeller/events"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
 	"github.com/flyteorg/flytestdlib/promutils"
 
 	"github.com/flyteorg/flytestdlib/logger"
@@ -27,12 +29,22 @@ type workflowNodeHandler struct {
 }
 
 type metrics struct {
-	CacheError labeled.Counter
+	CacheError             labeled.Counter
+	CatalogHitCount        labeled.Counter
+	CatalogMissCount       labeled.Counter
+	CatalogGetFailureCount labeled.Counter
+	CatalogPutSuccessCount labeled.Counter
+	CatalogPutFailureCount labeled.Counter
 }
 
 func newMetrics(scope promutils.Scope) metrics {
 	return metrics{
-		CacheError: labeled.NewCounter("cache_err", "workflow handler failed to store or load from data store.", scope),
+		CacheError:             labeled.NewCounter("cache_err", "workflow handler failed to store or load from data store.", scope),
+		CatalogHitCount:        labeled.NewCounter("discovery_hit_count", "Launch plan cached in Discovery", scope),
+		CatalogMissCount:       labeled.NewCounter("discovery_miss_count", "Launch plan not cached in Discovery", scope),
+		CatalogGetFailureCount: labeled.NewCounter("discovery_get_failure_count", "Discovery Get failure count", scope),
+		CatalogPutSuccessCount: labeled.NewCounter("discovery_put_success_count", "Discovery Put success count", scope),
+		CatalogPutFailureCount: labeled.NewCounter("discovery_put_failure_count", "Discovery Put failure count", scope),
 	}
 }
 
@@ -82,6 +94,11 @@ func (w *workflowNodeHandler) Handle(ctx context.Context, nCtx handler.NodeExecu
 			return updateNodeStateFn(trns, v1alpha1.WorkflowNodePhaseExecuting, err)
 		} else if wfNode.GetLaunchPlanRefID() != nil {
 			trns, err := w.lpHandler.StartLaunchPlan(ctx, nCtx)
+			if err == nil && trns.Info().GetPhase() == handler.EPhaseQueued {
+				// The launch was postponed, the child execution has not been created yet.
+				return trns, nil
+			}
+
 			return updateNodeStateFn(trns, v1alpha1.WorkflowNodePhaseExecuting, err)
 		}
 
@@ -129,15 +146,26 @@ func (w *workflowNodeHandler) Finalize(ctx context.Context, _ handler.NodeExecut
 	return nil
 }
 
-func New(executor executors.Node, workflowLauncher launchplan.Executor, recoveryClient recovery.Client, eventConfig *config.EventConfig, scope promutils.Scope) handler.Node {
+func New(executor executors.Node, workflowLauncher launchplan.Executor, launchPlanReader launchplan.Reader, catalogClient catalog.Client,
+	recoveryClient recovery.Client, eventConfig *config.EventConfig, eventRecorder events.NodeEventRecorder, clusterID string,
+	scope promutils.Scope) handler.Node {
 	workflowScope := scope.NewSubScope("workflow")
+	m := newMetrics(workflowScope)
 	return &workflowNodeHandler{
-		subWfHandler: newSubworkflowHandler(executor, eventConfig),
+		subWfHandler: newSubworkflowHandler(executor, eventConfig, config.GetConfig().NodeConfig.ParallelismBudget.SubWorkflowWeight),
 		lpHandler: launchPlanHandler{
-			launchPlan:     workflowLauncher,
-			recoveryClient: recoveryClient,
-			eventConfig:    eventConfig,
+			launchPlan:             workflowLauncher,
+			launchPlanReader:       launchPlanReader,
+			catalog:                catalogClient,
+			recoveryClient:         recoveryClient,
+			eventConfig:            eventConfig,
+			literalOffloading:      config.GetConfig().NodeConfig.LiteralOffloading,
+			validateInterface:      launchplan.GetAdminConfig().ValidateInterface,
+			terminationGracePeriod: launchplan.GetAdminConfig().TerminationGracePeriod.Duration,
+			eventRecorder:          eventRecorder,
+			clusterID:              clusterID,
+			metrics:                m,
 		},
-		metrics: newMetrics(workflowScope),
+		metrics: m,
 	}
 }
@@ -56,6 +56,10 @@ func (t workflowNodeStateHolder) PutDynamicNodeState(s handler.DynamicNodeState)
 	panic("not implemented")
 }
 
+func (t workflowNodeStateHolder) PutArrayNodeState(s handler.ArrayNodeState) error {
+	panic("not implemented")
+}
+
 var wfExecID = &core.WorkflowExecutionIdentifier{
 	Project: "project",
 	Domain:  "domain",
@@ -158,7 +162,7 @@ func TestWorkflowNodeHandler_StartNode_Launchplan(t *testing.T) {
 	t.Run("happy v0", func(t *testing.T) {
 
 		mockLPExec := &mocks.Executor{}
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnLaunchMatch(
 			ctx,
 			mock.MatchedBy(func(o launchplan.LaunchContext) bool {
@@ -183,7 +187,7 @@ func TestWorkflowNodeHandler_StartNode_Launchplan(t *testing.T) {
 	t.Run("happy v1", func(t *testing.T) {
 
 		mockLPExec := &mocks.Executor{}
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnLaunchMatch(
 			ctx,
 			mock.MatchedBy(func(o launchplan.LaunchContext) bool {
@@ -238,7 +242,7 @@ func TestWorkflowNodeHandler_CheckNodeStatus(t *testing.T) {
 
 		mockLPExec := &mocks.Executor{}
 
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnGetStatusMatch(
 			ctx,
 			mock.MatchedBy(func(o *core.WorkflowExecutionIdentifier) bool {
@@ -259,7 +263,7 @@ func TestWorkflowNodeHandler_CheckNodeStatus(t *testing.T) {
 
 		mockLPExec := &mocks.Executor{}
 
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnGetStatusMatch(
 			ctx,
 			mock.MatchedBy(func(o *core.WorkflowExecutionIdentifier) bool {
@@ -311,7 +315,7 @@ func TestWorkflowNodeHandler_AbortNode(t *testing.T) {
 		mockLPExec := &mocks.Executor{}
 		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, mockNodeStatus)
 
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnKillMatch(
 			ctx,
 			mock.MatchedBy(func(o *core.WorkflowExecutionIdentifier) bool {
@@ -332,7 +336,7 @@ func TestWorkflowNodeHandler_AbortNode(t *testing.T) {
 		mockLPExec := &mocks.Executor{}
 		nCtx := createNodeContextV1(v1alpha1.WorkflowNodePhaseExecuting, mockNode, mockNodeStatus)
 
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnKillMatch(
 			ctx,
 			mock.MatchedBy(func(o *core.WorkflowExecutionIdentifier) bool {
@@ -351,7 +355,7 @@ func TestWorkflowNodeHandler_AbortNode(t *testing.T) {
 
 		mockLPExec := &mocks.Executor{}
 		expectedErr := fmt.Errorf("fail")
-		h := New(nil, mockLPExec, recoveryClient, eventConfig, promutils.NewTestScope())
+		h := New(nil, mockLPExec, nil, nil, recoveryClient, eventConfig, nil, "", promutils.NewTestScope())
 		mockLPExec.OnKillMatch(
 			ctx,
 			mock.MatchedBy(func(o *core.WorkflowExecutionIdentifier) bool {
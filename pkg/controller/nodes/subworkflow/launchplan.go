@@ -3,8 +3,13 @@ package subworkflow
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/flyteorg/flytepropeller/events"
+	eventsErr "github.com/flyteorg/flytepropeller/events/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery"
 	"google.golang.org/grpc/codes"
@@ -12,20 +17,37 @@ import (
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan"
 )
 
 type launchPlanHandler struct {
-	launchPlan     launchplan.Executor
-	recoveryClient recovery.Client
-	eventConfig    *config.EventConfig
+	launchPlan       launchplan.Executor
+	launchPlanReader launchplan.Reader
+	catalog          catalog.Client
+	recoveryClient   recovery.Client
+	eventConfig      *config.EventConfig
+	// Offloads large inputs of child executions, which are sent inline to admin when launched.
+	literalOffloading config.LiteralOffloadingConfig
+	// Checks the interface of launch plans against the inputs of nodes before launching them.
+	validateInterface bool
+	// Maximum time the abort of the node waits for the child execution to terminate.
+	terminationGracePeriod time.Duration
+	eventRecorder          events.NodeEventRecorder
+	clusterID              string
+	metrics                metrics
 }
 
 func getParentNodeExecutionID(nCtx handler.NodeExecutionContext) (*core.NodeExecutionIdentifier, error) {
@@ -45,6 +67,49 @@ func getParentNodeExecutionID(nCtx handler.NodeExecutionContext) (*core.NodeExec
 	return nodeExecID, nil
 }
 
+// getChildWorkflowExecutionID returns the ID of the execution launched for the current attempt of the node. Unlike the
+// parent node execution ID reported to admin, it always includes the lineage of the node, regardless of the event
+// version, so that a retried parent never observes the executions launched by its previous attempts.
+func getChildWorkflowExecutionID(nCtx handler.NodeExecutionContext) (*core.WorkflowExecutionIdentifier, error) {
+	nodeExecID := nCtx.NodeExecutionMetadata().GetNodeExecutionID()
+	currentNodeUniqueID, err := common.GenerateUniqueID(nCtx.ExecutionContext().GetParentInfo(), nodeExecID.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetChildWorkflowExecutionID(
+		&core.NodeExecutionIdentifier{
+			ExecutionId: nodeExecID.ExecutionId,
+			NodeId:      currentNodeUniqueID,
+		},
+		nCtx.CurrentAttempt(),
+	)
+}
+
+// Offloads the largest inputs to blob storage if the inputs exceed the configured size, so that launching the child
+// execution does not fail on the gRPC message size limit. The child execution resolves the references transparently.
+func (l *launchPlanHandler) offloadInputs(ctx context.Context, nCtx handler.NodeExecutionContext, nodeInputs *core.LiteralMap) (
+	*core.LiteralMap, error) {
+	if nodeInputs == nil || int64(proto.Size(nodeInputs)) <= l.literalOffloading.MaxSizeBytes {
+		return nodeInputs, nil
+	}
+
+	dir, err := nCtx.DataStore().ConstructReference(ctx, nCtx.NodeStatus().GetDataDir(), "offloaded-inputs")
+	if err != nil {
+		return nil, err
+	}
+
+	// The inputs may be shared with the input reader, so the offloaded references are set on a copy.
+	offloadedInputs := proto.Clone(nodeInputs).(*core.LiteralMap)
+	offloaded, err := common.OffloadLiterals(ctx, nCtx.DataStore(), offloadedInputs, dir, l.literalOffloading.MaxSizeBytes)
+	if err != nil {
+		return nil, errors.Wrapf(errors.StorageError, nCtx.NodeID(), err, "failed to offload inputs to [%v]", dir)
+	}
+
+	logger.Infof(ctx, "Offloaded inputs %v of the child execution to [%v]", offloaded, dir)
+	return offloadedInputs, nil
+}
+
 func (l *launchPlanHandler) StartLaunchPlan(ctx context.Context, nCtx handler.NodeExecutionContext) (handler.Transition, error) {
 	nodeInputs, err := nCtx.InputReader().Get(ctx)
 	if err != nil {
@@ -52,14 +117,20 @@ func (l *launchPlanHandler) StartLaunchPlan(ctx context.Context, nCtx handler.No
 		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.RuntimeExecutionError, errMsg, nil)), nil
 	}
 
+	entry, err := l.CheckCatalogCache(ctx, nCtx)
+	if err != nil {
+		logger.Errorf(ctx, "failed to check catalog cache with error: %v", err)
+		return handler.UnknownTransition, err
+	}
+	if entry.GetStatus().GetCacheStatus() == core.CatalogCacheStatus_CACHE_HIT {
+		return l.handleCacheHit(ctx, nCtx, entry)
+	}
+
 	parentNodeExecutionID, err := getParentNodeExecutionID(nCtx)
 	if err != nil {
 		return handler.UnknownTransition, err
 	}
-	childID, err := GetChildWorkflowExecutionID(
-		parentNodeExecutionID,
-		nCtx.CurrentAttempt(),
-	)
+	childID, err := getChildWorkflowExecutionID(nCtx)
 	if err != nil {
 		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.RuntimeExecutionError, "failed to create unique ID", nil)), nil
 	}
@@ -67,10 +138,10 @@ func (l *launchPlanHandler) StartLaunchPlan(ctx context.Context, nCtx handler.No
 	launchCtx := launchplan.LaunchContext{
 		ParentNodeExecution: parentNodeExecutionID,
 		MaxParallelism:      nCtx.ExecutionContext().GetExecutionConfig().MaxParallelism,
-		SecurityContext:     nCtx.ExecutionContext().GetSecurityContext(),
+		SecurityContext:     common.PropagatedSecurityContext(nCtx.ExecutionContext(), nCtx.Node()),
 		RawOutputDataConfig: nCtx.ExecutionContext().GetRawOutputDataConfig().RawOutputDataConfig,
-		Labels:              nCtx.ExecutionContext().GetLabels(),
-		Annotations:         nCtx.ExecutionContext().GetAnnotations(),
+		Labels:              common.PropagatedLabels(nCtx.ExecutionContext(), nCtx.Node()),
+		Annotations:         common.PropagatedAnnotations(nCtx.ExecutionContext(), nCtx.Node()),
 	}
 
 	if nCtx.ExecutionContext().GetExecutionConfig().RecoveryExecution.WorkflowExecutionIdentifier != nil {
@@ -83,16 +154,38 @@ func (l *launchPlanHandler) StartLaunchPlan(ctx context.Context, nCtx handler.No
 		}
 		if recovered != nil && recovered.Closure != nil && recovered.Closure.Phase == core.NodeExecution_SUCCEEDED {
 			if recovered.Closure.GetWorkflowNodeMetadata() != nil {
-				launchCtx.RecoveryExecution = recovered.Closure.GetWorkflowNodeMetadata().ExecutionId
+				recoveredChildID := recovered.Closure.GetWorkflowNodeMetadata().ExecutionId
+				trns, ok, err := l.recoverChildExecution(ctx, nCtx, recoveredChildID)
+				if err != nil || ok {
+					return trns, err
+				}
+				launchCtx.RecoveryExecution = recoveredChildID
 			} else {
 				logger.Debugf(ctx, "Attempted to recovered workflow node execution [%+v] but was missing workflow node metadata", recovered.Id)
 			}
 		}
 	}
+	if l.validateInterface && l.launchPlanReader != nil {
+		if trns, ok, err := l.validateLaunchPlanInterface(ctx, nCtx, nodeInputs); err != nil || !ok {
+			return trns, err
+		}
+	}
+
+	if l.literalOffloading.Enabled {
+		nodeInputs, err = l.offloadInputs(ctx, nCtx, nodeInputs)
+		if err != nil {
+			return handler.UnknownTransition, err
+		}
+	}
+
 	err = l.launchPlan.Launch(ctx, launchCtx, childID, nCtx.Node().GetWorkflowNode().GetLaunchPlanRefID().Identifier, nodeInputs)
 	if err != nil {
 		if launchplan.IsAlreadyExists(err) {
 			logger.Infof(ctx, "Execution already exists [%s].", childID.Name)
+		} else if launchplan.IsThrottled(err) {
+			// The launch is attempted again in a later round, once the rate limits of admin allow it.
+			logger.Infof(ctx, "Launch of execution [%s] postponed. Error: %v", childID.Name, err)
+			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoQueued(err.Error())), nil
 		} else if launchplan.IsUserError(err) {
 			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER, errors.RuntimeExecutionError, err.Error(), &handler.ExecutionInfo{
 				WorkflowNodeInfo: &handler.WorkflowNodeInfo{LaunchedWorkflowID: childID},
@@ -110,16 +203,96 @@ func (l *launchPlanHandler) StartLaunchPlan(ctx context.Context, nCtx handler.No
 	})), nil
 }
 
-func (l *launchPlanHandler) CheckLaunchPlanStatus(ctx context.Context, nCtx handler.NodeExecutionContext) (handler.Transition, error) {
-	parentNodeExecutionID, err := getParentNodeExecutionID(nCtx)
+// copyChildOutputs copies the outputs of a successful child execution to the node's output location.
+func copyChildOutputs(ctx context.Context, nCtx handler.NodeExecutionContext, wfStatusClosure *admin.ExecutionClosure) (*handler.OutputInfo, error) {
+	if wfStatusClosure.GetOutputs() == nil {
+		return nil, nil
+	}
+
+	outputFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
+	if wfStatusClosure.GetOutputs().GetUri() != "" {
+		uri := wfStatusClosure.GetOutputs().GetUri()
+		store := nCtx.DataStore()
+		err := store.CopyRaw(ctx, storage.DataReference(uri), outputFile, storage.Options{})
+		if err != nil {
+			logger.Warnf(ctx, "remote output for launchplan execution was not found, uri [%s], err %s", uri, err.Error())
+			return nil, errors.Wrapf(errors.RuntimeExecutionError, nCtx.NodeID(), err, "remote output for launchplan execution was not found, uri [%s]", uri)
+		}
+	} else {
+		childOutput := wfStatusClosure.GetOutputs().GetValues()
+		if err := nCtx.DataStore().WriteProtobuf(ctx, outputFile, storage.Options{}, childOutput); err != nil {
+			logger.Debugf(ctx, "failed to write data to Storage, err: %v", err.Error())
+			return nil, errors.Wrapf(errors.CausedByError, nCtx.NodeID(), err, "failed to copy outputs for child workflow")
+		}
+	}
+	return &handler.OutputInfo{OutputURI: outputFile}, nil
+}
+
+// recoverChildExecution reuses the outputs of the child execution launched by the node in the recovered parent
+// execution, if that child execution succeeded. The returned bool is false if the child cannot be reused, in which case
+// the caller should launch a new child execution.
+func (l *launchPlanHandler) recoverChildExecution(ctx context.Context, nCtx handler.NodeExecutionContext,
+	recoveredChildID *core.WorkflowExecutionIdentifier) (handler.Transition, bool, error) {
+	wfStatusClosure, err := l.launchPlan.GetRecoveredStatus(ctx, recoveredChildID)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to retrieve recovered child execution [%v] with err [%+v]", recoveredChildID, err)
+		return handler.UnknownTransition, false, nil
+	}
+
+	if wfStatusClosure.GetPhase() != core.WorkflowExecution_SUCCEEDED {
+		logger.Debugf(ctx, "Recovered child execution [%v] phase [%v] is not reusable", recoveredChildID, wfStatusClosure.GetPhase())
+		return handler.UnknownTransition, false, nil
+	}
+
+	oInfo, err := copyChildOutputs(ctx, nCtx, wfStatusClosure)
+	if err != nil {
+		return handler.UnknownTransition, false, err
+	}
+
+	logger.Infof(ctx, "Reusing outputs of recovered child execution [%s]", recoveredChildID.Name)
+	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRecovered(&handler.ExecutionInfo{
+		WorkflowNodeInfo: &handler.WorkflowNodeInfo{LaunchedWorkflowID: recoveredChildID},
+		OutputInfo:       oInfo,
+	})), true, nil
+}
+
+// handleCacheHit copies the cached outputs to the node's output location and marks the node as succeeded without
+// launching a child execution.
+func (l *launchPlanHandler) handleCacheHit(ctx context.Context, nCtx handler.NodeExecutionContext, entry catalog.Entry) (handler.Transition, error) {
+	r := entry.GetOutputs()
+	if r == nil {
+		return handler.UnknownTransition, errors.Errorf(errors.IllegalStateError, nCtx.NodeID(), "failed to read outputs from a CacheHIT. Unexpected!")
+	}
+	o, ee, err := r.Read(ctx)
 	if err != nil {
+		logger.Errorf(ctx, "failed to read from catalog, err: %s", err.Error())
 		return handler.UnknownTransition, err
 	}
+	if ee != nil {
+		logger.Errorf(ctx, "got execution error from catalog output reader? This should not happen, err: %s", ee.String())
+		return handler.UnknownTransition, errors.Errorf(errors.IllegalStateError, nCtx.NodeID(), "execution error from a cache output, bad state: %s", ee.String())
+	}
+
+	outputFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
+	if err := nCtx.DataStore().WriteProtobuf(ctx, outputFile, storage.Options{}, o); err != nil {
+		logger.Errorf(ctx, "failed to write cached value to datastore, err: %s", err.Error())
+		return handler.UnknownTransition, errors.Wrapf(errors.CausedByError, nCtx.NodeID(), err, "failed to copy cached outputs for launch plan")
+	}
+
+	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoSuccess(&handler.ExecutionInfo{
+		OutputInfo: &handler.OutputInfo{OutputURI: outputFile},
+		TaskNodeInfo: &handler.TaskNodeInfo{
+			TaskNodeMetadata: &event.TaskNodeMetadata{
+				CacheStatus: entry.GetStatus().GetCacheStatus(),
+				CatalogKey:  entry.GetStatus().GetMetadata(),
+			},
+		},
+	})), nil
+}
+
+func (l *launchPlanHandler) CheckLaunchPlanStatus(ctx context.Context, nCtx handler.NodeExecutionContext) (handler.Transition, error) {
 	// Handle launch plan
-	childID, err := GetChildWorkflowExecutionID(
-		parentNodeExecutionID,
-		nCtx.CurrentAttempt(),
-	)
+	childID, err := getChildWorkflowExecutionID(nCtx)
 
 	if err != nil {
 		// THIS SHOULD NEVER HAPPEN
@@ -167,47 +340,121 @@ func (l *launchPlanHandler) CheckLaunchPlanStatus(ctx context.Context, nCtx hand
 	case core.WorkflowExecution_SUCCEEDED:
 		// TODO do we need to massage the output to match the alias or is the alias resolution done at the downstream consumer
 		// nCtx.Node().GetOutputAlias()
-		var oInfo *handler.OutputInfo
-		if wfStatusClosure.GetOutputs() != nil {
-			outputFile := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
-			if wfStatusClosure.GetOutputs().GetUri() != "" {
-				uri := wfStatusClosure.GetOutputs().GetUri()
-				store := nCtx.DataStore()
-				err := store.CopyRaw(ctx, storage.DataReference(uri), outputFile, storage.Options{})
-				if err != nil {
-					logger.Warnf(ctx, "remote output for launchplan execution was not found, uri [%s], err %s", uri, err.Error())
-					return handler.UnknownTransition, errors.Wrapf(errors.RuntimeExecutionError, nCtx.NodeID(), err, "remote output for launchplan execution was not found, uri [%s]", uri)
-				}
-			} else {
-				childOutput := wfStatusClosure.GetOutputs().GetValues()
-				if err := nCtx.DataStore().WriteProtobuf(ctx, outputFile, storage.Options{}, childOutput); err != nil {
-					logger.Debugf(ctx, "failed to write data to Storage, err: %v", err.Error())
-					return handler.UnknownTransition, errors.Wrapf(errors.CausedByError, nCtx.NodeID(), err, "failed to copy outputs for child workflow")
-				}
-			}
-			oInfo = &handler.OutputInfo{OutputURI: outputFile}
+		oInfo, err := copyChildOutputs(ctx, nCtx, wfStatusClosure)
+		if err != nil {
+			return handler.UnknownTransition, err
 		}
-		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoSuccess(&handler.ExecutionInfo{
+		info := &handler.ExecutionInfo{
 			WorkflowNodeInfo: &handler.WorkflowNodeInfo{LaunchedWorkflowID: childID},
 			OutputInfo:       oInfo,
-		})), nil
+		}
+		if cacheStatus := l.WriteCatalogCache(ctx, nCtx); cacheStatus.GetCacheStatus() != core.CatalogCacheStatus_CACHE_DISABLED {
+			// The cache status is carried alongside the child execution, which keeps identifying the node in events.
+			info.TaskNodeInfo = &handler.TaskNodeInfo{
+				TaskNodeMetadata: &event.TaskNodeMetadata{
+					CacheStatus: cacheStatus.GetCacheStatus(),
+					CatalogKey:  cacheStatus.GetMetadata(),
+				},
+			}
+		}
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoSuccess(info)), nil
 	}
 	logger.Infof(ctx, "LaunchPlan running, parallelism is now set to [%d]", nCtx.ExecutionContext().IncrementParallelism())
 	return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoRunning(nil)), nil
 }
 
 func (l *launchPlanHandler) HandleAbort(ctx context.Context, nCtx handler.NodeExecutionContext, reason string) error {
-	parentNodeExecutionID, err := getParentNodeExecutionID(nCtx)
+	childID, err := getChildWorkflowExecutionID(nCtx)
 	if err != nil {
+		// THIS SHOULD NEVER HAPPEN
 		return err
 	}
-	childID, err := GetChildWorkflowExecutionID(
-		parentNodeExecutionID,
-		nCtx.CurrentAttempt(),
-	)
-	if err != nil {
-		// THIS SHOULD NEVER HAPPEN
+
+	killReason := fmt.Sprintf("cascading abort as parent execution id [%s] aborted, reason [%s]", nCtx.ExecutionContext().GetName(), reason)
+	if l.terminationGracePeriod <= 0 {
+		return l.launchPlan.Kill(ctx, childID, killReason)
+	}
+
+	// The child execution is killed once, and its phase is re-checked on later rounds, rather than blocking the
+	// evaluation of the workflow until it terminated. The abort of the node completes once the child execution reached a
+	// terminal phase or the grace period since it was killed expired.
+	wfStatusClosure, err := l.launchPlan.GetStatus(ctx, childID)
+	if err != nil && !launchplan.IsNotFound(err) {
 		return err
 	}
-	return l.launchPlan.Kill(ctx, childID, fmt.Sprintf("cascading abort as parent execution id [%s] aborted, reason [%s]", nCtx.ExecutionContext().GetName(), reason))
+
+	phase := wfStatusClosure.GetPhase()
+	wfNodeStatus := nCtx.NodeStatus().GetOrCreateWorkflowStatus()
+	killedAt := wfNodeStatus.GetKilledAt()
+	switch {
+	case launchplan.IsNotFound(err) || launchplan.IsWorkflowTerminated(phase):
+		wfNodeStatus.SetKilledAt(nil)
+		return l.recordChildTermination(ctx, nCtx, childID, reason, phase)
+	case killedAt == nil:
+		if err := l.launchPlan.Kill(ctx, childID, killReason); err != nil {
+			return err
+		}
+
+		now := metav1.NewTime(clocks.Now(ctx))
+		killedAt = &now
+		wfNodeStatus.SetKilledAt(killedAt)
+	case clocks.Now(ctx).Sub(killedAt.Time) > l.terminationGracePeriod:
+		logger.Warnf(ctx, "Child execution [%s] did not terminate within [%v], last observed phase [%s]", childID.Name,
+			l.terminationGracePeriod, phase.String())
+		wfNodeStatus.SetKilledAt(nil)
+		return l.recordChildTermination(ctx, nCtx, childID, reason, phase)
+	}
+
+	if !executors.SetTerminationPending(ctx) {
+		// The abort can't be repeated on a later round, so the child execution is left to terminate on its own.
+		logger.Infof(ctx, "Not waiting for child execution [%s] to terminate, last observed phase [%s]", childID.Name, phase.String())
+		return nil
+	}
+
+	logger.Infof(ctx, "Waiting for child execution [%s] killed at [%v] to terminate, last observed phase [%s]", childID.Name,
+		killedAt.Time, phase.String())
+	return nil
+}
+
+// recordChildTermination records the abort of the node along with the final phase of its child execution.
+func (l *launchPlanHandler) recordChildTermination(ctx context.Context, nCtx handler.NodeExecutionContext,
+	childID *core.WorkflowExecutionIdentifier, reason string, phase core.WorkflowExecution_Phase) error {
+	logger.Infof(ctx, "Child execution [%s] terminated in phase [%s]", childID.Name, phase.String())
+	if l.eventRecorder == nil {
+		return nil
+	}
+
+	nodeExecutionID := &core.NodeExecutionIdentifier{
+		ExecutionId: nCtx.NodeExecutionMetadata().GetNodeExecutionID().GetExecutionId(),
+		NodeId:      nCtx.NodeExecutionMetadata().GetNodeExecutionID().GetNodeId(),
+	}
+	if nCtx.ExecutionContext().GetEventVersion() != v1alpha1.EventVersion0 {
+		currentNodeUniqueID, err := common.GenerateUniqueID(nCtx.ExecutionContext().GetParentInfo(), nodeExecutionID.NodeId)
+		if err != nil {
+			return err
+		}
+		nodeExecutionID.NodeId = currentNodeUniqueID
+	}
+
+	err := l.eventRecorder.RecordNodeEvent(ctx, &event.NodeExecutionEvent{
+		Id:         nodeExecutionID,
+		Phase:      core.NodeExecution_ABORTED,
+		OccurredAt: ptypes.TimestampNow(),
+		ProducerId: l.clusterID,
+		TargetMetadata: &event.NodeExecutionEvent_WorkflowNodeMetadata{
+			WorkflowNodeMetadata: &event.WorkflowNodeMetadata{ExecutionId: childID},
+		},
+		OutputResult: &event.NodeExecutionEvent_Error{
+			Error: &core.ExecutionError{
+				Code:    "NodeAborted",
+				Message: fmt.Sprintf("%s, child execution [%s] terminated in phase [%s]", reason, childID.Name, phase.String()),
+			},
+		},
+		EventVersion: common.NodeExecutionEventVersion,
+	}, l.eventConfig)
+	if err != nil && !eventsErr.IsAlreadyExists(err) && !eventsErr.IsEventAlreadyInTerminalStateError(err) {
+		return errors.Wrapf(errors.EventRecordingFailed, nCtx.NodeID(), err, "failed to record abort event")
+	}
+
+	return nil
 }
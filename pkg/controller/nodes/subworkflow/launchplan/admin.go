@@ -3,9 +3,11 @@ package launchplan
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/flyteorg/flytestdlib/cache"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/time/rate"
 	"k8s.io/client-go/util/workqueue"
 
@@ -32,8 +34,14 @@ func IsWorkflowTerminated(p core.WorkflowExecution_Phase) bool {
 
 // Executor for Launchplans that executes on a remote FlyteAdmin service (if configured)
 type adminLaunchPlanExecutor struct {
-	adminClient service.AdminServiceClient
-	cache       cache.AutoRefresh
+	adminClient   service.AdminServiceClient
+	cache         cache.AutoRefresh
+	launchLimiter *launchLimiter
+	// Number of retries and initial backoff of launches admin responds to with RESOURCE_EXHAUSTED
+	resourceExhaustedRetries int
+	resourceExhaustedBackoff time.Duration
+	resourceExhaustedRetried prometheus.Counter
+	syncBatchSize            int
 }
 
 type executionCacheItem struct {
@@ -62,23 +70,56 @@ func (a *adminLaunchPlanExecutor) handleLaunchError(ctx context.Context, isRecov
 		}
 
 		return errors.Wrapf(RemoteErrorAlreadyExists, err, "ExecID %s already exists", executionID.Name)
-	case codes.DataLoss, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.Canceled:
+	case codes.DataLoss, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.Canceled, codes.ResourceExhausted:
 		return errors.Wrapf(RemoteErrorSystem, err, "failed to launch workflow [%s], system error", launchPlanRef.Name)
 	default:
 		return errors.Wrapf(RemoteErrorUser, err, "failed to launch workflow")
 	}
 }
 
+// Calls admin, retrying the call with an exponential backoff as long as admin responds with RESOURCE_EXHAUSTED.
+func (a *adminLaunchPlanExecutor) retryResourceExhausted(ctx context.Context, call func() error) error {
+	backoff := a.resourceExhaustedBackoff
+	for retries := 0; ; retries++ {
+		err := call()
+		if status.Code(err) != codes.ResourceExhausted || retries >= a.resourceExhaustedRetries {
+			return err
+		}
+
+		logger.Warnf(ctx, "Admin is out of resources, retrying in [%v]. Error: %v", backoff, err)
+		a.resourceExhaustedRetried.Inc()
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
 func (a *adminLaunchPlanExecutor) Launch(ctx context.Context, launchCtx LaunchContext,
 	executionID *core.WorkflowExecutionIdentifier, launchPlanRef *core.Identifier, inputs *core.LiteralMap) error {
-	var err error
+	allowed, err := a.launchLimiter.Wait(ctx, executionID.Project, executionID.Domain)
+	if err != nil {
+		return errors.Wrapf(RemoteErrorSystem, err, "failed to wait for launch of execID [%s]", executionID.Name)
+	}
+
+	if !allowed {
+		return errors.Errorf(RemoteErrorThrottled, "launch of execID [%s] in [%s/%s] throttled", executionID.Name,
+			executionID.Project, executionID.Domain)
+	}
+
 	if launchCtx.RecoveryExecution != nil {
-		_, err = a.adminClient.RecoverExecution(ctx, &admin.ExecutionRecoverRequest{
-			Id:   launchCtx.RecoveryExecution,
-			Name: executionID.Name,
-			Metadata: &admin.ExecutionMetadata{
-				ParentNodeExecution: launchCtx.ParentNodeExecution,
-			},
+		err = a.retryResourceExhausted(ctx, func() error {
+			_, err := a.adminClient.RecoverExecution(ctx, &admin.ExecutionRecoverRequest{
+				Id:   launchCtx.RecoveryExecution,
+				Name: executionID.Name,
+				Metadata: &admin.ExecutionMetadata{
+					ParentNodeExecution: launchCtx.ParentNodeExecution,
+				},
+			})
+			return err
 		})
 		if err != nil {
 			launchErr := a.handleLaunchError(ctx, isRecovery, executionID, launchPlanRef, err)
@@ -111,7 +152,10 @@ func (a *adminLaunchPlanExecutor) Launch(ctx context.Context, launchCtx LaunchCo
 		},
 	}
 
-	_, err = a.adminClient.CreateExecution(ctx, req)
+	err = a.retryResourceExhausted(ctx, func() error {
+		_, err := a.adminClient.CreateExecution(ctx, req)
+		return err
+	})
 	if err != nil {
 		launchErr := a.handleLaunchError(ctx, !isRecovery, executionID, launchPlanRef, err)
 		if launchErr != nil {
@@ -142,6 +186,24 @@ func (a *adminLaunchPlanExecutor) GetStatus(ctx context.Context, executionID *co
 	return item.ExecutionClosure, item.SyncError
 }
 
+func (a *adminLaunchPlanExecutor) GetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) (*admin.ExecutionClosure, error) {
+	if executionID == nil {
+		return nil, fmt.Errorf("nil executionID")
+	}
+
+	res, err := a.adminClient.GetExecution(ctx, &admin.WorkflowExecutionGetRequest{
+		Id: executionID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, errors.Wrapf(RemoteErrorNotFound, err, "execID [%s] not found on remote", executionID.Name)
+		}
+		return nil, errors.Wrapf(RemoteErrorSystem, err, "system error")
+	}
+
+	return res.GetClosure(), nil
+}
+
 func (a *adminLaunchPlanExecutor) GetLaunchPlan(ctx context.Context, launchPlanRef *core.Identifier) (*admin.LaunchPlan, error) {
 	if launchPlanRef == nil {
 		return nil, fmt.Errorf("launch plan reference is nil")
@@ -181,9 +243,36 @@ func (a *adminLaunchPlanExecutor) Initialize(ctx context.Context) error {
 	return a.cache.Start(ctx)
 }
 
+// Groups the executions in the cache by project and domain, into batches of at most syncBatchSize executions, so that
+// the states of the executions of a batch can be polled from admin with a single call.
+func (a *adminLaunchPlanExecutor) createBatches(_ context.Context, snapshot []cache.ItemWrapper) ([]cache.Batch, error) {
+	batches := make([]cache.Batch, 0, len(snapshot))
+	pending := map[string]cache.Batch{}
+	for _, obj := range snapshot {
+		exec := obj.GetItem().(executionCacheItem)
+		key := exec.Project + "/" + exec.Domain
+		batch := append(pending[key], obj)
+		if len(batch) >= a.syncBatchSize {
+			batches = append(batches, batch)
+			batch = nil
+		}
+
+		pending[key] = batch
+	}
+
+	for _, batch := range pending {
+		if len(batch) > 0 {
+			batches = append(batches, batch)
+		}
+	}
+
+	return batches, nil
+}
+
 func (a *adminLaunchPlanExecutor) syncItem(ctx context.Context, batch cache.Batch) (
 	resp []cache.ItemSyncResponse, err error) {
 	resp = make([]cache.ItemSyncResponse, 0, len(batch))
+	pending := map[string][]executionCacheItem{}
 	for _, obj := range batch {
 		exec := obj.GetItem().(executionCacheItem)
 
@@ -200,55 +289,127 @@ func (a *adminLaunchPlanExecutor) syncItem(ctx context.Context, batch cache.Batc
 			}
 		}
 
-		// Workflow is not already terminated, lets check the status
-		req := &admin.WorkflowExecutionGetRequest{
-			Id: &exec.WorkflowExecutionIdentifier,
+		key := exec.Project + "/" + exec.Domain
+		pending[key] = append(pending[key], exec)
+	}
+
+	// Workflows are not already terminated, lets check their status
+	for _, execs := range pending {
+		if len(execs) == 1 {
+			resp = append(resp, a.syncExecution(ctx, execs[0]))
+		} else {
+			resp = append(resp, a.syncExecutions(ctx, execs)...)
 		}
+	}
 
-		res, err := a.adminClient.GetExecution(ctx, req)
-		if err != nil {
-			// TODO: Define which error codes are system errors (and return the error) vs user errors.
+	return resp, nil
+}
 
-			if status.Code(err) == codes.NotFound {
-				err = errors.Wrapf(RemoteErrorNotFound, err, "execID [%s] not found on remote", exec.WorkflowExecutionIdentifier.Name)
-			} else {
-				err = errors.Wrapf(RemoteErrorSystem, err, "system error")
-			}
+// Polls the state of a single execution from admin.
+func (a *adminLaunchPlanExecutor) syncExecution(ctx context.Context, exec executionCacheItem) cache.ItemSyncResponse {
+	req := &admin.WorkflowExecutionGetRequest{
+		Id: &exec.WorkflowExecutionIdentifier,
+	}
 
-			resp = append(resp, cache.ItemSyncResponse{
-				ID: obj.GetID(),
-				Item: executionCacheItem{
-					WorkflowExecutionIdentifier: exec.WorkflowExecutionIdentifier,
-					SyncError:                   err,
-				},
-				Action: cache.Update,
-			})
+	res, err := a.adminClient.GetExecution(ctx, req)
+	if err != nil {
+		// TODO: Define which error codes are system errors (and return the error) vs user errors.
 
-			continue
+		if status.Code(err) == codes.NotFound {
+			err = errors.Wrapf(RemoteErrorNotFound, err, "execID [%s] not found on remote", exec.WorkflowExecutionIdentifier.Name)
+		} else {
+			err = errors.Wrapf(RemoteErrorSystem, err, "system error")
 		}
 
-		// Update the cache with the retrieved status
-		resp = append(resp, cache.ItemSyncResponse{
-			ID: obj.GetID(),
+		return cache.ItemSyncResponse{
+			ID: exec.ID(),
 			Item: executionCacheItem{
 				WorkflowExecutionIdentifier: exec.WorkflowExecutionIdentifier,
-				ExecutionClosure:            res.Closure,
+				SyncError:                   err,
 			},
 			Action: cache.Update,
+		}
+	}
+
+	// Update the cache with the retrieved status
+	return cache.ItemSyncResponse{
+		ID: exec.ID(),
+		Item: executionCacheItem{
+			WorkflowExecutionIdentifier: exec.WorkflowExecutionIdentifier,
+			ExecutionClosure:            res.Closure,
+		},
+		Action: cache.Update,
+	}
+}
+
+// Polls the states of executions of the same project and domain from admin with a single call.
+func (a *adminLaunchPlanExecutor) syncExecutions(ctx context.Context, execs []executionCacheItem) []cache.ItemSyncResponse {
+	names := make([]string, 0, len(execs))
+	for _, exec := range execs {
+		names = append(names, exec.Name)
+	}
+
+	closures := make(map[string]*admin.ExecutionClosure, len(execs))
+	res, err := a.adminClient.ListExecutions(ctx, &admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: execs[0].Project,
+			Domain:  execs[0].Domain,
+		},
+		Filters: fmt.Sprintf("value_in(execution_name,%s)", strings.Join(names, ";")),
+		Limit:   uint32(len(execs)),
+	})
+	if err != nil {
+		err = errors.Wrapf(RemoteErrorSystem, err, "system error")
+	} else {
+		for _, execution := range res.Executions {
+			closures[execution.GetId().GetName()] = execution.Closure
+		}
+	}
+
+	resp := make([]cache.ItemSyncResponse, 0, len(execs))
+	for _, exec := range execs {
+		item := executionCacheItem{WorkflowExecutionIdentifier: exec.WorkflowExecutionIdentifier}
+		if err != nil {
+			item.SyncError = err
+		} else if closure, ok := closures[exec.Name]; ok {
+			item.ExecutionClosure = closure
+		} else {
+			item.SyncError = errors.Errorf(RemoteErrorNotFound, "execID [%s] not found on remote", exec.Name)
+		}
+
+		resp = append(resp, cache.ItemSyncResponse{
+			ID:     exec.ID(),
+			Item:   item,
+			Action: cache.Update,
 		})
 	}
 
-	return resp, nil
+	return resp
 }
 
 func NewAdminLaunchPlanExecutor(_ context.Context, client service.AdminServiceClient,
 	syncPeriod time.Duration, cfg *AdminConfig, scope promutils.Scope) (FlyteAdmin, error) {
 	exec := &adminLaunchPlanExecutor{
-		adminClient: client,
+		adminClient:              client,
+		launchLimiter:            newLaunchLimiter(cfg, scope),
+		resourceExhaustedRetries: cfg.ResourceExhaustedRetries,
+		resourceExhaustedBackoff: cfg.ResourceExhaustedBackoff.Duration,
+		resourceExhaustedRetried: scope.MustNewCounter("launch_resource_exhausted_retries", "Counts the launches retried"+
+			" because admin responded with RESOURCE_EXHAUSTED."),
+		syncBatchSize: cfg.SyncBatchSize,
+	}
+
+	if exec.syncBatchSize <= 0 {
+		exec.syncBatchSize = 1
+	}
+
+	if cfg.SyncPeriod.Duration > 0 {
+		syncPeriod = cfg.SyncPeriod.Duration
 	}
 
 	rateLimiter := &workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(cfg.TPS), cfg.Burst)}
-	c, err := cache.NewAutoRefreshCache("admin-launcher", exec.syncItem, rateLimiter, syncPeriod, cfg.Workers, cfg.MaxCacheSize, scope)
+	c, err := cache.NewAutoRefreshBatchedCache("admin-launcher", exec.createBatches, exec.syncItem, rateLimiter, syncPeriod,
+		cfg.Workers, cfg.MaxCacheSize, scope)
 	if err != nil {
 		return nil, err
 	}
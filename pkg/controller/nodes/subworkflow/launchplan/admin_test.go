@@ -9,6 +9,7 @@ import (
 
 	"github.com/flyteorg/flytestdlib/cache"
 	mocks2 "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/config"
 
 	"github.com/flyteorg/flytestdlib/promutils"
 
@@ -336,6 +337,64 @@ func TestAdminLaunchPlanExecutor_Launch(t *testing.T) {
 		assert.Error(t, err)
 		assert.False(t, IsAlreadyExists(err))
 	})
+
+	t.Run("resourceExhausted", func(t *testing.T) {
+
+		mockClient := &mocks.AdminServiceClient{}
+		cfg := *defaultAdminConfig
+		cfg.ResourceExhaustedBackoff = config.Duration{Duration: time.Millisecond}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, &cfg, promutils.NewTestScope())
+		assert.NoError(t, err)
+		mockClient.On("CreateExecution",
+			ctx,
+			mock.MatchedBy(func(o *admin.ExecutionCreateRequest) bool { return true }),
+		).Return(nil, status.Error(codes.ResourceExhausted, "")).Twice()
+		mockClient.On("CreateExecution",
+			ctx,
+			mock.MatchedBy(func(o *admin.ExecutionCreateRequest) bool { return true }),
+		).Return(nil, nil).Once()
+		err = exec.Launch(ctx, LaunchContext{}, id, &core.Identifier{}, nil)
+		assert.NoError(t, err)
+		mockClient.AssertNumberOfCalls(t, "CreateExecution", 3)
+	})
+
+	t.Run("resourceExhaustedRetriesExhausted", func(t *testing.T) {
+
+		mockClient := &mocks.AdminServiceClient{}
+		cfg := *defaultAdminConfig
+		cfg.ResourceExhaustedRetries = 1
+		cfg.ResourceExhaustedBackoff = config.Duration{Duration: time.Millisecond}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, &cfg, promutils.NewTestScope())
+		assert.NoError(t, err)
+		mockClient.On("CreateExecution",
+			ctx,
+			mock.MatchedBy(func(o *admin.ExecutionCreateRequest) bool { return true }),
+		).Return(nil, status.Error(codes.ResourceExhausted, ""))
+		err = exec.Launch(ctx, LaunchContext{}, id, &core.Identifier{}, nil)
+		assert.Error(t, err)
+		assert.False(t, IsUserError(err))
+		mockClient.AssertNumberOfCalls(t, "CreateExecution", 2)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+
+		mockClient := &mocks.AdminServiceClient{}
+		cfg := *defaultAdminConfig
+		cfg.ProjectDomainLaunchTPS = 1
+		cfg.ProjectDomainLaunchBurst = 1
+		cfg.MaxLaunchWait = config.Duration{Duration: 0}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, &cfg, promutils.NewTestScope())
+		assert.NoError(t, err)
+		mockClient.On("CreateExecution",
+			ctx,
+			mock.MatchedBy(func(o *admin.ExecutionCreateRequest) bool { return true }),
+		).Return(nil, nil)
+		assert.NoError(t, exec.Launch(ctx, LaunchContext{}, id, &core.Identifier{}, nil))
+		err = exec.Launch(ctx, LaunchContext{}, id, &core.Identifier{}, nil)
+		assert.Error(t, err)
+		assert.True(t, IsThrottled(err))
+		mockClient.AssertNumberOfCalls(t, "CreateExecution", 1)
+	})
 }
 
 func TestAdminLaunchPlanExecutor_Kill(t *testing.T) {
@@ -425,6 +484,118 @@ func TestNewAdminLaunchPlanExecutor_GetLaunchPlan(t *testing.T) {
 	})
 }
 
+func TestAdminLaunchPlanExecutor_GetRecoveredStatus(t *testing.T) {
+	ctx := context.TODO()
+	id := &core.WorkflowExecutionIdentifier{
+		Name:    "n",
+		Domain:  "d",
+		Project: "p",
+	}
+
+	t.Run("happy", func(t *testing.T) {
+		mockClient := &mocks.AdminServiceClient{}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, defaultAdminConfig, promutils.NewTestScope())
+		assert.NoError(t, err)
+		closure := &admin.ExecutionClosure{Phase: core.WorkflowExecution_SUCCEEDED}
+		mockClient.OnGetExecutionMatch(
+			ctx,
+			mock.MatchedBy(func(o *admin.WorkflowExecutionGetRequest) bool { return proto.Equal(o.Id, id) }),
+		).Return(&admin.Execution{Id: id, Closure: closure}, nil)
+		s, err := exec.GetRecoveredStatus(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, closure, s)
+	})
+
+	t.Run("notFound", func(t *testing.T) {
+		mockClient := &mocks.AdminServiceClient{}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, defaultAdminConfig, promutils.NewTestScope())
+		assert.NoError(t, err)
+		mockClient.OnGetExecutionMatch(ctx, mock.Anything).Return(nil, status.Error(codes.NotFound, ""))
+		s, err := exec.GetRecoveredStatus(ctx, id)
+		assert.Nil(t, s)
+		assert.True(t, IsNotFound(err))
+	})
+}
+
+func TestAdminLaunchPlanExecutor_SyncBatches(t *testing.T) {
+	ctx := context.TODO()
+	itemWrapper := func(project, domain, name string) cache.ItemWrapper {
+		item := executionCacheItem{WorkflowExecutionIdentifier: core.WorkflowExecutionIdentifier{
+			Project: project, Domain: domain, Name: name,
+		}}
+		iwMock := &mocks2.ItemWrapper{}
+		iwMock.OnGetItem().Return(item)
+		iwMock.OnGetID().Return(item.ID())
+		return iwMock
+	}
+
+	t.Run("createBatches", func(t *testing.T) {
+		cfg := *defaultAdminConfig
+		cfg.SyncBatchSize = 2
+		exec, err := NewAdminLaunchPlanExecutor(ctx, &mocks.AdminServiceClient{}, time.Second, &cfg, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		batches, err := exec.(*adminLaunchPlanExecutor).createBatches(ctx, []cache.ItemWrapper{
+			itemWrapper("p", "d", "a"),
+			itemWrapper("p", "d", "b"),
+			itemWrapper("p", "d", "c"),
+			itemWrapper("p2", "d", "e"),
+		})
+		assert.NoError(t, err)
+		sizes := map[int]int{}
+		for _, batch := range batches {
+			sizes[len(batch)]++
+			project := batch[0].GetItem().(executionCacheItem).Project
+			for _, obj := range batch {
+				assert.Equal(t, project, obj.GetItem().(executionCacheItem).Project)
+			}
+		}
+		assert.Equal(t, map[int]int{2: 1, 1: 2}, sizes)
+	})
+
+	t.Run("bulk", func(t *testing.T) {
+		mockClient := &mocks.AdminServiceClient{}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, defaultAdminConfig, promutils.NewTestScope())
+		assert.NoError(t, err)
+		closure := &admin.ExecutionClosure{Phase: core.WorkflowExecution_RUNNING}
+		mockClient.OnListExecutionsMatch(ctx, mock.MatchedBy(func(o *admin.ResourceListRequest) bool {
+			return o.Id.Project == "p" && o.Id.Domain == "d" && o.Filters == "value_in(execution_name,a;b)" && o.Limit == 2
+		})).Return(&admin.ExecutionList{Executions: []*admin.Execution{
+			{Id: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "a"}, Closure: closure},
+		}}, nil)
+
+		resp, err := exec.(*adminLaunchPlanExecutor).syncItem(ctx, cache.Batch{
+			itemWrapper("p", "d", "a"),
+			itemWrapper("p", "d", "b"),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+		assert.Equal(t, closure, resp[0].Item.(executionCacheItem).ExecutionClosure)
+		assert.NoError(t, resp[0].Item.(executionCacheItem).SyncError)
+		assert.True(t, IsNotFound(resp[1].Item.(executionCacheItem).SyncError))
+		mockClient.AssertNotCalled(t, "GetExecution", mock.Anything, mock.Anything)
+	})
+
+	t.Run("bulk-error", func(t *testing.T) {
+		mockClient := &mocks.AdminServiceClient{}
+		exec, err := NewAdminLaunchPlanExecutor(ctx, mockClient, time.Second, defaultAdminConfig, promutils.NewTestScope())
+		assert.NoError(t, err)
+		mockClient.OnListExecutionsMatch(ctx, mock.Anything).Return(nil, status.Error(codes.Unavailable, ""))
+
+		resp, err := exec.(*adminLaunchPlanExecutor).syncItem(ctx, cache.Batch{
+			itemWrapper("p", "d", "a"),
+			itemWrapper("p", "d", "b"),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+		for _, r := range resp {
+			syncErr := r.Item.(executionCacheItem).SyncError
+			assert.Error(t, syncErr)
+			assert.False(t, IsNotFound(syncErr))
+		}
+	})
+}
+
 func TestIsWorkflowTerminated(t *testing.T) {
 	assert.True(t, IsWorkflowTerminated(core.WorkflowExecution_SUCCEEDED))
 	assert.True(t, IsWorkflowTerminated(core.WorkflowExecution_ABORTED))
@@ -1,6 +1,10 @@
 package launchplan
 
 import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+
 	ctrlConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
 )
 
@@ -8,10 +12,22 @@ import (
 
 var (
 	defaultAdminConfig = &AdminConfig{
-		TPS:          100,
-		Burst:        10,
-		MaxCacheSize: 10000,
-		Workers:      10,
+		TPS:                      100,
+		Burst:                    10,
+		MaxCacheSize:             10000,
+		Workers:                  10,
+		LaunchTPS:                100,
+		LaunchBurst:              100,
+		ProjectDomainLaunchTPS:   20,
+		ProjectDomainLaunchBurst: 50,
+		MaxLaunchWait: config.Duration{
+			Duration: time.Second,
+		},
+		ResourceExhaustedRetries: 3,
+		ResourceExhaustedBackoff: config.Duration{
+			Duration: 500 * time.Millisecond,
+		},
+		SyncBatchSize: 50,
 	}
 
 	adminConfigSection = ctrlConfig.MustRegisterSubSection("admin-launcher", defaultAdminConfig)
@@ -31,6 +47,46 @@ type AdminConfig struct {
 	MaxCacheSize int `json:"cacheSize" pflag:",Maximum cache in terms of number of items stored."`
 
 	Workers int `json:"workers" pflag:",Number of parallel workers to work on the queue."`
+
+	// TerminationGracePeriod is the maximum time the abort of a node waits for its terminated child execution to reach a
+	// terminal phase. The phase is re-checked on every round, so the wait does not hold up the evaluation of the
+	// workflow. If it's zero, child executions are terminated without waiting for them to complete.
+	TerminationGracePeriod config.Duration `json:"terminationGracePeriod" pflag:",Maximum time to wait for a terminated child execution to reach a terminal phase. Zero disables the wait."`
+
+	// LaunchTPS limits the rate at which child executions are created across all projects. If it's zero, the rate is
+	// not limited.
+	LaunchTPS int64 `json:"launchTps" pflag:",The maximum number of child executions created per second. Zero disables the limit."`
+
+	LaunchBurst int `json:"launchBurst" pflag:",Maximum burst of child executions created."`
+
+	// ProjectDomainLaunchTPS limits the rate at which child executions are created in every project and domain, so that
+	// a single fan-out cannot use up the rate of all projects. If it's zero, the rate is not limited.
+	ProjectDomainLaunchTPS int64 `json:"projectDomainLaunchTps" pflag:",The maximum number of child executions created per second in a project and domain. Zero disables the limit."`
+
+	ProjectDomainLaunchBurst int `json:"projectDomainLaunchBurst" pflag:",Maximum burst of child executions created in a project and domain."`
+
+	// MaxLaunchWait is the maximum time a launch waits to be allowed by the rate limits. Launches that would need to
+	// wait longer are postponed to a later round, rather than holding up the evaluation of the workflow.
+	MaxLaunchWait config.Duration `json:"maxLaunchWait" pflag:",Maximum time a launch waits to be allowed by the rate limits before it's postponed."`
+
+	// ResourceExhaustedRetries is the number of times the creation of a child execution is retried when FlyteAdmin
+	// responds with RESOURCE_EXHAUSTED.
+	ResourceExhaustedRetries int `json:"resourceExhaustedRetries" pflag:",Number of times a launch is retried when admin responds with RESOURCE_EXHAUSTED."`
+
+	ResourceExhaustedBackoff config.Duration `json:"resourceExhaustedBackoff" pflag:",Initial backoff between retries of launches admin responded to with RESOURCE_EXHAUSTED, doubled on every retry."`
+
+	// SyncPeriod is the interval at which the cached states of child executions are synced from FlyteAdmin. If it's
+	// zero, the downstream-eval-duration is used.
+	SyncPeriod config.Duration `json:"syncPeriod" pflag:",Interval at which the states of child executions are synced from admin. Zero uses the downstream-eval-duration."`
+
+	// SyncBatchSize is the maximum number of child executions of the same project and domain whose states are polled
+	// from FlyteAdmin with a single call.
+	SyncBatchSize int `json:"syncBatchSize" pflag:",Maximum number of child executions of a project and domain synced from admin with a single call."`
+
+	// ValidateInterface fetches the launch plan from FlyteAdmin before launching a child execution, to check that its
+	// interface still accepts the inputs of the node. If it drifted since the parent workflow was compiled, the node fails
+	// with the mismatching inputs rather than the child execution failing to bind them.
+	ValidateInterface bool `json:"validateInterface" pflag:",Check that the interface of a launch plan accepts the inputs of the node before launching it."`
 }
 
 func GetAdminConfig() *AdminConfig {
@@ -54,5 +54,16 @@ func (cfg AdminConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "burst"), defaultAdminConfig.Burst, "Maximum burst for throttle")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "cacheSize"), defaultAdminConfig.MaxCacheSize, "Maximum cache in terms of number of items stored.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "workers"), defaultAdminConfig.Workers, "Number of parallel workers to work on the queue.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "terminationGracePeriod"), defaultAdminConfig.TerminationGracePeriod.String(), "Maximum time to wait for a terminated child execution to reach a terminal phase. Zero disables the wait.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "launchTps"), defaultAdminConfig.LaunchTPS, "The maximum number of child executions created per second. Zero disables the limit.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "launchBurst"), defaultAdminConfig.LaunchBurst, "Maximum burst of child executions created.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "projectDomainLaunchTps"), defaultAdminConfig.ProjectDomainLaunchTPS, "The maximum number of child executions created per second in a project and domain. Zero disables the limit.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "projectDomainLaunchBurst"), defaultAdminConfig.ProjectDomainLaunchBurst, "Maximum burst of child executions created in a project and domain.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "maxLaunchWait"), defaultAdminConfig.MaxLaunchWait.String(), "Maximum time a launch waits to be allowed by the rate limits before it's postponed.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "resourceExhaustedRetries"), defaultAdminConfig.ResourceExhaustedRetries, "Number of times a launch is retried when admin responds with RESOURCE_EXHAUSTED.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "resourceExhaustedBackoff"), defaultAdminConfig.ResourceExhaustedBackoff.String(), "Initial backoff between retries of launches admin responded to with RESOURCE_EXHAUSTED, doubled on every retry.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "syncPeriod"), defaultAdminConfig.SyncPeriod.String(), "Interval at which the states of child executions are synced from admin. Zero uses the downstream-eval-duration.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "syncBatchSize"), defaultAdminConfig.SyncBatchSize, "Maximum number of child executions of a project and domain synced from admin with a single call.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "validateInterface"), defaultAdminConfig.ValidateInterface, "Check that the interface of a launch plan accepts the inputs of the node before launching it.")
 	return cmdFlags
 }
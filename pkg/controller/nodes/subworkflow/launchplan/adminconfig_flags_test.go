@@ -155,4 +155,158 @@ func TestAdminConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_terminationGracePeriod", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultAdminConfig.TerminationGracePeriod.String()
+
+			cmdFlags.Set("terminationGracePeriod", testValue)
+			if vString, err := cmdFlags.GetString("terminationGracePeriod"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vString), &actual.TerminationGracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_launchTps", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("launchTps", testValue)
+			if vInt64, err := cmdFlags.GetInt64("launchTps"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vInt64), &actual.LaunchTPS)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_launchBurst", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("launchBurst", testValue)
+			if vInt, err := cmdFlags.GetInt("launchBurst"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vInt), &actual.LaunchBurst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_projectDomainLaunchTps", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("projectDomainLaunchTps", testValue)
+			if vInt64, err := cmdFlags.GetInt64("projectDomainLaunchTps"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vInt64), &actual.ProjectDomainLaunchTPS)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_projectDomainLaunchBurst", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("projectDomainLaunchBurst", testValue)
+			if vInt, err := cmdFlags.GetInt("projectDomainLaunchBurst"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vInt), &actual.ProjectDomainLaunchBurst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_maxLaunchWait", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultAdminConfig.MaxLaunchWait.String()
+
+			cmdFlags.Set("maxLaunchWait", testValue)
+			if vString, err := cmdFlags.GetString("maxLaunchWait"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vString), &actual.MaxLaunchWait)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_resourceExhaustedRetries", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("resourceExhaustedRetries", testValue)
+			if vInt, err := cmdFlags.GetInt("resourceExhaustedRetries"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vInt), &actual.ResourceExhaustedRetries)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_resourceExhaustedBackoff", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultAdminConfig.ResourceExhaustedBackoff.String()
+
+			cmdFlags.Set("resourceExhaustedBackoff", testValue)
+			if vString, err := cmdFlags.GetString("resourceExhaustedBackoff"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vString), &actual.ResourceExhaustedBackoff)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_syncPeriod", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultAdminConfig.SyncPeriod.String()
+
+			cmdFlags.Set("syncPeriod", testValue)
+			if vString, err := cmdFlags.GetString("syncPeriod"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vString), &actual.SyncPeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_syncBatchSize", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("syncBatchSize", testValue)
+			if vInt, err := cmdFlags.GetInt("syncBatchSize"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vInt), &actual.SyncBatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_validateInterface", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("validateInterface", testValue)
+			if vBool, err := cmdFlags.GetBool("validateInterface"); err == nil {
+				testDecodeJson_AdminConfig(t, fmt.Sprintf("%v", vBool), &actual.ValidateInterface)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
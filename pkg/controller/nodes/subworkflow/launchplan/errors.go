@@ -11,6 +11,7 @@ const (
 	RemoteErrorNotFound      ErrorCode = "NotFound"
 	RemoteErrorSystem        ErrorCode = "SystemError" // timeouts, network error etc
 	RemoteErrorUser          ErrorCode = "UserError"   // Incase of bad specification, invalid arguments, etc
+	RemoteErrorThrottled     ErrorCode = "Throttled"   // The launch was postponed by the client side rate limits
 )
 
 // Checks if the error is of type RemoteError and the ErrorCode is of type RemoteErrorAlreadyExists
@@ -27,3 +28,8 @@ func IsUserError(err error) bool {
 func IsNotFound(err error) bool {
 	return errors2.IsCausedBy(err, RemoteErrorNotFound)
 }
+
+// Checks if the error is of type RemoteError and the ErrorCode is of type RemoteErrorThrottled
+func IsThrottled(err error) bool {
+	return errors2.IsCausedBy(err, RemoteErrorThrottled)
+}
@@ -0,0 +1,118 @@
+package launchplan
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+type launchLimiterMetrics struct {
+	// Per project and domain
+	QueueDepth *prometheus.GaugeVec
+	Throttled  *prometheus.CounterVec
+}
+
+// launchLimiter limits the rate at which child executions are created in FlyteAdmin, across all projects and for every
+// project and domain, so that a fan-out of launch plan nodes does not stampede FlyteAdmin. Launches wait for a token
+// up to maxWait, the ones that would wait longer are throttled and are expected to be attempted again later.
+type launchLimiter struct {
+	global        *rate.Limiter
+	projectDomain map[string]*rate.Limiter
+	tps           rate.Limit
+	burst         int
+	maxWait       time.Duration
+	lock          sync.Mutex
+	metrics       launchLimiterMetrics
+}
+
+func (l *launchLimiter) getProjectDomainLimiter(project, domain string) *rate.Limiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := project + "/" + domain
+	limiter, ok := l.projectDomain[key]
+	if !ok {
+		limiter = rate.NewLimiter(l.tps, l.burst)
+		l.projectDomain[key] = limiter
+	}
+
+	return limiter
+}
+
+// Wait blocks until the launch of an execution in the project and domain is allowed, and returns false without
+// blocking if the launch would need to wait longer than maxWait.
+func (l *launchLimiter) Wait(ctx context.Context, project, domain string) (bool, error) {
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, 2)
+	delay := time.Duration(0)
+	for _, limiter := range []*rate.Limiter{l.getProjectDomainLimiter(project, domain), l.global} {
+		if limiter.Limit() == rate.Inf {
+			continue
+		}
+
+		r := limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if !r.OK() || r.DelayFrom(now) > l.maxWait {
+			for _, reserved := range reservations {
+				reserved.CancelAt(now)
+			}
+
+			l.metrics.Throttled.WithLabelValues(project, domain).Inc()
+			return false, nil
+		}
+
+		if d := r.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+
+	if delay == 0 {
+		return true, nil
+	}
+
+	queueDepth := l.metrics.QueueDepth.WithLabelValues(project, domain)
+	queueDepth.Inc()
+	defer queueDepth.Dec()
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true, nil
+	case <-ctx.Done():
+		for _, reserved := range reservations {
+			reserved.Cancel()
+		}
+
+		return false, ctx.Err()
+	}
+}
+
+// Converts a limit in transactions per second to a rate limit, a limit of zero or less disables rate limiting.
+func tpsToLimit(tps int64) rate.Limit {
+	if tps <= 0 {
+		return rate.Inf
+	}
+
+	return rate.Limit(tps)
+}
+
+func newLaunchLimiter(cfg *AdminConfig, scope promutils.Scope) *launchLimiter {
+	return &launchLimiter{
+		global:        rate.NewLimiter(tpsToLimit(cfg.LaunchTPS), cfg.LaunchBurst),
+		projectDomain: map[string]*rate.Limiter{},
+		tps:           tpsToLimit(cfg.ProjectDomainLaunchTPS),
+		burst:         cfg.ProjectDomainLaunchBurst,
+		maxWait:       cfg.MaxLaunchWait.Duration,
+		metrics: launchLimiterMetrics{
+			QueueDepth: scope.MustNewGaugeVec("launch_queue_depth", "Number of launches of child executions in the"+
+				" project and domain waiting to be allowed by the rate limits.", "project", "domain"),
+			Throttled: scope.MustNewCounterVec("launch_throttled", "Counts the launches of child executions in the"+
+				" project and domain that were throttled and postponed.", "project", "domain"),
+		},
+	}
+}
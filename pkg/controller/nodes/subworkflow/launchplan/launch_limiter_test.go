@@ -0,0 +1,64 @@
+package launchplan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaunchLimiter_Wait(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("per-project-domain", func(t *testing.T) {
+		l := newLaunchLimiter(&AdminConfig{
+			ProjectDomainLaunchTPS:   1,
+			ProjectDomainLaunchBurst: 1,
+		}, promutils.NewTestScope())
+
+		allowed, err := l.Wait(ctx, "p", "d")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = l.Wait(ctx, "p", "d")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+
+		// Other projects and domains have their own budget
+		allowed, err = l.Wait(ctx, "p", "d2")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		l := newLaunchLimiter(&AdminConfig{
+			LaunchTPS:   1,
+			LaunchBurst: 1,
+		}, promutils.NewTestScope())
+
+		allowed, err := l.Wait(ctx, "p", "d")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = l.Wait(ctx, "p", "d2")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		l := newLaunchLimiter(&AdminConfig{
+			ProjectDomainLaunchTPS:   100,
+			ProjectDomainLaunchBurst: 1,
+			MaxLaunchWait:            config.Duration{Duration: time.Second},
+		}, promutils.NewTestScope())
+
+		for i := 0; i < 3; i++ {
+			allowed, err := l.Wait(ctx, "p", "d")
+			assert.NoError(t, err)
+			assert.True(t, allowed)
+		}
+	})
+}
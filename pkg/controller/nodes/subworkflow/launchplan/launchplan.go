@@ -38,6 +38,10 @@ type Executor interface {
 	// GetStatus retrieves status of a LaunchPlan execution
 	GetStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) (*admin.ExecutionClosure, error)
 
+	// GetRecoveredStatus retrieves status of an execution launched by a previous run of the parent workflow. The
+	// execution is fetched directly from the remote system and is not tracked for status updates.
+	GetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) (*admin.ExecutionClosure, error)
+
 	// Kill a remote execution
 	Kill(ctx context.Context, executionID *core.WorkflowExecutionIdentifier, reason string) error
 
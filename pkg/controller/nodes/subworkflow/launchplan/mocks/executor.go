@@ -19,6 +19,47 @@ type Executor struct {
 	mock.Mock
 }
 
+type Executor_GetRecoveredStatus struct {
+	*mock.Call
+}
+
+func (_m Executor_GetRecoveredStatus) Return(_a0 *admin.ExecutionClosure, _a1 error) *Executor_GetRecoveredStatus {
+	return &Executor_GetRecoveredStatus{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *Executor) OnGetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) *Executor_GetRecoveredStatus {
+	c_call := _m.On("GetRecoveredStatus", ctx, executionID)
+	return &Executor_GetRecoveredStatus{Call: c_call}
+}
+
+func (_m *Executor) OnGetRecoveredStatusMatch(matchers ...interface{}) *Executor_GetRecoveredStatus {
+	c_call := _m.On("GetRecoveredStatus", matchers...)
+	return &Executor_GetRecoveredStatus{Call: c_call}
+}
+
+// GetRecoveredStatus provides a mock function with given fields: ctx, executionID
+func (_m *Executor) GetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) (*admin.ExecutionClosure, error) {
+	ret := _m.Called(ctx, executionID)
+
+	var r0 *admin.ExecutionClosure
+	if rf, ok := ret.Get(0).(func(context.Context, *core.WorkflowExecutionIdentifier) *admin.ExecutionClosure); ok {
+		r0 = rf(ctx, executionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ExecutionClosure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *core.WorkflowExecutionIdentifier) error); ok {
+		r1 = rf(ctx, executionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type Executor_GetStatus struct {
 	*mock.Call
 }
@@ -60,6 +60,47 @@ func (_m *FlyteAdmin) GetLaunchPlan(ctx context.Context, launchPlanRef *core.Ide
 	return r0, r1
 }
 
+type FlyteAdmin_GetRecoveredStatus struct {
+	*mock.Call
+}
+
+func (_m FlyteAdmin_GetRecoveredStatus) Return(_a0 *admin.ExecutionClosure, _a1 error) *FlyteAdmin_GetRecoveredStatus {
+	return &FlyteAdmin_GetRecoveredStatus{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *FlyteAdmin) OnGetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) *FlyteAdmin_GetRecoveredStatus {
+	c_call := _m.On("GetRecoveredStatus", ctx, executionID)
+	return &FlyteAdmin_GetRecoveredStatus{Call: c_call}
+}
+
+func (_m *FlyteAdmin) OnGetRecoveredStatusMatch(matchers ...interface{}) *FlyteAdmin_GetRecoveredStatus {
+	c_call := _m.On("GetRecoveredStatus", matchers...)
+	return &FlyteAdmin_GetRecoveredStatus{Call: c_call}
+}
+
+// GetRecoveredStatus provides a mock function with given fields: ctx, executionID
+func (_m *FlyteAdmin) GetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) (*admin.ExecutionClosure, error) {
+	ret := _m.Called(ctx, executionID)
+
+	var r0 *admin.ExecutionClosure
+	if rf, ok := ret.Get(0).(func(context.Context, *core.WorkflowExecutionIdentifier) *admin.ExecutionClosure); ok {
+		r0 = rf(ctx, executionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ExecutionClosure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *core.WorkflowExecutionIdentifier) error); ok {
+		r1 = rf(ctx, executionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type FlyteAdmin_GetStatus struct {
 	*mock.Call
 }
@@ -26,6 +26,11 @@ func (failFastWorkflowLauncher) GetStatus(ctx context.Context, executionID *core
 	return nil, errors.Wrapf(RemoteErrorUser, fmt.Errorf("badly configured system"), "please enable admin workflow launch to use launchplans")
 }
 
+func (failFastWorkflowLauncher) GetRecoveredStatus(ctx context.Context, executionID *core.WorkflowExecutionIdentifier) (*admin.ExecutionClosure, error) {
+	logger.Infof(ctx, "NOOP: Recovered Workflow Status ExecID [%s]", executionID.Name)
+	return nil, errors.Wrapf(RemoteErrorUser, fmt.Errorf("badly configured system"), "please enable admin workflow launch to use launchplans")
+}
+
 func (failFastWorkflowLauncher) Kill(ctx context.Context, executionID *core.WorkflowExecutionIdentifier, reason string) error {
 	return nil
 }
@@ -21,6 +21,16 @@ func TestFailFastWorkflowLauncher(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("getRecoveredStatus", func(t *testing.T) {
+		a, err := f.GetRecoveredStatus(ctx, &core.WorkflowExecutionIdentifier{
+			Project: "p",
+			Domain:  "d",
+			Name:    "n",
+		})
+		assert.Nil(t, a)
+		assert.Error(t, err)
+	})
+
 	t.Run("launch", func(t *testing.T) {
 		err := f.Launch(ctx, LaunchContext{
 			ParentNodeExecution: &core.NodeExecutionIdentifier{
@@ -0,0 +1,136 @@
+package subworkflow
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+)
+
+var cacheDisabled = catalog.NewStatus(core.CatalogCacheStatus_CACHE_DISABLED, nil)
+
+// isCacheable returns true if the launch plan node requests catalog caching and a catalog client is configured.
+func (l *launchPlanHandler) isCacheable(nCtx handler.NodeExecutionContext) bool {
+	if l.catalog == nil || l.launchPlanReader == nil {
+		return false
+	}
+	wfNode := nCtx.Node().GetWorkflowNode()
+	return wfNode != nil && wfNode.IsDiscoverable()
+}
+
+// launchPlanInterface builds the typed interface of the launch plan from its expected inputs and outputs.
+func launchPlanInterface(lp *admin.LaunchPlan) core.TypedInterface {
+	inputs := &core.VariableMap{Variables: map[string]*core.Variable{}}
+	for name, p := range lp.GetClosure().GetExpectedInputs().GetParameters() {
+		inputs.Variables[name] = p.GetVar()
+	}
+
+	outputs := lp.GetClosure().GetExpectedOutputs()
+	if outputs == nil {
+		outputs = &core.VariableMap{Variables: map[string]*core.Variable{}}
+	}
+
+	return core.TypedInterface{Inputs: inputs, Outputs: outputs}
+}
+
+func (l *launchPlanHandler) getCatalogKey(ctx context.Context, nCtx handler.NodeExecutionContext) (catalog.Key, error) {
+	wfNode := nCtx.Node().GetWorkflowNode()
+	lpID := wfNode.GetLaunchPlanRefID().Identifier
+	lp, err := l.launchPlanReader.GetLaunchPlan(ctx, lpID)
+	if err != nil {
+		return catalog.Key{}, errors.Wrapf(err, "failed to retrieve launch plan [%v] interface", lpID)
+	}
+
+	return catalog.Key{
+		Identifier:     *lpID,
+		CacheVersion:   wfNode.GetDiscoveryVersion(),
+		TypedInterface: launchPlanInterface(lp),
+		InputReader:    nCtx.InputReader(),
+	}, nil
+}
+
+func (l *launchPlanHandler) getCatalogMetadata(nCtx handler.NodeExecutionContext) catalog.Metadata {
+	lpID := nCtx.Node().GetWorkflowNode().GetLaunchPlanRefID().Identifier
+	return catalog.Metadata{
+		TaskExecutionIdentifier: &core.TaskExecutionIdentifier{
+			TaskId:          lpID,
+			NodeExecutionId: nCtx.NodeExecutionMetadata().GetNodeExecutionID(),
+			RetryAttempt:    nCtx.CurrentAttempt(),
+		},
+	}
+}
+
+// CheckCatalogCache looks up the outputs of a cacheable launch plan node in the catalog. A cache hit allows the node to
+// succeed without launching a child execution.
+func (l *launchPlanHandler) CheckCatalogCache(ctx context.Context, nCtx handler.NodeExecutionContext) (catalog.Entry, error) {
+	if !l.isCacheable(nCtx) {
+		return catalog.NewCatalogEntry(nil, cacheDisabled), nil
+	}
+
+	key, err := l.getCatalogKey(ctx, nCtx)
+	if err != nil {
+		return catalog.Entry{}, err
+	}
+
+	logger.Infof(ctx, "Catalog CacheEnabled: Looking up catalog Cache for launch plan [%v].", key.Identifier)
+	resp, err := l.catalog.Get(ctx, key)
+	if err != nil {
+		causeErr := errors.Cause(err)
+		if lpStatus, ok := status.FromError(causeErr); ok && lpStatus.Code() == codes.NotFound {
+			l.metrics.CatalogMissCount.Inc(ctx)
+			logger.Infof(ctx, "Catalog CacheMiss: Artifact not found in Catalog. Launching launch plan.")
+			return catalog.NewCatalogEntry(nil, catalog.NewStatus(core.CatalogCacheStatus_CACHE_MISS, nil)), nil
+		}
+
+		l.metrics.CatalogGetFailureCount.Inc(ctx)
+		logger.Errorf(ctx, "Catalog Failure: memoization check failed. err: %v", err.Error())
+		return catalog.Entry{}, errors.Wrapf(err, "Failed to check Catalog for previous results")
+	}
+
+	if resp.GetStatus().GetCacheStatus() != core.CatalogCacheStatus_CACHE_HIT {
+		logger.Errorf(ctx, "No CacheHIT and no Error received. Illegal state, Cache State: %s", resp.GetStatus().GetCacheStatus().String())
+		return resp, nil
+	}
+
+	logger.Infof(ctx, "Catalog CacheHit: for launch plan [%v]", key.Identifier)
+	l.metrics.CatalogHitCount.Inc(ctx)
+	return resp, nil
+}
+
+// WriteCatalogCache records the outputs of a successfully completed child execution in the catalog. Failures to write
+// to the catalog are not fatal and are reported through the returned status.
+func (l *launchPlanHandler) WriteCatalogCache(ctx context.Context, nCtx handler.NodeExecutionContext) catalog.Status {
+	if !l.isCacheable(nCtx) {
+		return cacheDisabled
+	}
+
+	key, err := l.getCatalogKey(ctx, nCtx)
+	if err != nil {
+		l.metrics.CatalogPutFailureCount.Inc(ctx)
+		logger.Errorf(ctx, "Failed to compute catalog key for node [%s]. Error: %v", nCtx.NodeID(), err)
+		return catalog.NewStatus(core.CatalogCacheStatus_CACHE_PUT_FAILURE, nil)
+	}
+
+	outputPaths := ioutils.NewReadOnlyOutputFilePaths(ctx, nCtx.DataStore(), nCtx.NodeStatus().GetOutputDir())
+	outputReader := ioutils.NewRemoteFileOutputReader(ctx, nCtx.DataStore(), outputPaths, nCtx.MaxDatasetSizeBytes())
+
+	logger.Infof(ctx, "Catalog CacheEnabled. recording execution of launch plan [%v]", key.Identifier)
+	s, err := l.catalog.Put(ctx, key, outputReader, l.getCatalogMetadata(nCtx))
+	if err != nil {
+		l.metrics.CatalogPutFailureCount.Inc(ctx)
+		logger.Errorf(ctx, "Failed to write results to catalog for launch plan [%v]. Error: %v", key.Identifier, err)
+		return catalog.NewStatus(core.CatalogCacheStatus_CACHE_PUT_FAILURE, s.GetMetadata())
+	}
+
+	l.metrics.CatalogPutSuccessCount.Inc(ctx)
+	logger.Infof(ctx, "Successfully cached results to catalog - launch plan [%v]", key.Identifier)
+	return s
+}
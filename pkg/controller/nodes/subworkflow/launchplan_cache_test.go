@@ -0,0 +1,176 @@
+package subworkflow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	catalogMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	mocks2 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan/mocks"
+)
+
+func TestLaunchPlanHandler_CatalogCache(t *testing.T) {
+	ctx := context.TODO()
+
+	lpID := &core.Identifier{
+		Project:      "p",
+		Domain:       "d",
+		Name:         "n",
+		Version:      "v",
+		ResourceType: core.ResourceType_LAUNCH_PLAN,
+	}
+	lp := &admin.LaunchPlan{
+		Id: lpID,
+		Closure: &admin.LaunchPlanClosure{
+			ExpectedInputs: &core.ParameterMap{},
+			ExpectedOutputs: &core.VariableMap{
+				Variables: map[string]*core.Variable{
+					"x": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+				},
+			},
+		},
+	}
+
+	mockWfNode := &mocks2.ExecutableWorkflowNode{}
+	mockWfNode.OnGetLaunchPlanRefID().Return(&v1alpha1.Identifier{Identifier: lpID})
+	mockWfNode.OnIsDiscoverable().Return(true)
+	mockWfNode.OnGetDiscoveryVersion().Return("1.0")
+
+	mockNode := &mocks2.ExecutableNode{}
+	mockNode.OnGetID().Return("n1")
+	mockNode.OnGetWorkflowNode().Return(mockWfNode)
+
+	mockNodeStatus := &mocks2.ExecutableNodeStatus{}
+	mockNodeStatus.OnGetAttempts().Return(uint32(1))
+	mockNodeStatus.OnGetOutputDir().Return("s3://output-dir")
+
+	newHandler := func(c catalog.Client, lpExec *mocks.Executor) launchPlanHandler {
+		lpReader := &mocks.Reader{}
+		lpReader.OnGetLaunchPlanMatch(mock.Anything, lpID).Return(lp, nil)
+		return launchPlanHandler{
+			launchPlan:       lpExec,
+			launchPlanReader: lpReader,
+			catalog:          c,
+			metrics:          newMetrics(promutils.NewTestScope()),
+		}
+	}
+
+	t.Run("cache-hit", func(t *testing.T) {
+		outputs := coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap()
+		c := &catalogMocks.Client{}
+		c.OnGetMatch(mock.Anything, mock.MatchedBy(func(k catalog.Key) bool {
+			return k.CacheVersion == "1.0" && k.Identifier.Name == lpID.Name
+		})).Return(catalog.NewCatalogEntry(ioutils.NewInMemoryOutputReader(outputs, nil),
+			catalog.NewStatus(core.CatalogCacheStatus_CACHE_HIT, nil)), nil)
+
+		mockLPExec := &mocks.Executor{}
+		h := newHandler(c, mockLPExec)
+
+		dataStore := createInmemoryStore(t)
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseUndefined, mockNode, mockNodeStatus)
+		nCtx.OnDataStore().Return(dataStore)
+
+		s, err := h.StartLaunchPlan(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseSuccess, s.Info().GetPhase())
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_HIT, s.Info().GetInfo().TaskNodeInfo.TaskNodeMetadata.CacheStatus)
+		mockLPExec.AssertNotCalled(t, "Launch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		actual := &core.LiteralMap{}
+		assert.NoError(t, dataStore.ReadProtobuf(ctx, v1alpha1.GetOutputsFile("s3://output-dir"), actual))
+		assert.Equal(t, int64(1), actual.Literals["x"].GetScalar().GetPrimitive().GetInteger())
+	})
+
+	t.Run("cache-miss", func(t *testing.T) {
+		c := &catalogMocks.Client{}
+		c.OnGetMatch(mock.Anything, mock.Anything).Return(catalog.Entry{}, status.Error(codes.NotFound, "not found"))
+
+		mockLPExec := &mocks.Executor{}
+		mockLPExec.OnLaunchMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		h := newHandler(c, mockLPExec)
+
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseUndefined, mockNode, mockNodeStatus)
+		s, err := h.StartLaunchPlan(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRunning, s.Info().GetPhase())
+	})
+
+	t.Run("catalog-failure", func(t *testing.T) {
+		c := &catalogMocks.Client{}
+		c.OnGetMatch(mock.Anything, mock.Anything).Return(catalog.Entry{}, fmt.Errorf("failed to read from catalog"))
+
+		h := newHandler(c, &mocks.Executor{})
+
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseUndefined, mockNode, mockNodeStatus)
+		_, err := h.StartLaunchPlan(ctx, nCtx)
+		assert.Error(t, err)
+	})
+
+	t.Run("write-on-success", func(t *testing.T) {
+		c := &catalogMocks.Client{}
+		c.OnPutMatch(mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(m catalog.Metadata) bool {
+			return m.TaskExecutionIdentifier.TaskId == lpID
+		})).Return(catalog.NewStatus(core.CatalogCacheStatus_CACHE_POPULATED, nil), nil)
+
+		mockLPExec := &mocks.Executor{}
+		mockLPExec.OnGetStatusMatch(mock.Anything, mock.Anything).Return(&admin.ExecutionClosure{
+			Phase: core.WorkflowExecution_SUCCEEDED,
+			OutputResult: &admin.ExecutionClosure_Outputs{
+				Outputs: &admin.LiteralMapBlob{
+					Data: &admin.LiteralMapBlob_Values{
+						Values: coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap(),
+					},
+				},
+			},
+		}, nil)
+		h := newHandler(c, mockLPExec)
+
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, mockNodeStatus)
+		nCtx.OnDataStore().Return(createInmemoryStore(t))
+		s, err := h.CheckLaunchPlanStatus(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseSuccess, s.Info().GetPhase())
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_POPULATED, s.Info().GetInfo().TaskNodeInfo.TaskNodeMetadata.CacheStatus)
+		// The launched child execution is kept along with the cache status.
+		assert.NotNil(t, s.Info().GetInfo().WorkflowNodeInfo.LaunchedWorkflowID)
+		c.AssertNumberOfCalls(t, "Put", 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		h := launchPlanHandler{}
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseUndefined, mockNode, mockNodeStatus)
+		entry, err := h.CheckCatalogCache(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_DISABLED, entry.GetStatus().GetCacheStatus())
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_DISABLED, h.WriteCatalogCache(ctx, nCtx).GetCacheStatus())
+	})
+}
+
+func TestLaunchPlanInterface(t *testing.T) {
+	iface := launchPlanInterface(&admin.LaunchPlan{
+		Closure: &admin.LaunchPlanClosure{
+			ExpectedInputs: &core.ParameterMap{
+				Parameters: map[string]*core.Parameter{
+					"a": {Var: &core.Variable{Description: "a"}},
+				},
+			},
+		},
+	})
+	assert.Equal(t, "a", iface.Inputs.Variables["a"].Description)
+	assert.NotNil(t, iface.Outputs)
+	assert.Empty(t, iface.Outputs.Variables)
+}
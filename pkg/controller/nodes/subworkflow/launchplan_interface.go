@@ -0,0 +1,42 @@
+package subworkflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	compilerErrors "github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/validators"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan"
+)
+
+// validateLaunchPlanInterface checks that the launch plan still accepts the inputs of the node, since its interface may
+// have drifted since the parent workflow was compiled against it. The returned bool is false if it doesn't, in which
+// case the node fails with the returned transition, listing the inputs the workflow binds and the launch plan expects.
+func (l *launchPlanHandler) validateLaunchPlanInterface(ctx context.Context, nCtx handler.NodeExecutionContext,
+	nodeInputs *core.LiteralMap) (handler.Transition, bool, error) {
+	lpID := nCtx.Node().GetWorkflowNode().GetLaunchPlanRefID().Identifier
+	lp, err := l.launchPlanReader.GetLaunchPlan(ctx, lpID)
+	if err != nil {
+		if launchplan.IsNotFound(err) {
+			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER,
+				errors.LaunchPlanInterfaceDrift, fmt.Sprintf("Launch plan [%v] not found", lpID), nil)), false, nil
+		}
+
+		return handler.UnknownTransition, false, errors.Wrapf(errors.RuntimeExecutionError, nCtx.NodeID(), err,
+			"failed to retrieve launch plan [%v] interface", lpID)
+	}
+
+	errs := compilerErrors.NewCompileErrors()
+	if !validators.ValidateLaunchPlanInputs(nCtx.NodeID(), nodeInputs, lp.GetClosure().GetExpectedInputs(), errs) {
+		logger.Infof(ctx, "Interface of launch plan [%v] drifted from the inputs of node [%v]. Errors: %v", lpID, nCtx.NodeID(), errs)
+		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_USER,
+			errors.LaunchPlanInterfaceDrift, fmt.Sprintf("Launch plan [%v] no longer accepts the inputs of the node. %v", lpID, errs), nil)), false, nil
+	}
+
+	return handler.UnknownTransition, true, nil
+}
@@ -0,0 +1,81 @@
+package subworkflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	mocks2 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/subworkflow/launchplan/mocks"
+)
+
+func TestLaunchPlanHandler_ValidateLaunchPlanInterface(t *testing.T) {
+	ctx := context.TODO()
+	lpID := &core.Identifier{Project: "p", Domain: "d", Name: "n", Version: "v", ResourceType: core.ResourceType_LAUNCH_PLAN}
+	lp := &admin.LaunchPlan{
+		Id: lpID,
+		Closure: &admin.LaunchPlanClosure{
+			ExpectedInputs: &core.ParameterMap{
+				Parameters: map[string]*core.Parameter{
+					"x": {
+						Var:      &core.Variable{Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+						Behavior: &core.Parameter_Required{Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	mockWfNode := &mocks2.ExecutableWorkflowNode{}
+	mockWfNode.OnGetLaunchPlanRefID().Return(&v1alpha1.Identifier{Identifier: lpID})
+
+	mockNode := &mocks2.ExecutableNode{}
+	mockNode.OnGetID().Return("n1")
+	mockNode.OnGetWorkflowNode().Return(mockWfNode)
+
+	mockNodeStatus := &mocks2.ExecutableNodeStatus{}
+	mockNodeStatus.OnGetAttempts().Return(uint32(1))
+
+	lpReader := &mocks.Reader{}
+	lpReader.OnGetLaunchPlanMatch(mock.Anything, lpID).Return(lp, nil)
+	h := launchPlanHandler{
+		launchPlan:        &mocks.Executor{},
+		launchPlanReader:  lpReader,
+		validateInterface: true,
+		metrics:           newMetrics(promutils.NewTestScope()),
+	}
+
+	nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseUndefined, mockNode, mockNodeStatus)
+
+	t.Run("matching", func(t *testing.T) {
+		_, ok, err := h.validateLaunchPlanInterface(ctx, nCtx, coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		for _, inputs := range []map[string]interface{}{{}, {"x": "1"}, {"x": 1, "y": 2}} {
+			s, ok, err := h.validateLaunchPlanInterface(ctx, nCtx, coreutils.MustMakeLiteral(inputs).GetMap())
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, handler.EPhaseFailed, s.Info().GetPhase())
+			assert.Equal(t, errors.LaunchPlanInterfaceDrift, s.Info().GetErr().GetCode())
+		}
+	})
+
+	t.Run("start fails fast", func(t *testing.T) {
+		s, err := h.StartLaunchPlan(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseFailed, s.Info().GetPhase())
+		assert.Contains(t, s.Info().GetErr().GetMessage(), "[x]")
+	})
+}
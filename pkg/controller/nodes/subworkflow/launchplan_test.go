@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	mocks4 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	mocks3 "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
@@ -16,13 +18,18 @@ import (
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/clock"
 
+	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	mocks2 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	execMocks "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	recoveryMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/recovery/mocks"
@@ -147,6 +154,22 @@ func TestSubWorkflowHandler_StartLaunchPlan(t *testing.T) {
 		assert.Equal(t, handler.EPhaseUndefined, s.Info().GetPhase())
 	})
 
+	t.Run("throttled", func(t *testing.T) {
+
+		mockLPExec := &mocks.Executor{}
+
+		h := launchPlanHandler{
+			launchPlan: mockLPExec,
+		}
+		mockLPExec.OnLaunchMatch(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			errors.Errorf(launchplan.RemoteErrorThrottled, "throttled"))
+
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, mockNodeStatus)
+		s, err := h.StartLaunchPlan(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseQueued, s.Info().GetPhase())
+	})
+
 	t.Run("userError", func(t *testing.T) {
 
 		mockLPExec := &mocks.Executor{}
@@ -204,6 +227,11 @@ func TestSubWorkflowHandler_StartLaunchPlan(t *testing.T) {
 			},
 		}, nil)
 
+		// The child execution did not succeed, so it is re-launched in recovery mode
+		mockLPExec.OnGetRecoveredStatusMatch(mock.Anything, recoveredExecID).Return(&admin.ExecutionClosure{
+			Phase: core.WorkflowExecution_FAILED,
+		}, nil)
+
 		h := launchPlanHandler{
 			launchPlan:     mockLPExec,
 			recoveryClient: &recoveryClient,
@@ -270,6 +298,88 @@ func TestSubWorkflowHandler_StartLaunchPlan(t *testing.T) {
 		assert.Equal(t, s.Info().GetPhase(), handler.EPhaseRunning)
 		assert.Equal(t, len(recoveryClient.Calls), 1)
 	})
+	t.Run("reuse outputs of recovered child", func(t *testing.T) {
+		recoveredExecID := &core.WorkflowExecutionIdentifier{
+			Project: "p",
+			Domain:  "d",
+			Name:    "n",
+		}
+
+		mockLPExec := &mocks.Executor{}
+		mockLPExec.OnGetRecoveredStatusMatch(mock.Anything, recoveredExecID).Return(&admin.ExecutionClosure{
+			Phase: core.WorkflowExecution_SUCCEEDED,
+			OutputResult: &admin.ExecutionClosure_Outputs{
+				Outputs: &admin.LiteralMapBlob{
+					Data: &admin.LiteralMapBlob_Values{
+						Values: coreutils.MustMakeLiteral(map[string]interface{}{"x": 1}).GetMap(),
+					},
+				},
+			},
+		}, nil)
+
+		recoveryClient := recoveryMocks.RecoveryClient{}
+		recoveryClient.On("RecoverNodeExecution", mock.Anything, recoveredExecID, mock.Anything).Return(&admin.NodeExecution{
+			Closure: &admin.NodeExecutionClosure{
+				Phase: core.NodeExecution_SUCCEEDED,
+				TargetMetadata: &admin.NodeExecutionClosure_WorkflowNodeMetadata{
+					WorkflowNodeMetadata: &admin.WorkflowNodeMetadata{
+						ExecutionId: recoveredExecID,
+					},
+				},
+			},
+		}, nil)
+
+		h := launchPlanHandler{
+			launchPlan:     mockLPExec,
+			recoveryClient: &recoveryClient,
+		}
+
+		mockNodeStatus := &mocks2.ExecutableNodeStatus{}
+		mockNodeStatus.OnGetAttempts().Return(attempts)
+		mockNodeStatus.OnGetOutputDir().Return("output")
+
+		nCtx := &mocks3.NodeExecutionContext{}
+		ir := &mocks4.InputReader{}
+		ir.OnGetMatch(mock.Anything).Return(&core.LiteralMap{}, nil)
+		nCtx.OnInputReader().Return(ir)
+
+		nm := &mocks3.NodeExecutionMetadata{}
+		nm.OnGetNodeExecutionID().Return(&core.NodeExecutionIdentifier{
+			ExecutionId: wfExecID,
+			NodeId:      "n",
+		})
+		nCtx.OnNodeExecutionMetadata().Return(nm)
+
+		ectx := &execMocks.ExecutionContext{}
+		ectx.OnGetEventVersion().Return(1)
+		ectx.OnGetParentInfo().Return(nil)
+		ectx.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{
+			RecoveryExecution: v1alpha1.WorkflowExecutionIdentifier{
+				WorkflowExecutionIdentifier: recoveredExecID,
+			},
+		})
+		ectx.OnGetSecurityContext().Return(core.SecurityContext{})
+		ectx.OnGetRawOutputDataConfig().Return(v1alpha1.RawOutputDataConfig{})
+		ectx.OnGetLabels().Return(nil)
+		ectx.OnGetAnnotations().Return(nil)
+		nCtx.OnExecutionContext().Return(ectx)
+		nCtx.OnCurrentAttempt().Return(uint32(1))
+		nCtx.OnNode().Return(mockNode)
+		nCtx.OnNodeID().Return("n")
+		nCtx.OnNodeStatus().Return(mockNodeStatus)
+		dataStore := createInmemoryStore(t)
+		nCtx.OnDataStore().Return(dataStore)
+
+		s, err := h.StartLaunchPlan(ctx, nCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, handler.EPhaseRecovered, s.Info().GetPhase())
+		assert.Equal(t, recoveredExecID, s.Info().GetInfo().WorkflowNodeInfo.LaunchedWorkflowID)
+		mockLPExec.AssertNotCalled(t, "Launch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		actual := &core.LiteralMap{}
+		assert.NoError(t, dataStore.ReadProtobuf(ctx, v1alpha1.GetOutputsFile("output"), actual))
+		assert.Equal(t, int64(1), actual.Literals["x"].GetScalar().GetPrimitive().GetInteger())
+	})
 }
 
 func TestSubWorkflowHandler_CheckLaunchPlanStatus(t *testing.T) {
@@ -732,4 +842,142 @@ func TestLaunchPlanHandler_HandleAbort(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, err, expectedErr)
 	})
+
+	isChildID := mock.MatchedBy(func(o *core.WorkflowExecutionIdentifier) bool {
+		return o.Project == wfExecID.Project && o.Domain == wfExecID.Domain
+	})
+
+	t.Run("terminate-once", func(t *testing.T) {
+		mockLPExec := &mocks.Executor{}
+		mockLPExec.OnGetStatusMatch(mock.Anything, isChildID).Return(&admin.ExecutionClosure{Phase: core.WorkflowExecution_RUNNING}, nil).Once()
+		mockLPExec.OnGetStatusMatch(mock.Anything, isChildID).Return(&admin.ExecutionClosure{Phase: core.WorkflowExecution_ABORTING}, nil)
+		mockLPExec.OnKillMatch(mock.Anything, isChildID, mock.Anything).Return(nil)
+
+		h := launchPlanHandler{
+			launchPlan:             mockLPExec,
+			terminationGracePeriod: time.Minute,
+		}
+		nodeStatus := &v1alpha1.NodeStatus{}
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, nodeStatus)
+		for i := 0; i < 2; i++ {
+			abortCtx, terminations := executors.WithTerminationTracker(ctx)
+			assert.NoError(t, h.HandleAbort(abortCtx, nCtx, "reason"))
+			assert.True(t, terminations.IsPending())
+		}
+		mockLPExec.AssertNumberOfCalls(t, "Kill", 1)
+		assert.NotNil(t, nodeStatus.GetWorkflowNodeStatus().GetKilledAt())
+	})
+
+	t.Run("not-tracked", func(t *testing.T) {
+		mockLPExec := &mocks.Executor{}
+		mockLPExec.OnGetStatusMatch(ctx, isChildID).Return(&admin.ExecutionClosure{Phase: core.WorkflowExecution_RUNNING}, nil)
+		mockLPExec.OnKillMatch(ctx, isChildID, mock.Anything).Return(nil)
+
+		h := launchPlanHandler{
+			launchPlan:             mockLPExec,
+			terminationGracePeriod: time.Minute,
+		}
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, &v1alpha1.NodeStatus{})
+		assert.NoError(t, h.HandleAbort(ctx, nCtx, "reason"))
+		mockLPExec.AssertNumberOfCalls(t, "Kill", 1)
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		mockLPExec := &mocks.Executor{}
+		mockLPExec.OnGetStatusMatch(mock.Anything, isChildID).Return(&admin.ExecutionClosure{Phase: core.WorkflowExecution_ABORTED}, nil)
+		recorder := &eventMocks.NodeEventRecorder{}
+		recorder.OnRecordNodeEventMatch(mock.Anything, mock.MatchedBy(func(e *event.NodeExecutionEvent) bool {
+			return e.Phase == core.NodeExecution_ABORTED && e.GetWorkflowNodeMetadata().GetExecutionId().Project == wfExecID.Project &&
+				strings.Contains(e.GetError().GetMessage(), core.WorkflowExecution_ABORTED.String())
+		}), mock.Anything).Return(nil)
+
+		h := launchPlanHandler{
+			launchPlan:             mockLPExec,
+			terminationGracePeriod: time.Minute,
+			eventRecorder:          recorder,
+		}
+		killedAt := v1.Now()
+		nodeStatus := &v1alpha1.NodeStatus{WorkflowNodeStatus: &v1alpha1.WorkflowNodeStatus{KilledAt: &killedAt}}
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, nodeStatus)
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		assert.NoError(t, h.HandleAbort(abortCtx, nCtx, "reason"))
+		assert.False(t, terminations.IsPending())
+		mockLPExec.AssertNotCalled(t, "Kill", mock.Anything, mock.Anything, mock.Anything)
+		recorder.AssertNumberOfCalls(t, "RecordNodeEvent", 1)
+		assert.Nil(t, nodeStatus.GetWorkflowNodeStatus().GetKilledAt())
+	})
+
+	t.Run("grace-period-expired", func(t *testing.T) {
+		mockLPExec := &mocks.Executor{}
+		// The grace period is measured from the kill, regardless of whether admin reports when the child was updated.
+		mockLPExec.OnGetStatusMatch(mock.Anything, isChildID).Return(&admin.ExecutionClosure{Phase: core.WorkflowExecution_ABORTING}, nil)
+
+		h := launchPlanHandler{
+			launchPlan:             mockLPExec,
+			terminationGracePeriod: time.Minute,
+		}
+		killedAt := v1.Now()
+		nodeStatus := &v1alpha1.NodeStatus{WorkflowNodeStatus: &v1alpha1.WorkflowNodeStatus{KilledAt: &killedAt}}
+		nCtx := createNodeContext(v1alpha1.WorkflowNodePhaseExecuting, mockNode, nodeStatus)
+
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		assert.NoError(t, h.HandleAbort(abortCtx, nCtx, "reason"))
+		assert.True(t, terminations.IsPending())
+
+		fakeClock := clock.NewFakeClock(killedAt.Add(2 * time.Minute))
+		abortCtx, terminations = executors.WithTerminationTracker(clocks.WithClock(ctx, fakeClock))
+		assert.NoError(t, h.HandleAbort(abortCtx, nCtx, "reason"))
+		assert.False(t, terminations.IsPending())
+		mockLPExec.AssertNotCalled(t, "Kill", mock.Anything, mock.Anything, mock.Anything)
+		assert.Nil(t, nodeStatus.GetWorkflowNodeStatus().GetKilledAt())
+	})
+}
+
+func TestGetChildWorkflowExecutionID_ParentAttempts(t *testing.T) {
+	newNodeContext := func(parentInfo executors.ImmutableParentInfo) *mocks3.NodeExecutionContext {
+		nm := &mocks3.NodeExecutionMetadata{}
+		nm.OnGetNodeExecutionID().Return(&core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: "p",
+				Domain:  "d",
+				Name:    "n",
+			},
+			NodeId: "n1",
+		})
+
+		ectx := &execMocks.ExecutionContext{}
+		ectx.OnGetEventVersion().Return(v1alpha1.EventVersion0)
+		ectx.OnGetParentInfo().Return(parentInfo)
+
+		nCtx := &mocks3.NodeExecutionContext{}
+		nCtx.OnNodeExecutionMetadata().Return(nm)
+		nCtx.OnExecutionContext().Return(ectx)
+		nCtx.OnCurrentAttempt().Return(uint32(0))
+		return nCtx
+	}
+
+	t.Run("no parent", func(t *testing.T) {
+		nCtx := newNodeContext(nil)
+		childID, err := getChildWorkflowExecutionID(nCtx)
+		assert.NoError(t, err)
+
+		parentNodeExecID, err := getParentNodeExecutionID(nCtx)
+		assert.NoError(t, err)
+		expected, err := GetChildWorkflowExecutionID(parentNodeExecID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.String(), childID.String())
+	})
+
+	t.Run("parent retried", func(t *testing.T) {
+		first, err := getChildWorkflowExecutionID(newNodeContext(executors.NewParentInfo("sub", 0)))
+		assert.NoError(t, err)
+		second, err := getChildWorkflowExecutionID(newNodeContext(executors.NewParentInfo("sub", 1)))
+		assert.NoError(t, err)
+		assert.NotEqual(t, first.Name, second.Name)
+
+		// The parent node execution reported to admin is unchanged for EventVersion0.
+		parentNodeExecID, err := getParentNodeExecutionID(newNodeContext(executors.NewParentInfo("sub", 1)))
+		assert.NoError(t, err)
+		assert.Equal(t, "n1", parentNodeExecID.NodeId)
+	})
 }
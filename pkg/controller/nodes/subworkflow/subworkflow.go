@@ -22,6 +22,8 @@ import (
 type subworkflowHandler struct {
 	nodeExecutor executors.Node
 	eventConfig  *config.EventConfig
+	// Share of the remaining parallelism the nodes of the sub workflow may use.
+	parallelismWeight float64
 }
 
 // Helper method that extracts the SubWorkflow from the ExecutionContext
@@ -70,7 +72,7 @@ func (s *subworkflowHandler) handleSubWorkflow(ctx context.Context, nCtx handler
 	if err != nil {
 		return handler.UnknownTransition, err
 	}
-	execContext := executors.NewExecutionContextWithParentInfo(nCtx.ExecutionContext(), newParentInfo)
+	execContext := executors.NewExecutionContextWithParallelismBudget(nCtx.ExecutionContext(), newParentInfo, s.parallelismWeight)
 	state, err := s.nodeExecutor.RecursiveNodeHandler(ctx, execContext, subworkflow, nl, subworkflow.StartNode())
 	if err != nil {
 		return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoUndefined), err
@@ -101,21 +103,36 @@ func (s *subworkflowHandler) handleSubWorkflow(ctx context.Context, nCtx handler
 			}
 
 			sourcePath := v1alpha1.GetOutputsFile(endNodeStatus.GetOutputDir())
-			if metadata, err := store.Head(ctx, sourcePath); err == nil {
-				if !metadata.Exists() {
-					errMsg := fmt.Sprintf("Subworkflow is expected to produce outputs but no outputs file was written to %v.", sourcePath)
-					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.SubWorkflowExecutionFailed, errMsg, nil)), nil
-				}
-			} else {
+			metadata, err := store.Head(ctx, sourcePath)
+			if err != nil {
 				return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoUndefined), nil
 			}
 
-			// TODO optimization, we could just point the outputInfo to the path of the subworkflows output
 			destinationPath := v1alpha1.GetOutputsFile(nCtx.NodeStatus().GetOutputDir())
-			if err := store.CopyRaw(ctx, sourcePath, destinationPath, storage.Options{}); err != nil {
-				errMsg := fmt.Sprintf("Failed to copy subworkflow outputs from [%v] to [%v]", sourcePath, destinationPath)
-				return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.SubWorkflowExecutionFailed, errMsg, nil)), nil
+			if metadata.Exists() {
+				// TODO optimization, we could just point the outputInfo to the path of the subworkflows output
+				if err := store.CopyRaw(ctx, sourcePath, destinationPath, storage.Options{}); err != nil {
+					errMsg := fmt.Sprintf("Failed to copy subworkflow outputs from [%v] to [%v]", sourcePath, destinationPath)
+					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.SubWorkflowExecutionFailed, errMsg, nil)), nil
+				}
+			} else {
+				// Subworkflows whose outputs are all optional may not produce any.
+				var variables *core.VariableMap
+				if o := subworkflow.GetOutputs(); o != nil {
+					variables = o.VariableMap
+				}
+
+				outputs := &core.LiteralMap{}
+				if _, missingRequired := common.FillOptionalOutputs(outputs, variables); len(missingRequired) > 0 {
+					errMsg := fmt.Sprintf("Subworkflow is expected to produce outputs but no outputs file was written to %v.", sourcePath)
+					return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailure(core.ExecutionError_SYSTEM, errors.SubWorkflowExecutionFailed, errMsg, nil)), nil
+				}
+
+				if err := store.WriteProtobuf(ctx, destinationPath, storage.Options{}, outputs); err != nil {
+					return handler.UnknownTransition, err
+				}
 			}
+
 			oInfo = &handler.OutputInfo{OutputURI: destinationPath}
 		}
 
@@ -148,7 +165,8 @@ func (s *subworkflowHandler) HandleFailureNodeOfSubWorkflow(ctx context.Context,
 		if err != nil {
 			return handler.UnknownTransition, err
 		}
-		state, err := s.nodeExecutor.RecursiveNodeHandler(ctx, execContext, subworkflow, nl, subworkflow.GetOnFailureNode())
+		failureNodeLookup := executors.NewFailureNodeLookup(ctx, subworkflow, nl, originalError)
+		state, err := s.nodeExecutor.RecursiveNodeHandler(ctx, execContext, subworkflow, failureNodeLookup, subworkflow.GetOnFailureNode())
 		if err != nil {
 			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoUndefined), err
 		}
@@ -236,12 +254,14 @@ func (s *subworkflowHandler) HandleAbort(ctx context.Context, nCtx handler.NodeE
 	if err != nil {
 		return err
 	}
+	ctx = executors.WithAbortScope(ctx, subWorkflow.GetID())
 	return s.nodeExecutor.AbortHandler(ctx, execContext, subWorkflow, nodeLookup, subWorkflow.StartNode(), reason)
 }
 
-func newSubworkflowHandler(nodeExecutor executors.Node, eventConfig *config.EventConfig) subworkflowHandler {
+func newSubworkflowHandler(nodeExecutor executors.Node, eventConfig *config.EventConfig, parallelismWeight float64) subworkflowHandler {
 	return subworkflowHandler{
-		nodeExecutor: nodeExecutor,
-		eventConfig:  eventConfig,
+		nodeExecutor:      nodeExecutor,
+		eventConfig:       eventConfig,
+		parallelismWeight: parallelismWeight,
 	}
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 
 	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 )
 
 // Controller is a name-spaced collection of back-off handlers
@@ -21,7 +23,8 @@ type Controller struct {
 	backOffHandlerMap HandlerMap
 }
 
-func (m *Controller) GetOrCreateHandler(ctx context.Context, key string, backOffBaseSecond int, maxBackOffDuration time.Duration) *ComputeResourceAwareBackOffHandler {
+func (m *Controller) GetOrCreateHandler(ctx context.Context, key string, backOffBaseSecond int, maxBackOffDuration time.Duration,
+	jitterFactor float64) *ComputeResourceAwareBackOffHandler {
 	h, loaded := m.backOffHandlerMap.LoadOrStore(key, &ComputeResourceAwareBackOffHandler{
 		SimpleBackOffBlocker: &SimpleBackOffBlocker{
 			Clock:              m.Clock,
@@ -29,6 +32,7 @@ func (m *Controller) GetOrCreateHandler(ctx context.Context, key string, backOff
 			BackOffExponent:    stdAtomic.NewUint32(0),
 			NextEligibleTime:   NewAtomicTime(m.Clock.Now()),
 			MaxBackOffDuration: maxBackOffDuration,
+			JitterFactor:       jitterFactor,
 		}, ComputeResourceCeilings: &ComputeResourceCeilings{
 			computeResourceCeilings: NewSyncResourceList(),
 		},
@@ -58,7 +62,7 @@ func ComposeResourceKey(o client.Object) string {
 func NewController(ctx context.Context) *Controller {
 	logger.Infof(ctx, "Initializing the back-off controller.\n")
 	return &Controller{
-		Clock:             clock.RealClock{},
+		Clock:             clocks.FromContext(ctx),
 		backOffHandlerMap: HandlerMap{},
 	}
 }
@@ -15,6 +15,7 @@ import (
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 var (
@@ -26,6 +27,9 @@ type SimpleBackOffBlocker struct {
 	Clock              clock.Clock
 	BackOffBaseSecond  int
 	MaxBackOffDuration time.Duration
+	// Up to this fraction of the back-off duration is randomly added to it, so that the operations blocked by the same
+	// back-off are not all retried at once.
+	JitterFactor float64
 
 	// Mutable fields
 	BackOffExponent  stdAtomic.Uint32
@@ -58,6 +62,10 @@ func (b *SimpleBackOffBlocker) backOff(ctx context.Context) time.Duration {
 		b.BackOffExponent.Inc()
 	}
 
+	if b.JitterFactor > 0 {
+		backOffDuration = wait.Jitter(backOffDuration, b.JitterFactor)
+	}
+
 	b.NextEligibleTime.Store(b.Clock.Now().Add(backOffDuration))
 	return backOffDuration
 }
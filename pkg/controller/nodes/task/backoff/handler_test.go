@@ -492,6 +492,23 @@ func TestSimpleBackOffBlocker_backOff(t *testing.T) {
 			assert.Equal(t, uint32(10), b.BackOffExponent.Load())
 		}
 	})
+
+	t.Run("backoff with jitter", func(t *testing.T) {
+		b := &SimpleBackOffBlocker{
+			Clock:              tc,
+			BackOffBaseSecond:  2,
+			BackOffExponent:    stdAtomic.NewUint32(3),
+			NextEligibleTime:   NewAtomicTime(tc.Now()),
+			MaxBackOffDuration: maxBackOffDuration,
+			JitterFactor:       0.5,
+		}
+
+		backOffDuration := b.backOff(context.Background())
+		assert.True(t, backOffDuration >= 8*time.Second)
+		assert.True(t, backOffDuration <= 12*time.Second)
+		assert.Equal(t, uint32(4), b.BackOffExponent.Load())
+		assert.Equal(t, tc.Now().Add(backOffDuration), b.NextEligibleTime.Load())
+	})
 }
 
 func TestErrorTypes(t *testing.T) {
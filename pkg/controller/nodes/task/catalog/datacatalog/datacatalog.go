@@ -21,6 +21,8 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 )
 
 var (
@@ -379,7 +381,7 @@ func NewDataCatalog(ctx context.Context, endpoint string, insecureConnection boo
 
 	retryInterceptor := grpc.WithUnaryInterceptor(grpcRetry.UnaryClientInterceptor(grpcOptions...))
 
-	opts = append(opts, retryInterceptor)
+	opts = append(opts, retryInterceptor, grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()))
 	clientConn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return nil, err
@@ -26,21 +26,103 @@ var (
 			CacheTTL:  config.Duration{Duration: time.Minute * 30},
 		},
 		BackOffConfig: BackOffConfig{
-			BaseSecond:  2,
-			MaxDuration: config.Duration{Duration: time.Second * 20},
+			BaseSecond:   2,
+			MaxDuration:  config.Duration{Duration: time.Second * 20},
+			JitterFactor: 0.1,
 		},
 		MaxErrorMessageLength: 2048,
+		OutputSigning: OutputSigningConfig{
+			ExpiresIn: config.Duration{Duration: time.Hour},
+		},
+		PodTemplate: PodTemplateConfig{
+			Name: "flyte-template",
+		},
 	}
 
 	section = config.MustRegisterSection(SectionKey, defaultConfig)
 )
 
 type Config struct {
-	TaskPlugins            TaskPluginConfig `json:"task-plugins" pflag:",Task plugin configuration"`
-	MaxPluginPhaseVersions int32            `json:"max-plugin-phase-versions" pflag:",Maximum number of plugin phase versions allowed for one phase."`
-	BarrierConfig          BarrierConfig    `json:"barrier" pflag:",Config for Barrier implementation"`
-	BackOffConfig          BackOffConfig    `json:"backoff" pflag:",Config for Exponential BackOff implementation"`
-	MaxErrorMessageLength  int              `json:"maxLogMessageLength" pflag:",Max length of error message."`
+	TaskPlugins            TaskPluginConfig    `json:"task-plugins" pflag:",Task plugin configuration"`
+	MaxPluginPhaseVersions int32               `json:"max-plugin-phase-versions" pflag:",Maximum number of plugin phase versions allowed for one phase."`
+	BarrierConfig          BarrierConfig       `json:"barrier" pflag:",Config for Barrier implementation"`
+	BackOffConfig          BackOffConfig       `json:"backoff" pflag:",Config for Exponential BackOff implementation"`
+	MaxErrorMessageLength  int                 `json:"maxLogMessageLength" pflag:",Max length of error message."`
+	OutputSigning          OutputSigningConfig `json:"output-signing" pflag:",Config for signing the outputs of tasks"`
+	Abort                  AbortConfig         `json:"abort" pflag:",Config for aborting tasks"`
+	LogLinks               LogLinksConfig      `json:"log-links" pflag:",Config for the log links of k8s tasks"`
+	DetectDeck             bool                `json:"detect-deck" pflag:",Add the URI of the deck succeeded tasks render into their output prefix to their events."`
+	InjectSecrets          bool                `json:"inject-secrets" pflag:",Inject the secrets requested by tasks into their pods when creating them, so that the pod webhook does not need to run. Not supported for other k8s resources."`
+	SkipIfCached           bool                `json:"skip-if-cached" pflag:",Look up the outputs of cacheable tasks in the catalog before setting up their plugin, tasks that hit the cache then succeed without any plugin setup and task events."`
+	PodTemplate            PodTemplateConfig   `json:"pod-template" pflag:",Config for merging the PodTemplate of the namespace of tasks into their pods"`
+	MeasureDataTransfer    bool                `json:"measure-data-transfer" pflag:",Record the size of the inputs and outputs of succeeded tasks as metrics and in their events."`
+	ValidateInputs         bool                `json:"validate-inputs" pflag:",Validate the resolved inputs of tasks against their interface before launching them, tasks with inputs of the wrong type fail with a user error."`
+}
+
+// PodTemplateConfig configures merging a PodTemplate into the pods of tasks when creating them. The PodTemplate with the
+// configured name is looked up in the namespace of the task, and in the default namespace if there is none, so that
+// tolerations, the runtime class and image pull secrets can be set per namespace without changing the tasks. What the
+// task sets in its pod takes precedence over the PodTemplate.
+type PodTemplateConfig struct {
+	Enabled          bool   `json:"enabled" pflag:",Merge the PodTemplate of the namespace of tasks into their pods. Not supported for other k8s resources."`
+	Name             string `json:"name" pflag:",Name of the PodTemplate looked up in the namespace of tasks."`
+	DefaultNamespace string `json:"default-namespace" pflag:",Namespace of the PodTemplate merged into the pods of tasks in namespaces without one. Empty disables the fallback."`
+}
+
+// LogLinksConfig configures the links to the logs of the pods of k8s tasks that are added to their events as soon as the
+// pods are scheduled. Templates may reference the {{ .podName }}, {{ .namespace }}, {{ .containerName }},
+// {{ .containerId }}, {{ .attempt }} and {{ .hostName }} of the pod.
+type LogLinksConfig struct {
+	CloudwatchEnabled  bool   `json:"cloudwatch-enabled" pflag:",Add a link to the logs of the pod in Cloudwatch."`
+	CloudwatchRegion   string `json:"cloudwatch-region" pflag:",AWS region the Cloudwatch logs are stored in."`
+	CloudwatchLogGroup string `json:"cloudwatch-log-group" pflag:",Log group the logs of pods are written to in Cloudwatch."`
+
+	StackdriverEnabled         bool   `json:"stackdriver-enabled" pflag:",Add a link to the logs of the pod in Stackdriver."`
+	GCPProjectName             string `json:"gcp-project" pflag:",Name of the GCP project the Stackdriver logs are stored in."`
+	StackdriverLogResourceName string `json:"stackdriver-logresourcename" pflag:",Name of the log resource the logs of pods are written to in Stackdriver."`
+
+	KubernetesEnabled bool   `json:"kubernetes-enabled" pflag:",Add a link to the logs of the pod in the Kubernetes dashboard."`
+	KubernetesURL     string `json:"kubernetes-url" pflag:",URL of the Kubernetes dashboard."`
+
+	// Custom links, e.g. to a log aggregator of the cluster.
+	Templates []LogLinkTemplate `json:"templates" pflag:"-,"`
+}
+
+type LogLinkTemplate struct {
+	DisplayName string `json:"display-name"`
+	TemplateURI string `json:"template-uri"`
+}
+
+// AbortConfig configures how long the resources of aborted tasks are given to shut down gracefully (i.e. between SIGTERM
+// and SIGKILL for pods) once deleted, so that user code can flush its state.
+type AbortConfig struct {
+	DefaultGracePeriod config.Duration `json:"default-grace-period" pflag:",Grace period the resources of aborted tasks are deleted with. The grace period of the resource is used if 0."`
+	// Maps task types to the grace period their resources are deleted with, overriding the default one.
+	GracePeriods map[TaskType]config.Duration `json:"grace-periods" pflag:"-,"`
+}
+
+// GetGracePeriod returns the grace period the resources of aborted tasks of the type are deleted with, if one is
+// configured.
+func (a AbortConfig) GetGracePeriod(taskType TaskType) (time.Duration, bool) {
+	if gracePeriod, found := a.GracePeriods[taskType]; found {
+		return gracePeriod.Duration, true
+	}
+
+	if a.DefaultGracePeriod.Duration > 0 {
+		return a.DefaultGracePeriod.Duration, true
+	}
+
+	return 0, false
+}
+
+// OutputSigningConfig configures including signed URLs of the outputs and deck of succeeded tasks in their events, so
+// that users can download them without credentials for the bucket.
+type OutputSigningConfig struct {
+	Enabled   bool            `json:"enabled" pflag:",Include signed URLs of the outputs and deck of succeeded tasks in their events."`
+	ExpiresIn config.Duration `json:"expires-in" pflag:",Duration signed URLs remain valid for."`
+	// Signing URLs ahead of time requires credentials that are allowed to sign, and the URLs expire even if they are
+	// never used. A signing endpoint signs URLs on request instead.
+	SigningEndpoint string `json:"signing-endpoint" pflag:",If set, URLs of this endpoint with the uri query parameter set to the output are included instead of signed URLs."`
 }
 
 type BarrierConfig struct {
@@ -56,8 +138,9 @@ type TaskPluginConfig struct {
 }
 
 type BackOffConfig struct {
-	BaseSecond  int             `json:"base-second" pflag:",The number of seconds representing the base duration of the exponential backoff"`
-	MaxDuration config.Duration `json:"max-duration" pflag:",The cap of the backoff duration"`
+	BaseSecond   int             `json:"base-second" pflag:",The number of seconds representing the base duration of the exponential backoff"`
+	MaxDuration  config.Duration `json:"max-duration" pflag:",The cap of the backoff duration"`
+	JitterFactor float64         `json:"jitter-factor" pflag:",Up to this fraction of the backoff duration is randomly added to it, so that the launches of tasks backing off in the same namespace are spread out."`
 }
 
 type PluginID = string
@@ -57,6 +57,27 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "barrier.cache-ttl"), defaultConfig.BarrierConfig.CacheTTL.String(), " Max duration that a barrier would be respected if the process is not restarted. This should account for time required to store the record into persistent storage (across multiple rounds.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "backoff.base-second"), defaultConfig.BackOffConfig.BaseSecond, "The number of seconds representing the base duration of the exponential backoff")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "backoff.max-duration"), defaultConfig.BackOffConfig.MaxDuration.String(), "The cap of the backoff duration")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "backoff.jitter-factor"), defaultConfig.BackOffConfig.JitterFactor, "Up to this fraction of the backoff duration is randomly added to it, so that the launches of tasks backing off in the same namespace are spread out.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "maxLogMessageLength"), defaultConfig.MaxErrorMessageLength, "Max length of error message.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "output-signing.enabled"), defaultConfig.OutputSigning.Enabled, "Include signed URLs of the outputs and deck of succeeded tasks in their events.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "output-signing.expires-in"), defaultConfig.OutputSigning.ExpiresIn.String(), "Duration signed URLs remain valid for.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "output-signing.signing-endpoint"), defaultConfig.OutputSigning.SigningEndpoint, "If set, URLs of this endpoint with the uri query parameter set to the output are included instead of signed URLs.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "abort.default-grace-period"), defaultConfig.Abort.DefaultGracePeriod.String(), "Grace period the resources of aborted tasks are deleted with. The grace period of the resource is used if 0.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "log-links.cloudwatch-enabled"), defaultConfig.LogLinks.CloudwatchEnabled, "Add a link to the logs of the pod in Cloudwatch.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-links.cloudwatch-region"), defaultConfig.LogLinks.CloudwatchRegion, "AWS region the Cloudwatch logs are stored in.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-links.cloudwatch-log-group"), defaultConfig.LogLinks.CloudwatchLogGroup, "Log group the logs of pods are written to in Cloudwatch.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "log-links.stackdriver-enabled"), defaultConfig.LogLinks.StackdriverEnabled, "Add a link to the logs of the pod in Stackdriver.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-links.gcp-project"), defaultConfig.LogLinks.GCPProjectName, "Name of the GCP project the Stackdriver logs are stored in.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-links.stackdriver-logresourcename"), defaultConfig.LogLinks.StackdriverLogResourceName, "Name of the log resource the logs of pods are written to in Stackdriver.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "log-links.kubernetes-enabled"), defaultConfig.LogLinks.KubernetesEnabled, "Add a link to the logs of the pod in the Kubernetes dashboard.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-links.kubernetes-url"), defaultConfig.LogLinks.KubernetesURL, "URL of the Kubernetes dashboard.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "detect-deck"), defaultConfig.DetectDeck, "Add the URI of the deck succeeded tasks render into their output prefix to their events.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "inject-secrets"), defaultConfig.InjectSecrets, "Inject the secrets requested by tasks into their pods when creating them, so that the pod webhook does not need to run. Not supported for other k8s resources.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "skip-if-cached"), defaultConfig.SkipIfCached, "Look up the outputs of cacheable tasks in the catalog before setting up their plugin, tasks that hit the cache then succeed without any plugin setup and task events.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "pod-template.enabled"), defaultConfig.PodTemplate.Enabled, "Merge the PodTemplate of the namespace of tasks into their pods. Not supported for other k8s resources.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "pod-template.name"), defaultConfig.PodTemplate.Name, "Name of the PodTemplate looked up in the namespace of tasks.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "pod-template.default-namespace"), defaultConfig.PodTemplate.DefaultNamespace, "Namespace of the PodTemplate merged into the pods of tasks in namespaces without one. Empty disables the fallback.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "measure-data-transfer"), defaultConfig.MeasureDataTransfer, "Record the size of the inputs and outputs of succeeded tasks as metrics and in their events.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "validate-inputs"), defaultConfig.ValidateInputs, "Validate the resolved inputs of tasks against their interface before launching them, tasks with inputs of the wrong type fail with a user error.")
 	return cmdFlags
 }
@@ -197,6 +197,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_backoff.jitter-factor", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("backoff.jitter-factor", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("backoff.jitter-factor"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vFloat64), &actual.BackOffConfig.JitterFactor)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_maxLogMessageLength", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -211,4 +225,284 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_output-signing.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("output-signing.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("output-signing.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.OutputSigning.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_output-signing.expires-in", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.OutputSigning.ExpiresIn.String()
+
+			cmdFlags.Set("output-signing.expires-in", testValue)
+			if vString, err := cmdFlags.GetString("output-signing.expires-in"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.OutputSigning.ExpiresIn)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_output-signing.signing-endpoint", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("output-signing.signing-endpoint", testValue)
+			if vString, err := cmdFlags.GetString("output-signing.signing-endpoint"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.OutputSigning.SigningEndpoint)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_abort.default-grace-period", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Abort.DefaultGracePeriod.String()
+
+			cmdFlags.Set("abort.default-grace-period", testValue)
+			if vString, err := cmdFlags.GetString("abort.default-grace-period"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Abort.DefaultGracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.cloudwatch-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.cloudwatch-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("log-links.cloudwatch-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogLinks.CloudwatchEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.cloudwatch-region", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.cloudwatch-region", testValue)
+			if vString, err := cmdFlags.GetString("log-links.cloudwatch-region"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogLinks.CloudwatchRegion)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.cloudwatch-log-group", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.cloudwatch-log-group", testValue)
+			if vString, err := cmdFlags.GetString("log-links.cloudwatch-log-group"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogLinks.CloudwatchLogGroup)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.stackdriver-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.stackdriver-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("log-links.stackdriver-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogLinks.StackdriverEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.gcp-project", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.gcp-project", testValue)
+			if vString, err := cmdFlags.GetString("log-links.gcp-project"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogLinks.GCPProjectName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.stackdriver-logresourcename", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.stackdriver-logresourcename", testValue)
+			if vString, err := cmdFlags.GetString("log-links.stackdriver-logresourcename"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogLinks.StackdriverLogResourceName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.kubernetes-enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.kubernetes-enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("log-links.kubernetes-enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.LogLinks.KubernetesEnabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-links.kubernetes-url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-links.kubernetes-url", testValue)
+			if vString, err := cmdFlags.GetString("log-links.kubernetes-url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.LogLinks.KubernetesURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_detect-deck", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("detect-deck", testValue)
+			if vBool, err := cmdFlags.GetBool("detect-deck"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.DetectDeck)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_inject-secrets", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("inject-secrets", testValue)
+			if vBool, err := cmdFlags.GetBool("inject-secrets"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.InjectSecrets)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_skip-if-cached", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("skip-if-cached", testValue)
+			if vBool, err := cmdFlags.GetBool("skip-if-cached"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.SkipIfCached)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pod-template.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("pod-template.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("pod-template.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.PodTemplate.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pod-template.name", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("pod-template.name", testValue)
+			if vString, err := cmdFlags.GetString("pod-template.name"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.PodTemplate.Name)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pod-template.default-namespace", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("pod-template.default-namespace", testValue)
+			if vString, err := cmdFlags.GetString("pod-template.default-namespace"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.PodTemplate.DefaultNamespace)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_measure-data-transfer", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("measure-data-transfer", testValue)
+			if vBool, err := cmdFlags.GetBool("measure-data-transfer"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.MeasureDataTransfer)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_validate-inputs", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("validate-inputs", testValue)
+			if vBool, err := cmdFlags.GetBool("validate-inputs"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.ValidateInputs)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -0,0 +1,41 @@
+package task
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flytestdlib/storage"
+)
+
+// The key the data transferred by a task is sent under in the custom info of its events.
+const dataTransferCustomInfoKey = "dataTransfer"
+
+// DataTransfer is the size of the inputs a task read and the outputs it wrote, so that the stages of workflows that move
+// a lot of data can be identified.
+type DataTransfer struct {
+	InputBytes  int64 `json:"inputBytes"`
+	OutputBytes int64 `json:"outputBytes"`
+}
+
+// Measures the size of the inputs and outputs of the task. Inputs or outputs the task does not have count as no bytes.
+func measureDataTransfer(ctx context.Context, store *storage.DataStore, in io.InputFilePaths, ow io.OutputFilePaths) (*DataTransfer, error) {
+	transfer := &DataTransfer{}
+	for _, o := range []struct {
+		reference storage.DataReference
+		bytes     *int64
+	}{
+		{reference: in.GetInputPath(), bytes: &transfer.InputBytes},
+		{reference: ow.GetOutputPath(), bytes: &transfer.OutputBytes},
+	} {
+		metadata, err := store.Head(ctx, o.reference)
+		if err != nil {
+			return nil, err
+		}
+
+		if metadata.Exists() {
+			*o.bytes = metadata.Size()
+		}
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,33 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureDataTransfer(t *testing.T) {
+	ctx := context.TODO()
+	in := &mocks.InputFilePaths{}
+	in.On("GetInputPath").Return(storage.DataReference("s3://bucket/inputs.pb"))
+	ow := &mocks.OutputFilePaths{}
+	ow.On("GetOutputPath").Return(storage.DataReference("s3://bucket/prefix/outputs.pb"))
+
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	transfer, err := measureDataTransfer(ctx, store, in, ow)
+	assert.NoError(t, err)
+	assert.Equal(t, &DataTransfer{}, transfer)
+
+	assert.NoError(t, store.WriteRaw(ctx, "s3://bucket/inputs.pb", 3, storage.Options{}, bytes.NewReader([]byte("abc"))))
+	assert.NoError(t, store.WriteRaw(ctx, "s3://bucket/prefix/outputs.pb", 5, storage.Options{}, bytes.NewReader([]byte("abcde"))))
+	transfer, err = measureDataTransfer(ctx, store, in, ow)
+	assert.NoError(t, err)
+	assert.Equal(t, &DataTransfer{InputBytes: 3, OutputBytes: 5}, transfer)
+}
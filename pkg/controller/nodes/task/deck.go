@@ -0,0 +1,37 @@
+package task
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flytestdlib/storage"
+)
+
+// The key the URI of the deck of a task is sent under in the custom info of its events.
+const deckURICustomInfoKey = "deckUri"
+
+// The name of the deck flytekit renders into the output prefix of a task.
+const deckFileName = "deck.html"
+
+func getDeckPath(ctx context.Context, store *storage.DataStore, ow io.OutputFilePaths) (storage.DataReference, error) {
+	return store.ConstructReference(ctx, ow.GetOutputPrefixPath(), deckFileName)
+}
+
+// Returns the URI of the deck the task rendered into its output prefix, or an empty reference if it did not render one.
+func detectDeck(ctx context.Context, store *storage.DataStore, ow io.OutputFilePaths) (storage.DataReference, error) {
+	deckPath, err := getDeckPath(ctx, store, ow)
+	if err != nil {
+		return "", err
+	}
+
+	metadata, err := store.Head(ctx, deckPath)
+	if err != nil {
+		return "", err
+	}
+
+	if !metadata.Exists() {
+		return "", nil
+	}
+
+	return deckPath, nil
+}
@@ -0,0 +1,30 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDeck(t *testing.T) {
+	ctx := context.TODO()
+	ow := &mocks.OutputFilePaths{}
+	ow.On("GetOutputPrefixPath").Return(storage.DataReference("s3://bucket/prefix"))
+
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	deckURI, err := detectDeck(ctx, store, ow)
+	assert.NoError(t, err)
+	assert.Empty(t, deckURI)
+
+	assert.NoError(t, store.WriteRaw(ctx, "s3://bucket/prefix/deck.html", 0, storage.Options{}, bytes.NewReader(nil)))
+	deckURI, err = detectDeck(ctx, store, ow)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.DataReference("s3://bucket/prefix/deck.html"), deckURI)
+}
@@ -0,0 +1,76 @@
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/version"
+	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// The key the execution environment of a task is sent under in the custom info of its events.
+const executionEnvironmentCustomInfoKey = "executionEnvironment"
+
+// computeConfigHash returns the hex encoded sha256 checksum of the serialized configuration.
+func computeConfigHash(cfg interface{}) (string, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Returns the execution environment of the task, i.e. the environment the plugin observed in this round, or else the one
+// observed in an earlier round, completed with the plugin and the version and configuration of propeller.
+func (t Handler) executionEnvironment(p pluginCore.Plugin, observed, previous *v1alpha1.ExecutionEnvironment) *v1alpha1.ExecutionEnvironment {
+	var env *v1alpha1.ExecutionEnvironment
+	switch {
+	case observed != nil:
+		env = observed.DeepCopy()
+	case previous != nil:
+		return previous
+	default:
+		env = &v1alpha1.ExecutionEnvironment{}
+	}
+
+	env.PluginID = p.GetID()
+	env.PropellerVersion = version.Version
+	env.ConfigHash = t.configHash
+	return env
+}
+
+// Returns a copy of the custom info of a task event with the execution environment of the task added to it.
+func withExecutionEnvironment(customInfo *structpb.Struct, env *v1alpha1.ExecutionEnvironment) (*structpb.Struct, error) {
+	return withCustomInfoValue(customInfo, executionEnvironmentCustomInfoKey, env)
+}
+
+// Returns a copy of the custom info of a task event with the JSON representation of v added to it under the key.
+func withCustomInfoValue(customInfo *structpb.Struct, key string, v interface{}) (*structpb.Struct, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	value := &structpb.Value{}
+	if err := jsonpb.UnmarshalString(string(raw), value); err != nil {
+		return nil, err
+	}
+
+	out := &structpb.Struct{
+		Fields: make(map[string]*structpb.Value, len(customInfo.GetFields())+1),
+	}
+
+	for k, f := range customInfo.GetFields() {
+		out.Fields[k] = f
+	}
+
+	out.Fields[key] = value
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package task
+
+import (
+	"testing"
+
+	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flytestdlib/version"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestComputeConfigHash(t *testing.T) {
+	h1, err := computeConfigHash(map[string]int{"a": 1})
+	assert.NoError(t, err)
+	h2, err := computeConfigHash(map[string]int{"a": 1})
+	assert.NoError(t, err)
+	h3, err := computeConfigHash(map[string]int{"a": 2})
+	assert.NoError(t, err)
+
+	assert.Len(t, h1, 64)
+	assert.Equal(t, h1, h2)
+	assert.NotEqual(t, h1, h3)
+}
+
+func TestHandler_executionEnvironment(t *testing.T) {
+	p := &pluginCoreMocks.Plugin{}
+	p.OnGetID().Return("k8s-pod")
+	h := Handler{configHash: "hash"}
+
+	t.Run("observed", func(t *testing.T) {
+		observed := &v1alpha1.ExecutionEnvironment{Images: map[string]string{"c": "img@sha256:abc"}}
+		env := h.executionEnvironment(p, observed, &v1alpha1.ExecutionEnvironment{PluginID: "old"})
+		assert.Equal(t, map[string]string{"c": "img@sha256:abc"}, env.GetImages())
+		assert.Equal(t, "k8s-pod", env.GetPluginID())
+		assert.Equal(t, version.Version, env.GetPropellerVersion())
+		assert.Equal(t, "hash", env.GetConfigHash())
+		// The observed environment is not modified.
+		assert.Empty(t, observed.GetPluginID())
+	})
+
+	t.Run("previous", func(t *testing.T) {
+		previous := &v1alpha1.ExecutionEnvironment{PluginID: "old", ConfigHash: "old-hash"}
+		assert.Equal(t, previous, h.executionEnvironment(p, nil, previous))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		env := h.executionEnvironment(p, nil, nil)
+		assert.Empty(t, env.GetImages())
+		assert.Equal(t, "k8s-pod", env.GetPluginID())
+		assert.Equal(t, "hash", env.GetConfigHash())
+	})
+}
+
+func TestWithExecutionEnvironment(t *testing.T) {
+	env := &v1alpha1.ExecutionEnvironment{
+		Images:   map[string]string{"c": "img@sha256:abc"},
+		PluginID: "k8s-pod",
+	}
+
+	t.Run("nil custom info", func(t *testing.T) {
+		out, err := withExecutionEnvironment(nil, env)
+		assert.NoError(t, err)
+		v := out.GetFields()[executionEnvironmentCustomInfoKey].GetStructValue()
+		assert.Equal(t, "k8s-pod", v.GetFields()["pluginId"].GetStringValue())
+		assert.Equal(t, "img@sha256:abc", v.GetFields()["images"].GetStructValue().GetFields()["c"].GetStringValue())
+	})
+
+	t.Run("existing custom info", func(t *testing.T) {
+		customInfo := &structpb.Struct{Fields: map[string]*structpb.Value{
+			"foo": {Kind: &structpb.Value_StringValue{StringValue: "bar"}},
+		}}
+		out, err := withExecutionEnvironment(customInfo, env)
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", out.GetFields()["foo"].GetStringValue())
+		assert.NotNil(t, out.GetFields()[executionEnvironmentCustomInfoKey])
+		// The original custom info is not modified.
+		assert.Len(t, customInfo.GetFields(), 1)
+	})
+}
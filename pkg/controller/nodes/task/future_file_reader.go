@@ -54,31 +54,42 @@ func (f FutureFileReader) CacheExists(ctx context.Context) (bool, error) {
 	return f.RemoteFileWorkflowStore.Exists(ctx, f.flyteWfClosureCacheLoc)
 }
 
-func (f FutureFileReader) Cache(ctx context.Context, wf *v1alpha1.FlyteWorkflow, workflowClosure *core.CompiledWorkflowClosure) error {
-	err := f.RemoteFileWorkflowStore.PutFlyteWorkflowCRD(ctx, wf, f.flyteWfCRDCacheLoc)
+// Cache offloads the compiled dynamic workflow to blob storage and returns the checksum of the offloaded workflow CRD
+// and compiled workflow closure.
+func (f FutureFileReader) Cache(ctx context.Context, wf *v1alpha1.FlyteWorkflow, workflowClosure *core.CompiledWorkflowClosure) (string, error) {
+	crdChecksum, err := f.RemoteFileWorkflowStore.putFlyteWorkflowCRD(ctx, wf, f.flyteWfCRDCacheLoc)
 	if err != nil {
-		return err
+		return "", err
 	}
-	return f.RemoteFileWorkflowStore.PutCompiledFlyteWorkflow(ctx, workflowClosure, f.flyteWfClosureCacheLoc)
+
+	closureChecksum, err := f.RemoteFileWorkflowStore.putCompiledFlyteWorkflow(ctx, workflowClosure, f.flyteWfClosureCacheLoc)
+	if err != nil {
+		return "", err
+	}
+
+	return combineChecksums(crdChecksum, closureChecksum), nil
 }
 
 type CacheContents struct {
 	WorkflowCRD      *v1alpha1.FlyteWorkflow
 	CompiledWorkflow *core.CompiledWorkflowClosure
+	// Checksum of the offloaded workflow CRD and compiled workflow closure as they were read from blob storage.
+	Checksum string
 }
 
 func (f FutureFileReader) RetrieveCache(ctx context.Context) (CacheContents, error) {
-	workflowCRD, err := f.RemoteFileWorkflowStore.GetWorkflowCRD(ctx, f.flyteWfCRDCacheLoc)
+	workflowCRD, crdChecksum, err := f.RemoteFileWorkflowStore.getWorkflowCRD(ctx, f.flyteWfCRDCacheLoc)
 	if err != nil {
 		return CacheContents{}, err
 	}
-	compiledWorkflow, err := f.RemoteFileWorkflowStore.GetCompiledWorkflow(ctx, f.flyteWfClosureCacheLoc)
+	compiledWorkflow, closureChecksum, err := f.RemoteFileWorkflowStore.getCompiledWorkflow(ctx, f.flyteWfClosureCacheLoc)
 	if err != nil {
 		return CacheContents{}, err
 	}
 	return CacheContents{
 		WorkflowCRD:      workflowCRD,
 		CompiledWorkflow: compiledWorkflow,
+		Checksum:         combineChecksums(crdChecksum, closureChecksum),
 	}, nil
 }
 
@@ -0,0 +1,44 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestFutureFileReader_RetrieveCache(t *testing.T) {
+	ctx := context.TODO()
+	store := createInmemoryStore(t)
+	f, err := NewRemoteFutureFileReader(ctx, "s3://bucket/node", store)
+	assert.NoError(t, err)
+
+	closure := &core.CompiledWorkflowClosure{
+		Primary: &core.CompiledWorkflow{
+			Template: &core.WorkflowTemplate{
+				Id: &core.Identifier{ResourceType: core.ResourceType_WORKFLOW, Name: "name"},
+			},
+		},
+	}
+
+	checksum, err := f.Cache(ctx, &v1alpha1.FlyteWorkflow{ServiceAccountName: "sa"}, closure)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, checksum)
+
+	contents, err := f.RetrieveCache(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, contents.Checksum)
+	assert.Equal(t, "sa", contents.WorkflowCRD.ServiceAccountName)
+
+	t.Run("closure changed", func(t *testing.T) {
+		assert.NoError(t, store.WriteProtobuf(ctx, f.flyteWfClosureCacheLoc, storage.Options{}, &core.CompiledWorkflowClosure{}))
+
+		contents, err := f.RetrieveCache(ctx)
+		assert.NoError(t, err)
+		assert.NotEqual(t, checksum, contents.Checksum)
+	})
+}
@@ -22,6 +22,7 @@ import (
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 	pluginK8s "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
 	controllerConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -29,7 +30,9 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	regErrors "github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager"
 	rmConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager/config"
@@ -38,11 +41,16 @@ import (
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/k8s"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/secretmanager"
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 )
 
 const pluginContextKey = contextutils.Key("plugin")
 
+// The key how the resource of an aborted task was deleted is sent under in the custom info of its abort event.
+const abortCustomInfoKey = "abort"
+
 type metrics struct {
 	pluginPanics                   labeled.Counter
 	unsupportedTaskType            labeled.Counter
@@ -51,12 +59,15 @@ type metrics struct {
 	catalogPutSuccessCount         labeled.Counter
 	catalogMissCount               labeled.Counter
 	catalogHitCount                labeled.Counter
+	catalogSkipCount               labeled.Counter
 	pluginExecutionLatency         labeled.StopWatch
 	pluginQueueLatency             labeled.StopWatch
 	reservationGetSuccessCount     labeled.Counter
 	reservationGetFailureCount     labeled.Counter
 	reservationReleaseSuccessCount labeled.Counter
 	reservationReleaseFailureCount labeled.Counter
+	inputBytes                     labeled.Counter
+	outputBytes                    labeled.Counter
 
 	// TODO We should have a metric to capture custom state size
 	scope promutils.Scope
@@ -188,6 +199,8 @@ type Handler struct {
 	kubeClient      pluginCore.KubeClient
 	secretManager   pluginCore.SecretManager
 	resourceManager resourcemanager.BaseResourceManager
+	taskQuotas      []taskQuota
+	configHash      string
 	barrierCache    *barrier
 	cfg             *config.Config
 	pluginScope     promutils.Scope
@@ -292,6 +305,12 @@ func (t *Handler) Setup(ctx context.Context, sCtx handler.SetupContext) error {
 		}
 	}
 
+	t.taskQuotas, err = registerTaskQuotas(ctx, newResourceManagerBuilder, resourceManagerConfig.TaskQuotas)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to register task quotas")
+		return err
+	}
+
 	rm, err := newResourceManagerBuilder.BuildResourceManager(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to build a resource manager")
@@ -405,7 +424,7 @@ func (t Handler) invokePlugin(ctx context.Context, p pluginCore.Plugin, tCtx *ta
 	if ts.PluginPhase == pluginCore.PhaseQueued &&
 		(pluginTrns.pInfo.Phase() == pluginCore.PhaseInitializing || pluginTrns.pInfo.Phase() == pluginCore.PhaseRunning) {
 		if !ts.LastPhaseUpdatedAt.IsZero() {
-			t.metrics.pluginQueueLatency.Observe(ctx, ts.LastPhaseUpdatedAt, time.Now())
+			t.metrics.pluginQueueLatency.Observe(ctx, ts.LastPhaseUpdatedAt, clocks.Now(ctx))
 		}
 	}
 
@@ -486,8 +505,11 @@ func (t Handler) invokePlugin(ctx context.Context, p pluginCore.Plugin, tCtx *ta
 	return pluginTrns, nil
 }
 
-func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext) (handler.Transition, error) {
+func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext) (_ handler.Transition, err error) {
 	ttype := nCtx.TaskReader().GetTaskType()
+	ctx, span := tracing.StartSpan(ctx, "task.handle", attribute.String("flyte.task_type", ttype))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	ctx = contextutils.WithTaskType(ctx, ttype)
 	p, err := t.ResolvePlugin(ctx, ttype, nCtx.ExecutionContext().GetExecutionConfig())
 	if err != nil {
@@ -495,8 +517,36 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 	}
 
 	checkCatalog := !p.GetProperties().DisableNodeLevelCaching
+	readCatalog := checkCatalog
 	if !checkCatalog {
 		logger.Infof(ctx, "Node level caching is disabled. Skipping catalog read.")
+	} else if nCtx.ExecutionContext().GetExecutionConfig().OverwriteCache {
+		// The outputs of the task are still written to the catalog once it succeeds, overwriting the cached ones.
+		logger.Infof(ctx, "Execution overwrites the cache. Skipping catalog read.")
+		readCatalog = false
+	}
+
+	ts := nCtx.NodeStateReader().GetTaskNodeState()
+
+	// Fast path for tasks that hit the cache, which complete without ever invoking the plugin: the catalog is looked up
+	// before the task execution context is set up. A miss is not looked up again below.
+	var cacheEntry *catalog.Entry
+	if t.cfg.SkipIfCached && readCatalog && ts.PluginPhase == pluginCore.PhaseUndefined {
+		entry, outputPath, err := t.lookupCache(ctx, nCtx)
+		if err != nil {
+			logger.Errorf(ctx, "failed to check catalog cache with error")
+			return handler.UnknownTransition, err
+		}
+
+		if entry.GetStatus().GetCacheStatus() == core.CatalogCacheStatus_CACHE_HIT {
+			t.metrics.catalogSkipCount.Inc(ctx)
+			pluginTrns := &pluginRequestedTransition{}
+			pluginTrns.CacheHit(outputPath, entry)
+			return pluginTrns.FinalTransition(ctx)
+		}
+
+		readCatalog = false
+		cacheEntry = &entry
 	}
 
 	tCtx, err := t.newTaskExecutionContext(ctx, nCtx, p)
@@ -504,18 +554,50 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 		return handler.UnknownTransition, errors.Wrapf(errors.IllegalStateError, nCtx.NodeID(), err, "unable to create Handler execution context")
 	}
 
-	ts := nCtx.NodeStateReader().GetTaskNodeState()
+	// Fail fast, before the task is launched, if the plugin or propeller are older than the task requires, or if its
+	// inputs don't match its interface.
+	if ts.PluginPhase == pluginCore.PhaseUndefined {
+		tk, err := nCtx.TaskReader().Read(ctx)
+		if err != nil {
+			return handler.UnknownTransition, errors.Wrapf(errors.BadSpecificationError, nCtx.NodeID(), err, "unable to read task template")
+		}
+
+		execErr, err := validateVersionRequirements(ctx, tk, p)
+		if err != nil {
+			return handler.UnknownTransition, err
+		}
+
+		if execErr != nil {
+			logger.Errorf(ctx, "Task [%s] can't run on plugin [%s]: %s", tk.GetId(), p.GetID(), execErr.GetMessage())
+			return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailureErr(execErr, nil)), nil
+		}
+
+		if t.cfg.ValidateInputs {
+			execErr, err := validateInputs(ctx, nCtx, tk)
+			if err != nil {
+				return handler.UnknownTransition, err
+			}
+
+			if execErr != nil {
+				logger.Infof(ctx, "Inputs of task [%s] don't match its interface: %s", tk.GetId(), execErr.GetMessage())
+				return handler.DoTransition(handler.TransitionTypeEphemeral, handler.PhaseInfoFailureErr(execErr, nil)), nil
+			}
+		}
+	}
 
 	pluginTrns := &pluginRequestedTransition{}
 	// We will start with the assumption that catalog is disabled
 	pluginTrns.PopulateCacheInfo(catalog.NewFailedCatalogEntry(catalog.NewStatus(core.CatalogCacheStatus_CACHE_DISABLED, nil)))
+	if cacheEntry != nil {
+		pluginTrns.PopulateCacheInfo(*cacheEntry)
+	}
 
 	// NOTE: Ideally we should use a taskExecution state for this handler. But, doing that will make it completely backwards incompatible
 	// So now we will derive this from the plugin phase
 	// TODO @kumare re-evaluate this decision
 
 	// STEP 1: Check Cache
-	if (ts.PluginPhase == pluginCore.PhaseUndefined || ts.PluginPhase == pluginCore.PhaseWaitingForCache) && checkCatalog {
+	if (ts.PluginPhase == pluginCore.PhaseUndefined || ts.PluginPhase == pluginCore.PhaseWaitingForCache) && readCatalog {
 		// This is assumed to be first time. we will check catalog and call handle
 		entry, err := t.CheckCatalogCache(ctx, tCtx.tr, nCtx.InputReader(), tCtx.ow)
 		if err != nil {
@@ -523,22 +605,7 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 			return handler.UnknownTransition, err
 		}
 		if entry.GetStatus().GetCacheStatus() == core.CatalogCacheStatus_CACHE_HIT {
-			r := tCtx.ow.GetReader()
-			if r == nil {
-				return handler.UnknownTransition, errors.Errorf(errors.IllegalStateError, nCtx.NodeID(), "failed to reader outputs from a CacheHIT. Unexpected!")
-			}
-			// TODO @kumare this can be optimized, if we have paths then the reader could be pipelined to a sink
-			o, ee, err := r.Read(ctx)
-			if err != nil {
-				logger.Errorf(ctx, "failed to read from catalog, err: %s", err.Error())
-				return handler.UnknownTransition, err
-			}
-			if ee != nil {
-				logger.Errorf(ctx, "got execution error from catalog output reader? This should not happen, err: %s", ee.String())
-				return handler.UnknownTransition, errors.Errorf(errors.IllegalStateError, nCtx.NodeID(), "execution error from a cache output, bad state: %s", ee.String())
-			}
-			if err := nCtx.DataStore().WriteProtobuf(ctx, tCtx.ow.GetOutputPath(), storage.Options{}, o); err != nil {
-				logger.Errorf(ctx, "failed to write cached value to datastore, err: %s", err.Error())
+			if err := writeCachedOutputs(ctx, nCtx, tCtx.ow); err != nil {
 				return handler.UnknownTransition, err
 			}
 			pluginTrns.CacheHit(tCtx.ow.GetOutputPath(), entry)
@@ -580,10 +647,33 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 		}
 	}
 
+	invokePlugin := pluginTrns.execInfo.TaskNodeInfo == nil || (pluginTrns.pInfo.Phase() != pluginCore.PhaseWaitingForCache &&
+		pluginTrns.execInfo.TaskNodeInfo.TaskNodeMetadata.CacheStatus != core.CatalogCacheStatus_CACHE_HIT)
+
+	// Check the task quotas before the plugin is invoked for the first time, if one of them is exhausted the task waits
+	// for resources without invoking the plugin.
+	if invokePlugin && len(t.taskQuotas) > 0 && isWaitingForTaskQuota(ts.PluginPhase) {
+		exhausted, err := t.allocateTaskQuotas(ctx, tCtx, ttype)
+		if err != nil {
+			return handler.UnknownTransition, errors.Wrapf(errors.RuntimeExecutionError, nCtx.NodeID(), err, "failed to allocate task quota")
+		}
+
+		if len(exhausted) > 0 {
+			invokePlugin = false
+			pluginTrns.ttype = handler.TransitionTypeEphemeral
+			pluginTrns.pInfo = pluginCore.PhaseInfoWaitingForResourcesInfo(clocks.Now(ctx), pluginCore.DefaultPhaseVersion,
+				fmt.Sprintf("Exceeded task quota [%v]", exhausted), nil)
+
+			if ts.PluginPhase == pluginCore.PhaseWaitingForResources {
+				logger.Debugf(ctx, "No state change for Task, still waiting for task quota [%v]. Short circuiting.", exhausted)
+				return pluginTrns.FinalTransition(ctx)
+			}
+		}
+	}
+
 	barrierTick := uint32(0)
 	// STEP 2: If no cache-hit and not transitioning to PhaseWaitingForCache, then lets invoke the plugin and wait for a transition out of undefined
-	if pluginTrns.execInfo.TaskNodeInfo == nil || (pluginTrns.pInfo.Phase() != pluginCore.PhaseWaitingForCache &&
-		pluginTrns.execInfo.TaskNodeInfo.TaskNodeMetadata.CacheStatus != core.CatalogCacheStatus_CACHE_HIT) {
+	if invokePlugin {
 		prevBarrier := t.barrierCache.GetPreviousBarrierTransition(ctx, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
 		// Lets start with the current barrierTick (the value to be stored) same as the barrierTick in the cache
 		barrierTick = prevBarrier.BarrierClockTick
@@ -630,6 +720,8 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 		return handler.UnknownTransition, errors.Errorf(errors.IllegalStateError, nCtx.NodeID(), "plugin transition is not observed and no error as well.")
 	}
 
+	env := t.executionEnvironment(p, tCtx.env, ts.ExecutionEnvironment)
+
 	// STEP 4: Send buffered events!
 	logger.Debugf(ctx, "Sending buffered Task events.")
 	for _, ev := range tCtx.ber.GetAll(ctx) {
@@ -657,6 +749,30 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 	}
 
 	// STEP 5: Send Transition events
+	var signedOutputs *SignedOutputs
+	if t.cfg.OutputSigning.Enabled && pluginTrns.pInfo.Phase().IsSuccess() {
+		// Signed outputs are a convenience for users, failing to sign them does not fail the task.
+		if signedOutputs, err = signOutputs(ctx, nCtx.DataStore(), t.cfg.OutputSigning, tCtx.ow); err != nil {
+			logger.Warnf(ctx, "Failed to sign the outputs of the task. Error: %v", err)
+		}
+	}
+
+	var deckURI storage.DataReference
+	if t.cfg.DetectDeck && pluginTrns.pInfo.Phase().IsSuccess() {
+		// As with signed outputs, failing to detect the deck does not fail the task.
+		if deckURI, err = detectDeck(ctx, nCtx.DataStore(), tCtx.ow); err != nil {
+			logger.Warnf(ctx, "Failed to detect the deck of the task. Error: %v", err)
+		}
+	}
+
+	var dataTransfer *DataTransfer
+	if t.cfg.MeasureDataTransfer && pluginTrns.pInfo.Phase().IsSuccess() {
+		// Nor does failing to measure the data transferred by the task.
+		if dataTransfer, err = measureDataTransfer(ctx, nCtx.DataStore(), nCtx.InputReader(), tCtx.ow); err != nil {
+			logger.Warnf(ctx, "Failed to measure the data transferred by the task. Error: %v", err)
+		}
+	}
+
 	logger.Debugf(ctx, "Sending transition event for plugin phase [%s]", pluginTrns.pInfo.Phase().String())
 	evInfo, err := pluginTrns.FinalTaskEvent(ToTaskExecutionEventInputs{
 		TaskExecContext:       tCtx,
@@ -668,6 +784,10 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 		PluginID:              p.GetID(),
 		ResourcePoolInfo:      tCtx.rm.GetResourcePoolInfo(),
 		ClusterID:             t.clusterID,
+		ExecutionEnvironment:  env,
+		SignedOutputs:         signedOutputs,
+		DeckURI:               deckURI,
+		DataTransfer:          dataTransfer,
 	})
 	if err != nil {
 		logger.Errorf(ctx, "failed to convert plugin transition to TaskExecutionEvent. Error: %s", err.Error())
@@ -680,18 +800,25 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 			logger.Errorf(ctx, "failed to send event to Admin. error: %s", err.Error())
 			return handler.UnknownTransition, err
 		}
+
+		// Recorded once the event was, so that the transfer is not counted again if the event is retried.
+		if dataTransfer != nil {
+			t.metrics.inputBytes.Add(ctx, float64(dataTransfer.InputBytes))
+			t.metrics.outputBytes.Add(ctx, float64(dataTransfer.OutputBytes))
+		}
 	} else {
 		logger.Debugf(ctx, "Received no event to record.")
 	}
 
 	// STEP 6: Persist the plugin state
 	err = nCtx.NodeStateWriter().PutTaskNodeState(handler.TaskNodeState{
-		PluginState:        pluginTrns.pluginState,
-		PluginStateVersion: pluginTrns.pluginStateVersion,
-		PluginPhase:        pluginTrns.pInfo.Phase(),
-		PluginPhaseVersion: pluginTrns.pInfo.Version(),
-		BarrierClockTick:   barrierTick,
-		LastPhaseUpdatedAt: time.Now(),
+		PluginState:          pluginTrns.pluginState,
+		PluginStateVersion:   pluginTrns.pluginStateVersion,
+		PluginPhase:          pluginTrns.pInfo.Phase(),
+		PluginPhaseVersion:   pluginTrns.pInfo.Version(),
+		BarrierClockTick:     barrierTick,
+		LastPhaseUpdatedAt:   clocks.Now(ctx),
+		ExecutionEnvironment: env,
 	})
 	if err != nil {
 		logger.Errorf(ctx, "Failed to store TaskNode state, err :%s", err.Error())
@@ -701,6 +828,9 @@ func (t Handler) Handle(ctx context.Context, nCtx handler.NodeExecutionContext)
 	if !pluginTrns.pInfo.Phase().IsTerminal() {
 		eCtx := nCtx.ExecutionContext()
 		logger.Infof(ctx, "Parallelism now set to [%d].", eCtx.IncrementParallelism())
+	} else if err := t.releaseTaskQuotas(ctx, tCtx, ttype); err != nil {
+		logger.Errorf(ctx, "Failed to release task quotas, err :%s", err.Error())
+		return handler.UnknownTransition, err
 	}
 	return pluginTrns.FinalTransition(ctx)
 }
@@ -725,6 +855,11 @@ func (t Handler) Abort(ctx context.Context, nCtx handler.NodeExecutionContext, r
 		return errors.Wrapf(errors.IllegalStateError, nCtx.NodeID(), err, "unable to create Handler execution context")
 	}
 
+	abortInfo := &k8s.AbortInfo{Reason: reason}
+	if gracePeriod, ok := config.GetConfig().Abort.GetGracePeriod(ttype); ok {
+		abortInfo.GracePeriod = &gracePeriod
+	}
+
 	err = func() (err error) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -736,7 +871,7 @@ func (t Handler) Abort(ctx context.Context, nCtx handler.NodeExecutionContext, r
 		}()
 
 		childCtx := context.WithValue(ctx, pluginContextKey, p.GetID())
-		err = p.Abort(childCtx, tCtx)
+		err = p.Abort(k8s.WithAbortInfo(childCtx, abortInfo), tCtx)
 		return
 	}()
 
@@ -750,6 +885,22 @@ func (t Handler) Abort(ctx context.Context, nCtx handler.NodeExecutionContext, r
 	if err != nil {
 		return err
 	}
+
+	// Records how the resource of the task was deleted, if the plugin deleted one, e.g. to tell whether user code had a
+	// chance to flush its state.
+	var customInfo *structpb.Struct
+	if abortInfo.Deleted {
+		info := map[string]interface{}{"gracefulShutdown": abortInfo.Graceful}
+		if abortInfo.GracePeriod != nil {
+			info["gracePeriod"] = abortInfo.GracePeriod.String()
+		}
+
+		customInfo, err = withCustomInfoValue(nil, abortCustomInfoKey, info)
+		if err != nil {
+			logger.Warnf(ctx, "Failed to add the abort info to the task event. Error: %v", err)
+		}
+	}
+
 	if err := evRecorder.RecordTaskEvent(ctx, &event.TaskExecutionEvent{
 		TaskId:                taskExecID.TaskId,
 		ParentNodeExecutionId: nodeExecutionID,
@@ -761,6 +912,7 @@ func (t Handler) Abort(ctx context.Context, nCtx handler.NodeExecutionContext, r
 				Code:    "Task Aborted",
 				Message: reason,
 			}},
+		CustomInfo: customInfo,
 	}, t.eventConfig); err != nil && !eventsErr.IsEventIncompatibleClusterError(err) {
 		// If a prior workflow/node/task execution event has failed because of an invalid cluster error, don't stall the abort
 		// at this point in the clean-up.
@@ -800,6 +952,10 @@ func (t Handler) Finalize(ctx context.Context, nCtx handler.NodeExecutionContext
 			return errors.Wrapf(errors.CatalogCallFailed, nCtx.NodeID(), err, "failed to release reservation")
 		}
 
+		if err = t.releaseTaskQuotas(ctx, tCtx, ttype); err != nil {
+			return errors.Wrapf(errors.RuntimeExecutionError, nCtx.NodeID(), err, "failed to release task quotas")
+		}
+
 		childCtx := context.WithValue(ctx, pluginContextKey, p.GetID())
 		err = p.Finalize(childCtx, tCtx)
 		return
@@ -818,6 +974,11 @@ func New(ctx context.Context, kubeClient executors.Client, client catalog.Client
 	}
 
 	cfg := config.GetConfig()
+	configHash, err := computeConfigHash([]interface{}{controllerConfig.GetConfig(), cfg})
+	if err != nil {
+		return nil, err
+	}
+
 	return &Handler{
 		pluginRegistry: pluginMachinery.PluginRegistry(),
 		defaultPlugins: make(map[pluginCore.TaskType]pluginCore.Plugin),
@@ -827,6 +988,7 @@ func New(ctx context.Context, kubeClient executors.Client, client catalog.Client
 			pluginPanics:                   labeled.NewCounter("plugin_panic", "Task plugin paniced when trying to execute a Handler.", scope),
 			unsupportedTaskType:            labeled.NewCounter("unsupported_tasktype", "No Handler plugin configured for Handler type", scope),
 			catalogHitCount:                labeled.NewCounter("discovery_hit_count", "Task cached in Discovery", scope),
+			catalogSkipCount:               labeled.NewCounter("discovery_skip_count", "Task cached in Discovery and completed without setting up its plugin", scope),
 			catalogMissCount:               labeled.NewCounter("discovery_miss_count", "Task not cached in Discovery", scope),
 			catalogPutSuccessCount:         labeled.NewCounter("discovery_put_success_count", "Discovery Put success count", scope),
 			catalogPutFailureCount:         labeled.NewCounter("discovery_put_failure_count", "Discovery Put failure count", scope),
@@ -836,6 +998,8 @@ func New(ctx context.Context, kubeClient executors.Client, client catalog.Client
 			reservationGetFailureCount:     labeled.NewCounter("reservation_get_failure_count", "Reservation GetOrExtend failure count", scope),
 			reservationGetSuccessCount:     labeled.NewCounter("reservation_get_success_count", "Reservation GetOrExtend success count", scope),
 			reservationReleaseFailureCount: labeled.NewCounter("reservation_release_failure_count", "Reservation Release failure count", scope),
+			inputBytes:                     labeled.NewCounter("input_bytes", "Size of the inputs read by succeeded tasks", scope),
+			outputBytes:                    labeled.NewCounter("output_bytes", "Size of the outputs written by succeeded tasks", scope),
 			reservationReleaseSuccessCount: labeled.NewCounter("reservation_release_success_count", "Reservation Release success count", scope),
 			scope:                          scope,
 		},
@@ -849,5 +1013,6 @@ func New(ctx context.Context, kubeClient executors.Client, client catalog.Client
 		cfg:             cfg,
 		eventConfig:     eventConfig,
 		clusterID:       clusterID,
+		configHash:      configHash,
 	}, nil
 }
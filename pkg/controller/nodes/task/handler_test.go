@@ -380,6 +380,10 @@ func (t taskNodeStateHolder) PutDynamicNodeState(s handler.DynamicNodeState) err
 	panic("not implemented")
 }
 
+func (t taskNodeStateHolder) PutArrayNodeState(s handler.ArrayNodeState) error {
+	panic("not implemented")
+}
+
 func CreateNoopResourceManager(ctx context.Context, scope promutils.Scope) resourcemanager.BaseResourceManager {
 	rmBuilder, _ := resourcemanager.GetResourceManagerBuilderByType(ctx, rmConfig.TypeNoop, scope)
 	rm, _ := rmBuilder.BuildResourceManager(ctx)
@@ -412,6 +416,7 @@ func Test_task_Handle_NoCatalog(t *testing.T) {
 			Name: "name",
 		})
 		nm.OnIsInterruptible().Return(false)
+		nm.OnIsInterruptibleDemoted().Return(false)
 
 		tk := &core.TaskTemplate{
 			Id:   &core.Identifier{ResourceType: core.ResourceType_TASK, Project: "proj", Domain: "dom", Version: "ver"},
@@ -721,7 +726,7 @@ func Test_task_Handle_NoCatalog(t *testing.T) {
 
 func Test_task_Handle_Catalog(t *testing.T) {
 
-	createNodeContext := func(recorder events.TaskEventRecorder, ttype string, s *taskNodeStateHolder) *nodeMocks.NodeExecutionContext {
+	createNodeContext := func(recorder events.TaskEventRecorder, ttype string, s *taskNodeStateHolder, overwriteCache bool) *nodeMocks.NodeExecutionContext {
 		wfExecID := &core.WorkflowExecutionIdentifier{
 			Project: "project",
 			Domain:  "domain",
@@ -806,7 +811,7 @@ func Test_task_Handle_Catalog(t *testing.T) {
 		nCtx.OnEnqueueOwnerFunc().Return(nil)
 
 		executionContext := &mocks.ExecutionContext{}
-		executionContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{})
+		executionContext.OnGetExecutionConfig().Return(v1alpha1.ExecutionConfig{OverwriteCache: overwriteCache})
 		executionContext.OnGetEventVersion().Return(v1alpha1.EventVersion0)
 		executionContext.OnGetParentInfo().Return(nil)
 		nCtx.OnExecutionContext().Return(executionContext)
@@ -835,6 +840,8 @@ func Test_task_Handle_Catalog(t *testing.T) {
 		catalogFetch      bool
 		catalogFetchError bool
 		catalogWriteError bool
+		overwriteCache    bool
+		skipIfCached      bool
 	}
 	type want struct {
 		handlerPhase handler.EPhase
@@ -876,6 +883,17 @@ func Test_task_Handle_Catalog(t *testing.T) {
 				eventPhase:   core.TaskExecution_SUCCEEDED,
 			},
 		},
+		{
+			"cache-overwrite",
+			args{
+				catalogFetch:   true,
+				overwriteCache: true,
+			},
+			want{
+				handlerPhase: handler.EPhaseSuccess,
+				eventPhase:   core.TaskExecution_SUCCEEDED,
+			},
+		},
 		{
 			"cache-write-err",
 			args{
@@ -886,12 +904,32 @@ func Test_task_Handle_Catalog(t *testing.T) {
 				eventPhase:   core.TaskExecution_SUCCEEDED,
 			},
 		},
+		{
+			"skip-if-cached-hit",
+			args{
+				catalogFetch: true,
+				skipIfCached: true,
+			},
+			want{
+				handlerPhase: handler.EPhaseSuccess,
+			},
+		},
+		{
+			"skip-if-cached-miss",
+			args{
+				skipIfCached: true,
+			},
+			want{
+				handlerPhase: handler.EPhaseSuccess,
+				eventPhase:   core.TaskExecution_SUCCEEDED,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			state := &taskNodeStateHolder{}
 			ev := &fakeBufferedTaskEventRecorder{}
-			nCtx := createNodeContext(ev, "test", state)
+			nCtx := createNodeContext(ev, "test", state, tt.args.overwriteCache)
 			c := &pluginCatalogMocks.Client{}
 			if tt.args.catalogFetch {
 				or := &ioMocks.OutputReader{}
@@ -915,11 +953,30 @@ func Test_task_Handle_Catalog(t *testing.T) {
 			}
 			tk.catalog = c
 			tk.resourceManager = noopRm
+			cfg := *tk.cfg
+			cfg.SkipIfCached = tt.args.skipIfCached
+			tk.cfg = &cfg
 			got, err := tk.Handle(context.TODO(), nCtx)
 			if (err != nil) != tt.want.wantErr {
 				t.Errorf("Handler.Handle() error = %v, wantErr %v", err, tt.want.wantErr)
 				return
 			}
+			if err == nil && tt.args.skipIfCached {
+				// The catalog is looked up only once, cache hits succeed without invoking the plugin or sending events.
+				c.AssertNumberOfCalls(t, "Get", 1)
+				if tt.args.catalogFetch {
+					assert.Equal(t, tt.want.handlerPhase.String(), got.Info().GetPhase().String())
+					assert.Empty(t, ev.evs)
+					assert.Equal(t, pluginCore.PhaseUndefined.String(), state.s.PluginPhase.String())
+					assert.Equal(t, core.CatalogCacheStatus_CACHE_HIT, got.Info().GetInfo().TaskNodeInfo.TaskNodeMetadata.CacheStatus)
+					s := storage.DataReference("/output-dir/outputs.pb")
+					assert.Equal(t, s, got.Info().GetInfo().OutputInfo.OutputURI)
+					r, err := nCtx.DataStore().Head(context.TODO(), s)
+					assert.NoError(t, err)
+					assert.True(t, r.Exists())
+					return
+				}
+			}
 			if err == nil {
 				assert.Equal(t, tt.want.handlerPhase.String(), got.Info().GetPhase().String())
 				if assert.Equal(t, 1, len(ev.evs)) {
@@ -928,7 +985,10 @@ func Test_task_Handle_Catalog(t *testing.T) {
 				}
 				assert.Equal(t, pluginCore.PhaseSuccess.String(), state.s.PluginPhase.String())
 				assert.Equal(t, uint32(0), state.s.PluginPhaseVersion)
-				if tt.args.catalogFetch {
+				if tt.args.overwriteCache {
+					c.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+					c.AssertNumberOfCalls(t, "Put", 1)
+				} else if tt.args.catalogFetch {
 					if assert.NotNil(t, got.Info().GetInfo().TaskNodeInfo) {
 						assert.NotNil(t, got.Info().GetInfo().TaskNodeInfo.TaskNodeMetadata)
 						assert.Equal(t, core.CatalogCacheStatus_CACHE_HIT, got.Info().GetInfo().TaskNodeInfo.TaskNodeMetadata.CacheStatus)
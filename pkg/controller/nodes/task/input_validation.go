@@ -0,0 +1,34 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	compilerErrors "github.com/flyteorg/flytepropeller/pkg/compiler/errors"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/validators"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
+)
+
+// Validates the resolved inputs of the task against the inputs of its interface, so that tasks whose bindings resolved
+// to inputs of the wrong type fail before their plugin launches them. Returns an execution error that lists the
+// mismatching inputs if they don't match, nil otherwise.
+func validateInputs(ctx context.Context, nCtx handler.NodeExecutionContext, tk *core.TaskTemplate) (*core.ExecutionError, error) {
+	inputs, err := nCtx.InputReader().Get(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(errors.InputsNotFoundError, nCtx.NodeID(), err, "failed to read inputs")
+	}
+
+	errs := compilerErrors.NewCompileErrors()
+	if validators.ValidateInputs(nCtx.NodeID(), inputs, tk.GetInterface().GetInputs(), errs) {
+		return nil, nil
+	}
+
+	return &core.ExecutionError{
+		Code:    errors.MismatchingInputsError,
+		Message: fmt.Sprintf("inputs of the node don't match the interface of task [%v]. %v", tk.GetId(), errs),
+		Kind:    core.ExecutionError_USER,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	nodeMocks "github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler/mocks"
+)
+
+func TestValidateInputs(t *testing.T) {
+	ctx := context.TODO()
+	tk := &core.TaskTemplate{
+		Id: &core.Identifier{Name: "task"},
+		Interface: &core.TypedInterface{Inputs: &core.VariableMap{Variables: map[string]*core.Variable{
+			"x": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+		}}},
+	}
+
+	newNodeContext := func(inputs map[string]interface{}) *nodeMocks.NodeExecutionContext {
+		ir := &ioMocks.InputReader{}
+		ir.OnGetMatch(mock.Anything).Return(coreutils.MustMakeLiteral(inputs).GetMap(), nil)
+		nCtx := &nodeMocks.NodeExecutionContext{}
+		nCtx.OnInputReader().Return(ir)
+		nCtx.OnNodeID().Return("n0")
+		return nCtx
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		execErr, err := validateInputs(ctx, newNodeContext(map[string]interface{}{"x": 1}), tk)
+		assert.NoError(t, err)
+		assert.Nil(t, execErr)
+	})
+
+	t.Run("mismatching", func(t *testing.T) {
+		execErr, err := validateInputs(ctx, newNodeContext(map[string]interface{}{"x": "1"}), tk)
+		assert.NoError(t, err)
+		if assert.NotNil(t, execErr) {
+			assert.Equal(t, errors.MismatchingInputsError, execErr.Code)
+			assert.Equal(t, core.ExecutionError_USER, execErr.Kind)
+			assert.Contains(t, execErr.Message, "[x]")
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+)
+
+// TerminationReasonAnnotation is set on the resources of aborted tasks to the reason of the abort, so that user code can
+// read it (e.g. through the downward API) while it shuts down.
+const TerminationReasonAnnotation = "flyte.org/termination-reason"
+
+const abortInfoContextKey = contextutils.Key("abort_info")
+
+// AbortInfo describes the abort of a task to the PluginManager and reports back how its resource was deleted.
+type AbortInfo struct {
+	// Reason the task is aborted for.
+	Reason string
+	// GracePeriod the resource is deleted with. The grace period of the resource is used if nil.
+	GracePeriod *time.Duration
+	// Deleted is set if the resource was deleted by the PluginManager.
+	Deleted bool
+	// Graceful is set if the resource was deleted with its grace period, rather than immediately.
+	Graceful bool
+}
+
+// WithAbortInfo returns a context to call the Abort of a plugin with, that passes the abort info to the PluginManager.
+func WithAbortInfo(ctx context.Context, info *AbortInfo) context.Context {
+	return context.WithValue(ctx, abortInfoContextKey, info)
+}
+
+func getAbortInfo(ctx context.Context) *AbortInfo {
+	if info, ok := ctx.Value(abortInfoContextKey).(*AbortInfo); ok {
+		return info
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	pluginsk8sMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// Records the annotations and options resources are deleted with and fails graceful deletions if configured to.
+type deleteRecordingClient struct {
+	extendedFakeClient
+	gracefulDeleteError error
+	deleteOptions       []*client.DeleteOptions
+	annotations         []map[string]string
+}
+
+func (d *deleteRecordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	deleteOptions := &client.DeleteOptions{}
+	deleteOptions.ApplyOptions(opts)
+	d.deleteOptions = append(d.deleteOptions, deleteOptions)
+	d.annotations = append(d.annotations, obj.GetAnnotations())
+	if d.gracefulDeleteError != nil && (deleteOptions.GracePeriodSeconds == nil || *deleteOptions.GracePeriodSeconds > 0) {
+		return d.gracefulDeleteError
+	}
+
+	return d.extendedFakeClient.Delete(ctx, obj, opts...)
+}
+
+func TestPluginManager_Abort_WithAbortInfo(t *testing.T) {
+	ctx := context.TODO()
+	tm := getMockTaskExecutionMetadata()
+	newPod := func() *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: v12.ObjectMeta{
+				Name:      tm.GetTaskExecutionID().GetGeneratedName(),
+				Namespace: tm.GetNamespace(),
+			},
+		}
+	}
+
+	newPluginManager := func(t *testing.T, c client.Client) *PluginManager {
+		mockResourceHandler := &pluginsk8sMock.Plugin{}
+		mockResourceHandler.OnGetProperties().Return(k8s.PluginProperties{})
+		mockResourceHandler.OnBuildIdentityResourceMatch(mock.Anything, mock.Anything).Return(&v1.Pod{}, nil)
+		pluginManager, err := NewPluginManager(ctx, dummySetupContext(c), k8s.PluginEntry{
+			ID:              "x",
+			ResourceToWatch: &v1.Pod{},
+			Plugin:          mockResourceHandler,
+		}, NewResourceMonitorIndex())
+		assert.NoError(t, err)
+		return pluginManager
+	}
+
+	t.Run("graceful", func(t *testing.T) {
+		fc := &deleteRecordingClient{extendedFakeClient: extendedFakeClient{Client: fake.NewFakeClientWithScheme(scheme.Scheme, newPod())}}
+		pluginManager := newPluginManager(t, fc)
+
+		gracePeriod := 30 * time.Second
+		info := &AbortInfo{Reason: "aborted by user", GracePeriod: &gracePeriod}
+		err := pluginManager.Abort(WithAbortInfo(ctx, info), getMockTaskContext(PluginPhaseStarted, PluginPhaseStarted))
+		assert.NoError(t, err)
+		assert.True(t, info.Deleted)
+		assert.True(t, info.Graceful)
+		if assert.Len(t, fc.deleteOptions, 1) {
+			assert.Equal(t, int64(30), *fc.deleteOptions[0].GracePeriodSeconds)
+			assert.Equal(t, "aborted by user", fc.annotations[0][TerminationReasonAnnotation])
+		}
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		fc := &deleteRecordingClient{
+			extendedFakeClient:  extendedFakeClient{Client: fake.NewFakeClientWithScheme(scheme.Scheme, newPod())},
+			gracefulDeleteError: errors.New("graceful deletion failed"),
+		}
+		pluginManager := newPluginManager(t, fc)
+
+		info := &AbortInfo{Reason: "aborted by user"}
+		err := pluginManager.Abort(WithAbortInfo(ctx, info), getMockTaskContext(PluginPhaseStarted, PluginPhaseStarted))
+		assert.NoError(t, err)
+		assert.True(t, info.Deleted)
+		assert.False(t, info.Graceful)
+		if assert.Len(t, fc.deleteOptions, 2) {
+			assert.Nil(t, fc.deleteOptions[0].GracePeriodSeconds)
+			assert.Equal(t, int64(0), *fc.deleteOptions[1].GracePeriodSeconds)
+		}
+	})
+
+	t.Run("resource doesn't exist", func(t *testing.T) {
+		fc := &deleteRecordingClient{extendedFakeClient: extendedFakeClient{Client: fake.NewFakeClientWithScheme(scheme.Scheme)}}
+		pluginManager := newPluginManager(t, fc)
+
+		info := &AbortInfo{Reason: "aborted by user"}
+		err := pluginManager.Abort(WithAbortInfo(ctx, info), getMockTaskContext(PluginPhaseStarted, PluginPhaseStarted))
+		assert.NoError(t, err)
+		assert.False(t, info.Deleted)
+		assert.Len(t, fc.deleteOptions, 1)
+	})
+}
@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// ExecutionEnvironmentRecorder is implemented by task execution contexts that keep a snapshot of the environment the task
+// executes in.
+type ExecutionEnvironmentRecorder interface {
+	RecordExecutionEnvironment(env *v1alpha1.ExecutionEnvironment)
+}
+
+// Builds a snapshot of the environment of a pod, i.e. the digests of the images the kubelet pulled for its containers and
+// the resources of its containers. Returns nil if the images of the pod have not been pulled yet.
+func podExecutionEnvironment(pod *v1.Pod) *v1alpha1.ExecutionEnvironment {
+	images := make(map[string]string, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		if len(status.ImageID) > 0 {
+			images[status.Name] = status.ImageID
+		}
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+
+	resources := make(map[string]v1.ResourceRequirements, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		resources[container.Name] = *container.Resources.DeepCopy()
+	}
+
+	return &v1alpha1.ExecutionEnvironment{
+		Images:    images,
+		Resources: resources,
+	}
+}
+
+// Records the environment of the observed resource with the task execution context, if the resource is a pod and the
+// context records environments.
+func recordExecutionEnvironment(tCtx pluginsCore.TaskExecutionContext, o client.Object) {
+	recorder, ok := tCtx.(ExecutionEnvironmentRecorder)
+	if !ok {
+		return
+	}
+
+	pod, ok := o.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	if env := podExecutionEnvironment(pod); env != nil {
+		recorder.RecordExecutionEnvironment(env)
+	}
+}
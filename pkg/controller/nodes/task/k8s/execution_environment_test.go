@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"testing"
+
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+type recordingTaskExecutionContext struct {
+	*pluginsCoreMock.TaskExecutionContext
+	env *v1alpha1.ExecutionEnvironment
+}
+
+func (r *recordingTaskExecutionContext) RecordExecutionEnvironment(env *v1alpha1.ExecutionEnvironment) {
+	r.env = env
+}
+
+func newExecutionEnvironmentTestPod(imageID string) *v1.Pod {
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "primary",
+					Image: "image:latest",
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "primary", ImageID: imageID}},
+		},
+	}
+}
+
+func TestPodExecutionEnvironment(t *testing.T) {
+	t.Run("pulled", func(t *testing.T) {
+		env := podExecutionEnvironment(newExecutionEnvironmentTestPod("docker-pullable://image@sha256:abc"))
+		assert.Equal(t, map[string]string{"primary": "docker-pullable://image@sha256:abc"}, env.GetImages())
+		assert.Equal(t, resource.MustParse("1"), env.GetResources()["primary"].Limits[v1.ResourceCPU])
+	})
+
+	t.Run("not pulled", func(t *testing.T) {
+		assert.Nil(t, podExecutionEnvironment(newExecutionEnvironmentTestPod("")))
+	})
+}
+
+func TestRecordExecutionEnvironment(t *testing.T) {
+	t.Run("recorder", func(t *testing.T) {
+		tCtx := &recordingTaskExecutionContext{TaskExecutionContext: &pluginsCoreMock.TaskExecutionContext{}}
+		recordExecutionEnvironment(tCtx, newExecutionEnvironmentTestPod("image@sha256:abc"))
+		assert.Equal(t, "image@sha256:abc", tCtx.env.GetImages()["primary"])
+	})
+
+	t.Run("not a pod", func(t *testing.T) {
+		tCtx := &recordingTaskExecutionContext{TaskExecutionContext: &pluginsCoreMock.TaskExecutionContext{}}
+		recordExecutionEnvironment(tCtx, &v1.ConfigMap{})
+		assert.Nil(t, tCtx.env)
+	})
+
+	t.Run("no recorder", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			recordExecutionEnvironment(&pluginsCoreMock.TaskExecutionContext{}, newExecutionEnvironmentTestPod("image@sha256:abc"))
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nodeTaskConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+)
+
+var logLinkTemplateVar = regexp.MustCompile(`{{\s*\.(\w+)\s*}}`)
+
+// Renders a log link template, leaving variables it doesn't know as they are.
+func renderLogLinkTemplate(template string, vars map[string]string) string {
+	return logLinkTemplateVar.ReplaceAllStringFunc(template, func(match string) string {
+		if v, ok := vars[logLinkTemplateVar.FindStringSubmatch(match)[1]]; ok {
+			return v
+		}
+
+		return match
+	})
+}
+
+// Resolves the log links configured for a pod. Returns nil until the pod is scheduled, since the links may depend on
+// the host it's scheduled on.
+func getLogLinks(cfg nodeTaskConfig.LogLinksConfig, pod *v1.Pod, attempt uint32) []*core.TaskLog {
+	if len(pod.Spec.NodeName) == 0 || len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	containerName := pod.Spec.Containers[0].Name
+	containerID := ""
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			// The runtime is prefixed to the ID, e.g. docker://<id>.
+			if i := strings.Index(status.ContainerID, "://"); i >= 0 {
+				containerID = status.ContainerID[i+len("://"):]
+			} else {
+				containerID = status.ContainerID
+			}
+		}
+	}
+
+	vars := map[string]string{
+		"podName":       pod.Name,
+		"namespace":     pod.Namespace,
+		"containerName": containerName,
+		"containerId":   containerID,
+		"attempt":       strconv.FormatUint(uint64(attempt), 10),
+		"hostName":      pod.Spec.NodeName,
+	}
+
+	var logs []*core.TaskLog
+	if cfg.CloudwatchEnabled {
+		logs = append(logs, &core.TaskLog{
+			Name: "Cloudwatch Logs",
+			Uri: fmt.Sprintf("https://console.aws.amazon.com/cloudwatch/home?region=%s#logEventViewer:group=%s;stream=var.log.containers.%s_%s_%s-%s.log",
+				cfg.CloudwatchRegion, cfg.CloudwatchLogGroup, pod.Name, pod.Namespace, containerName, containerID),
+			MessageFormat: core.TaskLog_JSON,
+		})
+	}
+
+	if cfg.StackdriverEnabled {
+		logs = append(logs, &core.TaskLog{
+			Name: "Stackdriver Logs",
+			Uri: fmt.Sprintf("https://console.cloud.google.com/logs/viewer?project=%s&resource=%s&advancedFilter=resource.labels.pod_name%%3D%s",
+				cfg.GCPProjectName, cfg.StackdriverLogResourceName, pod.Name),
+			MessageFormat: core.TaskLog_JSON,
+		})
+	}
+
+	if cfg.KubernetesEnabled {
+		logs = append(logs, &core.TaskLog{
+			Name:          "Kubernetes Logs",
+			Uri:           fmt.Sprintf("%s/#!/log/%s/%s/pod?namespace=%s", strings.TrimSuffix(cfg.KubernetesURL, "/"), pod.Namespace, pod.Name, pod.Namespace),
+			MessageFormat: core.TaskLog_JSON,
+		})
+	}
+
+	for _, template := range cfg.Templates {
+		logs = append(logs, &core.TaskLog{
+			Name:          template.DisplayName,
+			Uri:           renderLogLinkTemplate(template.TemplateURI, vars),
+			MessageFormat: core.TaskLog_JSON,
+		})
+	}
+
+	return logs
+}
+
+// Adds the log links configured for the pod of a task to the info of its phase, skipping those the plugin already set.
+// Only phases with info are changed, plugins report the info of running pods.
+func addLogLinks(cfg nodeTaskConfig.LogLinksConfig, tCtx pluginsCore.TaskExecutionContext, o client.Object, p pluginsCore.PhaseInfo) {
+	pod, ok := o.(*v1.Pod)
+	if !ok || p.Info() == nil || p.Phase().IsTerminal() {
+		return
+	}
+
+	existing := make(map[string]bool, len(p.Info().Logs))
+	for _, l := range p.Info().Logs {
+		existing[l.GetName()] = true
+	}
+
+	attempt := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().RetryAttempt
+	for _, l := range getLogLinks(cfg, pod, attempt) {
+		if !existing[l.GetName()] {
+			p.Info().Logs = append(p.Info().Logs, l)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodeTaskConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+)
+
+func newLogLinksTestPod(nodeName string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Spec: v1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []v1.Container{{Name: "primary"}, {Name: "sidecar"}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "sidecar", ContainerID: "docker://other"},
+				{Name: "primary", ContainerID: "containerd://abc"},
+			},
+		},
+	}
+}
+
+func TestGetLogLinks(t *testing.T) {
+	cfg := nodeTaskConfig.LogLinksConfig{
+		CloudwatchEnabled:  true,
+		CloudwatchRegion:   "us-east-1",
+		CloudwatchLogGroup: "/kubernetes/flyte",
+		KubernetesEnabled:  true,
+		KubernetesURL:      "https://dashboard/",
+		Templates: []nodeTaskConfig.LogLinkTemplate{
+			{DisplayName: "Custom", TemplateURI: "https://logs/{{ .hostName }}/{{.podName}}/{{ .containerName }}/{{ .containerId }}/{{ .attempt }}/{{ .unknown }}"},
+		},
+	}
+
+	t.Run("not scheduled", func(t *testing.T) {
+		assert.Nil(t, getLogLinks(cfg, newLogLinksTestPod(""), 1))
+	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		logs := getLogLinks(cfg, newLogLinksTestPod("host-1"), 2)
+		if assert.Len(t, logs, 3) {
+			assert.Equal(t, "Cloudwatch Logs", logs[0].Name)
+			assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/kubernetes/flyte;stream=var.log.containers.pod_ns_primary-abc.log", logs[0].Uri)
+			assert.Equal(t, "Kubernetes Logs", logs[1].Name)
+			assert.Equal(t, "https://dashboard/#!/log/ns/pod/pod?namespace=ns", logs[1].Uri)
+			assert.Equal(t, "Custom", logs[2].Name)
+			assert.Equal(t, "https://logs/host-1/pod/primary/abc/2/{{ .unknown }}", logs[2].Uri)
+		}
+	})
+
+	t.Run("nothing configured", func(t *testing.T) {
+		assert.Empty(t, getLogLinks(nodeTaskConfig.LogLinksConfig{}, newLogLinksTestPod("host-1"), 0))
+	})
+}
+
+func TestAddLogLinks(t *testing.T) {
+	cfg := nodeTaskConfig.LogLinksConfig{
+		KubernetesEnabled: true,
+		KubernetesURL:     "https://dashboard",
+		Templates: []nodeTaskConfig.LogLinkTemplate{
+			{DisplayName: "Custom", TemplateURI: "https://logs/{{ .podName }}"},
+		},
+	}
+
+	tCtx := &pluginsCoreMock.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(getMockTaskExecutionMetadata())
+
+	t.Run("running", func(t *testing.T) {
+		p := pluginsCore.PhaseInfoRunning(0, &pluginsCore.TaskInfo{
+			Logs: []*core.TaskLog{{Name: "Kubernetes Logs", Uri: "https://plugin"}},
+		})
+		addLogLinks(cfg, tCtx, newLogLinksTestPod("host-1"), p)
+		if assert.Len(t, p.Info().Logs, 2) {
+			assert.Equal(t, "https://plugin", p.Info().Logs[0].Uri)
+			assert.Equal(t, "https://logs/pod", p.Info().Logs[1].Uri)
+		}
+	})
+
+	t.Run("terminal", func(t *testing.T) {
+		p := pluginsCore.PhaseInfoSuccess(&pluginsCore.TaskInfo{})
+		addLogLinks(cfg, tCtx, newLogLinksTestPod("host-1"), p)
+		assert.Empty(t, p.Info().Logs)
+	})
+
+	t.Run("not a pod", func(t *testing.T) {
+		p := pluginsCore.PhaseInfoRunning(0, &pluginsCore.TaskInfo{})
+		addLogLinks(cfg, tCtx, &v1.ConfigMap{}, p)
+		assert.Empty(t, p.Info().Logs)
+	})
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/flyteorg/flytepropeller/pkg/controller/maintenance"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/backoff"
 	v1 "k8s.io/api/core/v1"
 
@@ -30,6 +32,7 @@ import (
 
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/prometheus/client_golang/prometheus"
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
@@ -45,6 +48,8 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 
 	nodeTaskConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+	"github.com/flyteorg/flytepropeller/pkg/webhook"
+	webhookConfig "github.com/flyteorg/flytepropeller/pkg/webhook/config"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
@@ -70,6 +75,9 @@ type PluginMetrics struct {
 	GetCacheHit     labeled.StopWatch
 	GetAPILatency   labeled.StopWatch
 	ResourceDeleted labeled.Counter
+	// Per namespace
+	BackOffSaturated *prometheus.GaugeVec
+	BackOffBlocked   *prometheus.CounterVec
 }
 
 func newPluginMetrics(s promutils.Scope) PluginMetrics {
@@ -83,6 +91,10 @@ func newPluginMetrics(s promutils.Scope) PluginMetrics {
 			time.Millisecond, s),
 		ResourceDeleted: labeled.NewCounter("pods_deleted", "Counts how many times CheckTaskStatus is"+
 			" called with a deleted resource.", s),
+		BackOffSaturated: s.MustNewGaugeVec("backoff_saturated", "Whether the launches of resources in the namespace"+
+			" are backing off because its resources are exhausted.", "namespace"),
+		BackOffBlocked: s.MustNewCounterVec("backoff_blocked", "Counts the launches of resources in the namespace"+
+			" that were blocked or rejected while backing off.", "namespace"),
 	}
 }
 
@@ -98,6 +110,8 @@ type PluginManager struct {
 	resourceToWatch runtime.Object
 	kubeClient      pluginsCore.KubeClient
 	metrics         PluginMetrics
+	secretsMutator  webhook.Mutator
+	podTemplates    *podTemplateStore
 	// Per namespace-resource
 	backOffController    *backoff.Controller
 	resourceLevelMonitor *ResourceLevelMonitor
@@ -134,6 +148,15 @@ func (e *PluginManager) GetID() string {
 	return e.id
 }
 
+// GetVersion returns the version the k8s plugin advertises, if any.
+func (e *PluginManager) GetVersion() string {
+	if v, ok := e.plugin.(interface{ GetVersion() string }); ok {
+		return v.GetVersion()
+	}
+
+	return ""
+}
+
 func (e *PluginManager) getPodEffectiveResourceLimits(ctx context.Context, pod *v1.Pod) v1.ResourceList {
 	podRequestedResources := make(v1.ResourceList)
 	initContainersRequestedResources := make(v1.ResourceList)
@@ -206,6 +229,18 @@ func (e *PluginManager) LaunchResource(ctx context.Context, tCtx pluginsCore.Tas
 	}
 
 	e.AddObjectMetadata(k8sTaskCtxMetadata, o, config.GetK8sPluginConfig())
+	if pod, casted := o.(*v1.Pod); casted && e.podTemplates != nil {
+		if err := mergePodTemplate(ctx, e.podTemplates, pod); err != nil {
+			return pluginsCore.UnknownTransition, err
+		}
+	}
+
+	if pod, casted := o.(*v1.Pod); casted && e.secretsMutator != nil {
+		if err := injectSecrets(ctx, e.secretsMutator, pod); err != nil {
+			return pluginsCore.UnknownTransition, err
+		}
+	}
+
 	logger.Infof(ctx, "Creating Object: Type:[%v], Object:[%v/%v]", o.GetObjectKind().GroupVersionKind(), o.GetNamespace(), o.GetName())
 
 	key := backoff.ComposeResourceKey(o)
@@ -215,11 +250,20 @@ func (e *PluginManager) LaunchResource(ctx context.Context, tCtx pluginsCore.Tas
 		podRequestedResources := e.getPodEffectiveResourceLimits(ctx, pod)
 
 		cfg := nodeTaskConfig.GetConfig()
-		backOffHandler := e.backOffController.GetOrCreateHandler(ctx, key, cfg.BackOffConfig.BaseSecond, cfg.BackOffConfig.MaxDuration.Duration)
+		backOffHandler := e.backOffController.GetOrCreateHandler(ctx, key, cfg.BackOffConfig.BaseSecond,
+			cfg.BackOffConfig.MaxDuration.Duration, cfg.BackOffConfig.JitterFactor)
 
 		err = backOffHandler.Handle(ctx, func() error {
 			return e.kubeClient.GetClient().Create(ctx, o)
 		}, podRequestedResources)
+
+		// Records whether the namespace is saturated, i.e. whether launches in it are backing off.
+		if backoff.IsBackoffError(err) {
+			e.metrics.BackOffSaturated.WithLabelValues(o.GetNamespace()).Set(1)
+			e.metrics.BackOffBlocked.WithLabelValues(o.GetNamespace()).Inc()
+		} else if err == nil {
+			e.metrics.BackOffSaturated.WithLabelValues(o.GetNamespace()).Set(0)
+		}
 	} else {
 		err = e.kubeClient.GetClient().Create(ctx, o)
 	}
@@ -276,6 +320,8 @@ func (e *PluginManager) CheckResourcePhase(ctx context.Context, tCtx pluginsCore
 		e.metrics.ResourceDeleted.Inc(ctx)
 	}
 
+	recordExecutionEnvironment(tCtx, o)
+
 	pCtx := newPluginContext(tCtx)
 	p, err := e.plugin.GetTaskPhase(ctx, pCtx, o)
 	if err != nil {
@@ -283,6 +329,8 @@ func (e *PluginManager) CheckResourcePhase(ctx context.Context, tCtx pluginsCore
 		return pluginsCore.UnknownTransition, err
 	}
 
+	addLogLinks(nodeTaskConfig.GetConfig().LogLinks, tCtx, o, p)
+
 	if p.Phase() == pluginsCore.PhaseSuccess {
 		var opReader io.OutputReader
 		if pCtx.ow == nil {
@@ -321,6 +369,11 @@ func (e PluginManager) Handle(ctx context.Context, tCtx pluginsCore.TaskExecutio
 		return pluginsCore.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
 	}
 	if ps.Phase == PluginPhaseNotStarted {
+		// Running resources keep being tracked in maintenance mode, but no new ones are created
+		if maintenance.IsEnabled(ctx) {
+			return pluginsCore.DoTransition(pluginsCore.PhaseInfoWaitingForResourcesInfo(time.Now(), pluginsCore.DefaultPhaseVersion, maintenance.Message, nil)), nil
+		}
+
 		t, err := e.LaunchResource(ctx, tCtx)
 		if err == nil && t.Info().Phase() == pluginsCore.PhaseQueued {
 			if err := tCtx.PluginStateWriter().Put(pluginStateVersion, &PluginState{Phase: PluginPhaseStarted}); err != nil {
@@ -362,7 +415,7 @@ func (e PluginManager) Abort(ctx context.Context, tCtx pluginsCore.TaskExecution
 
 	if err != nil {
 	} else if deleteResource {
-		err = e.kubeClient.GetClient().Delete(ctx, resourceToFinalize)
+		err = e.deleteAbortedResource(ctx, resourceToFinalize)
 	} else {
 		if behavior.Patch != nil && behavior.Update == nil {
 			err = e.kubeClient.GetClient().Patch(ctx, resourceToFinalize, behavior.Patch.Patch, behavior.Patch.Options...)
@@ -386,6 +439,57 @@ func (e PluginManager) Abort(ctx context.Context, tCtx pluginsCore.TaskExecution
 	return nil
 }
 
+// Deletes the resource of an aborted task. If the abort info is passed through the context, the resource is first
+// annotated with the reason of the abort and then deleted with the configured grace period, so that user code can flush
+// its state. It is deleted immediately if that fails.
+func (e PluginManager) deleteAbortedResource(ctx context.Context, o client.Object) error {
+	info := getAbortInfo(ctx)
+	if info == nil {
+		return e.kubeClient.GetClient().Delete(ctx, o)
+	}
+
+	if len(info.Reason) > 0 {
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{TerminationReasonAnnotation: info.Reason},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// The annotation is best effort, it must not prevent the resource from being deleted.
+		err = e.kubeClient.GetClient().Patch(ctx, o, client.RawPatch(k8stypes.MergePatchType, patch))
+		if err != nil && !IsK8sObjectNotExists(err) {
+			logger.Warningf(ctx, "Failed to annotate Resource with name: %v/%v with the termination reason. Error: %v",
+				o.GetNamespace(), o.GetName(), err)
+		}
+	}
+
+	var opts []client.DeleteOption
+	if info.GracePeriod != nil {
+		opts = append(opts, client.GracePeriodSeconds(int64(info.GracePeriod.Seconds())))
+	}
+
+	err := e.kubeClient.GetClient().Delete(ctx, o, opts...)
+	if err == nil {
+		info.Deleted = true
+		info.Graceful = true
+		return nil
+	} else if IsK8sObjectNotExists(err) {
+		return err
+	}
+
+	logger.Warningf(ctx, "Failed to gracefully delete Resource with name: %v/%v. Will delete it immediately. Error: %v",
+		o.GetNamespace(), o.GetName(), err)
+	err = e.kubeClient.GetClient().Delete(ctx, o, client.GracePeriodSeconds(0))
+	if err == nil {
+		info.Deleted = true
+	}
+
+	return err
+}
+
 func (e *PluginManager) ClearFinalizers(ctx context.Context, o client.Object) error {
 	if len(o.GetFinalizers()) > 0 {
 		o.SetFinalizers([]string{})
@@ -595,12 +699,25 @@ func NewPluginManager(ctx context.Context, iCtx pluginsCore.SetupContext, entry
 	// Start the poller and gauge emitter
 	rm.RunCollectorOnce(ctx)
 
+	// Without the pod webhook, the secrets of tasks are injected into their pods before they are created.
+	var secretsMutator webhook.Mutator
+	if nodeTaskConfig.GetConfig().InjectSecrets {
+		secretsMutator = webhook.NewSecretsMutator(webhookConfig.GetConfig(), metricsScope.NewSubScope("secrets"))
+	}
+
+	var podTemplates *podTemplateStore
+	if cfg := nodeTaskConfig.GetConfig().PodTemplate; cfg.Enabled {
+		podTemplates = newPodTemplateStore(iCtx.KubeClient().GetCache(), cfg)
+	}
+
 	return &PluginManager{
 		id:                   entry.ID,
 		plugin:               entry.Plugin,
 		resourceToWatch:      entry.ResourceToWatch,
 		metrics:              newPluginMetrics(metricsScope),
 		kubeClient:           kubeClient,
+		secretsMutator:       secretsMutator,
+		podTemplates:         podTemplates,
 		resourceLevelMonitor: rm,
 	}, nil
 }
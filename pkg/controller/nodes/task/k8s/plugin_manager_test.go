@@ -27,6 +27,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	pluginsk8sMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/maintenance"
 )
 
 type extendedFakeClient struct {
@@ -371,6 +373,32 @@ func TestK8sTaskExecutor_Handle_LaunchResource(t *testing.T) {
 		assert.True(t, k8serrors.IsNotFound(err))
 	})
 
+	t.Run("maintenanceMode", func(t *testing.T) {
+		tctx := getMockTaskContext(PluginPhaseNotStarted, PluginPhaseNotStarted)
+		// common setup code
+		mockResourceHandler := &pluginsk8sMock.Plugin{}
+		mockResourceHandler.OnGetProperties().Return(k8s.PluginProperties{})
+		mockResourceHandler.OnBuildResourceMatch(mock.Anything, mock.Anything).Return(&v1.Pod{}, nil)
+		fakeClient := fake.NewClientBuilder().WithRuntimeObjects().Build()
+		pluginManager, err := NewPluginManager(ctx, dummySetupContext(fakeClient), k8s.PluginEntry{
+			ID:              "x",
+			ResourceToWatch: &v1.Pod{},
+			Plugin:          mockResourceHandler,
+		}, NewResourceMonitorIndex())
+		assert.NoError(t, err)
+
+		transition, err := pluginManager.Handle(maintenance.WithMaintenance(ctx, true), tctx)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseWaitingForResources, transition.Info().Phase())
+		assert.Equal(t, maintenance.Message, transition.Info().Reason())
+		mockResourceHandler.AssertNotCalled(t, "BuildResource", mock.Anything, mock.Anything)
+
+		createdPod := &v1.Pod{}
+		err = fakeClient.Get(ctx, k8stypes.NamespacedName{Namespace: tctx.TaskExecutionMetadata().GetNamespace(),
+			Name: tctx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()}, createdPod)
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
 	t.Run("jobForbidden", func(t *testing.T) {
 		// common setup code
 		tctx := getMockTaskContext(PluginPhaseNotStarted, PluginPhaseNotStarted)
@@ -456,6 +484,8 @@ func TestK8sTaskExecutor_Handle_LaunchResource(t *testing.T) {
 		podBackOffHandler, found := backOffController.GetBackOffHandler(refKey)
 		assert.True(t, found)
 		assert.Equal(t, uint32(1), podBackOffHandler.BackOffExponent.Load())
+		assert.Equal(t, float64(1), testutil.ToFloat64(pluginManager.metrics.BackOffSaturated.WithLabelValues("ns")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(pluginManager.metrics.BackOffBlocked.WithLabelValues("ns")))
 	})
 }
 
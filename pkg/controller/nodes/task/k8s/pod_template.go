@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nodeTaskConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+)
+
+// podTemplateStore looks up the PodTemplate merged into the pods of tasks. The reader is the cache of the kube client,
+// so the PodTemplates are watched rather than read from the kube api for every pod.
+type podTemplateStore struct {
+	reader           client.Reader
+	name             string
+	defaultNamespace string
+}
+
+// Gets the PodTemplate of the namespace, or the one of the default namespace if the namespace has none. Returns nil if
+// neither exists.
+func (s *podTemplateStore) get(ctx context.Context, namespace string) (*v1.PodTemplate, error) {
+	namespaces := []string{namespace}
+	if len(s.defaultNamespace) > 0 && s.defaultNamespace != namespace {
+		namespaces = append(namespaces, s.defaultNamespace)
+	}
+
+	for _, ns := range namespaces {
+		podTemplate := &v1.PodTemplate{}
+		err := s.reader.Get(ctx, k8stypes.NamespacedName{Namespace: ns, Name: s.name}, podTemplate)
+		if err == nil {
+			return podTemplate, nil
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// Merges the tolerations, runtime class and image pull secrets of the PodTemplate of the namespace of the pod into the
+// pod. The pod takes precedence, tolerations and image pull secrets of the PodTemplate are only added if the pod does not
+// have them yet and the runtime class is only set if the pod has none.
+func mergePodTemplate(ctx context.Context, store *podTemplateStore, pod *v1.Pod) error {
+	podTemplate, err := store.get(ctx, pod.Namespace)
+	if err != nil {
+		return errors.Wrapf(errors.RuntimeFailure, err, "failed to get PodTemplate [%v] for pod [%v/%v]", store.name,
+			pod.Namespace, pod.Name)
+	}
+
+	if podTemplate == nil {
+		return nil
+	}
+
+	templateSpec := podTemplate.Template.Spec
+	for _, toleration := range templateSpec.Tolerations {
+		if !hasToleration(pod.Spec.Tolerations, toleration) {
+			pod.Spec.Tolerations = append(pod.Spec.Tolerations, toleration)
+		}
+	}
+
+	if pod.Spec.RuntimeClassName == nil && templateSpec.RuntimeClassName != nil {
+		runtimeClassName := *templateSpec.RuntimeClassName
+		pod.Spec.RuntimeClassName = &runtimeClassName
+	}
+
+	for _, secret := range templateSpec.ImagePullSecrets {
+		if !hasImagePullSecret(pod.Spec.ImagePullSecrets, secret) {
+			pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, secret)
+		}
+	}
+
+	logger.Debugf(ctx, "Merged PodTemplate [%v/%v] into pod [%v/%v]", podTemplate.Namespace, podTemplate.Name,
+		pod.Namespace, pod.Name)
+	return nil
+}
+
+func hasToleration(tolerations []v1.Toleration, toleration v1.Toleration) bool {
+	for _, t := range tolerations {
+		if t.MatchToleration(&toleration) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasImagePullSecret(secrets []v1.LocalObjectReference, secret v1.LocalObjectReference) bool {
+	for _, s := range secrets {
+		if s.Name == secret.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newPodTemplateStore(reader client.Reader, cfg nodeTaskConfig.PodTemplateConfig) *podTemplateStore {
+	return &podTemplateStore{
+		reader:           reader,
+		name:             cfg.Name,
+		defaultNamespace: cfg.DefaultNamespace,
+	}
+}
@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nodeTaskConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+)
+
+func TestMergePodTemplate(t *testing.T) {
+	ctx := context.TODO()
+	gvisor := "gvisor"
+	kata := "kata"
+	newPodTemplate := func(namespace string, spec v1.PodSpec) *v1.PodTemplate {
+		return &v1.PodTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "flyte-template", Namespace: namespace},
+			Template:   v1.PodTemplateSpec{Spec: spec},
+		}
+	}
+
+	newPod := func(namespace string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: namespace},
+			Spec: v1.PodSpec{
+				Containers:       []v1.Container{{Name: "container"}},
+				Tolerations:      []v1.Toleration{{Key: "gpu", Operator: v1.TolerationOpExists}},
+				ImagePullSecrets: []v1.LocalObjectReference{{Name: "task-secret"}},
+			},
+		}
+	}
+
+	cfg := nodeTaskConfig.PodTemplateConfig{Name: "flyte-template", DefaultNamespace: "flyte"}
+	store := newPodTemplateStore(fake.NewClientBuilder().WithObjects(
+		newPodTemplate("ns", v1.PodSpec{
+			Tolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpExists},
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "ns", Effect: v1.TaintEffectNoSchedule},
+			},
+			RuntimeClassName: &gvisor,
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "task-secret"}, {Name: "ns-secret"}},
+		}),
+		newPodTemplate("flyte", v1.PodSpec{
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "default-secret"}},
+		}),
+	).Build(), cfg)
+
+	t.Run("namespace template", func(t *testing.T) {
+		pod := newPod("ns")
+		assert.NoError(t, mergePodTemplate(ctx, store, pod))
+		assert.Equal(t, []v1.Toleration{
+			{Key: "gpu", Operator: v1.TolerationOpExists},
+			{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "ns", Effect: v1.TaintEffectNoSchedule},
+		}, pod.Spec.Tolerations)
+		assert.Equal(t, &gvisor, pod.Spec.RuntimeClassName)
+		assert.Equal(t, []v1.LocalObjectReference{{Name: "task-secret"}, {Name: "ns-secret"}}, pod.Spec.ImagePullSecrets)
+	})
+
+	t.Run("pod takes precedence", func(t *testing.T) {
+		pod := newPod("ns")
+		pod.Spec.RuntimeClassName = &kata
+		assert.NoError(t, mergePodTemplate(ctx, store, pod))
+		assert.Equal(t, &kata, pod.Spec.RuntimeClassName)
+	})
+
+	t.Run("default template", func(t *testing.T) {
+		pod := newPod("other")
+		assert.NoError(t, mergePodTemplate(ctx, store, pod))
+		assert.Equal(t, []v1.LocalObjectReference{{Name: "task-secret"}, {Name: "default-secret"}}, pod.Spec.ImagePullSecrets)
+		assert.Nil(t, pod.Spec.RuntimeClassName)
+	})
+
+	t.Run("no template", func(t *testing.T) {
+		store := newPodTemplateStore(fake.NewClientBuilder().Build(), cfg)
+		pod := newPod("other")
+		assert.NoError(t, mergePodTemplate(ctx, store, pod))
+		assert.Equal(t, newPod("other"), pod)
+	})
+}
@@ -0,0 +1,33 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils/secrets"
+	"github.com/flyteorg/flytestdlib/logger"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook"
+)
+
+// Injects the secrets requested by the task into its pod, as the pod webhook would once the pod is created. The label
+// that selects the pod for the webhook is removed, so that secrets are not injected twice if the webhook still runs.
+func injectSecrets(ctx context.Context, mutator webhook.Mutator, pod *v1.Pod) error {
+	if pod.GetLabels()[secrets.PodLabel] != secrets.PodLabelValue {
+		return nil
+	}
+
+	newPod, injected, err := mutator.Mutate(ctx, pod)
+	if err != nil {
+		return errors.Wrapf(errors.RuntimeFailure, err, "failed to inject secrets into pod [%v/%v]", pod.Namespace, pod.Name)
+	}
+
+	if newPod != pod {
+		*pod = *newPod
+	}
+
+	logger.Debugf(ctx, "Injected secrets into pod [%v/%v]: [%v]", pod.Namespace, pod.Name, injected)
+	delete(pod.Labels, secrets.PodLabel)
+	return nil
+}
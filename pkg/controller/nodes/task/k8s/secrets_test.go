@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook"
+	webhookConfig "github.com/flyteorg/flytepropeller/pkg/webhook/config"
+	"github.com/flyteorg/flytepropeller/pkg/webhook/mocks"
+)
+
+func TestInjectSecrets(t *testing.T) {
+	ctx := context.TODO()
+	newPod := func(t *testing.T) *v1.Pod {
+		annotations, err := secrets.MarshalSecretsToMapStrings([]*core.Secret{
+			{Group: "group", Key: "key", MountRequirement: core.Secret_ENV_VAR},
+		})
+		assert.NoError(t, err)
+
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Namespace:   "ns",
+				Annotations: annotations,
+				Labels:      map[string]string{secrets.PodLabel: secrets.PodLabelValue, "other": "label"},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "container"}},
+			},
+		}
+	}
+
+	t.Run("injected", func(t *testing.T) {
+		mutator := webhook.NewSecretsMutator(&webhookConfig.Config{SecretManagerType: webhookConfig.SecretManagerTypeK8s}, nil)
+		pod := newPod(t)
+		assert.NoError(t, injectSecrets(ctx, mutator, pod))
+
+		env := map[string]string{}
+		for _, e := range pod.Spec.Containers[0].Env {
+			env[e.Name] = e.Name
+		}
+
+		assert.Contains(t, env, webhook.SecretEnvVarPrefix)
+		assert.NotContains(t, pod.Labels, secrets.PodLabel)
+		assert.Equal(t, "label", pod.Labels["other"])
+	})
+
+	t.Run("no secrets requested", func(t *testing.T) {
+		mutator := &mocks.Mutator{}
+		pod := newPod(t)
+		delete(pod.Labels, secrets.PodLabel)
+		assert.NoError(t, injectSecrets(ctx, mutator, pod))
+		mutator.AssertNotCalled(t, "Mutate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mutator := &mocks.Mutator{}
+		mutator.OnMutateMatch(mock.Anything, mock.Anything).Return(nil, false, fmt.Errorf("failed"))
+		pod := newPod(t)
+		assert.Error(t, injectSecrets(ctx, mutator, pod))
+		assert.Contains(t, pod.Labels, secrets.PodLabel)
+	})
+}
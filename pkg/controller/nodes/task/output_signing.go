@@ -0,0 +1,78 @@
+package task
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+)
+
+// The key the signed outputs of a task are sent under in the custom info of its events.
+const signedOutputsCustomInfoKey = "signedOutputs"
+
+// SignedOutputs are URLs users can download the outputs and the deck of a task from, without credentials for the bucket.
+type SignedOutputs struct {
+	Outputs string `json:"outputs,omitempty"`
+	Deck    string `json:"deck,omitempty"`
+	// ExpiresAt is not set for references to a signing endpoint, which sign on request.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+func signURL(ctx context.Context, store *storage.DataStore, cfg config.OutputSigningConfig, reference storage.DataReference) (string, error) {
+	if len(cfg.SigningEndpoint) > 0 {
+		return cfg.SigningEndpoint + "?uri=" + url.QueryEscape(reference.String()), nil
+	}
+
+	// The default scope of the properties requests a URL to download the reference.
+	resp, err := store.CreateSignedURL(ctx, reference, storage.SignedURLProperties{ExpiresIn: cfg.ExpiresIn.Duration})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.URL.String(), nil
+}
+
+// Signs the outputs and the deck of a task, skipping those the task did not write. Returns nil if there are none.
+func signOutputs(ctx context.Context, store *storage.DataStore, cfg config.OutputSigningConfig, ow io.OutputFilePaths) (*SignedOutputs, error) {
+	deckPath, err := getDeckPath(ctx, store, ow)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := &SignedOutputs{}
+	for _, o := range []struct {
+		reference storage.DataReference
+		url       *string
+	}{
+		{reference: ow.GetOutputPath(), url: &signed.Outputs},
+		{reference: deckPath, url: &signed.Deck},
+	} {
+		metadata, err := store.Head(ctx, o.reference)
+		if err != nil {
+			return nil, err
+		}
+
+		if !metadata.Exists() {
+			continue
+		}
+
+		if *o.url, err = signURL(ctx, store, cfg, o.reference); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(signed.Outputs) == 0 && len(signed.Deck) == 0 {
+		return nil, nil
+	}
+
+	if len(cfg.SigningEndpoint) == 0 {
+		expiresAt := time.Now().Add(cfg.ExpiresIn.Duration).UTC()
+		signed.ExpiresAt = &expiresAt
+	}
+
+	return signed, nil
+}
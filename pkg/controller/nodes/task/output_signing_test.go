@@ -0,0 +1,110 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	taskConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/config"
+)
+
+// signingStore signs URLs by appending the expiry to the reference.
+type signingStore struct {
+	storage.RawStore
+	err error
+}
+
+func (s signingStore) CreateSignedURL(ctx context.Context, reference storage.DataReference, properties storage.SignedURLProperties) (storage.SignedURLResponse, error) {
+	if s.err != nil {
+		return storage.SignedURLResponse{}, s.err
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s?expires=%s", reference, properties.ExpiresIn))
+	if err != nil {
+		return storage.SignedURLResponse{}, err
+	}
+
+	return storage.SignedURLResponse{URL: *u}, nil
+}
+
+func newSigningDataStore(t *testing.T, err error) *storage.DataStore {
+	mem, e := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, e)
+	return storage.NewCompositeDataStore(storage.URLPathConstructor{},
+		storage.NewDefaultProtobufStore(signingStore{RawStore: mem.ComposedProtobufStore, err: err}, promutils.NewTestScope()))
+}
+
+func TestSignOutputs(t *testing.T) {
+	ctx := context.TODO()
+	cfg := taskConfig.OutputSigningConfig{Enabled: true, ExpiresIn: config.Duration{Duration: time.Hour}}
+	ow := &mocks.OutputFilePaths{}
+	ow.On("GetOutputPrefixPath").Return(storage.DataReference("s3://bucket/prefix"))
+	ow.On("GetOutputPath").Return(storage.DataReference("s3://bucket/prefix/outputs.pb"))
+
+	write := func(t *testing.T, store *storage.DataStore, reference storage.DataReference) {
+		assert.NoError(t, store.WriteRaw(ctx, reference, 0, storage.Options{}, bytes.NewReader(nil)))
+	}
+
+	t.Run("nothing written", func(t *testing.T) {
+		signed, err := signOutputs(ctx, newSigningDataStore(t, nil), cfg, ow)
+		assert.NoError(t, err)
+		assert.Nil(t, signed)
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		store := newSigningDataStore(t, nil)
+		write(t, store, "s3://bucket/prefix/outputs.pb")
+		write(t, store, "s3://bucket/prefix/deck.html")
+		signed, err := signOutputs(ctx, store, cfg, ow)
+		assert.NoError(t, err)
+		assert.Equal(t, "s3://bucket/prefix/outputs.pb?expires=1h0m0s", signed.Outputs)
+		assert.Equal(t, "s3://bucket/prefix/deck.html?expires=1h0m0s", signed.Deck)
+		assert.NotNil(t, signed.ExpiresAt)
+	})
+
+	t.Run("without deck", func(t *testing.T) {
+		store := newSigningDataStore(t, nil)
+		write(t, store, "s3://bucket/prefix/outputs.pb")
+		signed, err := signOutputs(ctx, store, cfg, ow)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, signed.Outputs)
+		assert.Empty(t, signed.Deck)
+	})
+
+	t.Run("signing endpoint", func(t *testing.T) {
+		store := newSigningDataStore(t, fmt.Errorf("signing not allowed"))
+		write(t, store, "s3://bucket/prefix/outputs.pb")
+		endpointCfg := cfg
+		endpointCfg.SigningEndpoint = "https://flyte.example.com/api/v1/sign"
+		signed, err := signOutputs(ctx, store, endpointCfg, ow)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://flyte.example.com/api/v1/sign?uri=s3%3A%2F%2Fbucket%2Fprefix%2Foutputs.pb", signed.Outputs)
+		assert.Nil(t, signed.ExpiresAt)
+	})
+
+	t.Run("signing fails", func(t *testing.T) {
+		store := newSigningDataStore(t, fmt.Errorf("signing not allowed"))
+		write(t, store, "s3://bucket/prefix/outputs.pb")
+		_, err := signOutputs(ctx, store, cfg, ow)
+		assert.Error(t, err)
+	})
+}
+
+func TestWithSignedOutputs(t *testing.T) {
+	expiresAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	out, err := withCustomInfoValue(nil, signedOutputsCustomInfoKey, &SignedOutputs{Outputs: "https://outputs", ExpiresAt: &expiresAt})
+	assert.NoError(t, err)
+	v := out.GetFields()[signedOutputsCustomInfoKey].GetStructValue()
+	assert.Equal(t, "https://outputs", v.GetFields()["outputs"].GetStringValue())
+	assert.Equal(t, "2021-01-01T00:00:00Z", v.GetFields()["expiresAt"].GetStringValue())
+	assert.NotContains(t, v.GetFields(), "deck")
+}
@@ -0,0 +1,156 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/version"
+)
+
+const (
+	// The key of the config of a task template that declares the minimum version of the plugin the task needs.
+	MinPluginVersionConfigKey = "min-plugin-version"
+	// The key of the config of a task template that declares the minimum version of propeller the task needs.
+	MinPropellerVersionConfigKey = "min-propeller-version"
+)
+
+// The error code tasks fail with if the plugin or propeller are older than the task template requires.
+const unsupportedVersionErrorCode = "UnsupportedPluginVersion"
+
+const flytePluginsModule = "github.com/flyteorg/flyteplugins"
+
+// VersionedPlugin is implemented by plugins that advertise their version. Plugins that don't are assumed to be of the
+// version of the flyteplugins module propeller is built with.
+type VersionedPlugin interface {
+	GetVersion() string
+}
+
+// Returns the version of a module propeller is built with, or an empty string if it's unknown.
+func getModuleVersion(module string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == module {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+
+			return dep.Version
+		}
+	}
+
+	return ""
+}
+
+func getPluginVersion(p pluginCore.Plugin) string {
+	if v, ok := p.(VersionedPlugin); ok && len(v.GetVersion()) > 0 {
+		return v.GetVersion()
+	}
+
+	return getModuleVersion(flytePluginsModule)
+}
+
+// Parses the major, minor and patch numbers of a semantic version, ignoring a leading 'v' and any pre-release or build
+// suffix. Missing minor or patch numbers are treated as 0.
+func parseVersion(v string) ([3]int, error) {
+	var parsed [3]int
+	trimmed := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(trimmed) == 0 || len(parts) > len(parsed) {
+		return parsed, fmt.Errorf("invalid version [%s]", v)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("invalid version [%s]", v)
+		}
+
+		parsed[i] = n
+	}
+
+	return parsed, nil
+}
+
+// Returns whether the version is at least the minimum version. Both must be semantic versions.
+func isVersionAtLeast(v, minimum string) (bool, error) {
+	parsedV, err := parseVersion(v)
+	if err != nil {
+		return false, err
+	}
+
+	parsedMinimum, err := parseVersion(minimum)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range parsedV {
+		if parsedV[i] != parsedMinimum[i] {
+			return parsedV[i] > parsedMinimum[i], nil
+		}
+	}
+
+	return true, nil
+}
+
+// Validates the plugin and propeller are at least of the minimum versions the task template declares. Returns an
+// execution error that tells users what to upgrade if they are not, nil otherwise. Versions that are unknown, e.g. in
+// development builds, are not validated.
+func validateVersionRequirements(ctx context.Context, tk *core.TaskTemplate, p pluginCore.Plugin) (*core.ExecutionError, error) {
+	for _, requirement := range []struct {
+		configKey string
+		component string
+		version   string
+	}{
+		{configKey: MinPluginVersionConfigKey, component: fmt.Sprintf("plugin [%s]", p.GetID()), version: getPluginVersion(p)},
+		{configKey: MinPropellerVersionConfigKey, component: "propeller", version: version.Version},
+	} {
+		minimum, ok := tk.GetConfig()[requirement.configKey]
+		if !ok || len(minimum) == 0 {
+			continue
+		}
+
+		if _, err := parseVersion(minimum); err != nil {
+			return &core.ExecutionError{
+				Code:    unsupportedVersionErrorCode,
+				Message: fmt.Sprintf("task declares an invalid %s [%s]", requirement.configKey, minimum),
+				Kind:    core.ExecutionError_USER,
+			}, nil
+		}
+
+		if _, err := parseVersion(requirement.version); err != nil {
+			logger.Warnf(ctx, "Version of %s is unknown [%s], not validating the %s [%s] of the task",
+				requirement.component, requirement.version, requirement.configKey, minimum)
+			continue
+		}
+
+		atLeast, err := isVersionAtLeast(requirement.version, minimum)
+		if err != nil {
+			return nil, err
+		}
+
+		if !atLeast {
+			return &core.ExecutionError{
+				Code: unsupportedVersionErrorCode,
+				Message: fmt.Sprintf("task requires %s version [%s] or later but [%s] is deployed, upgrade %s to run it",
+					requirement.component, minimum, requirement.version, requirement.component),
+				Kind: core.ExecutionError_SYSTEM,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flytestdlib/version"
+	"github.com/stretchr/testify/assert"
+)
+
+type versionedPlugin struct {
+	pluginCoreMocks.Plugin
+	version string
+}
+
+func (v *versionedPlugin) GetVersion() string {
+	return v.version
+}
+
+func Test_isVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		v        string
+		minimum  string
+		expected bool
+	}{
+		{"v1.2.3", "v1.2.3", true},
+		{"1.2.3", "v1.2.2", true},
+		{"v1.10.0", "v1.9.9", true},
+		{"v1.2.3", "v1.3", false},
+		{"v2", "v1.99.99", true},
+		{"v0.10.24-rc1", "v0.10.24", true},
+		{"v0.10.23+build", "v0.10.24", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.v+">="+test.minimum, func(t *testing.T) {
+			atLeast, err := isVersionAtLeast(test.v, test.minimum)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, atLeast)
+		})
+	}
+
+	for _, invalid := range []string{"", "unknown", "v1.2.3.4", "v1.x"} {
+		_, err := isVersionAtLeast(invalid, "v1.0.0")
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_validateVersionRequirements(t *testing.T) {
+	ctx := context.TODO()
+	newPlugin := func(v string) *versionedPlugin {
+		p := &versionedPlugin{version: v}
+		p.OnGetID().Return("my-plugin")
+		return p
+	}
+
+	template := func(config map[string]string) *core.TaskTemplate {
+		return &core.TaskTemplate{Config: config}
+	}
+
+	t.Run("no requirements", func(t *testing.T) {
+		execErr, err := validateVersionRequirements(ctx, template(nil), newPlugin("v0.1.0"))
+		assert.NoError(t, err)
+		assert.Nil(t, execErr)
+	})
+
+	t.Run("plugin is recent enough", func(t *testing.T) {
+		execErr, err := validateVersionRequirements(ctx, template(map[string]string{MinPluginVersionConfigKey: "v0.10.0"}), newPlugin("v0.10.24"))
+		assert.NoError(t, err)
+		assert.Nil(t, execErr)
+	})
+
+	t.Run("plugin is too old", func(t *testing.T) {
+		execErr, err := validateVersionRequirements(ctx, template(map[string]string{MinPluginVersionConfigKey: "v0.11.0"}), newPlugin("v0.10.24"))
+		assert.NoError(t, err)
+		if assert.NotNil(t, execErr) {
+			assert.Equal(t, unsupportedVersionErrorCode, execErr.GetCode())
+			assert.Equal(t, core.ExecutionError_SYSTEM, execErr.GetKind())
+			assert.Contains(t, execErr.GetMessage(), "upgrade plugin [my-plugin]")
+		}
+	})
+
+	t.Run("invalid requirement", func(t *testing.T) {
+		execErr, err := validateVersionRequirements(ctx, template(map[string]string{MinPluginVersionConfigKey: "latest"}), newPlugin("v0.10.24"))
+		assert.NoError(t, err)
+		if assert.NotNil(t, execErr) {
+			assert.Equal(t, core.ExecutionError_USER, execErr.GetKind())
+		}
+	})
+
+	t.Run("unknown plugin version", func(t *testing.T) {
+		execErr, err := validateVersionRequirements(ctx, template(map[string]string{MinPluginVersionConfigKey: "v0.11.0"}), newPlugin("(devel)"))
+		assert.NoError(t, err)
+		assert.Nil(t, execErr)
+	})
+
+	t.Run("propeller is too old", func(t *testing.T) {
+		original := version.Version
+		version.Version = "v0.10.0"
+		defer func() { version.Version = original }()
+
+		execErr, err := validateVersionRequirements(ctx, template(map[string]string{MinPropellerVersionConfigKey: "v0.11.0"}), newPlugin("v0.10.24"))
+		assert.NoError(t, err)
+		if assert.NotNil(t, execErr) {
+			assert.Contains(t, execErr.GetMessage(), "upgrade propeller")
+		}
+	})
+}
@@ -11,12 +11,15 @@ import (
 	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
 	errors2 "github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
 )
 
 var cacheDisabled = catalog.NewStatus(core.CatalogCacheStatus_CACHE_DISABLED, nil)
@@ -72,6 +75,52 @@ func (t *Handler) CheckCatalogCache(ctx context.Context, tr pluginCore.TaskReade
 	return catalog.NewCatalogEntry(nil, cacheDisabled), nil
 }
 
+// lookupCache checks the catalog for the outputs of the task before its task execution context is set up, only the
+// output dir of the node is needed to copy them. On a cache hit, the outputs are copied to the output dir and the path of
+// the outputs file is returned alongside the entry.
+func (t *Handler) lookupCache(ctx context.Context, nCtx handler.NodeExecutionContext) (catalog.Entry, storage.DataReference, error) {
+	ow := ioutils.NewBufferedOutputWriter(ctx, ioutils.NewReadOnlyOutputFilePaths(ctx, nCtx.DataStore(), nCtx.NodeStatus().GetOutputDir()))
+	entry, err := t.CheckCatalogCache(ctx, nCtx.TaskReader(), nCtx.InputReader(), ow)
+	if err != nil {
+		return catalog.Entry{}, "", err
+	}
+
+	if entry.GetStatus().GetCacheStatus() != core.CatalogCacheStatus_CACHE_HIT {
+		logger.Infof(ctx, "No CacheHIT. Status [%s]", entry.GetStatus().GetCacheStatus().String())
+		return entry, "", nil
+	}
+
+	if err := writeCachedOutputs(ctx, nCtx, ow); err != nil {
+		return catalog.Entry{}, "", err
+	}
+
+	return entry, ow.GetOutputPath(), nil
+}
+
+// writeCachedOutputs persists the outputs a cache hit put into the output writer to the outputs file of the node.
+func writeCachedOutputs(ctx context.Context, nCtx handler.NodeExecutionContext, ow io.OutputWriter) error {
+	r := ow.GetReader()
+	if r == nil {
+		return errors2.Errorf(errors2.IllegalStateError, nCtx.NodeID(), "failed to reader outputs from a CacheHIT. Unexpected!")
+	}
+	// TODO @kumare this can be optimized, if we have paths then the reader could be pipelined to a sink
+	o, ee, err := r.Read(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "failed to read from catalog, err: %s", err.Error())
+		return err
+	}
+	if ee != nil {
+		logger.Errorf(ctx, "got execution error from catalog output reader? This should not happen, err: %s", ee.String())
+		return errors2.Errorf(errors2.IllegalStateError, nCtx.NodeID(), "execution error from a cache output, bad state: %s", ee.String())
+	}
+	if err := nCtx.DataStore().WriteProtobuf(ctx, ow.GetOutputPath(), storage.Options{}, o); err != nil {
+		logger.Errorf(ctx, "failed to write cached value to datastore, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
 // GetOrExtendCatalogReservation attempts to acquire an artifact reservation if the task is
 // cachable and cache serializable. If the reservation already exists for this owner, the
 // reservation is extended.
@@ -174,15 +223,33 @@ func (t *Handler) ValidateOutputAndCacheAdd(ctx context.Context, nodeID v1alpha1
 	}
 
 	if !ok {
-		// Does not exist
-		return cacheDisabled,
-			&io.ExecutionError{
-				ExecutionError: &core.ExecutionError{
-					Code:    "OutputsNotFound",
-					Message: "Outputs not generated by task execution",
-				},
-				IsRecoverable: true,
-			}, nil
+		// Tasks whose outputs are all optional may not write any.
+		outputs := &core.LiteralMap{}
+		if _, missingRequired := common.FillOptionalOutputs(outputs, iface.Outputs); len(missingRequired) > 0 {
+			// Does not exist
+			return cacheDisabled,
+				&io.ExecutionError{
+					ExecutionError: &core.ExecutionError{
+						Code:    "OutputsNotFound",
+						Message: "Outputs not generated by task execution",
+					},
+					IsRecoverable: true,
+				}, nil
+		}
+
+		logger.Infof(ctx, "Task wrote no outputs, setting its optional outputs to none")
+		r = ioutils.NewInMemoryOutputReader(outputs, nil)
+	} else if common.HasOptionalVariables(iface.Outputs) {
+		outputs, _, err := r.Read(ctx)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to read the outputs of the task. Error: %s", err.Error())
+			return cacheDisabled, nil, err
+		}
+
+		if filled, _ := common.FillOptionalOutputs(outputs, iface.Outputs); filled {
+			// The outputs are committed with the missing optional outputs set below.
+			r = ioutils.NewInMemoryOutputReader(outputs, nil)
+		}
 	}
 
 	if !r.IsFile(ctx) {
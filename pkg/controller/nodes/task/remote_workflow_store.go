@@ -3,9 +3,13 @@ package task
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"strings"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
 
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/storage"
@@ -28,17 +32,46 @@ func (r RemoteFileWorkflowStore) Exists(ctx context.Context, path storage.DataRe
 	return metadata.Exists(), nil
 }
 
+// computeChecksum returns the hex encoded sha256 checksum of a serialized workflow.
+func computeChecksum(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// combineChecksums returns the checksum of a workflow offloaded to several files from the checksums of the files.
+func combineChecksums(checksums ...string) string {
+	return computeChecksum([]byte(strings.Join(checksums, "")))
+}
+
 func (r RemoteFileWorkflowStore) PutFlyteWorkflowCRD(ctx context.Context, wf *v1alpha1.FlyteWorkflow, target storage.DataReference) error {
+	_, err := r.putFlyteWorkflowCRD(ctx, wf, target)
+	return err
+}
+
+// putFlyteWorkflowCRD writes the workflow CRD to the target location and returns the checksum of the written contents.
+func (r RemoteFileWorkflowStore) putFlyteWorkflowCRD(ctx context.Context, wf *v1alpha1.FlyteWorkflow, target storage.DataReference) (string, error) {
 	raw, err := json.Marshal(wf)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return r.store.WriteRaw(ctx, target, int64(len(raw)), storage.Options{}, bytes.NewReader(raw))
+	return computeChecksum(raw), r.store.WriteRaw(ctx, target, int64(len(raw)), storage.Options{}, bytes.NewReader(raw))
 }
 
 func (r RemoteFileWorkflowStore) PutCompiledFlyteWorkflow(ctx context.Context, workflow *core.CompiledWorkflowClosure, target storage.DataReference) error {
-	return r.store.WriteProtobuf(ctx, target, storage.Options{}, workflow)
+	_, err := r.putCompiledFlyteWorkflow(ctx, workflow, target)
+	return err
+}
+
+// putCompiledFlyteWorkflow writes the compiled workflow closure to the target location and returns the checksum of the
+// written contents.
+func (r RemoteFileWorkflowStore) putCompiledFlyteWorkflow(ctx context.Context, workflow *core.CompiledWorkflowClosure, target storage.DataReference) (string, error) {
+	raw, err := proto.Marshal(workflow)
+	if err != nil {
+		return "", err
+	}
+
+	return computeChecksum(raw), r.store.WriteRaw(ctx, target, int64(len(raw)), storage.Options{}, bytes.NewReader(raw))
 }
 
 func (r RemoteFileWorkflowStore) getRawBytes(ctx context.Context, source storage.DataReference) ([]byte, error) {
@@ -62,13 +95,20 @@ func (r RemoteFileWorkflowStore) getRawBytes(ctx context.Context, source storage
 }
 
 func (r RemoteFileWorkflowStore) GetWorkflowCRD(ctx context.Context, source storage.DataReference) (*v1alpha1.FlyteWorkflow, error) {
+	wf, _, err := r.getWorkflowCRD(ctx, source)
+	return wf, err
+}
+
+// getWorkflowCRD reads the workflow CRD from the source location and returns it along with the checksum of the read
+// contents.
+func (r RemoteFileWorkflowStore) getWorkflowCRD(ctx context.Context, source storage.DataReference) (*v1alpha1.FlyteWorkflow, string, error) {
 	wfBytes, err := r.getRawBytes(ctx, source)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	wf := &v1alpha1.FlyteWorkflow{}
-	return wf, json.Unmarshal(wfBytes, wf)
+	return wf, computeChecksum(wfBytes), json.Unmarshal(wfBytes, wf)
 }
 
 func (r RemoteFileWorkflowStore) GetCompiledWorkflow(ctx context.Context, source storage.DataReference) (*core.CompiledWorkflowClosure, error) {
@@ -77,6 +117,18 @@ func (r RemoteFileWorkflowStore) GetCompiledWorkflow(ctx context.Context, source
 	return &closure, err
 }
 
+// getCompiledWorkflow reads the compiled workflow closure from the source location and returns it along with the
+// checksum of the read contents.
+func (r RemoteFileWorkflowStore) getCompiledWorkflow(ctx context.Context, source storage.DataReference) (*core.CompiledWorkflowClosure, string, error) {
+	raw, err := r.getRawBytes(ctx, source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	closure := &core.CompiledWorkflowClosure{}
+	return closure, computeChecksum(raw), proto.Unmarshal(raw, closure)
+}
+
 func NewRemoteWorkflowStore(store *storage.DataStore) RemoteFileWorkflowStore {
 	return RemoteFileWorkflowStore{store: store}
 }
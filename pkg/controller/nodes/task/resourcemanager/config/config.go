@@ -11,8 +11,9 @@ const configSectionKey = "resourcemanager"
 type Type = string
 
 const (
-	TypeNoop  Type = "noop"
-	TypeRedis Type = "redis"
+	TypeNoop     Type = "noop"
+	TypeRedis    Type = "redis"
+	TypeInMemory Type = "inmemory"
 )
 
 var (
@@ -30,6 +31,18 @@ type Config struct {
 	Type             Type        `json:"type" pflag:"noop,Which resource manager to use"`
 	ResourceMaxQuota int         `json:"resourceMaxQuota" pflag:",Global limit for concurrent Qubole queries"`
 	RedisConfig      RedisConfig `json:"redis" pflag:",Config for Redis resourcemanager."`
+	TaskQuotas       []TaskQuota `json:"taskQuotas,omitempty" pflag:"-,Concurrency quotas enforced on task nodes before their plugins are invoked."`
+}
+
+// TaskQuota limits the number of task nodes of a project, domain and task type that run at the same time. Empty fields
+// match any value, e.g. a quota with only the task type set applies to the tasks of that type across all projects.
+// Every matching quota has to grant a task node before its plugin is invoked. Task quotas require the inmemory or redis
+// resource manager, the noop resource manager can't enforce them.
+type TaskQuota struct {
+	Project  string `json:"project,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	TaskType string `json:"taskType,omitempty"`
+	Quota    int    `json:"quota"`
 }
 
 // Specific configs for Redis resource manager
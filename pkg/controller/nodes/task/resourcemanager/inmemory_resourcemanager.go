@@ -0,0 +1,180 @@
+package resourcemanager
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	rmConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const InMemoryResourceManagerID = "inmemoryresourcemanager"
+
+// InMemoryResourceManagerBuilder builds a resource manager that keeps the allocated tokens in memory. The quotas are only
+// enforced within a single propeller process, which is enough for deployments that run one propeller and do not want to
+// run Redis.
+type InMemoryResourceManagerBuilder struct {
+	MetricsScope                promutils.Scope
+	namespacedResourcesQuotaMap map[pluginCore.ResourceNamespace]int
+}
+
+func (r *InMemoryResourceManagerBuilder) GetID() string {
+	return InMemoryResourceManagerID
+}
+
+func (r *InMemoryResourceManagerBuilder) GetResourceRegistrar(namespacePrefix pluginCore.ResourceNamespace) pluginCore.ResourceRegistrar {
+	return ResourceRegistrarProxy{
+		ResourceRegistrar:       r,
+		ResourceNamespacePrefix: namespacePrefix,
+	}
+}
+
+func (r *InMemoryResourceManagerBuilder) RegisterResourceQuota(ctx context.Context, namespace pluginCore.ResourceNamespace, quota int) error {
+	config := rmConfig.GetConfig()
+	if quota <= 0 || quota > config.ResourceMaxQuota {
+		return errors.Errorf("Invalid request for resource quota (<= 0 || > %v): [%v]", config.ResourceMaxQuota, quota)
+	}
+
+	if _, ok := r.namespacedResourcesQuotaMap[namespace]; ok {
+		return errors.Errorf("Resource namespace already exists [%v]", namespace)
+	}
+
+	r.namespacedResourcesQuotaMap[namespace] = quota
+	logger.Infof(ctx, "Registering resource quota for Namespace [%v]. Quota [%v]", namespace, quota)
+	return nil
+}
+
+func (r *InMemoryResourceManagerBuilder) BuildResourceManager(ctx context.Context) (BaseResourceManager, error) {
+	if r.MetricsScope == nil || r.namespacedResourcesQuotaMap == nil {
+		return nil, errors.Errorf("Failed to build an in-memory resource manager. Missing key property(s)")
+	}
+
+	rm := &InMemoryResourceManager{
+		namespacedResourcesMap: map[pluginCore.ResourceNamespace]*Resource{},
+		allocatedTokens:        map[pluginCore.ResourceNamespace]map[Token]struct{}{},
+	}
+
+	for namespace, quota := range r.namespacedResourcesQuotaMap {
+		rm.namespacedResourcesMap[namespace] = &Resource{
+			quota:          BaseResourceConstraint{Value: int64(quota)},
+			metrics:        NewInMemoryResourceManagerMetrics(r.MetricsScope.NewSubScope(getValidMetricScopeName(string(namespace)))),
+			rejectedTokens: sync.Map{},
+		}
+		rm.allocatedTokens[namespace] = map[Token]struct{}{}
+		logger.Infof(ctx, "Creating namespacedResourcesMap: added namespace [%v] and resource [%v]", namespace, rm.namespacedResourcesMap[namespace])
+	}
+
+	return rm, nil
+}
+
+func NewInMemoryResourceManagerBuilder(_ context.Context, scope promutils.Scope) (*InMemoryResourceManagerBuilder, error) {
+	return &InMemoryResourceManagerBuilder{
+		MetricsScope:                scope,
+		namespacedResourcesQuotaMap: map[pluginCore.ResourceNamespace]int{},
+	}, nil
+}
+
+type InMemoryResourceManagerMetrics struct {
+	Scope                promutils.Scope
+	AllocatedTokensGauge prometheus.Gauge
+}
+
+func (m InMemoryResourceManagerMetrics) GetScope() promutils.Scope {
+	return m.Scope
+}
+
+func NewInMemoryResourceManagerMetrics(scope promutils.Scope) *InMemoryResourceManagerMetrics {
+	return &InMemoryResourceManagerMetrics{
+		Scope:                scope,
+		AllocatedTokensGauge: scope.MustNewGauge("size", "The number of allocation tokens currently allocated"),
+	}
+}
+
+type InMemoryResourceManager struct {
+	lock                   sync.Mutex
+	namespacedResourcesMap map[pluginCore.ResourceNamespace]*Resource
+	allocatedTokens        map[pluginCore.ResourceNamespace]map[Token]struct{}
+}
+
+func (r *InMemoryResourceManager) GetID() string {
+	return InMemoryResourceManagerID
+}
+
+func (r *InMemoryResourceManager) getResource(namespace pluginCore.ResourceNamespace) (*Resource, error) {
+	if resource, ok := r.namespacedResourcesMap[namespace]; ok {
+		return resource, nil
+	}
+	return nil, errors.Errorf("Requested resource [%v] not found in namespacedResourceMap", namespace)
+}
+
+// Counts the tokens allocated in the namespace that match the prefix of the constraint, e.g. the tokens of one project.
+func countMatchingTokens(tokens map[Token]struct{}, constraint FullyQualifiedResourceConstraint) int64 {
+	var count int64
+	for token := range tokens {
+		if strings.HasPrefix(string(token), constraint.TargetedPrefixString) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *InMemoryResourceManager) AllocateResource(ctx context.Context, namespace pluginCore.ResourceNamespace, allocationToken Token,
+	constraints []FullyQualifiedResourceConstraint) (pluginCore.AllocationStatus, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	resource, err := r.getResource(namespace)
+	if err != nil {
+		logger.Errorf(ctx, "Error finding resource [%v] during allocation", namespace)
+		return pluginCore.AllocationUndefined, err
+	}
+
+	tokens := r.allocatedTokens[namespace]
+	if _, found := tokens[allocationToken]; found {
+		logger.Infof(ctx, "Already allocated [%s:%s]", namespace, allocationToken)
+		return pluginCore.AllocationStatusGranted, nil
+	}
+
+	if !resource.quota.IsAllowed(int64(len(tokens))) {
+		logger.Infof(ctx, "Too many allocations (total [%d]), rejecting [%s:%s]", len(tokens), namespace, allocationToken)
+		resource.rejectedTokens.Store(allocationToken, struct{}{})
+		return pluginCore.AllocationStatusExhausted, nil
+	}
+
+	for _, constraint := range constraints {
+		if !constraint.IsAllowed(countMatchingTokens(tokens, constraint)) {
+			logger.Infof(ctx, "Too many allocations for resource [%v], scope [%v] (max allocation: [%d]), rejecting token [%s]",
+				namespace, constraint.TargetedPrefixString, constraint.Value, allocationToken)
+			resource.rejectedTokens.Store(allocationToken, struct{}{})
+			return pluginCore.AllocationStatusExhausted, nil
+		}
+	}
+
+	tokens[allocationToken] = struct{}{}
+	resource.rejectedTokens.Delete(allocationToken)
+	resource.metrics.(*InMemoryResourceManagerMetrics).AllocatedTokensGauge.Set(float64(len(tokens)))
+	return pluginCore.AllocationStatusGranted, nil
+}
+
+func (r *InMemoryResourceManager) ReleaseResource(ctx context.Context, namespace pluginCore.ResourceNamespace, allocationToken Token) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	resource, err := r.getResource(namespace)
+	if err != nil {
+		logger.Errorf(ctx, "Error finding resource [%v] during releasing", namespace)
+		return err
+	}
+
+	tokens := r.allocatedTokens[namespace]
+	delete(tokens, allocationToken)
+	resource.rejectedTokens.Delete(allocationToken)
+	resource.metrics.(*InMemoryResourceManagerMetrics).AllocatedTokensGauge.Set(float64(len(tokens)))
+	logger.Infof(ctx, "Removed token: %s", allocationToken)
+	return nil
+}
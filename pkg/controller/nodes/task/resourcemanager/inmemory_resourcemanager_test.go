@@ -0,0 +1,72 @@
+package resourcemanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryResourceManager(t *testing.T) {
+	ctx := context.TODO()
+	builder, err := NewInMemoryResourceManagerBuilder(ctx, promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.NoError(t, builder.RegisterResourceQuota(ctx, "test-resource1", 2))
+	assert.Error(t, builder.RegisterResourceQuota(ctx, "test-resource1", 2))
+	assert.Error(t, builder.RegisterResourceQuota(ctx, "test-resource2", 0))
+
+	rm, err := builder.BuildResourceManager(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, InMemoryResourceManagerID, rm.GetID())
+
+	t.Run("Namespace cap is enforced", func(t *testing.T) {
+		status, err := rm.AllocateResource(ctx, "test-resource1", "ns1-token1", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+
+		// Allocating the same token again is idempotent.
+		status, err = rm.AllocateResource(ctx, "test-resource1", "ns1-token1", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+
+		status, err = rm.AllocateResource(ctx, "test-resource1", "ns2-token1", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+
+		status, err = rm.AllocateResource(ctx, "test-resource1", "ns2-token2", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusExhausted, status)
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "test-resource1", "ns2-token1"))
+		status, err = rm.AllocateResource(ctx, "test-resource1", "ns2-token2", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "test-resource1", "ns1-token1"))
+		assert.NoError(t, rm.ReleaseResource(ctx, "test-resource1", "ns2-token2"))
+	})
+
+	t.Run("Constraints are enforced", func(t *testing.T) {
+		constraints := []FullyQualifiedResourceConstraint{{TargetedPrefixString: "ns1", Value: 1}}
+		status, err := rm.AllocateResource(ctx, "test-resource1", "ns1-token1", constraints)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+
+		status, err = rm.AllocateResource(ctx, "test-resource1", "ns1-token2", constraints)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusExhausted, status)
+
+		status, err = rm.AllocateResource(ctx, "test-resource1", "ns2-token1",
+			[]FullyQualifiedResourceConstraint{{TargetedPrefixString: "ns2", Value: 1}})
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+	})
+
+	t.Run("Unknown namespace", func(t *testing.T) {
+		_, err := rm.AllocateResource(ctx, "unknown", "token", nil)
+		assert.Error(t, err)
+		assert.Error(t, rm.ReleaseResource(ctx, "unknown", "token"))
+	})
+}
@@ -9,8 +9,9 @@ import (
 )
 
 const (
-	resourceManagerPrometheusScope      = "resourcemanager"
-	redisResourceManagerPrometheusScope = "redis"
+	resourceManagerPrometheusScope         = "resourcemanager"
+	redisResourceManagerPrometheusScope    = "redis"
+	inMemoryResourceManagerPrometheusScope = "inmemory"
 )
 
 func GetResourceManagerBuilderByType(ctx context.Context, managerType rmConfig.Type, scope promutils.Scope) (
@@ -30,6 +31,9 @@ func GetResourceManagerBuilderByType(ctx context.Context, managerType rmConfig.T
 			return nil, err
 		}
 		return NewRedisResourceManagerBuilder(ctx, redisClient, rmScope.NewSubScope(redisResourceManagerPrometheusScope))
+	case rmConfig.TypeInMemory:
+		logger.Infof(ctx, "Using the in-memory resource manager")
+		return NewInMemoryResourceManagerBuilder(ctx, rmScope.NewSubScope(inMemoryResourceManagerPrometheusScope))
 	}
 	logger.Infof(ctx, "Using the NOOP resource manager by default")
 	return &NoopResourceManagerBuilder{}, nil
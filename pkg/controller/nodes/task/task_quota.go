@@ -0,0 +1,131 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager"
+	rmConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager/config"
+)
+
+const (
+	taskQuotaNamespace = pluginCore.ResourceNamespace("taskquota")
+	anyQuotaField      = "*"
+)
+
+type taskQuota struct {
+	rmConfig.TaskQuota
+	namespace pluginCore.ResourceNamespace
+}
+
+func matchesQuotaField(quotaField, value string) bool {
+	return len(quotaField) == 0 || quotaField == value
+}
+
+func (q taskQuota) matches(id *core.WorkflowExecutionIdentifier, taskType string) bool {
+	return matchesQuotaField(q.Project, id.GetProject()) &&
+		matchesQuotaField(q.Domain, id.GetDomain()) &&
+		matchesQuotaField(q.TaskType, taskType)
+}
+
+func quotaNamespaceField(quotaField string) string {
+	if len(quotaField) == 0 {
+		return anyQuotaField
+	}
+	return quotaField
+}
+
+// Registers the task quotas with the resource manager. Every quota gets a namespace of its own, named after the project,
+// domain and task type it applies to, so that the tokens of a quota survive reordering the configured quotas. Fails if
+// quotas are configured with the noop resource manager, which grants every allocation and would silently ignore them.
+func registerTaskQuotas(ctx context.Context, rmBuilder resourcemanager.Builder, quotas []rmConfig.TaskQuota) ([]taskQuota, error) {
+	if len(quotas) > 0 && rmBuilder.GetID() == resourcemanager.NoopResourceManagerID {
+		return nil, fmt.Errorf("[%d] task quotas are configured but the noop resource manager can't enforce them, "+
+			"configure the inmemory or redis resource manager", len(quotas))
+	}
+
+	prefix := pluginCore.ResourceNamespace(rmBuilder.GetID()).CreateSubNamespace(taskQuotaNamespace)
+	registrar := rmBuilder.GetResourceRegistrar(prefix)
+	taskQuotas := make([]taskQuota, 0, len(quotas))
+	for _, quota := range quotas {
+		namespace := pluginCore.ResourceNamespace(fmt.Sprintf("%s:%s:%s", quotaNamespaceField(quota.Project),
+			quotaNamespaceField(quota.Domain), quotaNamespaceField(quota.TaskType)))
+		if err := registrar.RegisterResourceQuota(ctx, namespace, quota.Quota); err != nil {
+			return nil, err
+		}
+
+		logger.Infof(ctx, "Registered task quota [%v] with quota [%d]", namespace, quota.Quota)
+		taskQuotas = append(taskQuotas, taskQuota{
+			TaskQuota: quota,
+			namespace: prefix.CreateSubNamespace(namespace),
+		})
+	}
+
+	return taskQuotas, nil
+}
+
+// Allocates a token for the task execution in every task quota that matches it. Returns the namespace of the first quota
+// that is exhausted, in which case tokens granted by the other quotas are released again so that a waiting task does
+// not hold on to them. An empty namespace is returned if all matching quotas granted the task.
+func (t Handler) allocateTaskQuotas(ctx context.Context, tCtx *taskExecutionContext, taskType string) (
+	pluginCore.ResourceNamespace, error) {
+	execID := tCtx.TaskExecutionMetadata().GetTaskExecutionID()
+	token := resourcemanager.Token(execID.GetGeneratedName())
+	workflowExecID := execID.GetID().NodeExecutionId.GetExecutionId()
+	granted := make([]pluginCore.ResourceNamespace, 0, len(t.taskQuotas))
+	for _, q := range t.taskQuotas {
+		if !q.matches(workflowExecID, taskType) {
+			continue
+		}
+
+		status, err := t.resourceManager.AllocateResource(ctx, q.namespace, token, nil)
+		if err != nil {
+			return "", err
+		}
+
+		if status != pluginCore.AllocationStatusGranted {
+			logger.Infof(ctx, "Task quota [%v] exhausted, task [%v] has to wait", q.namespace, token)
+			for _, namespace := range granted {
+				if err := t.resourceManager.ReleaseResource(ctx, namespace, token); err != nil {
+					return "", err
+				}
+			}
+
+			return q.namespace, nil
+		}
+
+		granted = append(granted, q.namespace)
+	}
+
+	return "", nil
+}
+
+// Releases the tokens of the task execution in all task quotas that match it. Releasing a token that is not allocated is
+// a no-op.
+func (t Handler) releaseTaskQuotas(ctx context.Context, tCtx *taskExecutionContext, taskType string) error {
+	execID := tCtx.TaskExecutionMetadata().GetTaskExecutionID()
+	token := resourcemanager.Token(execID.GetGeneratedName())
+	workflowExecID := execID.GetID().NodeExecutionId.GetExecutionId()
+	for _, q := range t.taskQuotas {
+		if !q.matches(workflowExecID, taskType) {
+			continue
+		}
+
+		if err := t.resourceManager.ReleaseResource(ctx, q.namespace, token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Task quotas are checked until the plugin is invoked for the first time, running tasks keep their tokens until they
+// reach a terminal phase.
+func isWaitingForTaskQuota(phase pluginCore.Phase) bool {
+	return phase == pluginCore.PhaseUndefined || phase == pluginCore.PhaseWaitingForCache ||
+		phase == pluginCore.PhaseWaitingForResources
+}
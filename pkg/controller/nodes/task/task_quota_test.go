@@ -0,0 +1,108 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager"
+	rmConfig "github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/resourcemanager/config"
+)
+
+func newTaskQuotaTestContext(project, name string) *taskExecutionContext {
+	return &taskExecutionContext{
+		tm: taskExecutionMetadata{
+			taskExecID: taskExecutionID{
+				execName: name,
+				id: &core.TaskExecutionIdentifier{
+					NodeExecutionId: &core.NodeExecutionIdentifier{
+						ExecutionId: &core.WorkflowExecutionIdentifier{Project: project, Domain: "development", Name: name},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTaskQuotas(t *testing.T) {
+	ctx := context.TODO()
+	rmBuilder, err := resourcemanager.GetResourceManagerBuilderByType(ctx, rmConfig.TypeInMemory, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	taskQuotas, err := registerTaskQuotas(ctx, rmBuilder, []rmConfig.TaskQuota{
+		{Project: "flytesnacks", Quota: 2},
+		{TaskType: "hive", Quota: 1},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, taskQuotas, 2)
+	assert.Equal(t, pluginCore.ResourceNamespace("inmemoryresourcemanager:taskquota:flytesnacks:*:*"), taskQuotas[0].namespace)
+	assert.Equal(t, pluginCore.ResourceNamespace("inmemoryresourcemanager:taskquota:*:*:hive"), taskQuotas[1].namespace)
+
+	rm, err := rmBuilder.BuildResourceManager(ctx)
+	assert.NoError(t, err)
+	h := Handler{resourceManager: rm, taskQuotas: taskQuotas}
+
+	t.Run("matches", func(t *testing.T) {
+		id := &core.WorkflowExecutionIdentifier{Project: "flytesnacks", Domain: "development"}
+		assert.True(t, taskQuotas[0].matches(id, "python-task"))
+		assert.False(t, taskQuotas[1].matches(id, "python-task"))
+		assert.True(t, taskQuotas[1].matches(id, "hive"))
+		assert.False(t, taskQuotas[0].matches(&core.WorkflowExecutionIdentifier{Project: "other"}, "hive"))
+	})
+
+	t.Run("allocate and release", func(t *testing.T) {
+		exhausted, err := h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "a"), "hive")
+		assert.NoError(t, err)
+		assert.Empty(t, exhausted)
+
+		// Allocating again is idempotent.
+		exhausted, err = h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "a"), "hive")
+		assert.NoError(t, err)
+		assert.Empty(t, exhausted)
+
+		// The hive quota is exhausted, the token granted by the project quota is released again.
+		exhausted, err = h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "b"), "hive")
+		assert.NoError(t, err)
+		assert.Equal(t, taskQuotas[1].namespace, exhausted)
+
+		exhausted, err = h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "c"), "python-task")
+		assert.NoError(t, err)
+		assert.Empty(t, exhausted)
+
+		// Tasks of other projects are not limited by the project quota.
+		exhausted, err = h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("other", "d"), "python-task")
+		assert.NoError(t, err)
+		assert.Empty(t, exhausted)
+
+		exhausted, err = h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "e"), "python-task")
+		assert.NoError(t, err)
+		assert.Equal(t, taskQuotas[0].namespace, exhausted)
+
+		assert.NoError(t, h.releaseTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "a"), "hive"))
+		exhausted, err = h.allocateTaskQuotas(ctx, newTaskQuotaTestContext("flytesnacks", "b"), "hive")
+		assert.NoError(t, err)
+		assert.Empty(t, exhausted)
+	})
+
+	t.Run("noop resource manager", func(t *testing.T) {
+		noopBuilder, err := resourcemanager.GetResourceManagerBuilderByType(ctx, rmConfig.TypeNoop, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		_, err = registerTaskQuotas(ctx, noopBuilder, []rmConfig.TaskQuota{{Project: "flytesnacks", Quota: 2}})
+		assert.Error(t, err)
+
+		noQuotas, err := registerTaskQuotas(ctx, noopBuilder, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, noQuotas)
+	})
+
+	t.Run("isWaitingForTaskQuota", func(t *testing.T) {
+		assert.True(t, isWaitingForTaskQuota(pluginCore.PhaseUndefined))
+		assert.True(t, isWaitingForTaskQuota(pluginCore.PhaseWaitingForResources))
+		assert.False(t, isWaitingForTaskQuota(pluginCore.PhaseRunning))
+	})
+}
@@ -91,6 +91,12 @@ type taskExecutionContext struct {
 	ber *bufferedEventRecorder
 	sm  pluginCore.SecretManager
 	c   pluginCatalog.AsyncClient
+	env *v1alpha1.ExecutionEnvironment
+}
+
+// RecordExecutionEnvironment keeps the environment the plugin observed the task executing in during this round.
+func (t *taskExecutionContext) RecordExecutionEnvironment(env *v1alpha1.ExecutionEnvironment) {
+	t.env = env
 }
 
 func (t *taskExecutionContext) TaskRefreshIndicator() pluginCore.SignalAsync {
@@ -218,6 +224,9 @@ func ComputeRawOutputPrefix(ctx context.Context, length int, nCtx handler.NodeEx
 }
 
 // ComputePreviousCheckpointPath returns the checkpoint path for the previous attempt, if this is the first attempt then returns an empty path
+// Checkpoints live under the raw output prefixes of the attempts and are not garbage collected by propeller, since the
+// blob store can't delete blobs. Checkpoints of earlier attempts are expired with the rest of the raw output data, e.g.
+// by lifecycle rules of the object store.
 func ComputePreviousCheckpointPath(ctx context.Context, length int, nCtx handler.NodeExecutionContext, currentNodeUniqueID v1alpha1.NodeID, currentAttempt uint32) (storage.DataReference, error) {
 	if currentAttempt == 0 {
 		return "", nil
@@ -233,13 +242,11 @@ func ComputePreviousCheckpointPath(ctx context.Context, length int, nCtx handler
 func (t *Handler) newTaskExecutionContext(ctx context.Context, nCtx handler.NodeExecutionContext, plugin pluginCore.Plugin) (*taskExecutionContext, error) {
 	id := GetTaskExecutionIdentifier(nCtx)
 
-	currentNodeUniqueID := nCtx.NodeID()
-	if nCtx.ExecutionContext().GetEventVersion() != v1alpha1.EventVersion0 {
-		var err error
-		currentNodeUniqueID, err = common.GenerateUniqueID(nCtx.ExecutionContext().GetParentInfo(), nCtx.NodeID())
-		if err != nil {
-			return nil, err
-		}
+	// Resources (e.g. pods, raw output prefixes) are always named after the lineage of the node, regardless of the event
+	// version, so that a retried parent never observes or adopts the resources created by its previous attempts.
+	currentNodeUniqueID, err := common.GenerateUniqueID(nCtx.ExecutionContext().GetParentInfo(), nCtx.NodeID())
+	if err != nil {
+		return nil, err
 	}
 
 	length := IDMaxLength
@@ -8,6 +8,7 @@ import (
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/common"
+	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/golang/protobuf/ptypes"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/handler"
@@ -16,6 +17,10 @@ import (
 // This is used by flyteadmin to indicate that map tasks now report subtask metadata individually.
 var taskExecutionEventVersion = int32(1)
 
+// The key set in the custom info of the events of tasks that are no longer interruptible because they hit the
+// interruptible failure threshold.
+const interruptibleDemotedCustomInfoKey = "interruptibleDemoted"
+
 func ToTransitionType(ttype pluginCore.TransitionType) handler.TransitionType {
 	if ttype == pluginCore.TransitionTypeBarrier {
 		return handler.TransitionTypeBarrier
@@ -83,6 +88,10 @@ type ToTaskExecutionEventInputs struct {
 	PluginID              string
 	ResourcePoolInfo      []*event.ResourcePoolInfo
 	ClusterID             string
+	ExecutionEnvironment  *v1alpha1.ExecutionEnvironment
+	SignedOutputs         *SignedOutputs
+	DeckURI               storage.DataReference
+	DataTransfer          *DataTransfer
 }
 
 func ToTaskExecutionEvent(input ToTaskExecutionEventInputs) (*event.TaskExecutionEvent, error) {
@@ -157,10 +166,54 @@ func ToTaskExecutionEvent(input ToTaskExecutionEventInputs) (*event.TaskExecutio
 		tev.CustomInfo = input.Info.Info().CustomInfo
 	}
 
+	if input.ExecutionEnvironment != nil {
+		customInfo, err := withExecutionEnvironment(tev.CustomInfo, input.ExecutionEnvironment)
+		if err != nil {
+			return nil, err
+		}
+
+		tev.CustomInfo = customInfo
+	}
+
+	if input.SignedOutputs != nil {
+		customInfo, err := withCustomInfoValue(tev.CustomInfo, signedOutputsCustomInfoKey, input.SignedOutputs)
+		if err != nil {
+			return nil, err
+		}
+
+		tev.CustomInfo = customInfo
+	}
+
+	if len(input.DeckURI) > 0 {
+		customInfo, err := withCustomInfoValue(tev.CustomInfo, deckURICustomInfoKey, input.DeckURI.String())
+		if err != nil {
+			return nil, err
+		}
+
+		tev.CustomInfo = customInfo
+	}
+
+	if input.DataTransfer != nil {
+		customInfo, err := withCustomInfoValue(tev.CustomInfo, dataTransferCustomInfoKey, input.DataTransfer)
+		if err != nil {
+			return nil, err
+		}
+
+		tev.CustomInfo = customInfo
+	}
+
 	if input.NodeExecutionMetadata.IsInterruptible() {
 		tev.Metadata.InstanceClass = event.TaskExecutionMetadata_INTERRUPTIBLE
 	} else {
 		tev.Metadata.InstanceClass = event.TaskExecutionMetadata_DEFAULT
+		if input.NodeExecutionMetadata.IsInterruptibleDemoted() {
+			customInfo, err := withCustomInfoValue(tev.CustomInfo, interruptibleDemotedCustomInfoKey, true)
+			if err != nil {
+				return nil, err
+			}
+
+			tev.CustomInfo = customInfo
+		}
 	}
 
 	return tev, nil
@@ -172,6 +172,7 @@ func TestToTaskExecutionEvent(t *testing.T) {
 
 	defaultNodeExecutionMetadata := handlerMocks.NodeExecutionMetadata{}
 	defaultNodeExecutionMetadata.OnIsInterruptible().Return(false)
+	defaultNodeExecutionMetadata.OnIsInterruptibleDemoted().Return(false)
 	tev, err = ToTaskExecutionEvent(ToTaskExecutionEventInputs{
 		TaskExecContext: tCtx,
 		InputReader:     in,
@@ -206,6 +207,52 @@ func TestToTaskExecutionEvent(t *testing.T) {
 	assert.Equal(t, generatedName, tev.Metadata.GeneratedName)
 	assert.EqualValues(t, resourcePoolInfo, tev.Metadata.ResourcePoolInfo)
 	assert.Equal(t, testClusterID, tev.ProducerId)
+
+	demotedNodeExecutionMetadata := handlerMocks.NodeExecutionMetadata{}
+	demotedNodeExecutionMetadata.OnIsInterruptible().Return(false)
+	demotedNodeExecutionMetadata.OnIsInterruptibleDemoted().Return(true)
+	tev, err = ToTaskExecutionEvent(ToTaskExecutionEventInputs{
+		TaskExecContext: tCtx,
+		InputReader:     in,
+		OutputWriter:    out,
+		Info: pluginCore.PhaseInfoRunning(0, &pluginCore.TaskInfo{
+			OccurredAt: &n,
+			CustomInfo: c,
+		}),
+		NodeExecutionMetadata: &demotedNodeExecutionMetadata,
+		ExecContext:           mockExecContext,
+		TaskType:              containerTaskType,
+		PluginID:              containerPluginIdentifier,
+		ResourcePoolInfo:      resourcePoolInfo,
+		ClusterID:             testClusterID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, event.TaskExecutionMetadata_DEFAULT, tev.Metadata.InstanceClass)
+	assert.True(t, tev.CustomInfo.GetFields()[interruptibleDemotedCustomInfoKey].GetBoolValue())
+	for k, v := range c.GetFields() {
+		assert.Equal(t, v, tev.CustomInfo.GetFields()[k])
+	}
+
+	tev, err = ToTaskExecutionEvent(ToTaskExecutionEventInputs{
+		TaskExecContext: tCtx,
+		InputReader:     in,
+		OutputWriter:    out,
+		Info: pluginCore.PhaseInfoSuccess(&pluginCore.TaskInfo{
+			OccurredAt: &n,
+		}),
+		NodeExecutionMetadata: &defaultNodeExecutionMetadata,
+		ExecContext:           mockExecContext,
+		TaskType:              containerTaskType,
+		PluginID:              containerPluginIdentifier,
+		ClusterID:             testClusterID,
+		DeckURI:               "s3://bucket/prefix/deck.html",
+		DataTransfer:          &DataTransfer{InputBytes: 3, OutputBytes: 5},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket/prefix/deck.html", tev.CustomInfo.GetFields()[deckURICustomInfoKey].GetStringValue())
+	dataTransfer := tev.CustomInfo.GetFields()[dataTransferCustomInfoKey].GetStructValue().GetFields()
+	assert.Equal(t, float64(3), dataTransfer["inputBytes"].GetNumberValue())
+	assert.Equal(t, float64(5), dataTransfer["outputBytes"].GetNumberValue())
 }
 
 func TestToTransitionType(t *testing.T) {
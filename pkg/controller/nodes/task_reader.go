@@ -4,12 +4,17 @@ import (
 	"context"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
 
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 )
 
 type taskReader struct {
 	*core.TaskTemplate
+	// Set for tasks that reference their template in the DataStore, in which case TaskTemplate only has the id and type
+	// of the task and the template is read lazily.
+	templateRef storage.DataReference
+	templates   *taskTemplateCache
 }
 
 func (t taskReader) GetTaskType() v1alpha1.TaskType {
@@ -21,5 +26,9 @@ func (t taskReader) GetTaskID() *core.Identifier {
 }
 
 func (t taskReader) Read(ctx context.Context) (*core.TaskTemplate, error) {
-	return t.TaskTemplate, nil
+	if len(t.templateRef) == 0 {
+		return t.TaskTemplate, nil
+	}
+
+	return t.templates.Get(ctx, t.templateRef)
 }
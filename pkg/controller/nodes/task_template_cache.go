@@ -0,0 +1,64 @@
+package nodes
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/errors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/sizedcache"
+)
+
+const (
+	// Number of task templates that are kept in memory, across all workflows.
+	defaultTaskTemplateCacheSize = 1000
+	// Referenced task templates are content-addressed and never change, they only expire to make room for the templates
+	// of tasks that are still in use.
+	defaultTaskTemplateTTL = 24 * time.Hour
+)
+
+// taskTemplateCache keeps the task templates that workflows reference in the DataStore, rather than store inline, so
+// that templates shared by many executions are read once.
+type taskTemplateCache struct {
+	store     *storage.DataStore
+	templates *sizedcache.Cache
+	ttl       time.Duration
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+}
+
+// Get returns the task template stored at the given location, reading it from the DataStore on first use.
+func (c *taskTemplateCache) Get(ctx context.Context, ref storage.DataReference) (*core.TaskTemplate, error) {
+	if t, ok := c.templates.Get(ref); ok {
+		c.hits.Inc()
+		return t.(*core.TaskTemplate), nil
+	}
+
+	c.misses.Inc()
+	t := &core.TaskTemplate{}
+	if err := c.store.ReadProtobuf(ctx, ref, t); err != nil {
+		return nil, errors.Wrapf(errors.StorageError, "", err, "failed to read task template from [%v]", ref)
+	}
+
+	logger.Debugf(ctx, "Caching task template [%v] read from [%v]", t.GetId(), ref)
+	c.templates.Add(ref, t, int64(proto.Size(t)), c.ttl)
+	return t, nil
+}
+
+func newTaskTemplateCache(store *storage.DataStore, size int, maxSizeBytes int64, ttl time.Duration,
+	scope promutils.Scope) *taskTemplateCache {
+	return &taskTemplateCache{
+		store:     store,
+		templates: sizedcache.New(size, maxSizeBytes, clock.RealClock{}, scope),
+		ttl:       ttl,
+		hits:      scope.MustNewCounter("hits", "Number of task templates served from the cache"),
+		misses:    scope.MustNewCounter("misses", "Number of task templates read from the DataStore because they were not cached"),
+	}
+}
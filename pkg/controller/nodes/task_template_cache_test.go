@@ -0,0 +1,67 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskReader_Read(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	template := &core.TaskTemplate{
+		Id:   &core.Identifier{ResourceType: core.ResourceType_TASK, Name: "t1"},
+		Type: "python-task",
+		Metadata: &core.TaskMetadata{
+			Retries: &core.RetryStrategy{Retries: 3},
+		},
+	}
+
+	const ref = storage.DataReference("s3://bucket/task-templates/abc")
+	assert.NoError(t, store.WriteProtobuf(ctx, ref, storage.Options{}, template))
+	templates := newTaskTemplateCache(store, 10, 0, time.Hour, promutils.NewTestScope())
+
+	t.Run("inline", func(t *testing.T) {
+		tr := taskReader{TaskTemplate: template}
+		actual, err := tr.Read(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, template, actual)
+	})
+
+	t.Run("referenced", func(t *testing.T) {
+		tr := taskReader{
+			TaskTemplate: &core.TaskTemplate{Id: template.Id, Type: template.Type},
+			templateRef:  ref,
+			templates:    templates,
+		}
+
+		assert.Equal(t, "python-task", tr.GetTaskType())
+		actual, err := tr.Read(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(3), actual.GetMetadata().GetRetries().GetRetries())
+
+		// The template is read once and then served from memory.
+		assert.NoError(t, store.WriteProtobuf(ctx, ref, storage.Options{}, &core.TaskTemplate{}))
+		actual, err = tr.Read(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(3), actual.GetMetadata().GetRetries().GetRetries())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		tr := taskReader{
+			TaskTemplate: &core.TaskTemplate{Id: template.Id, Type: template.Type},
+			templateRef:  "s3://bucket/task-templates/missing",
+			templates:    templates,
+		}
+
+		_, err := tr.Read(ctx)
+		assert.Error(t, err)
+	})
+}
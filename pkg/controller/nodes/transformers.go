@@ -20,7 +20,7 @@ import (
 )
 
 // This is used by flyteadmin to indicate that the events will now contain populated IsParent and IsDynamic bits.
-var nodeExecutionEventVersion = int32(1)
+var nodeExecutionEventVersion = common.NodeExecutionEventVersion
 
 func ToNodeExecOutput(info *handler.OutputInfo) *event.NodeExecutionEvent_OutputUri {
 	if info == nil || info.OutputURI == "" {
@@ -68,6 +68,8 @@ func ToNodeExecEventPhase(p handler.EPhase) core.NodeExecution_Phase {
 		return core.NodeExecution_FAILED
 	case handler.EPhaseRecovered:
 		return core.NodeExecution_RECOVERED
+	case handler.EPhaseTimedout:
+		return core.NodeExecution_TIMED_OUT
 	default:
 		return core.NodeExecution_UNDEFINED
 	}
@@ -147,6 +149,8 @@ func ToNodeExecutionEvent(nodeExecID *core.NodeExecutionIdentifier,
 
 	eInfo := info.GetInfo()
 	if eInfo != nil {
+		// The target metadata is one of both, the child execution launched by a workflow node takes precedence over the
+		// cache status it may carry alongside.
 		if eInfo.WorkflowNodeInfo != nil {
 			v := ToNodeExecWorkflowNodeMetadata(eInfo.WorkflowNodeInfo)
 			if v != nil {
@@ -208,9 +212,13 @@ func ToK8sTime(t time.Time) v1.Time {
 }
 
 func UpdateNodeStatus(np v1alpha1.NodePhase, p handler.PhaseInfo, n *nodeStateManager, s v1alpha1.ExecutableNodeStatus) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
 	// We update the phase only if it is not already updated
 	if np != s.GetPhase() {
 		s.UpdatePhase(np, ToK8sTime(p.GetOccurredAt()), p.GetReason(), p.GetErr())
+		s.SetReasonCode(string(p.GetReasonCode()))
 	}
 	// Update TaskStatus
 	if n.t != nil {
@@ -221,6 +229,7 @@ func UpdateNodeStatus(np v1alpha1.NodePhase, p handler.PhaseInfo, n *nodeStateMa
 		t.SetPluginState(n.t.PluginState)
 		t.SetPluginStateVersion(n.t.PluginStateVersion)
 		t.SetBarrierClockTick(n.t.BarrierClockTick)
+		t.SetExecutionEnvironment(n.t.ExecutionEnvironment)
 	}
 
 	// Update dynamic node status
@@ -229,6 +238,7 @@ func UpdateNodeStatus(np v1alpha1.NodePhase, p handler.PhaseInfo, n *nodeStateMa
 		t.SetDynamicNodePhase(n.d.Phase)
 		t.SetDynamicNodeReason(n.d.Reason)
 		t.SetExecutionError(n.d.Error)
+		t.SetWorkflowChecksum(n.d.WorkflowChecksum)
 	}
 
 	// Update branch node status
@@ -249,4 +259,16 @@ func UpdateNodeStatus(np v1alpha1.NodePhase, p handler.PhaseInfo, n *nodeStateMa
 		t.SetWorkflowNodePhase(n.w.Phase)
 		t.SetExecutionError(n.w.Error)
 	}
+
+	// Update array node status
+	if n.a != nil {
+		t := s.GetOrCreateArrayNodeStatus()
+		t.SetArrayNodePhase(n.a.Phase)
+		t.SetExecutionError(n.a.Error)
+		t.SetSubNodePhases(n.a.SubNodePhases)
+		t.SetSubNodeTaskPhases(n.a.SubNodeTaskPhases)
+		t.SetSubNodeRetryAttempts(n.a.SubNodeRetryAttempts)
+		t.SetSubNodeSystemFailures(n.a.SubNodeSystemFailures)
+		t.SetSubNodeTaskStates(n.a.SubNodeTaskStates)
+	}
 }
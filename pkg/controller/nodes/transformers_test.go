@@ -85,4 +85,34 @@ func TestToNodeExecutionEvent(t *testing.T) {
 		assert.True(t, nev.IsParent)
 		assert.Equal(t, nodeExecutionEventVersion, nev.EventVersion)
 	})
+	t.Run("launch plan node with cache status", func(t *testing.T) {
+		childID := &core.WorkflowExecutionIdentifier{Project: project, Domain: domain, Name: "child"}
+		info := handler.PhaseInfoSuccess(&handler.ExecutionInfo{
+			WorkflowNodeInfo: &handler.WorkflowNodeInfo{LaunchedWorkflowID: childID},
+			TaskNodeInfo: &handler.TaskNodeInfo{
+				TaskNodeMetadata: &event.TaskNodeMetadata{CacheStatus: core.CatalogCacheStatus_CACHE_POPULATED},
+			},
+		})
+		status := mocks.ExecutableNodeStatus{}
+		status.OnGetParentTaskID().Return(nil)
+		node := mocks.ExecutableNode{}
+		node.OnGetID().Return("n")
+		node.OnGetName().Return("nodey")
+		node.OnGetKind().Return(v1alpha1.NodeKindWorkflow)
+		executableWorkflowNode := mocks.ExecutableWorkflowNode{}
+		executableWorkflowNode.OnGetSubWorkflowRef().Return(nil)
+		node.OnGetWorkflowNode().Return(&executableWorkflowNode)
+
+		nev, err := ToNodeExecutionEvent(&core.NodeExecutionIdentifier{
+			NodeId: "nodey",
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: "project",
+				Domain:  "domain",
+				Name:    "exec",
+			},
+		}, info, "inputPath", &status, v1alpha1.EventVersion0, nil, &node, "clusterID", v1alpha1.DynamicNodePhaseNone)
+		assert.NoError(t, err)
+		assert.Equal(t, childID, nev.GetWorkflowNodeMetadata().GetExecutionId())
+		assert.False(t, nev.IsParent)
+	})
 }
@@ -0,0 +1,168 @@
+// Package outcomes records the outcomes of the top-level nodes of executions once they terminate, so that a relaunch of
+// a failed execution can reuse the outputs of the nodes that succeeded instead of running them again.
+package outcomes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// ReuseResultsAnnotation is set on a relaunched execution to reuse the results of the nodes of a previous execution.
+// Its value is the name of the previous execution, which must belong to the same project and domain.
+const ReuseResultsAnnotation = "flyte.org/reuse-results-of"
+
+const (
+	manifestFile = "node-outcomes.json"
+	// A relaunch reads the manifest of the previous execution for every one of its top-level nodes, the manifests are
+	// cached for the duration of the relaunch.
+	manifestCacheSize = 128
+	manifestCacheTTL  = 30 * time.Minute
+)
+
+// NodeOutcome is the outcome of a top-level node of an execution.
+type NodeOutcome struct {
+	Phase      v1alpha1.NodePhase    `json:"phase"`
+	InputsURI  storage.DataReference `json:"inputsUri,omitempty"`
+	OutputsURI storage.DataReference `json:"outputsUri,omitempty"`
+}
+
+// Manifest holds the outcomes of the succeeded top-level nodes of a terminated execution.
+type Manifest struct {
+	ExecutionID string                          `json:"executionId"`
+	Phase       v1alpha1.WorkflowPhase          `json:"phase"`
+	Nodes       map[v1alpha1.NodeID]NodeOutcome `json:"nodes"`
+}
+
+// Store writes and reads the manifests of executions. The manifest of an execution is stored next to the rest of its
+// metadata.
+type Store struct {
+	store      *storage.DataStore
+	basePrefix storage.DataReference
+	manifests  *cache.LRUExpireCache
+}
+
+// Returns the name of the directory of the metadata of the execution.
+func executionDir(project, domain, name string) string {
+	return fmt.Sprintf("%v-%v-%v", project, domain, name)
+}
+
+func (s *Store) manifestReference(ctx context.Context, project, domain, name string) (storage.DataReference, error) {
+	return s.store.ConstructReference(ctx, s.basePrefix, executionDir(project, domain, name), manifestFile)
+}
+
+// Write records the outcomes of the succeeded top-level nodes of the terminated workflow. The start and end nodes are
+// not recorded, since they are never reused.
+func (s *Store) Write(ctx context.Context, w *v1alpha1.FlyteWorkflow) error {
+	if w.GetExecutionID().WorkflowExecutionIdentifier == nil {
+		return nil
+	}
+
+	m := Manifest{
+		ExecutionID: w.GetExecutionID().GetName(),
+		Phase:       w.GetExecutionStatus().GetPhase(),
+		Nodes:       map[v1alpha1.NodeID]NodeOutcome{},
+	}
+
+	for id := range w.Status.NodeStatus {
+		if id == v1alpha1.StartNodeID || id == v1alpha1.EndNodeID {
+			continue
+		}
+
+		ns := w.GetNodeExecutionStatus(ctx, id)
+		if ns.GetPhase() != v1alpha1.NodePhaseSucceeded && ns.GetPhase() != v1alpha1.NodePhaseRecovered {
+			continue
+		}
+
+		m.Nodes[id] = NodeOutcome{
+			Phase:      ns.GetPhase(),
+			InputsURI:  v1alpha1.GetInputsFile(ns.GetDataDir()),
+			OutputsURI: v1alpha1.GetOutputsFile(ns.GetOutputDir()),
+		}
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	ref, err := s.manifestReference(ctx, w.GetExecutionID().GetProject(), w.GetExecutionID().GetDomain(), m.ExecutionID)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf(ctx, "Recording the outcomes of [%d] nodes in [%v]", len(m.Nodes), ref)
+	return s.store.WriteRaw(ctx, ref, int64(len(raw)), storage.Options{}, bytes.NewReader(raw))
+}
+
+// Read returns the manifest of the given execution, or nil if the execution recorded no manifest.
+func (s *Store) Read(ctx context.Context, project, domain, name string) (*Manifest, error) {
+	ref, err := s.manifestReference(ctx, project, domain, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := s.manifests.Get(ref); ok {
+		return cached.(*Manifest), nil
+	}
+
+	metadata, err := s.store.Head(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metadata.Exists() {
+		return nil, nil
+	}
+
+	rc, err := s.store.ReadRaw(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rc.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close reader for [%v]. Error: %v", ref, err)
+		}
+	}()
+
+	m := &Manifest{}
+	if err := json.NewDecoder(rc).Decode(m); err != nil {
+		return nil, fmt.Errorf("failed to decode node outcomes [%v]: %w", ref, err)
+	}
+
+	s.manifests.Add(ref, m, manifestCacheTTL)
+	return m, nil
+}
+
+// NewStore creates the Store of the manifests, or returns nil if recording the outcomes of nodes is disabled. The
+// manifests are stored under the metadata prefix, like the rest of the metadata of executions.
+func NewStore(ctx context.Context, cfg config.NodeOutcomesConfig, store *storage.DataStore, metadataPrefix string) (*Store, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	basePrefix := store.GetBaseContainerFQN(ctx)
+	if metadataPrefix != "" {
+		var err error
+		basePrefix, err = store.ConstructReference(ctx, basePrefix, metadataPrefix)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Store{
+		store:      store,
+		basePrefix: basePrefix,
+		manifests:  cache.NewLRUExpireCache(manifestCacheSize),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package outcomes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.TODO()
+	dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		s, err := NewStore(ctx, config.NodeOutcomesConfig{}, dataStore, "metadata")
+		assert.NoError(t, err)
+		assert.Nil(t, s)
+	})
+
+	s, err := NewStore(ctx, config.NodeOutcomesConfig{Enabled: true}, dataStore, "metadata")
+	assert.NoError(t, err)
+
+	w := &v1alpha1.FlyteWorkflow{
+		ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "wf"},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:   v1alpha1.WorkflowPhaseFailed,
+			DataDir: "s3://bucket/wf",
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				v1alpha1.StartNodeID: {Phase: v1alpha1.NodePhaseSucceeded},
+				"n0":                 {Phase: v1alpha1.NodePhaseSucceeded},
+				"n1":                 {Phase: v1alpha1.NodePhaseRecovered, Attempts: 2},
+				"n2":                 {Phase: v1alpha1.NodePhaseFailed},
+			},
+		},
+		DataReferenceConstructor: dataStore,
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		m, err := s.Read(ctx, "p", "d", "wf")
+		assert.NoError(t, err)
+		assert.Nil(t, m)
+	})
+
+	assert.NoError(t, s.Write(ctx, w))
+	m, err := s.Read(ctx, "p", "d", "wf")
+	assert.NoError(t, err)
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "wf", m.ExecutionID)
+		assert.Equal(t, v1alpha1.WorkflowPhaseFailed, m.Phase)
+		assert.Len(t, m.Nodes, 2)
+		assert.Equal(t, v1alpha1.NodePhaseSucceeded, m.Nodes["n0"].Phase)
+		assert.Equal(t, storage.DataReference("s3://bucket/wf/n0/data/inputs.pb"), m.Nodes["n0"].InputsURI)
+		assert.Equal(t, storage.DataReference("s3://bucket/wf/n0/data/0/outputs.pb"), m.Nodes["n0"].OutputsURI)
+		assert.Equal(t, storage.DataReference("s3://bucket/wf/n1/data/2/outputs.pb"), m.Nodes["n1"].OutputsURI)
+	}
+
+	ref, err := dataStore.ConstructReference(ctx, dataStore.GetBaseContainerFQN(ctx), "metadata", "p-d-wf", manifestFile)
+	assert.NoError(t, err)
+	metadata, err := dataStore.Head(ctx, ref)
+	assert.NoError(t, err)
+	assert.True(t, metadata.Exists())
+}
@@ -0,0 +1,87 @@
+// Package phasemetrics records the time workflows and nodes spend in each of their phases, labeled by the phase they
+// left and the one they entered, along with the number of transitions by their cause. The time spent queued before
+// running, e.g., is the scheduling latency that SLOs are commonly defined on.
+package phasemetrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+const (
+	fromLabel  = "from"
+	toLabel    = "to"
+	causeLabel = "cause"
+
+	// CauseNone is the cause of transitions that are not caused by an error.
+	CauseNone = "none"
+)
+
+// The buckets of the durations in seconds, from 100ms to about 2 days.
+var durationBuckets = prometheus.ExponentialBuckets(0.1, 2, 21)
+
+// Recorder records the transitions between the phases of either workflows or nodes.
+type Recorder struct {
+	durations   *prometheus.HistogramVec
+	transitions *prometheus.CounterVec
+}
+
+// Cause returns the cause of a transition that was caused by the error, the kind of the error, or CauseNone if there
+// was no error.
+func Cause(err *core.ExecutionError) string {
+	if err == nil {
+		return CauseNone
+	}
+
+	return strings.ToLower(err.GetKind().String())
+}
+
+// Record records the transition from one phase to another. The time spent in the phase that was left is observed if
+// the times the phase was entered and left at are both known. Recording on a nil Recorder is a no-op.
+func (r *Recorder) Record(ctx context.Context, from, to fmt.Stringer, enteredAt, leftAt *metav1.Time, cause string) {
+	if r == nil || from.String() == to.String() {
+		return
+	}
+
+	r.transitions.WithLabelValues(from.String(), to.String(), cause).Inc()
+	if enteredAt == nil || leftAt == nil || enteredAt.IsZero() || leftAt.IsZero() {
+		return
+	}
+
+	d := leftAt.Sub(enteredAt.Time)
+	if d < 0 {
+		logger.Debugf(ctx, "Ignoring the negative time [%v] spent in phase [%s]", d, from)
+		return
+	}
+
+	r.durations.WithLabelValues(from.String(), to.String()).Observe(d.Seconds())
+}
+
+// NewRecorder creates a Recorder whose metrics are registered under the scope, or returns nil if recording the
+// transitions between phases is disabled.
+func NewRecorder(cfg config.PhaseMetricsConfig, scope promutils.Scope) *Recorder {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    scope.NewScopedMetricName("phase_duration_seconds"),
+		Help:    "Time spent in a phase before transitioning to the next one",
+		Buckets: durationBuckets,
+	}, []string{fromLabel, toLabel})
+	prometheus.MustRegister(durations)
+
+	return &Recorder{
+		durations:   durations,
+		transitions: scope.MustNewCounterVec("phase_transitions", "Transitions between phases by their cause", fromLabel, toLabel, causeLabel),
+	}
+}
@@ -0,0 +1,50 @@
+package phasemetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestNewRecorder_Disabled(t *testing.T) {
+	r := NewRecorder(config.PhaseMetricsConfig{}, promutils.NewTestScope())
+	assert.Nil(t, r)
+
+	// Recording on a nil recorder is a no-op
+	r.Record(context.TODO(), v1alpha1.NodePhaseQueued, v1alpha1.NodePhaseRunning, nil, nil, CauseNone)
+}
+
+func TestRecorder_Record(t *testing.T) {
+	ctx := context.TODO()
+	r := NewRecorder(config.PhaseMetricsConfig{Enabled: true}, promutils.NewTestScope())
+	queuedAt := metav1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	runningAt := metav1.NewTime(queuedAt.Add(time.Minute))
+
+	r.Record(ctx, v1alpha1.NodePhaseQueued, v1alpha1.NodePhaseRunning, &queuedAt, &runningAt, CauseNone)
+	assert.Equal(t, float64(1), testutil.ToFloat64(r.transitions.WithLabelValues("Queued", "Running", CauseNone)))
+	assert.Equal(t, 1, testutil.CollectAndCount(r.durations))
+
+	// The time spent in the phase is unknown
+	r.Record(ctx, v1alpha1.NodePhaseNotYetStarted, v1alpha1.NodePhaseQueued, nil, &queuedAt, CauseNone)
+	assert.Equal(t, float64(1), testutil.ToFloat64(r.transitions.WithLabelValues("NotYetStarted", "Queued", CauseNone)))
+	assert.Equal(t, 1, testutil.CollectAndCount(r.durations))
+
+	// Not a transition
+	r.Record(ctx, v1alpha1.NodePhaseRunning, v1alpha1.NodePhaseRunning, &runningAt, &runningAt, CauseNone)
+	assert.Equal(t, 2, testutil.CollectAndCount(r.transitions))
+
+	cause := Cause(&core.ExecutionError{Kind: core.ExecutionError_USER})
+	assert.Equal(t, "user", cause)
+	r.Record(ctx, v1alpha1.WorkflowPhaseRunning, v1alpha1.WorkflowPhaseFailing, &queuedAt, &runningAt, cause)
+	assert.Equal(t, float64(1), testutil.ToFloat64(r.transitions.WithLabelValues("Running", "Failing", "user")))
+	assert.Equal(t, 2, testutil.CollectAndCount(r.durations))
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const workflowQuarantinedKey = "quarantined"
+const workflowQuarantinedValue = "true"
+
+// workflowPanicError is returned when evaluating or aborting a workflow panicked.
+type workflowPanicError struct {
+	operation string
+	stack     []byte
+}
+
+func (e *workflowPanicError) Error() string {
+	return fmt.Sprintf("panic when %s workflow, Stack: [%s]", e.operation, string(e.stack))
+}
+
+func IsWorkflowPanic(err error) bool {
+	_, ok := err.(*workflowPanicError)
+	return ok
+}
+
+// Creates a LabelSelector requirement that ignores all workflows that have been quarantined.
+func IgnoreQuarantinedWorkflowsLabelSelectorRequirement() v1.LabelSelectorRequirement {
+	return v1.LabelSelectorRequirement{
+		Key:      workflowQuarantinedKey,
+		Operator: v1.LabelSelectorOpNotIn,
+		Values:   []string{workflowQuarantinedValue},
+	}
+}
+
+// Quarantines the workflow. Quarantined workflows are no longer evaluated, until the quarantined label is removed from
+// them, for example using kubectl.
+func SetQuarantinedLabel(w *v1alpha1.FlyteWorkflow) {
+	if w.Labels == nil {
+		w.Labels = make(map[string]string)
+	}
+	w.Labels[workflowQuarantinedKey] = workflowQuarantinedValue
+}
+
+func IsQuarantined(w *v1alpha1.FlyteWorkflow) bool {
+	return w.Labels[workflowQuarantinedKey] == workflowQuarantinedValue
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestIgnoreQuarantinedWorkflowsLabelSelectorRequirement(t *testing.T) {
+	s := &v1.LabelSelector{
+		MatchExpressions: []v1.LabelSelectorRequirement{IgnoreQuarantinedWorkflowsLabelSelectorRequirement()},
+	}
+	selector, err := v1.LabelSelectorAsSelector(s)
+	assert.NoError(t, err)
+
+	w := &v1alpha1.FlyteWorkflow{}
+	assert.True(t, selector.Matches(labels.Set(w.Labels)))
+	SetQuarantinedLabel(w)
+	assert.False(t, selector.Matches(labels.Set(w.Labels)))
+}
+
+func TestIsQuarantined(t *testing.T) {
+	w := &v1alpha1.FlyteWorkflow{}
+	assert.False(t, IsQuarantined(w))
+	SetQuarantinedLabel(w)
+	assert.True(t, IsQuarantined(w))
+	delete(w.Labels, workflowQuarantinedKey)
+	assert.False(t, IsQuarantined(w))
+}
+
+func TestIsWorkflowPanic(t *testing.T) {
+	err := &workflowPanicError{operation: "reconciling", stack: []byte("stack")}
+	assert.True(t, IsWorkflowPanic(err))
+	assert.Equal(t, "panic when reconciling workflow, Stack: [stack]", err.Error())
+	assert.False(t, IsWorkflowPanic(fmt.Errorf("error")))
+}
@@ -0,0 +1,221 @@
+// Package resilientstore retries the blob storage calls made by the node executor and fails them fast during outages of
+// the object store. Every attempt of a call is bounded by a timeout and failed attempts are retried with an exponential
+// backoff. A circuit breaker shared by all calls opens after a number of consecutive failed calls, and rejects calls
+// with ErrUnavailable until its cooldown has passed, so that nodes fail with a retryable system error rather than
+// holding up the evaluation rounds of their workflows.
+package resilientstore
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// ErrUnavailable is returned by the calls the circuit breaker rejects, and wraps the error of calls that failed on
+// every attempt.
+var ErrUnavailable = fmt.Errorf("blob storage is unavailable")
+
+// IsUnavailable returns whether the error, or any error it wraps, is caused by the blob storage being unavailable.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
+
+type unavailableError struct {
+	cause error
+}
+
+func (e unavailableError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrUnavailable, e.cause)
+}
+
+func (e unavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+func (e unavailableError) Unwrap() error {
+	return e.cause
+}
+
+type metrics struct {
+	retries  prometheus.Counter
+	failures prometheus.Counter
+	rejected prometheus.Counter
+	opened   prometheus.Counter
+}
+
+// breaker counts the consecutive failed calls. It's updated atomically, since the store is shared by all workers.
+type breaker struct {
+	threshold int64
+	cooldown  time.Duration
+	failures  int64
+	// Unix nanos the breaker was opened at, 0 while it's closed.
+	openedAt int64
+}
+
+// allow returns whether a call may be attempted. Once the cooldown of an open breaker has passed, calls are let
+// through again and the breaker closes on the first one that succeeds, or reopens on the first one that fails.
+func (b *breaker) allow(now time.Time) bool {
+	openedAt := atomic.LoadInt64(&b.openedAt)
+	return openedAt == 0 || now.Sub(time.Unix(0, openedAt)) >= b.cooldown
+}
+
+func (b *breaker) onSuccess() {
+	atomic.StoreInt64(&b.failures, 0)
+	atomic.StoreInt64(&b.openedAt, 0)
+}
+
+// onFailure records a failed call and returns whether it opened the breaker.
+func (b *breaker) onFailure(now time.Time) bool {
+	if atomic.AddInt64(&b.failures, 1) < b.threshold {
+		return false
+	}
+
+	atomic.StoreInt64(&b.openedAt, now.UnixNano())
+	return true
+}
+
+// resilientStore retries the calls the node executor makes to read and write the inputs and outputs of nodes.
+type resilientStore struct {
+	storage.ComposedProtobufStore
+	cfg     config.StoreResilienceConfig
+	breaker *breaker
+	metrics metrics
+}
+
+// isRetryable returns whether the error may be caused by the blob storage being unavailable. Missing and existing
+// objects and objects that are too large are answers of the store, that another attempt won't change.
+func isRetryable(err error) bool {
+	return !storage.IsNotFound(err) && !storage.IsExists(err) && !storage.IsExceedsLimit(err)
+}
+
+func (s resilientStore) backoff(attempt int) time.Duration {
+	backoff := s.cfg.InitialBackoff.Duration << uint(attempt)
+	if backoff <= 0 || backoff > s.cfg.MaxBackoff.Duration {
+		return s.cfg.MaxBackoff.Duration
+	}
+
+	return backoff
+}
+
+// do runs the call, retrying it until it succeeds, fails with an error that isn't retryable, or runs out of retries.
+func (s resilientStore) do(ctx context.Context, op string, reference storage.DataReference, call func(ctx context.Context) error) error {
+	if !s.breaker.allow(clocks.Now(ctx)) {
+		s.metrics.rejected.Inc()
+		return unavailableError{cause: errors.Errorf("circuit breaker is open, rejected %v of [%v]", op, reference)}
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = s.attempt(ctx, call)
+		if err == nil {
+			s.breaker.onSuccess()
+			return nil
+		}
+
+		if !isRetryable(err) {
+			// The store answered, so it's available.
+			s.breaker.onSuccess()
+			return err
+		}
+
+		if ctx.Err() != nil || attempt >= s.cfg.MaxRetries {
+			break
+		}
+
+		s.metrics.retries.Inc()
+		logger.Debugf(ctx, "Retrying %v of [%v] after failed attempt [%v]. Error: %v", op, reference, attempt, err)
+
+		select {
+		case <-ctx.Done():
+		case <-clocks.FromContext(ctx).After(s.backoff(attempt)):
+		}
+	}
+
+	if ctx.Err() != nil {
+		// The caller gave up on the call, which says nothing about the store.
+		return err
+	}
+
+	s.metrics.failures.Inc()
+	if s.breaker.onFailure(clocks.Now(ctx)) {
+		s.metrics.opened.Inc()
+		logger.Warnf(ctx, "Opened the circuit breaker of blob storage for [%v] after [%v] consecutive failed calls",
+			s.cfg.Cooldown.Duration, s.cfg.FailureThreshold)
+	}
+
+	return unavailableError{cause: errors.Wrapf(err, "%v of [%v] failed after [%v] attempts", op, reference, s.cfg.MaxRetries+1)}
+}
+
+func (s resilientStore) attempt(ctx context.Context, call func(ctx context.Context) error) error {
+	if s.cfg.Timeout.Duration <= 0 {
+		return call(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout.Duration)
+	defer cancel()
+	return call(attemptCtx)
+}
+
+func (s resilientStore) Head(ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+	var metadata storage.Metadata
+	err := s.do(ctx, "head", reference, func(ctx context.Context) (err error) {
+		metadata, err = s.ComposedProtobufStore.Head(ctx, reference)
+		return err
+	})
+
+	return metadata, err
+}
+
+func (s resilientStore) ReadProtobuf(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+	return s.do(ctx, "read", reference, func(ctx context.Context) error {
+		return s.ComposedProtobufStore.ReadProtobuf(ctx, reference, msg)
+	})
+}
+
+func (s resilientStore) WriteProtobuf(ctx context.Context, reference storage.DataReference, opts storage.Options, msg proto.Message) error {
+	return s.do(ctx, "write", reference, func(ctx context.Context) error {
+		return s.ComposedProtobufStore.WriteProtobuf(ctx, reference, opts, msg)
+	})
+}
+
+func (s resilientStore) CopyRaw(ctx context.Context, source, destination storage.DataReference, opts storage.Options) error {
+	return s.do(ctx, "copy", source, func(ctx context.Context) error {
+		return s.ComposedProtobufStore.CopyRaw(ctx, source, destination, opts)
+	})
+}
+
+// NewDataStore wraps the store so that its calls are retried and fail fast while the blob storage is unavailable. If
+// disabled, the store is returned as is.
+func NewDataStore(store *storage.DataStore, cfg config.StoreResilienceConfig, scope promutils.Scope) *storage.DataStore {
+	if !cfg.Enabled {
+		return store
+	}
+
+	threshold := int64(cfg.FailureThreshold)
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	return storage.NewCompositeDataStore(store.ReferenceConstructor, resilientStore{
+		ComposedProtobufStore: store.ComposedProtobufStore,
+		cfg:                   cfg,
+		breaker:               &breaker{threshold: threshold, cooldown: cfg.Cooldown.Duration},
+		metrics: metrics{
+			retries:  scope.MustNewCounter("retries", "Retried attempts of blob storage calls"),
+			failures: scope.MustNewCounter("failures", "Blob storage calls that failed on every attempt"),
+			rejected: scope.MustNewCounter("rejected", "Blob storage calls rejected by the open circuit breaker"),
+			opened:   scope.MustNewCounter("breaker_opened", "Times the circuit breaker of blob storage opened"),
+		},
+	})
+}
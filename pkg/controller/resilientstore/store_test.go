@@ -0,0 +1,99 @@
+package resilientstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/clocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// flakyStore fails the reads of protobufs with the error until it's reset.
+type flakyStore struct {
+	storage.ComposedProtobufStore
+	err   error
+	reads *int
+}
+
+func (s flakyStore) ReadProtobuf(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+	*s.reads++
+	return s.err
+}
+
+func newFlakyStore(t *testing.T, err error) (*storage.DataStore, *int) {
+	store, e := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, e)
+	reads := 0
+	return storage.NewCompositeDataStore(store.ReferenceConstructor, flakyStore{
+		ComposedProtobufStore: store.ComposedProtobufStore,
+		err:                   err,
+		reads:                 &reads,
+	}), &reads
+}
+
+func TestNewDataStore_Disabled(t *testing.T) {
+	store, _ := newFlakyStore(t, nil)
+	assert.Equal(t, store, NewDataStore(store, config.StoreResilienceConfig{}, promutils.NewTestScope()))
+}
+
+func TestNewDataStore(t *testing.T) {
+	cfg := config.StoreResilienceConfig{
+		Enabled:          true,
+		MaxRetries:       2,
+		FailureThreshold: 2,
+		Cooldown:         config.Duration{Duration: time.Minute},
+	}
+
+	t.Run("retries", func(t *testing.T) {
+		flaky, reads := newFlakyStore(t, fmt.Errorf("connection reset"))
+		store := NewDataStore(flaky, cfg, promutils.NewTestScope())
+
+		err := store.ReadProtobuf(context.TODO(), "s3://bucket/key", &core.Identifier{})
+		assert.True(t, IsUnavailable(err))
+		assert.Equal(t, 3, *reads)
+		assert.Equal(t, float64(2), testutil.ToFloat64(store.ComposedProtobufStore.(resilientStore).metrics.retries))
+	})
+
+	t.Run("passes through", func(t *testing.T) {
+		flaky, reads := newFlakyStore(t, nil)
+		store := NewDataStore(flaky, cfg, promutils.NewTestScope())
+		assert.NoError(t, store.WriteProtobuf(context.TODO(), "s3://bucket/key", storage.Options{}, &core.Identifier{Name: "x"}))
+		m, err := store.Head(context.TODO(), "s3://bucket/key")
+		assert.NoError(t, err)
+		assert.True(t, m.Exists())
+		assert.NoError(t, store.ReadProtobuf(context.TODO(), "s3://bucket/key", &core.Identifier{}))
+		assert.Equal(t, 1, *reads)
+	})
+
+	t.Run("circuit breaker", func(t *testing.T) {
+		fakeClock := clock.NewFakeClock(time.Now())
+		ctx := clocks.WithClock(context.TODO(), fakeClock)
+		flaky, reads := newFlakyStore(t, fmt.Errorf("connection reset"))
+		noRetries := cfg
+		noRetries.MaxRetries = 0
+		store := NewDataStore(flaky, noRetries, promutils.NewTestScope())
+
+		for i := 0; i < 2; i++ {
+			assert.True(t, IsUnavailable(store.ReadProtobuf(ctx, "s3://bucket/key", &core.Identifier{})))
+		}
+		assert.Equal(t, 2, *reads)
+
+		// The breaker is open, so the store isn't called.
+		assert.True(t, IsUnavailable(store.ReadProtobuf(ctx, "s3://bucket/key", &core.Identifier{})))
+		assert.Equal(t, 2, *reads)
+
+		fakeClock.Step(time.Minute)
+		assert.True(t, IsUnavailable(store.ReadProtobuf(ctx, "s3://bucket/key", &core.Identifier{})))
+		assert.Equal(t, 3, *reads)
+	})
+}
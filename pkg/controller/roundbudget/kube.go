@@ -0,0 +1,57 @@
+package roundbudget
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+)
+
+// budgetedWriter counts the writes to the kube api towards the budget of the round they are made in.
+type budgetedWriter struct {
+	client.Client
+}
+
+func (w budgetedWriter) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	RecordKubeWrite(ctx)
+	return w.Client.Create(ctx, obj, opts...)
+}
+
+func (w budgetedWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	RecordKubeWrite(ctx)
+	return w.Client.Update(ctx, obj, opts...)
+}
+
+func (w budgetedWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	RecordKubeWrite(ctx)
+	return w.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (w budgetedWriter) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	RecordKubeWrite(ctx)
+	return w.Client.Delete(ctx, obj, opts...)
+}
+
+func (w budgetedWriter) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	RecordKubeWrite(ctx)
+	return w.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+type budgetedKubeClient struct {
+	client executors.Client
+}
+
+func (c budgetedKubeClient) GetClient() client.Client {
+	return budgetedWriter{Client: c.client.GetClient()}
+}
+
+func (c budgetedKubeClient) GetCache() cache.Cache {
+	return c.client.GetCache()
+}
+
+// NewKubeClient wraps the kube client so that its writes are counted towards the budget of rounds.
+func NewKubeClient(kubeClient executors.Client) executors.Client {
+	return budgetedKubeClient{client: kubeClient}
+}
@@ -0,0 +1,89 @@
+// Package roundbudget caps the blob storage reads and kube writes of a single evaluation round of a workflow. The budget
+// of a round is carried by its context. The data store and kube client used by the node executor count the calls made
+// on behalf of the round, and once the budget is used up the node executor leaves the remaining nodes for the next round,
+// so that a single enormous workflow can't monopolize the shared client rate limits and a worker.
+package roundbudget
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type contextKey struct{}
+
+// budget of a single round. It's updated atomically, since the ready nodes of a workflow may be evaluated concurrently
+// within a round.
+type budget struct {
+	maxBlobReads  int64
+	maxKubeWrites int64
+	blobReads     int64
+	kubeWrites    int64
+	exhausted     int32
+	onExhausted   prometheus.Counter
+}
+
+func (b *budget) record(count *int64, limit int64) {
+	if atomic.AddInt64(count, 1) == limit && atomic.CompareAndSwapInt32(&b.exhausted, 0, 1) {
+		b.onExhausted.Inc()
+	}
+}
+
+// Budgets hands out the budget of every round.
+type Budgets struct {
+	maxBlobReads    int64
+	maxKubeWrites   int64
+	exhaustedRounds prometheus.Counter
+}
+
+// WithBudget returns a context that carries a fresh budget for a round of a workflow. If the budgets are nil, the
+// context is returned as is, which leaves the round uncapped.
+func (b *Budgets) WithBudget(ctx context.Context) context.Context {
+	if b == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey{}, &budget{
+		maxBlobReads:  b.maxBlobReads,
+		maxKubeWrites: b.maxKubeWrites,
+		onExhausted:   b.exhaustedRounds,
+	})
+}
+
+// RecordBlobRead counts a blob storage read towards the budget of the round the context belongs to, if any.
+func RecordBlobRead(ctx context.Context) {
+	if b, ok := ctx.Value(contextKey{}).(*budget); ok && b.maxBlobReads > 0 {
+		b.record(&b.blobReads, b.maxBlobReads)
+	}
+}
+
+// RecordKubeWrite counts a write to the kube api towards the budget of the round the context belongs to, if any.
+func RecordKubeWrite(ctx context.Context) {
+	if b, ok := ctx.Value(contextKey{}).(*budget); ok && b.maxKubeWrites > 0 {
+		b.record(&b.kubeWrites, b.maxKubeWrites)
+	}
+}
+
+// IsExhausted returns whether the round the context belongs to used up its budget and should yield.
+func IsExhausted(ctx context.Context) bool {
+	b, ok := ctx.Value(contextKey{}).(*budget)
+	return ok && atomic.LoadInt32(&b.exhausted) == 1
+}
+
+// NewBudgets creates the Budgets for rounds, or returns nil if neither blob reads nor kube writes are capped.
+func NewBudgets(cfg config.RoundBudgetConfig, scope promutils.Scope) *Budgets {
+	if cfg.MaxBlobReads <= 0 && cfg.MaxKubeWrites <= 0 {
+		return nil
+	}
+
+	return &Budgets{
+		maxBlobReads:  cfg.MaxBlobReads,
+		maxKubeWrites: cfg.MaxKubeWrites,
+		exhaustedRounds: scope.MustNewCounter("exhausted_rounds", "Rounds that used up their budget of blob reads"+
+			" or kube writes and yielded"),
+	}
+}
@@ -0,0 +1,107 @@
+package roundbudget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
+)
+
+func TestNewBudgets_Disabled(t *testing.T) {
+	budgets := NewBudgets(config.RoundBudgetConfig{}, promutils.NewTestScope())
+	assert.Nil(t, budgets)
+
+	ctx := budgets.WithBudget(context.TODO())
+	for i := 0; i < 10; i++ {
+		RecordBlobRead(ctx)
+		RecordKubeWrite(ctx)
+	}
+	assert.False(t, IsExhausted(ctx))
+}
+
+func TestBudgets_WithBudget(t *testing.T) {
+	budgets := NewBudgets(config.RoundBudgetConfig{MaxBlobReads: 2, MaxKubeWrites: 1}, promutils.NewTestScope())
+
+	t.Run("blob reads", func(t *testing.T) {
+		ctx := budgets.WithBudget(context.TODO())
+		RecordBlobRead(ctx)
+		assert.False(t, IsExhausted(ctx))
+		RecordBlobRead(ctx)
+		assert.True(t, IsExhausted(ctx))
+	})
+
+	t.Run("kube writes", func(t *testing.T) {
+		ctx := budgets.WithBudget(context.TODO())
+		RecordKubeWrite(ctx)
+		assert.True(t, IsExhausted(ctx))
+		RecordKubeWrite(ctx)
+		assert.True(t, IsExhausted(ctx))
+	})
+
+	t.Run("fresh budget every round", func(t *testing.T) {
+		assert.False(t, IsExhausted(budgets.WithBudget(context.TODO())))
+	})
+
+	t.Run("no round", func(t *testing.T) {
+		ctx := context.TODO()
+		RecordKubeWrite(ctx)
+		assert.False(t, IsExhausted(ctx))
+	})
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(budgets.exhaustedRounds))
+}
+
+func TestNewBudgets_Uncapped(t *testing.T) {
+	budgets := NewBudgets(config.RoundBudgetConfig{MaxKubeWrites: 1}, promutils.NewTestScope())
+	ctx := budgets.WithBudget(context.TODO())
+	for i := 0; i < 10; i++ {
+		RecordBlobRead(ctx)
+	}
+	assert.False(t, IsExhausted(ctx))
+}
+
+func TestNewDataStore(t *testing.T) {
+	ctx := context.TODO()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	ref := storage.DataReference("s3://bucket/key")
+	assert.NoError(t, store.WriteProtobuf(ctx, ref, storage.Options{}, &core.Identifier{Name: "x"}))
+
+	budgetedStore := NewDataStore(store)
+	budgets := NewBudgets(config.RoundBudgetConfig{MaxBlobReads: 2}, promutils.NewTestScope())
+	roundCtx := budgets.WithBudget(ctx)
+
+	assert.NoError(t, budgetedStore.WriteProtobuf(roundCtx, ref, storage.Options{}, &core.Identifier{Name: "x"}))
+	assert.NoError(t, budgetedStore.ReadProtobuf(roundCtx, ref, &core.Identifier{}))
+	assert.False(t, IsExhausted(roundCtx))
+
+	r, err := budgetedStore.ReadRaw(roundCtx, ref)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.True(t, IsExhausted(roundCtx))
+}
+
+func TestNewKubeClient(t *testing.T) {
+	ctx := context.TODO()
+	kubeClient := NewKubeClient(mocks.NewFakeKubeClient())
+	budgets := NewBudgets(config.RoundBudgetConfig{MaxKubeWrites: 2}, promutils.NewTestScope())
+	roundCtx := budgets.WithBudget(ctx)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"}}
+	assert.NoError(t, kubeClient.GetClient().Create(roundCtx, pod))
+	assert.NoError(t, kubeClient.GetClient().Get(roundCtx, client.ObjectKeyFromObject(pod), &v1.Pod{}))
+	assert.False(t, IsExhausted(roundCtx))
+
+	assert.NoError(t, kubeClient.GetClient().Delete(roundCtx, pod))
+	assert.True(t, IsExhausted(roundCtx))
+}
@@ -0,0 +1,29 @@
+package roundbudget
+
+import (
+	"context"
+	"io"
+
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+)
+
+// budgetedStore counts the reads from blob storage towards the budget of the round they are made in.
+type budgetedStore struct {
+	storage.ComposedProtobufStore
+}
+
+func (s budgetedStore) ReadRaw(ctx context.Context, reference storage.DataReference) (io.ReadCloser, error) {
+	RecordBlobRead(ctx)
+	return s.ComposedProtobufStore.ReadRaw(ctx, reference)
+}
+
+func (s budgetedStore) ReadProtobuf(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+	RecordBlobRead(ctx)
+	return s.ComposedProtobufStore.ReadProtobuf(ctx, reference, msg)
+}
+
+// NewDataStore wraps the store so that its reads are counted towards the budget of rounds.
+func NewDataStore(store *storage.DataStore) *storage.DataStore {
+	return storage.NewCompositeDataStore(store.ReferenceConstructor, budgetedStore{ComposedProtobufStore: store.ComposedProtobufStore})
+}
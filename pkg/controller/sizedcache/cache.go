@@ -0,0 +1,152 @@
+// Package sizedcache provides an LRU cache whose entries expire, bounded by both the number of its entries and their
+// estimated size in memory. The in-memory caches of propeller hold structures whose size varies by orders of magnitude
+// between workflows, so a bound on the number of entries alone does not bound the memory they take up.
+package sizedcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+type cacheMetrics struct {
+	evictions   prometheus.Counter
+	expirations prometheus.Counter
+	sizeBytes   prometheus.Gauge
+	entries     prometheus.Gauge
+}
+
+type cacheEntry struct {
+	key       interface{}
+	value     interface{}
+	sizeBytes int64
+	expiresAt time.Time
+}
+
+// Cache is an LRU cache whose entries expire after a TTL. Once it holds more than the max number of entries, or its
+// entries take up more than the max size, the least recently used entries are evicted. The size of an entry is
+// estimated by the caller when the entry is added.
+type Cache struct {
+	maxEntries int
+	maxBytes   int64
+	clk        clock.Clock
+	metrics    *cacheMetrics
+
+	lock sync.Mutex
+	// The entries, most recently used first.
+	lru       *list.List
+	entries   map[interface{}]*list.Element
+	sizeBytes int64
+}
+
+// Get returns the value of the entry with the given key, unless there is none or it expired.
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if c.clk.Now().After(e.Value.(*cacheEntry).expiresAt) {
+		c.remove(e)
+		c.metrics.expirations.Inc()
+		return nil, false
+	}
+
+	c.lru.MoveToFront(e)
+	return e.Value.(*cacheEntry).value, true
+}
+
+// Add adds the value with its estimated size, replacing the entry with the same key, and evicts the least recently used
+// entries that no longer fit. A value larger than the max size of the cache is not added.
+func (c *Cache) Add(key interface{}, value interface{}, sizeBytes int64, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+
+	if c.maxBytes > 0 && sizeBytes > c.maxBytes {
+		c.metrics.evictions.Inc()
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key:       key,
+		value:     value,
+		sizeBytes: sizeBytes,
+		expiresAt: c.clk.Now().Add(ttl),
+	})
+	c.sizeBytes += sizeBytes
+
+	for c.lru.Len() > 0 && ((c.maxEntries > 0 && c.lru.Len() > c.maxEntries) || (c.maxBytes > 0 && c.sizeBytes > c.maxBytes)) {
+		c.remove(c.lru.Back())
+		c.metrics.evictions.Inc()
+	}
+
+	c.updateGauges()
+}
+
+// Remove removes the entry with the given key, if any.
+func (c *Cache) Remove(key interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Len returns the number of entries in the cache, including the ones that expired but were not removed yet.
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lru.Len()
+}
+
+// SizeBytes returns the estimated size of the entries in the cache.
+func (c *Cache) SizeBytes() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.sizeBytes
+}
+
+// Must be called with the lock held.
+func (c *Cache) remove(e *list.Element) {
+	entry := c.lru.Remove(e).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.sizeBytes -= entry.sizeBytes
+	c.updateGauges()
+}
+
+func (c *Cache) updateGauges() {
+	c.metrics.sizeBytes.Set(float64(c.sizeBytes))
+	c.metrics.entries.Set(float64(c.lru.Len()))
+}
+
+// New creates a Cache that holds up to maxEntries entries of up to maxBytes in total. A max of 0 leaves the cache
+// unbounded in that dimension.
+func New(maxEntries int, maxBytes int64, clk clock.Clock, scope promutils.Scope) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		clk:        clk,
+		metrics: &cacheMetrics{
+			evictions:   scope.MustNewCounter("evictions", "Number of entries evicted to stay within the max number of entries or max size"),
+			expirations: scope.MustNewCounter("expirations", "Number of entries removed after their TTL expired"),
+			sizeBytes:   scope.MustNewGauge("size_bytes", "Estimated size in bytes of the entries in the cache"),
+			entries:     scope.MustNewGauge("entries", "Number of entries in the cache"),
+		},
+		lru:     list.New(),
+		entries: map[interface{}]*list.Element{},
+	}
+}
@@ -0,0 +1,76 @@
+package sizedcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+
+	t.Run("evicts by size", func(t *testing.T) {
+		c := New(10, 100, clock.NewFakeClock(now), promutils.NewTestScope())
+		c.Add("a", 1, 40, time.Hour)
+		c.Add("b", 2, 40, time.Hour)
+		_, ok := c.Get("a")
+		assert.True(t, ok)
+
+		// b is the least recently used entry
+		c.Add("c", 3, 40, time.Hour)
+		_, ok = c.Get("b")
+		assert.False(t, ok)
+		v, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+		assert.Equal(t, int64(80), c.SizeBytes())
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("evicts by number of entries", func(t *testing.T) {
+		c := New(2, 0, clock.NewFakeClock(now), promutils.NewTestScope())
+		c.Add("a", 1, 1000, time.Hour)
+		c.Add("b", 2, 1000, time.Hour)
+		c.Add("c", 3, 1000, time.Hour)
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 2, c.Len())
+		assert.Equal(t, int64(2000), c.SizeBytes())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		c := New(10, 100, clock.NewFakeClock(now), promutils.NewTestScope())
+		c.Add("a", 1, 40, time.Hour)
+		c.Add("a", 2, 200, time.Hour)
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, int64(0), c.SizeBytes())
+	})
+
+	t.Run("replaces", func(t *testing.T) {
+		c := New(10, 100, clock.NewFakeClock(now), promutils.NewTestScope())
+		c.Add("a", 1, 40, time.Hour)
+		c.Add("a", 2, 60, time.Hour)
+		v, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, v)
+		assert.Equal(t, int64(60), c.SizeBytes())
+
+		c.Remove("a")
+		assert.Equal(t, 0, c.Len())
+		assert.Equal(t, int64(0), c.SizeBytes())
+	})
+
+	t.Run("expires", func(t *testing.T) {
+		fakeClock := clock.NewFakeClock(now)
+		c := New(10, 100, fakeClock, promutils.NewTestScope())
+		c.Add("a", 1, 40, time.Minute)
+		fakeClock.Step(2 * time.Minute)
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, int64(0), c.SizeBytes())
+	})
+}
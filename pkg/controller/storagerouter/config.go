@@ -0,0 +1,78 @@
+package storagerouter
+
+import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	ctrlConfig "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// Category identifies a category of data that can be stored in a dedicated storage backend.
+type Category = string
+
+const (
+	// CategoryRawData is the data produced and consumed by user code, referenced from the raw output prefix.
+	CategoryRawData Category = "raw-data"
+	// CategoryArchive is long lived data like archived executions and decks.
+	CategoryArchive Category = "archive"
+)
+
+var (
+	defaultConfig = &Config{
+		HTTPClient: HTTPClientConfig{
+			Enabled:             false,
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 100,
+			IdleConnTimeout:     config.Duration{Duration: 90 * time.Second},
+			DialTimeout:         config.Duration{Duration: 30 * time.Second},
+			KeepAlive:           config.Duration{Duration: 30 * time.Second},
+		},
+	}
+
+	configSection = ctrlConfig.MustRegisterSubSection("storage-router", defaultConfig)
+)
+
+// Route configures the storage backend used for all references that start with the given prefix.
+type Route struct {
+	// Prefix of the references served by this backend, e.g. s3://my-raw-data-bucket
+	Prefix string `json:"prefix"`
+	// Storage configuration of the backend, including its own credentials.
+	Storage storage.Config `json:"storage"`
+}
+
+// Config for routing data references to storage backends. Metadata (inputs and outputs protobufs) is always stored in
+// the backend configured through the top level storage section, which is also used for references that don't match
+// any route.
+type Config struct {
+	Routes     map[Category]Route `json:"routes,omitempty" pflag:"-,Dedicated storage backends keyed by data category."`
+	HTTPClient HTTPClientConfig   `json:"http-client,omitempty" pflag:"-,Tunes the HTTP connections to the storage backends."`
+}
+
+// HTTPClientConfig tunes the HTTP transport the storage backends are reached with, to stabilize performance when they
+// are accessed through proxies. The S3 and GCS clients use the default HTTP transport of the process, which is replaced
+// with the tuned transport if enabled.
+type HTTPClientConfig struct {
+	// Enables the tuned transport. If disabled, the default transport of the Go runtime is used.
+	Enabled bool `json:"enabled"`
+	// Maximum number of idle connections across all hosts.
+	MaxIdleConns int `json:"max-idle-conns"`
+	// Maximum number of idle connections kept per host. Set this as high as the number of concurrent requests to a
+	// proxy, the default of the Go runtime only keeps 2 idle connections per host.
+	MaxIdleConnsPerHost int `json:"max-idle-conns-per-host"`
+	// Maximum number of connections per host, including connections in use. 0 means no limit.
+	MaxConnsPerHost int `json:"max-conns-per-host"`
+	// Time after which idle connections are closed.
+	IdleConnTimeout config.Duration `json:"idle-conn-timeout"`
+	// Timeout for establishing new connections.
+	DialTimeout config.Duration `json:"dial-timeout"`
+	// Interval of TCP keep-alive probes on open connections. A negative value disables keep-alive probes.
+	KeepAlive config.Duration `json:"keep-alive"`
+	// Time for which resolved addresses of hosts are cached. 0 disables caching and resolves every new connection.
+	DNSCacheTTL config.Duration `json:"dns-cache-ttl"`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
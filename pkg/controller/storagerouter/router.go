@@ -0,0 +1,135 @@
+// Package storagerouter provides a data store that serves categories of data, like metadata and raw data, from different
+// storage backends. Each reference is resolved to a backend by its prefix.
+package storagerouter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/pkg/errors"
+)
+
+type route struct {
+	prefix string
+	store  storage.RawStore
+}
+
+// router implements storage.RawStore by forwarding every call to the backend that serves the reference.
+type router struct {
+	defaultStore storage.RawStore
+	// routes sorted by descending prefix length so that the most specific prefix matches first
+	routes []route
+}
+
+func (r router) resolve(reference storage.DataReference) storage.RawStore {
+	for _, rt := range r.routes {
+		if strings.HasPrefix(string(reference), rt.prefix) {
+			return rt.store
+		}
+	}
+
+	return r.defaultStore
+}
+
+func (r router) GetBaseContainerFQN(ctx context.Context) storage.DataReference {
+	return r.defaultStore.GetBaseContainerFQN(ctx)
+}
+
+func (r router) CreateSignedURL(ctx context.Context, reference storage.DataReference, properties storage.SignedURLProperties) (storage.SignedURLResponse, error) {
+	return r.resolve(reference).CreateSignedURL(ctx, reference, properties)
+}
+
+func (r router) Head(ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+	return r.resolve(reference).Head(ctx, reference)
+}
+
+func (r router) ReadRaw(ctx context.Context, reference storage.DataReference) (io.ReadCloser, error) {
+	return r.resolve(reference).ReadRaw(ctx, reference)
+}
+
+func (r router) WriteRaw(ctx context.Context, reference storage.DataReference, size int64, opts storage.Options, raw io.Reader) error {
+	return r.resolve(reference).WriteRaw(ctx, reference, size, opts, raw)
+}
+
+// CopyRaw copies within a backend if both references are served by the same one, otherwise it streams the data from
+// the source to the destination backend.
+func (r router) CopyRaw(ctx context.Context, source, destination storage.DataReference, opts storage.Options) error {
+	sourceStore := r.resolve(source)
+	destinationStore := r.resolve(destination)
+	if sourceStore == destinationStore {
+		return sourceStore.CopyRaw(ctx, source, destination, opts)
+	}
+
+	metadata, err := sourceStore.Head(ctx, source)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get metadata of [%v]", source)
+	}
+
+	rc, err := sourceStore.ReadRaw(ctx, source)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read [%v]", source)
+	}
+
+	defer func() {
+		if err := rc.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close reader for [%v]. Error: %v", source, err)
+		}
+	}()
+
+	return destinationStore.WriteRaw(ctx, destination, metadata.Size(), opts, rc)
+}
+
+// NewDataStore creates the data store used by propeller. Metadata and all references that don't match a configured
+// route are served by the backend configured in metadataCfg. If no routes are configured, the metadata store is
+// returned as is.
+//
+// If enabled, the tuned HTTP transport is installed as the default transport of the process before the backends are
+// created, as that is the transport their clients use.
+func NewDataStore(ctx context.Context, metadataCfg *storage.Config, cfg *Config, scope promutils.Scope) (*storage.DataStore, error) {
+	if cfg != nil && cfg.HTTPClient.Enabled {
+		logger.Infof(ctx, "Reaching storage backends with a tuned HTTP transport, max idle connections per host [%v]",
+			cfg.HTTPClient.MaxIdleConnsPerHost)
+		http.DefaultTransport = newTransport(cfg.HTTPClient, scope.NewSubScope("http"))
+	}
+
+	metadataStore, err := storage.NewDataStore(metadataCfg, scope.NewSubScope("metastore"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create metadata storage")
+	}
+
+	if cfg == nil || len(cfg.Routes) == 0 {
+		return metadataStore, nil
+	}
+
+	r := router{
+		defaultStore: metadataStore,
+		routes:       make([]route, 0, len(cfg.Routes)),
+	}
+
+	for category, rt := range cfg.Routes {
+		if len(rt.Prefix) == 0 {
+			return nil, errors.Errorf("storage route for category [%v] has no prefix", category)
+		}
+
+		rtCfg := rt.Storage
+		store, err := storage.NewDataStore(&rtCfg, scope.NewSubScope(strings.ReplaceAll(category, "-", "_")))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create storage for category [%v]", category)
+		}
+
+		logger.Infof(ctx, "Routing references with prefix [%v] to the [%v] storage backend", rt.Prefix, category)
+		r.routes = append(r.routes, route{prefix: rt.Prefix, store: store})
+	}
+
+	sort.Slice(r.routes, func(i, j int) bool {
+		return len(r.routes[i].prefix) > len(r.routes[j].prefix)
+	})
+
+	return storage.NewCompositeDataStore(storage.URLPathConstructor{}, storage.NewDefaultProtobufStore(r, scope.NewSubScope("router"))), nil
+}
@@ -0,0 +1,87 @@
+package storagerouter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMemStore(t *testing.T) *storage.DataStore {
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return store
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.TODO()
+	metadataStore := newMemStore(t)
+	rawStore := newMemStore(t)
+	r := router{
+		defaultStore: metadataStore,
+		routes:       []route{{prefix: "s3://raw", store: rawStore}},
+	}
+
+	raw := []byte("hello")
+	t.Run("write routed", func(t *testing.T) {
+		assert.NoError(t, r.WriteRaw(ctx, "s3://raw/a", int64(len(raw)), storage.Options{}, bytes.NewReader(raw)))
+
+		m, err := rawStore.Head(ctx, "s3://raw/a")
+		assert.NoError(t, err)
+		assert.True(t, m.Exists())
+
+		m, err = metadataStore.Head(ctx, "s3://raw/a")
+		assert.NoError(t, err)
+		assert.False(t, m.Exists())
+	})
+
+	t.Run("copy across backends", func(t *testing.T) {
+		assert.NoError(t, r.CopyRaw(ctx, "s3://raw/a", "s3://metadata/a", storage.Options{}))
+
+		m, err := metadataStore.Head(ctx, "s3://metadata/a")
+		assert.NoError(t, err)
+		assert.True(t, m.Exists())
+	})
+
+	t.Run("copy within backend", func(t *testing.T) {
+		assert.NoError(t, r.CopyRaw(ctx, "s3://raw/a", "s3://raw/b", storage.Options{}))
+
+		m, err := rawStore.Head(ctx, "s3://raw/b")
+		assert.NoError(t, err)
+		assert.True(t, m.Exists())
+	})
+}
+
+func TestNewDataStore(t *testing.T) {
+	ctx := context.TODO()
+	metadataCfg := &storage.Config{Type: storage.TypeMemory}
+
+	t.Run("no routes", func(t *testing.T) {
+		store, err := NewDataStore(ctx, metadataCfg, &Config{}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NotNil(t, store)
+	})
+
+	t.Run("routes", func(t *testing.T) {
+		store, err := NewDataStore(ctx, metadataCfg, &Config{
+			Routes: map[Category]Route{
+				CategoryRawData: {Prefix: "s3://raw", Storage: storage.Config{Type: storage.TypeMemory}},
+			},
+		}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, store.WriteProtobuf(ctx, "s3://raw/a", storage.Options{}, &core.LiteralMap{}))
+	})
+
+	t.Run("missing prefix", func(t *testing.T) {
+		_, err := NewDataStore(ctx, metadataCfg, &Config{
+			Routes: map[Category]Route{
+				CategoryArchive: {Storage: storage.Config{Type: storage.TypeMemory}},
+			},
+		}, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+}
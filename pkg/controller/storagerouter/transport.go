@@ -0,0 +1,151 @@
+package storagerouter
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type resolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+type dnsCacheEntry struct {
+	addrs     []string
+	expiresAt time.Time
+}
+
+// dnsCache caches the resolved addresses of hosts, so that new connections to the storage backends, which are opened
+// frequently when a proxy closes idle connections, do not have to wait for DNS.
+type dnsCache struct {
+	resolver resolver
+	ttl      time.Duration
+	lock     sync.Mutex
+	entries  map[string]dnsCacheEntry
+	now      func() time.Time
+}
+
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[host]
+	c.lock.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.entries[host] = dnsCacheEntry{addrs: addrs, expiresAt: c.now().Add(c.ttl)}
+	c.lock.Unlock()
+	return addrs, nil
+}
+
+func (c *dnsCache) invalidate(host string) {
+	c.lock.Lock()
+	delete(c.entries, host)
+	c.lock.Unlock()
+}
+
+// Returns a dial function that connects to the cached addresses of a host, trying them in order. If none of them can be
+// connected to, the host is resolved again on the next dial.
+func (c *dnsCache) dialContext(dial func(ctx context.Context, network, address string) (net.Conn, error)) func(
+	ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		if net.ParseIP(host) != nil {
+			return dial(ctx, network, address)
+		}
+
+		addrs, err := c.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var lastErr error
+		for _, addr := range addrs {
+			conn, err := dial(ctx, network, net.JoinHostPort(addr, port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+
+		c.invalidate(host)
+		if lastErr == nil {
+			lastErr = errors.Errorf("no addresses found for host [%v]", host)
+		}
+		return nil, lastErr
+	}
+}
+
+func newDNSCache(r resolver, ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		resolver: r,
+		ttl:      ttl,
+		entries:  map[string]dnsCacheEntry{},
+		now:      time.Now,
+	}
+}
+
+// instrumentedTransport records the latency and the errors of requests per storage endpoint.
+type instrumentedTransport struct {
+	transport http.RoundTripper
+	latency   *prometheus.SummaryVec
+	errors    *prometheus.CounterVec
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	t.latency.WithLabelValues(req.URL.Host, req.Method).Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		t.errors.WithLabelValues(req.URL.Host, req.Method).Inc()
+	}
+
+	return resp, err
+}
+
+// newTransport creates the HTTP transport the storage backends are reached with.
+func newTransport(cfg HTTPClientConfig, scope promutils.Scope) http.RoundTripper {
+	dialer := &net.Dialer{
+		Timeout:   cfg.DialTimeout.Duration,
+		KeepAlive: cfg.KeepAlive.Duration,
+	}
+
+	dial := dialer.DialContext
+	if cfg.DNSCacheTTL.Duration > 0 {
+		dial = newDNSCache(net.DefaultResolver, cfg.DNSCacheTTL.Duration).dialContext(dialer.DialContext)
+	}
+
+	return &instrumentedTransport{
+		transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dial,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          cfg.MaxIdleConns,
+			MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+			MaxConnsPerHost:       cfg.MaxConnsPerHost,
+			IdleConnTimeout:       cfg.IdleConnTimeout.Duration,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		latency: scope.MustNewSummaryVec("request_latency_ms",
+			"Latency of requests to the storage backends in milliseconds", "endpoint", "method"),
+		errors: scope.MustNewCounterVec("request_errors",
+			"Number of requests to the storage backends that failed without a response", "endpoint", "method"),
+	}
+}
@@ -0,0 +1,108 @@
+package storagerouter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingResolver struct {
+	addrs   []string
+	lookups int
+}
+
+func (r *countingResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	r.lookups++
+	if len(r.addrs) == 0 {
+		return nil, fmt.Errorf("unknown host [%v]", host)
+	}
+	return r.addrs, nil
+}
+
+func TestDNSCache(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	r := &countingResolver{addrs: []string{"10.0.0.1", "10.0.0.2"}}
+	c := newDNSCache(r, time.Minute)
+	c.now = func() time.Time { return now }
+
+	var dialed []string
+	reachable := map[string]bool{"10.0.0.2:443": true}
+	dial := c.dialContext(func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+		if !reachable[address] {
+			return nil, fmt.Errorf("unreachable [%v]", address)
+		}
+		conn, _ := net.Pipe()
+		return conn, nil
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		conn, err := dial(ctx, "tcp", "minio:443")
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Close())
+		assert.Equal(t, []string{"10.0.0.1:443", "10.0.0.2:443"}, dialed)
+
+		_, err = dial(ctx, "tcp", "minio:443")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, r.lookups)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		_, err := dial(ctx, "tcp", "minio:443")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, r.lookups)
+	})
+
+	t.Run("unreachable addresses are resolved again", func(t *testing.T) {
+		reachable = map[string]bool{}
+		_, err := dial(ctx, "tcp", "minio:443")
+		assert.Error(t, err)
+
+		reachable = map[string]bool{"10.0.0.1:443": true}
+		_, err = dial(ctx, "tcp", "minio:443")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, r.lookups)
+	})
+
+	t.Run("ip addresses are dialed directly", func(t *testing.T) {
+		reachable = map[string]bool{"10.0.0.3:443": true}
+		_, err := dial(ctx, "tcp", "10.0.0.3:443")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, r.lookups)
+	})
+}
+
+func TestNewTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newTransport(HTTPClientConfig{
+		Enabled:             true,
+		MaxIdleConnsPerHost: 10,
+		DialTimeout:         config.Duration{Duration: time.Second},
+		DNSCacheTTL:         config.Duration{Duration: time.Minute},
+	}, promutils.NewTestScope())
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	instrumented := transport.(*instrumentedTransport)
+	assert.Equal(t, 1, testutil.CollectAndCount(instrumented.latency))
+	assert.Equal(t, 0, testutil.CollectAndCount(instrumented.errors))
+}
@@ -0,0 +1,121 @@
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+const (
+	instrumentationName = "github.com/flyteorg/flytepropeller"
+	serviceName         = "flytepropeller"
+
+	// ExecutionIDKey is the attribute of the spans of a round that holds the ID of the execution the round evaluates.
+	ExecutionIDKey = attribute.Key("flyte.execution_id")
+)
+
+// InitOpenTelemetry registers the tracer provider that exports the spans of evaluation rounds via OTLP. If exporting
+// spans is disabled, the default no-op provider is left in place and spans cost next to nothing. The returned function
+// flushes the spans not exported yet and must be called on shutdown.
+func InitOpenTelemetry(ctx context.Context, cfg config.OpenTelemetryConfig) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	logger.Infof(ctx, "Exporting OpenTelemetry spans to [%s]", cfg.Endpoint)
+	return provider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(instrumentationName)
+}
+
+// Returns the span context that the rounds of the execution link to. It's derived from the ID of the execution, so that
+// the rounds of an execution, each of which is a trace of its own, can be found from one another, even across restarts
+// of propeller.
+func executionSpanContext(executionID string) trace.SpanContext {
+	sum := sha256.Sum256([]byte(executionID))
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], sum[:16])
+	copy(spanID[:], sum[16:24])
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+// StartRound starts the span of an evaluation round of the workflow. Every round is the root of a trace of its own,
+// linked to the rounds before and after it by the ID of the execution.
+func StartRound(ctx context.Context, w *v1alpha1.FlyteWorkflow) (context.Context, trace.Span) {
+	executionID := w.GetID()
+	if execID := w.GetExecutionID(); execID.WorkflowExecutionIdentifier != nil {
+		executionID = execID.String()
+	}
+
+	executionAttr := ExecutionIDKey.String(executionID)
+	return tracer().Start(ctx, "propeller.round",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.Link{SpanContext: executionSpanContext(executionID), Attributes: []attribute.KeyValue{executionAttr}}),
+		trace.WithAttributes(executionAttr, attribute.String("flyte.workflow_phase", w.GetExecutionStatus().GetPhase().String())),
+	)
+}
+
+// StartSpan starts a span as a child of the span in the context, if any.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, marking it as failed if the operation it covers returned an error.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// UnaryClientInterceptor returns an interceptor that records a span for every call made by a gRPC client, e.g. to
+// Admin or to the catalog.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := tracer().Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("rpc.system", "grpc"), attribute.String("rpc.method", method)))
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		EndSpan(span, err)
+		return err
+	}
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestStartRound(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	w := &v1alpha1.FlyteWorkflow{
+		ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "exec"},
+		},
+	}
+
+	var traceIDs []string
+	for i := 0; i < 2; i++ {
+		ctx, round := StartRound(context.TODO(), w)
+		_, span := StartSpan(ctx, "node.handle")
+		EndSpan(span, fmt.Errorf("failed"))
+		round.End()
+		traceIDs = append(traceIDs, round.SpanContext().TraceID().String())
+	}
+
+	// Every round is a trace of its own
+	assert.NotEqual(t, traceIDs[0], traceIDs[1])
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 4)
+	node, first, second := spans[0], spans[1], spans[3]
+	assert.Equal(t, first.SpanContext().TraceID(), node.SpanContext().TraceID())
+	assert.Equal(t, first.SpanContext().SpanID(), node.Parent().SpanID())
+	assert.Equal(t, codes.Error, node.Status().Code)
+
+	// The rounds of the execution link to the same span context
+	assert.Len(t, first.Links(), 1)
+	assert.Len(t, second.Links(), 1)
+	assert.Equal(t, first.Links()[0].SpanContext, second.Links()[0].SpanContext)
+	assert.NotEqual(t, first.Links()[0].SpanContext, executionSpanContext("other"))
+}
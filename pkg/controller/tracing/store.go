@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedStore records a span for every call made to blob storage.
+type tracedStore struct {
+	storage.ComposedProtobufStore
+}
+
+func referenceAttr(reference storage.DataReference) attribute.KeyValue {
+	return attribute.String("flyte.storage.reference", reference.String())
+}
+
+func (s tracedStore) Head(ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+	ctx, span := StartSpan(ctx, "storage.head", referenceAttr(reference))
+	metadata, err := s.ComposedProtobufStore.Head(ctx, reference)
+	EndSpan(span, err)
+	return metadata, err
+}
+
+func (s tracedStore) ReadRaw(ctx context.Context, reference storage.DataReference) (io.ReadCloser, error) {
+	ctx, span := StartSpan(ctx, "storage.read_raw", referenceAttr(reference))
+	rc, err := s.ComposedProtobufStore.ReadRaw(ctx, reference)
+	EndSpan(span, err)
+	return rc, err
+}
+
+func (s tracedStore) WriteRaw(ctx context.Context, reference storage.DataReference, size int64, opts storage.Options, raw io.Reader) error {
+	ctx, span := StartSpan(ctx, "storage.write_raw", referenceAttr(reference), attribute.Int64("flyte.storage.size_bytes", size))
+	err := s.ComposedProtobufStore.WriteRaw(ctx, reference, size, opts, raw)
+	EndSpan(span, err)
+	return err
+}
+
+func (s tracedStore) CopyRaw(ctx context.Context, source, destination storage.DataReference, opts storage.Options) error {
+	ctx, span := StartSpan(ctx, "storage.copy_raw", referenceAttr(destination),
+		attribute.String("flyte.storage.source", source.String()))
+	err := s.ComposedProtobufStore.CopyRaw(ctx, source, destination, opts)
+	EndSpan(span, err)
+	return err
+}
+
+func (s tracedStore) ReadProtobuf(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+	ctx, span := StartSpan(ctx, "storage.read_protobuf", referenceAttr(reference))
+	err := s.ComposedProtobufStore.ReadProtobuf(ctx, reference, msg)
+	EndSpan(span, err)
+	return err
+}
+
+func (s tracedStore) WriteProtobuf(ctx context.Context, reference storage.DataReference, opts storage.Options, msg proto.Message) error {
+	ctx, span := StartSpan(ctx, "storage.write_protobuf", referenceAttr(reference))
+	err := s.ComposedProtobufStore.WriteProtobuf(ctx, reference, opts, msg)
+	EndSpan(span, err)
+	return err
+}
+
+// NewDataStore wraps the store so that its calls to blob storage are recorded as spans of the round they are made in.
+func NewDataStore(store *storage.DataStore) *storage.DataStore {
+	return storage.NewCompositeDataStore(store.ReferenceConstructor, tracedStore{ComposedProtobufStore: store.ComposedProtobufStore})
+}
@@ -0,0 +1,98 @@
+// Package tracing allows operators to inspect the evaluation of a single workflow in detail, without changing the log
+// level of propeller as a whole. A workflow is traced while it's annotated with TraceUntilAnnotation and the annotated
+// time has not passed, so tracing expires on its own.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// TraceUntilAnnotation is the annotation of a FlyteWorkflow that enables tracing its evaluation until the annotated
+// time, formatted as RFC3339, e.g. 2021-06-01T15:04:05Z.
+const TraceUntilAnnotation = "flyte.org/trace-until"
+
+type contextKey struct{}
+
+// Tracer decides which workflows are traced and rate limits the trace lines logged across all of them.
+type Tracer struct {
+	limiter         *rate.Limiter
+	clk             clock.Clock
+	tracedRounds    prometheus.Counter
+	droppedMessages prometheus.Counter
+}
+
+// WithTracing returns a context that traces the evaluation of the workflow with the given annotations, if the workflow
+// is annotated to be traced at this time. Otherwise, or if the tracer is nil, the context is returned as is.
+func (t *Tracer) WithTracing(ctx context.Context, annotations map[string]string) context.Context {
+	if t == nil {
+		return ctx
+	}
+
+	value, ok := annotations[TraceUntilAnnotation]
+	if !ok {
+		return ctx
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logger.Warnf(ctx, "Ignoring invalid value [%s] of annotation [%s], expected an RFC3339 time. Error: %v",
+			value, TraceUntilAnnotation, err)
+		return ctx
+	}
+
+	if !t.clk.Now().Before(until) {
+		return ctx
+	}
+
+	t.tracedRounds.Inc()
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+func (t *Tracer) tracef(ctx context.Context, format string, args ...interface{}) {
+	if !t.limiter.AllowN(t.clk.Now(), 1) {
+		t.droppedMessages.Inc()
+		return
+	}
+
+	logger.Infof(ctx, "[trace] "+format, args...)
+}
+
+// IsTraced returns whether the evaluation of the workflow the context belongs to is traced.
+func IsTraced(ctx context.Context) bool {
+	_, ok := ctx.Value(contextKey{}).(*Tracer)
+	return ok
+}
+
+// Tracef logs the message regardless of the log level if the evaluation of the workflow the context belongs to is
+// traced, and at debug level otherwise.
+func Tracef(ctx context.Context, format string, args ...interface{}) {
+	if t, ok := ctx.Value(contextKey{}).(*Tracer); ok {
+		t.tracef(ctx, format, args...)
+		return
+	}
+
+	logger.Debugf(ctx, format, args...)
+}
+
+// NewTracer creates a Tracer, or returns nil if tracing is disabled, which leaves all workflows untraced.
+func NewTracer(cfg config.VerboseTracingConfig, scope promutils.Scope, clk clock.Clock) *Tracer {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &Tracer{
+		limiter:         rate.NewLimiter(rate.Limit(cfg.Rate), cfg.Burst),
+		clk:             clk,
+		tracedRounds:    scope.MustNewCounter("traced_rounds", "Evaluation rounds of workflows annotated to be traced"),
+		droppedMessages: scope.MustNewCounter("dropped_messages", "Trace lines dropped because of the rate limit"),
+	}
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestNewTracer_Disabled(t *testing.T) {
+	tracer := NewTracer(config.VerboseTracingConfig{}, promutils.NewTestScope(), clock.RealClock{})
+	assert.Nil(t, tracer)
+
+	ctx := tracer.WithTracing(context.TODO(), map[string]string{
+		TraceUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	assert.False(t, IsTraced(ctx))
+}
+
+func TestTracer_WithTracing(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracer := NewTracer(config.VerboseTracingConfig{Enabled: true, Rate: 1, Burst: 1}, promutils.NewTestScope(),
+		clock.NewFakeClock(now))
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		traced      bool
+	}{
+		{"missing", nil, false},
+		{"invalid", map[string]string{TraceUntilAnnotation: "tomorrow"}, false},
+		{"expired", map[string]string{TraceUntilAnnotation: now.Add(-time.Minute).Format(time.RFC3339)}, false},
+		{"active", map[string]string{TraceUntilAnnotation: now.Add(time.Minute).Format(time.RFC3339)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.traced, IsTraced(tracer.WithTracing(context.TODO(), tt.annotations)))
+		})
+	}
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(tracer.tracedRounds))
+}
+
+func TestTracef_RateLimited(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewFakeClock(now)
+	tracer := NewTracer(config.VerboseTracingConfig{Enabled: true, Rate: 1, Burst: 2}, promutils.NewTestScope(), clk)
+	ctx := tracer.WithTracing(context.TODO(), map[string]string{
+		TraceUntilAnnotation: now.Add(time.Hour).Format(time.RFC3339),
+	})
+
+	for i := 0; i < 5; i++ {
+		Tracef(ctx, "line %d", i)
+	}
+	assert.Equal(t, float64(3), testutil.ToFloat64(tracer.droppedMessages))
+
+	clk.Step(time.Second)
+	Tracef(ctx, "line after refill")
+	assert.Equal(t, float64(3), testutil.ToFloat64(tracer.droppedMessages))
+
+	// Untraced contexts are not rate limited.
+	Tracef(context.TODO(), "untraced line")
+	assert.Equal(t, float64(3), testutil.ToFloat64(tracer.droppedMessages))
+}
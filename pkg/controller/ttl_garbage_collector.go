@@ -0,0 +1,311 @@
+package controller
+
+import (
+	"context"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	corev1Types "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	flyteworkflowv1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/archive"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+	"github.com/flyteorg/flytepropeller/pkg/controller/workflowstore"
+)
+
+type ttlGCMetrics struct {
+	workflowsDeleted labeled.Counter
+	deleteFailures   labeled.Counter
+	archiveFailures  labeled.Counter
+	scanFailures     prometheus.Counter
+	scanTime         promutils.StopWatch
+}
+
+// A terminated workflow to delete. The UID guards against deleting a workflow that was recreated with the same name
+// since it was scanned.
+type expiredWorkflow struct {
+	namespace string
+	name      string
+	uid       types.UID
+}
+
+// TTLGarbageCollector is a background service that deletes terminated workflows once they outlived the TTL of their
+// namespace, with separate TTLs for workflows that succeeded and ones that failed or were aborted. Unlike the
+// GarbageCollector, which deletes workflows by the hour they completed in, TTLs are not limited to a day. Workflows are
+// found by periodic scans and deleted by a dedicated worker, at a limited rate to not overwhelm the KubeAPI.
+type TTLGarbageCollector struct {
+	wfClient        v1alpha1.FlyteworkflowV1alpha1Interface
+	namespaceClient corev1.NamespaceInterface
+	enabled         bool
+	interval        time.Duration
+	defaultPolicy   config.TTLPolicy
+	policies        map[string]config.TTLPolicy
+	queue           workqueue.Interface
+	limiter         *rate.Limiter
+	clk             clock.Clock
+	metrics         *ttlGCMetrics
+	namespace       string
+	// archiver, if set, archives workflows before they are deleted.
+	archiver *archive.Archiver
+	// dataStore reads the node status the workflow store offloaded, for workflows to be archived with it.
+	dataStore *storage.DataStore
+}
+
+// Returns the TTL of the terminated workflow, as configured for its namespace. TTLs that are not configured for the
+// namespace fall back to the default ones.
+func (g *TTLGarbageCollector) ttl(w *flyteworkflowv1alpha1.FlyteWorkflow) time.Duration {
+	policy, ok := g.policies[w.GetNamespace()]
+	if !ok {
+		policy = g.defaultPolicy
+	}
+
+	if w.Status.Phase == flyteworkflowv1alpha1.WorkflowPhaseSuccess {
+		if policy.SuccessTTL.Duration > 0 {
+			return policy.SuccessTTL.Duration
+		}
+
+		return g.defaultPolicy.SuccessTTL.Duration
+	}
+
+	if policy.FailureTTL.Duration > 0 {
+		return policy.FailureTTL.Duration
+	}
+
+	return g.defaultPolicy.FailureTTL.Duration
+}
+
+// Returns whether the workflow terminated more than its TTL ago. A TTL of 0 keeps workflows forever.
+func (g *TTLGarbageCollector) isExpired(w *flyteworkflowv1alpha1.FlyteWorkflow) bool {
+	if !w.Status.IsTerminated() || w.GetDeletionTimestamp() != nil {
+		return false
+	}
+
+	ttl := g.ttl(w)
+	if ttl <= 0 {
+		return false
+	}
+
+	stoppedAt := lastActivity(w)
+	if w.Status.StoppedAt != nil {
+		stoppedAt = w.Status.StoppedAt.Time
+	}
+
+	return g.clk.Since(stoppedAt) > ttl
+}
+
+// Enqueues the expired workflows of the namespace to be deleted.
+func (g *TTLGarbageCollector) scanNamespace(ctx context.Context, namespace string) error {
+	workflows, err := g.wfClient.FlyteWorkflows(namespace).List(ctx, v1.ListOptions{
+		LabelSelector: v1.FormatLabelSelector(CompletedWorkflowsLabelSelector()),
+	})
+	if err != nil {
+		return err
+	}
+
+	expired := 0
+	for i := range workflows.Items {
+		w := &workflows.Items[i]
+		if g.isExpired(w) {
+			expired++
+			g.queue.Add(expiredWorkflow{namespace: namespace, name: w.GetName(), uid: w.GetUID()})
+		}
+	}
+
+	if expired > 0 {
+		logger.Infof(ctx, "Found [%d] workflows in namespace [%s] that outlived their TTL", expired, namespace)
+	}
+
+	return nil
+}
+
+func (g *TTLGarbageCollector) scan(ctx context.Context) error {
+	t := g.metrics.scanTime.Start()
+	defer t.Stop()
+
+	var namespaces []string
+	if g.namespace == "" || strings.ToLower(g.namespace) == "all" || strings.ToLower(g.namespace) == "all-namespaces" {
+		namespaceList, err := g.namespaceClient.List(ctx, v1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, n := range namespaceList.Items {
+			if n.Status.Phase != corev1Types.NamespaceTerminating {
+				namespaces = append(namespaces, n.GetName())
+			}
+		}
+	} else {
+		namespaces = []string{g.namespace}
+	}
+
+	for _, namespace := range namespaces {
+		if err := g.scanNamespace(contextutils.WithNamespace(ctx, namespace), namespace); err != nil {
+			g.metrics.scanFailures.Inc()
+			logger.Errorf(ctx, "Failed to scan namespace [%s] for workflows that outlived their TTL. Error: %v", namespace, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *TTLGarbageCollector) deleteWorkflow(ctx context.Context, w expiredWorkflow) {
+	ctx = contextutils.WithNamespace(ctx, w.namespace)
+	if g.archiver != nil {
+		if err := g.archiveWorkflow(ctx, w); err != nil {
+			// The workflow is kept, to be archived and deleted once it's picked up again by the next scan.
+			g.metrics.archiveFailures.Inc(ctx)
+			logger.Errorf(ctx, "Failed to archive workflow [%s/%s] that outlived its TTL. Error: %v", w.namespace, w.name, err)
+			return
+		}
+	}
+
+	gracePeriodZero := int64(0)
+	propagation := v1.DeletePropagationBackground
+	err := g.wfClient.FlyteWorkflows(w.namespace).Delete(ctx, w.name, v1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriodZero,
+		PropagationPolicy:  &propagation,
+		Preconditions:      &v1.Preconditions{UID: &w.uid},
+	})
+
+	if err != nil {
+		// Workflows that are gone already, or were recreated, are picked up again by the next scan if they expired.
+		if k8serrors.IsNotFound(err) || k8serrors.IsConflict(err) {
+			return
+		}
+
+		g.metrics.deleteFailures.Inc(ctx)
+		logger.Errorf(ctx, "Failed to delete workflow [%s/%s] that outlived its TTL. Error: %v", w.namespace, w.name, err)
+		return
+	}
+
+	g.metrics.workflowsDeleted.Inc(ctx)
+}
+
+// Archives the expired workflow, unless it's gone already or was recreated since it was scanned.
+func (g *TTLGarbageCollector) archiveWorkflow(ctx context.Context, w expiredWorkflow) error {
+	workflow, err := g.wfClient.FlyteWorkflows(w.namespace).Get(ctx, w.name, v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if workflow.GetUID() != w.uid {
+		return nil
+	}
+
+	// Workflows are archived decoded and hydrated, so that they can be read without the workflow store.
+	workflow, err = workflowstore.HydrateWorkflow(ctx, g.dataStore, workflow)
+	if err != nil {
+		return err
+	}
+
+	return g.archiver.Archive(ctx, workflow)
+}
+
+// Deletes the enqueued workflows one by one, at the configured rate, until the queue is shut down.
+func (g *TTLGarbageCollector) runWorker(ctx context.Context) {
+	ctx = contextutils.WithGoroutineLabel(ctx, "ttl-gc-delete-worker")
+	pprof.SetGoroutineLabels(ctx)
+	for {
+		item, shutdown := g.queue.Get()
+		if shutdown {
+			return
+		}
+
+		if err := g.limiter.Wait(ctx); err == nil {
+			g.deleteWorkflow(ctx, item.(expiredWorkflow))
+		}
+
+		g.queue.Done(item)
+
+		// The archive index is updated once all the workflows found by a scan were deleted.
+		if g.archiver != nil && g.queue.Len() == 0 {
+			if err := g.archiver.Flush(ctx); err != nil {
+				logger.Errorf(ctx, "Failed to update the index of archived workflows. Error: %v", err)
+			}
+		}
+	}
+}
+
+func (g *TTLGarbageCollector) run(ctx context.Context, ticker clock.Ticker) {
+	logger.Infof(ctx, "Background TTL garbage collection started, with interval [%s]", g.interval.String())
+
+	ctx = contextutils.WithGoroutineLabel(ctx, "ttl-gc-worker")
+	pprof.SetGoroutineLabels(ctx)
+	defer ticker.Stop()
+	defer g.queue.ShutDown()
+	for {
+		select {
+		case <-ticker.C():
+			if err := g.scan(ctx); err != nil {
+				g.metrics.scanFailures.Inc()
+				logger.Errorf(ctx, "Failed to scan for workflows that outlived their TTL in this round. Error: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Infof(ctx, "TTL garbage collector stopping")
+			return
+		}
+	}
+}
+
+// Use this method to start the background TTL garbage collection routine. Use the context to signal an exit signal
+func (g *TTLGarbageCollector) Start(ctx context.Context) error {
+	if !g.enabled {
+		logger.Infof(ctx, "TTL garbage collection is disabled")
+		return nil
+	}
+
+	go g.runWorker(ctx)
+	go g.run(ctx, g.clk.NewTicker(g.interval))
+	return nil
+}
+
+func NewTTLGarbageCollector(cfg *config.Config, scope promutils.Scope, clk clock.Clock, namespaceClient corev1.NamespaceInterface,
+	wfClient v1alpha1.FlyteworkflowV1alpha1Interface) *TTLGarbageCollector {
+	r := cfg.TTLGarbageCollector.Rate
+	if r <= 0 {
+		r = 1
+	}
+
+	gcScope := scope.NewSubScope("ttl_gc")
+	return &TTLGarbageCollector{
+		wfClient:        wfClient,
+		namespaceClient: namespaceClient,
+		enabled:         cfg.TTLGarbageCollector.Enabled,
+		interval:        cfg.TTLGarbageCollector.Interval.Duration,
+		defaultPolicy: config.TTLPolicy{
+			SuccessTTL: cfg.TTLGarbageCollector.SuccessTTL,
+			FailureTTL: cfg.TTLGarbageCollector.FailureTTL,
+		},
+		policies: cfg.TTLGarbageCollector.NamespacePolicies,
+		queue:    workqueue.NewNamed("ttl-gc"),
+		limiter:  rate.NewLimiter(rate.Limit(r), int(r)),
+		clk:      clk,
+		metrics: &ttlGCMetrics{
+			workflowsDeleted: labeled.NewCounter("workflows_deleted", "Terminated workflows deleted because they outlived their TTL", gcScope),
+			deleteFailures:   labeled.NewCounter("delete_failures", "Failures to delete a terminated workflow that outlived its TTL", gcScope),
+			archiveFailures:  labeled.NewCounter("archive_failures", "Failures to archive a terminated workflow before deleting it", gcScope),
+			scanFailures:     gcScope.MustNewCounter("scan_failures", "Failures to scan for workflows that outlived their TTL"),
+			scanTime:         gcScope.MustNewStopWatch("scan_latency", "Time taken to scan for workflows that outlived their TTL", time.Millisecond),
+		},
+		namespace: cfg.LimitNamespace,
+	}
+}
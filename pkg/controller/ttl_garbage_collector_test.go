@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	corev1Types "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytepropeller/pkg/controller/archive"
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+func TestTTLGarbageCollector(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	at := func(d time.Duration) *v1.Time {
+		ts := v1.NewTime(now.Add(-d))
+		return &ts
+	}
+
+	newWorkflow := func(namespace, name string, phase v1alpha1.WorkflowPhase, stoppedAt *v1.Time) *v1alpha1.FlyteWorkflow {
+		w := &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				UID:       types.UID(name),
+			},
+			Status: v1alpha1.WorkflowStatus{
+				Phase:     phase,
+				StoppedAt: stoppedAt,
+			},
+		}
+
+		if phase != v1alpha1.WorkflowPhaseRunning {
+			SetCompletedLabel(w, stoppedAt.Time)
+		}
+
+		return w
+	}
+
+	wfClient := fake.NewSimpleClientset(
+		newWorkflow("ns", "running", v1alpha1.WorkflowPhaseRunning, nil),
+		newWorkflow("ns", "recently-succeeded", v1alpha1.WorkflowPhaseSuccess, at(time.Hour)),
+		newWorkflow("ns", "old-succeeded", v1alpha1.WorkflowPhaseSuccess, at(25*time.Hour)),
+		newWorkflow("ns", "recently-failed", v1alpha1.WorkflowPhaseFailed, at(25*time.Hour)),
+		newWorkflow("ns", "old-aborted", v1alpha1.WorkflowPhaseAborted, at(73*time.Hour)),
+		newWorkflow("short", "succeeded", v1alpha1.WorkflowPhaseSuccess, at(2*time.Hour)),
+		newWorkflow("short", "failed", v1alpha1.WorkflowPhaseFailed, at(25*time.Hour)),
+	)
+	kubeClient := kubeFake.NewSimpleClientset(
+		&corev1Types.Namespace{ObjectMeta: v1.ObjectMeta{Name: "ns"}},
+		&corev1Types.Namespace{ObjectMeta: v1.ObjectMeta{Name: "short"}},
+	)
+
+	cfg := &config2.Config{
+		LimitNamespace: "all",
+		TTLGarbageCollector: config2.TTLGarbageCollectorConfig{
+			Enabled:    true,
+			Interval:   config.Duration{Duration: time.Minute},
+			SuccessTTL: config.Duration{Duration: 24 * time.Hour},
+			FailureTTL: config.Duration{Duration: 72 * time.Hour},
+			NamespacePolicies: map[string]config2.TTLPolicy{
+				// The failure TTL falls back to the default one.
+				"short": {SuccessTTL: config.Duration{Duration: time.Hour}},
+			},
+			Rate: 100,
+		},
+	}
+
+	g := NewTTLGarbageCollector(cfg, promutils.NewTestScope(), clock.NewFakeClock(now), kubeClient.CoreV1().Namespaces(),
+		wfClient.FlyteworkflowV1alpha1())
+
+	t.Run("ttl", func(t *testing.T) {
+		assert.Equal(t, 24*time.Hour, g.ttl(newWorkflow("ns", "w", v1alpha1.WorkflowPhaseSuccess, at(0))))
+		assert.Equal(t, 72*time.Hour, g.ttl(newWorkflow("ns", "w", v1alpha1.WorkflowPhaseAborted, at(0))))
+		assert.Equal(t, time.Hour, g.ttl(newWorkflow("short", "w", v1alpha1.WorkflowPhaseSuccess, at(0))))
+		assert.Equal(t, 72*time.Hour, g.ttl(newWorkflow("short", "w", v1alpha1.WorkflowPhaseFailed, at(0))))
+	})
+
+	t.Run("scan and delete", func(t *testing.T) {
+		assert.NoError(t, g.scan(ctx))
+		assert.Equal(t, 3, g.queue.Len())
+		for g.queue.Len() > 0 {
+			item, _ := g.queue.Get()
+			g.deleteWorkflow(ctx, item.(expiredWorkflow))
+			g.queue.Done(item)
+		}
+
+		remaining := map[string][]string{}
+		for _, namespace := range []string{"ns", "short"} {
+			workflows, err := wfClient.FlyteworkflowV1alpha1().FlyteWorkflows(namespace).List(ctx, v1.ListOptions{})
+			assert.NoError(t, err)
+			for _, w := range workflows.Items {
+				remaining[namespace] = append(remaining[namespace], w.GetName())
+			}
+		}
+
+		assert.ElementsMatch(t, []string{"running", "recently-succeeded", "recently-failed"}, remaining["ns"])
+		assert.ElementsMatch(t, []string{"failed"}, remaining["short"])
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		g.deleteWorkflow(ctx, expiredWorkflow{namespace: "ns", name: "old-succeeded", uid: "old-succeeded"})
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		wfClient := fake.NewSimpleClientset(newWorkflow("ns", "old-failed", v1alpha1.WorkflowPhaseFailed, at(73*time.Hour)))
+		g := NewTTLGarbageCollector(cfg, promutils.NewTestScope(), clock.NewFakeClock(now), kubeClient.CoreV1().Namespaces(),
+			wfClient.FlyteworkflowV1alpha1())
+		store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		g.archiver = archive.NewArchiver(store, "s3://bucket/archive")
+
+		g.deleteWorkflow(ctx, expiredWorkflow{namespace: "ns", name: "old-failed", uid: "old-failed"})
+		assert.NoError(t, g.archiver.Flush(ctx))
+
+		_, err = wfClient.FlyteworkflowV1alpha1().FlyteWorkflows("ns").Get(ctx, "old-failed", v1.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		ref, err := archive.IndexReference(ctx, store, "s3://bucket/archive", "ns", now.Add(-73*time.Hour))
+		assert.NoError(t, err)
+		records, err := archive.ReadIndex(ctx, store, ref)
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, "old-failed", records[0].Name)
+			assert.Equal(t, v1alpha1.WorkflowPhaseFailed.String(), records[0].Phase)
+		}
+	})
+
+	t.Run("archive offloaded node status", func(t *testing.T) {
+		w := newWorkflow("ns", "offloaded", v1alpha1.WorkflowPhaseSuccess, at(25*time.Hour))
+		w.Status.NodeStatus = map[v1alpha1.NodeID]*v1alpha1.NodeStatus{"n1": {Phase: v1alpha1.NodePhaseSucceeded}}
+		w.Status.NodeStatusRef = "s3://bucket/metadata/offloaded/node-status/1"
+		wfClient := fake.NewSimpleClientset(w)
+		g := NewTTLGarbageCollector(cfg, promutils.NewTestScope(), clock.NewFakeClock(now), kubeClient.CoreV1().Namespaces(),
+			wfClient.FlyteworkflowV1alpha1())
+		store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		g.archiver = archive.NewArchiver(store, "s3://bucket/archive")
+		g.dataStore = store
+
+		raw, err := json.Marshal(map[v1alpha1.NodeID]*v1alpha1.NodeStatus{"n1": {Phase: v1alpha1.NodePhaseSucceeded, Message: "done"}})
+		assert.NoError(t, err)
+		assert.NoError(t, store.WriteRaw(ctx, w.Status.NodeStatusRef, int64(len(raw)), storage.Options{}, bytes.NewReader(raw)))
+
+		g.deleteWorkflow(ctx, expiredWorkflow{namespace: "ns", name: "offloaded", uid: "offloaded"})
+		assert.NoError(t, g.archiver.Flush(ctx))
+
+		ref, err := archive.IndexReference(ctx, store, "s3://bucket/archive", "ns", now.Add(-25*time.Hour))
+		assert.NoError(t, err)
+		records, err := archive.ReadIndex(ctx, store, ref)
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			reader, err := store.ReadRaw(ctx, records[0].WorkflowRef)
+			assert.NoError(t, err)
+			archived := &v1alpha1.FlyteWorkflow{}
+			assert.NoError(t, json.NewDecoder(reader).Decode(archived))
+			assert.NoError(t, reader.Close())
+			assert.Equal(t, "done", archived.Status.NodeStatus["n1"].Message)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g := NewTTLGarbageCollector(&config2.Config{}, promutils.NewTestScope(), clock.NewFakeClock(now), nil, nil)
+		assert.NoError(t, g.Start(ctx))
+	})
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+
+	flyteworkflowv1alpha1 "github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// The parts of the FlyteWorkflow informer the WatchHealthMonitor needs, implemented by cache.SharedIndexInformer.
+type watchedInformer interface {
+	GetIndexer() cache.Indexer
+}
+
+// Expires the watches of the FlyteWorkflow informer, which forces it to re-list all workflows.
+type watchExpirer interface {
+	Expire() int
+}
+
+type watchHealthMetrics struct {
+	secondsSinceLastEvent prometheus.Gauge
+	outdatedWorkflows     prometheus.Gauge
+	staleDetected         prometheus.Counter
+	expiredWatches        prometheus.Counter
+	checkFailures         prometheus.Counter
+}
+
+// WatchHealthMonitor is a background service that detects when the informer cache of FlyteWorkflows went stale, which
+// happens when watch events are silently missed after apiserver or network disruptions. When the informer has not
+// received events for a while, it compares a page of the workflows of the apiserver to the ones in the informer cache,
+// moving on to the next page with every check so that all workflows are sampled in turn. If any of them is missing or
+// outdated in the cache, the watch of the informer is expired, so that the informer re-lists all workflows and the
+// ones that changed are not stuck until the next resync.
+type WatchHealthMonitor struct {
+	informer           watchedInformer
+	watches            watchExpirer
+	wfClient           v1alpha1.FlyteworkflowV1alpha1Interface
+	enabled            bool
+	interval           time.Duration
+	stalenessThreshold time.Duration
+	sampleSize         int64
+	namespace          string
+	labelSelector      string
+	clk                clock.Clock
+	// The continue token of the page of workflows to sample next, so that successive checks sample all workflows in
+	// turn rather than the same first page.
+	continueToken string
+	// Unix nanoseconds of the last event received by the informer.
+	lastEventAt int64
+	metrics     *watchHealthMetrics
+}
+
+func (w *WatchHealthMonitor) recordEvent() {
+	atomic.StoreInt64(&w.lastEventAt, w.clk.Now().UnixNano())
+}
+
+// EventHandler returns the handler to register with the FlyteWorkflow informer to track when it last received events.
+func (w *WatchHealthMonitor) EventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.recordEvent() },
+		UpdateFunc: func(old, new interface{}) { w.recordEvent() },
+		DeleteFunc: func(obj interface{}) { w.recordEvent() },
+	}
+}
+
+// Returns whether the resource version of the cached workflow is older than the listed one. Continued lists are read
+// from a snapshot of etcd, so the cache may be ahead of them.
+func isOlderResourceVersion(cached, listed string) bool {
+	cachedVersion, err := strconv.ParseUint(cached, 10, 64)
+	if err != nil {
+		return cached != listed
+	}
+
+	listedVersion, err := strconv.ParseUint(listed, 10, 64)
+	if err != nil {
+		return cached != listed
+	}
+
+	return cachedVersion < listedVersion
+}
+
+// Returns how many of the listed workflows are missing from or outdated in the informer cache.
+func (w *WatchHealthMonitor) countOutdated(workflows []flyteworkflowv1alpha1.FlyteWorkflow) (int, error) {
+	indexer := w.informer.GetIndexer()
+	outdated := 0
+	for i := range workflows {
+		wf := &workflows[i]
+		key, err := cache.MetaNamespaceKeyFunc(wf)
+		if err != nil {
+			return 0, err
+		}
+
+		cached, exists, err := indexer.GetByKey(key)
+		if err != nil {
+			return 0, err
+		}
+
+		if cachedWf, ok := cached.(*flyteworkflowv1alpha1.FlyteWorkflow); !exists || !ok || isOlderResourceVersion(cachedWf.GetResourceVersion(), wf.GetResourceVersion()) {
+			outdated++
+		}
+	}
+
+	return outdated, nil
+}
+
+// Lists the next page of workflows to sample. Lists with a limit are read from etcd, not from the watch cache of the
+// apiserver that may be stale as well. After the last page, the sample starts over from the first one.
+func (w *WatchHealthMonitor) listSample(ctx context.Context) (*flyteworkflowv1alpha1.FlyteWorkflowList, error) {
+	options := v1.ListOptions{LabelSelector: w.labelSelector, Limit: w.sampleSize, Continue: w.continueToken}
+	list, err := w.wfClient.FlyteWorkflows(w.namespace).List(ctx, options)
+	if apierrors.IsResourceExpired(err) && len(options.Continue) > 0 {
+		// The snapshot the pages are read from was compacted since the previous check, start over from the first page.
+		options.Continue = ""
+		list, err = w.wfClient.FlyteWorkflows(w.namespace).List(ctx, options)
+	}
+
+	if err != nil {
+		w.continueToken = ""
+		return nil, err
+	}
+
+	w.continueToken = list.Continue
+	return list, nil
+}
+
+func (w *WatchHealthMonitor) check(ctx context.Context) error {
+	sinceLastEvent := w.clk.Since(time.Unix(0, atomic.LoadInt64(&w.lastEventAt)))
+	w.metrics.secondsSinceLastEvent.Set(sinceLastEvent.Seconds())
+	if sinceLastEvent < w.stalenessThreshold {
+		return nil
+	}
+
+	list, err := w.listSample(ctx)
+	if err != nil {
+		return err
+	}
+
+	outdated, err := w.countOutdated(list.Items)
+	if err != nil {
+		return err
+	}
+
+	w.metrics.outdatedWorkflows.Set(float64(outdated))
+	if outdated == 0 {
+		return nil
+	}
+
+	w.metrics.staleDetected.Inc()
+	logger.Warnf(ctx, "FlyteWorkflow informer received no events for [%s] and [%d] of [%d] sampled workflows are outdated in its cache, expiring its watch to re-list workflows",
+		sinceLastEvent.String(), outdated, len(list.Items))
+	w.metrics.expiredWatches.Add(float64(w.watches.Expire()))
+	w.recordEvent()
+	return nil
+}
+
+func (w *WatchHealthMonitor) run(ctx context.Context, ticker clock.Ticker) {
+	logger.Infof(ctx, "Background watch health monitoring started, with interval [%s]", w.interval.String())
+
+	ctx = contextutils.WithGoroutineLabel(ctx, "watch-health-worker")
+	pprof.SetGoroutineLabels(ctx)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			if err := w.check(ctx); err != nil {
+				w.metrics.checkFailures.Inc()
+				logger.Errorf(ctx, "Failed to check the health of the FlyteWorkflow informer in this round. Error: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Infof(ctx, "Watch health monitoring stopping")
+			return
+		}
+	}
+}
+
+// Use this method to start the background watch health monitoring routine. Use the context to signal an exit signal
+func (w *WatchHealthMonitor) Start(ctx context.Context) error {
+	if !w.enabled {
+		logger.Infof(ctx, "Watch health monitoring is disabled")
+		return nil
+	}
+
+	w.recordEvent()
+	go w.run(ctx, w.clk.NewTicker(w.interval))
+	return nil
+}
+
+// Returns the namespace FlyteWorkflows are watched in.
+func watchedNamespace(cfg *config.Config) string {
+	namespace := cfg.LimitNamespace
+	if strings.ToLower(namespace) == "all" || strings.ToLower(namespace) == "all-namespaces" {
+		return v1.NamespaceAll
+	}
+
+	return namespace
+}
+
+func NewWatchHealthMonitor(cfg *config.Config, scope promutils.Scope, clk clock.Clock, informer watchedInformer,
+	watches watchExpirer, wfClient v1alpha1.FlyteworkflowV1alpha1Interface) *WatchHealthMonitor {
+	watchScope := scope.NewSubScope("watch_health")
+	return &WatchHealthMonitor{
+		informer:           informer,
+		watches:            watches,
+		wfClient:           wfClient,
+		enabled:            cfg.WatchHealth.Enabled,
+		interval:           cfg.WatchHealth.Interval.Duration,
+		stalenessThreshold: cfg.WatchHealth.StalenessThreshold.Duration,
+		sampleSize:         cfg.WatchHealth.SampleSize,
+		namespace:          watchedNamespace(cfg),
+		labelSelector:      workflowsLabelSelector(cfg),
+		clk:                clk,
+		metrics: &watchHealthMetrics{
+			secondsSinceLastEvent: watchScope.MustNewGauge("seconds_since_last_event", "Seconds since the FlyteWorkflow informer last received an event"),
+			outdatedWorkflows:     watchScope.MustNewGauge("outdated_workflows", "Sampled FlyteWorkflows that were missing from or outdated in the informer cache when last checked"),
+			staleDetected:         watchScope.MustNewCounter("stale_detected", "Times the FlyteWorkflow informer was found stale"),
+			expiredWatches:        watchScope.MustNewCounter("expired_watches", "Watches of the FlyteWorkflow informer expired to re-list workflows after it was found stale"),
+			checkFailures:         watchScope.MustNewCounter("check_failures", "Failures to check the health of the FlyteWorkflow informer"),
+		},
+	}
+}
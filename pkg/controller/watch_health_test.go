@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	config2 "github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+type fakeWatchedInformer struct {
+	indexer cache.Indexer
+}
+
+func (f fakeWatchedInformer) GetIndexer() cache.Indexer {
+	return f.indexer
+}
+
+type fakeWatchExpirer struct {
+	expired int
+}
+
+func (f *fakeWatchExpirer) Expire() int {
+	f.expired++
+	return 1
+}
+
+func TestWatchHealthMonitor_check(t *testing.T) {
+	ctx := context.TODO()
+	newWorkflow := func(name, resourceVersion string) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "ns", ResourceVersion: resourceVersion},
+		}
+	}
+
+	cfg := &config2.Config{
+		LimitNamespace: "all",
+		WatchHealth: config2.WatchHealthConfig{
+			Enabled:            true,
+			Interval:           config.Duration{Duration: time.Minute},
+			StalenessThreshold: config.Duration{Duration: 5 * time.Minute},
+			SampleSize:         100,
+		},
+	}
+
+	// Returns a monitor whose informer cache holds the unchanged and the changed workflow at resource version 1.
+	setup := func(serverWorkflows ...runtime.Object) (*WatchHealthMonitor, *fake.Clientset, *clock.FakeClock, *fakeWatchExpirer) {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, wf := range []*v1alpha1.FlyteWorkflow{newWorkflow("unchanged", "1"), newWorkflow("changed", "1")} {
+			assert.NoError(t, indexer.Add(wf))
+		}
+
+		wfClient := fake.NewSimpleClientset(serverWorkflows...)
+		watches := &fakeWatchExpirer{}
+		clk := clock.NewFakeClock(time.Now())
+		w := NewWatchHealthMonitor(cfg, promutils.NewTestScope(), clk, fakeWatchedInformer{indexer: indexer}, watches,
+			wfClient.FlyteworkflowV1alpha1())
+		w.recordEvent()
+		return w, wfClient, clk, watches
+	}
+
+	t.Run("recent events", func(t *testing.T) {
+		w, wfClient, clk, watches := setup(newWorkflow("unchanged", "1"), newWorkflow("changed", "2"))
+		clk.Step(time.Minute)
+		assert.NoError(t, w.check(ctx))
+		assert.Empty(t, wfClient.Actions())
+		assert.Equal(t, 0, watches.expired)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		w, wfClient, clk, watches := setup(newWorkflow("unchanged", "1"), newWorkflow("changed", "1"))
+		clk.Step(10 * time.Minute)
+		assert.NoError(t, w.check(ctx))
+		assert.Len(t, wfClient.Actions(), 1)
+		assert.Equal(t, 0, watches.expired)
+		assert.Equal(t, float64(0), testutil.ToFloat64(w.metrics.outdatedWorkflows))
+		assert.Equal(t, float64(0), testutil.ToFloat64(w.metrics.staleDetected))
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		w, _, clk, watches := setup(newWorkflow("unchanged", "1"), newWorkflow("changed", "2"), newWorkflow("created", "3"))
+		clk.Step(10 * time.Minute)
+		assert.NoError(t, w.check(ctx))
+		assert.Equal(t, 1, watches.expired)
+		assert.Equal(t, float64(2), testutil.ToFloat64(w.metrics.outdatedWorkflows))
+		assert.Equal(t, float64(1), testutil.ToFloat64(w.metrics.staleDetected))
+		assert.Equal(t, float64(1), testutil.ToFloat64(w.metrics.expiredWatches))
+
+		// Expiring the watches counts as an event.
+		assert.NoError(t, w.check(ctx))
+		assert.Equal(t, 1, watches.expired)
+	})
+	// The fake clientset ignores limits and continue tokens, the pages are served in order of the calls instead.
+	servePages := func(wfClient *fake.Clientset, pages ...interface{}) *int {
+		calls := 0
+		wfClient.PrependReactor("list", "flyteworkflows", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			page := pages[calls%len(pages)]
+			calls++
+			if err, ok := page.(error); ok {
+				return true, nil, err
+			}
+
+			return true, page.(*v1alpha1.FlyteWorkflowList), nil
+		})
+
+		return &calls
+	}
+
+	t.Run("rotating sample", func(t *testing.T) {
+		w, wfClient, clk, watches := setup()
+		servePages(wfClient,
+			&v1alpha1.FlyteWorkflowList{ListMeta: v1.ListMeta{Continue: "next"}, Items: []v1alpha1.FlyteWorkflow{*newWorkflow("unchanged", "1")}},
+			&v1alpha1.FlyteWorkflowList{Items: []v1alpha1.FlyteWorkflow{*newWorkflow("changed", "2")}})
+		clk.Step(10 * time.Minute)
+		assert.NoError(t, w.check(ctx))
+		assert.Equal(t, 0, watches.expired)
+		assert.Equal(t, "next", w.continueToken)
+
+		assert.NoError(t, w.check(ctx))
+		assert.Equal(t, 1, watches.expired)
+		assert.Equal(t, "", w.continueToken)
+	})
+
+	t.Run("cache ahead of the sample", func(t *testing.T) {
+		w, wfClient, clk, watches := setup()
+		servePages(wfClient, &v1alpha1.FlyteWorkflowList{Items: []v1alpha1.FlyteWorkflow{*newWorkflow("changed", "0")}})
+		clk.Step(10 * time.Minute)
+		assert.NoError(t, w.check(ctx))
+		assert.Equal(t, 0, watches.expired)
+	})
+
+	t.Run("expired continue token", func(t *testing.T) {
+		w, wfClient, clk, watches := setup()
+		calls := servePages(wfClient,
+			&v1alpha1.FlyteWorkflowList{ListMeta: v1.ListMeta{Continue: "next"}, Items: []v1alpha1.FlyteWorkflow{*newWorkflow("unchanged", "1")}},
+			apierrors.NewResourceExpired("the continue token is too old"),
+			&v1alpha1.FlyteWorkflowList{Items: []v1alpha1.FlyteWorkflow{*newWorkflow("changed", "2")}})
+		clk.Step(10 * time.Minute)
+		assert.NoError(t, w.check(ctx))
+		assert.NoError(t, w.check(ctx))
+		assert.Equal(t, 3, *calls)
+		assert.Equal(t, 1, watches.expired)
+	})
+}
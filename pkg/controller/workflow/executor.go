@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/flyteorg/flytepropeller/pkg/controller/config"
@@ -13,13 +14,18 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/flyteorg/flytepropeller/events"
 	eventsErr "github.com/flyteorg/flytepropeller/events/errors"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
 	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
+	"github.com/flyteorg/flytepropeller/pkg/controller/outcomes"
+	"github.com/flyteorg/flytepropeller/pkg/controller/phasemetrics"
+	"github.com/flyteorg/flytepropeller/pkg/controller/tracing"
 	"github.com/flyteorg/flytepropeller/pkg/controller/workflow/errors"
 	"github.com/flyteorg/flytepropeller/pkg/utils"
 )
@@ -68,6 +74,9 @@ type workflowExecutor struct {
 	metrics         *workflowMetrics
 	eventConfig     *config.EventConfig
 	clusterID       string
+	nodeOutcomes    *outcomes.Store
+	identities      k8s.IdentityAllowlist
+	phaseMetrics    *phasemetrics.Recorder
 }
 
 func (c *workflowExecutor) constructWorkflowMetadataPrefix(ctx context.Context, w *v1alpha1.FlyteWorkflow) (storage.DataReference, error) {
@@ -80,6 +89,18 @@ func (c *workflowExecutor) constructWorkflowMetadataPrefix(ctx context.Context,
 	return c.store.ConstructReference(ctx, c.metadataPrefix, w.Name)
 }
 
+// Records the outcomes of the nodes of the terminated workflow, so that they can be reused by relaunches. This is best
+// effort, a relaunch of a workflow without recorded outcomes runs all its nodes.
+func (c *workflowExecutor) recordNodeOutcomes(ctx context.Context, w *v1alpha1.FlyteWorkflow) {
+	if c.nodeOutcomes == nil {
+		return
+	}
+
+	if err := c.nodeOutcomes.Write(ctx, w); err != nil {
+		logger.Warnf(ctx, "Failed to record the outcomes of the nodes of the workflow. Error: %v", err)
+	}
+}
+
 func (c *workflowExecutor) handleReadyWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) (Status, error) {
 
 	startNode := w.StartNode()
@@ -90,6 +111,13 @@ func (c *workflowExecutor) handleReadyWorkflow(ctx context.Context, w *v1alpha1.
 			Message: "StartNode not found."}), nil
 	}
 
+	if err := k8s.ValidateIdentities(w, c.identities); err != nil {
+		return StatusFailing(&core.ExecutionError{
+			Kind:    core.ExecutionError_USER,
+			Code:    errors.BadSpecificationError.String(),
+			Message: err.Error()}), nil
+	}
+
 	ref, err := c.constructWorkflowMetadataPrefix(ctx, w)
 	if err != nil {
 		return StatusFailing(&core.ExecutionError{
@@ -98,10 +126,14 @@ func (c *workflowExecutor) handleReadyWorkflow(ctx context.Context, w *v1alpha1.
 			Message: err.Error()}), nil
 	}
 	w.GetExecutionStatus().SetDataDir(ref)
-	var inputs *core.LiteralMap
-	if w.Inputs != nil {
-		inputs = w.Inputs.LiteralMap
+	inputs, err := withKickoffTimeInput(w)
+	if err != nil {
+		return StatusFailing(&core.ExecutionError{
+			Kind:    core.ExecutionError_USER,
+			Code:    errors.BadSpecificationError.String(),
+			Message: err.Error()}), nil
 	}
+
 	// Before starting the subworkflow, lets set the inputs for the Workflow. The inputs for a SubWorkflow are essentially
 	// Copy of the inputs to the Node
 	nodeStatus := w.GetNodeExecutionStatus(ctx, startNode.GetID())
@@ -153,6 +185,9 @@ func (c *workflowExecutor) handleRunningWorkflow(ctx context.Context, w *v1alpha
 		return StatusFailing(state.Err), nil
 	}
 	if state.HasTimedOut() {
+		if state.Err != nil {
+			return StatusFailing(state.Err), nil
+		}
 		return StatusFailing(&core.ExecutionError{
 			Kind:    core.ExecutionError_USER,
 			Code:    "Timeout",
@@ -171,30 +206,85 @@ func (c *workflowExecutor) handleFailureNode(ctx context.Context, w *v1alpha1.Fl
 	execErr := executionErrorOrDefault(w.GetExecutionStatus().GetExecutionError(), w.GetExecutionStatus().GetMessage())
 	errorNode := w.GetOnFailureNode()
 	execcontext := executors.NewExecutionContext(w, w, w, nil, executors.InitializeControlFlow())
-	state, err := c.nodeExecutor.RecursiveNodeHandler(ctx, execcontext, w, w, errorNode)
+	// The failure node looks up nodes through a FailureNodeLookup so that the original error can be passed to it.
+	nl := executors.NewFailureNodeLookup(ctx, w, w, execErr)
+	state, err := c.nodeExecutor.RecursiveNodeHandler(ctx, execcontext, w, nl, errorNode)
 	if err != nil {
 		return StatusFailureNode(execErr), err
 	}
 
+	finalErr := execErr
 	if state.HasFailed() {
-		return StatusFailed(state.Err), nil
-	}
-
-	if state.HasTimedOut() {
-		return StatusFailed(&core.ExecutionError{
+		finalErr = withFailureNodeError(execErr, errorNode.GetID(), executionErrorOrDefault(state.Err, "failure node failed"))
+	} else if state.HasTimedOut() {
+		finalErr = withFailureNodeError(execErr, errorNode.GetID(), &core.ExecutionError{
 			Kind:    core.ExecutionError_USER,
 			Code:    "TimedOut",
-			Message: "FailureNode Timed-out"}), nil
+			Message: "FailureNode Timed-out"})
+	} else if !state.IsComplete() {
+		// The failure node is still running
+		if state.PartiallyComplete() {
+			c.enqueueWorkflow(w.GetK8sWorkflowID().String())
+		}
+		return StatusFailureNode(execErr), nil
 	}
 
-	if state.PartiallyComplete() {
-		// Re-enqueue the workflow
+	// Once the failure node finished executing, the finally nodes run before transitioning to failed.
+	done, err := c.handleFinallyNodes(ctx, w, executors.TerminalStatus{Phase: v1alpha1.WorkflowPhaseFailed, Error: finalErr})
+	if err != nil || !done {
+		return StatusFailureNode(execErr), err
+	}
+
+	return StatusFailed(finalErr), nil
+}
+
+// Returns the error the workflow fails with when its failure node failed as well. The workflow fails with the original
+// error, which caused the failure node to run, and the error of the failure node is appended to its message so that
+// both are surfaced in the terminal event.
+func withFailureNodeError(originalErr *core.ExecutionError, failureNodeID v1alpha1.NodeID, failureNodeErr *core.ExecutionError) *core.ExecutionError {
+	return &core.ExecutionError{
+		Kind:     originalErr.GetKind(),
+		Code:     originalErr.GetCode(),
+		ErrorUri: originalErr.GetErrorUri(),
+		Message: fmt.Sprintf("%s\nThe failure node [%s] failed as well. Code: %s, Message: %s", originalErr.GetMessage(),
+			failureNodeID, failureNodeErr.GetCode(), failureNodeErr.GetMessage()),
+	}
+}
+
+// Executes the finally nodes of the workflow, which run once the rest of the workflow reached the terminal status, and
+// returns whether all of them completed. Finally nodes are independent of each other and of the DAG, so each is
+// handled as a leaf node. Their failures are logged, but do not change the terminal status of the workflow.
+func (c *workflowExecutor) handleFinallyNodes(ctx context.Context, w *v1alpha1.FlyteWorkflow, status executors.TerminalStatus) (bool, error) {
+	finallyNodes := w.GetFinallyNodes()
+	if len(finallyNodes) == 0 {
+		return true, nil
+	}
+
+	execcontext := executors.NewExecutionContext(w, w, w, nil, executors.InitializeControlFlow())
+	// The finally nodes look up nodes through a FinallyNodeLookup so that the terminal status can be passed to them.
+	nl := executors.NewFinallyNodeLookup(w, finallyNodes, status)
+	allCompleted := true
+	for _, n := range finallyNodes {
+		state, err := c.nodeExecutor.RecursiveNodeHandler(ctx, execcontext, executors.NewLeafNodeDAGStructure(n.GetID()), nl, n)
+		if err != nil {
+			return false, err
+		}
+
+		if state.HasFailed() || state.HasTimedOut() {
+			logger.Warningf(ctx, "Finally node [%s] did not succeed, the workflow remains [%s]", n.GetID(), status.Phase.String())
+			continue
+		}
+
+		if !state.IsComplete() {
+			allCompleted = false
+		}
+	}
+
+	if !allCompleted {
 		c.enqueueWorkflow(w.GetK8sWorkflowID().String())
-		return StatusFailureNode(execErr), nil
 	}
 
-	// If the failure node finished executing, transition to failed.
-	return StatusFailed(execErr), nil
+	return allCompleted, nil
 }
 
 func executionErrorOrDefault(execError *core.ExecutionError, fallbackMessage string) *core.ExecutionError {
@@ -213,21 +303,37 @@ func (c *workflowExecutor) handleFailingWorkflow(ctx context.Context, w *v1alpha
 	execErr := executionErrorOrDefault(w.GetExecutionStatus().GetExecutionError(), w.GetExecutionStatus().GetMessage())
 
 	// Best effort clean-up.
-	if err := c.cleanupRunningNodes(ctx, w, "Some node execution failed, auto-abort."); err != nil {
+	abortCtx, terminations := executors.WithTerminationTracker(ctx)
+	if err := c.cleanupRunningNodes(abortCtx, w, "Some node execution failed, auto-abort."); err != nil {
 		logger.Errorf(ctx, "Failed to propagate Abort for workflow:%v. Error: %v",
 			w.ExecutionID.WorkflowExecutionIdentifier, err)
 		return StatusFailing(execErr), err
 	}
 
+	if terminations.IsPending() {
+		logger.Infof(ctx, "Waiting for the executions terminated by the abort of workflow:%v", w.ExecutionID.WorkflowExecutionIdentifier)
+		return StatusFailing(execErr), nil
+	}
+
 	errorNode := w.GetOnFailureNode()
 	if errorNode != nil {
 		return StatusFailureNode(execErr), nil
 	}
 
+	done, err := c.handleFinallyNodes(ctx, w, executors.TerminalStatus{Phase: v1alpha1.WorkflowPhaseFailed, Error: execErr})
+	if err != nil || !done {
+		return StatusFailing(execErr), err
+	}
+
 	return StatusFailed(execErr), nil
 }
 
-func (c *workflowExecutor) handleSucceedingWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) Status {
+func (c *workflowExecutor) handleSucceedingWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) (Status, error) {
+	done, err := c.handleFinallyNodes(ctx, w, executors.TerminalStatus{Phase: v1alpha1.WorkflowPhaseSuccess})
+	if err != nil || !done {
+		return StatusSucceeding, err
+	}
+
 	logger.Infof(ctx, "Workflow completed successfully")
 	endNodeStatus := w.GetNodeExecutionStatus(ctx, v1alpha1.EndNodeID)
 	if endNodeStatus.GetPhase() == v1alpha1.NodePhaseSucceeded {
@@ -235,7 +341,7 @@ func (c *workflowExecutor) handleSucceedingWorkflow(ctx context.Context, w *v1al
 			w.Status.SetOutputReference(v1alpha1.GetOutputsFile(endNodeStatus.GetOutputDir()))
 		}
 	}
-	return StatusSuccess
+	return StatusSuccess, nil
 }
 
 func convertToExecutionError(err *core.ExecutionError, alternateErr *core.ExecutionError) *event.WorkflowExecutionEvent_Error {
@@ -267,13 +373,14 @@ func (c *workflowExecutor) IdempotentReportEvent(ctx context.Context, e *event.W
 
 func (c *workflowExecutor) TransitionToPhase(ctx context.Context, execID *core.WorkflowExecutionIdentifier, wStatus v1alpha1.ExecutableWorkflowStatus, toStatus Status) error {
 	if wStatus.GetPhase() != toStatus.TransitionToPhase {
-		logger.Debugf(ctx, "Transitioning/Recording event for workflow state transition [%s] -> [%s]", wStatus.GetPhase().String(), toStatus.TransitionToPhase.String())
+		tracing.Tracef(ctx, "Transitioning/Recording event for workflow state transition [%s] -> [%s]", wStatus.GetPhase().String(), toStatus.TransitionToPhase.String())
 
 		wfEvent := &event.WorkflowExecutionEvent{
 			ExecutionId: execID,
 			ProducerId:  c.clusterID,
 		}
 		previousError := wStatus.GetExecutionError()
+		previousPhase, enteredAt := wStatus.GetPhase(), wStatus.GetLastUpdatedAt()
 		switch toStatus.TransitionToPhase {
 		case v1alpha1.WorkflowPhaseReady:
 			// Do nothing
@@ -283,7 +390,12 @@ func (c *workflowExecutor) TransitionToPhase(ctx context.Context, execID *core.W
 			wStatus.UpdatePhase(v1alpha1.WorkflowPhaseRunning, "Workflow Started", nil)
 			wfEvent.OccurredAt = utils.GetProtoTime(wStatus.GetStartedAt())
 		case v1alpha1.WorkflowPhaseHandlingFailureNode:
-			fallthrough
+			// The failing event was recorded when the workflow started failing, it's recorded again in case the workflow
+			// went straight to handling its failure node.
+			wfEvent.Phase = core.WorkflowExecution_FAILING
+			wfEvent.OutputResult = convertToExecutionError(toStatus.Err, previousError)
+			wStatus.UpdatePhase(v1alpha1.WorkflowPhaseHandlingFailureNode, "Running the failure node", wfEvent.GetError())
+			wfEvent.OccurredAt = utils.GetProtoTime(nil)
 		case v1alpha1.WorkflowPhaseFailing:
 			wfEvent.Phase = core.WorkflowExecution_FAILING
 			wfEvent.OutputResult = convertToExecutionError(toStatus.Err, previousError)
@@ -329,6 +441,8 @@ func (c *workflowExecutor) TransitionToPhase(ctx context.Context, execID *core.W
 			return errors.Errorf(errors.IllegalStateError, "", "Illegal transition from [%v] -> [%v]", wStatus.GetPhase().String(), toStatus.TransitionToPhase.String())
 		}
 
+		c.phaseMetrics.Record(ctx, previousPhase, wStatus.GetPhase(), enteredAt, wStatus.GetLastUpdatedAt(), phasemetrics.Cause(wfEvent.GetError()))
+
 		if recordingErr := c.IdempotentReportEvent(ctx, wfEvent); recordingErr != nil {
 			if eventsErr.IsAlreadyExists(recordingErr) {
 				logger.Warningf(ctx, "Failed to record workflowEvent, error [%s]. Trying to record state: %s. Ignoring this error!", recordingErr.Error(), wfEvent.Phase)
@@ -360,11 +474,15 @@ func (c *workflowExecutor) Initialize(ctx context.Context) error {
 	return c.nodeExecutor.Initialize(ctx)
 }
 
-func (c *workflowExecutor) HandleFlyteWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) error {
+func (c *workflowExecutor) HandleFlyteWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "workflow.handle", attribute.String("flyte.workflow_phase", w.GetExecutionStatus().GetPhase().String()))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	logger.Infof(ctx, "Handling Workflow [%s], id: [%s], p [%s]", w.GetName(), w.GetExecutionID(), w.GetExecutionStatus().GetPhase().String())
 	defer logger.Infof(ctx, "Handling Workflow [%s] Done", w.GetName())
 
 	w.DataReferenceConstructor = c.store
+	w.Status.SetEvaluationLock(&sync.RWMutex{})
 
 	wStatus := w.GetExecutionStatus()
 	// Initialize the Status if not already initialized
@@ -400,12 +518,18 @@ func (c *workflowExecutor) HandleFlyteWorkflow(ctx context.Context, w *v1alpha1.
 		}
 		return nil
 	case v1alpha1.WorkflowPhaseSucceeding:
-		newStatus := c.handleSucceedingWorkflow(ctx, w)
+		newStatus, err := c.handleSucceedingWorkflow(ctx, w)
+		if err != nil {
+			return err
+		}
 
 		if err := c.TransitionToPhase(ctx, w.ExecutionID.WorkflowExecutionIdentifier, wStatus, newStatus); err != nil {
 			return err
 		}
-		c.k8sRecorder.Event(w, corev1.EventTypeNormal, v1alpha1.WorkflowPhaseSuccess.String(), "Workflow completed.")
+		if newStatus.TransitionToPhase == v1alpha1.WorkflowPhaseSuccess {
+			c.k8sRecorder.Event(w, corev1.EventTypeNormal, v1alpha1.WorkflowPhaseSuccess.String(), "Workflow completed.")
+			c.recordNodeOutcomes(ctx, w)
+		}
 		return nil
 	case v1alpha1.WorkflowPhaseFailing:
 		newStatus, err := c.handleFailingWorkflow(ctx, w)
@@ -417,7 +541,10 @@ func (c *workflowExecutor) HandleFlyteWorkflow(ctx context.Context, w *v1alpha1.
 		if failingErr != nil && !(eventsErr.IsNotFound(failingErr) || eventsErr.IsEventIncompatibleClusterError(failingErr)) {
 			return failingErr
 		}
-		c.k8sRecorder.Event(w, corev1.EventTypeWarning, v1alpha1.WorkflowPhaseFailed.String(), "Workflow failed.")
+		if newStatus.TransitionToPhase == v1alpha1.WorkflowPhaseFailed {
+			c.k8sRecorder.Event(w, corev1.EventTypeWarning, v1alpha1.WorkflowPhaseFailed.String(), "Workflow failed.")
+			c.recordNodeOutcomes(ctx, w)
+		}
 		return nil
 	case v1alpha1.WorkflowPhaseHandlingFailureNode:
 		newStatus, err := c.handleFailureNode(ctx, w)
@@ -429,7 +556,10 @@ func (c *workflowExecutor) HandleFlyteWorkflow(ctx context.Context, w *v1alpha1.
 		if failureErr != nil && !(eventsErr.IsNotFound(failureErr) || eventsErr.IsEventIncompatibleClusterError(failureErr)) {
 			return failureErr
 		}
-		c.k8sRecorder.Event(w, corev1.EventTypeWarning, v1alpha1.WorkflowPhaseFailed.String(), "Workflow failed.")
+		if newStatus.TransitionToPhase == v1alpha1.WorkflowPhaseFailed {
+			c.k8sRecorder.Event(w, corev1.EventTypeWarning, v1alpha1.WorkflowPhaseFailed.String(), "Workflow failed.")
+			c.recordNodeOutcomes(ctx, w)
+		}
 		return nil
 	default:
 		return errors.Errorf(errors.IllegalStateError, w.ID, "Unsupported state [%s] for workflow", w.GetExecutionStatus().GetPhase().String())
@@ -438,6 +568,7 @@ func (c *workflowExecutor) HandleFlyteWorkflow(ctx context.Context, w *v1alpha1.
 
 func (c *workflowExecutor) HandleAbortedWorkflow(ctx context.Context, w *v1alpha1.FlyteWorkflow, maxRetries uint32) error {
 	w.DataReferenceConstructor = c.store
+	w.Status.SetEvaluationLock(&sync.RWMutex{})
 	if !w.Status.IsTerminated() {
 		reason := fmt.Sprintf("max number of system retry attempts [%d/%d] exhausted - system failure.", w.Status.FailedAttempts, maxRetries)
 		c.metrics.IncompleteWorkflowAborted.Inc(ctx)
@@ -448,7 +579,18 @@ func (c *workflowExecutor) HandleAbortedWorkflow(ctx context.Context, w *v1alpha
 
 		// We will always try to cleanup, even if we have extinguished all our retries
 		// TODO ABORT should have its separate set of retries
-		err := c.cleanupRunningNodes(ctx, w, reason)
+		abortCtx, terminations := executors.WithTerminationTracker(ctx)
+		err := c.cleanupRunningNodes(abortCtx, w, reason)
+		if err == nil && w.GetExecutionStatus().GetPhase() == v1alpha1.WorkflowPhaseHandlingFailureNode {
+			err = c.abortFailureNode(abortCtx, w, reason)
+		}
+
+		// The abort is repeated on the next round until the executions terminated by the nodes reached a terminal
+		// phase, which is bounded by the termination grace period.
+		if err == nil && terminations.IsPending() {
+			logger.Infof(ctx, "Waiting for the executions terminated by the abort of workflow:%v", w.ExecutionID.WorkflowExecutionIdentifier)
+			return nil
+		}
 		// Best effort clean-up.
 		if err != nil && w.Status.FailedAttempts <= maxRetries {
 			logger.Errorf(ctx, "Failed to propagate Abort for workflow:%v. Error: %v", w.ExecutionID.WorkflowExecutionIdentifier, err)
@@ -459,6 +601,14 @@ func (c *workflowExecutor) HandleAbortedWorkflow(ctx context.Context, w *v1alpha
 			err = errors.Errorf(errors.RuntimeExecutionError, w.GetID(), "max number of system retry attempts [%d/%d] exhausted. Last known status message: %v", w.Status.FailedAttempts, maxRetries, w.Status.Message)
 		}
 
+		if err == nil {
+			// The finally nodes run before the workflow is recorded as aborted, unless it exhausted its system retries.
+			done, err := c.handleFinallyNodes(ctx, w, executors.TerminalStatus{Phase: v1alpha1.WorkflowPhaseAborted})
+			if err != nil || !done {
+				return err
+			}
+		}
+
 		var status Status
 		if err != nil {
 			// This workflow failed, record that phase and corresponding error message.
@@ -476,6 +626,8 @@ func (c *workflowExecutor) HandleAbortedWorkflow(ctx context.Context, w *v1alpha
 		if err := c.TransitionToPhase(ctx, w.ExecutionID.WorkflowExecutionIdentifier, w.GetExecutionStatus(), status); err != nil {
 			return err
 		}
+
+		c.recordNodeOutcomes(ctx, w)
 	}
 	return nil
 }
@@ -494,6 +646,24 @@ func (c *workflowExecutor) cleanupRunningNodes(ctx context.Context, w v1alpha1.E
 	return nil
 }
 
+// Aborts the failure node of the workflow, which is not part of its DAG, if the workflow is aborted while the failure
+// node runs.
+func (c *workflowExecutor) abortFailureNode(ctx context.Context, w *v1alpha1.FlyteWorkflow, reason string) error {
+	errorNode := w.GetOnFailureNode()
+	if errorNode == nil {
+		return nil
+	}
+
+	execErr := executionErrorOrDefault(w.GetExecutionStatus().GetExecutionError(), w.GetExecutionStatus().GetMessage())
+	execcontext := executors.NewExecutionContext(w, w, w, nil, executors.InitializeControlFlow())
+	nl := executors.NewFailureNodeLookup(ctx, w, w, execErr)
+	if err := c.nodeExecutor.AbortHandler(ctx, execcontext, executors.NewLeafNodeDAGStructure(errorNode.GetID()), nl, errorNode, reason); err != nil {
+		return errors.Errorf(errors.CausedByError, w.GetID(), "Failed to propagate Abort to the failure node. Error: %v", err)
+	}
+
+	return nil
+}
+
 func NewExecutor(ctx context.Context, store *storage.DataStore, enQWorkflow v1alpha1.EnqueueWorkflow, eventSink events.EventSink,
 	k8sEventRecorder record.EventRecorder, metadataPrefix string, nodeExecutor executors.Node, eventConfig *config.EventConfig,
 	clusterID string, scope promutils.Scope) (executors.Workflow, error) {
@@ -507,6 +677,11 @@ func NewExecutor(ctx context.Context, store *storage.DataStore, enQWorkflow v1al
 	}
 	logger.Infof(ctx, "Metadata will be stored in container path: [%s]", basePrefix)
 
+	nodeOutcomes, err := outcomes.NewStore(ctx, config.GetConfig().NodeOutcomes, store, metadataPrefix)
+	if err != nil {
+		return nil, err
+	}
+
 	workflowScope := scope.NewSubScope("workflow")
 
 	return &workflowExecutor{
@@ -519,6 +694,12 @@ func NewExecutor(ctx context.Context, store *storage.DataStore, enQWorkflow v1al
 		metrics:         newMetrics(workflowScope),
 		eventConfig:     eventConfig,
 		clusterID:       clusterID,
+		nodeOutcomes:    nodeOutcomes,
+		phaseMetrics:    phasemetrics.NewRecorder(config.GetConfig().PhaseMetrics, workflowScope),
+		identities: k8s.IdentityAllowlist{
+			ServiceAccounts: config.GetConfig().NodeConfig.Identity.AllowedServiceAccounts,
+			IamRoles:        config.GetConfig().NodeConfig.Identity.AllowedIamRoles,
+		},
 	}, nil
 }
 
@@ -31,6 +31,7 @@ import (
 	"github.com/flyteorg/flytepropeller/events"
 	eventsErr "github.com/flyteorg/flytepropeller/events/errors"
 	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/controller/executors"
 	mocks2 "github.com/flyteorg/flytepropeller/pkg/controller/executors/mocks"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/catalog"
 	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/fakeplugins"
@@ -739,7 +740,7 @@ func TestWorkflowExecutor_HandleAbortedWorkflow(t *testing.T) {
 			metrics:      newMetrics(promutils.NewTestScope()),
 		}
 
-		nodeExec.OnAbortHandlerMatch(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("error"))
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("error"))
 
 		w := &v1alpha1.FlyteWorkflow{
 			ObjectMeta: v1.ObjectMeta{
@@ -780,7 +781,7 @@ func TestWorkflowExecutor_HandleAbortedWorkflow(t *testing.T) {
 			clusterID: testClusterID,
 		}
 
-		nodeExec.OnAbortHandlerMatch(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 		w := &v1alpha1.FlyteWorkflow{
 			ObjectMeta: v1.ObjectMeta{
@@ -822,7 +823,7 @@ func TestWorkflowExecutor_HandleAbortedWorkflow(t *testing.T) {
 			clusterID: testClusterID,
 		}
 
-		nodeExec.OnAbortHandlerMatch(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 		w := &v1alpha1.FlyteWorkflow{
 			ObjectMeta: v1.ObjectMeta{
@@ -863,7 +864,7 @@ func TestWorkflowExecutor_HandleAbortedWorkflow(t *testing.T) {
 			clusterID: testClusterID,
 		}
 
-		nodeExec.OnAbortHandlerMatch(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 		w := &v1alpha1.FlyteWorkflow{
 			Status: v1alpha1.WorkflowStatus{
@@ -890,7 +891,7 @@ func TestWorkflowExecutor_HandleAbortedWorkflow(t *testing.T) {
 			metrics:      newMetrics(promutils.NewTestScope()),
 		}
 
-		nodeExec.OnAbortHandlerMatch(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("err"))
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("err"))
 
 		w := &v1alpha1.FlyteWorkflow{
 			Status: v1alpha1.WorkflowStatus{
@@ -907,4 +908,228 @@ func TestWorkflowExecutor_HandleAbortedWorkflow(t *testing.T) {
 
 		assert.Equal(t, uint32(1), w.Status.FailedAttempts)
 	})
+
+	t.Run("termination-pending", func(t *testing.T) {
+
+		nodeExec := &mocks2.Node{}
+		wfRecorder := &eventMocks.WorkflowEventRecorder{}
+		wExec := &workflowExecutor{
+			nodeExecutor: nodeExec,
+			wfRecorder:   wfRecorder,
+			metrics:      newMetrics(promutils.NewTestScope()),
+		}
+
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			executors.SetTerminationPending(args.Get(0).(context.Context))
+		}).Return(nil)
+
+		w := &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{
+				DeletionTimestamp: &v1.Time{},
+			},
+			Status: v1alpha1.WorkflowStatus{
+				Phase:          v1alpha1.WorkflowPhaseRunning,
+				FailedAttempts: 1,
+			},
+			WorkflowSpec: &v1alpha1.WorkflowSpec{
+				Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+					v1alpha1.StartNodeID: {},
+				},
+			},
+		}
+
+		// The workflow keeps aborting without counting a failed attempt until the terminations completed.
+		assert.NoError(t, wExec.HandleAbortedWorkflow(ctx, w, 5))
+		assert.Equal(t, v1alpha1.WorkflowPhaseRunning, w.Status.Phase)
+		assert.Equal(t, uint32(1), w.Status.FailedAttempts)
+		wfRecorder.AssertNotCalled(t, "RecordWorkflowEvent", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkflowExecutor_FinallyNodes(t *testing.T) {
+	ctx := context.TODO()
+	execErr := &core.ExecutionError{Code: "code", Message: "msg", Kind: core.ExecutionError_USER}
+
+	newWorkflow := func(phase v1alpha1.WorkflowPhase) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+				WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "n"},
+			},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: phase,
+				Error: &v1alpha1.ExecutionError{ExecutionError: execErr},
+			},
+			WorkflowSpec: &v1alpha1.WorkflowSpec{
+				Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+					v1alpha1.StartNodeID: {ID: v1alpha1.StartNodeID},
+				},
+				Finally: []*v1alpha1.NodeSpec{{ID: "finally"}},
+			},
+		}
+	}
+
+	newExecutor := func(t *testing.T, finallyState executors.NodeStatus, terminalPhase v1alpha1.WorkflowPhase) (*workflowExecutor, *[]*event.WorkflowExecutionEvent, *int) {
+		var evs []*event.WorkflowExecutionEvent
+		enqueued := 0
+		nodeExec := &mocks2.Node{}
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		nodeExec.OnRecursiveNodeHandlerMatch(ctx, mock.Anything, mock.Anything, mock.MatchedBy(func(nl executors.NodeLookup) bool {
+			fl, ok := nl.(executors.FinallyNodeLookup)
+			return ok && fl.GetTerminalStatus().Phase == terminalPhase
+		}), mock.MatchedBy(func(n v1alpha1.ExecutableNode) bool {
+			return n.GetID() == "finally"
+		})).Return(finallyState, nil)
+
+		wfRecorder := &eventMocks.WorkflowEventRecorder{}
+		wfRecorder.OnRecordWorkflowEventMatch(mock.Anything, mock.MatchedBy(func(ev *event.WorkflowExecutionEvent) bool {
+			evs = append(evs, ev)
+			return true
+		}), mock.Anything).Return(nil)
+
+		return &workflowExecutor{
+			nodeExecutor: nodeExec,
+			wfRecorder:   wfRecorder,
+			k8sRecorder:  record.NewFakeRecorder(10),
+			metrics:      newMetrics(promutils.NewTestScope()),
+			eventConfig:  &config.EventConfig{},
+			enqueueWorkflow: func(workflowID v1alpha1.WorkflowID) {
+				enqueued++
+			},
+		}, &evs, &enqueued
+	}
+
+	t.Run("succeeding-running", func(t *testing.T) {
+		wExec, evs, enqueued := newExecutor(t, executors.NodeStatusRunning, v1alpha1.WorkflowPhaseSuccess)
+		w := newWorkflow(v1alpha1.WorkflowPhaseSucceeding)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseSucceeding, w.Status.Phase)
+		assert.Empty(t, *evs)
+		assert.Equal(t, 1, *enqueued)
+	})
+
+	t.Run("succeeding-complete", func(t *testing.T) {
+		wExec, evs, _ := newExecutor(t, executors.NodeStatusComplete, v1alpha1.WorkflowPhaseSuccess)
+		w := newWorkflow(v1alpha1.WorkflowPhaseSucceeding)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseSuccess, w.Status.Phase)
+		assert.Len(t, *evs, 1)
+	})
+
+	t.Run("failing-finally-failed", func(t *testing.T) {
+		wExec, evs, _ := newExecutor(t, executors.NodeStatusFailed(&core.ExecutionError{Code: "finally"}), v1alpha1.WorkflowPhaseFailed)
+		w := newWorkflow(v1alpha1.WorkflowPhaseFailing)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseFailed, w.Status.Phase)
+		if assert.Len(t, *evs, 1) {
+			assert.Equal(t, "code", (*evs)[0].GetError().GetCode())
+		}
+	})
+
+	t.Run("aborted-running", func(t *testing.T) {
+		wExec, evs, enqueued := newExecutor(t, executors.NodeStatusRunning, v1alpha1.WorkflowPhaseAborted)
+		w := newWorkflow(v1alpha1.WorkflowPhaseRunning)
+		w.DeletionTimestamp = &v1.Time{}
+		assert.NoError(t, wExec.HandleAbortedWorkflow(ctx, w, 5))
+		assert.Equal(t, v1alpha1.WorkflowPhaseRunning, w.Status.Phase)
+		assert.Empty(t, *evs)
+		assert.Equal(t, 1, *enqueued)
+	})
+
+	t.Run("aborted-complete", func(t *testing.T) {
+		wExec, evs, _ := newExecutor(t, executors.NodeStatusComplete, v1alpha1.WorkflowPhaseAborted)
+		w := newWorkflow(v1alpha1.WorkflowPhaseRunning)
+		w.DeletionTimestamp = &v1.Time{}
+		assert.NoError(t, wExec.HandleAbortedWorkflow(ctx, w, 5))
+		assert.Equal(t, v1alpha1.WorkflowPhaseAborted, w.Status.Phase)
+		assert.Len(t, *evs, 1)
+	})
+}
+
+func TestWorkflowExecutor_FailureNode(t *testing.T) {
+	ctx := context.TODO()
+	execErr := &core.ExecutionError{Code: "code", Message: "msg", Kind: core.ExecutionError_USER}
+
+	newWorkflow := func(phase v1alpha1.WorkflowPhase) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+				WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "n"},
+			},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: phase,
+				Error: &v1alpha1.ExecutionError{ExecutionError: execErr},
+			},
+			WorkflowSpec: &v1alpha1.WorkflowSpec{
+				Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+					v1alpha1.StartNodeID: {ID: v1alpha1.StartNodeID},
+				},
+				OnFailure: &v1alpha1.NodeSpec{ID: "on-failure"},
+			},
+		}
+	}
+
+	newExecutor := func(t *testing.T, failureNodeState executors.NodeStatus) (*workflowExecutor, *[]*event.WorkflowExecutionEvent) {
+		var evs []*event.WorkflowExecutionEvent
+		nodeExec := &mocks2.Node{}
+		nodeExec.OnAbortHandlerMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		nodeExec.OnRecursiveNodeHandlerMatch(ctx, mock.Anything, mock.Anything, mock.MatchedBy(func(nl executors.NodeLookup) bool {
+			_, ok := nl.(executors.FailureNodeLookup)
+			return ok
+		}), mock.MatchedBy(func(n v1alpha1.ExecutableNode) bool {
+			return n.GetID() == "on-failure"
+		})).Return(failureNodeState, nil)
+
+		wfRecorder := &eventMocks.WorkflowEventRecorder{}
+		wfRecorder.OnRecordWorkflowEventMatch(mock.Anything, mock.MatchedBy(func(ev *event.WorkflowExecutionEvent) bool {
+			evs = append(evs, ev)
+			return true
+		}), mock.Anything).Return(nil)
+
+		return &workflowExecutor{
+			nodeExecutor:    nodeExec,
+			wfRecorder:      wfRecorder,
+			k8sRecorder:     record.NewFakeRecorder(10),
+			metrics:         newMetrics(promutils.NewTestScope()),
+			eventConfig:     &config.EventConfig{},
+			enqueueWorkflow: func(workflowID v1alpha1.WorkflowID) {},
+		}, &evs
+	}
+
+	t.Run("failing", func(t *testing.T) {
+		wExec, _ := newExecutor(t, executors.NodeStatusRunning)
+		w := newWorkflow(v1alpha1.WorkflowPhaseFailing)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseHandlingFailureNode, w.Status.Phase)
+		assert.Equal(t, "code", w.Status.GetExecutionError().GetCode())
+	})
+
+	t.Run("failure-node-running", func(t *testing.T) {
+		wExec, evs := newExecutor(t, executors.NodeStatusRunning)
+		w := newWorkflow(v1alpha1.WorkflowPhaseHandlingFailureNode)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseHandlingFailureNode, w.Status.Phase)
+		assert.Empty(t, *evs)
+	})
+
+	t.Run("failure-node-succeeded", func(t *testing.T) {
+		wExec, evs := newExecutor(t, executors.NodeStatusComplete)
+		w := newWorkflow(v1alpha1.WorkflowPhaseHandlingFailureNode)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseFailed, w.Status.Phase)
+		if assert.Len(t, *evs, 1) {
+			assert.Equal(t, "code", (*evs)[0].GetError().GetCode())
+			assert.Equal(t, "msg", (*evs)[0].GetError().GetMessage())
+		}
+	})
+
+	t.Run("failure-node-failed", func(t *testing.T) {
+		wExec, evs := newExecutor(t, executors.NodeStatusFailed(&core.ExecutionError{Code: "cleanup", Message: "boom"}))
+		w := newWorkflow(v1alpha1.WorkflowPhaseHandlingFailureNode)
+		assert.NoError(t, wExec.HandleFlyteWorkflow(ctx, w))
+		assert.Equal(t, v1alpha1.WorkflowPhaseFailed, w.Status.Phase)
+		if assert.Len(t, *evs, 1) {
+			assert.Equal(t, "code", (*evs)[0].GetError().GetCode())
+			assert.Contains(t, (*evs)[0].GetError().GetMessage(), "msg")
+			assert.Contains(t, (*evs)[0].GetError().GetMessage(), "[on-failure] failed as well. Code: cleanup, Message: boom")
+		}
+	})
 }
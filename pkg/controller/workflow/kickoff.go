@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+const (
+	// ScheduledTimeAnnotation carries the time, in RFC3339 format, an execution was scheduled for. Schedules and
+	// backfills set it so that re-running an execution for the same schedule produces the same kickoff time.
+	ScheduledTimeAnnotation = "flyte.org/scheduled-time"
+	// KickoffTimeInputAnnotation optionally overrides the name of the input the scheduled time is materialized as.
+	KickoffTimeInputAnnotation = "flyte.org/kickoff-time-input"
+	// DefaultKickoffTimeInput is the name of the input the scheduled time is materialized as by default.
+	DefaultKickoffTimeInput = "kickoff_time"
+)
+
+// Returns the inputs of the workflow with the scheduled time of the workflow materialized as its kickoff time input.
+// The scheduled time takes precedence over a kickoff time input that was passed in, so that all nodes of a backfilled
+// execution, e.g. when they resolve the data partition to work on, see the time the execution was scheduled for rather
+// than the time it actually started. The scheduled time is only materialized if the interface of the workflow declares
+// the kickoff time input as a datetime, workflows without the scheduled time annotation or without such an input keep
+// their inputs as they are.
+func withKickoffTimeInput(w *v1alpha1.FlyteWorkflow) (*core.LiteralMap, error) {
+	var inputs *core.LiteralMap
+	if w.Inputs != nil {
+		inputs = w.Inputs.LiteralMap
+	}
+
+	scheduledTime, ok := w.GetAnnotations()[ScheduledTimeAnnotation]
+	if !ok {
+		return inputs, nil
+	}
+
+	inputName := DefaultKickoffTimeInput
+	if name, ok := w.GetAnnotations()[KickoffTimeInputAnnotation]; ok && len(name) > 0 {
+		inputName = name
+	}
+
+	if !declaresKickoffTimeInput(w, inputName) {
+		return inputs, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, scheduledTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduled time [%v], expected RFC3339: %v", scheduledTime, err)
+	}
+
+	datetime, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduled time [%v]: %v", scheduledTime, err)
+	}
+
+	// The literal map of the inputs is shared with copies of the workflow, it must not be modified in place.
+	literals := make(map[string]*core.Literal, len(inputs.GetLiterals())+1)
+	for k, v := range inputs.GetLiterals() {
+		literals[k] = v
+	}
+
+	literals[inputName] = &core.Literal{
+		Value: &core.Literal_Scalar{
+			Scalar: &core.Scalar{
+				Value: &core.Scalar_Primitive{
+					Primitive: &core.Primitive{Value: &core.Primitive_Datetime{Datetime: datetime}},
+				},
+			},
+		},
+	}
+
+	return &core.LiteralMap{Literals: literals}, nil
+}
+
+func declaresKickoffTimeInput(w *v1alpha1.FlyteWorkflow, inputName string) bool {
+	if w.WorkflowSpec == nil || w.GetInputVars() == nil {
+		return false
+	}
+
+	variable, ok := w.GetInputVars().GetVariables()[inputName]
+	return ok && variable.GetType().GetSimple() == core.SimpleType_DATETIME
+}
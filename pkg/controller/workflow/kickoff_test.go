@@ -0,0 +1,109 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func TestWithKickoffTimeInput(t *testing.T) {
+	datetime := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_DATETIME}}
+	newWorkflow := func(annotations map[string]string) *v1alpha1.FlyteWorkflow {
+		return &v1alpha1.FlyteWorkflow{
+			ObjectMeta: v1.ObjectMeta{Annotations: annotations},
+			WorkflowSpec: &v1alpha1.WorkflowSpec{
+				InputVars: &v1alpha1.InputVarMap{VariableMap: &core.VariableMap{
+					Variables: map[string]*core.Variable{
+						"x":            {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+						"kickoff_time": {Type: datetime},
+						"run_date":     {Type: datetime},
+					},
+				}},
+			},
+			Inputs: &v1alpha1.Inputs{LiteralMap: &core.LiteralMap{
+				Literals: map[string]*core.Literal{
+					"x":            coreutils.MustMakeLiteral(1),
+					"kickoff_time": coreutils.MustMakeLiteral(time.Now()),
+				},
+			}},
+		}
+	}
+
+	kickoffTime := func(t *testing.T, l *core.Literal) time.Time {
+		ts, err := ptypes.Timestamp(l.GetScalar().GetPrimitive().GetDatetime())
+		assert.NoError(t, err)
+		return ts
+	}
+
+	t.Run("not scheduled", func(t *testing.T) {
+		w := newWorkflow(nil)
+		inputs, err := withKickoffTimeInput(w)
+		assert.NoError(t, err)
+		assert.Equal(t, w.Inputs.LiteralMap, inputs)
+	})
+
+	t.Run("no inputs", func(t *testing.T) {
+		inputs, err := withKickoffTimeInput(&v1alpha1.FlyteWorkflow{})
+		assert.NoError(t, err)
+		assert.Nil(t, inputs)
+	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		w := newWorkflow(map[string]string{ScheduledTimeAnnotation: "2021-06-01T10:00:00Z"})
+		inputs, err := withKickoffTimeInput(w)
+		assert.NoError(t, err)
+		assert.Len(t, inputs.GetLiterals(), 2)
+		assert.Equal(t, time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC), kickoffTime(t, inputs.GetLiterals()[DefaultKickoffTimeInput]))
+		// The inputs of the workflow itself are left untouched.
+		assert.NotEqual(t, inputs.GetLiterals()[DefaultKickoffTimeInput], w.Inputs.GetLiterals()[DefaultKickoffTimeInput])
+	})
+
+	t.Run("custom input name", func(t *testing.T) {
+		w := newWorkflow(map[string]string{
+			ScheduledTimeAnnotation:    "2021-06-01T10:00:00+02:00",
+			KickoffTimeInputAnnotation: "run_date",
+		})
+		inputs, err := withKickoffTimeInput(w)
+		assert.NoError(t, err)
+		assert.Len(t, inputs.GetLiterals(), 3)
+		assert.Equal(t, time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC), kickoffTime(t, inputs.GetLiterals()["run_date"]))
+	})
+
+	t.Run("not declared", func(t *testing.T) {
+		w := newWorkflow(map[string]string{ScheduledTimeAnnotation: "2021-06-01T10:00:00Z"})
+		w.InputVars = nil
+		inputs, err := withKickoffTimeInput(w)
+		assert.NoError(t, err)
+		assert.Equal(t, w.Inputs.LiteralMap, inputs)
+
+		w = newWorkflow(map[string]string{
+			ScheduledTimeAnnotation:    "2021-06-01T10:00:00Z",
+			KickoffTimeInputAnnotation: "other",
+		})
+		inputs, err = withKickoffTimeInput(w)
+		assert.NoError(t, err)
+		assert.Equal(t, w.Inputs.LiteralMap, inputs)
+	})
+
+	t.Run("declared with another type", func(t *testing.T) {
+		w := newWorkflow(map[string]string{
+			ScheduledTimeAnnotation:    "2021-06-01T10:00:00Z",
+			KickoffTimeInputAnnotation: "x",
+		})
+		inputs, err := withKickoffTimeInput(w)
+		assert.NoError(t, err)
+		assert.Equal(t, w.Inputs.LiteralMap, inputs)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := withKickoffTimeInput(newWorkflow(map[string]string{ScheduledTimeAnnotation: "yesterday"}))
+		assert.Error(t, err)
+	})
+}
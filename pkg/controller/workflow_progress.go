@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/events"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// The max number of running workflows whose last progress event is remembered. Workflows that are forgotten may have
+// their progress emitted again before the interval passed.
+const workflowProgressCacheSize = 10000
+
+type workflowProgressMetrics struct {
+	emitted  prometheus.Counter
+	failures prometheus.Counter
+}
+
+// workflowProgressReporter emits WorkflowProgressEvents for running workflows, so that consumers can show the progress of
+// executions without aggregating the events of all their nodes. The progress of a workflow is emitted at most once per
+// interval.
+type workflowProgressReporter struct {
+	sink      events.EventSink
+	clusterID string
+	interval  time.Duration
+	clk       clock.Clock
+	// Keyed by the namespaced name of workflows, entries expire once the interval passed.
+	reported *cache.LRUExpireCache
+	metrics  workflowProgressMetrics
+}
+
+// Computes the progress of the nodes of the workflow. Only the nodes of the workflow itself are counted, the nodes of
+// its subworkflows and dynamic workflows are part of the progress of their parent node.
+func computeWorkflowProgress(w *v1alpha1.FlyteWorkflow) events.WorkflowProgress {
+	progress := events.WorkflowProgress{}
+	downstream := w.WorkflowSpec.GetConnections().Downstream
+	// Length of the longest chain of nodes downstream of every node, memoized as the workflow is a DAG.
+	chainLengths := map[v1alpha1.NodeID]int{}
+	var chainLength func(id v1alpha1.NodeID) int
+	chainLength = func(id v1alpha1.NodeID) int {
+		if l, ok := chainLengths[id]; ok {
+			return l
+		}
+
+		l := 0
+		for _, child := range downstream[id] {
+			if childLength := chainLength(child) + 1; childLength > l {
+				l = childLength
+			}
+		}
+
+		chainLengths[id] = l
+		return l
+	}
+
+	ids := w.WorkflowSpec.GetNodes()
+	// Sorted, so that ties between candidates for the critical path are broken the same way every time.
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	criticalPathLength := -1
+	completed := 0
+	for _, id := range ids {
+		if id == v1alpha1.StartNodeID || id == v1alpha1.EndNodeID {
+			continue
+		}
+
+		progress.TotalNodes++
+		phase := v1alpha1.NodePhaseNotYetStarted
+		if nodeStatus, ok := w.Status.NodeStatus[id]; ok && nodeStatus != nil {
+			phase = nodeStatus.GetPhase()
+		}
+
+		switch phase {
+		case v1alpha1.NodePhaseSucceeded, v1alpha1.NodePhaseRecovered:
+			progress.SucceededNodes++
+			completed++
+		case v1alpha1.NodePhaseFailed, v1alpha1.NodePhaseTimedOut:
+			progress.FailedNodes++
+			completed++
+		case v1alpha1.NodePhaseSkipped:
+			completed++
+		case v1alpha1.NodePhaseNotYetStarted:
+		default:
+			progress.RunningNodes++
+			if l := chainLength(id); l > criticalPathLength {
+				criticalPathLength = l
+				progress.CriticalPathNodeID = id
+			}
+		}
+	}
+
+	if progress.TotalNodes > 0 {
+		progress.PercentComplete = float64(completed) * 100 / float64(progress.TotalNodes)
+	}
+
+	return progress
+}
+
+// Report emits the progress of the workflow, unless its progress was emitted within the interval or the workflow
+// terminated. Failures to emit the progress are logged, they do not fail the round.
+func (r *workflowProgressReporter) Report(ctx context.Context, w *v1alpha1.FlyteWorkflow) {
+	if r == nil {
+		return
+	}
+
+	key := w.GetK8sWorkflowID().String()
+	if w.GetExecutionStatus().IsTerminated() {
+		r.reported.Remove(key)
+		return
+	}
+
+	if _, ok := r.reported.Get(key); ok {
+		return
+	}
+
+	progress := computeWorkflowProgress(w)
+	progress.ExecutionID = w.GetExecutionID().WorkflowExecutionIdentifier
+	progress.ProducerID = r.clusterID
+	progress.OccurredAt = r.clk.Now()
+	if err := r.sink.Sink(ctx, events.NewWorkflowProgressEvent(progress)); err != nil {
+		logger.Warnf(ctx, "Failed to emit the progress of workflow [%v]. Error: %v", key, err)
+		r.metrics.failures.Inc()
+		return
+	}
+
+	r.reported.Add(key, progress.OccurredAt, r.interval)
+	r.metrics.emitted.Inc()
+}
+
+// Creates the workflowProgressReporter, or returns nil if progress events are disabled.
+func newWorkflowProgressReporter(cfg config.WorkflowProgressConfig, clusterID string, sink events.EventSink,
+	clk clock.Clock, scope promutils.Scope) *workflowProgressReporter {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &workflowProgressReporter{
+		sink:      sink,
+		clusterID: clusterID,
+		interval:  cfg.Interval.Duration,
+		clk:       clk,
+		reported:  cache.NewLRUExpireCacheWithClock(workflowProgressCacheSize, clk),
+		metrics: workflowProgressMetrics{
+			emitted:  scope.MustNewCounter("emitted", "Progress events emitted for running workflows"),
+			failures: scope.MustNewCounter("failures", "Progress events of running workflows that failed to be emitted"),
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/flyteorg/flytepropeller/events"
+	eventMocks "github.com/flyteorg/flytepropeller/events/mocks"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// Creates a workflow start-node -> a -> b -> c -> end-node, with d between start-node and end-node.
+func newProgressWorkflow(phases map[v1alpha1.NodeID]v1alpha1.NodePhase) *v1alpha1.FlyteWorkflow {
+	nodes := map[v1alpha1.NodeID]*v1alpha1.NodeSpec{}
+	for _, id := range []v1alpha1.NodeID{v1alpha1.StartNodeID, "a", "b", "c", "d", v1alpha1.EndNodeID} {
+		nodes[id] = &v1alpha1.NodeSpec{ID: id}
+	}
+
+	nodeStatus := map[v1alpha1.NodeID]*v1alpha1.NodeStatus{}
+	for id, phase := range phases {
+		nodeStatus[id] = &v1alpha1.NodeStatus{Phase: phase}
+	}
+
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "wf"},
+		ExecutionID: v1alpha1.WorkflowExecutionIdentifier{
+			WorkflowExecutionIdentifier: &core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "wf"},
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			Nodes: nodes,
+			Connections: v1alpha1.Connections{
+				Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{
+					v1alpha1.StartNodeID: {"a", "d"},
+					"a":                  {"b"},
+					"b":                  {"c"},
+					"c":                  {v1alpha1.EndNodeID},
+					"d":                  {v1alpha1.EndNodeID},
+				},
+			},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowPhaseRunning, NodeStatus: nodeStatus},
+	}
+}
+
+func TestComputeWorkflowProgress(t *testing.T) {
+	t.Run("not started", func(t *testing.T) {
+		progress := computeWorkflowProgress(newProgressWorkflow(nil))
+		assert.Equal(t, events.WorkflowProgress{TotalNodes: 4}, progress)
+	})
+
+	t.Run("running", func(t *testing.T) {
+		progress := computeWorkflowProgress(newProgressWorkflow(map[v1alpha1.NodeID]v1alpha1.NodePhase{
+			v1alpha1.StartNodeID: v1alpha1.NodePhaseSucceeded,
+			"a":                  v1alpha1.NodePhaseRunning,
+			"d":                  v1alpha1.NodePhaseQueued,
+		}))
+		assert.Equal(t, events.WorkflowProgress{TotalNodes: 4, RunningNodes: 2, CriticalPathNodeID: "a"}, progress)
+	})
+
+	t.Run("partially completed", func(t *testing.T) {
+		progress := computeWorkflowProgress(newProgressWorkflow(map[v1alpha1.NodeID]v1alpha1.NodePhase{
+			"a": v1alpha1.NodePhaseSucceeded,
+			"b": v1alpha1.NodePhaseSucceeded,
+			"c": v1alpha1.NodePhaseRetryableFailure,
+			"d": v1alpha1.NodePhaseFailed,
+		}))
+		assert.Equal(t, events.WorkflowProgress{TotalNodes: 4, SucceededNodes: 2, FailedNodes: 1, RunningNodes: 1,
+			PercentComplete: 75, CriticalPathNodeID: "c"}, progress)
+	})
+}
+
+func TestWorkflowProgressReporter(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	cfg := config.WorkflowProgressConfig{Enabled: true, Interval: stdConfig.Duration{Duration: time.Minute}}
+
+	t.Run("disabled", func(t *testing.T) {
+		r := newWorkflowProgressReporter(config.WorkflowProgressConfig{}, "c1", &eventMocks.EventSink{}, fakeClock,
+			promutils.NewTestScope())
+		assert.Nil(t, r)
+		r.Report(ctx, newProgressWorkflow(nil))
+	})
+
+	t.Run("once per interval", func(t *testing.T) {
+		sink := &eventMocks.EventSink{}
+		var sunk []*events.WorkflowProgressEvent
+		sink.OnSinkMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			sunk = append(sunk, args.Get(1).(*events.WorkflowProgressEvent))
+		}).Return(nil)
+
+		r := newWorkflowProgressReporter(cfg, "c1", sink, fakeClock, promutils.NewTestScope())
+		w := newProgressWorkflow(map[v1alpha1.NodeID]v1alpha1.NodePhase{"a": v1alpha1.NodePhaseRunning})
+		r.Report(ctx, w)
+		r.Report(ctx, w)
+		assert.Len(t, sunk, 1)
+		assert.True(t, proto.Equal(&core.WorkflowExecutionIdentifier{Project: "p", Domain: "d", Name: "wf"},
+			sunk[0].GetExecutionId()))
+		assert.Equal(t, "a", sunk[0].GetFields()["criticalPathNodeId"].GetStringValue())
+		assert.Equal(t, "c1", sunk[0].GetFields()["producerId"].GetStringValue())
+
+		fakeClock.Step(time.Minute + time.Second)
+		r.Report(ctx, w)
+		assert.Len(t, sunk, 2)
+		assert.Equal(t, float64(2), testutil.ToFloat64(r.metrics.emitted))
+
+		w.Status.Phase = v1alpha1.WorkflowPhaseSuccess
+		r.Report(ctx, w)
+		assert.Len(t, sunk, 2)
+	})
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	clientset "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
+	flyteworkflowinformers "github.com/flyteorg/flytepropeller/pkg/client/informers/externalversions/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/informers/externalversions/internalinterfaces"
+	"github.com/flyteorg/flytepropeller/pkg/controller/config"
+)
+
+// The open watches of FlyteWorkflows of the informer, which can be expired on demand. The reflector of an informer
+// whose watch ends with an expired error re-lists all workflows from the apiserver and replaces its cache with them,
+// notifying the event handlers of every change it missed, before it watches again.
+type expirableWatches struct {
+	lock    sync.Mutex
+	watches map[*expirableWatch]struct{}
+}
+
+// Expires all open watches and returns how many were expired.
+func (e *expirableWatches) Expire() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for w := range e.watches {
+		w.expireOnce.Do(func() { close(w.expire) })
+	}
+
+	return len(e.watches)
+}
+
+func (e *expirableWatches) watch(source watch.Interface) watch.Interface {
+	w := &expirableWatch{
+		source: source,
+		result: make(chan watch.Event),
+		expire: make(chan struct{}),
+		stop:   make(chan struct{}),
+	}
+
+	e.lock.Lock()
+	e.watches[w] = struct{}{}
+	e.lock.Unlock()
+	go w.run(func() {
+		e.lock.Lock()
+		delete(e.watches, w)
+		e.lock.Unlock()
+	})
+
+	return w
+}
+
+// Returns the constructor of the FlyteWorkflow informer to register with the informer factory, whose watches are
+// tracked to be expired.
+func (e *expirableWatches) newInformerFunc(cfg *config.Config) internalinterfaces.NewInformerFunc {
+	labelSelector := workflowsLabelSelector(cfg)
+	return func(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return flyteworkflowinformers.NewFilteredFlyteWorkflowInformer(expirableWatchesClientset{Interface: client, watches: e},
+			watchedNamespace(cfg), resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+			func(options *v1.ListOptions) {
+				options.LabelSelector = labelSelector
+			})
+	}
+}
+
+func newExpirableWatches() *expirableWatches {
+	return &expirableWatches{
+		watches: map[*expirableWatch]struct{}{},
+	}
+}
+
+// A watch that forwards the events of its source until it's stopped or expired.
+type expirableWatch struct {
+	source     watch.Interface
+	result     chan watch.Event
+	expire     chan struct{}
+	expireOnce sync.Once
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+func (w *expirableWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *expirableWatch) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+func (w *expirableWatch) sendExpired() {
+	status := apierrors.NewResourceExpired("the watch was expired to re-list FlyteWorkflows").ErrStatus
+	select {
+	case w.result <- watch.Event{Type: watch.Error, Object: &status}:
+	case <-w.stop:
+	}
+}
+
+func (w *expirableWatch) run(done func()) {
+	defer close(w.result)
+	defer done()
+	defer w.source.Stop()
+	for {
+		select {
+		case event, ok := <-w.source.ResultChan():
+			if !ok {
+				return
+			}
+
+			select {
+			case w.result <- event:
+			case <-w.expire:
+				w.sendExpired()
+				return
+			case <-w.stop:
+				return
+			}
+		case <-w.expire:
+			w.sendExpired()
+			return
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+type expirableWatchesClientset struct {
+	clientset.Interface
+	watches *expirableWatches
+}
+
+func (c expirableWatchesClientset) FlyteworkflowV1alpha1() v1alpha1.FlyteworkflowV1alpha1Interface {
+	return expirableWatchesV1alpha1{FlyteworkflowV1alpha1Interface: c.Interface.FlyteworkflowV1alpha1(), watches: c.watches}
+}
+
+type expirableWatchesV1alpha1 struct {
+	v1alpha1.FlyteworkflowV1alpha1Interface
+	watches *expirableWatches
+}
+
+func (c expirableWatchesV1alpha1) FlyteWorkflows(namespace string) v1alpha1.FlyteWorkflowInterface {
+	return expirableWatchesFlyteWorkflows{FlyteWorkflowInterface: c.FlyteworkflowV1alpha1Interface.FlyteWorkflows(namespace), watches: c.watches}
+}
+
+type expirableWatchesFlyteWorkflows struct {
+	v1alpha1.FlyteWorkflowInterface
+	watches *expirableWatches
+}
+
+func (c expirableWatchesFlyteWorkflows) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	w, err := c.FlyteWorkflowInterface.Watch(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.watches.watch(w), nil
+}
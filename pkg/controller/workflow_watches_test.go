@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+)
+
+func TestExpirableWatches(t *testing.T) {
+	t.Run("forwards events", func(t *testing.T) {
+		watches := newExpirableWatches()
+		source := watch.NewFake()
+		w := watches.watch(source)
+
+		wf := &v1alpha1.FlyteWorkflow{ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "ns"}}
+		go source.Add(wf)
+		event := <-w.ResultChan()
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, wf, event.Object)
+
+		w.Stop()
+		_, ok := <-w.ResultChan()
+		assert.False(t, ok)
+		assert.True(t, source.IsStopped())
+		assert.Equal(t, 0, watches.Expire())
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		watches := newExpirableWatches()
+		source := watch.NewFake()
+		w := watches.watch(source)
+
+		assert.Equal(t, 1, watches.Expire())
+		event := <-w.ResultChan()
+		assert.Equal(t, watch.Error, event.Type)
+		assert.True(t, apierrors.IsResourceExpired(apierrors.FromObject(event.Object)))
+
+		_, ok := <-w.ResultChan()
+		assert.False(t, ok)
+		assert.True(t, source.IsStopped())
+		assert.Equal(t, 0, watches.Expire())
+	})
+
+	t.Run("clientset", func(t *testing.T) {
+		watches := newExpirableWatches()
+		client := expirableWatchesClientset{Interface: fake.NewSimpleClientset(), watches: watches}
+		w, err := client.FlyteworkflowV1alpha1().FlyteWorkflows("ns").Watch(context.TODO(), v1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, watches.Expire())
+		event := <-w.ResultChan()
+		assert.Equal(t, watch.Error, event.Type)
+	})
+}
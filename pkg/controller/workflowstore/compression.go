@@ -0,0 +1,331 @@
+package workflowstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+const (
+	// WorkflowCodecAnnotation names the codec the spec of a workflow is encoded with, see
+	// v1alpha1.FlyteWorkflow.EncodedWorkflow.
+	WorkflowCodecAnnotation = "flyte.org/workflow-codec"
+
+	// GzipCodecName is the name of the gzip codec, which is always available.
+	GzipCodecName = "gzip"
+)
+
+// Codec compresses serialized workflows. Codecs are registered by name with RegisterCodec, only gzip is available by
+// default.
+type Codec interface {
+	Name() string
+	Encode(raw []byte) ([]byte, error)
+	Decode(encoded []byte) ([]byte, error)
+}
+
+var (
+	codecsLock sync.RWMutex
+	codecs     = map[string]Codec{
+		GzipCodecName: gzipCodec{},
+	}
+)
+
+// RegisterCodec makes the codec available to compress workflows with, e.g. zstd. Codecs must be registered before the
+// workflow store is created and must stay registered as long as workflows encoded with them exist.
+func RegisterCodec(codec Codec) {
+	codecsLock.Lock()
+	defer codecsLock.Unlock()
+	codecs[codec.Name()] = codec
+}
+
+// GetCodec returns the codec registered with the name.
+func GetCodec(name string) (Codec, bool) {
+	codecsLock.RLock()
+	defer codecsLock.RUnlock()
+	codec, ok := codecs[name]
+	return codec, ok
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string {
+	return GzipCodecName
+}
+
+func (gzipCodec) Encode(raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decode(encoded []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// The sections of a workflow that never change once it's created and are encoded together.
+type encodedWorkflow struct {
+	Spec         *v1alpha1.WorkflowSpec                         `json:"spec"`
+	Tasks        map[v1alpha1.TaskID]*v1alpha1.TaskSpec         `json:"tasks,omitempty"`
+	SubWorkflows map[v1alpha1.WorkflowID]*v1alpha1.WorkflowSpec `json:"subWorkflows,omitempty"`
+}
+
+type compressionMetrics struct {
+	specCompressedCount   prometheus.Counter
+	statusCompressedCount prometheus.Counter
+	decodeFailureCount    prometheus.Counter
+	compressionRatio      prometheus.Summary
+	encodeLatency         promutils.StopWatch
+	decodeLatency         promutils.StopWatch
+}
+
+// A store that compresses large workflows before they are written to etcd. The spec, tasks and sub workflows of a
+// workflow are encoded once, since they never change, and only the ID of the workflow is kept in the inline spec. The
+// status of the nodes is optionally encoded on every update, keeping a summary of the phase of every node inline.
+// Workflows read from the underlying store are decoded again, with the codec they were encoded with.
+type workflowCompression struct {
+	w       FlyteWorkflow
+	codec   Codec
+	cfg     CompressionConfig
+	metrics *compressionMetrics
+}
+
+func (c *workflowCompression) encode(raw []byte) ([]byte, error) {
+	t := c.metrics.encodeLatency.Start()
+	defer t.Stop()
+
+	encoded, err := c.codec.Encode(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode with codec [%v]", c.codec.Name())
+	}
+
+	c.metrics.compressionRatio.Observe(float64(len(raw)) / float64(len(encoded)))
+	return encoded, nil
+}
+
+// Decodes the encoded value with the codec registered with the name and unmarshals it into v.
+func decode(codecName string, encoded []byte, v interface{}) error {
+	codec, ok := GetCodec(codecName)
+	if !ok {
+		return fmt.Errorf("codec [%v] is not registered", codecName)
+	}
+
+	raw, err := codec.Decode(encoded)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode with codec [%v]", codecName)
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal workflow decoded with codec [%v]", codecName)
+	}
+
+	return nil
+}
+
+func decodeWorkflow(w *v1alpha1.FlyteWorkflow, decode func(codecName string, encoded []byte, v interface{}) error) (
+	*v1alpha1.FlyteWorkflow, error) {
+	if len(w.EncodedWorkflow) == 0 && len(w.Status.EncodedNodeStatus) == 0 {
+		return w, nil
+	}
+
+	// The workflow may be shared with the informer cache and must not be modified.
+	w = w.DeepCopy()
+	if len(w.EncodedWorkflow) > 0 {
+		decoded := encodedWorkflow{}
+		if err := decode(w.Annotations[WorkflowCodecAnnotation], w.EncodedWorkflow, &decoded); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the spec of workflow [%v/%v]", w.Namespace, w.Name)
+		}
+
+		w.WorkflowSpec = decoded.Spec
+		w.Tasks = decoded.Tasks
+		w.SubWorkflows = decoded.SubWorkflows
+	}
+
+	if len(w.Status.EncodedNodeStatus) > 0 {
+		statuses := map[v1alpha1.NodeID]*v1alpha1.NodeStatus{}
+		if err := decode(w.Status.NodeStatusCodec, w.Status.EncodedNodeStatus, &statuses); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the node status of workflow [%v/%v]", w.Namespace, w.Name)
+		}
+
+		w.Status.NodeStatus = statuses
+	}
+
+	return w, nil
+}
+
+// DecodeWorkflow returns a copy of the workflow with its spec and the status of its nodes decoded, if they were
+// compressed by the workflow store, or the workflow itself otherwise. Code that reads workflows from the apiserver
+// without the workflow store must decode them before it reads their spec or the status of their nodes, or use
+// HydrateWorkflow if the status of their nodes may be offloaded. Metadata and the rest of the status, including the
+// phase of every node, are always stored inline.
+func DecodeWorkflow(w *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+	return decodeWorkflow(w, decode)
+}
+
+func (c *workflowCompression) decode(codecName string, encoded []byte, v interface{}) error {
+	t := c.metrics.decodeLatency.Start()
+	defer t.Stop()
+
+	if err := decode(codecName, encoded, v); err != nil {
+		c.metrics.decodeFailureCount.Inc()
+		return err
+	}
+
+	return nil
+}
+
+// Returns a copy of the workflow to write to the underlying store, with its spec and node status encoded if they are
+// large enough.
+func (c *workflowCompression) compress(ctx context.Context, workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+	compressed := *workflow
+	if len(workflow.EncodedWorkflow) == 0 && workflow.WorkflowSpec != nil {
+		raw, err := json.Marshal(encodedWorkflow{
+			Spec:         workflow.WorkflowSpec,
+			Tasks:        workflow.Tasks,
+			SubWorkflows: workflow.SubWorkflows,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if int64(len(raw)) >= c.cfg.MinSizeBytes {
+			encoded, err := c.encode(raw)
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Debugf(ctx, "Encoded the spec of the workflow, [%d] bytes, to [%d] bytes with codec [%v]",
+				len(raw), len(encoded), c.codec.Name())
+			c.metrics.specCompressedCount.Inc()
+			compressed.EncodedWorkflow = encoded
+			compressed.Annotations = make(map[string]string, len(workflow.Annotations)+1)
+			for k, v := range workflow.Annotations {
+				compressed.Annotations[k] = v
+			}
+
+			compressed.Annotations[WorkflowCodecAnnotation] = c.codec.Name()
+		}
+	}
+
+	if len(compressed.EncodedWorkflow) > 0 {
+		compressed.WorkflowSpec = &v1alpha1.WorkflowSpec{ID: workflow.ID}
+		compressed.Tasks = nil
+		compressed.SubWorkflows = nil
+	}
+
+	compressed.Status.EncodedNodeStatus = nil
+	compressed.Status.NodeStatusCodec = ""
+	if !c.cfg.CompressStatus {
+		return &compressed, nil
+	}
+
+	raw, err := json.Marshal(workflow.Status.NodeStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(raw)) < c.cfg.MinSizeBytes {
+		return &compressed, nil
+	}
+
+	encoded, err := c.encode(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	c.metrics.statusCompressedCount.Inc()
+	compressed.Status.NodeStatus = summarizeNodeStatus(workflow.Status.NodeStatus)
+	compressed.Status.EncodedNodeStatus = encoded
+	compressed.Status.NodeStatusCodec = c.codec.Name()
+	return &compressed, nil
+}
+
+func (c *workflowCompression) update(ctx context.Context, workflow *v1alpha1.FlyteWorkflow,
+	update func(workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error)) (*v1alpha1.FlyteWorkflow, error) {
+	compressed, err := c.compress(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	newWF, err := update(compressed)
+	if err != nil || newWF == nil {
+		return newWF, err
+	}
+
+	// The stored workflow is compressed, callers get to continue with the decoded workflow.
+	newWF.WorkflowSpec = workflow.WorkflowSpec
+	newWF.Tasks = workflow.Tasks
+	newWF.SubWorkflows = workflow.SubWorkflows
+	newWF.Status.NodeStatus = workflow.Status.NodeStatus
+	return newWF, nil
+}
+
+func (c *workflowCompression) Get(ctx context.Context, namespace, name string) (*v1alpha1.FlyteWorkflow, error) {
+	w, err := c.w.Get(ctx, namespace, name)
+	if err != nil || w == nil {
+		return w, err
+	}
+
+	return decodeWorkflow(w, c.decode)
+}
+
+func (c *workflowCompression) UpdateStatus(ctx context.Context, workflow *v1alpha1.FlyteWorkflow, priorityClass PriorityClass) (
+	newWF *v1alpha1.FlyteWorkflow, err error) {
+	return c.update(ctx, workflow, func(workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+		return c.w.UpdateStatus(ctx, workflow, priorityClass)
+	})
+}
+
+func (c *workflowCompression) Update(ctx context.Context, workflow *v1alpha1.FlyteWorkflow, priorityClass PriorityClass) (
+	newWF *v1alpha1.FlyteWorkflow, err error) {
+	return c.update(ctx, workflow, func(workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+		return c.w.Update(ctx, workflow, priorityClass)
+	})
+}
+
+func NewCompressionStore(_ context.Context, cfg CompressionConfig, scope promutils.Scope, workflowStore FlyteWorkflow) (
+	FlyteWorkflow, error) {
+	codec, ok := GetCodec(cfg.Codec)
+	if !ok {
+		return nil, fmt.Errorf("codec [%v] to compress workflows with is not registered", cfg.Codec)
+	}
+
+	return &workflowCompression{
+		w:     workflowStore,
+		codec: codec,
+		cfg:   cfg,
+		metrics: &compressionMetrics{
+			specCompressedCount:   scope.MustNewCounter("workflow_spec_compressed", "Number of times the spec of a workflow was compressed"),
+			statusCompressedCount: scope.MustNewCounter("workflow_status_compressed", "Number of times the node status of a workflow was compressed"),
+			decodeFailureCount:    scope.MustNewCounter("workflow_decode_failure", "Number of times a compressed workflow failed to be decoded"),
+			compressionRatio:      scope.MustNewSummary("workflow_compression_ratio", "Ratio of the serialized size of workflows to their compressed size"),
+			encodeLatency:         scope.MustNewStopWatch("workflow_encode_latency", "Time taken to compress workflows", time.Millisecond),
+			decodeLatency:         scope.MustNewStopWatch("workflow_decode_latency", "Time taken to decompress workflows", time.Millisecond),
+		},
+	}, nil
+}
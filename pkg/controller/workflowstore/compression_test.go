@@ -0,0 +1,189 @@
+package workflowstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func newCompressionTestWorkflow() *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "name",
+			Annotations: map[string]string{"a": "b"},
+		},
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "wf",
+			Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+				"n1": {ID: "n1"},
+				"n2": {ID: "n2"},
+			},
+		},
+		Tasks: map[v1alpha1.TaskID]*v1alpha1.TaskSpec{
+			"t1": {TaskTemplate: &core.TaskTemplate{Type: "python-task"}},
+		},
+		SubWorkflows: map[v1alpha1.WorkflowID]*v1alpha1.WorkflowSpec{
+			"sub": {ID: "sub"},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				"n1": {Phase: v1alpha1.NodePhaseSucceeded, Message: "done", Attempts: 1},
+				"n2": {Phase: v1alpha1.NodePhaseRunning, Message: "running"},
+			},
+		},
+	}
+}
+
+type reverseCodec struct{}
+
+func (reverseCodec) Name() string {
+	return "reverse"
+}
+
+func (reverseCodec) Encode(raw []byte) ([]byte, error) {
+	encoded := make([]byte, len(raw))
+	for i, b := range raw {
+		encoded[len(raw)-1-i] = b
+	}
+
+	return encoded, nil
+}
+
+func (r reverseCodec) Decode(encoded []byte) ([]byte, error) {
+	return r.Encode(encoded)
+}
+
+func TestGzipCodec(t *testing.T) {
+	codec, ok := GetCodec(GzipCodecName)
+	assert.True(t, ok)
+
+	raw := []byte(`{"spec": {"id": "wf"}}`)
+	encoded, err := codec.Encode(raw)
+	assert.NoError(t, err)
+
+	decoded, err := codec.Decode(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, raw, decoded)
+
+	_, err = codec.Decode([]byte("not gzip"))
+	assert.Error(t, err)
+}
+
+func TestNewCompressionStore_UnknownCodec(t *testing.T) {
+	_, err := NewCompressionStore(context.TODO(), CompressionConfig{Enabled: true, Codec: "unknown"},
+		promutils.NewTestScope(), NewInMemoryWorkflowStore())
+	assert.Error(t, err)
+}
+
+func TestWorkflowCompression(t *testing.T) {
+	ctx := context.TODO()
+	RegisterCodec(reverseCodec{})
+
+	for _, codecName := range []string{GzipCodecName, "reverse"} {
+		t.Run(codecName, func(t *testing.T) {
+			underlying := NewInMemoryWorkflowStore()
+			w := newCompressionTestWorkflow()
+			assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+			s, err := NewCompressionStore(ctx, CompressionConfig{Enabled: true, Codec: codecName, CompressStatus: true},
+				promutils.NewTestScope(), underlying)
+			assert.NoError(t, err)
+
+			newWF, err := s.Update(ctx, w, PriorityClassCritical)
+			assert.NoError(t, err)
+			// Callers continue with the decoded workflow.
+			assert.Len(t, newWF.Nodes, 2)
+			assert.Len(t, newWF.Tasks, 1)
+			assert.Equal(t, "done", newWF.Status.NodeStatus["n1"].Message)
+			// The workflow that was passed in is not modified.
+			assert.Empty(t, w.EncodedWorkflow)
+			assert.Len(t, w.Annotations, 1)
+
+			// Only the ID of the workflow and a summary of the node status are stored inline.
+			stored, err := underlying.Get(ctx, "ns", "name")
+			assert.NoError(t, err)
+			assert.Equal(t, codecName, stored.Annotations[WorkflowCodecAnnotation])
+			assert.Equal(t, "b", stored.Annotations["a"])
+			assert.NotEmpty(t, stored.EncodedWorkflow)
+			assert.Equal(t, "wf", stored.ID)
+			assert.Empty(t, stored.Nodes)
+			assert.Empty(t, stored.Tasks)
+			assert.Empty(t, stored.SubWorkflows)
+			assert.Equal(t, codecName, stored.Status.NodeStatusCodec)
+			assert.Equal(t, v1alpha1.NodePhaseSucceeded, stored.Status.NodeStatus["n1"].Phase)
+			assert.Empty(t, stored.Status.NodeStatus["n1"].Message)
+
+			decoded, err := s.Get(ctx, "ns", "name")
+			assert.NoError(t, err)
+			assert.Len(t, decoded.Nodes, 2)
+			assert.Equal(t, "python-task", decoded.Tasks["t1"].Type)
+			assert.Equal(t, "sub", decoded.SubWorkflows["sub"].ID)
+			assert.Equal(t, "done", decoded.Status.NodeStatus["n1"].Message)
+			assert.Equal(t, uint32(1), decoded.Status.NodeStatus["n1"].Attempts)
+			// The stored workflow is not modified by decoding.
+			assert.Empty(t, stored.Nodes)
+
+			// Workflows read without the store are decoded the same way.
+			decoded, err = DecodeWorkflow(stored)
+			assert.NoError(t, err)
+			assert.Len(t, decoded.Nodes, 2)
+			assert.Equal(t, "done", decoded.Status.NodeStatus["n1"].Message)
+			assert.Empty(t, stored.Nodes)
+
+			// The spec is not encoded again, the node status is.
+			decoded.Status.NodeStatus["n1"].Message = "updated"
+			_, err = s.UpdateStatus(ctx, decoded, PriorityClassRegular)
+			assert.NoError(t, err)
+			decoded, err = s.Get(ctx, "ns", "name")
+			assert.NoError(t, err)
+			assert.Len(t, decoded.Nodes, 2)
+			assert.Equal(t, "updated", decoded.Status.NodeStatus["n1"].Message)
+		})
+	}
+
+	t.Run("small workflows stay inline", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newCompressionTestWorkflow()
+		assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+		s, err := NewCompressionStore(ctx, CompressionConfig{Enabled: true, Codec: GzipCodecName, CompressStatus: true,
+			MinSizeBytes: 1024 * 1024}, promutils.NewTestScope(), underlying)
+		assert.NoError(t, err)
+
+		_, err = s.Update(ctx, w, PriorityClassCritical)
+		assert.NoError(t, err)
+
+		stored, err := underlying.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Empty(t, stored.EncodedWorkflow)
+		assert.Empty(t, stored.Status.EncodedNodeStatus)
+		assert.Len(t, stored.Nodes, 2)
+		assert.Equal(t, "done", stored.Status.NodeStatus["n1"].Message)
+	})
+
+	t.Run("unregistered codec", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newCompressionTestWorkflow()
+		w.EncodedWorkflow = []byte("encoded")
+		w.Annotations[WorkflowCodecAnnotation] = "unknown"
+		assert.NoError(t, underlying.Create(ctx, w))
+		s, err := NewCompressionStore(ctx, CompressionConfig{Enabled: true, Codec: GzipCodecName},
+			promutils.NewTestScope(), underlying)
+		assert.NoError(t, err)
+
+		_, err = s.Get(ctx, "ns", "name")
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeWorkflow_NotEncoded(t *testing.T) {
+	w := newCompressionTestWorkflow()
+	decoded, err := DecodeWorkflow(w)
+	assert.NoError(t, err)
+	assert.True(t, w == decoded)
+}
@@ -22,6 +22,15 @@ const (
 var (
 	defaultConfig = &Config{
 		Policy: PolicyResourceVersionCache,
+		NodeStatusOffloading: NodeStatusOffloadingConfig{
+			Enabled:      false,
+			MinSizeBytes: 256 * 1024,
+			CacheSize:    1000,
+		},
+		Compression: CompressionConfig{
+			Codec:        GzipCodecName,
+			MinSizeBytes: 64 * 1024,
+		},
 	}
 
 	configSection = ctrlConfig.MustRegisterSubSection("workflowStore", defaultConfig)
@@ -30,7 +39,28 @@ var (
 // Config for Workflow access in the controller.
 // Various policies are available like - InMemory, PassThrough, ResourceVersionCache
 type Config struct {
-	Policy Policy `json:"policy" pflag:",Workflow Store Policy to initialize"`
+	Policy               Policy                     `json:"policy" pflag:",Workflow Store Policy to initialize"`
+	NodeStatusOffloading NodeStatusOffloadingConfig `json:"nodeStatusOffloading,omitempty" pflag:",Configures offloading the status of nodes to blob storage."`
+	Compression          CompressionConfig          `json:"compression,omitempty" pflag:",Configures compressing large workflows stored in etcd."`
+}
+
+// NodeStatusOffloadingConfig configures offloading the status of the nodes of large workflows to blob storage, so that
+// wide or deep workflows do not exceed the size limit of etcd. Only a summary of the phase of every node is kept in the
+// FlyteWorkflow CRD.
+type NodeStatusOffloadingConfig struct {
+	Enabled      bool  `json:"enabled" pflag:",Enables offloading the status of nodes to blob storage."`
+	MinSizeBytes int64 `json:"minSizeBytes" pflag:",The status of nodes is only offloaded if its serialized size exceeds this many bytes."`
+	CacheSize    int   `json:"cacheSize" pflag:",Number of offloaded node statuses kept in memory."`
+}
+
+// CompressionConfig configures encoding the spec of large workflows, and optionally the status of their nodes, with a
+// compression codec, so that large compiled workflows take less space in etcd. Workflows are transparently decoded when
+// read through the workflow store.
+type CompressionConfig struct {
+	Enabled        bool   `json:"enabled" pflag:",Enables compressing large workflows."`
+	Codec          string `json:"codec" pflag:",Name of the codec workflows are compressed with."`
+	CompressStatus bool   `json:"compressStatus" pflag:",Compresses the status of the nodes in addition to the spec of workflows."`
+	MinSizeBytes   int64  `json:"minSizeBytes" pflag:",Workflows are only compressed if the serialized spec or node status exceeds this many bytes."`
 }
 
 func GetConfig() *Config {
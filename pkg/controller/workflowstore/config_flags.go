@@ -51,5 +51,12 @@ func (Config) mustMarshalJSON(v json.Marshaler) string {
 func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "policy"), defaultConfig.Policy, "Workflow Store Policy to initialize")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "nodeStatusOffloading.enabled"), defaultConfig.NodeStatusOffloading.Enabled, "Enables offloading the status of nodes to blob storage.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "nodeStatusOffloading.minSizeBytes"), defaultConfig.NodeStatusOffloading.MinSizeBytes, "The status of nodes is only offloaded if its serialized size exceeds this many bytes.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "nodeStatusOffloading.cacheSize"), defaultConfig.NodeStatusOffloading.CacheSize, "Number of offloaded node statuses kept in memory.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "compression.enabled"), defaultConfig.Compression.Enabled, "Enables compressing large workflows.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "compression.codec"), defaultConfig.Compression.Codec, "Name of the codec workflows are compressed with.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "compression.compressStatus"), defaultConfig.Compression.CompressStatus, "Compresses the status of the nodes in addition to the spec of workflows.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "compression.minSizeBytes"), defaultConfig.Compression.MinSizeBytes, "Workflows are only compressed if the serialized spec or node status exceeds this many bytes.")
 	return cmdFlags
 }
@@ -113,4 +113,102 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_nodeStatusOffloading.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("nodeStatusOffloading.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("nodeStatusOffloading.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.NodeStatusOffloading.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_nodeStatusOffloading.minSizeBytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("nodeStatusOffloading.minSizeBytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("nodeStatusOffloading.minSizeBytes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.NodeStatusOffloading.MinSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_nodeStatusOffloading.cacheSize", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("nodeStatusOffloading.cacheSize", testValue)
+			if vInt, err := cmdFlags.GetInt("nodeStatusOffloading.cacheSize"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.NodeStatusOffloading.CacheSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_compression.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("compression.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("compression.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Compression.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_compression.codec", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("compression.codec", testValue)
+			if vString, err := cmdFlags.GetString("compression.codec"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Compression.Codec)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_compression.compressStatus", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("compression.compressStatus", testValue)
+			if vBool, err := cmdFlags.GetBool("compression.compressStatus"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Compression.CompressStatus)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_compression.minSizeBytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("compression.minSizeBytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("compression.minSizeBytes"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt64), &actual.Compression.MinSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -7,19 +7,38 @@ import (
 	flyteworkflowv1alpha1 "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytepropeller/pkg/client/listers/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 )
 
 func NewWorkflowStore(ctx context.Context, cfg *Config, lister v1alpha1.FlyteWorkflowLister,
-	workflows flyteworkflowv1alpha1.FlyteworkflowV1alpha1Interface, scope promutils.Scope) (FlyteWorkflow, error) {
+	workflows flyteworkflowv1alpha1.FlyteworkflowV1alpha1Interface, dataStore *storage.DataStore, scope promutils.Scope) (FlyteWorkflow, error) {
 
+	var passthrough FlyteWorkflow
 	switch cfg.Policy {
 	case PolicyInMemory:
 		return NewInMemoryWorkflowStore(), nil
-	case PolicyPassThrough:
-		return NewPassthroughWorkflowStore(ctx, scope, workflows, lister), nil
-	case PolicyResourceVersionCache:
-		return NewResourceVersionCachingStore(ctx, scope, NewPassthroughWorkflowStore(ctx, scope, workflows, lister)), nil
+	case PolicyPassThrough, PolicyResourceVersionCache:
+		passthrough = NewPassthroughWorkflowStore(ctx, scope, workflows, lister)
+	default:
+		return nil, fmt.Errorf("empty workflow store config")
 	}
 
-	return nil, fmt.Errorf("empty workflow store config")
+	// Stale workflows are rejected before they're decoded or their offloaded node status is read.
+	if cfg.Policy == PolicyResourceVersionCache {
+		passthrough = NewResourceVersionCachingStore(ctx, scope, passthrough)
+	}
+
+	if cfg.Compression.Enabled {
+		var err error
+		passthrough, err = NewCompressionStore(ctx, cfg.Compression, scope, passthrough)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.NodeStatusOffloading.Enabled {
+		passthrough = NewNodeStatusOffloadingStore(ctx, cfg.NodeStatusOffloading, scope, dataStore, passthrough)
+	}
+
+	return passthrough, nil
 }
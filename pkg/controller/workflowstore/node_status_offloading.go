@@ -0,0 +1,267 @@
+package workflowstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+// Offloaded node statuses never change, they only expire from the cache once their workflow is no longer evaluated.
+const offloadedNodeStatusTTL = time.Hour
+
+type nodeStatusOffloadingMetrics struct {
+	offloadedCount   prometheus.Counter
+	inlinedCount     prometheus.Counter
+	hydratedCount    prometheus.Counter
+	cacheHitCount    prometheus.Counter
+	truncatedCount   prometheus.Counter
+	offloadedSize    prometheus.Summary
+	offloadLatency   promutils.StopWatch
+	hydrationLatency promutils.StopWatch
+}
+
+// A store that offloads the status of the nodes of large workflows to blob storage. The full status is written under the
+// data directory of the workflow, keyed by the resource version it was derived from, and only a summary of the phase of
+// every node is stored in the FlyteWorkflow CRD. Workflows read from the underlying store are hydrated with the full
+// status again, using an in-memory cache of the offloaded statuses to spare reading them from blob storage every round.
+// Superseded statuses are kept, since stale copies of the workflow, e.g. in the informer cache, may still reference
+// them, and are removed along with the data directory of the workflow. Only the statuses offloaded for updates that
+// were rejected are truncated, as the blob store offers no deletes.
+type nodeStatusOffloading struct {
+	w        FlyteWorkflow
+	store    *storage.DataStore
+	cfg      NodeStatusOffloadingConfig
+	statuses *cache.LRUExpireCache
+	metrics  *nodeStatusOffloadingMetrics
+}
+
+// Returns a summary of the status of the nodes that only holds their phase.
+func summarizeNodeStatus(statuses map[v1alpha1.NodeID]*v1alpha1.NodeStatus) map[v1alpha1.NodeID]*v1alpha1.NodeStatus {
+	summary := make(map[v1alpha1.NodeID]*v1alpha1.NodeStatus, len(statuses))
+	for id, s := range statuses {
+		summary[id] = &v1alpha1.NodeStatus{Phase: s.GetPhase()}
+	}
+
+	return summary
+}
+
+func copyNodeStatus(statuses map[v1alpha1.NodeID]*v1alpha1.NodeStatus) map[v1alpha1.NodeID]*v1alpha1.NodeStatus {
+	c := make(map[v1alpha1.NodeID]*v1alpha1.NodeStatus, len(statuses))
+	for id, s := range statuses {
+		c[id] = s.DeepCopy()
+	}
+
+	return c
+}
+
+// Reads the status of the nodes offloaded to the reference.
+func readOffloadedNodeStatus(ctx context.Context, store *storage.DataStore, ref v1alpha1.DataReference) (
+	map[v1alpha1.NodeID]*v1alpha1.NodeStatus, error) {
+	reader, err := store.ReadRaw(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the offloaded status of the nodes from [%v]", ref)
+	}
+
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close reader of [%v]. Error: %v", ref, err)
+		}
+	}()
+
+	raw, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the offloaded status of the nodes from [%v]", ref)
+	}
+
+	statuses := map[v1alpha1.NodeID]*v1alpha1.NodeStatus{}
+	if err := json.Unmarshal(raw, &statuses); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the offloaded status of the nodes from [%v]", ref)
+	}
+
+	return statuses, nil
+}
+
+// HydrateWorkflow returns a copy of the workflow decoded like DecodeWorkflow, with the status of its nodes read from
+// blob storage if it was offloaded by the workflow store. The data store is only required to read offloaded statuses.
+func HydrateWorkflow(ctx context.Context, store *storage.DataStore, w *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+	w, err := DecodeWorkflow(w)
+	if err != nil || len(w.Status.NodeStatusRef) == 0 {
+		return w, err
+	}
+
+	if store == nil {
+		return nil, fmt.Errorf("the node status of workflow [%v/%v] is offloaded to [%v] and requires a data store to be read",
+			w.Namespace, w.Name, w.Status.NodeStatusRef)
+	}
+
+	statuses, err := readOffloadedNodeStatus(ctx, store, w.Status.NodeStatusRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to hydrate the node status of workflow [%v/%v]", w.Namespace, w.Name)
+	}
+
+	// The workflow is returned as is by DecodeWorkflow if it was not encoded.
+	w = w.DeepCopy()
+	w.Status.NodeStatus = statuses
+	return w, nil
+}
+
+func (o *nodeStatusOffloading) readNodeStatus(ctx context.Context, ref v1alpha1.DataReference) (
+	map[v1alpha1.NodeID]*v1alpha1.NodeStatus, error) {
+	if statuses, ok := o.statuses.Get(ref); ok {
+		o.metrics.cacheHitCount.Inc()
+		return copyNodeStatus(statuses.(map[v1alpha1.NodeID]*v1alpha1.NodeStatus)), nil
+	}
+
+	t := o.metrics.hydrationLatency.Start()
+	defer t.Stop()
+
+	statuses, err := readOffloadedNodeStatus(ctx, o.store, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	o.metrics.hydratedCount.Inc()
+	o.statuses.Add(ref, statuses, offloadedNodeStatusTTL)
+	return copyNodeStatus(statuses), nil
+}
+
+// Returns a copy of the workflow to write to the underlying store, with the status of its nodes offloaded if it is
+// large enough.
+func (o *nodeStatusOffloading) offload(ctx context.Context, workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+	raw, err := json.Marshal(workflow.Status.NodeStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	offloaded := *workflow
+	if int64(len(raw)) < o.cfg.MinSizeBytes || len(workflow.Status.DataDir) == 0 {
+		if len(workflow.Status.NodeStatusRef) > 0 {
+			o.metrics.inlinedCount.Inc()
+			offloaded.Status.NodeStatusRef = ""
+		}
+		return &offloaded, nil
+	}
+
+	t := o.metrics.offloadLatency.Start()
+	defer t.Stop()
+
+	// The content hash makes offloaded statuses immutable, even if several statuses are derived from the same version.
+	sum := sha256.Sum256(raw)
+	ref, err := o.store.ConstructReference(ctx, workflow.Status.DataDir, "node-status",
+		fmt.Sprintf("%s-%s", workflow.ResourceVersion, hex.EncodeToString(sum[:8])))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.store.WriteRaw(ctx, ref, int64(len(raw)), storage.Options{}, bytes.NewReader(raw)); err != nil {
+		return nil, errors.Wrapf(err, "failed to offload the status of the nodes to [%v]", ref)
+	}
+
+	logger.Debugf(ctx, "Offloaded the status of [%d] nodes, [%d] bytes, to [%v]", len(workflow.Status.NodeStatus), len(raw), ref)
+	o.metrics.offloadedCount.Inc()
+	o.metrics.offloadedSize.Observe(float64(len(raw)))
+	// Callers continue to modify the status of the nodes of the workflow after it was written.
+	o.statuses.Add(ref, copyNodeStatus(workflow.Status.NodeStatus), offloadedNodeStatusTTL)
+	offloaded.Status.NodeStatus = summarizeNodeStatus(workflow.Status.NodeStatus)
+	offloaded.Status.NodeStatusRef = ref
+	return &offloaded, nil
+}
+
+// Frees the storage of an offloaded status that was never referenced by a stored workflow.
+func (o *nodeStatusOffloading) truncate(ctx context.Context, ref v1alpha1.DataReference) {
+	o.statuses.Remove(ref)
+	if err := o.store.WriteRaw(ctx, ref, 0, storage.Options{}, bytes.NewReader(nil)); err != nil {
+		logger.Warnf(ctx, "Failed to truncate the offloaded status of the nodes at [%v]. Error: %v", ref, err)
+		return
+	}
+
+	o.metrics.truncatedCount.Inc()
+}
+
+func (o *nodeStatusOffloading) update(ctx context.Context, workflow *v1alpha1.FlyteWorkflow,
+	update func(workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error)) (*v1alpha1.FlyteWorkflow, error) {
+	offloaded, err := o.offload(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := offloaded.Status.NodeStatusRef
+	newWF, err := update(offloaded)
+	if err != nil || newWF == nil {
+		// Only rejected updates are certain to not reference the status that was just offloaded.
+		if len(ref) > 0 && ref != workflow.Status.NodeStatusRef && (kubeerrors.IsConflict(err) || IsWorkflowTooLarge(err)) {
+			o.truncate(ctx, ref)
+		}
+		return newWF, err
+	}
+
+	// The stored workflow only has the summary of the status of the nodes, callers get to continue with the full status.
+	newWF.Status.NodeStatus = workflow.Status.NodeStatus
+	return newWF, nil
+}
+
+func (o *nodeStatusOffloading) Get(ctx context.Context, namespace, name string) (*v1alpha1.FlyteWorkflow, error) {
+	w, err := o.w.Get(ctx, namespace, name)
+	if err != nil || w == nil || len(w.Status.NodeStatusRef) == 0 {
+		return w, err
+	}
+
+	statuses, err := o.readNodeStatus(ctx, w.Status.NodeStatusRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// The workflow may be shared with the informer cache and must not be modified.
+	w = w.DeepCopy()
+	w.Status.NodeStatus = statuses
+	return w, nil
+}
+
+func (o *nodeStatusOffloading) UpdateStatus(ctx context.Context, workflow *v1alpha1.FlyteWorkflow, priorityClass PriorityClass) (
+	newWF *v1alpha1.FlyteWorkflow, err error) {
+	return o.update(ctx, workflow, func(workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+		return o.w.UpdateStatus(ctx, workflow, priorityClass)
+	})
+}
+
+func (o *nodeStatusOffloading) Update(ctx context.Context, workflow *v1alpha1.FlyteWorkflow, priorityClass PriorityClass) (
+	newWF *v1alpha1.FlyteWorkflow, err error) {
+	return o.update(ctx, workflow, func(workflow *v1alpha1.FlyteWorkflow) (*v1alpha1.FlyteWorkflow, error) {
+		return o.w.Update(ctx, workflow, priorityClass)
+	})
+}
+
+func NewNodeStatusOffloadingStore(_ context.Context, cfg NodeStatusOffloadingConfig, scope promutils.Scope,
+	dataStore *storage.DataStore, workflowStore FlyteWorkflow) FlyteWorkflow {
+	return &nodeStatusOffloading{
+		w:        workflowStore,
+		store:    dataStore,
+		cfg:      cfg,
+		statuses: cache.NewLRUExpireCache(cfg.CacheSize),
+		metrics: &nodeStatusOffloadingMetrics{
+			offloadedCount:   scope.MustNewCounter("node_status_offloaded", "Number of times the status of nodes was offloaded to blob storage"),
+			inlinedCount:     scope.MustNewCounter("node_status_inlined", "Number of times offloaded node status was stored inline again"),
+			hydratedCount:    scope.MustNewCounter("node_status_hydrated", "Number of times offloaded node status was read from blob storage"),
+			cacheHitCount:    scope.MustNewCounter("node_status_cache_hit", "Number of times offloaded node status was found in memory"),
+			truncatedCount:   scope.MustNewCounter("node_status_truncated", "Number of offloaded node statuses truncated because their update was rejected"),
+			offloadedSize:    scope.MustNewSummary("node_status_offloaded_size", "Size in bytes of the offloaded status of nodes"),
+			offloadLatency:   scope.MustNewStopWatch("node_status_offload_latency", "Time taken to offload the status of nodes", time.Millisecond),
+			hydrationLatency: scope.MustNewStopWatch("node_status_hydration_latency", "Time taken to read offloaded node status from blob storage", time.Millisecond),
+		},
+	}
+}
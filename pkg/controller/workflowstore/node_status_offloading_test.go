@@ -0,0 +1,188 @@
+package workflowstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+)
+
+func newOffloadingTestWorkflow() *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:       "ns",
+			Name:            "name",
+			ResourceVersion: "1",
+		},
+		Status: v1alpha1.WorkflowStatus{
+			DataDir: "s3://bucket/metadata/name",
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				"n1": {Phase: v1alpha1.NodePhaseSucceeded, Message: "done", Attempts: 1},
+				"n2": {Phase: v1alpha1.NodePhaseRunning, Message: "running"},
+			},
+		},
+	}
+}
+
+func TestNodeStatusOffloading(t *testing.T) {
+	ctx := context.TODO()
+	dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	t.Run("offloaded", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newOffloadingTestWorkflow()
+		assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+		s := NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, CacheSize: 10},
+			promutils.NewTestScope(), dataStore, underlying)
+
+		newWF, err := s.Update(ctx, w, PriorityClassCritical)
+		assert.NoError(t, err)
+		// Callers continue with the full status.
+		assert.Equal(t, "done", newWF.Status.NodeStatus["n1"].Message)
+		assert.NotEmpty(t, newWF.Status.NodeStatusRef)
+		// The workflow that was passed in is not modified.
+		assert.Empty(t, w.Status.NodeStatusRef)
+
+		// Only a summary is stored inline.
+		stored, err := underlying.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodePhaseSucceeded, stored.Status.NodeStatus["n1"].Phase)
+		assert.Empty(t, stored.Status.NodeStatus["n1"].Message)
+		assert.Equal(t, newWF.Status.NodeStatusRef, stored.Status.NodeStatusRef)
+
+		hydrated, err := s.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Equal(t, "done", hydrated.Status.NodeStatus["n1"].Message)
+		assert.Equal(t, uint32(1), hydrated.Status.NodeStatus["n1"].Attempts)
+		assert.Equal(t, "running", hydrated.Status.NodeStatus["n2"].Message)
+		// The stored workflow is not modified by hydration.
+		assert.Empty(t, stored.Status.NodeStatus["n1"].Message)
+
+		// Hydrating a workflow without the in-memory cache reads the status from blob storage.
+		s = NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, CacheSize: 10},
+			promutils.NewTestScope(), dataStore, underlying)
+		hydrated, err = s.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Equal(t, "done", hydrated.Status.NodeStatus["n1"].Message)
+	})
+
+	t.Run("superseded statuses are kept", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newOffloadingTestWorkflow()
+		assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+		s := NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, CacheSize: 10},
+			promutils.NewTestScope(), dataStore, underlying)
+
+		first, err := s.Update(ctx, w, PriorityClassCritical)
+		assert.NoError(t, err)
+		// Modifying the status after the update does not modify the cached offloaded status.
+		first.Status.NodeStatus["n2"].Message = "succeeded"
+		first.Status.NodeStatus["n2"].Phase = v1alpha1.NodePhaseSucceeded
+		hydrated, err := s.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Equal(t, "running", hydrated.Status.NodeStatus["n2"].Message)
+
+		second, err := s.Update(ctx, first, PriorityClassCritical)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first.Status.NodeStatusRef, second.Status.NodeStatusRef)
+
+		// Stale copies of the workflow still reference the first status.
+		statuses, err := readOffloadedNodeStatus(ctx, dataStore, first.Status.NodeStatusRef)
+		assert.NoError(t, err)
+		assert.Equal(t, "running", statuses["n2"].Message)
+
+		hydrated, err = s.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Equal(t, "succeeded", hydrated.Status.NodeStatus["n2"].Message)
+	})
+
+	t.Run("stale workflows are not hydrated", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newOffloadingTestWorkflow()
+		assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+		scope := promutils.NewTestScope()
+		s := NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, CacheSize: 10},
+			scope, dataStore, NewResourceVersionCachingStore(ctx, scope, underlying))
+
+		_, err := s.Update(ctx, w, PriorityClassCritical)
+		assert.NoError(t, err)
+
+		// A stale copy of the workflow, whose offloaded status can't be read.
+		stale := newOffloadingTestWorkflow()
+		stale.Status.NodeStatusRef = "s3://bucket/metadata/name/node-status/missing"
+		assert.NoError(t, underlying.Create(ctx, stale))
+		_, err = s.Get(ctx, "ns", "name")
+		assert.Equal(t, ErrStaleWorkflowError, err)
+	})
+
+	t.Run("small statuses stay inline", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newOffloadingTestWorkflow()
+		w.Status.NodeStatusRef = "s3://bucket/metadata/name/node-status/0"
+		assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+		s := NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, MinSizeBytes: 1024 * 1024, CacheSize: 10},
+			promutils.NewTestScope(), dataStore, underlying)
+
+		newWF, err := s.UpdateStatus(ctx, w, PriorityClassCritical)
+		assert.NoError(t, err)
+		assert.Empty(t, newWF.Status.NodeStatusRef)
+
+		stored, err := underlying.Get(ctx, "ns", "name")
+		assert.NoError(t, err)
+		assert.Empty(t, stored.Status.NodeStatusRef)
+		assert.Equal(t, "done", stored.Status.NodeStatus["n1"].Message)
+	})
+
+	t.Run("missing offloaded status", func(t *testing.T) {
+		underlying := NewInMemoryWorkflowStore()
+		w := newOffloadingTestWorkflow()
+		w.Status.NodeStatusRef = "s3://bucket/metadata/name/node-status/missing"
+		assert.NoError(t, underlying.Create(ctx, w))
+		s := NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, CacheSize: 10},
+			promutils.NewTestScope(), dataStore, underlying)
+
+		_, err := s.Get(ctx, "ns", "name")
+		assert.Error(t, err)
+	})
+}
+
+func TestHydrateWorkflow(t *testing.T) {
+	ctx := context.TODO()
+	dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	underlying := NewInMemoryWorkflowStore()
+	w := newOffloadingTestWorkflow()
+	assert.NoError(t, underlying.Create(ctx, w.DeepCopy()))
+	s := NewNodeStatusOffloadingStore(ctx, NodeStatusOffloadingConfig{Enabled: true, CacheSize: 10},
+		promutils.NewTestScope(), dataStore, underlying)
+	_, err = s.Update(ctx, w, PriorityClassCritical)
+	assert.NoError(t, err)
+	stored, err := underlying.Get(ctx, "ns", "name")
+	assert.NoError(t, err)
+
+	t.Run("offloaded", func(t *testing.T) {
+		hydrated, err := HydrateWorkflow(ctx, dataStore, stored)
+		assert.NoError(t, err)
+		assert.Equal(t, "done", hydrated.Status.NodeStatus["n1"].Message)
+		// The stored workflow is not modified.
+		assert.Empty(t, stored.Status.NodeStatus["n1"].Message)
+	})
+
+	t.Run("no data store", func(t *testing.T) {
+		_, err := HydrateWorkflow(ctx, nil, stored)
+		assert.Error(t, err)
+	})
+
+	t.Run("inline", func(t *testing.T) {
+		inline := newOffloadingTestWorkflow()
+		hydrated, err := HydrateWorkflow(ctx, nil, inline)
+		assert.NoError(t, err)
+		assert.Equal(t, inline, hydrated)
+	})
+}
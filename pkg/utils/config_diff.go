@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+// ConfigChange describes a configuration value that differs from its default.
+type ConfigChange struct {
+	Key     string
+	Default interface{}
+	Value   interface{}
+}
+
+// SnapshotConfigSections returns the json representation of the given section and all of its sub-sections, keyed by
+// section key.
+func SnapshotConfigSections(section config.Section) (map[string]interface{}, error) {
+	snapshot := map[string]interface{}{}
+	if cfg := section.GetConfig(); cfg != nil {
+		raw, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(raw, &snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, sub := range section.GetSections() {
+		subSnapshot, err := SnapshotConfigSections(sub)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot[key] = subSnapshot
+	}
+
+	return snapshot, nil
+}
+
+func flattenConfig(prefix string, value interface{}, flattened map[string]interface{}) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		flattened[prefix] = value
+		return
+	}
+
+	for k, v := range m {
+		key := k
+		if len(prefix) > 0 {
+			key = prefix + "." + k
+		}
+
+		flattenConfig(key, v, flattened)
+	}
+}
+
+// DiffConfigs compares two config snapshots and returns the values that differ between them, sorted by their dotted
+// key.
+func DiffConfigs(defaults, effective map[string]interface{}) []ConfigChange {
+	flatDefaults := map[string]interface{}{}
+	flattenConfig("", defaults, flatDefaults)
+	flatEffective := map[string]interface{}{}
+	flattenConfig("", effective, flatEffective)
+
+	var changes []ConfigChange
+	for k, v := range flatEffective {
+		if d, ok := flatDefaults[k]; !ok || !reflect.DeepEqual(d, v) {
+			changes = append(changes, ConfigChange{Key: k, Default: flatDefaults[k], Value: v})
+		}
+	}
+
+	for k, d := range flatDefaults {
+		if _, ok := flatEffective[k]; !ok {
+			changes = append(changes, ConfigChange{Key: k, Default: d})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	defaults := map[string]interface{}{
+		"propeller": map[string]interface{}{
+			"workers": 20.0,
+			"queue": map[string]interface{}{
+				"type": "batch",
+			},
+			"labels": []interface{}{"a"},
+		},
+		"webhook": map[string]interface{}{
+			"certDir": "/etc/webhook/certs",
+		},
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		assert.Empty(t, DiffConfigs(defaults, defaults))
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		effective := map[string]interface{}{
+			"propeller": map[string]interface{}{
+				"workers": 40.0,
+				"queue": map[string]interface{}{
+					"type": "batch",
+				},
+				"labels": []interface{}{"a", "b"},
+				"new":    true,
+			},
+		}
+
+		assert.Equal(t, []ConfigChange{
+			{Key: "propeller.labels", Default: []interface{}{"a"}, Value: []interface{}{"a", "b"}},
+			{Key: "propeller.new", Value: true},
+			{Key: "propeller.workers", Default: 20.0, Value: 40.0},
+			{Key: "webhook.certDir", Default: "/etc/webhook/certs"},
+		}, DiffConfigs(defaults, effective))
+	})
+}
@@ -0,0 +1,126 @@
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/common"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Escapes the text to be used in a quoted Mermaid label.
+func mermaidEscape(text string) string {
+	return strings.ReplaceAll(text, "\"", "#quot;")
+}
+
+// Returns Mermaid https://mermaid-js.github.io/ flowchart representation of the current state of the state machine.
+// Nodes are color-coded by their live phase. Node IDs are not necessarily valid Mermaid IDs (e.g. "end" is a keyword),
+// so nodes are numbered in the order they are visited and labeled with their ID.
+func WorkflowToMermaid(g *v1alpha1.FlyteWorkflow) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TB\n")
+
+	nodeLabel := func(nodeId common.NodeID) string {
+		node, ok := g.Nodes[nodeId]
+		if !ok {
+			return nodeId
+		}
+
+		return fmt.Sprintf("%v(%v)", node.ID, node.Kind)
+	}
+
+	edgeLabel := func(nodeFromId, nodeToId common.NodeID) string {
+		flatMap := make(map[common.NodeID]sets.String)
+		if nodeTo, ok := g.Nodes[nodeToId]; ok {
+			for _, binding := range nodeTo.GetInputBindings() {
+				flatten(binding.GetBinding(), flatMap)
+			}
+		}
+
+		if vars, found := flatMap[nodeFromId]; found {
+			return strings.Join(vars.List(), ",")
+		} else if vars, found := flatMap[""]; found && nodeFromId == common.StartNodeID {
+			return strings.Join(vars.List(), ",")
+		}
+
+		return executionEdgeLabel
+	}
+
+	ids := make(map[common.NodeID]string)
+	var visitOrder []common.NodeID
+	mermaidID := func(nodeId common.NodeID) string {
+		if id, ok := ids[nodeId]; ok {
+			return id
+		}
+
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[nodeId] = id
+		visitOrder = append(visitOrder, nodeId)
+		sb.WriteString(fmt.Sprintf("    %v[\"%v\"]\n", id, mermaidEscape(nodeLabel(nodeId))))
+		return id
+	}
+
+	mermaidID(common.StartNodeID)
+	visitedNodes := sets.NewString()
+	hasStatic := false
+
+	for nodesToVisit := NewNodeNameQ(common.StartNodeID); nodesToVisit.HasNext(); {
+		node := nodesToVisit.Deque()
+		if visitedNodes.Has(node) {
+			continue
+		}
+
+		visitedNodes.Insert(node)
+		for _, child := range g.GetConnections().Downstream[node] {
+			nodesToVisit.Enqueue(child)
+			from, to := mermaidID(node), mermaidID(child)
+			if label := edgeLabel(node, child); label == executionEdgeLabel {
+				sb.WriteString(fmt.Sprintf("    %v -.->|%v| %v\n", from, label, to))
+			} else {
+				sb.WriteString(fmt.Sprintf("    %v -->|\"%v\"| %v\n", from, mermaidEscape(label), to))
+			}
+		}
+
+		// add static bindings' links
+		flatMap := make(common.StringAdjacencyList)
+		if n, ok := g.Nodes[node]; ok {
+			for _, binding := range n.GetInputBindings() {
+				flatten(binding.GetBinding(), flatMap)
+			}
+		}
+
+		if vars, found := flatMap[staticNodeID]; found {
+			if !hasStatic {
+				sb.WriteString(fmt.Sprintf("    %v[(\"%v\")]\n", staticNodeID, staticNodeID))
+				hasStatic = true
+			}
+
+			to := mermaidID(node)
+			if vars.Len() == 0 {
+				sb.WriteString(fmt.Sprintf("    %v --> %v\n", staticNodeID, to))
+			} else {
+				sb.WriteString(fmt.Sprintf("    %v -->|\"%v\"| %v\n", staticNodeID, mermaidEscape(strings.Join(vars.List(), ",")), to))
+			}
+		}
+	}
+
+	// Group the nodes by phase, with one class per phase.
+	nodesByPhase := make(map[v1alpha1.NodePhase][]string)
+	var phases []v1alpha1.NodePhase
+	for _, nodeId := range visitOrder {
+		phase := nodePhase(g, nodeId)
+		if _, ok := nodesByPhase[phase]; !ok {
+			phases = append(phases, phase)
+		}
+
+		nodesByPhase[phase] = append(nodesByPhase[phase], ids[nodeId])
+	}
+
+	for _, phase := range phases {
+		sb.WriteString(fmt.Sprintf("    classDef %v fill:%v\n", phase.String(), phaseColor(phase)))
+		sb.WriteString(fmt.Sprintf("    class %v %v\n", strings.Join(nodesByPhase[phase], ","), phase.String()))
+	}
+
+	return sb.String()
+}
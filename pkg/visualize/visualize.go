@@ -22,6 +22,41 @@ const (
 
 const staticNodeID = "static"
 
+// Fill colors of the nodes by the phase they are in, so that stuck or failed nodes stand out. The names are valid both
+// as GraphViz colors and as CSS colors for Mermaid.
+var phaseColors = map[v1alpha1.NodePhase]string{
+	v1alpha1.NodePhaseNotYetStarted:    "white",
+	v1alpha1.NodePhaseQueued:           "lightblue",
+	v1alpha1.NodePhaseRunning:          "yellow",
+	v1alpha1.NodePhaseDynamicRunning:   "yellow",
+	v1alpha1.NodePhaseSucceeding:       "palegreen",
+	v1alpha1.NodePhaseSucceeded:        "green",
+	v1alpha1.NodePhaseRecovered:        "lightgreen",
+	v1alpha1.NodePhaseFailing:          "orange",
+	v1alpha1.NodePhaseTimingOut:        "orange",
+	v1alpha1.NodePhaseRetryableFailure: "orange",
+	v1alpha1.NodePhaseFailed:           "red",
+	v1alpha1.NodePhaseTimedOut:         "red",
+	v1alpha1.NodePhaseSkipped:          "grey",
+}
+
+// Returns the live phase of the node in the workflow. Nodes without a status did not start yet.
+func nodePhase(g *v1alpha1.FlyteWorkflow, nodeID common.NodeID) v1alpha1.NodePhase {
+	if status, ok := g.Status.NodeStatus[nodeID]; ok && status != nil {
+		return status.GetPhase()
+	}
+
+	return v1alpha1.NodePhaseNotYetStarted
+}
+
+func phaseColor(phase v1alpha1.NodePhase) string {
+	if color, ok := phaseColors[phase]; ok {
+		return color
+	}
+
+	return "white"
+}
+
 func flatten(binding *core.BindingData, flatMap map[common.NodeID]sets.String) {
 	switch binding.GetValue().(type) {
 	case *core.BindingData_Collection:
@@ -45,7 +80,8 @@ func flatten(binding *core.BindingData, flatMap map[common.NodeID]sets.String) {
 	}
 }
 
-// Returns GraphViz https://www.graphviz.org/ representation of the current state of the state machine.
+// Returns GraphViz https://www.graphviz.org/ representation of the current state of the state machine. Nodes are
+// color-coded by their live phase.
 func WorkflowToGraphViz(g *v1alpha1.FlyteWorkflow) string {
 	res := fmt.Sprintf("digraph G {rankdir=TB;workflow[label=\"Workflow Id: %v\"];node[style=filled];",
 		g.ID)
@@ -134,6 +170,10 @@ func WorkflowToGraphViz(g *v1alpha1.FlyteWorkflow) string {
 		visitedNodes.Insert(node)
 	}
 
+	for _, node := range visitedNodes.List() {
+		res += fmt.Sprintf("\"%v\" [fillcolor=\"%v\"];", nodeLabel(node), phaseColor(nodePhase(g, node)))
+	}
+
 	res += "}"
 
 	return res
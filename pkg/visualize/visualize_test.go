@@ -0,0 +1,80 @@
+package visualize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytepropeller/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWorkflow() *v1alpha1.FlyteWorkflow {
+	return &v1alpha1.FlyteWorkflow{
+		WorkflowSpec: &v1alpha1.WorkflowSpec{
+			ID: "wf",
+			Nodes: map[v1alpha1.NodeID]*v1alpha1.NodeSpec{
+				v1alpha1.StartNodeID: {ID: v1alpha1.StartNodeID, Kind: v1alpha1.NodeKindStart},
+				"n1": {ID: "n1", Kind: v1alpha1.NodeKindTask, InputBindings: []*v1alpha1.Binding{
+					{Binding: utils.MakeBinding("a", utils.MakeBindingDataPromise("", "x"))},
+					{Binding: utils.MakeBinding("b", utils.MustMakePrimitiveBindingData(1))},
+				}},
+				"n2": {ID: "n2", Kind: v1alpha1.NodeKindTask},
+				v1alpha1.EndNodeID: {ID: v1alpha1.EndNodeID, Kind: v1alpha1.NodeKindEnd, InputBindings: []*v1alpha1.Binding{
+					{Binding: utils.MakeBinding("y", utils.MakeBindingDataPromise("n1", "y"))},
+				}},
+			},
+			Connections: v1alpha1.Connections{
+				Downstream: map[v1alpha1.NodeID][]v1alpha1.NodeID{
+					v1alpha1.StartNodeID: {"n1", "n2"},
+					"n1":                 {v1alpha1.EndNodeID},
+					"n2":                 {v1alpha1.EndNodeID},
+				},
+			},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			NodeStatus: map[v1alpha1.NodeID]*v1alpha1.NodeStatus{
+				v1alpha1.StartNodeID: {Phase: v1alpha1.NodePhaseSucceeded},
+				"n1":                 {Phase: v1alpha1.NodePhaseSucceeded},
+				"n2":                 {Phase: v1alpha1.NodePhaseFailed},
+			},
+		},
+	}
+}
+
+func TestWorkflowToGraphViz(t *testing.T) {
+	dot := WorkflowToGraphViz(newTestWorkflow())
+	assert.True(t, strings.HasPrefix(dot, "digraph G {"))
+	assert.Contains(t, dot, "\"start-node(start)\" -> \"n1(task)\" [label=\"x\",style=\"solid\"];")
+	assert.Contains(t, dot, "\"start-node(start)\" -> \"n2(task)\" [label=\"execution\",style=\"dashed\"];")
+	assert.Contains(t, dot, "\"static\" -> \"n1(task)\" [label=\"\"];")
+	assert.Contains(t, dot, "\"n1(task)\" [fillcolor=\"green\"];")
+	assert.Contains(t, dot, "\"n2(task)\" [fillcolor=\"red\"];")
+	assert.Contains(t, dot, "\"end-node(end)\" [fillcolor=\"white\"];")
+}
+
+func TestWorkflowToMermaid(t *testing.T) {
+	expected := `flowchart TB
+    n0["start-node(start)"]
+    n1["n1(task)"]
+    n0 -->|"x"| n1
+    n2["n2(task)"]
+    n0 -.->|execution| n2
+    n3["end-node(end)"]
+    n1 -->|"y"| n3
+    static[("static")]
+    static --> n1
+    n2 -.->|execution| n3
+    classDef Succeeded fill:green
+    class n0,n1 Succeeded
+    classDef Failed fill:red
+    class n2 Failed
+    classDef NotYetStarted fill:white
+    class n3 NotYetStarted
+`
+	assert.Equal(t, expected, WorkflowToMermaid(newTestWorkflow()))
+}
+
+func TestMermaidEscape(t *testing.T) {
+	assert.Equal(t, "a#quot;b", mermaidEscape("a\"b"))
+}
@@ -0,0 +1,266 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path"
+	"runtime/pprof"
+	"time"
+
+	webhookConfig "github.com/flyteorg/flytepropeller/pkg/webhook/config"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	kubeErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	admissionregistrationv1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type certRotatorMetrics struct {
+	rotations        prometheus.Counter
+	rotationFailures prometheus.Counter
+	expiry           prometheus.Gauge
+}
+
+// CertRotator generates the self-signed certs of the webhook, stores them in the configured secret and rotates them
+// before they expire. Every replica of the webhook writes the certs of the secret to its cert dir, where they are
+// reloaded by the webhook server, and keeps the CA bundle of the MutatingWebhookConfiguration in sync.
+type CertRotator struct {
+	cfg           *webhookConfig.Config
+	namespace     string
+	secretsClient v1.SecretInterface
+	webhookClient admissionregistrationv1.MutatingWebhookConfigurationInterface
+	clk           clock.Clock
+	metrics       *certRotatorMetrics
+}
+
+// firstPEMBlock returns the first PEM encoded block of data, or nil if there is none.
+func firstPEMBlock(data []byte) *pem.Block {
+	block, _ := pem.Decode(data)
+	return block
+}
+
+// requiresRotation returns whether the certs in the secret data are missing, invalid or about to expire.
+func (r *CertRotator) requiresRotation(data map[string][]byte) bool {
+	for _, key := range []string{CaCertKey, ServerCertKey, ServerCertPrivateKey} {
+		if len(data[key]) == 0 {
+			return true
+		}
+	}
+
+	block := firstPEMBlock(data[ServerCertKey])
+	if block == nil {
+		return true
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+
+	r.metrics.expiry.Set(float64(cert.NotAfter.Unix()))
+	return !r.clk.Now().Before(cert.NotAfter.Add(-r.cfg.CertRotation.RotateBefore.Duration))
+}
+
+// rotate issues new certs and stores them in the secret. The CA of the previous certs is kept in the CA bundle so
+// that the API Server keeps trusting the replicas that still serve the previous certs until they reload them.
+func (r *CertRotator) rotate(ctx context.Context, existing *corev1.Secret) (*corev1.Secret, error) {
+	certs, err := createCerts(r.namespace, r.cfg.CertRotation.Validity.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle := certs.CaPEM.Bytes()
+	if existing != nil {
+		if block := firstPEMBlock(existing.Data[CaCertKey]); block != nil {
+			caBundle = append(caBundle, pem.EncodeToMemory(block)...)
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.cfg.SecretName,
+			Namespace: r.namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			CaCertKey:            caBundle,
+			ServerCertKey:        certs.ServerPEM.Bytes(),
+			ServerCertPrivateKey: certs.PrivateKeyPEM.Bytes(),
+		},
+	}
+
+	var stored *corev1.Secret
+	switch {
+	case existing == nil:
+		stored, err = r.secretsClient.Create(ctx, secret, metav1.CreateOptions{})
+	case existing.Immutable != nil && *existing.Immutable:
+		// Secrets created by init-certs are immutable, they have to be replaced.
+		uid := existing.UID
+		err = r.secretsClient.Delete(ctx, existing.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+		if err != nil && !kubeErrors.IsNotFound(err) && !kubeErrors.IsConflict(err) {
+			return nil, err
+		}
+
+		stored, err = r.secretsClient.Create(ctx, secret, metav1.CreateOptions{})
+	default:
+		secret.ResourceVersion = existing.ResourceVersion
+		stored, err = r.secretsClient.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+
+	if err != nil && (kubeErrors.IsAlreadyExists(err) || kubeErrors.IsConflict(err)) {
+		logger.Infof(ctx, "Another replica of the webhook has rotated the certs in secret [%v]. Using them.", r.cfg.SecretName)
+		return r.secretsClient.Get(ctx, r.cfg.SecretName, metav1.GetOptions{})
+	} else if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "Rotated the webhook certs in secret [%v]", r.cfg.SecretName)
+	r.metrics.rotations.Inc()
+	return stored, nil
+}
+
+// writeCertFile atomically replaces the file, if its content changed, so that the webhook server never reloads a
+// partially written cert.
+func writeCertFile(dir, name string, data []byte) error {
+	filePath := path.Join(dir, name)
+	if existing, err := os.ReadFile(filePath); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, permission); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filePath)
+}
+
+func writeCerts(dir string, data map[string][]byte) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	// The server cert is written last, once its private key is in place.
+	for _, key := range []string{CaCertKey, ServerCertPrivateKey, ServerCertKey} {
+		if err := writeCertFile(dir, key, data[key]); err != nil {
+			return fmt.Errorf("failed to write [%v] to [%v]. Error: %w", key, dir, err)
+		}
+	}
+
+	return nil
+}
+
+// updateCABundle sets the CA bundle of all the webhooks of the MutatingWebhookConfiguration, if it exists.
+func (r *CertRotator) updateCABundle(ctx context.Context, caBundle []byte) error {
+	mutateConfig, err := r.webhookClient.Get(ctx, r.cfg.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		if kubeErrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	changed := false
+	for i := range mutateConfig.Webhooks {
+		if !bytes.Equal(mutateConfig.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			mutateConfig.Webhooks[i].ClientConfig.CABundle = caBundle
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	logger.Infof(ctx, "Updating the CA bundle of MutatingWebhookConfiguration [%v]", mutateConfig.GetName())
+	_, err = r.webhookClient.Update(ctx, mutateConfig, metav1.UpdateOptions{})
+	return err
+}
+
+// Sync makes sure the secret holds valid certs that are not about to expire, rotating them otherwise, then writes them
+// to the cert dir and updates the CA bundle of the MutatingWebhookConfiguration.
+func (r *CertRotator) Sync(ctx context.Context) error {
+	secret, err := r.secretsClient.Get(ctx, r.cfg.SecretName, metav1.GetOptions{})
+	if err != nil {
+		if !kubeErrors.IsNotFound(err) {
+			return err
+		}
+
+		secret = nil
+	}
+
+	if secret == nil || r.requiresRotation(secret.Data) {
+		secret, err = r.rotate(ctx, secret)
+		if err != nil {
+			r.metrics.rotationFailures.Inc()
+			return fmt.Errorf("failed to rotate the webhook certs. Error: %w", err)
+		}
+	}
+
+	if err := writeCerts(r.cfg.CertDir, secret.Data); err != nil {
+		return err
+	}
+
+	return r.updateCABundle(ctx, secret.Data[CaCertKey])
+}
+
+func (r *CertRotator) run(ctx context.Context, ticker clock.Ticker) {
+	logger.Infof(ctx, "Background cert rotation started, with interval [%s]", r.cfg.CertRotation.CheckInterval.String())
+
+	ctx = contextutils.WithGoroutineLabel(ctx, "cert-rotation-worker")
+	pprof.SetGoroutineLabels(ctx)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			if err := r.Sync(ctx); err != nil {
+				logger.Errorf(ctx, "Failed to sync the webhook certs. Error: %v", err)
+			}
+		case <-ctx.Done():
+			logger.Infof(ctx, "Cert rotation stopping")
+			return
+		}
+	}
+}
+
+// Start periodically syncs the certs in the background. Use the context to signal an exit signal.
+func (r *CertRotator) Start(ctx context.Context) error {
+	if !r.cfg.CertRotation.Enabled {
+		logger.Infof(ctx, "Cert rotation is disabled")
+		return nil
+	}
+
+	interval := r.cfg.CertRotation.CheckInterval.Duration
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	go r.run(ctx, r.clk.NewTicker(interval))
+	return nil
+}
+
+func NewCertRotator(cfg *webhookConfig.Config, namespace string, secretsClient v1.SecretInterface,
+	webhookClient admissionregistrationv1.MutatingWebhookConfigurationInterface, clk clock.Clock, scope promutils.Scope) *CertRotator {
+	return &CertRotator{
+		cfg:           cfg,
+		namespace:     namespace,
+		secretsClient: secretsClient,
+		webhookClient: webhookClient,
+		clk:           clk,
+		metrics: &certRotatorMetrics{
+			rotations:        scope.MustNewCounter("cert_rotations", "Number of times the webhook certs were rotated"),
+			rotationFailures: scope.MustNewCounter("cert_rotation_failures", "Number of failures to rotate the webhook certs"),
+			expiry:           scope.MustNewGauge("cert_expiry", "Expiry of the webhook serving cert, as a unix timestamp"),
+		},
+	}
+}
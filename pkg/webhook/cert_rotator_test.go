@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"context"
+	"encoding/pem"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	flyteConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/kubernetes/fake"
+
+	webhookConfig "github.com/flyteorg/flytepropeller/pkg/webhook/config"
+)
+
+func countPEMBlocks(data []byte) int {
+	count := 0
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		count++
+	}
+
+	return count
+}
+
+func TestCertRotator_Sync(t *testing.T) {
+	ctx := context.TODO()
+	newRotator := func(t *testing.T, clk clock.Clock, objects ...runtime.Object) (*CertRotator, *fake.Clientset, *webhookConfig.Config) {
+		cfg := &webhookConfig.Config{
+			SecretName:  "flyte-pod-webhook",
+			ServiceName: "flyte-pod-webhook",
+			CertDir:     t.TempDir(),
+			CertRotation: webhookConfig.CertRotationConfig{
+				Enabled:       true,
+				Validity:      flyteConfig.Duration{Duration: 10 * time.Hour},
+				RotateBefore:  flyteConfig.Duration{Duration: time.Hour},
+				CheckInterval: flyteConfig.Duration{Duration: time.Minute},
+			},
+		}
+
+		kubeClient := fake.NewSimpleClientset(objects...)
+		r := NewCertRotator(cfg, "flyte", kubeClient.CoreV1().Secrets("flyte"),
+			kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(), clk, promutils.NewTestScope())
+		return r, kubeClient, cfg
+	}
+
+	mutateConfig := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "flyte-pod-webhook",
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name: webhookName,
+			},
+		},
+	}
+
+	t.Run("generate missing certs", func(t *testing.T) {
+		r, kubeClient, cfg := newRotator(t, clock.NewFakeClock(time.Now()), mutateConfig.DeepCopy())
+		assert.NoError(t, r.Sync(ctx))
+
+		secret, err := kubeClient.CoreV1().Secrets("flyte").Get(ctx, cfg.SecretName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, countPEMBlocks(secret.Data[CaCertKey]))
+		assert.NoError(t, ValidateCerts(cfg))
+
+		caBytes, err := os.ReadFile(path.Join(cfg.CertDir, CaCertKey))
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Data[CaCertKey], caBytes)
+
+		obj, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, cfg.ServiceName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Data[CaCertKey], obj.Webhooks[0].ClientConfig.CABundle)
+	})
+
+	t.Run("valid certs are kept", func(t *testing.T) {
+		clk := clock.NewFakeClock(time.Now())
+		r, kubeClient, cfg := newRotator(t, clk)
+		assert.NoError(t, r.Sync(ctx))
+		before, err := kubeClient.CoreV1().Secrets("flyte").Get(ctx, cfg.SecretName, metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		clk.Step(8 * time.Hour)
+		assert.NoError(t, r.Sync(ctx))
+		after, err := kubeClient.CoreV1().Secrets("flyte").Get(ctx, cfg.SecretName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, before.Data, after.Data)
+	})
+
+	t.Run("expiring certs are rotated", func(t *testing.T) {
+		clk := clock.NewFakeClock(time.Now())
+		certs, err := createCerts("flyte", 10*time.Hour)
+		assert.NoError(t, err)
+		immutable := true
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "flyte-pod-webhook",
+				Namespace: "flyte",
+			},
+			Data: map[string][]byte{
+				CaCertKey:            certs.CaPEM.Bytes(),
+				ServerCertKey:        certs.ServerPEM.Bytes(),
+				ServerCertPrivateKey: certs.PrivateKeyPEM.Bytes(),
+			},
+			Immutable: &immutable,
+		}
+
+		r, kubeClient, cfg := newRotator(t, clk, secret, mutateConfig.DeepCopy())
+		clk.Step(9*time.Hour + time.Minute)
+		assert.NoError(t, r.Sync(ctx))
+
+		rotated, err := kubeClient.CoreV1().Secrets("flyte").Get(ctx, cfg.SecretName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NotEqual(t, certs.ServerPEM.Bytes(), rotated.Data[ServerCertKey])
+		// The previous CA is still trusted.
+		assert.Equal(t, 2, countPEMBlocks(rotated.Data[CaCertKey]))
+		assert.Contains(t, string(rotated.Data[CaCertKey]), certs.CaPEM.String())
+		assert.NoError(t, ValidateCerts(cfg))
+
+		obj, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, cfg.ServiceName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, rotated.Data[CaCertKey], obj.Webhooks[0].ClientConfig.CABundle)
+	})
+
+	t.Run("invalid certs are rotated", func(t *testing.T) {
+		r, _, _ := newRotator(t, clock.NewFakeClock(time.Now()))
+		assert.True(t, r.requiresRotation(map[string][]byte{
+			CaCertKey:            []byte("ca"),
+			ServerCertKey:        []byte("cert"),
+			ServerCertPrivateKey: []byte("key"),
+		}))
+		assert.True(t, r.requiresRotation(nil))
+	})
+}
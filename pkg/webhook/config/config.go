@@ -1,9 +1,12 @@
 package config
 
 import (
+	"time"
+
 	"github.com/flyteorg/flytestdlib/config"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 //go:generate enumer --type=SecretManagerType --trimprefix=SecretManagerType -json -yaml
@@ -20,6 +23,11 @@ var (
 		LocalCert:         false,
 		ListenPort:        9443,
 		SecretManagerType: SecretManagerTypeK8s,
+		CertRotation: CertRotationConfig{
+			Validity:      config.Duration{Duration: 365 * 24 * time.Hour},
+			RotateBefore:  config.Duration{Duration: 30 * 24 * time.Hour},
+			CheckInterval: config.Duration{Duration: time.Hour},
+		},
 		AWSSecretManagerConfig: AWSSecretManagerConfig{
 			SidecarImage: "docker.io/amazon/aws-secrets-manager-secret-sidecar:v0.1.4",
 			Resources: corev1.ResourceRequirements{
@@ -33,10 +41,26 @@ var (
 				},
 			},
 		},
+		GCPSecretManagerConfig: GCPSecretManagerConfig{
+			SidecarImage: "gcr.io/google.com/cloudsdktool/cloud-sdk:alpine",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("500Mi"),
+					corev1.ResourceCPU:    resource.MustParse("200m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("500Mi"),
+					corev1.ResourceCPU:    resource.MustParse("200m"),
+				},
+			},
+		},
 		VaultSecretManagerConfig: VaultSecretManagerConfig{
 			Role:      "flyte",
 			KVVersion: KVVersion2,
 		},
+		PriorityClasses: PriorityClassConfig{
+			Label: "flyte.org/priority",
+		},
 	}
 
 	configSection = config.MustRegisterSection("webhook", DefaultConfig)
@@ -59,6 +83,10 @@ const (
 
 	// SecretManagerTypeVault defines a secret manager webhook that pulls secrets from Hashicorp Vault.
 	SecretManagerTypeVault
+
+	// SecretManagerTypeGCP defines a secret manager webhook that injects an init container to pull secrets from GCP
+	// Secret Manager and mount them to a local file system (in memory) shared with the other containers in the pod.
+	SecretManagerTypeGCP
 )
 
 // Defines with KV Engine Version to use with VaultSecretManager - https://www.vaultproject.io/docs/secrets/kv#kv-secrets-engine
@@ -79,9 +107,26 @@ type Config struct {
 	ServiceName              string                   `json:"serviceName" pflag:",The name of the webhook service."`
 	ServicePort              int32                    `json:"servicePort" pflag:",The port on the service that hosting webhook."`
 	SecretName               string                   `json:"secretName" pflag:",Secret name to write generated certs to."`
+	CertRotation             CertRotationConfig       `json:"certRotation" pflag:",Configures the generation and rotation of the webhook certs."`
+	NamespaceSelector        *metav1.LabelSelector    `json:"namespaceSelector" pflag:"-,Restricts the webhook to the pods of the namespaces matching this selector. All namespaces are matched if not set."`
+	ObjectSelector           *metav1.LabelSelector    `json:"objectSelector" pflag:"-,Restricts the webhook to the pods matching this selector. Defaults to the pods labeled for secret injection."`
 	SecretManagerType        SecretManagerType        `json:"secretManagerType" pflag:"-,Secret manager type to use if secrets are not found in global secrets."`
 	AWSSecretManagerConfig   AWSSecretManagerConfig   `json:"awsSecretManager" pflag:",AWS Secret Manager config."`
+	GCPSecretManagerConfig   GCPSecretManagerConfig   `json:"gcpSecretManager" pflag:",GCP Secret Manager config."`
 	VaultSecretManagerConfig VaultSecretManagerConfig `json:"vaultSecretManager" pflag:",Vault Secret Manager config."`
+	PodMutations             []PodMutationConfig      `json:"podMutations" pflag:"-,Additional mutations applied, in order, to the pods after secrets are injected."`
+	URL                      string                   `json:"url" pflag:",Base URL the API Server calls the webhook at, e.g. https://webhook.example.com. If set, the webhook is registered with this URL instead of a reference to the webhook service, for webhooks running out of the cluster or behind a load balancer."`
+	CABundlePath             string                   `json:"caBundlePath" pflag:",Path to the CA bundle the API Server verifies the webhook cert with. Defaults to ca.crt in the cert directory."`
+	PriorityClasses          PriorityClassConfig      `json:"priorityClasses" pflag:",Maps Flyte-level priorities to the PriorityClasses of the pods."`
+}
+
+// CertRotationConfig configures the webhook to generate its own self-signed certs, store them in the configured secret
+// and rotate them before they expire. The certs are written to CertDir, which must then be writable.
+type CertRotationConfig struct {
+	Enabled       bool            `json:"enabled" pflag:",Generates the webhook certs if missing and rotates them before they expire."`
+	Validity      config.Duration `json:"validity" pflag:",How long generated certs are valid for."`
+	RotateBefore  config.Duration `json:"rotateBefore" pflag:",How long before their expiry the certs are rotated."`
+	CheckInterval config.Duration `json:"checkInterval" pflag:",How often the certs are checked for expiry."`
 }
 
 type AWSSecretManagerConfig struct {
@@ -89,9 +134,50 @@ type AWSSecretManagerConfig struct {
 	Resources    corev1.ResourceRequirements `json:"resources" pflag:"-,Specifies resource requirements for the init container."`
 }
 
+type GCPSecretManagerConfig struct {
+	SidecarImage string                      `json:"sidecarImage" pflag:",Specifies the sidecar docker image to use. It must provide the gcloud CLI."`
+	Resources    corev1.ResourceRequirements `json:"resources" pflag:"-,Specifies resource requirements for the init container."`
+}
+
 type VaultSecretManagerConfig struct {
-	Role      string    `json:"role" pflag:",Specifies the vault role to use"`
-	KVVersion KVVersion `json:"kvVersion" pflag:"-,The KV Engine Version. Defaults to 2. Use 1 for unversioned secrets. Refer to - https://www.vaultproject.io/docs/secrets/kv#kv-secrets-engine."`
+	Role        string    `json:"role" pflag:",Specifies the vault role to use"`
+	KVVersion   KVVersion `json:"kvVersion" pflag:"-,The KV Engine Version. Defaults to 2. Use 1 for unversioned secrets. Refer to - https://www.vaultproject.io/docs/secrets/kv#kv-secrets-engine."`
+	AuthPath    string    `json:"authPath" pflag:",Mount path of the Vault auth method to log in with, e.g. auth/kubernetes. Defaults to the one of the Vault Agent."`
+	KVMountPath string    `json:"kvMountPath" pflag:",Mount path of the KV Engine, e.g. secret. If set, secret groups are paths relative to the KV Engine, otherwise they are full Vault paths."`
+	// GroupPrefixes allows injecting some secrets from Vault while another secret manager is the default one.
+	GroupPrefixes []string `json:"groupPrefixes" pflag:",Secrets with a group starting with one of these prefixes are injected from Vault, even if it is not the configured secret manager. Secrets that must be mounted as env vars are not."`
+}
+
+// PodMutationConfig configures a mutation applied to the pods handled by the webhook, e.g. to inject runtime env vars,
+// tolerations or a logging sidecar. Note that the webhook only handles the pods matching its object selector.
+type PodMutationConfig struct {
+	// ID identifies the mutation in logs.
+	ID string `json:"id"`
+	// Required mutations fail the creation of the pod if they fail, other mutations are skipped.
+	Required bool `json:"required"`
+	// Namespaces the mutation is applied to. It is applied to all namespaces if empty.
+	Namespaces []string `json:"namespaces"`
+	// ExcludedNamespaces the mutation is never applied to.
+	ExcludedNamespaces []string `json:"excludedNamespaces"`
+	// Env vars added to all the containers of the pod, unless a container already defines them.
+	Env []corev1.EnvVar `json:"env"`
+	// Tolerations added to the pod.
+	Tolerations []corev1.Toleration `json:"tolerations"`
+	// Sidecars added to the pod, unless it already has a container with the same name.
+	Sidecars []corev1.Container `json:"sidecars"`
+}
+
+// PriorityClassConfig configures setting the PriorityClass of pods from the Flyte-level priority they are labeled with,
+// which task pods inherit from the labels of their execution, so that cluster-level preemption aligns with it. Note
+// that the webhook only handles the pods matching its object selector.
+type PriorityClassConfig struct {
+	// Label of the pods holding their Flyte-level priority.
+	Label string `json:"label" pflag:",Label of the pods holding their Flyte-level priority."`
+	// Mapping from the Flyte-level priorities to the names of the PriorityClasses of the pods. Pods with other
+	// priorities are left untouched.
+	Mapping map[string]string `json:"mapping" pflag:"-,Mapping from the Flyte-level priorities to the names of the PriorityClasses of the pods."`
+	// Required fails the creation of pods whose PriorityClass doesn't exist, instead of creating them without it.
+	Required bool `json:"required" pflag:",Fails the creation of pods whose PriorityClass doesn't exist, instead of creating them without it."`
 }
 
 func GetConfig() *Config {
@@ -57,7 +57,19 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "serviceName"), DefaultConfig.ServiceName, "The name of the webhook service.")
 	cmdFlags.Int32(fmt.Sprintf("%v%v", prefix, "servicePort"), DefaultConfig.ServicePort, "The port on the service that hosting webhook.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "secretName"), DefaultConfig.SecretName, "Secret name to write generated certs to.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "certRotation.enabled"), DefaultConfig.CertRotation.Enabled, "Generates the webhook certs if missing and rotates them before they expire.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "certRotation.validity"), DefaultConfig.CertRotation.Validity.String(), "How long generated certs are valid for.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "certRotation.rotateBefore"), DefaultConfig.CertRotation.RotateBefore.String(), "How long before their expiry the certs are rotated.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "certRotation.checkInterval"), DefaultConfig.CertRotation.CheckInterval.String(), "How often the certs are checked for expiry.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "awsSecretManager.sidecarImage"), DefaultConfig.AWSSecretManagerConfig.SidecarImage, "Specifies the sidecar docker image to use")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "vaultSecretManager.role"), DefaultConfig.VaultSecretManagerConfig.Role, "Specifies the vault role to use")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "vaultSecretManager.authPath"), DefaultConfig.VaultSecretManagerConfig.AuthPath, "Mount path of the Vault auth method to log in with, e.g. auth/kubernetes. Defaults to the one of the Vault Agent.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "vaultSecretManager.kvMountPath"), DefaultConfig.VaultSecretManagerConfig.KVMountPath, "Mount path of the KV Engine, e.g. secret. If set, secret groups are paths relative to the KV Engine, otherwise they are full Vault paths.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "vaultSecretManager.groupPrefixes"), DefaultConfig.VaultSecretManagerConfig.GroupPrefixes, "Secrets with a group starting with one of these prefixes are injected from Vault, even if it is not the configured secret manager. Secrets that must be mounted as env vars are not.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "gcpSecretManager.sidecarImage"), DefaultConfig.GCPSecretManagerConfig.SidecarImage, "Specifies the sidecar docker image to use. It must provide the gcloud CLI.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "url"), DefaultConfig.URL, "Base URL the API Server calls the webhook at, e.g. https://webhook.example.com. If set, the webhook is registered with this URL instead of a reference to the webhook service, for webhooks running out of the cluster or behind a load balancer.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caBundlePath"), DefaultConfig.CABundlePath, "Path to the CA bundle the API Server verifies the webhook cert with. Defaults to ca.crt in the cert directory.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "priorityClasses.label"), DefaultConfig.PriorityClasses.Label, "Label of the pods holding their Flyte-level priority.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "priorityClasses.required"), DefaultConfig.PriorityClasses.Required, "Fails the creation of pods whose PriorityClass doesn't exist, instead of creating them without it.")
 	return cmdFlags
 }
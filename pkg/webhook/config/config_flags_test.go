@@ -197,6 +197,62 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_certRotation.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("certRotation.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("certRotation.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.CertRotation.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_certRotation.validity", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.CertRotation.Validity.String()
+
+			cmdFlags.Set("certRotation.validity", testValue)
+			if vString, err := cmdFlags.GetString("certRotation.validity"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CertRotation.Validity)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_certRotation.rotateBefore", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.CertRotation.RotateBefore.String()
+
+			cmdFlags.Set("certRotation.rotateBefore", testValue)
+			if vString, err := cmdFlags.GetString("certRotation.rotateBefore"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CertRotation.RotateBefore)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_certRotation.checkInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.CertRotation.CheckInterval.String()
+
+			cmdFlags.Set("certRotation.checkInterval", testValue)
+			if vString, err := cmdFlags.GetString("certRotation.checkInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CertRotation.CheckInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_awsSecretManager.sidecarImage", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -225,4 +281,116 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_vaultSecretManager.authPath", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("vaultSecretManager.authPath", testValue)
+			if vString, err := cmdFlags.GetString("vaultSecretManager.authPath"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.VaultSecretManagerConfig.AuthPath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_vaultSecretManager.kvMountPath", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("vaultSecretManager.kvMountPath", testValue)
+			if vString, err := cmdFlags.GetString("vaultSecretManager.kvMountPath"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.VaultSecretManagerConfig.KVMountPath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_vaultSecretManager.groupPrefixes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(DefaultConfig.VaultSecretManagerConfig.GroupPrefixes, ",")
+
+			cmdFlags.Set("vaultSecretManager.groupPrefixes", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("vaultSecretManager.groupPrefixes"); err == nil {
+				testDecodeRaw_Config(t, join_Config(vStringSlice, ","), &actual.VaultSecretManagerConfig.GroupPrefixes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_gcpSecretManager.sidecarImage", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("gcpSecretManager.sidecarImage", testValue)
+			if vString, err := cmdFlags.GetString("gcpSecretManager.sidecarImage"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.GCPSecretManagerConfig.SidecarImage)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_url", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("url", testValue)
+			if vString, err := cmdFlags.GetString("url"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.URL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_caBundlePath", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("caBundlePath", testValue)
+			if vString, err := cmdFlags.GetString("caBundlePath"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CABundlePath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_priorityClasses.label", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("priorityClasses.label", testValue)
+			if vString, err := cmdFlags.GetString("priorityClasses.label"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.PriorityClasses.Label)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_priorityClasses.required", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("priorityClasses.required", testValue)
+			if vBool, err := cmdFlags.GetBool("priorityClasses.required"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.PriorityClasses.Required)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
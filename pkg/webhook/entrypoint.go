@@ -14,6 +14,7 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -40,6 +41,27 @@ func Run(ctx context.Context, propellerCfg *config.Config, cfg *config2.Config,
 	webhookScope := (*scope).NewSubScope("webhook")
 
 	secretsWebhook := NewPodMutator(cfg, webhookScope)
+	if len(cfg.PriorityClasses.Mapping) > 0 {
+		secretsWebhook.Mutators = append(secretsWebhook.Mutators, MutatorConfig{
+			Mutator:  NewPriorityClassMutator(cfg.PriorityClasses, kubeClient.SchedulingV1().PriorityClasses()),
+			Required: cfg.PriorityClasses.Required,
+		})
+	}
+
+	var certRotator *CertRotator
+	if cfg.CertRotation.Enabled {
+		podNamespace, found := os.LookupEnv(PodNamespaceEnvVar)
+		if !found {
+			podNamespace = defaultNamespace
+		}
+
+		// Makes sure valid certs are in the cert dir before the MutationConfig is created from them.
+		certRotator = NewCertRotator(cfg, podNamespace, kubeClient.CoreV1().Secrets(podNamespace),
+			kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(), clock.RealClock{}, webhookScope.NewSubScope("certs"))
+		if err = certRotator.Sync(ctx); err != nil {
+			return err
+		}
+	}
 
 	// Creates a MutationConfig to instruct ApiServer to call this service whenever a Pod is being created.
 	err = createMutationConfig(ctx, kubeClient, secretsWebhook, defaultNamespace)
@@ -52,6 +74,12 @@ func Run(ctx context.Context, propellerCfg *config.Config, cfg *config2.Config,
 		logger.Fatalf(ctx, "Failed to register webhook with manager. Error: %v", err)
 	}
 
+	if certRotator != nil {
+		if err = certRotator.Start(ctx); err != nil {
+			return err
+		}
+	}
+
 	logger.Infof(ctx, "Starting controller-runtime manager")
 	return (*mgr).Start(ctx)
 }
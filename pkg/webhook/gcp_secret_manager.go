@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// GCPSecretNameEnvVar defines the environment variable name to use to specify to the init container which secret
+	// to pull.
+	GCPSecretNameEnvVar = "SECRET_NAME"
+
+	// GCPSecretVersionEnvVar defines the environment variable name to use to specify to the init container which
+	// version of the secret to pull.
+	GCPSecretVersionEnvVar = "SECRET_VERSION"
+
+	// GCPSecretFilenameEnvVar defines the environment variable name to use to specify to the init container where
+	// to store the secret.
+	GCPSecretFilenameEnvVar = "SECRET_FILENAME"
+
+	// GCPSecretsVolumeName defines the static name of the volume used for mounting/sharing secrets between init-container
+	// and the rest of the containers in the pod.
+	GCPSecretsVolumeName = "gcp-secret-vol" // #nosec
+
+	// GCPDefaultSecretVersion is the version pulled when the secret does not specify one.
+	GCPDefaultSecretVersion = "latest"
+
+	// gcpPullSecretScript pulls a single secret version using the gcloud CLI and writes it to $SECRET_FILENAME.
+	gcpPullSecretScript = `mkdir -p "$(dirname "$SECRET_FILENAME")" && ` +
+		`gcloud secrets versions access "$SECRET_VERSION" --secret="$SECRET_NAME" --out-file="$SECRET_FILENAME"`
+)
+
+// GCPSecretManagerInjector allows injecting of secrets from GCP Secret Manager as files. It uses an init-container
+// running the gcloud CLI to download the secret and save it to a local volume shared with all other containers in the
+// pod. It supports multiple secrets to be mounted but that will result into adding an init container for each secret.
+// The service account used to run the Pod must have permissions (e.g. through Workload Identity) to access the secret
+// in GCP Secret Manager. Otherwise, the Pod will fail with an init-error.
+// The secret group is the secret name (or its full resource name), the group version is the secret version (defaults
+// to latest). Files will be mounted on /etc/flyte/secrets/<SecretGroup>/<SecretKey>, or
+// /etc/flyte/secrets/<SecretGroup>/<SecretGroupVersion> if no key is set.
+type GCPSecretManagerInjector struct {
+	cfg config.GCPSecretManagerConfig
+}
+
+func formatGCPSecretVersion(secret *core.Secret) string {
+	if len(secret.GroupVersion) == 0 {
+		return GCPDefaultSecretVersion
+	}
+
+	return secret.GroupVersion
+}
+
+func formatGCPSecretFilename(secret *core.Secret) string {
+	file := secret.Key
+	if len(file) == 0 {
+		file = formatGCPSecretVersion(secret)
+	}
+
+	return filepath.Join(filepath.Join(AWSSecretMountPathPrefix...), strings.ToLower(secret.Group), strings.ToLower(file))
+}
+
+func formatGCPInitContainerName(index int) string {
+	return fmt.Sprintf("gcp-pull-secret-%v", index)
+}
+
+func (i GCPSecretManagerInjector) Type() config.SecretManagerType {
+	return config.SecretManagerTypeGCP
+}
+
+func (i GCPSecretManagerInjector) Inject(ctx context.Context, secret *core.Secret, p *corev1.Pod) (newP *corev1.Pod, injected bool, err error) {
+	if len(secret.Group) == 0 {
+		return nil, false, fmt.Errorf("GCP Secrets Webhook require group to be set. "+
+			"Secret: [%v]", secret)
+	}
+
+	switch secret.MountRequirement {
+	case core.Secret_ANY:
+		fallthrough
+	case core.Secret_FILE:
+		// A Volume with a static name so that if we try to inject multiple secrets, we won't mount multiple volumes.
+		// We use Memory as the storage medium for volume source to avoid writing secrets to the node's disk.
+		vol := corev1.Volume{
+			Name: GCPSecretsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		}
+
+		p.Spec.Volumes = appendVolumeIfNotExists(p.Spec.Volumes, vol)
+		p.Spec.InitContainers = append(p.Spec.InitContainers, createGCPInitContainer(i.cfg, p, secret))
+
+		secretVolumeMount := corev1.VolumeMount{
+			Name:      GCPSecretsVolumeName,
+			ReadOnly:  true,
+			MountPath: filepath.Join(AWSSecretMountPathPrefix...),
+		}
+
+		p.Spec.Containers = AppendVolumeMounts(p.Spec.Containers, secretVolumeMount)
+		p.Spec.InitContainers = AppendVolumeMounts(p.Spec.InitContainers, secretVolumeMount)
+
+		envVars := []corev1.EnvVar{
+			// Set environment variable to let the container know where to find the mounted files.
+			{
+				Name:  SecretPathDefaultDirEnvVar,
+				Value: filepath.Join(AWSSecretMountPathPrefix...),
+			},
+			// Sets an empty prefix to let the containers know the file names will match the secret keys as-is.
+			{
+				Name:  SecretPathFilePrefixEnvVar,
+				Value: "",
+			},
+		}
+
+		for _, envVar := range envVars {
+			p.Spec.InitContainers = AppendEnvVars(p.Spec.InitContainers, envVar)
+			p.Spec.Containers = AppendEnvVars(p.Spec.Containers, envVar)
+		}
+	case core.Secret_ENV_VAR:
+		fallthrough
+	default:
+		err := fmt.Errorf("unrecognized mount requirement [%v] for secret [%v]", secret.MountRequirement.String(), secret.Key)
+		logger.Error(ctx, err)
+		return p, false, err
+	}
+
+	return p, true, nil
+}
+
+func createGCPInitContainer(cfg config.GCPSecretManagerConfig, p *corev1.Pod, secret *core.Secret) corev1.Container {
+	return corev1.Container{
+		Image: cfg.SidecarImage,
+		// Create a unique name to allow multiple secrets to be mounted.
+		Name:    formatGCPInitContainerName(len(p.Spec.InitContainers)),
+		Command: []string{"sh", "-ec", gcpPullSecretScript},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      GCPSecretsVolumeName,
+				MountPath: filepath.Join(AWSSecretMountPathPrefix...),
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name:  GCPSecretNameEnvVar,
+				Value: secret.Group,
+			},
+			{
+				Name:  GCPSecretVersionEnvVar,
+				Value: formatGCPSecretVersion(secret),
+			},
+			{
+				Name:  GCPSecretFilenameEnvVar,
+				Value: formatGCPSecretFilename(secret),
+			},
+		},
+		Resources: cfg.Resources,
+	}
+}
+
+// NewGCPSecretManagerInjector creates a SecretInjector that's able to mount secrets from GCP Secret Manager.
+func NewGCPSecretManagerInjector(cfg config.GCPSecretManagerConfig) GCPSecretManagerInjector {
+	return GCPSecretManagerInjector{
+		cfg: cfg,
+	}
+}
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
+
+	"github.com/go-test/deep"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGCPSecretManagerInjector_Inject(t *testing.T) {
+	injector := NewGCPSecretManagerInjector(config.DefaultConfig.GCPSecretManagerConfig)
+	newExpected := func(version, filename string) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{
+						Name: "gcp-secret-vol",
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{
+								Medium: corev1.StorageMediumMemory,
+							},
+						},
+					},
+				},
+
+				InitContainers: []corev1.Container{
+					{
+						Name:    "gcp-pull-secret-0",
+						Image:   "gcr.io/google.com/cloudsdktool/cloud-sdk:alpine",
+						Command: []string{"sh", "-ec", gcpPullSecretScript},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "SECRET_NAME",
+								Value: "My-Secret",
+							},
+							{
+								Name:  "SECRET_VERSION",
+								Value: version,
+							},
+							{
+								Name:  "SECRET_FILENAME",
+								Value: filename,
+							},
+							{
+								Name:  "FLYTE_SECRETS_DEFAULT_DIR",
+								Value: "/etc/flyte/secrets",
+							},
+							{
+								Name:  "FLYTE_SECRETS_FILE_PREFIX",
+								Value: "",
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "gcp-secret-vol",
+								MountPath: "/etc/flyte/secrets",
+							},
+						},
+						Resources: config.DefaultConfig.GCPSecretManagerConfig.Resources,
+					},
+				},
+				Containers: []corev1.Container{},
+			},
+		}
+	}
+
+	p := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{},
+		},
+	}
+
+	t.Run("Latest version", func(t *testing.T) {
+		inputSecret := &core.Secret{
+			Group: "My-Secret",
+		}
+
+		actualP, injected, err := injector.Inject(context.Background(), inputSecret, p.DeepCopy())
+		assert.NoError(t, err)
+		assert.True(t, injected)
+		if diff := deep.Equal(actualP, newExpected("latest", "/etc/flyte/secrets/my-secret/latest")); diff != nil {
+			assert.Fail(t, "actual != expected", "Diff: %v", diff)
+		}
+	})
+
+	t.Run("Version and key", func(t *testing.T) {
+		inputSecret := &core.Secret{
+			Group:        "My-Secret",
+			GroupVersion: "2",
+			Key:          "Token",
+		}
+
+		actualP, injected, err := injector.Inject(context.Background(), inputSecret, p.DeepCopy())
+		assert.NoError(t, err)
+		assert.True(t, injected)
+		if diff := deep.Equal(actualP, newExpected("2", "/etc/flyte/secrets/my-secret/token")); diff != nil {
+			assert.Fail(t, "actual != expected", "Diff: %v", diff)
+		}
+	})
+
+	t.Run("Missing group", func(t *testing.T) {
+		_, injected, err := injector.Inject(context.Background(), &core.Secret{Key: "Token"}, p.DeepCopy())
+		assert.Error(t, err)
+		assert.False(t, injected)
+	})
+
+	t.Run("Env var unsupported", func(t *testing.T) {
+		inputSecret := &core.Secret{
+			Group:            "My-Secret",
+			MountRequirement: core.Secret_ENV_VAR,
+		}
+
+		_, injected, err := injector.Inject(context.Background(), inputSecret, p.DeepCopy())
+		assert.Error(t, err)
+		assert.False(t, injected)
+	})
+}
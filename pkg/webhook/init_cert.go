@@ -5,6 +5,7 @@ import (
 	"context"
 	cryptorand "crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
@@ -48,7 +49,7 @@ func InitCerts(ctx context.Context, propellerCfg *config.Config, cfg *webhookCon
 	}
 
 	logger.Infof(ctx, "Issuing certs")
-	certs, err := createCerts(podNamespace)
+	certs, err := createCerts(podNamespace, cfg.CertRotation.Validity.Duration)
 	if err != nil {
 		return err
 	}
@@ -67,6 +68,18 @@ func InitCerts(ctx context.Context, propellerCfg *config.Config, cfg *webhookCon
 	return nil
 }
 
+// ValidateCerts verifies that the server certificate and its private key exist in the configured cert directory and
+// form a valid key pair.
+func ValidateCerts(cfg *webhookConfig.Config) error {
+	certFile := path.Join(cfg.CertDir, ServerCertKey)
+	keyFile := path.Join(cfg.CertDir, ServerCertPrivateKey)
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("failed to load webhook certs from [%v]. Error: %w", cfg.CertDir, err)
+	}
+
+	return nil
+}
+
 func createWebhookSecret(ctx context.Context, namespace string, cfg *webhookConfig.Config, certs webhookCerts, secretsClient v1.SecretInterface) error {
 	isImmutable := true
 	secretData := map[string][]byte{
@@ -153,15 +166,33 @@ func createWebhookSecret(ctx context.Context, namespace string, cfg *webhookConf
 	return err
 }
 
-func createCerts(serviceNamespace string) (certs webhookCerts, err error) {
+// newSerialNumber returns a random serial number so that rotated certs never reuse the serial of a previous cert.
+func newSerialNumber() (*big.Int, error) {
+	return cryptorand.Int(cryptorand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func createCerts(serviceNamespace string, validity time.Duration) (certs webhookCerts, err error) {
+	caSerialNumber, err := newSerialNumber()
+	if err != nil {
+		return webhookCerts{}, err
+	}
+
+	serverSerialNumber, err := newSerialNumber()
+	if err != nil {
+		return webhookCerts{}, err
+	}
+
+	notBefore := time.Now()
+	notAfter := notBefore.Add(validity)
+
 	// CA config
 	caRequest := &x509.Certificate{
-		SerialNumber: big.NewInt(2021),
+		SerialNumber: caSerialNumber,
 		Subject: pkix.Name{
 			Organization: []string{"flyte.org"},
 		},
-		NotBefore:             time.Now(),
-		NotAfter:              time.Now().AddDate(1, 0, 0),
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
 		IsCA:                  true,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -197,13 +228,13 @@ func createCerts(serviceNamespace string) (certs webhookCerts, err error) {
 	// server cert config
 	certRequest := &x509.Certificate{
 		DNSNames:     dnsNames,
-		SerialNumber: big.NewInt(1658),
+		SerialNumber: serverSerialNumber,
 		Subject: pkix.Name{
 			CommonName:   commonName,
 			Organization: []string{"flyte.org"},
 		},
-		NotBefore:    time.Now(),
-		NotAfter:     time.Now().AddDate(1, 0, 0),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
 		SubjectKeyId: []byte{1, 2, 3, 4, 6},
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 		KeyUsage:     x509.KeyUsageDigitalSignature,
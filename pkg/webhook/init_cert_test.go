@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	webhookConfig "github.com/flyteorg/flytepropeller/pkg/webhook/config"
+)
+
+func TestValidateCerts(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &webhookConfig.Config{CertDir: dir}
+
+	t.Run("missing", func(t *testing.T) {
+		assert.Error(t, ValidateCerts(cfg))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		certs, err := createCerts("flyte", time.Hour)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(path.Join(dir, ServerCertKey), certs.ServerPEM.Bytes(), permission))
+		assert.NoError(t, os.WriteFile(path.Join(dir, ServerCertPrivateKey), certs.PrivateKeyPEM.Bytes(), permission))
+		assert.NoError(t, ValidateCerts(cfg))
+	})
+}
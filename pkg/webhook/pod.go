@@ -1,5 +1,6 @@
-// The PodMutator is a controller-runtime webhook that intercepts Pod Creation events and mutates them. Currently, there
-// is only one registered Mutator, that's the SecretsMutator. It works as follows:
+// The PodMutator is a controller-runtime webhook that intercepts Pod Creation events and mutates them. The SecretsMutator
+// is always registered first, followed by the PodMutations configured by the operator (e.g. to inject env vars,
+// tolerations or a logging sidecar into the pods of some namespaces). It works as follows:
 //
 //  -  The Webhook only works on Pods. If propeller/plugins launch a resource outside of K8s (or in a separate k8s
 //     cluster), it's the responsibility of the plugin to correctly pass secret injection information.
@@ -9,7 +10,8 @@
 //  -  If a k8s plugin creates a CRD that launches other Pods (e.g. Spark/PyTorch... etc.), it's its responsibility to
 //     make sure the labels/annotations set on the CRD by PluginManager are propagated to those launched Pods. This
 //     ensures secret injection happens no matter how many levels of indirections there are.
-//  -  The Webhook expects 'inject-flyte-secrets: true' as a label on the Pod. Otherwise it won't listen/observe that pod.
+//  -  The Webhook expects 'inject-flyte-secrets: true' as a label on the Pod, unless another object selector is
+//     configured. Otherwise it won't listen/observe that pod.
 //  -  Once it intercepts the admission request, it goes over all registered Mutators and invoke them in the order they
 //     are registered as. If a Mutator fails and it's marked as `required`, the operation will fail and the admission
 //     will be rejected.
@@ -32,6 +34,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -90,6 +93,11 @@ func (pm *PodMutator) Handle(ctx context.Context, request admission.Request) adm
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// Pods created with a generated name may not have their namespace set yet, mutators rely on it.
+	if len(obj.Namespace) == 0 {
+		obj.Namespace = request.Namespace
+	}
+
 	newObj, changed, err := pm.Mutate(ctx, obj)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
@@ -160,7 +168,12 @@ func generateMutatePath(gvk schema.GroupVersionKind) string {
 }
 
 func (pm PodMutator) CreateMutationWebhookConfiguration(namespace string) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
-	caBytes, err := ioutil.ReadFile(filepath.Join(pm.cfg.CertDir, "ca.crt"))
+	caBundlePath := pm.cfg.CABundlePath
+	if len(caBundlePath) == 0 {
+		caBundlePath = filepath.Join(pm.cfg.CertDir, "ca.crt")
+	}
+
+	caBytes, err := ioutil.ReadFile(caBundlePath)
 	if err != nil {
 		// ca.crt is optional. If not provided, API Server will assume the webhook is serving SSL using a certificate
 		// issued by a known Cert Authority.
@@ -171,7 +184,45 @@ func (pm PodMutator) CreateMutationWebhookConfiguration(namespace string) (*admi
 		}
 	}
 
+	// Only pods labeled for secret injection are sent to the webhook, unless configured otherwise.
+	objectSelector := pm.cfg.ObjectSelector
+	if objectSelector == nil {
+		objectSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				secrets.PodLabel: secrets.PodLabelValue,
+			},
+		}
+	}
+
 	path := pm.GetMutatePath()
+	clientConfig := admissionregistrationv1.WebhookClientConfig{
+		CABundle: caBytes, // CA bundle created earlier
+	}
+
+	// Webhooks running out of the cluster or behind a load balancer are called by URL, other webhooks through their
+	// service.
+	if len(pm.cfg.URL) > 0 {
+		webhookURL, err := url.Parse(pm.cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook url [%v]. Error: %w", pm.cfg.URL, err)
+		}
+
+		if webhookURL.Scheme != "https" {
+			return nil, fmt.Errorf("webhook url [%v] must use the https scheme", pm.cfg.URL)
+		}
+
+		webhookURL.Path = strings.TrimSuffix(webhookURL.Path, "/") + path
+		urlStr := webhookURL.String()
+		clientConfig.URL = &urlStr
+	} else {
+		clientConfig.Service = &admissionregistrationv1.ServiceReference{
+			Name:      pm.cfg.ServiceName,
+			Namespace: namespace,
+			Path:      &path,
+			Port:      &pm.cfg.ServicePort,
+		}
+	}
+
 	fail := admissionregistrationv1.Ignore
 	sideEffects := admissionregistrationv1.SideEffectClassNoneOnDryRun
 
@@ -183,16 +234,8 @@ func (pm PodMutator) CreateMutationWebhookConfiguration(namespace string) (*admi
 
 		Webhooks: []admissionregistrationv1.MutatingWebhook{
 			{
-				Name: webhookName,
-				ClientConfig: admissionregistrationv1.WebhookClientConfig{
-					CABundle: caBytes, // CA bundle created earlier
-					Service: &admissionregistrationv1.ServiceReference{
-						Name:      pm.cfg.ServiceName,
-						Namespace: namespace,
-						Path:      &path,
-						Port:      &pm.cfg.ServicePort,
-					},
-				},
+				Name:         webhookName,
+				ClientConfig: clientConfig,
 				Rules: []admissionregistrationv1.RuleWithOperations{
 					{
 						Operations: []admissionregistrationv1.OperationType{
@@ -211,11 +254,8 @@ func (pm PodMutator) CreateMutationWebhookConfiguration(namespace string) (*admi
 					"v1",
 					"v1beta1",
 				},
-				ObjectSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						secrets.PodLabel: secrets.PodLabelValue,
-					},
-				},
+				NamespaceSelector: pm.cfg.NamespaceSelector,
+				ObjectSelector:    objectSelector,
 			}},
 	}
 
@@ -223,12 +263,21 @@ func (pm PodMutator) CreateMutationWebhookConfiguration(namespace string) (*admi
 }
 
 func NewPodMutator(cfg *config.Config, scope promutils.Scope) *PodMutator {
-	return &PodMutator{
-		cfg: cfg,
-		Mutators: []MutatorConfig{
-			{
-				Mutator: NewSecretsMutator(cfg, scope.NewSubScope("secrets")),
-			},
+	mutators := []MutatorConfig{
+		{
+			Mutator: NewSecretsMutator(cfg, scope.NewSubScope("secrets")),
 		},
 	}
+
+	for _, mutationCfg := range cfg.PodMutations {
+		mutators = append(mutators, MutatorConfig{
+			Mutator:  NewPodMutation(mutationCfg),
+			Required: mutationCfg.Required,
+		})
+	}
+
+	return &PodMutator{
+		cfg:      cfg,
+		Mutators: mutators,
+	}
 }
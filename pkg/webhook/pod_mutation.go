@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodMutation is a Mutator that applies a mutation configured by the operator to the pods of the selected namespaces.
+// It adds env vars to all the containers of the pod, tolerations and sidecars, leaving the ones the pod already defines
+// untouched.
+type PodMutation struct {
+	cfg config.PodMutationConfig
+}
+
+func (m PodMutation) ID() string {
+	return m.cfg.ID
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns whether the mutation is applied to the pods of the namespace.
+func (m PodMutation) appliesTo(namespace string) bool {
+	if containsString(m.cfg.ExcludedNamespaces, namespace) {
+		return false
+	}
+
+	return len(m.cfg.Namespaces) == 0 || containsString(m.cfg.Namespaces, namespace)
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(&toleration) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasContainer(containers []corev1.Container, name string) bool {
+	for _, c := range containers {
+		if c.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m PodMutation) Mutate(ctx context.Context, p *corev1.Pod) (newP *corev1.Pod, changed bool, err error) {
+	if !m.appliesTo(p.Namespace) {
+		return p, false, nil
+	}
+
+	for _, envVar := range m.cfg.Env {
+		for _, c := range p.Spec.Containers {
+			if !hasEnvVar(c.Env, envVar.Name) {
+				changed = true
+				break
+			}
+		}
+
+		p.Spec.Containers = AppendEnvVars(p.Spec.Containers, envVar)
+	}
+
+	for _, toleration := range m.cfg.Tolerations {
+		if !hasToleration(p.Spec.Tolerations, toleration) {
+			p.Spec.Tolerations = append(p.Spec.Tolerations, toleration)
+			changed = true
+		}
+	}
+
+	for _, sidecar := range m.cfg.Sidecars {
+		if !hasContainer(p.Spec.Containers, sidecar.Name) {
+			p.Spec.Containers = append(p.Spec.Containers, *sidecar.DeepCopy())
+			changed = true
+		}
+	}
+
+	if changed {
+		logger.Debugf(ctx, "Applied pod mutation [%v] to pod [%v/%v]", m.cfg.ID, p.Namespace, p.Name)
+	}
+
+	return p, changed, nil
+}
+
+// NewPodMutation creates a Mutator that applies the configured mutation.
+func NewPodMutation(cfg config.PodMutationConfig) PodMutation {
+	return PodMutation{
+		cfg: cfg,
+	}
+}
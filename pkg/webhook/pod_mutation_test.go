@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
+)
+
+func TestPodMutation_Mutate(t *testing.T) {
+	ctx := context.TODO()
+	mutation := NewPodMutation(config.PodMutationConfig{
+		ID:                 "runtime",
+		Namespaces:         []string{"flytesnacks-development", "flytesnacks-staging"},
+		ExcludedNamespaces: []string{"flytesnacks-staging"},
+		Env: []corev1.EnvVar{
+			{Name: "RUNTIME", Value: "flyte"},
+		},
+		Tolerations: []corev1.Toleration{
+			{Key: "flyte", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+		Sidecars: []corev1.Container{
+			{Name: "log-forwarder", Image: "fluent-bit"},
+		},
+	})
+
+	newPod := func(namespace string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: namespace,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "main"},
+				},
+			},
+		}
+	}
+
+	assert.Equal(t, "runtime", mutation.ID())
+
+	t.Run("mutated", func(t *testing.T) {
+		p, changed, err := mutation.Mutate(ctx, newPod("flytesnacks-development"))
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Len(t, p.Spec.Containers, 2)
+		assert.Equal(t, []corev1.EnvVar{{Name: "RUNTIME", Value: "flyte"}}, p.Spec.Containers[0].Env)
+		assert.Equal(t, "log-forwarder", p.Spec.Containers[1].Name)
+		assert.Empty(t, p.Spec.Containers[1].Env)
+		assert.Len(t, p.Spec.Tolerations, 1)
+
+		// Mutating again does not change the pod.
+		p, changed, err = mutation.Mutate(ctx, p)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Len(t, p.Spec.Containers, 2)
+		assert.Len(t, p.Spec.Containers[0].Env, 1)
+		assert.Len(t, p.Spec.Tolerations, 1)
+	})
+
+	t.Run("existing env vars are kept", func(t *testing.T) {
+		pod := newPod("flytesnacks-development")
+		pod.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "RUNTIME", Value: "custom"}}
+		p, changed, err := mutation.Mutate(ctx, pod)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []corev1.EnvVar{{Name: "RUNTIME", Value: "custom"}}, p.Spec.Containers[0].Env)
+	})
+
+	t.Run("excluded namespace", func(t *testing.T) {
+		p, changed, err := mutation.Mutate(ctx, newPod("flytesnacks-staging"))
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, newPod("flytesnacks-staging"), p)
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		_, changed, err := mutation.Mutate(ctx, newPod("default"))
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		m := NewPodMutation(config.PodMutationConfig{
+			ID:  "runtime",
+			Env: []corev1.EnvVar{{Name: "RUNTIME", Value: "flyte"}},
+		})
+
+		_, changed, err := m.Mutate(ctx, newPod("default"))
+		assert.NoError(t, err)
+		assert.True(t, changed)
+	})
+}
+
+func TestNewPodMutator_PodMutations(t *testing.T) {
+	pm := NewPodMutator(&config.Config{
+		PodMutations: []config.PodMutationConfig{
+			{ID: "env"},
+			{ID: "sidecar", Required: true},
+		},
+	}, promutils.NewTestScope())
+
+	assert.Len(t, pm.Mutators, 3)
+	assert.Equal(t, "secrets", pm.Mutators[0].Mutator.ID())
+	assert.Equal(t, "env", pm.Mutators[1].Mutator.ID())
+	assert.False(t, pm.Mutators[1].Required)
+	assert.Equal(t, "sidecar", pm.Mutators[2].Mutator.ID())
+	assert.True(t, pm.Mutators[2].Required)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils/secrets"
 	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
 
 	"k8s.io/client-go/tools/clientcmd/api/latest"
@@ -20,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPodMutator_Mutate(t *testing.T) {
@@ -102,6 +104,90 @@ func Test_CreateMutationWebhookConfiguration(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, c)
 	})
+
+	t.Run("Default selectors", func(t *testing.T) {
+		c, err := pm.CreateMutationWebhookConfiguration("my-namespace")
+		assert.NoError(t, err)
+		assert.Nil(t, c.Webhooks[0].NamespaceSelector)
+		assert.Equal(t, map[string]string{secrets.PodLabel: secrets.PodLabelValue}, c.Webhooks[0].ObjectSelector.MatchLabels)
+	})
+
+	t.Run("Configured selectors", func(t *testing.T) {
+		namespaceSelector := &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "kubernetes.io/metadata.name",
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{"kube-system"},
+				},
+			},
+		}
+		objectSelector := &metav1.LabelSelector{
+			MatchLabels: map[string]string{"flyte-injected": "true"},
+		}
+
+		pm := NewPodMutator(&config.Config{
+			CertDir:           "testdata",
+			ServiceName:       "my-service",
+			NamespaceSelector: namespaceSelector,
+			ObjectSelector:    objectSelector,
+		}, promutils.NewTestScope())
+
+		c, err := pm.CreateMutationWebhookConfiguration("my-namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, namespaceSelector, c.Webhooks[0].NamespaceSelector)
+		assert.Equal(t, objectSelector, c.Webhooks[0].ObjectSelector)
+	})
+
+	t.Run("Service client config", func(t *testing.T) {
+		c, err := pm.CreateMutationWebhookConfiguration("my-namespace")
+		assert.NoError(t, err)
+		assert.Nil(t, c.Webhooks[0].ClientConfig.URL)
+		if assert.NotNil(t, c.Webhooks[0].ClientConfig.Service) {
+			assert.Equal(t, "my-service", c.Webhooks[0].ClientConfig.Service.Name)
+			assert.Equal(t, "my-namespace", c.Webhooks[0].ClientConfig.Service.Namespace)
+			assert.Equal(t, pm.GetMutatePath(), *c.Webhooks[0].ClientConfig.Service.Path)
+		}
+	})
+
+	t.Run("URL client config", func(t *testing.T) {
+		pm := NewPodMutator(&config.Config{
+			CertDir:      "testdata",
+			ServiceName:  "my-service",
+			URL:          "https://webhook.example.com:8443/",
+			CABundlePath: "testdata/ca.crt",
+		}, promutils.NewTestScope())
+
+		c, err := pm.CreateMutationWebhookConfiguration("my-namespace")
+		assert.NoError(t, err)
+		assert.Nil(t, c.Webhooks[0].ClientConfig.Service)
+		if assert.NotNil(t, c.Webhooks[0].ClientConfig.URL) {
+			assert.Equal(t, "https://webhook.example.com:8443"+pm.GetMutatePath(), *c.Webhooks[0].ClientConfig.URL)
+		}
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		pm := NewPodMutator(&config.Config{
+			CertDir:     "testdata",
+			ServiceName: "my-service",
+			URL:         "http://webhook.example.com",
+		}, promutils.NewTestScope())
+
+		_, err := pm.CreateMutationWebhookConfiguration("my-namespace")
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing CA bundle", func(t *testing.T) {
+		pm := NewPodMutator(&config.Config{
+			CertDir:      "testdata",
+			ServiceName:  "my-service",
+			CABundlePath: "testdata/missing.crt",
+		}, promutils.NewTestScope())
+
+		c, err := pm.CreateMutationWebhookConfiguration("my-namespace")
+		assert.NoError(t, err)
+		assert.Empty(t, c.Webhooks[0].ClientConfig.CABundle)
+	})
 }
 
 func Test_Handle(t *testing.T) {
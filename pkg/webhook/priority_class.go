@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1 "k8s.io/client-go/kubernetes/typed/scheduling/v1"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
+)
+
+const priorityClassMutatorID = "priority-class"
+
+// PriorityClassMutator is a Mutator that sets the PriorityClass of pods from the Flyte-level priority they are labeled
+// with, according to the configured mapping.
+type PriorityClassMutator struct {
+	cfg             config.PriorityClassConfig
+	priorityClasses schedulingv1.PriorityClassInterface
+}
+
+func (m PriorityClassMutator) ID() string {
+	return priorityClassMutatorID
+}
+
+func (m PriorityClassMutator) Mutate(ctx context.Context, p *corev1.Pod) (newP *corev1.Pod, changed bool, err error) {
+	// PriorityClasses set explicitly take precedence.
+	if len(p.Spec.PriorityClassName) > 0 {
+		return p, false, nil
+	}
+
+	priority, ok := p.Labels[m.cfg.Label]
+	if !ok {
+		return p, false, nil
+	}
+
+	className, ok := m.cfg.Mapping[priority]
+	if !ok {
+		logger.Debugf(ctx, "No PriorityClass mapped to priority [%v] of pod [%v/%v]", priority, p.Namespace, p.Name)
+		return p, false, nil
+	}
+
+	// The priority admission plugin resolves the priority of the pod from its PriorityClass before the webhook is
+	// called, so the resolved priority must be updated along with the class for the pod to pass validation.
+	class, err := m.priorityClasses.Get(ctx, className, metav1.GetOptions{})
+	if err != nil {
+		return p, false, fmt.Errorf("failed to get PriorityClass [%v] mapped to priority [%v]. Error: %w", className,
+			priority, err)
+	}
+
+	p.Spec.PriorityClassName = class.Name
+	value := class.Value
+	p.Spec.Priority = &value
+	p.Spec.PreemptionPolicy = class.PreemptionPolicy
+	logger.Debugf(ctx, "Set PriorityClass [%v] of pod [%v/%v] with priority [%v]", class.Name, p.Namespace, p.Name,
+		priority)
+
+	return p, true, nil
+}
+
+// NewPriorityClassMutator creates a Mutator that sets the PriorityClass of pods, validating that it exists through the
+// given client.
+func NewPriorityClassMutator(cfg config.PriorityClassConfig, priorityClasses schedulingv1.PriorityClassInterface) PriorityClassMutator {
+	return PriorityClassMutator{
+		cfg:             cfg,
+		priorityClasses: priorityClasses,
+	}
+}
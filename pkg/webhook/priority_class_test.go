@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
+)
+
+func TestPriorityClassMutator_Mutate(t *testing.T) {
+	ctx := context.TODO()
+	preemptionPolicy := corev1.PreemptNever
+	kubeClient := fake.NewSimpleClientset(&schedulingv1.PriorityClass{
+		ObjectMeta:       metav1.ObjectMeta{Name: "flyte-high"},
+		Value:            1000,
+		PreemptionPolicy: &preemptionPolicy,
+	})
+
+	mutator := NewPriorityClassMutator(config.PriorityClassConfig{
+		Label: "flyte.org/priority",
+		Mapping: map[string]string{
+			"high":    "flyte-high",
+			"missing": "flyte-missing",
+		},
+	}, kubeClient.SchedulingV1().PriorityClasses())
+
+	newPod := func(priority string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "flytesnacks-development",
+			},
+		}
+
+		if len(priority) > 0 {
+			p.Labels = map[string]string{"flyte.org/priority": priority}
+		}
+
+		return p
+	}
+
+	assert.Equal(t, "priority-class", mutator.ID())
+
+	t.Run("mapped", func(t *testing.T) {
+		p, changed, err := mutator.Mutate(ctx, newPod("high"))
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "flyte-high", p.Spec.PriorityClassName)
+		assert.Equal(t, int32(1000), *p.Spec.Priority)
+		assert.Equal(t, corev1.PreemptNever, *p.Spec.PreemptionPolicy)
+
+		// Mutating again does not change the pod.
+		_, changed, err = mutator.Mutate(ctx, p)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("not labeled", func(t *testing.T) {
+		p, changed, err := mutator.Mutate(ctx, newPod(""))
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, p.Spec.PriorityClassName)
+	})
+
+	t.Run("not mapped", func(t *testing.T) {
+		p, changed, err := mutator.Mutate(ctx, newPod("low"))
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, p.Spec.PriorityClassName)
+	})
+
+	t.Run("explicit class", func(t *testing.T) {
+		p := newPod("high")
+		p.Spec.PriorityClassName = "system-node-critical"
+		p, changed, err := mutator.Mutate(ctx, p)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "system-node-critical", p.Spec.PriorityClassName)
+	})
+
+	t.Run("missing class", func(t *testing.T) {
+		p, changed, err := mutator.Mutate(ctx, newPod("missing"))
+		assert.Error(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, p.Spec.PriorityClassName)
+	})
+}
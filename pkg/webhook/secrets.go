@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"context"
+	"strings"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
@@ -32,6 +33,35 @@ type SecretsInjector interface {
 	Inject(ctx context.Context, secrets *core.Secret, p *corev1.Pod) (newP *corev1.Pod, injected bool, err error)
 }
 
+// Returns whether the secret should be injected from Vault although Vault is not the configured secret manager.
+func (s *SecretsMutator) injectFromVault(secret *core.Secret) bool {
+	if s.cfg.SecretManagerType == config.SecretManagerTypeVault || secret.MountRequirement == core.Secret_ENV_VAR {
+		return false
+	}
+
+	for _, prefix := range s.cfg.VaultSecretManagerConfig.GroupPrefixes {
+		if strings.HasPrefix(secret.Group, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns whether the injector is used to inject the secret. The global injector is always used, besides that only the
+// injector of the configured secret manager, or the Vault injector for secrets selected to be injected from Vault.
+func (s *SecretsMutator) isEnabled(injector SecretsInjector, secret *core.Secret) bool {
+	if injector.Type() == config.SecretManagerTypeGlobal {
+		return true
+	}
+
+	if s.injectFromVault(secret) {
+		return injector.Type() == config.SecretManagerTypeVault
+	}
+
+	return injector.Type() == s.cfg.SecretManagerType
+}
+
 func (s SecretsMutator) ID() string {
 	return "secrets"
 }
@@ -44,7 +74,7 @@ func (s *SecretsMutator) Mutate(ctx context.Context, p *corev1.Pod) (newP *corev
 
 	for _, secret := range secrets {
 		for _, injector := range s.injectors {
-			if injector.Type() != config.SecretManagerTypeGlobal && injector.Type() != s.cfg.SecretManagerType {
+			if !s.isEnabled(injector, secret) {
 				logger.Infof(ctx, "Skipping SecretManager [%v] since it's not enabled.", injector.Type())
 				continue
 			}
@@ -74,6 +104,7 @@ func NewSecretsMutator(cfg *config.Config, _ promutils.Scope) *SecretsMutator {
 			NewGlobalSecrets(secretmanager.NewFileEnvSecretManager(secretmanager.GetConfig())),
 			NewK8sSecretsInjector(),
 			NewAWSSecretManagerInjector(cfg.AWSSecretManagerConfig),
+			NewGCPSecretManagerInjector(cfg.GCPSecretManagerConfig),
 			NewVaultSecretManagerInjector(cfg.VaultSecretManagerConfig),
 		},
 	}
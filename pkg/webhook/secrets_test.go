@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -60,4 +62,25 @@ func TestSecretsWebhook_Mutate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, changed)
 	})
+	t.Run("injected from vault by group prefix", func(t *testing.T) {
+		k8sInjector := &mocks.SecretsInjector{}
+		k8sInjector.OnType().Return(config.SecretManagerTypeK8s)
+		vaultInjector := &mocks.SecretsInjector{}
+		vaultInjector.OnType().Return(config.SecretManagerTypeVault)
+
+		m := SecretsMutator{
+			cfg: &config.Config{
+				SecretManagerType:        config.SecretManagerTypeK8s,
+				VaultSecretManagerConfig: config.VaultSecretManagerConfig{GroupPrefixes: []string{"vault/"}},
+			},
+		}
+
+		assert.True(t, m.isEnabled(vaultInjector, &core.Secret{Group: "vault/group"}))
+		assert.False(t, m.isEnabled(k8sInjector, &core.Secret{Group: "vault/group"}))
+		assert.True(t, m.isEnabled(k8sInjector, &core.Secret{Group: "group"}))
+		assert.False(t, m.isEnabled(vaultInjector, &core.Secret{Group: "group"}))
+		// Vault does not support mounting secrets as env vars.
+		assert.True(t, m.isEnabled(k8sInjector, &core.Secret{Group: "vault/group", MountRequirement: core.Secret_ENV_VAR}))
+		assert.False(t, m.isEnabled(vaultInjector, &core.Secret{Group: "vault/group", MountRequirement: core.Secret_ENV_VAR}))
+	})
 }
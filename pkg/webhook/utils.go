@@ -119,10 +119,25 @@ func AppendVolume(volumes []corev1.Volume, volume corev1.Volume) []corev1.Volume
 	return append(volumes, volume)
 }
 
-func CreateVaultAnnotationsForSecret(secret *core.Secret, kvversion config.KVVersion) (map[string]string, error) {
+// Returns the Vault path of the secret. Groups are full Vault paths, unless the mount path of the KV Engine is
+// configured. Version 2 of the KV Engine serves secrets under the data/ prefix of its mount path.
+func vaultSecretPath(secret *core.Secret, cfg config.VaultSecretManagerConfig) string {
+	mountPath := strings.Trim(cfg.KVMountPath, "/")
+	if len(mountPath) == 0 {
+		return secret.Group
+	}
+
+	if cfg.KVVersion == config.KVVersion2 {
+		return fmt.Sprintf("%s/data/%s", mountPath, secret.Group)
+	}
+
+	return fmt.Sprintf("%s/%s", mountPath, secret.Group)
+}
+
+func CreateVaultAnnotationsForSecret(secret *core.Secret, cfg config.VaultSecretManagerConfig) (map[string]string, error) {
 	// Creates three grouped annotations "agent-inject-secret", "agent-inject-file" and "agent-inject-template"
 	// for a given secret request and KV engine version. The annotations respectively handle: 1. retrieving the
-	// secret from a vault path specified in secret.Group, 2. storing it in a file named after secret.Group/secret.Key
+	// secret from the vault path of secret.Group, 2. storing it in a file named after secret.Group/secret.Key
 	// and 3. creating a template that retrieves only secret.Key from the multiple k:v pairs present in a vault secret.
 	id := string(uuid.NewUUID())
 
@@ -130,17 +145,18 @@ func CreateVaultAnnotationsForSecret(secret *core.Secret, kvversion config.KVVer
 	// Version 1 stores plain k:v pairs under .Data, version 2 supports versioned secrets
 	// and wraps the k:v pairs into an additional subfield.
 	var query string
-	if kvversion == config.KVVersion1 {
+	if cfg.KVVersion == config.KVVersion1 {
 		query = ".Data"
-	} else if kvversion == config.KVVersion2 {
+	} else if cfg.KVVersion == config.KVVersion2 {
 		query = ".Data.data"
 	} else {
-		err := fmt.Errorf("unsupported KV Version %v, supported versions are 1 and 2", kvversion)
+		err := fmt.Errorf("unsupported KV Version %v, supported versions are 1 and 2", cfg.KVVersion)
 		return nil, err
 	}
-	template := fmt.Sprintf(`{{- with secret "%s" -}}{{ %s.%s }}{{- end -}}`, secret.Group, query, secret.Key)
+	path := vaultSecretPath(secret, cfg)
+	template := fmt.Sprintf(`{{- with secret "%s" -}}{{ %s.%s }}{{- end -}}`, path, query, secret.Key)
 	secretVaultAnnotations := map[string]string{
-		fmt.Sprintf("vault.hashicorp.com/agent-inject-secret-%s", id):   secret.Group,
+		fmt.Sprintf("vault.hashicorp.com/agent-inject-secret-%s", id):   path,
 		fmt.Sprintf("vault.hashicorp.com/agent-inject-file-%s", id):     fmt.Sprintf("%s/%s", secret.Group, secret.Key),
 		fmt.Sprintf("vault.hashicorp.com/agent-inject-template-%s", id): template,
 	}
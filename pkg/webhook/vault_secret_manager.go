@@ -68,7 +68,11 @@ func (i VaultSecretManagerInjector) Inject(ctx context.Context, secret *coreIdl.
 			"vault.hashicorp.com/agent-pre-populate-only": "true",
 		}
 
-		secretVaultAnnotations, err := CreateVaultAnnotationsForSecret(secret, i.cfg.KVVersion)
+		if len(i.cfg.AuthPath) > 0 {
+			commonVaultAnnotations["vault.hashicorp.com/auth-path"] = i.cfg.AuthPath
+		}
+
+		secretVaultAnnotations, err := CreateVaultAnnotationsForSecret(secret, i.cfg)
 		// Creating annotations can break with an unsupported KVVersion
 		if err != nil {
 			return p, false, err
@@ -8,6 +8,7 @@ import (
 	coreIdl "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/webhook/config"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -82,6 +83,26 @@ func ExpectedKVv2(uuid string) *corev1.Pod {
 	return expected
 }
 
+func ExpectedKVv2WithMountPath(uuid string) *corev1.Pod {
+	// Injects uuid into expected output for KV v2 secrets relative to the mount path of the KV Engine
+	expected := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"vault.hashicorp.com/agent-inject":                                "true",
+				"vault.hashicorp.com/secret-volume-path":                          "/etc/flyte/secrets",
+				"vault.hashicorp.com/role":                                        "flyte",
+				"vault.hashicorp.com/agent-pre-populate-only":                     "true",
+				"vault.hashicorp.com/auth-path":                                   "auth/kubernetes",
+				fmt.Sprintf("vault.hashicorp.com/agent-inject-secret-%s", uuid):   "secret/data/foo",
+				fmt.Sprintf("vault.hashicorp.com/agent-inject-file-%s", uuid):     "foo/bar",
+				fmt.Sprintf("vault.hashicorp.com/agent-inject-template-%s", uuid): `{{- with secret "secret/data/foo" -}}{{ .Data.data.bar }}{{- end -}}`,
+			},
+		},
+		Spec: PodSpec,
+	}
+	return expected
+}
+
 func NewInputPod() *corev1.Pod {
 	// Need to create a new Pod for every test since annotations are otherwise appended to original reference object
 	p := &corev1.Pod{
@@ -137,6 +158,17 @@ func TestVaultSecretManagerInjector_Inject(t *testing.T) {
 			want:    ExpectedKVv2,
 			wantErr: false,
 		},
+		{
+			name: "KVv2 Secret with mount and auth path",
+			args: args{
+				cfg: config.VaultSecretManagerConfig{Role: "flyte", KVVersion: config.KVVersion2, AuthPath: "auth/kubernetes",
+					KVMountPath: "/secret/"},
+				secret: inputSecret,
+				p:      NewInputPod(),
+			},
+			want:    ExpectedKVv2WithMountPath,
+			wantErr: false,
+		},
 		{
 			name: "Unsupported KV version",
 			args: args{
@@ -169,3 +201,12 @@ func TestVaultSecretManagerInjector_Inject(t *testing.T) {
 		})
 	}
 }
+
+func TestVaultSecretPath(t *testing.T) {
+	secret := &coreIdl.Secret{Group: "team/foo", Key: "bar"}
+	assert.Equal(t, "team/foo", vaultSecretPath(secret, config.VaultSecretManagerConfig{KVVersion: config.KVVersion2}))
+	assert.Equal(t, "secret/data/team/foo", vaultSecretPath(secret, config.VaultSecretManagerConfig{
+		KVVersion: config.KVVersion2, KVMountPath: "secret"}))
+	assert.Equal(t, "kv/team/foo", vaultSecretPath(secret, config.VaultSecretManagerConfig{
+		KVVersion: config.KVVersion1, KVMountPath: "kv/"}))
+}